@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/config"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/database"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/storage"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+		log.Fatal(err)
+	}
+}
+
+func run(args []string) error {
+	cfg, err := config.Load(args)
+	if err != nil {
+		return err
+	}
+
+	conn, err := database.NewPostgresConnection(cfg.DatabaseURL)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	dbClient := database.NewPostgreSQLClient(conn.GetDB())
+	if cfg.ApplySchema {
+		if err := database.ApplySchema(dbClient); err != nil {
+			return err
+		}
+		log.Println("📦 Database schema applied")
+	}
+
+	blobStore, err := storage.NewLocalBlobStore(cfg.AttachmentDir)
+	if err != nil {
+		return err
+	}
+
+	app := buildApplication(dbClient, blobStore, cfg)
+	if cfg.ExchangeRatesFile != "" {
+		if err := importExchangeRatesFile(app.importExchangeRates, cfg.ExchangeRatesFile); err != nil {
+			return err
+		}
+	}
+	server := &http.Server{
+		Addr:         cfg.Addr(),
+		Handler:      app.router.SetupRoutes(),
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Outbox Relay 在背景投遞已提交的事件並清除過期的已投遞訊息，收到停止訊號後結束
+	// 沒有註冊處理器時不啟動，事件維持pending直到有處理器的版本部署
+	if app.outboxRelay.HasHandlers() {
+		relayCtx, stopRelay := context.WithCancel(ctx)
+		relayDone := make(chan struct{})
+		go func() {
+			defer close(relayDone)
+			app.outboxRelay.Run(relayCtx)
+		}()
+		defer func() {
+			stopRelay()
+			<-relayDone
+		}()
+	} else {
+		log.Println("📭 Outbox relay not started: no handlers registered, events stay pending")
+	}
+
+	// 週期規則排程器在背景產生到期的交易，收到停止訊號後結束
+	schedulerCtx, stopScheduler := context.WithCancel(ctx)
+	schedulerDone := make(chan struct{})
+	go func() {
+		defer close(schedulerDone)
+		app.recurringScheduler.Run(schedulerCtx)
+	}()
+	defer func() {
+		stopScheduler()
+		<-schedulerDone
+	}()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("🏦 Accounting App starting on port %s", cfg.Port)
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	// 停止接受新連線，並在寬限期內等待處理中的請求完成
+	log.Printf("🛑 Shutting down, draining in-flight requests (timeout %s)", cfg.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	log.Println("👋 Server stopped")
+	return nil
+}
+
+// importExchangeRatesFile 啟動時匯入匯率CSV檔，同一組貨幣同一天的匯率會被覆蓋
+func importExchangeRatesFile(importer usecase.ImportExchangeRatesUseCase, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open exchange rates file: %w", err)
+	}
+	defer file.Close()
+
+	result := importer.Execute(usecase.ImportExchangeRatesInput{
+		CommandMetadata: usecase.CommandMetadata{ActorID: common.SystemActor},
+		Content:         file,
+	})
+	if result.GetExitCode() != common.Success {
+		return fmt.Errorf("failed to import exchange rates from %s: %s", path, result.GetMessage())
+	}
+	log.Printf("💱 %s from %s", result.GetMessage(), path)
+	return nil
+}
@@ -0,0 +1,397 @@
+package main
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	pgrepository "github.com/JingHsiu/accountingApp/internal/accounting/adapter/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/audit"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/duplicate"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/event"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/outbox"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/recurring"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/suggestion"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/auth"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/config"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/database"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/web"
+)
+
+// application 組裝完成的HTTP路由與背景工作
+type application struct {
+	router             *web.Router
+	outboxRelay        *outbox.Relay
+	recurringScheduler *recurring.Scheduler
+
+	// 啟動時匯入匯率檔使用 (不經過稽核，沒有操作者)
+	importExchangeRates usecase.ImportExchangeRatesUseCase
+}
+
+// buildApplication 組裝依賴圖：Store (Layer 4) → Peer (Layer 3) → Repository/Service (Layer 2) → Controller (Layer 3)
+func buildApplication(dbClient database.DatabaseClient, blobStore repository.BlobStore, cfg config.Config) *application {
+	// Layer 4: AggregateStores
+	walletStore := database.NewPgWalletStore(dbClient)
+	incomeStore := database.NewPgIncomeRecordStore(dbClient)
+	expenseStore := database.NewPgExpenseRecordStore(dbClient)
+	transferStore := database.NewPgTransferStore(dbClient)
+	expenseCategoryStore := database.NewPgExpenseCategoryStore(dbClient)
+	incomeCategoryStore := database.NewPgIncomeCategoryStore(dbClient)
+	apiKeyStore := database.NewPgAPIKeyStore(dbClient)
+	outboxStore := database.NewPgOutboxStore(dbClient)
+	budgetStore := database.NewPgBudgetStore(dbClient)
+	recurringRuleStore := database.NewPgRecurringRuleStore(dbClient)
+	attachmentStore := database.NewPgAttachmentStore(dbClient)
+	importProfileStore := database.NewPgImportProfileStore(dbClient)
+	duplicateFlagStore := database.NewPgDuplicateFlagStore(dbClient)
+	categorizationRuleStore := database.NewPgCategorizationRuleStore(dbClient)
+
+	// Layer 3: Repository Peers
+	walletPeer := pgrepository.NewPgWalletRepositoryPeerAdapter(walletStore, dbClient, incomeStore, expenseStore, transferStore)
+	expenseCategoryPeer := pgrepository.NewPgExpenseCategoryRepositoryPeerAdapter(expenseCategoryStore, dbClient)
+	incomeCategoryPeer := pgrepository.NewPgIncomeCategoryRepositoryPeerAdapter(incomeCategoryStore, dbClient)
+	apiKeyPeer := pgrepository.NewPgAPIKeyRepositoryPeerAdapter(apiKeyStore)
+	budgetPeer := pgrepository.NewPgBudgetRepositoryPeerAdapter(budgetStore)
+	recurringRulePeer := pgrepository.NewPgRecurringRuleRepositoryPeerAdapter(recurringRuleStore, dbClient)
+	attachmentPeer := pgrepository.NewPgAttachmentRepositoryPeerAdapter(attachmentStore)
+	importProfilePeer := pgrepository.NewPgImportProfileRepositoryPeerAdapter(importProfileStore)
+	duplicateFlagPeer := pgrepository.NewPgDuplicateFlagRepositoryPeerAdapter(duplicateFlagStore)
+	categorizationRulePeer := pgrepository.NewPgCategorizationRuleRepositoryPeerAdapter(categorizationRuleStore)
+	exchangeRatePeer := pgrepository.NewPgExchangeRateRepositoryPeerAdapter(dbClient)
+
+	// Layer 2: Domain Event Dispatcher (其他整合透過Subscribe訂閱，不需修改Command Service)
+	eventDispatcher := event.NewDispatcher()
+
+	// Layer 2: Repositories
+	walletRepo := repository.NewWalletRepositoryImpl(walletPeer, eventDispatcher)
+	expenseCategoryRepo := repository.NewExpenseCategoryRepositoryImpl(expenseCategoryPeer, eventDispatcher)
+	incomeCategoryRepo := repository.NewIncomeCategoryRepositoryImpl(incomeCategoryPeer, eventDispatcher)
+	apiKeyRepo := repository.NewAPIKeyRepositoryImpl(apiKeyPeer)
+	budgetRepo := repository.NewBudgetRepositoryImpl(budgetPeer)
+	recurringRuleRepo := repository.NewRecurringRuleRepositoryImpl(recurringRulePeer)
+	attachmentRepo := repository.NewAttachmentRepositoryImpl(attachmentPeer)
+	importProfileRepo := repository.NewImportProfileRepositoryImpl(importProfilePeer)
+	duplicateFlagRepo := repository.NewDuplicateFlagRepositoryImpl(duplicateFlagPeer)
+	categorizationRuleRepo := repository.NewCategorizationRuleRepositoryImpl(categorizationRulePeer)
+	exchangeRateRepo := repository.NewExchangeRateRepositoryImpl(exchangeRatePeer)
+	unitOfWork := pgrepository.NewPgUnitOfWork(dbClient, eventDispatcher)
+	outboxRepo := pgrepository.NewPgOutboxRepository(outboxStore, dbClient)
+	auditLogRepo := pgrepository.NewPgAuditLogRepository(dbClient)
+
+	// Layer 2: Outbox Relay (整合透過Register註冊at-least-once處理器)
+	relayConfig := outbox.DefaultRelayConfig()
+	relayConfig.PollInterval = cfg.OutboxPollInterval
+	relayConfig.Retention = cfg.OutboxRetention
+	outboxRelay := outbox.NewRelay(outboxRepo, relayConfig)
+	// 尚無外部整合：記錄每個事件，讓訊息標記為delivered並在保留期限後清除
+	outboxRelay.RegisterAll(outbox.LogHandler)
+
+	// Layer 2: Audit (每個Command以前後快照寫入稽核紀錄；錢包的紀錄由audit.NewWalletCommand在儲存錢包的交易中寫入)
+	auditRecorder := audit.NewRecorder(auditLogRepo)
+	expenseCategorySnapshots := audit.NewExpenseCategorySnapshots(expenseCategoryRepo)
+	incomeCategorySnapshots := audit.NewIncomeCategorySnapshots(incomeCategoryRepo)
+	userCategoriesSnapshots := audit.NewUserCategoriesSnapshots(expenseCategoryRepo, incomeCategoryRepo)
+	apiKeySnapshots := audit.WithoutSnapshot(mapper.AuditAggregateAPIKey)
+	outboxSnapshots := audit.WithoutSnapshot(mapper.AuditAggregateOutboxMessage)
+	budgetSnapshots := audit.NewBudgetSnapshots(budgetRepo)
+	recurringRuleSnapshots := audit.NewRecurringRuleSnapshots(recurringRuleRepo)
+	attachmentSnapshots := audit.NewAttachmentSnapshots(attachmentRepo)
+	importProfileSnapshots := audit.NewImportProfileSnapshots(importProfileRepo)
+	categorizationRuleSnapshots := audit.NewCategorizationRuleSnapshots(categorizationRuleRepo)
+	exchangeRateSnapshots := audit.WithoutSnapshot(mapper.AuditAggregateExchangeRates)
+
+	// Layer 2: Duplicate detection (新增與匯入的交易與同錢包的記錄比對，可能重複的加入審查佇列)
+	duplicateFlagger := duplicate.NewFlagger(walletRepo, duplicateFlagRepo, duplicate.NewDetector(duplicate.DefaultDetectorConfig()))
+
+	// Layer 2: Category suggestions (由使用者的記錄歷史訓練，透過記錄事件增量更新)
+	categorySuggester := suggestion.NewSuggester(walletRepo, suggestion.DefaultSuggesterConfig())
+	categorySuggester.Subscribe(eventDispatcher)
+
+	// Layer 2: Exchange rates (換算使用指定日期之前最近的匯率；設定檔已驗證捨入方式與匯率來源)
+	rounding, _ := model.ParseRoundingMode(cfg.ExchangeRounding)
+	currencyConverter := exchange.NewConverter(exchangeRateRepo, rounding)
+	var rateProvider exchange.RateProvider
+	if cfg.ExchangeRateProvider == "stub" {
+		rateProvider = exchange.NewStubProvider()
+	}
+
+	// Layer 2: Budget check (AddExpense以此回報跨過警告門檻的預算；其他幣別的支出換算為預算幣別)
+	checkBudgetWarningsService := query.NewCheckBudgetWarningsService(budgetRepo, walletRepo, expenseCategoryRepo, currencyConverter)
+
+	// Layer 2: Command Services (wrapped for auditing)
+	initializeDefaultCategoriesService := audit.NewCommand(command.NewInitializeDefaultCategoriesService(expenseCategoryRepo, incomeCategoryRepo), auditRecorder,
+		audit.Spec[usecase.InitializeDefaultCategoriesInput]{Command: "InitializeDefaultCategories", Aggregate: userCategoriesSnapshots,
+			Targets: func(in usecase.InitializeDefaultCategoriesInput) []string { return []string{in.UserID} }})
+	createWalletService := audit.NewWalletCommand("CreateWallet", walletRepo, unitOfWork,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.CreateWalletInput] {
+			return command.NewCreateWalletService(repos.Wallets, initializeDefaultCategoriesService)
+		})
+	updateWalletService := audit.NewWalletCommand("UpdateWallet", walletRepo, unitOfWork,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.UpdateWalletInput] {
+			return command.NewUpdateWalletService(repos.Wallets)
+		})
+	deleteWalletService := audit.NewWalletCommand("DeleteWallet", walletRepo, unitOfWork,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.DeleteWalletInput] {
+			return command.NewDeleteWalletService(repos.Wallets, attachmentRepo, blobStore)
+		})
+	// 週期規則產生的記錄不經過重複偵測 (每日規則的每一筆都會被標記為重複)
+	recordExpenseService := audit.NewWalletCommand("AddExpense", walletRepo, unitOfWork,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.AddExpenseInput] {
+			return command.NewAddExpenseService(repos.Wallets, expenseCategoryRepo, checkBudgetWarningsService, categorizationRuleRepo)
+		})
+	recordIncomeService := audit.NewWalletCommand("AddIncome", walletRepo, unitOfWork,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.AddIncomeInput] {
+			return command.NewAddIncomeService(repos.Wallets, incomeCategoryRepo)
+		})
+	addExpenseService := duplicate.NewAddExpenseCommand(recordExpenseService, duplicateFlagger)
+	addIncomeService := duplicate.NewAddIncomeCommand(recordIncomeService, duplicateFlagger)
+	updateExpenseService := audit.NewWalletCommand("UpdateExpense", walletRepo, unitOfWork,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.UpdateExpenseInput] {
+			return command.NewUpdateExpenseService(repos.Wallets)
+		})
+	deleteExpenseService := audit.NewWalletCommand("DeleteExpense", walletRepo, unitOfWork,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.DeleteExpenseInput] {
+			return command.NewDeleteExpenseService(repos.Wallets, attachmentRepo, blobStore)
+		})
+	updateIncomeService := audit.NewWalletCommand("UpdateIncome", walletRepo, unitOfWork,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.UpdateIncomeInput] {
+			return command.NewUpdateIncomeService(repos.Wallets)
+		})
+	deleteIncomeService := audit.NewWalletCommand("DeleteIncome", walletRepo, unitOfWork,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.DeleteIncomeInput] {
+			return command.NewDeleteIncomeService(repos.Wallets, attachmentRepo, blobStore)
+		})
+	processTransferService := audit.NewWalletCommand("ProcessTransfer", walletRepo, unitOfWork,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.ProcessTransferInput] {
+			return command.NewProcessTransferService(repos.UnitOfWork, currencyConverter)
+		})
+	editTransactionTagsService := audit.NewWalletCommand("EditTransactionTags", walletRepo, unitOfWork,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.EditTransactionTagsInput] {
+			return command.NewEditTransactionTagsService(repos.UnitOfWork)
+		})
+	uploadAttachmentService := audit.NewCommand(command.NewUploadAttachmentService(walletRepo, attachmentRepo, blobStore), auditRecorder,
+		audit.Spec[usecase.UploadAttachmentInput]{Command: "UploadAttachment", Aggregate: attachmentSnapshots})
+	deleteAttachmentService := audit.NewCommand(command.NewDeleteAttachmentService(attachmentRepo, blobStore), auditRecorder,
+		audit.Spec[usecase.DeleteAttachmentInput]{Command: "DeleteAttachment", Aggregate: attachmentSnapshots,
+			Targets: func(in usecase.DeleteAttachmentInput) []string { return []string{in.AttachmentID} }})
+	createImportProfileService := audit.NewCommand(command.NewCreateImportProfileService(importProfileRepo, expenseCategoryRepo, incomeCategoryRepo), auditRecorder,
+		audit.Spec[usecase.CreateImportProfileInput]{Command: "CreateImportProfile", Aggregate: importProfileSnapshots})
+	updateImportProfileService := audit.NewCommand(command.NewUpdateImportProfileService(importProfileRepo, expenseCategoryRepo, incomeCategoryRepo), auditRecorder,
+		audit.Spec[usecase.UpdateImportProfileInput]{Command: "UpdateImportProfile", Aggregate: importProfileSnapshots,
+			Targets: func(in usecase.UpdateImportProfileInput) []string { return []string{in.ProfileID} }})
+	deleteImportProfileService := audit.NewCommand(command.NewDeleteImportProfileService(importProfileRepo), auditRecorder,
+		audit.Spec[usecase.DeleteImportProfileInput]{Command: "DeleteImportProfile", Aggregate: importProfileSnapshots,
+			Targets: func(in usecase.DeleteImportProfileInput) []string { return []string{in.ProfileID} }})
+	commitImportService := duplicate.NewCommitImportCommand(audit.NewWalletCommand("CommitImport", walletRepo, unitOfWork,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.CommitImportInput] {
+			return command.NewCommitImportService(repos.Wallets, importProfileRepo, expenseCategoryRepo, incomeCategoryRepo, categorizationRuleRepo)
+		}), duplicateFlagger)
+	resolveDuplicateService := audit.NewWalletCommand("ResolveDuplicate", walletRepo, unitOfWork,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.ResolveDuplicateInput] {
+			return command.NewResolveDuplicateService(duplicateFlagRepo, repos.Wallets, attachmentRepo)
+		})
+	createCategorizationRuleService := audit.NewCommand(command.NewCreateCategorizationRuleService(categorizationRuleRepo, walletRepo, expenseCategoryRepo, incomeCategoryRepo), auditRecorder,
+		audit.Spec[usecase.CreateCategorizationRuleInput]{Command: "CreateCategorizationRule", Aggregate: categorizationRuleSnapshots})
+	updateCategorizationRuleService := audit.NewCommand(command.NewUpdateCategorizationRuleService(categorizationRuleRepo, walletRepo, expenseCategoryRepo, incomeCategoryRepo), auditRecorder,
+		audit.Spec[usecase.UpdateCategorizationRuleInput]{Command: "UpdateCategorizationRule", Aggregate: categorizationRuleSnapshots,
+			Targets: func(in usecase.UpdateCategorizationRuleInput) []string { return []string{in.RuleID} }})
+	deleteCategorizationRuleService := audit.NewCommand(command.NewDeleteCategorizationRuleService(categorizationRuleRepo), auditRecorder,
+		audit.Spec[usecase.DeleteCategorizationRuleInput]{Command: "DeleteCategorizationRule", Aggregate: categorizationRuleSnapshots,
+			Targets: func(in usecase.DeleteCategorizationRuleInput) []string { return []string{in.RuleID} }})
+	createExpenseCategoryService := audit.NewCommand(command.NewCreateExpenseCategoryService(expenseCategoryRepo), auditRecorder,
+		audit.Spec[usecase.CreateExpenseCategoryInput]{Command: "CreateExpenseCategory", Aggregate: expenseCategorySnapshots})
+	renameExpenseCategoryService := audit.NewCommand(command.NewRenameExpenseCategoryService(expenseCategoryRepo), auditRecorder,
+		audit.Spec[usecase.RenameExpenseCategoryInput]{Command: "RenameExpenseCategory", Aggregate: expenseCategorySnapshots,
+			Targets: func(in usecase.RenameExpenseCategoryInput) []string { return []string{in.CategoryID} }})
+	deleteExpenseCategoryService := audit.NewCommand(command.NewDeleteExpenseCategoryService(expenseCategoryRepo), auditRecorder,
+		audit.Spec[usecase.DeleteExpenseCategoryInput]{Command: "DeleteExpenseCategory", Aggregate: expenseCategorySnapshots,
+			Targets: func(in usecase.DeleteExpenseCategoryInput) []string { return []string{in.CategoryID} }})
+	addExpenseSubcategoryService := audit.NewCommand(command.NewAddExpenseSubcategoryService(expenseCategoryRepo), auditRecorder,
+		audit.Spec[usecase.AddExpenseSubcategoryInput]{Command: "AddExpenseSubcategory", Aggregate: expenseCategorySnapshots,
+			Targets: func(in usecase.AddExpenseSubcategoryInput) []string { return []string{in.CategoryID} }})
+	renameExpenseSubcategoryService := audit.NewCommand(command.NewRenameExpenseSubcategoryService(expenseCategoryRepo), auditRecorder,
+		audit.Spec[usecase.RenameExpenseSubcategoryInput]{Command: "RenameExpenseSubcategory", Aggregate: expenseCategorySnapshots,
+			Targets: func(in usecase.RenameExpenseSubcategoryInput) []string { return []string{in.CategoryID} }})
+	removeExpenseSubcategoryService := audit.NewCommand(command.NewRemoveExpenseSubcategoryService(expenseCategoryRepo), auditRecorder,
+		audit.Spec[usecase.RemoveExpenseSubcategoryInput]{Command: "RemoveExpenseSubcategory", Aggregate: expenseCategorySnapshots,
+			Targets: func(in usecase.RemoveExpenseSubcategoryInput) []string { return []string{in.CategoryID} }})
+	createIncomeCategoryService := audit.NewCommand(command.NewCreateIncomeCategoryService(incomeCategoryRepo), auditRecorder,
+		audit.Spec[usecase.CreateIncomeCategoryInput]{Command: "CreateIncomeCategory", Aggregate: incomeCategorySnapshots})
+	renameIncomeCategoryService := audit.NewCommand(command.NewRenameIncomeCategoryService(incomeCategoryRepo), auditRecorder,
+		audit.Spec[usecase.RenameIncomeCategoryInput]{Command: "RenameIncomeCategory", Aggregate: incomeCategorySnapshots,
+			Targets: func(in usecase.RenameIncomeCategoryInput) []string { return []string{in.CategoryID} }})
+	deleteIncomeCategoryService := audit.NewCommand(command.NewDeleteIncomeCategoryService(incomeCategoryRepo), auditRecorder,
+		audit.Spec[usecase.DeleteIncomeCategoryInput]{Command: "DeleteIncomeCategory", Aggregate: incomeCategorySnapshots,
+			Targets: func(in usecase.DeleteIncomeCategoryInput) []string { return []string{in.CategoryID} }})
+	addIncomeSubcategoryService := audit.NewCommand(command.NewAddIncomeSubcategoryService(incomeCategoryRepo), auditRecorder,
+		audit.Spec[usecase.AddIncomeSubcategoryInput]{Command: "AddIncomeSubcategory", Aggregate: incomeCategorySnapshots,
+			Targets: func(in usecase.AddIncomeSubcategoryInput) []string { return []string{in.CategoryID} }})
+	renameIncomeSubcategoryService := audit.NewCommand(command.NewRenameIncomeSubcategoryService(incomeCategoryRepo), auditRecorder,
+		audit.Spec[usecase.RenameIncomeSubcategoryInput]{Command: "RenameIncomeSubcategory", Aggregate: incomeCategorySnapshots,
+			Targets: func(in usecase.RenameIncomeSubcategoryInput) []string { return []string{in.CategoryID} }})
+	removeIncomeSubcategoryService := audit.NewCommand(command.NewRemoveIncomeSubcategoryService(incomeCategoryRepo), auditRecorder,
+		audit.Spec[usecase.RemoveIncomeSubcategoryInput]{Command: "RemoveIncomeSubcategory", Aggregate: incomeCategorySnapshots,
+			Targets: func(in usecase.RemoveIncomeSubcategoryInput) []string { return []string{in.CategoryID} }})
+	createAPIKeyService := audit.NewCommand(command.NewCreateAPIKeyService(apiKeyRepo), auditRecorder,
+		audit.Spec[usecase.CreateAPIKeyInput]{Command: "CreateAPIKey", Aggregate: apiKeySnapshots})
+	revokeAPIKeyService := audit.NewCommand(command.NewRevokeAPIKeyService(apiKeyRepo), auditRecorder,
+		audit.Spec[usecase.RevokeAPIKeyInput]{Command: "RevokeAPIKey", Aggregate: apiKeySnapshots,
+			Targets: func(in usecase.RevokeAPIKeyInput) []string { return []string{in.KeyID} }})
+	importExchangeRatesService := command.NewImportExchangeRatesService(exchangeRateRepo)
+	auditedImportExchangeRatesService := audit.NewCommand(importExchangeRatesService, auditRecorder,
+		audit.Spec[usecase.ImportExchangeRatesInput]{Command: "ImportExchangeRates", Aggregate: exchangeRateSnapshots})
+	refreshExchangeRatesService := audit.NewCommand(command.NewRefreshExchangeRatesService(exchangeRateRepo, rateProvider), auditRecorder,
+		audit.Spec[usecase.RefreshExchangeRatesInput]{Command: "RefreshExchangeRates", Aggregate: exchangeRateSnapshots})
+	retryOutboxMessageService := audit.NewCommand(command.NewRetryOutboxMessageService(outboxRepo), auditRecorder,
+		audit.Spec[usecase.RetryOutboxMessageInput]{Command: "RetryOutboxMessage", Aggregate: outboxSnapshots,
+			Targets: func(in usecase.RetryOutboxMessageInput) []string { return []string{in.MessageID} }})
+	createBudgetService := audit.NewCommand(command.NewCreateBudgetService(budgetRepo, expenseCategoryRepo), auditRecorder,
+		audit.Spec[usecase.CreateBudgetInput]{Command: "CreateBudget", Aggregate: budgetSnapshots})
+	updateBudgetService := audit.NewCommand(command.NewUpdateBudgetService(budgetRepo), auditRecorder,
+		audit.Spec[usecase.UpdateBudgetInput]{Command: "UpdateBudget", Aggregate: budgetSnapshots,
+			Targets: func(in usecase.UpdateBudgetInput) []string { return []string{in.BudgetID} }})
+	deleteBudgetService := audit.NewCommand(command.NewDeleteBudgetService(budgetRepo), auditRecorder,
+		audit.Spec[usecase.DeleteBudgetInput]{Command: "DeleteBudget", Aggregate: budgetSnapshots,
+			Targets: func(in usecase.DeleteBudgetInput) []string { return []string{in.BudgetID} }})
+	createRecurringRuleService := audit.NewCommand(command.NewCreateRecurringRuleService(recurringRuleRepo, walletRepo, expenseCategoryRepo, incomeCategoryRepo), auditRecorder,
+		audit.Spec[usecase.CreateRecurringRuleInput]{Command: "CreateRecurringRule", Aggregate: recurringRuleSnapshots})
+	pauseRecurringRuleService := audit.NewCommand(command.NewPauseRecurringRuleService(recurringRuleRepo), auditRecorder,
+		audit.Spec[usecase.PauseRecurringRuleInput]{Command: "PauseRecurringRule", Aggregate: recurringRuleSnapshots,
+			Targets: func(in usecase.PauseRecurringRuleInput) []string { return []string{in.RuleID} }})
+	resumeRecurringRuleService := audit.NewCommand(command.NewResumeRecurringRuleService(recurringRuleRepo), auditRecorder,
+		audit.Spec[usecase.ResumeRecurringRuleInput]{Command: "ResumeRecurringRule", Aggregate: recurringRuleSnapshots,
+			Targets: func(in usecase.ResumeRecurringRuleInput) []string { return []string{in.RuleID} }})
+	skipRecurringOccurrenceService := audit.NewCommand(command.NewSkipRecurringOccurrenceService(recurringRuleRepo), auditRecorder,
+		audit.Spec[usecase.SkipRecurringOccurrenceInput]{Command: "SkipRecurringOccurrence", Aggregate: recurringRuleSnapshots,
+			Targets: func(in usecase.SkipRecurringOccurrenceInput) []string { return []string{in.RuleID} }})
+	deleteRecurringRuleService := audit.NewCommand(command.NewDeleteRecurringRuleService(recurringRuleRepo), auditRecorder,
+		audit.Spec[usecase.DeleteRecurringRuleInput]{Command: "DeleteRecurringRule", Aggregate: recurringRuleSnapshots,
+			Targets: func(in usecase.DeleteRecurringRuleInput) []string { return []string{in.RuleID} }})
+
+	// Layer 2: Recurring Scheduler (透過已稽核的AddExpense/AddIncome產生交易)
+	schedulerConfig := recurring.DefaultSchedulerConfig()
+	schedulerConfig.PollInterval = cfg.RecurringPollInterval
+	recurringScheduler := recurring.NewScheduler(recurringRuleRepo, recordExpenseService, recordIncomeService, schedulerConfig)
+
+	// Layer 2: Query Services
+	getWalletsService := query.NewGetWalletsService(walletRepo, currencyConverter)
+	getWalletService := query.NewGetWalletService(walletRepo)
+	getWalletBalanceService := query.NewGetWalletBalanceService(walletRepo)
+	getExpensesService := query.NewGetExpensesService(walletRepo)
+	getIncomesService := query.NewGetIncomesService(walletRepo)
+	getTransfersService := query.NewGetTransfersService(walletRepo)
+	getExpenseCategoriesService := query.NewGetExpenseCategoriesService(expenseCategoryRepo)
+	getIncomeCategoriesService := query.NewGetIncomeCategoriesService(incomeCategoryRepo)
+	getAPIKeysService := query.NewGetAPIKeysService(apiKeyRepo)
+	authenticateAPIKeyService := query.NewAuthenticateAPIKeyService(apiKeyRepo)
+	getOutboxMessagesService := query.NewGetOutboxMessagesService(outboxRepo)
+	getAuditLogService := query.NewGetAuditLogService(auditLogRepo)
+	verifyAuditLogService := query.NewVerifyAuditLogService(auditLogRepo)
+	getBudgetsService := query.NewGetBudgetsService(budgetRepo)
+	getBudgetStatusService := query.NewGetBudgetStatusService(budgetRepo, walletRepo, expenseCategoryRepo, currencyConverter)
+	getRecurringRulesService := query.NewGetRecurringRulesService(recurringRuleRepo)
+	previewRecurringRuleService := query.NewPreviewRecurringRuleService(recurringRuleRepo)
+	getTagSummaryService := query.NewGetTagSummaryService(walletRepo, currencyConverter)
+	getAttachmentsService := query.NewGetAttachmentsService(attachmentRepo)
+	getAttachmentContentService := query.NewGetAttachmentContentService(attachmentRepo, blobStore)
+	getImportProfilesService := query.NewGetImportProfilesService(importProfileRepo)
+	previewImportService := query.NewPreviewImportService(walletRepo, importProfileRepo, expenseCategoryRepo, incomeCategoryRepo, categorizationRuleRepo)
+	getDuplicatesService := query.NewGetDuplicatesService(duplicateFlagRepo, walletRepo)
+	getCategorizationRulesService := query.NewGetCategorizationRulesService(categorizationRuleRepo)
+	testCategorizationRuleService := query.NewTestCategorizationRuleService(categorizationRuleRepo)
+	getCategorySuggestionsService := query.NewGetCategorySuggestionsService(categorySuggester, expenseCategoryRepo, incomeCategoryRepo)
+	getExchangeRatesService := query.NewGetExchangeRatesService(exchangeRateRepo)
+	convertMoneyService := query.NewConvertMoneyService(currencyConverter)
+	getCurrenciesService := query.NewGetCurrenciesService()
+
+	// Layer 4: Authentication
+	authMiddleware := web.NewAuthMiddleware(auth.NewJWTVerifier(cfg.JWTSigningKey), authenticateAPIKeyService)
+
+	// Layer 3: Controllers
+	router := web.NewRouter(web.Controllers{
+		CreateWalletController:     controller.NewCreateWalletController(createWalletService),
+		QueryWalletController:      controller.NewQueryWalletController(getWalletsService, getWalletService),
+		UpdateWalletController:     controller.NewUpdateWalletController(updateWalletService),
+		DeleteWalletController:     controller.NewDeleteWalletController(deleteWalletService),
+		GetWalletBalanceController: controller.NewGetWalletBalanceController(getWalletBalanceService),
+		AddExpenseController:       controller.NewAddExpenseController(addExpenseService),
+		AddIncomeController:        controller.NewAddIncomeController(addIncomeService),
+		QueryIncomeController:      controller.NewQueryIncomeController(getIncomesService),
+		QueryExpenseController:     controller.NewQueryExpenseController(getExpensesService),
+		UpdateExpenseController:    controller.NewUpdateExpenseController(updateExpenseService),
+		DeleteExpenseController:    controller.NewDeleteExpenseController(deleteExpenseService),
+		UpdateIncomeController:     controller.NewUpdateIncomeController(updateIncomeService),
+		DeleteIncomeController:     controller.NewDeleteIncomeController(deleteIncomeService),
+		ProcessTransferController:  controller.NewProcessTransferController(processTransferService),
+		QueryTransferController:    controller.NewQueryTransferController(getTransfersService),
+		CategoryController: controller.NewCategoryController(
+			createExpenseCategoryService,
+			createIncomeCategoryService,
+			getExpenseCategoriesService,
+			getIncomeCategoriesService,
+			renameExpenseCategoryService,
+			deleteExpenseCategoryService,
+			addExpenseSubcategoryService,
+			renameExpenseSubcategoryService,
+			removeExpenseSubcategoryService,
+			renameIncomeCategoryService,
+			deleteIncomeCategoryService,
+			addIncomeSubcategoryService,
+			renameIncomeSubcategoryService,
+			removeIncomeSubcategoryService,
+		),
+		GetCategoriesController:               controller.NewGetCategoriesController(),
+		InitializeDefaultCategoriesController: controller.NewInitializeDefaultCategoriesController(initializeDefaultCategoriesService),
+		APIKeyController:                      controller.NewAPIKeyController(createAPIKeyService, revokeAPIKeyService, getAPIKeysService),
+		OutboxAdminController:                 controller.NewOutboxAdminController(getOutboxMessagesService, retryOutboxMessageService, cfg.AdminUserIDs),
+		AuditController:                       controller.NewAuditController(getAuditLogService, verifyAuditLogService, cfg.AdminUserIDs),
+		BudgetController:                      controller.NewBudgetController(createBudgetService, updateBudgetService, deleteBudgetService, getBudgetsService, getBudgetStatusService),
+		RecurringRuleController: controller.NewRecurringRuleController(
+			createRecurringRuleService,
+			pauseRecurringRuleService,
+			resumeRecurringRuleService,
+			skipRecurringOccurrenceService,
+			deleteRecurringRuleService,
+			getRecurringRulesService,
+			previewRecurringRuleService,
+		),
+		TagController:        controller.NewTagController(editTransactionTagsService, getTagSummaryService),
+		AttachmentController: controller.NewAttachmentController(uploadAttachmentService, deleteAttachmentService, getAttachmentsService, getAttachmentContentService),
+		ImportController: controller.NewImportController(
+			createImportProfileService,
+			updateImportProfileService,
+			deleteImportProfileService,
+			getImportProfilesService,
+			previewImportService,
+			commitImportService,
+		),
+		DuplicateController: controller.NewDuplicateController(getDuplicatesService, resolveDuplicateService),
+		CategorizationRuleController: controller.NewCategorizationRuleController(
+			createCategorizationRuleService,
+			updateCategorizationRuleService,
+			deleteCategorizationRuleService,
+			getCategorizationRulesService,
+			testCategorizationRuleService,
+		),
+		SuggestionController: controller.NewSuggestionController(getCategorySuggestionsService),
+		ExchangeRateController: controller.NewExchangeRateController(
+			getExchangeRatesService,
+			convertMoneyService,
+			auditedImportExchangeRatesService,
+			refreshExchangeRatesService,
+			cfg.AdminUserIDs,
+		),
+		CurrencyController: controller.NewCurrencyController(getCurrenciesService),
+	}, authMiddleware)
+
+	return &application{
+		router:              router,
+		outboxRelay:         outboxRelay,
+		recurringScheduler:  recurringScheduler,
+		importExchangeRates: importExchangeRatesService,
+	}
+}
@@ -21,6 +21,10 @@ func NewAddExpenseController(addExpenseUseCase usecase.AddExpenseUseCase) *AddEx
 }
 
 // AddExpense handles POST /api/v1/expenses
+// A receipt covering several subcategories is sent as "splits" instead of
+// "subcategory_id"; the split amounts must sum to "amount". Without either, the
+// caller's first matching categorization rule picks the subcategory and adds
+// its tags; the request fails when no rule matches.
 func (c *AddExpenseController) AddExpense(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -28,12 +32,14 @@ func (c *AddExpenseController) AddExpense(w http.ResponseWriter, r *http.Request
 	}
 
 	var req struct {
-		WalletID      string    `json:"wallet_id"`
-		SubcategoryID string    `json:"subcategory_id"`
-		Amount        int64     `json:"amount"`
-		Currency      string    `json:"currency"`
-		Description   string    `json:"description"`
-		Date          time.Time `json:"date"`
+		WalletID      string                `json:"wallet_id"`
+		SubcategoryID string                `json:"subcategory_id"`
+		Amount        int64                 `json:"amount"`
+		Currency      string                `json:"currency"`
+		Description   string                `json:"description"`
+		Date          time.Time             `json:"date"`
+		Splits        []expenseSplitRequest `json:"splits"`
+		Tags          []string              `json:"tags"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -41,15 +47,16 @@ func (c *AddExpenseController) AddExpense(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
 	// Validate required fields
 	if req.WalletID == "" {
 		c.sendError(w, "wallet_id is required", http.StatusBadRequest)
 		return
 	}
-	if req.SubcategoryID == "" {
-		c.sendError(w, "subcategory_id is required", http.StatusBadRequest)
-		return
-	}
 	if req.Amount <= 0 {
 		c.sendError(w, "amount must be positive", http.StatusBadRequest)
 		return
@@ -60,26 +67,62 @@ func (c *AddExpenseController) AddExpense(w http.ResponseWriter, r *http.Request
 	}
 
 	input := usecase.AddExpenseInput{
-		WalletID:      req.WalletID,
-		SubcategoryID: req.SubcategoryID,
-		Amount:        req.Amount,
-		Currency:      req.Currency,
-		Description:   req.Description,
-		Date:          req.Date,
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		WalletID:        req.WalletID,
+		SubcategoryID:   req.SubcategoryID,
+		Amount:          req.Amount,
+		Currency:        req.Currency,
+		Description:     req.Description,
+		Date:            req.Date,
+		Splits:          toExpenseSplitInputs(req.Splits),
+		Tags:            req.Tags,
 	}
 
 	output := c.addExpenseUseCase.Execute(input)
 
 	w.Header().Set("Content-Type", "application/json")
 	if output.GetExitCode() != 0 {
-		w.WriteHeader(http.StatusBadRequest)
+		w.WriteHeader(commandOutputStatus(output))
 	}
 
-	json.NewEncoder(w).Encode(map[string]interface{}{
+	response := map[string]interface{}{
 		"id":      output.GetID(),
 		"success": output.GetExitCode() == 0,
 		"message": output.GetMessage(),
-	})
+	}
+	// Budgets this expense pushed past their warning threshold or limit
+	if result, ok := output.(usecase.AddExpenseOutput); ok && len(result.Warnings) > 0 {
+		response["warnings"] = result.Warnings
+	}
+	// Records this expense looks like a duplicate of (queued for review)
+	if result, ok := output.(usecase.AddExpenseOutput); ok && len(result.Duplicates) > 0 {
+		response["duplicates"] = result.Duplicates
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
+// expenseSplitRequest is one line of a split expense in a create or update request
+type expenseSplitRequest struct {
+	SubcategoryID string `json:"subcategory_id"`
+	Amount        int64  `json:"amount"`
+	Memo          string `json:"memo"`
+}
+
+func toExpenseSplitInputs(splits []expenseSplitRequest) []usecase.ExpenseSplitInput {
+	if len(splits) == 0 {
+		return nil
+	}
+	inputs := make([]usecase.ExpenseSplitInput, len(splits))
+	for i, split := range splits {
+		inputs[i] = usecase.ExpenseSplitInput{
+			SubcategoryID: split.SubcategoryID,
+			Amount:        split.Amount,
+			Memo:          split.Memo,
+		}
+	}
+	return inputs
 }
 
 // Helper methods
@@ -90,4 +133,4 @@ func (c *AddExpenseController) sendError(w http.ResponseWriter, message string,
 		"success": false,
 		"error":   message,
 	})
-}
\ No newline at end of file
+}
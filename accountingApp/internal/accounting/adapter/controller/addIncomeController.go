@@ -34,6 +34,7 @@ func (c *AddIncomeController) AddIncome(w http.ResponseWriter, r *http.Request)
 		Currency      string    `json:"currency"`
 		Description   string    `json:"description"`
 		Date          time.Time `json:"date"`
+		Tags          []string  `json:"tags"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -41,6 +42,11 @@ func (c *AddIncomeController) AddIncome(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
 	// Validate required fields
 	if req.WalletID == "" {
 		c.sendError(w, "wallet_id is required", http.StatusBadRequest)
@@ -60,26 +66,35 @@ func (c *AddIncomeController) AddIncome(w http.ResponseWriter, r *http.Request)
 	}
 
 	input := usecase.AddIncomeInput{
-		WalletID:      req.WalletID,
-		SubcategoryID: req.SubcategoryID,
-		Amount:        req.Amount,
-		Currency:      req.Currency,
-		Description:   req.Description,
-		Date:          req.Date,
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		WalletID:        req.WalletID,
+		SubcategoryID:   req.SubcategoryID,
+		Amount:          req.Amount,
+		Currency:        req.Currency,
+		Description:     req.Description,
+		Date:            req.Date,
+		Tags:            req.Tags,
 	}
 
 	output := c.addIncomeUseCase.Execute(input)
 
 	w.Header().Set("Content-Type", "application/json")
 	if output.GetExitCode() != 0 {
-		w.WriteHeader(http.StatusBadRequest)
+		w.WriteHeader(commandOutputStatus(output))
 	}
 
-	json.NewEncoder(w).Encode(map[string]interface{}{
+	response := map[string]interface{}{
 		"id":      output.GetID(),
 		"success": output.GetExitCode() == 0,
 		"message": output.GetMessage(),
-	})
+	}
+	// Records this income looks like a duplicate of (queued for review)
+	if result, ok := output.(usecase.AddIncomeOutput); ok && len(result.Duplicates) > 0 {
+		response["duplicates"] = result.Duplicates
+	}
+
+	json.NewEncoder(w).Encode(response)
 }
 
 // Helper methods
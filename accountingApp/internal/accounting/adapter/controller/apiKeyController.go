@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// APIKeyController handles management of the caller's personal API keys
+type APIKeyController struct {
+	createAPIKeyUseCase usecase.CreateAPIKeyUseCase
+	revokeAPIKeyUseCase usecase.RevokeAPIKeyUseCase
+	getAPIKeysUseCase   usecase.GetAPIKeysUseCase
+}
+
+// NewAPIKeyController creates a new APIKeyController
+func NewAPIKeyController(
+	createAPIKeyUseCase usecase.CreateAPIKeyUseCase,
+	revokeAPIKeyUseCase usecase.RevokeAPIKeyUseCase,
+	getAPIKeysUseCase usecase.GetAPIKeysUseCase,
+) *APIKeyController {
+	return &APIKeyController{
+		createAPIKeyUseCase: createAPIKeyUseCase,
+		revokeAPIKeyUseCase: revokeAPIKeyUseCase,
+		getAPIKeysUseCase:   getAPIKeysUseCase,
+	}
+}
+
+// GetAPIKeys handles GET /api/v1/api-keys
+func (c *APIKeyController) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	result := c.getAPIKeysUseCase.Execute(usecase.GetAPIKeysInput{
+		UserID: userID,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), http.StatusInternalServerError)
+		return
+	}
+
+	output, ok := result.(usecase.GetAPIKeysOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, output.Keys)
+}
+
+// CreateAPIKey handles POST /api/v1/api-keys
+// The plaintext key is returned only in this response.
+func (c *APIKeyController) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Name string `json:"name"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if req.Name == "" {
+		c.sendError(w, "name is required", http.StatusBadRequest)
+		return
+	}
+
+	result := c.createAPIKeyUseCase.Execute(usecase.CreateAPIKeyInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		Name:            req.Name,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.CreateAPIKeyOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusCreated, map[string]interface{}{
+		"id":      output.ID,
+		"name":    req.Name,
+		"key":     output.Key,
+		"message": output.Message,
+	})
+}
+
+// RevokeAPIKey handles DELETE /api/v1/api-keys/{keyID}
+func (c *APIKeyController) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	keyID := c.extractKeyID(r.URL.Path)
+	if keyID == "" {
+		c.sendError(w, "Invalid API key ID", http.StatusBadRequest)
+		return
+	}
+
+	result := c.revokeAPIKeyUseCase.Execute(usecase.RevokeAPIKeyInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		KeyID:           keyID,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// Helper methods
+func (c *APIKeyController) extractKeyID(path string) string {
+	// Extract key ID from paths like /api/v1/api-keys/{keyID}
+	parts := strings.Split(strings.TrimPrefix(path, "/api/v1/api-keys/"), "/")
+	if len(parts) > 0 && parts[0] != "" {
+		decoded, err := url.PathUnescape(parts[0])
+		if err != nil {
+			return parts[0]
+		}
+		return decoded
+	}
+	return ""
+}
+
+func (c *APIKeyController) sendSuccess(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *APIKeyController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
@@ -0,0 +1,243 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// multipartOverhead allows for the form fields and part headers around the file
+const multipartOverhead = 1 << 20
+
+// AttachmentController handles receipts and other files attached to expense and income records
+type AttachmentController struct {
+	uploadAttachmentUseCase     usecase.UploadAttachmentUseCase
+	deleteAttachmentUseCase     usecase.DeleteAttachmentUseCase
+	getAttachmentsUseCase       usecase.GetAttachmentsUseCase
+	getAttachmentContentUseCase usecase.GetAttachmentContentUseCase
+}
+
+// NewAttachmentController creates a new AttachmentController
+func NewAttachmentController(
+	uploadAttachmentUseCase usecase.UploadAttachmentUseCase,
+	deleteAttachmentUseCase usecase.DeleteAttachmentUseCase,
+	getAttachmentsUseCase usecase.GetAttachmentsUseCase,
+	getAttachmentContentUseCase usecase.GetAttachmentContentUseCase,
+) *AttachmentController {
+	return &AttachmentController{
+		uploadAttachmentUseCase:     uploadAttachmentUseCase,
+		deleteAttachmentUseCase:     deleteAttachmentUseCase,
+		getAttachmentsUseCase:       getAttachmentsUseCase,
+		getAttachmentContentUseCase: getAttachmentContentUseCase,
+	}
+}
+
+// UploadAttachment handles POST /api/v1/attachments
+// Expects multipart/form-data with record_type (EXPENSE or INCOME), record_id and a file part.
+func (c *AttachmentController) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, model.MaxAttachmentSize+multipartOverhead)
+	if err := r.ParseMultipartForm(multipartOverhead); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.sendError(w, "Attachment too large", http.StatusRequestEntityTooLarge)
+		} else {
+			c.sendError(w, "Invalid multipart form", http.StatusBadRequest)
+		}
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		c.sendError(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	result := c.uploadAttachmentUseCase.Execute(usecase.UploadAttachmentInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		RecordType:      strings.ToUpper(r.FormValue("record_type")),
+		RecordID:        r.FormValue("record_id"),
+		FileName:        header.Filename,
+		ContentType:     header.Header.Get("Content-Type"),
+		Content:         file,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), commandOutputStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.UploadAttachmentOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusCreated, output.Attachment)
+}
+
+// GetAttachments handles GET /api/v1/attachments?recordType=EXPENSE&recordId={id}
+func (c *AttachmentController) GetAttachments(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	result := c.getAttachmentsUseCase.Execute(usecase.GetAttachmentsInput{
+		UserID:     userID,
+		RecordType: strings.ToUpper(query.Get("recordType")),
+		RecordID:   query.Get("recordId"),
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.GetAttachmentsOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, output.Attachments)
+}
+
+// DownloadAttachment handles GET /api/v1/attachments/{attachmentID}
+// Responds with the stored file rather than a JSON envelope.
+func (c *AttachmentController) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	attachmentID := c.extractAttachmentID(r.URL.Path)
+	if attachmentID == "" {
+		c.sendError(w, "Invalid attachment ID", http.StatusBadRequest)
+		return
+	}
+
+	result := c.getAttachmentContentUseCase.Execute(usecase.GetAttachmentContentInput{
+		UserID:       userID,
+		AttachmentID: attachmentID,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.GetAttachmentContentOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+	defer output.Content.Close()
+
+	w.Header().Set("Content-Type", output.Attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(output.Attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": output.Attachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("X-Checksum-Sha256", output.Attachment.Checksum)
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, output.Content)
+}
+
+// DeleteAttachment handles DELETE /api/v1/attachments/{attachmentID}
+func (c *AttachmentController) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	attachmentID := c.extractAttachmentID(r.URL.Path)
+	if attachmentID == "" {
+		c.sendError(w, "Invalid attachment ID", http.StatusBadRequest)
+		return
+	}
+
+	result := c.deleteAttachmentUseCase.Execute(usecase.DeleteAttachmentInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		AttachmentID:    attachmentID,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// Helper methods
+func (c *AttachmentController) extractAttachmentID(path string) string {
+	// Extract attachment ID from paths like /api/v1/attachments/{attachmentID}
+	parts := strings.Split(strings.TrimPrefix(path, "/api/v1/attachments/"), "/")
+	if len(parts) > 0 && parts[0] != "" {
+		decoded, err := url.PathUnescape(parts[0])
+		if err != nil {
+			return parts[0]
+		}
+		return decoded
+	}
+	return ""
+}
+
+func (c *AttachmentController) sendSuccess(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *AttachmentController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
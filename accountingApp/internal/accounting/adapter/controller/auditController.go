@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// AuditController exposes the append-only audit log. Users see the entries for
+// commands they issued; administrators may query everyone's and verify the chain.
+type AuditController struct {
+	getAuditLogUseCase    usecase.GetAuditLogUseCase
+	verifyAuditLogUseCase usecase.VerifyAuditLogUseCase
+	adminUserIDs          map[string]bool
+}
+
+// NewAuditController creates a new AuditController
+func NewAuditController(
+	getAuditLogUseCase usecase.GetAuditLogUseCase,
+	verifyAuditLogUseCase usecase.VerifyAuditLogUseCase,
+	adminUserIDs []string,
+) *AuditController {
+	admins := make(map[string]bool, len(adminUserIDs))
+	for _, id := range adminUserIDs {
+		admins[id] = true
+	}
+	return &AuditController{
+		getAuditLogUseCase:    getAuditLogUseCase,
+		verifyAuditLogUseCase: verifyAuditLogUseCase,
+		adminUserIDs:          admins,
+	}
+}
+
+// GetAuditLog handles GET /api/v1/audit
+// Filters: actor_id, command, aggregate_type, aggregate_id, request_id,
+// from/to (RFC3339 or YYYY-MM-DD; to is exclusive, a plain date includes that day) and limit.
+func (c *AuditController) GetAuditLog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	params := r.URL.Query()
+	actorID := params.Get("actor_id")
+	if !c.adminUserIDs[userID] {
+		// Non-administrators only see the commands they issued themselves
+		if actorID != "" && actorID != userID {
+			c.sendError(w, "Access to another user's audit entries is forbidden", http.StatusForbidden)
+			return
+		}
+		actorID = userID
+	}
+
+	from, err := parseAuditTime(params.Get("from"), false)
+	if err != nil {
+		c.sendError(w, "Invalid from: use RFC3339 or YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	to, err := parseAuditTime(params.Get("to"), true)
+	if err != nil {
+		c.sendError(w, "Invalid to: use RFC3339 or YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	limit := 0
+	if limitStr := params.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			c.sendError(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	result := c.getAuditLogUseCase.Execute(usecase.GetAuditLogInput{
+		ActorID:       actorID,
+		Command:       params.Get("command"),
+		AggregateType: params.Get("aggregate_type"),
+		AggregateID:   params.Get("aggregate_id"),
+		RequestID:     params.Get("request_id"),
+		From:          from,
+		To:            to,
+		Limit:         limit,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.GetAuditLogOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, output.Entries)
+}
+
+// VerifyAuditLog handles GET /api/v1/audit/verify (administrators only)
+// A broken chain is still a 200 response with valid=false and the first bad sequence.
+func (c *AuditController) VerifyAuditLog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if _, ok := authenticatedAdmin(w, r, c.adminUserIDs); !ok {
+		return
+	}
+
+	result := c.verifyAuditLogUseCase.Execute(usecase.VerifyAuditLogInput{})
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), http.StatusInternalServerError)
+		return
+	}
+
+	output, ok := result.(usecase.VerifyAuditLogOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, output)
+}
+
+// parseAuditTime parses RFC3339 or YYYY-MM-DD. A plain date used as an upper
+// bound is moved to the start of the next day so the whole day is included.
+func parseAuditTime(value string, upperBound bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return &parsed, nil
+	}
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	if upperBound {
+		parsed = parsed.AddDate(0, 0, 1)
+	}
+	return &parsed, nil
+}
+
+func (c *AuditController) sendSuccess(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *AuditController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
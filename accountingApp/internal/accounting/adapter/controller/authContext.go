@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// contextKey is unexported so no other package can collide with or forge the entry
+type contextKey string
+
+const userIDContextKey contextKey = "userID"
+
+// WithUserID returns a copy of ctx carrying the authenticated user ID.
+// Only the authentication middleware should call this.
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDContextKey, userID)
+}
+
+// UserIDFromContext returns the authenticated user ID placed by the middleware
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(userIDContextKey).(string)
+	return userID, ok && userID != ""
+}
+
+// authenticatedUser resolves the caller for a request. Older clients still send
+// a userID/user_id parameter; it is accepted only when it names the caller,
+// otherwise the request is rejected with 403 instead of silently acting on it.
+// On failure the error response has already been written.
+func authenticatedUser(w http.ResponseWriter, r *http.Request, requestedUserID string) (string, bool) {
+	userID, ok := UserIDFromContext(r.Context())
+	if !ok {
+		writeAuthError(w, "Authentication required", http.StatusUnauthorized)
+		return "", false
+	}
+	if requestedUserID != "" && requestedUserID != userID {
+		writeAuthError(w, "Access to another user's data is forbidden", http.StatusForbidden)
+		return "", false
+	}
+	return userID, true
+}
+
+// authenticatedAdmin resolves the caller and requires them to be one of the
+// configured administrators. On failure the error response has already been written.
+func authenticatedAdmin(w http.ResponseWriter, r *http.Request, adminUserIDs map[string]bool) (string, bool) {
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return "", false
+	}
+	if !adminUserIDs[userID] {
+		writeAuthError(w, "Administrator access required", http.StatusForbidden)
+		return "", false
+	}
+	return userID, true
+}
+
+func writeAuthError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
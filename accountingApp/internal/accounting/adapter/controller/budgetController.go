@@ -0,0 +1,361 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// BudgetController handles the caller's budgets and their spending status
+type BudgetController struct {
+	createBudgetUseCase    usecase.CreateBudgetUseCase
+	updateBudgetUseCase    usecase.UpdateBudgetUseCase
+	deleteBudgetUseCase    usecase.DeleteBudgetUseCase
+	getBudgetsUseCase      usecase.GetBudgetsUseCase
+	getBudgetStatusUseCase usecase.GetBudgetStatusUseCase
+}
+
+// NewBudgetController creates a new BudgetController
+func NewBudgetController(
+	createBudgetUseCase usecase.CreateBudgetUseCase,
+	updateBudgetUseCase usecase.UpdateBudgetUseCase,
+	deleteBudgetUseCase usecase.DeleteBudgetUseCase,
+	getBudgetsUseCase usecase.GetBudgetsUseCase,
+	getBudgetStatusUseCase usecase.GetBudgetStatusUseCase,
+) *BudgetController {
+	return &BudgetController{
+		createBudgetUseCase:    createBudgetUseCase,
+		updateBudgetUseCase:    updateBudgetUseCase,
+		deleteBudgetUseCase:    deleteBudgetUseCase,
+		getBudgetsUseCase:      getBudgetsUseCase,
+		getBudgetStatusUseCase: getBudgetStatusUseCase,
+	}
+}
+
+// GetBudgets handles GET /api/v1/budgets
+func (c *BudgetController) GetBudgets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	result := c.getBudgetsUseCase.Execute(usecase.GetBudgetsInput{
+		UserID: userID,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), http.StatusInternalServerError)
+		return
+	}
+
+	output, ok := result.(usecase.GetBudgetsOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, output.Budgets)
+}
+
+// CreateBudget handles POST /api/v1/budgets
+// Dates accept YYYY-MM-DD or RFC3339; end_date is exclusive.
+func (c *BudgetController) CreateBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Name             string `json:"name"`
+		Period           string `json:"period"`
+		CategoryID       string `json:"category_id"`
+		SubcategoryID    string `json:"subcategory_id"`
+		Amount           int64  `json:"amount"`
+		Currency         string `json:"currency"`
+		Rollover         bool   `json:"rollover"`
+		WarningThreshold *int   `json:"warning_threshold"`
+		StartDate        string `json:"start_date"`
+		EndDate          string `json:"end_date"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// Validate required fields
+	if req.Name == "" {
+		c.sendError(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	if req.Period == "" {
+		c.sendError(w, "period is required", http.StatusBadRequest)
+		return
+	}
+	if req.Amount <= 0 {
+		c.sendError(w, "amount must be positive", http.StatusBadRequest)
+		return
+	}
+	if req.Currency == "" {
+		c.sendError(w, "currency is required", http.StatusBadRequest)
+		return
+	}
+
+	startDate := time.Now()
+	if req.StartDate != "" {
+		parsed, err := parseBudgetDate(req.StartDate)
+		if err != nil {
+			c.sendError(w, "Invalid start_date: use YYYY-MM-DD or RFC3339", http.StatusBadRequest)
+			return
+		}
+		startDate = parsed
+	}
+	var endDate *time.Time
+	if req.EndDate != "" {
+		parsed, err := parseBudgetDate(req.EndDate)
+		if err != nil {
+			c.sendError(w, "Invalid end_date: use YYYY-MM-DD or RFC3339", http.StatusBadRequest)
+			return
+		}
+		endDate = &parsed
+	}
+
+	result := c.createBudgetUseCase.Execute(usecase.CreateBudgetInput{
+		CommandMetadata:  commandMetadata(r),
+		UserID:           userID,
+		Name:             req.Name,
+		Period:           req.Period,
+		CategoryID:       req.CategoryID,
+		SubcategoryID:    req.SubcategoryID,
+		Amount:           req.Amount,
+		Currency:         req.Currency,
+		Rollover:         req.Rollover,
+		WarningThreshold: req.WarningThreshold,
+		StartDate:        startDate,
+		EndDate:          endDate,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendCommandError(w, result)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusCreated, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// UpdateBudget handles PUT /api/v1/budgets/{budgetID}
+// Omitted fields are left unchanged; period and category scope cannot be changed.
+func (c *BudgetController) UpdateBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	budgetID := c.extractBudgetID(r.URL.Path)
+	if budgetID == "" {
+		c.sendError(w, "Invalid budget ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Name             *string `json:"name"`
+		Amount           *int64  `json:"amount"`
+		Currency         *string `json:"currency"`
+		Rollover         *bool   `json:"rollover"`
+		WarningThreshold *int    `json:"warning_threshold"`
+		EndDate          *string `json:"end_date"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	var endDate *time.Time
+	if req.EndDate != nil {
+		parsed, err := parseBudgetDate(*req.EndDate)
+		if err != nil {
+			c.sendError(w, "Invalid end_date: use YYYY-MM-DD or RFC3339", http.StatusBadRequest)
+			return
+		}
+		endDate = &parsed
+	}
+
+	result := c.updateBudgetUseCase.Execute(usecase.UpdateBudgetInput{
+		CommandMetadata:  commandMetadata(r),
+		UserID:           userID,
+		BudgetID:         budgetID,
+		Name:             req.Name,
+		Amount:           req.Amount,
+		Currency:         req.Currency,
+		Rollover:         req.Rollover,
+		WarningThreshold: req.WarningThreshold,
+		EndDate:          endDate,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendCommandError(w, result)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// DeleteBudget handles DELETE /api/v1/budgets/{budgetID}
+func (c *BudgetController) DeleteBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	budgetID := c.extractBudgetID(r.URL.Path)
+	if budgetID == "" {
+		c.sendError(w, "Invalid budget ID", http.StatusBadRequest)
+		return
+	}
+
+	result := c.deleteBudgetUseCase.Execute(usecase.DeleteBudgetInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		BudgetID:        budgetID,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendCommandError(w, result)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// GetBudgetStatus handles GET /api/v1/budgets/{budgetID}/status[?date=YYYY-MM-DD]
+// Reports the period containing date (default today); status is null when the
+// budget is not active on that date.
+func (c *BudgetController) GetBudgetStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	budgetID := c.extractBudgetID(r.URL.Path)
+	if budgetID == "" {
+		c.sendError(w, "Invalid budget ID", http.StatusBadRequest)
+		return
+	}
+
+	var at time.Time
+	if dateStr := r.URL.Query().Get("date"); dateStr != "" {
+		parsed, err := parseBudgetDate(dateStr)
+		if err != nil {
+			c.sendError(w, "Invalid date: use YYYY-MM-DD or RFC3339", http.StatusBadRequest)
+			return
+		}
+		at = parsed
+	}
+
+	result := c.getBudgetStatusUseCase.Execute(usecase.GetBudgetStatusInput{
+		UserID:   userID,
+		BudgetID: budgetID,
+		At:       at,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendCommandError(w, result)
+		return
+	}
+
+	output, ok := result.(usecase.GetBudgetStatusOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"budget": output.Budget,
+		"status": output.Status,
+	})
+}
+
+// Helper methods
+func (c *BudgetController) extractBudgetID(path string) string {
+	// Extract budget ID from paths like /api/v1/budgets/{budgetID}[/status]
+	parts := strings.Split(strings.TrimPrefix(path, "/api/v1/budgets/"), "/")
+	if len(parts) > 0 && parts[0] != "" {
+		decoded, err := url.PathUnescape(parts[0])
+		if err != nil {
+			return parts[0]
+		}
+		return decoded
+	}
+	return ""
+}
+
+// parseBudgetDate parses YYYY-MM-DD or RFC3339
+func parseBudgetDate(value string) (time.Time, error) {
+	if parsed, err := time.Parse("2006-01-02", value); err == nil {
+		return parsed, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// sendCommandError maps a failed budget use case output to an HTTP status
+func (c *BudgetController) sendCommandError(w http.ResponseWriter, output common.Output) {
+	c.sendError(w, output.GetMessage(), failureStatus(output))
+}
+
+func (c *BudgetController) sendSuccess(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *BudgetController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
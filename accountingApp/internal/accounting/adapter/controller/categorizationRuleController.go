@@ -0,0 +1,297 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// CategorizationRuleController handles the rules that assign a subcategory and
+// tags to imported and entered transactions
+type CategorizationRuleController struct {
+	createCategorizationRuleUseCase usecase.CreateCategorizationRuleUseCase
+	updateCategorizationRuleUseCase usecase.UpdateCategorizationRuleUseCase
+	deleteCategorizationRuleUseCase usecase.DeleteCategorizationRuleUseCase
+	getCategorizationRulesUseCase   usecase.GetCategorizationRulesUseCase
+	testCategorizationRuleUseCase   usecase.TestCategorizationRuleUseCase
+}
+
+// NewCategorizationRuleController creates a new CategorizationRuleController
+func NewCategorizationRuleController(
+	createCategorizationRuleUseCase usecase.CreateCategorizationRuleUseCase,
+	updateCategorizationRuleUseCase usecase.UpdateCategorizationRuleUseCase,
+	deleteCategorizationRuleUseCase usecase.DeleteCategorizationRuleUseCase,
+	getCategorizationRulesUseCase usecase.GetCategorizationRulesUseCase,
+	testCategorizationRuleUseCase usecase.TestCategorizationRuleUseCase,
+) *CategorizationRuleController {
+	return &CategorizationRuleController{
+		createCategorizationRuleUseCase: createCategorizationRuleUseCase,
+		updateCategorizationRuleUseCase: updateCategorizationRuleUseCase,
+		deleteCategorizationRuleUseCase: deleteCategorizationRuleUseCase,
+		getCategorizationRulesUseCase:   getCategorizationRulesUseCase,
+		testCategorizationRuleUseCase:   testCategorizationRuleUseCase,
+	}
+}
+
+// categorizationRuleRequest is the body of a create or update request; the type
+// is only read on create
+type categorizationRuleRequest struct {
+	Name          string                     `json:"name"`
+	Type          string                     `json:"type"`
+	Priority      int                        `json:"priority"`
+	Conditions    usecase.RuleConditionsData `json:"conditions"`
+	SubcategoryID string                     `json:"subcategory_id"`
+	Tags          []string                   `json:"tags"`
+}
+
+// GetCategorizationRules handles GET /api/v1/categorization-rules[?type=EXPENSE|INCOME]
+// Rules are listed in the order they are tried.
+func (c *CategorizationRuleController) GetCategorizationRules(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	result := c.getCategorizationRulesUseCase.Execute(usecase.GetCategorizationRulesInput{
+		UserID: userID,
+		Type:   strings.ToUpper(r.URL.Query().Get("type")),
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.GetCategorizationRulesOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, output.Rules)
+}
+
+// CreateCategorizationRule handles POST /api/v1/categorization-rules
+// Body: {"name", "type", "priority", "conditions": {...}, "subcategory_id", "tags"};
+// at least one condition is required.
+func (c *CategorizationRuleController) CreateCategorizationRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	var req categorizationRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	result := c.createCategorizationRuleUseCase.Execute(usecase.CreateCategorizationRuleInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		Name:            req.Name,
+		Type:            strings.ToUpper(req.Type),
+		Priority:        req.Priority,
+		Conditions:      req.Conditions,
+		SubcategoryID:   req.SubcategoryID,
+		Tags:            req.Tags,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), commandOutputStatus(result))
+		return
+	}
+
+	c.sendSuccess(w, http.StatusCreated, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// UpdateCategorizationRule handles PUT /api/v1/categorization-rules/{ruleID}
+// Replaces everything but the type.
+func (c *CategorizationRuleController) UpdateCategorizationRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	ruleID := c.extractRuleID(r.URL.Path)
+	if ruleID == "" {
+		c.sendError(w, "Invalid categorization rule ID", http.StatusBadRequest)
+		return
+	}
+
+	var req categorizationRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	result := c.updateCategorizationRuleUseCase.Execute(usecase.UpdateCategorizationRuleInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		RuleID:          ruleID,
+		Name:            req.Name,
+		Priority:        req.Priority,
+		Conditions:      req.Conditions,
+		SubcategoryID:   req.SubcategoryID,
+		Tags:            req.Tags,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), commandOutputStatus(result))
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// DeleteCategorizationRule handles DELETE /api/v1/categorization-rules/{ruleID}
+func (c *CategorizationRuleController) DeleteCategorizationRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	ruleID := c.extractRuleID(r.URL.Path)
+	if ruleID == "" {
+		c.sendError(w, "Invalid categorization rule ID", http.StatusBadRequest)
+		return
+	}
+
+	result := c.deleteCategorizationRuleUseCase.Execute(usecase.DeleteCategorizationRuleInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		RuleID:          ruleID,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), commandOutputStatus(result))
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// TestCategorizationRule handles POST /api/v1/categorization-rules/test
+// Body: {"type", "wallet_id", "description", "amount", "currency", "date"};
+// reports which saved rule would categorize the sample without recording it.
+func (c *CategorizationRuleController) TestCategorizationRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Type        string    `json:"type"`
+		WalletID    string    `json:"wallet_id"`
+		Description string    `json:"description"`
+		Amount      int64     `json:"amount"`
+		Currency    string    `json:"currency"`
+		Date        time.Time `json:"date"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if req.Type == "" {
+		req.Type = "EXPENSE"
+	}
+
+	result := c.testCategorizationRuleUseCase.Execute(usecase.TestCategorizationRuleInput{
+		UserID:      userID,
+		Type:        strings.ToUpper(req.Type),
+		WalletID:    req.WalletID,
+		Description: req.Description,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		Date:        req.Date,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.TestCategorizationRuleOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"matched":  output.Matched,
+		"matching": output.Matching,
+		"message":  output.Message,
+	})
+}
+
+// Helper methods
+
+func (c *CategorizationRuleController) extractRuleID(path string) string {
+	// Extract rule ID from paths like /api/v1/categorization-rules/{ruleID}
+	parts := strings.Split(strings.TrimPrefix(path, "/api/v1/categorization-rules/"), "/")
+	if len(parts) > 0 && parts[0] != "" {
+		decoded, err := url.PathUnescape(parts[0])
+		if err != nil {
+			return parts[0]
+		}
+		return decoded
+	}
+	return ""
+}
+
+func (c *CategorizationRuleController) sendSuccess(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *CategorizationRuleController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
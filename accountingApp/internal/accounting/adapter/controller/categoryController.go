@@ -3,16 +3,29 @@ package controller
 import (
 	"encoding/json"
 	"net/http"
+	"net/url"
+	"strings"
 
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
 )
 
 // CategoryController handles category operations
 type CategoryController struct {
-	createExpenseCategoryUseCase usecase.CreateExpenseCategoryUseCase
-	createIncomeCategoryUseCase  usecase.CreateIncomeCategoryUseCase
-	getExpenseCategoriesUseCase  usecase.GetExpenseCategoriesUseCase
-	getIncomeCategoriesUseCase   usecase.GetIncomeCategoriesUseCase
+	createExpenseCategoryUseCase    usecase.CreateExpenseCategoryUseCase
+	createIncomeCategoryUseCase     usecase.CreateIncomeCategoryUseCase
+	getExpenseCategoriesUseCase     usecase.GetExpenseCategoriesUseCase
+	getIncomeCategoriesUseCase      usecase.GetIncomeCategoriesUseCase
+	renameExpenseCategoryUseCase    usecase.RenameExpenseCategoryUseCase
+	deleteExpenseCategoryUseCase    usecase.DeleteExpenseCategoryUseCase
+	addExpenseSubcategoryUseCase    usecase.AddExpenseSubcategoryUseCase
+	renameExpenseSubcategoryUseCase usecase.RenameExpenseSubcategoryUseCase
+	removeExpenseSubcategoryUseCase usecase.RemoveExpenseSubcategoryUseCase
+	renameIncomeCategoryUseCase     usecase.RenameIncomeCategoryUseCase
+	deleteIncomeCategoryUseCase     usecase.DeleteIncomeCategoryUseCase
+	addIncomeSubcategoryUseCase     usecase.AddIncomeSubcategoryUseCase
+	renameIncomeSubcategoryUseCase  usecase.RenameIncomeSubcategoryUseCase
+	removeIncomeSubcategoryUseCase  usecase.RemoveIncomeSubcategoryUseCase
 }
 
 // NewCategoryController creates a new CategoryController
@@ -21,12 +34,32 @@ func NewCategoryController(
 	createIncomeCategoryUseCase usecase.CreateIncomeCategoryUseCase,
 	getExpenseCategoriesUseCase usecase.GetExpenseCategoriesUseCase,
 	getIncomeCategoriesUseCase usecase.GetIncomeCategoriesUseCase,
+	renameExpenseCategoryUseCase usecase.RenameExpenseCategoryUseCase,
+	deleteExpenseCategoryUseCase usecase.DeleteExpenseCategoryUseCase,
+	addExpenseSubcategoryUseCase usecase.AddExpenseSubcategoryUseCase,
+	renameExpenseSubcategoryUseCase usecase.RenameExpenseSubcategoryUseCase,
+	removeExpenseSubcategoryUseCase usecase.RemoveExpenseSubcategoryUseCase,
+	renameIncomeCategoryUseCase usecase.RenameIncomeCategoryUseCase,
+	deleteIncomeCategoryUseCase usecase.DeleteIncomeCategoryUseCase,
+	addIncomeSubcategoryUseCase usecase.AddIncomeSubcategoryUseCase,
+	renameIncomeSubcategoryUseCase usecase.RenameIncomeSubcategoryUseCase,
+	removeIncomeSubcategoryUseCase usecase.RemoveIncomeSubcategoryUseCase,
 ) *CategoryController {
 	return &CategoryController{
-		createExpenseCategoryUseCase: createExpenseCategoryUseCase,
-		createIncomeCategoryUseCase:  createIncomeCategoryUseCase,
-		getExpenseCategoriesUseCase:  getExpenseCategoriesUseCase,
-		getIncomeCategoriesUseCase:   getIncomeCategoriesUseCase,
+		createExpenseCategoryUseCase:    createExpenseCategoryUseCase,
+		createIncomeCategoryUseCase:     createIncomeCategoryUseCase,
+		getExpenseCategoriesUseCase:     getExpenseCategoriesUseCase,
+		getIncomeCategoriesUseCase:      getIncomeCategoriesUseCase,
+		renameExpenseCategoryUseCase:    renameExpenseCategoryUseCase,
+		deleteExpenseCategoryUseCase:    deleteExpenseCategoryUseCase,
+		addExpenseSubcategoryUseCase:    addExpenseSubcategoryUseCase,
+		renameExpenseSubcategoryUseCase: renameExpenseSubcategoryUseCase,
+		removeExpenseSubcategoryUseCase: removeExpenseSubcategoryUseCase,
+		renameIncomeCategoryUseCase:     renameIncomeCategoryUseCase,
+		deleteIncomeCategoryUseCase:     deleteIncomeCategoryUseCase,
+		addIncomeSubcategoryUseCase:     addIncomeSubcategoryUseCase,
+		renameIncomeSubcategoryUseCase:  renameIncomeSubcategoryUseCase,
+		removeIncomeSubcategoryUseCase:  removeIncomeSubcategoryUseCase,
 	}
 }
 
@@ -47,19 +80,21 @@ func (c *CategoryController) CreateExpenseCategory(w http.ResponseWriter, r *htt
 		return
 	}
 
-	// Validate required fields
-	if req.UserID == "" {
-		c.sendError(w, "user_id is required", http.StatusBadRequest)
+	userID, ok := authenticatedUser(w, r, req.UserID)
+	if !ok {
 		return
 	}
+
+	// Validate required fields
 	if req.Name == "" {
 		c.sendError(w, "name is required", http.StatusBadRequest)
 		return
 	}
 
 	input := usecase.CreateExpenseCategoryInput{
-		UserID: req.UserID,
-		Name:   req.Name,
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		Name:            req.Name,
 	}
 
 	output := c.createExpenseCategoryUseCase.Execute(input)
@@ -93,19 +128,21 @@ func (c *CategoryController) CreateIncomeCategory(w http.ResponseWriter, r *http
 		return
 	}
 
-	// Validate required fields
-	if req.UserID == "" {
-		c.sendError(w, "user_id is required", http.StatusBadRequest)
+	userID, ok := authenticatedUser(w, r, req.UserID)
+	if !ok {
 		return
 	}
+
+	// Validate required fields
 	if req.Name == "" {
 		c.sendError(w, "name is required", http.StatusBadRequest)
 		return
 	}
 
 	input := usecase.CreateIncomeCategoryInput{
-		UserID: req.UserID,
-		Name:   req.Name,
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		Name:            req.Name,
 	}
 
 	output := c.createIncomeCategoryUseCase.Execute(input)
@@ -129,9 +166,8 @@ func (c *CategoryController) GetExpenseCategories(w http.ResponseWriter, r *http
 		return
 	}
 
-	userID := r.URL.Query().Get("userID")
-	if userID == "" {
-		c.sendError(w, "userID is required", http.StatusBadRequest)
+	userID, ok := authenticatedUser(w, r, r.URL.Query().Get("userID"))
+	if !ok {
 		return
 	}
 
@@ -171,9 +207,8 @@ func (c *CategoryController) GetIncomeCategories(w http.ResponseWriter, r *http.
 		return
 	}
 
-	userID := r.URL.Query().Get("userID")
-	if userID == "" {
-		c.sendError(w, "userID is required", http.StatusBadRequest)
+	userID, ok := authenticatedUser(w, r, r.URL.Query().Get("userID"))
+	if !ok {
 		return
 	}
 
@@ -206,7 +241,351 @@ func (c *CategoryController) GetIncomeCategories(w http.ResponseWriter, r *http.
 	}
 }
 
+// RenameExpenseCategory handles PUT /api/v1/categories/expense/{categoryID}
+func (c *CategoryController) RenameExpenseCategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	categoryID, _ := c.extractCategoryIDs(r.URL.Path, "/api/v1/categories/expense/")
+	if categoryID == "" {
+		c.sendError(w, "Invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	name, ok := c.decodeName(w, r)
+	if !ok {
+		return
+	}
+
+	c.sendCommandResult(w, c.renameExpenseCategoryUseCase.Execute(usecase.RenameExpenseCategoryInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		CategoryID:      categoryID,
+		Name:            name,
+	}))
+}
+
+// DeleteExpenseCategory handles DELETE /api/v1/categories/expense/{categoryID}
+func (c *CategoryController) DeleteExpenseCategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	categoryID, _ := c.extractCategoryIDs(r.URL.Path, "/api/v1/categories/expense/")
+	if categoryID == "" {
+		c.sendError(w, "Invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	c.sendCommandResult(w, c.deleteExpenseCategoryUseCase.Execute(usecase.DeleteExpenseCategoryInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		CategoryID:      categoryID,
+	}))
+}
+
+// AddExpenseSubcategory handles POST /api/v1/categories/expense/{categoryID}/subcategories
+func (c *CategoryController) AddExpenseSubcategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	categoryID, _ := c.extractCategoryIDs(r.URL.Path, "/api/v1/categories/expense/")
+	if categoryID == "" {
+		c.sendError(w, "Invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	name, ok := c.decodeName(w, r)
+	if !ok {
+		return
+	}
+
+	c.sendCommandResult(w, c.addExpenseSubcategoryUseCase.Execute(usecase.AddExpenseSubcategoryInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		CategoryID:      categoryID,
+		Name:            name,
+	}))
+}
+
+// RenameExpenseSubcategory handles PUT /api/v1/categories/expense/{categoryID}/subcategories/{subcategoryID}
+func (c *CategoryController) RenameExpenseSubcategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	categoryID, subcategoryID := c.extractCategoryIDs(r.URL.Path, "/api/v1/categories/expense/")
+	if categoryID == "" || subcategoryID == "" {
+		c.sendError(w, "Invalid subcategory ID", http.StatusBadRequest)
+		return
+	}
+
+	name, ok := c.decodeName(w, r)
+	if !ok {
+		return
+	}
+
+	c.sendCommandResult(w, c.renameExpenseSubcategoryUseCase.Execute(usecase.RenameExpenseSubcategoryInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		CategoryID:      categoryID,
+		SubcategoryID:   subcategoryID,
+		Name:            name,
+	}))
+}
+
+// RemoveExpenseSubcategory handles DELETE /api/v1/categories/expense/{categoryID}/subcategories/{subcategoryID}
+func (c *CategoryController) RemoveExpenseSubcategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	categoryID, subcategoryID := c.extractCategoryIDs(r.URL.Path, "/api/v1/categories/expense/")
+	if categoryID == "" || subcategoryID == "" {
+		c.sendError(w, "Invalid subcategory ID", http.StatusBadRequest)
+		return
+	}
+
+	c.sendCommandResult(w, c.removeExpenseSubcategoryUseCase.Execute(usecase.RemoveExpenseSubcategoryInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		CategoryID:      categoryID,
+		SubcategoryID:   subcategoryID,
+	}))
+}
+
+// RenameIncomeCategory handles PUT /api/v1/categories/income/{categoryID}
+func (c *CategoryController) RenameIncomeCategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	categoryID, _ := c.extractCategoryIDs(r.URL.Path, "/api/v1/categories/income/")
+	if categoryID == "" {
+		c.sendError(w, "Invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	name, ok := c.decodeName(w, r)
+	if !ok {
+		return
+	}
+
+	c.sendCommandResult(w, c.renameIncomeCategoryUseCase.Execute(usecase.RenameIncomeCategoryInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		CategoryID:      categoryID,
+		Name:            name,
+	}))
+}
+
+// DeleteIncomeCategory handles DELETE /api/v1/categories/income/{categoryID}
+func (c *CategoryController) DeleteIncomeCategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	categoryID, _ := c.extractCategoryIDs(r.URL.Path, "/api/v1/categories/income/")
+	if categoryID == "" {
+		c.sendError(w, "Invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	c.sendCommandResult(w, c.deleteIncomeCategoryUseCase.Execute(usecase.DeleteIncomeCategoryInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		CategoryID:      categoryID,
+	}))
+}
+
+// AddIncomeSubcategory handles POST /api/v1/categories/income/{categoryID}/subcategories
+func (c *CategoryController) AddIncomeSubcategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	categoryID, _ := c.extractCategoryIDs(r.URL.Path, "/api/v1/categories/income/")
+	if categoryID == "" {
+		c.sendError(w, "Invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	name, ok := c.decodeName(w, r)
+	if !ok {
+		return
+	}
+
+	c.sendCommandResult(w, c.addIncomeSubcategoryUseCase.Execute(usecase.AddIncomeSubcategoryInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		CategoryID:      categoryID,
+		Name:            name,
+	}))
+}
+
+// RenameIncomeSubcategory handles PUT /api/v1/categories/income/{categoryID}/subcategories/{subcategoryID}
+func (c *CategoryController) RenameIncomeSubcategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	categoryID, subcategoryID := c.extractCategoryIDs(r.URL.Path, "/api/v1/categories/income/")
+	if categoryID == "" || subcategoryID == "" {
+		c.sendError(w, "Invalid subcategory ID", http.StatusBadRequest)
+		return
+	}
+
+	name, ok := c.decodeName(w, r)
+	if !ok {
+		return
+	}
+
+	c.sendCommandResult(w, c.renameIncomeSubcategoryUseCase.Execute(usecase.RenameIncomeSubcategoryInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		CategoryID:      categoryID,
+		SubcategoryID:   subcategoryID,
+		Name:            name,
+	}))
+}
+
+// RemoveIncomeSubcategory handles DELETE /api/v1/categories/income/{categoryID}/subcategories/{subcategoryID}
+func (c *CategoryController) RemoveIncomeSubcategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	categoryID, subcategoryID := c.extractCategoryIDs(r.URL.Path, "/api/v1/categories/income/")
+	if categoryID == "" || subcategoryID == "" {
+		c.sendError(w, "Invalid subcategory ID", http.StatusBadRequest)
+		return
+	}
+
+	c.sendCommandResult(w, c.removeIncomeSubcategoryUseCase.Execute(usecase.RemoveIncomeSubcategoryInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		CategoryID:      categoryID,
+		SubcategoryID:   subcategoryID,
+	}))
+}
+
 // Helper methods
+// extractCategoryIDs parses paths like {prefix}{categoryID}/subcategories/{subcategoryID};
+// subcategoryID is empty for category-level paths
+func (c *CategoryController) extractCategoryIDs(path, prefix string) (string, string) {
+	parts := strings.Split(strings.TrimPrefix(path, prefix), "/")
+	categoryID := c.unescape(parts[0])
+	if len(parts) >= 3 && parts[1] == "subcategories" {
+		return categoryID, c.unescape(parts[2])
+	}
+	return categoryID, ""
+}
+
+func (c *CategoryController) unescape(segment string) string {
+	decoded, err := url.PathUnescape(segment)
+	if err != nil {
+		return segment // fallback to original if decode fails
+	}
+	return decoded
+}
+
+// decodeName reads the {"name": ...} body shared by the rename/add endpoints
+func (c *CategoryController) decodeName(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var req struct {
+		Name string `json:"name"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return "", false
+	}
+	if req.Name == "" {
+		c.sendError(w, "name is required", http.StatusBadRequest)
+		return "", false
+	}
+	return req.Name, true
+}
+
+// sendCommandResult maps a category command output to an HTTP response
+func (c *CategoryController) sendCommandResult(w http.ResponseWriter, output common.Output) {
+	if output.GetExitCode() != common.Success {
+		c.sendError(w, output.GetMessage(), failureStatus(output))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"id":      output.GetID(),
+			"message": output.GetMessage(),
+		},
+	})
+}
+
 func (c *CategoryController) sendError(w http.ResponseWriter, message string, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -214,4 +593,4 @@ func (c *CategoryController) sendError(w http.ResponseWriter, message string, st
 		"success": false,
 		"error":   message,
 	})
-}
\ No newline at end of file
+}
@@ -31,6 +31,7 @@ func (c *CreateWalletController) CreateWallet(w http.ResponseWriter, r *http.Req
 		Type           string `json:"type"`
 		Currency       string `json:"currency"`
 		InitialBalance *int64 `json:"initialBalance,omitempty"`
+		Locale         string `json:"locale,omitempty"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -38,11 +39,12 @@ func (c *CreateWalletController) CreateWallet(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// Validate required fields
-	if req.UserID == "" {
-		c.sendError(w, "user_id is required", http.StatusBadRequest)
+	userID, ok := authenticatedUser(w, r, req.UserID)
+	if !ok {
 		return
 	}
+
+	// Validate required fields
 	if req.Name == "" {
 		c.sendError(w, "name is required", http.StatusBadRequest)
 		return
@@ -57,11 +59,13 @@ func (c *CreateWalletController) CreateWallet(w http.ResponseWriter, r *http.Req
 	}
 
 	input := usecase.CreateWalletInput{
-		UserID:         req.UserID,
-		Name:           req.Name,
-		Type:           req.Type,
-		Currency:       req.Currency,
-		InitialBalance: req.InitialBalance,
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		Name:            req.Name,
+		Type:            req.Type,
+		Currency:        req.Currency,
+		InitialBalance:  req.InitialBalance,
+		Locale:          req.Locale,
 	}
 
 	output := c.createWalletUseCase.Execute(input)
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// CurrencyController serves the ISO 4217 currency registry
+type CurrencyController struct {
+	getCurrenciesUseCase usecase.GetCurrenciesUseCase
+}
+
+// NewCurrencyController creates a new CurrencyController
+func NewCurrencyController(getCurrenciesUseCase usecase.GetCurrenciesUseCase) *CurrencyController {
+	return &CurrencyController{getCurrenciesUseCase: getCurrenciesUseCase}
+}
+
+// GetCurrencies handles GET /api/v1/currencies[?locale=de-DE]
+// Lists every supported currency with its minor units, symbol and an example
+// amount written in the locale; without locale the Accept-Language header is used.
+func (c *CurrencyController) GetCurrencies(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if _, ok := authenticatedUser(w, r, ""); !ok {
+		return
+	}
+
+	locale := r.URL.Query().Get("locale")
+	if locale == "" {
+		locale = preferredLanguage(r.Header.Get("Accept-Language"))
+	}
+
+	result := c.getCurrenciesUseCase.Execute(usecase.GetCurrenciesInput{Locale: locale})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.GetCurrenciesOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"locale":     output.Locale,
+		"currencies": output.Currencies,
+	})
+}
+
+// Helper methods
+
+// preferredLanguage returns the first language tag of an Accept-Language
+// header, e.g. "zh-TW" for "zh-TW,zh;q=0.9,en;q=0.8"
+func preferredLanguage(header string) string {
+	for i, ch := range header {
+		if ch == ',' || ch == ';' {
+			return header[:i]
+		}
+	}
+	return header
+}
+
+func (c *CurrencyController) sendSuccess(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *CurrencyController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// DeleteExpenseController handles expense record deletion
+type DeleteExpenseController struct {
+	deleteExpenseUseCase usecase.DeleteExpenseUseCase
+}
+
+// NewDeleteExpenseController creates a new DeleteExpenseController
+func NewDeleteExpenseController(deleteExpenseUseCase usecase.DeleteExpenseUseCase) *DeleteExpenseController {
+	return &DeleteExpenseController{
+		deleteExpenseUseCase: deleteExpenseUseCase,
+	}
+}
+
+// DeleteExpense handles DELETE /api/v1/expenses/{expenseID}
+func (c *DeleteExpenseController) DeleteExpense(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	expenseID := c.extractExpenseID(r.URL.Path)
+	if expenseID == "" {
+		c.sendError(w, "Invalid expense ID", http.StatusBadRequest)
+		return
+	}
+
+	result := c.deleteExpenseUseCase.Execute(usecase.DeleteExpenseInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		ExpenseID:       expenseID,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	c.sendSuccess(w, map[string]interface{}{
+		"message": result.GetMessage(),
+	})
+}
+
+// Helper methods
+func (c *DeleteExpenseController) extractExpenseID(path string) string {
+	// Extract expense ID from paths like /api/v1/expenses/{expenseID}
+	parts := strings.Split(strings.TrimPrefix(path, "/api/v1/expenses/"), "/")
+	if len(parts) > 0 && parts[0] != "" {
+		// URL decode in case there are special characters
+		decoded, err := url.QueryUnescape(parts[0])
+		if err != nil {
+			return parts[0] // fallback to original if decode fails
+		}
+		return decoded
+	}
+	return ""
+}
+
+func (c *DeleteExpenseController) sendSuccess(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *DeleteExpenseController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// DeleteIncomeController handles income record deletion
+type DeleteIncomeController struct {
+	deleteIncomeUseCase usecase.DeleteIncomeUseCase
+}
+
+// NewDeleteIncomeController creates a new DeleteIncomeController
+func NewDeleteIncomeController(deleteIncomeUseCase usecase.DeleteIncomeUseCase) *DeleteIncomeController {
+	return &DeleteIncomeController{
+		deleteIncomeUseCase: deleteIncomeUseCase,
+	}
+}
+
+// DeleteIncome handles DELETE /api/v1/incomes/{incomeID}
+func (c *DeleteIncomeController) DeleteIncome(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	incomeID := c.extractIncomeID(r.URL.Path)
+	if incomeID == "" {
+		c.sendError(w, "Invalid income ID", http.StatusBadRequest)
+		return
+	}
+
+	result := c.deleteIncomeUseCase.Execute(usecase.DeleteIncomeInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		IncomeID:        incomeID,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	c.sendSuccess(w, map[string]interface{}{
+		"message": result.GetMessage(),
+	})
+}
+
+// Helper methods
+func (c *DeleteIncomeController) extractIncomeID(path string) string {
+	// Extract income ID from paths like /api/v1/incomes/{incomeID}
+	parts := strings.Split(strings.TrimPrefix(path, "/api/v1/incomes/"), "/")
+	if len(parts) > 0 && parts[0] != "" {
+		// URL decode in case there are special characters
+		decoded, err := url.QueryUnescape(parts[0])
+		if err != nil {
+			return parts[0] // fallback to original if decode fails
+		}
+		return decoded
+	}
+	return ""
+}
+
+func (c *DeleteIncomeController) sendSuccess(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *DeleteIncomeController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
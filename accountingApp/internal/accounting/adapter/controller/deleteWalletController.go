@@ -29,6 +29,11 @@ func (c *DeleteWalletController) DeleteWallet(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
 	walletID := c.extractWalletID(r.URL.Path)
 	if walletID == "" {
 		c.sendError(w, "Invalid wallet ID", http.StatusBadRequest)
@@ -36,16 +41,13 @@ func (c *DeleteWalletController) DeleteWallet(w http.ResponseWriter, r *http.Req
 	}
 
 	result := c.deleteWalletUseCase.Execute(usecase.DeleteWalletInput{
-		WalletID: walletID,
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		WalletID:        walletID,
 	})
 
 	if result.GetExitCode() != common.Success {
-		message := result.GetMessage()
-		if message == "Wallet not found" {
-			c.sendError(w, message, http.StatusNotFound)
-		} else {
-			c.sendError(w, message, http.StatusInternalServerError)
-		}
+		c.sendError(w, result.GetMessage(), failureStatus(result))
 		return
 	}
 
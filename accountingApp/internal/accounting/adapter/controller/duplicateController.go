@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// DuplicateController handles the review queue of transactions flagged as
+// likely duplicates when they were added or imported.
+type DuplicateController struct {
+	getDuplicatesUseCase    usecase.GetDuplicatesUseCase
+	resolveDuplicateUseCase usecase.ResolveDuplicateUseCase
+}
+
+// NewDuplicateController creates a new DuplicateController
+func NewDuplicateController(
+	getDuplicatesUseCase usecase.GetDuplicatesUseCase,
+	resolveDuplicateUseCase usecase.ResolveDuplicateUseCase,
+) *DuplicateController {
+	return &DuplicateController{
+		getDuplicatesUseCase:    getDuplicatesUseCase,
+		resolveDuplicateUseCase: resolveDuplicateUseCase,
+	}
+}
+
+// GetDuplicates handles GET /api/v1/duplicates[?status=PENDING&wallet_id=]
+// Without a status the pending review queue is listed, oldest first.
+func (c *DuplicateController) GetDuplicates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	result := c.getDuplicatesUseCase.Execute(usecase.GetDuplicatesInput{
+		UserID:   userID,
+		WalletID: query.Get("wallet_id"),
+		Status:   query.Get("status"),
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.GetDuplicatesOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, output.Duplicates)
+}
+
+// ResolveDuplicate handles POST /api/v1/duplicates/{flagID}/resolve
+// Body: {"resolution": "MERGE" | "KEEP_BOTH" | "DELETE"}; MERGE folds the newer
+// record's tags, attachments and import ID into the older one and deletes it.
+func (c *DuplicateController) ResolveDuplicate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	flagID := c.extractFlagID(r.URL.Path)
+	if flagID == "" {
+		c.sendError(w, "Invalid duplicate ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Resolution string `json:"resolution"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if req.Resolution == "" {
+		c.sendError(w, "resolution is required", http.StatusBadRequest)
+		return
+	}
+
+	result := c.resolveDuplicateUseCase.Execute(usecase.ResolveDuplicateInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		FlagID:          flagID,
+		Resolution:      strings.ToUpper(req.Resolution),
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), commandOutputStatus(result))
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// Helper methods
+
+func (c *DuplicateController) extractFlagID(path string) string {
+	// Extract flag ID from paths like /api/v1/duplicates/{flagID}/resolve
+	parts := strings.Split(strings.TrimPrefix(path, "/api/v1/duplicates/"), "/")
+	if len(parts) > 0 && parts[0] != "" {
+		decoded, err := url.PathUnescape(parts[0])
+		if err != nil {
+			return parts[0]
+		}
+		return decoded
+	}
+	return ""
+}
+
+func (c *DuplicateController) sendSuccess(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *DuplicateController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
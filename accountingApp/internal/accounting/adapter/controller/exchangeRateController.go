@@ -0,0 +1,245 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// ExchangeRateController serves stored exchange rates and conversions to every
+// user and lets administrators load rates from a CSV file or the rate provider
+type ExchangeRateController struct {
+	getExchangeRatesUseCase     usecase.GetExchangeRatesUseCase
+	convertMoneyUseCase         usecase.ConvertMoneyUseCase
+	importExchangeRatesUseCase  usecase.ImportExchangeRatesUseCase
+	refreshExchangeRatesUseCase usecase.RefreshExchangeRatesUseCase
+	adminUserIDs                map[string]bool
+}
+
+// NewExchangeRateController creates a new ExchangeRateController; only the given users may load rates
+func NewExchangeRateController(
+	getExchangeRatesUseCase usecase.GetExchangeRatesUseCase,
+	convertMoneyUseCase usecase.ConvertMoneyUseCase,
+	importExchangeRatesUseCase usecase.ImportExchangeRatesUseCase,
+	refreshExchangeRatesUseCase usecase.RefreshExchangeRatesUseCase,
+	adminUserIDs []string,
+) *ExchangeRateController {
+	admins := make(map[string]bool, len(adminUserIDs))
+	for _, id := range adminUserIDs {
+		admins[id] = true
+	}
+	return &ExchangeRateController{
+		getExchangeRatesUseCase:     getExchangeRatesUseCase,
+		convertMoneyUseCase:         convertMoneyUseCase,
+		importExchangeRatesUseCase:  importExchangeRatesUseCase,
+		refreshExchangeRatesUseCase: refreshExchangeRatesUseCase,
+		adminUserIDs:                admins,
+	}
+}
+
+// GetExchangeRates handles GET /api/v1/exchange-rates[?date=YYYY-MM-DD]
+// Lists the latest rate of every currency pair on or before the date (default
+// today); with base and quote it lists the history of that pair, newest first.
+func (c *ExchangeRateController) GetExchangeRates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if _, ok := authenticatedUser(w, r, ""); !ok {
+		return
+	}
+
+	params := r.URL.Query()
+	date, ok := c.parseDate(w, params.Get("date"))
+	if !ok {
+		return
+	}
+
+	result := c.getExchangeRatesUseCase.Execute(usecase.GetExchangeRatesInput{
+		Base:  params.Get("base"),
+		Quote: params.Get("quote"),
+		Date:  date,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.GetExchangeRatesOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, output.Rates)
+}
+
+// ConvertMoney handles GET /api/v1/exchange-rates/convert?amount=&from=&to=[&date=]
+// amount is in the smallest unit of from; the result is in the smallest unit of to.
+func (c *ExchangeRateController) ConvertMoney(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if _, ok := authenticatedUser(w, r, ""); !ok {
+		return
+	}
+
+	params := r.URL.Query()
+	amount, err := strconv.ParseInt(params.Get("amount"), 10, 64)
+	if err != nil {
+		c.sendError(w, "amount must be an integer in the smallest currency unit", http.StatusBadRequest)
+		return
+	}
+	date, ok := c.parseDate(w, params.Get("date"))
+	if !ok {
+		return
+	}
+
+	result := c.convertMoneyUseCase.Execute(usecase.ConvertMoneyInput{
+		Amount: amount,
+		From:   params.Get("from"),
+		To:     params.Get("to"),
+		Date:   date,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.ConvertMoneyOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"original":  output.Original,
+		"converted": output.Converted,
+		"rounding":  output.Rounding,
+	})
+}
+
+// ImportExchangeRates handles POST /api/v1/admin/exchange-rates/import (multipart form with file)
+// The CSV header is date,base,quote,rate; nothing is stored when any line is invalid.
+func (c *ExchangeRateController) ImportExchangeRates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if _, ok := authenticatedAdmin(w, r, c.adminUserIDs); !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, exchange.MaxFileSize+multipartOverhead)
+	if err := r.ParseMultipartForm(multipartOverhead); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.sendError(w, "Exchange rate file too large", http.StatusRequestEntityTooLarge)
+		} else {
+			c.sendError(w, "Invalid multipart form", http.StatusBadRequest)
+		}
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		c.sendError(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	result := c.importExchangeRatesUseCase.Execute(usecase.ImportExchangeRatesInput{
+		CommandMetadata: commandMetadata(r),
+		Content:         file,
+	})
+	c.sendImportResult(w, result)
+}
+
+// RefreshExchangeRates handles POST /api/v1/admin/exchange-rates/refresh[?date=YYYY-MM-DD]
+// Fetches the rates of the day (default today) from the configured rate provider.
+func (c *ExchangeRateController) RefreshExchangeRates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if _, ok := authenticatedAdmin(w, r, c.adminUserIDs); !ok {
+		return
+	}
+
+	date, ok := c.parseDate(w, r.URL.Query().Get("date"))
+	if !ok {
+		return
+	}
+
+	result := c.refreshExchangeRatesUseCase.Execute(usecase.RefreshExchangeRatesInput{
+		CommandMetadata: commandMetadata(r),
+		Date:            date,
+	})
+	c.sendImportResult(w, result)
+}
+
+// Helper methods
+
+// parseDate parses an optional YYYY-MM-DD parameter; the zero time means today
+func (c *ExchangeRateController) parseDate(w http.ResponseWriter, value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, true
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		c.sendError(w, "Invalid date: use YYYY-MM-DD", http.StatusBadRequest)
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+func (c *ExchangeRateController) sendImportResult(w http.ResponseWriter, result common.Output) {
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), commandOutputStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.ImportExchangeRatesOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"imported": output.Imported,
+		"rates":    output.Rates,
+		"message":  output.Message,
+	})
+}
+
+func (c *ExchangeRateController) sendSuccess(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *ExchangeRateController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
 )
 
@@ -29,6 +30,11 @@ func (c *GetWalletBalanceController) GetWalletBalance(w http.ResponseWriter, r *
 	}
 
 	// Extract wallet ID from URL path like /api/v1/wallets/{id}/balance
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
 	walletID := c.extractWalletIDFromBalancePath(r.URL.Path)
 	if walletID == "" {
 		c.sendError(w, "Invalid wallet ID", http.StatusBadRequest)
@@ -36,6 +42,7 @@ func (c *GetWalletBalanceController) GetWalletBalance(w http.ResponseWriter, r *
 	}
 
 	input := usecase.GetWalletBalanceInput{
+		UserID:   userID,
 		WalletID: walletID,
 	}
 
@@ -43,7 +50,7 @@ func (c *GetWalletBalanceController) GetWalletBalance(w http.ResponseWriter, r *
 
 	w.Header().Set("Content-Type", "application/json")
 	if output.GetExitCode() != 0 {
-		if output.GetMessage() == "Wallet not found" {
+		if output.GetExitCode() == common.NotFound {
 			w.WriteHeader(http.StatusNotFound)
 		} else {
 			w.WriteHeader(http.StatusBadRequest)
@@ -26,25 +26,26 @@ func NewQueryWalletController(getWalletsUseCase usecase.GetWalletsUseCase, getWa
 	}
 }
 
-// GetWallets handles GET /api/v1/wallets?userID={userID}
+// GetWallets handles GET /api/v1/wallets[?reportingCurrency=USD] for the authenticated user
+// A reporting currency adds each balance and the total converted with today's rates.
 func (c *QueryWalletController) GetWallets(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	userID := r.URL.Query().Get("userID")
-	if userID == "" {
-		c.sendError(w, "userID parameter is required", http.StatusBadRequest)
+	userID, ok := authenticatedUser(w, r, r.URL.Query().Get("userID"))
+	if !ok {
 		return
 	}
 
 	result := c.getWalletsUseCase.Execute(usecase.GetWalletsInput{
-		UserID: userID,
+		UserID:            userID,
+		ReportingCurrency: r.URL.Query().Get("reportingCurrency"),
 	})
 
 	if result.GetExitCode() != common.Success {
-		c.sendError(w, result.GetMessage(), http.StatusInternalServerError)
+		c.sendError(w, result.GetMessage(), failureStatus(result))
 		return
 	}
 
@@ -60,7 +61,26 @@ func (c *QueryWalletController) GetWallets(w http.ResponseWriter, r *http.Reques
 		response[i] = c.walletToResponse(wallet)
 	}
 
-	c.sendSuccess(w, response)
+	if output.ReportingCurrency == "" {
+		c.sendSuccess(w, response)
+		return
+	}
+
+	// With a reporting currency each wallet also carries its converted balance,
+	// and the total of all wallets is reported next to the list
+	for i, wallet := range output.Wallets {
+		response[i]["converted_balance"] = output.ConvertedBalances[wallet.ID]
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    response,
+		"reporting": map[string]interface{}{
+			"currency":      output.ReportingCurrency,
+			"total_balance": output.TotalBalance,
+		},
+	})
 }
 
 // GetWallet handles GET /api/v1/wallets/{walletID}
@@ -70,6 +90,11 @@ func (c *QueryWalletController) GetWallet(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
 	walletID := c.extractWalletID(r.URL.Path)
 	if walletID == "" {
 		c.sendError(w, "Invalid wallet ID", http.StatusBadRequest)
@@ -80,16 +105,13 @@ func (c *QueryWalletController) GetWallet(w http.ResponseWriter, r *http.Request
 	includeTransactions := r.URL.Query().Get("includeTransactions") == "true"
 
 	result := c.getWalletUseCase.Execute(usecase.GetWalletInput{
+		UserID:              userID,
 		WalletID:            walletID,
 		IncludeTransactions: includeTransactions,
 	})
 
 	if result.GetExitCode() != common.Success {
-		if result.GetMessage() == "Wallet not found" {
-			c.sendError(w, result.GetMessage(), http.StatusNotFound)
-		} else {
-			c.sendError(w, result.GetMessage(), http.StatusInternalServerError)
-		}
+		c.sendError(w, result.GetMessage(), failureStatus(result))
 		return
 	}
 
@@ -0,0 +1,374 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/statement"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// ImportController handles bank statement import profiles and the preview and
+// commit of statement files
+type ImportController struct {
+	createImportProfileUseCase usecase.CreateImportProfileUseCase
+	updateImportProfileUseCase usecase.UpdateImportProfileUseCase
+	deleteImportProfileUseCase usecase.DeleteImportProfileUseCase
+	getImportProfilesUseCase   usecase.GetImportProfilesUseCase
+	previewImportUseCase       usecase.PreviewImportUseCase
+	commitImportUseCase        usecase.CommitImportUseCase
+}
+
+// NewImportController creates a new ImportController
+func NewImportController(
+	createImportProfileUseCase usecase.CreateImportProfileUseCase,
+	updateImportProfileUseCase usecase.UpdateImportProfileUseCase,
+	deleteImportProfileUseCase usecase.DeleteImportProfileUseCase,
+	getImportProfilesUseCase usecase.GetImportProfilesUseCase,
+	previewImportUseCase usecase.PreviewImportUseCase,
+	commitImportUseCase usecase.CommitImportUseCase,
+) *ImportController {
+	return &ImportController{
+		createImportProfileUseCase: createImportProfileUseCase,
+		updateImportProfileUseCase: updateImportProfileUseCase,
+		deleteImportProfileUseCase: deleteImportProfileUseCase,
+		getImportProfilesUseCase:   getImportProfilesUseCase,
+		previewImportUseCase:       previewImportUseCase,
+		commitImportUseCase:        commitImportUseCase,
+	}
+}
+
+// GetImportProfiles handles GET /api/v1/import-profiles
+func (c *ImportController) GetImportProfiles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	result := c.getImportProfilesUseCase.Execute(usecase.GetImportProfilesInput{
+		UserID: userID,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), http.StatusInternalServerError)
+		return
+	}
+
+	output, ok := result.(usecase.GetImportProfilesOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, output.Profiles)
+}
+
+// CreateImportProfile handles POST /api/v1/import-profiles
+// Body: {"name": "...", "mapping": {...}}; columns are header names or 1-based numbers.
+func (c *ImportController) CreateImportProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Name    string                    `json:"name"`
+		Mapping usecase.ImportMappingData `json:"mapping"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	result := c.createImportProfileUseCase.Execute(usecase.CreateImportProfileInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		Name:            req.Name,
+		Mapping:         req.Mapping,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), commandOutputStatus(result))
+		return
+	}
+
+	c.sendSuccess(w, http.StatusCreated, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// UpdateImportProfile handles PUT /api/v1/import-profiles/{profileID}
+// Replaces the name and the whole mapping.
+func (c *ImportController) UpdateImportProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	profileID := c.extractProfileID(r.URL.Path)
+	if profileID == "" {
+		c.sendError(w, "Invalid import profile ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Name    string                    `json:"name"`
+		Mapping usecase.ImportMappingData `json:"mapping"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	result := c.updateImportProfileUseCase.Execute(usecase.UpdateImportProfileInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		ProfileID:       profileID,
+		Name:            req.Name,
+		Mapping:         req.Mapping,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), commandOutputStatus(result))
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// DeleteImportProfile handles DELETE /api/v1/import-profiles/{profileID}
+func (c *ImportController) DeleteImportProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	profileID := c.extractProfileID(r.URL.Path)
+	if profileID == "" {
+		c.sendError(w, "Invalid import profile ID", http.StatusBadRequest)
+		return
+	}
+
+	result := c.deleteImportProfileUseCase.Execute(usecase.DeleteImportProfileInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		ProfileID:       profileID,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), commandOutputStatus(result))
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// PreviewImport handles POST /api/v1/imports/preview
+// Expects multipart/form-data with wallet_id, a file part and the fields read by
+// statementInput; nothing is recorded.
+func (c *ImportController) PreviewImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	file, header, ok := c.statementFile(w, r)
+	if !ok {
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+	defer file.Close()
+
+	result := c.previewImportUseCase.Execute(usecase.PreviewImportInput{
+		StatementFile: statementInput(r, file, header),
+		UserID:        userID,
+		WalletID:      r.FormValue("wallet_id"),
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), commandOutputStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.PreviewImportOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"rows":           output.Rows,
+		"skipped":        output.Skipped,
+		"rejected":       output.Rejected,
+		"statements":     output.Statements,
+		"total_expenses": output.TotalExpenses,
+		"total_incomes":  output.TotalIncomes,
+		"message":        output.Message,
+	})
+}
+
+// CommitImport handles POST /api/v1/imports/commit
+// Same form as the preview; the accepted rows are recorded in the wallet in one
+// save, and the skipped (imported before) and rejected rows are reported with
+// their line numbers.
+func (c *ImportController) CommitImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	file, header, ok := c.statementFile(w, r)
+	if !ok {
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+	defer file.Close()
+
+	result := c.commitImportUseCase.Execute(usecase.CommitImportInput{
+		CommandMetadata: commandMetadata(r),
+		StatementFile:   statementInput(r, file, header),
+		UserID:          userID,
+		WalletID:        r.FormValue("wallet_id"),
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), commandOutputStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.CommitImportOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"imported":   output.Imported,
+		"rows":       output.Rows,
+		"skipped":    output.Skipped,
+		"rejected":   output.Rejected,
+		"statements": output.Statements,
+		"message":    output.Message,
+	}
+	// Imported rows that look like duplicates of records already in the wallet
+	if len(output.Duplicates) > 0 {
+		response["duplicates"] = output.Duplicates
+	}
+
+	c.sendSuccess(w, http.StatusOK, response)
+}
+
+// Helper methods
+
+// statementFile parses the multipart form and returns its file part; on failure
+// the error response has been written
+func (c *ImportController) statementFile(w http.ResponseWriter, r *http.Request) (multipart.File, *multipart.FileHeader, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, statement.MaxFileSize+multipartOverhead)
+	if err := r.ParseMultipartForm(multipartOverhead); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.sendError(w, "Statement file too large", http.StatusRequestEntityTooLarge)
+		} else {
+			c.sendError(w, "Invalid multipart form", http.StatusBadRequest)
+		}
+		return nil, nil, false
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		r.MultipartForm.RemoveAll()
+		c.sendError(w, "file is required", http.StatusBadRequest)
+		return nil, nil, false
+	}
+	return file, header, true
+}
+
+// statementInput describes the uploaded file with the form fields: format (CSV,
+// OFX, QFX or QIF; from the file name when omitted), profile_id for CSV, and
+// expense_subcategory_id, income_subcategory_id and encoding (QIF) for OFX and QIF
+func statementInput(r *http.Request, file multipart.File, header *multipart.FileHeader) usecase.StatementFile {
+	return usecase.StatementFile{
+		Format:               r.FormValue("format"),
+		FileName:             header.Filename,
+		Content:              file,
+		ProfileID:            r.FormValue("profile_id"),
+		ExpenseSubcategoryID: r.FormValue("expense_subcategory_id"),
+		IncomeSubcategoryID:  r.FormValue("income_subcategory_id"),
+		Encoding:             r.FormValue("encoding"),
+	}
+}
+
+func (c *ImportController) extractProfileID(path string) string {
+	// Extract profile ID from paths like /api/v1/import-profiles/{profileID}
+	parts := strings.Split(strings.TrimPrefix(path, "/api/v1/import-profiles/"), "/")
+	if len(parts) > 0 && parts[0] != "" {
+		decoded, err := url.PathUnescape(parts[0])
+		if err != nil {
+			return parts[0]
+		}
+		return decoded
+	}
+	return ""
+}
+
+func (c *ImportController) sendSuccess(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *ImportController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// InitializeDefaultCategoriesController provisions a user's default categories from a template
+type InitializeDefaultCategoriesController struct {
+	initializeDefaultCategoriesUseCase usecase.InitializeDefaultCategoriesUseCase
+}
+
+// NewInitializeDefaultCategoriesController creates a new InitializeDefaultCategoriesController
+func NewInitializeDefaultCategoriesController(initializeDefaultCategoriesUseCase usecase.InitializeDefaultCategoriesUseCase) *InitializeDefaultCategoriesController {
+	return &InitializeDefaultCategoriesController{
+		initializeDefaultCategoriesUseCase: initializeDefaultCategoriesUseCase,
+	}
+}
+
+// InitializeDefaultCategories handles POST /api/v1/categories/defaults
+// Safe to call repeatedly: only categories the user does not have yet are created
+func (c *InitializeDefaultCategoriesController) InitializeDefaultCategories(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		UserID string `json:"user_id"`
+		Locale string `json:"locale,omitempty"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, req.UserID)
+	if !ok {
+		return
+	}
+
+	result := c.initializeDefaultCategoriesUseCase.Execute(usecase.InitializeDefaultCategoriesInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		Locale:          req.Locale,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	c.sendSuccess(w, map[string]interface{}{
+		"user_id": result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+func (c *InitializeDefaultCategoriesController) sendSuccess(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *InitializeDefaultCategoriesController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
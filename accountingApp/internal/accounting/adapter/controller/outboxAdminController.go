@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// OutboxAdminController lets administrators inspect and requeue stuck outbox messages
+type OutboxAdminController struct {
+	getOutboxMessagesUseCase  usecase.GetOutboxMessagesUseCase
+	retryOutboxMessageUseCase usecase.RetryOutboxMessageUseCase
+	adminUserIDs              map[string]bool
+}
+
+// NewOutboxAdminController creates a new OutboxAdminController; only the given users may call it
+func NewOutboxAdminController(
+	getOutboxMessagesUseCase usecase.GetOutboxMessagesUseCase,
+	retryOutboxMessageUseCase usecase.RetryOutboxMessageUseCase,
+	adminUserIDs []string,
+) *OutboxAdminController {
+	admins := make(map[string]bool, len(adminUserIDs))
+	for _, id := range adminUserIDs {
+		admins[id] = true
+	}
+	return &OutboxAdminController{
+		getOutboxMessagesUseCase:  getOutboxMessagesUseCase,
+		retryOutboxMessageUseCase: retryOutboxMessageUseCase,
+		adminUserIDs:              admins,
+	}
+}
+
+// GetOutboxMessages handles GET /api/v1/admin/outbox?status=&limit=
+// Without a status it returns stuck messages: dead letters and messages being retried.
+func (c *OutboxAdminController) GetOutboxMessages(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if _, ok := authenticatedAdmin(w, r, c.adminUserIDs); !ok {
+		return
+	}
+
+	limit := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			c.sendError(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	result := c.getOutboxMessagesUseCase.Execute(usecase.GetOutboxMessagesInput{
+		Status: r.URL.Query().Get("status"),
+		Limit:  limit,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.GetOutboxMessagesOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, output.Messages)
+}
+
+// RetryOutboxMessage handles POST /api/v1/admin/outbox/{messageID}/retry
+func (c *OutboxAdminController) RetryOutboxMessage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if _, ok := authenticatedAdmin(w, r, c.adminUserIDs); !ok {
+		return
+	}
+
+	messageID := c.extractMessageID(r.URL.Path)
+	if messageID == "" {
+		c.sendError(w, "Invalid outbox message ID", http.StatusBadRequest)
+		return
+	}
+
+	result := c.retryOutboxMessageUseCase.Execute(usecase.RetryOutboxMessageInput{
+		CommandMetadata: commandMetadata(r),
+		MessageID:       messageID,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), commandOutputStatus(result))
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// Helper methods
+func (c *OutboxAdminController) extractMessageID(path string) string {
+	// Extract message ID from paths like /api/v1/admin/outbox/{messageID}/retry
+	trimmed := strings.TrimPrefix(path, "/api/v1/admin/outbox/")
+	parts := strings.Split(trimmed, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "retry" {
+		return ""
+	}
+	decoded, err := url.PathUnescape(parts[0])
+	if err != nil {
+		return parts[0]
+	}
+	return decoded
+}
+
+func (c *OutboxAdminController) sendSuccess(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *OutboxAdminController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
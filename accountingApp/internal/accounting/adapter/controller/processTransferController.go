@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// ProcessTransferController handles transfers between wallets
+type ProcessTransferController struct {
+	processTransferUseCase usecase.ProcessTransferUseCase
+}
+
+// NewProcessTransferController creates a new ProcessTransferController
+func NewProcessTransferController(processTransferUseCase usecase.ProcessTransferUseCase) *ProcessTransferController {
+	return &ProcessTransferController{
+		processTransferUseCase: processTransferUseCase,
+	}
+}
+
+// ProcessTransfer handles POST /api/v1/transfers
+// Between wallets in different currencies the destination amount comes from to_amount,
+// rate, or the stored exchange rate of the transfer date; fee_currency may be either wallet's currency.
+func (c *ProcessTransferController) ProcessTransfer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		FromWalletID string    `json:"from_wallet_id"`
+		ToWalletID   string    `json:"to_wallet_id"`
+		Amount       int64     `json:"amount"`
+		Currency     string    `json:"currency"`
+		ToAmount     int64     `json:"to_amount"`
+		Rate         string    `json:"rate"`
+		Fee          int64     `json:"fee"`
+		FeeCurrency  string    `json:"fee_currency"`
+		Description  string    `json:"description"`
+		Date         time.Time `json:"date"`
+		Tags         []string  `json:"tags"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	// Validate required fields
+	if req.FromWalletID == "" {
+		c.sendError(w, "from_wallet_id is required", http.StatusBadRequest)
+		return
+	}
+	if req.ToWalletID == "" {
+		c.sendError(w, "to_wallet_id is required", http.StatusBadRequest)
+		return
+	}
+	if req.FromWalletID == req.ToWalletID {
+		c.sendError(w, "from_wallet_id and to_wallet_id must be different", http.StatusBadRequest)
+		return
+	}
+	if req.Amount <= 0 {
+		c.sendError(w, "amount must be positive", http.StatusBadRequest)
+		return
+	}
+	if req.ToAmount < 0 {
+		c.sendError(w, "to_amount must be positive", http.StatusBadRequest)
+		return
+	}
+	if req.Fee < 0 {
+		c.sendError(w, "fee cannot be negative", http.StatusBadRequest)
+		return
+	}
+	if req.Currency == "" {
+		c.sendError(w, "currency is required", http.StatusBadRequest)
+		return
+	}
+	if req.Date.IsZero() {
+		req.Date = time.Now()
+	}
+
+	input := usecase.ProcessTransferInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		FromWalletID:    req.FromWalletID,
+		ToWalletID:      req.ToWalletID,
+		Amount:          req.Amount,
+		Currency:        req.Currency,
+		ToAmount:        req.ToAmount,
+		Rate:            req.Rate,
+		Fee:             req.Fee,
+		FeeCurrency:     req.FeeCurrency,
+		Description:     req.Description,
+		Date:            req.Date,
+		Tags:            req.Tags,
+	}
+
+	output := c.processTransferUseCase.Execute(input)
+
+	w.Header().Set("Content-Type", "application/json")
+	if output.GetExitCode() != 0 {
+		w.WriteHeader(commandOutputStatus(output))
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"id":      output.GetID(),
+		"success": output.GetExitCode() == 0,
+		"message": output.GetMessage(),
+	})
+}
+
+// Helper methods
+func (c *ProcessTransferController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
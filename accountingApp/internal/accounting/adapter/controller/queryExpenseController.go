@@ -31,10 +31,9 @@ func (c *QueryExpenseController) GetExpenses(w http.ResponseWriter, r *http.Requ
 	// Extract query parameters
 	query := r.URL.Query()
 	
-	// For now, use a demo user ID (in production this would come from auth)
-	userID := "demo-user-123"
-	if queryUserID := query.Get("userID"); queryUserID != "" {
-		userID = queryUserID
+	userID, ok := authenticatedUser(w, r, query.Get("userID"))
+	if !ok {
+		return
 	}
 
 	input := usecase.GetExpensesInput{
@@ -78,13 +77,17 @@ func (c *QueryExpenseController) GetExpenses(w http.ResponseWriter, r *http.Requ
 		input.Description = &description
 	}
 
+	// tags=a,b matches any of the tags unless tagMatch=all
+	input.Tags = splitTagsParam(query.Get("tags"))
+	input.TagMatch = query.Get("tagMatch")
+
 	// Execute use case
 	output := c.getExpensesUseCase.Execute(input)
 
 	w.Header().Set("Content-Type", "application/json")
 	
 	if output.GetExitCode() != 0 {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(failureStatus(output))
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
 			"error":   output.GetMessage(),
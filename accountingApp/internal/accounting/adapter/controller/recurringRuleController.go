@@ -0,0 +1,413 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// RecurringRuleController handles the caller's recurring transaction rules
+// (rent, salary, subscriptions); the background scheduler generates the transactions.
+type RecurringRuleController struct {
+	createRecurringRuleUseCase     usecase.CreateRecurringRuleUseCase
+	pauseRecurringRuleUseCase      usecase.PauseRecurringRuleUseCase
+	resumeRecurringRuleUseCase     usecase.ResumeRecurringRuleUseCase
+	skipRecurringOccurrenceUseCase usecase.SkipRecurringOccurrenceUseCase
+	deleteRecurringRuleUseCase     usecase.DeleteRecurringRuleUseCase
+	getRecurringRulesUseCase       usecase.GetRecurringRulesUseCase
+	previewRecurringRuleUseCase    usecase.PreviewRecurringRuleUseCase
+}
+
+// NewRecurringRuleController creates a new RecurringRuleController
+func NewRecurringRuleController(
+	createRecurringRuleUseCase usecase.CreateRecurringRuleUseCase,
+	pauseRecurringRuleUseCase usecase.PauseRecurringRuleUseCase,
+	resumeRecurringRuleUseCase usecase.ResumeRecurringRuleUseCase,
+	skipRecurringOccurrenceUseCase usecase.SkipRecurringOccurrenceUseCase,
+	deleteRecurringRuleUseCase usecase.DeleteRecurringRuleUseCase,
+	getRecurringRulesUseCase usecase.GetRecurringRulesUseCase,
+	previewRecurringRuleUseCase usecase.PreviewRecurringRuleUseCase,
+) *RecurringRuleController {
+	return &RecurringRuleController{
+		createRecurringRuleUseCase:     createRecurringRuleUseCase,
+		pauseRecurringRuleUseCase:      pauseRecurringRuleUseCase,
+		resumeRecurringRuleUseCase:     resumeRecurringRuleUseCase,
+		skipRecurringOccurrenceUseCase: skipRecurringOccurrenceUseCase,
+		deleteRecurringRuleUseCase:     deleteRecurringRuleUseCase,
+		getRecurringRulesUseCase:       getRecurringRulesUseCase,
+		previewRecurringRuleUseCase:    previewRecurringRuleUseCase,
+	}
+}
+
+// GetRecurringRules handles GET /api/v1/recurring-rules
+func (c *RecurringRuleController) GetRecurringRules(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	result := c.getRecurringRulesUseCase.Execute(usecase.GetRecurringRulesInput{
+		UserID: userID,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), http.StatusInternalServerError)
+		return
+	}
+
+	output, ok := result.(usecase.GetRecurringRulesOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, output.Rules)
+}
+
+// CreateRecurringRule handles POST /api/v1/recurring-rules
+// Dates accept YYYY-MM-DD or RFC3339; end_date is inclusive and exclusive with count.
+func (c *RecurringRuleController) CreateRecurringRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Name            string `json:"name"`
+		Type            string `json:"type"`
+		WalletID        string `json:"wallet_id"`
+		SubcategoryID   string `json:"subcategory_id"`
+		Amount          int64  `json:"amount"`
+		Currency        string `json:"currency"`
+		Description     string `json:"description"`
+		Frequency       string `json:"frequency"`
+		Interval        int    `json:"interval"`
+		DayOfMonth      int    `json:"day_of_month"`
+		LastBusinessDay bool   `json:"last_business_day"`
+		StartDate       string `json:"start_date"`
+		EndDate         string `json:"end_date"`
+		Count           int    `json:"count"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// Validate required fields
+	if req.Name == "" {
+		c.sendError(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	if req.Type == "" {
+		c.sendError(w, "type is required", http.StatusBadRequest)
+		return
+	}
+	if req.WalletID == "" {
+		c.sendError(w, "wallet_id is required", http.StatusBadRequest)
+		return
+	}
+	if req.SubcategoryID == "" {
+		c.sendError(w, "subcategory_id is required", http.StatusBadRequest)
+		return
+	}
+	if req.Amount <= 0 {
+		c.sendError(w, "amount must be positive", http.StatusBadRequest)
+		return
+	}
+	if req.Currency == "" {
+		c.sendError(w, "currency is required", http.StatusBadRequest)
+		return
+	}
+	if req.Frequency == "" {
+		c.sendError(w, "frequency is required", http.StatusBadRequest)
+		return
+	}
+
+	startDate := time.Now()
+	if req.StartDate != "" {
+		parsed, err := parseBudgetDate(req.StartDate)
+		if err != nil {
+			c.sendError(w, "Invalid start_date: use YYYY-MM-DD or RFC3339", http.StatusBadRequest)
+			return
+		}
+		startDate = parsed
+	}
+	var endDate *time.Time
+	if req.EndDate != "" {
+		parsed, err := parseBudgetDate(req.EndDate)
+		if err != nil {
+			c.sendError(w, "Invalid end_date: use YYYY-MM-DD or RFC3339", http.StatusBadRequest)
+			return
+		}
+		endDate = &parsed
+	}
+
+	result := c.createRecurringRuleUseCase.Execute(usecase.CreateRecurringRuleInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		Name:            req.Name,
+		Type:            req.Type,
+		WalletID:        req.WalletID,
+		SubcategoryID:   req.SubcategoryID,
+		Amount:          req.Amount,
+		Currency:        req.Currency,
+		Description:     req.Description,
+		Frequency:       req.Frequency,
+		Interval:        req.Interval,
+		DayOfMonth:      req.DayOfMonth,
+		LastBusinessDay: req.LastBusinessDay,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		Count:           req.Count,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendCommandError(w, result)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusCreated, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// DeleteRecurringRule handles DELETE /api/v1/recurring-rules/{ruleID}
+// Transactions the rule already generated are kept.
+func (c *RecurringRuleController) DeleteRecurringRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ruleID, ok := c.ruleRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result := c.deleteRecurringRuleUseCase.Execute(usecase.DeleteRecurringRuleInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		RuleID:          ruleID,
+	})
+	c.sendCommandResult(w, result)
+}
+
+// PauseRecurringRule handles POST /api/v1/recurring-rules/{ruleID}/pause
+func (c *RecurringRuleController) PauseRecurringRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ruleID, ok := c.ruleRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result := c.pauseRecurringRuleUseCase.Execute(usecase.PauseRecurringRuleInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		RuleID:          ruleID,
+	})
+	c.sendCommandResult(w, result)
+}
+
+// ResumeRecurringRule handles POST /api/v1/recurring-rules/{ruleID}/resume
+// Occurrences that fell due while the rule was paused, up to yesterday, are skipped, not generated.
+func (c *RecurringRuleController) ResumeRecurringRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ruleID, ok := c.ruleRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result := c.resumeRecurringRuleUseCase.Execute(usecase.ResumeRecurringRuleInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		RuleID:          ruleID,
+	})
+	c.sendCommandResult(w, result)
+}
+
+// SkipRecurringOccurrence handles POST /api/v1/recurring-rules/{ruleID}/skip
+// Body: {"date": "YYYY-MM-DD"}
+func (c *RecurringRuleController) SkipRecurringOccurrence(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ruleID, ok := c.ruleRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Date string `json:"date"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if req.Date == "" {
+		c.sendError(w, "date is required", http.StatusBadRequest)
+		return
+	}
+	date, err := parseBudgetDate(req.Date)
+	if err != nil {
+		c.sendError(w, "Invalid date: use YYYY-MM-DD or RFC3339", http.StatusBadRequest)
+		return
+	}
+
+	result := c.skipRecurringOccurrenceUseCase.Execute(usecase.SkipRecurringOccurrenceInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		RuleID:          ruleID,
+		Date:            date,
+	})
+	c.sendCommandResult(w, result)
+}
+
+// PreviewRecurringRule handles GET /api/v1/recurring-rules/{ruleID}/preview[?from=YYYY-MM-DD&limit=N]
+// Lists upcoming occurrences from the given date (default today) with their status.
+func (c *RecurringRuleController) PreviewRecurringRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ruleID, ok := c.ruleRequest(w, r)
+	if !ok {
+		return
+	}
+
+	params := r.URL.Query()
+	var from time.Time
+	if fromStr := params.Get("from"); fromStr != "" {
+		parsed, err := parseBudgetDate(fromStr)
+		if err != nil {
+			c.sendError(w, "Invalid from: use YYYY-MM-DD or RFC3339", http.StatusBadRequest)
+			return
+		}
+		from = parsed
+	}
+	limit := 0
+	if limitStr := params.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			c.sendError(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	result := c.previewRecurringRuleUseCase.Execute(usecase.PreviewRecurringRuleInput{
+		UserID: userID,
+		RuleID: ruleID,
+		From:   from,
+		Limit:  limit,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendCommandError(w, result)
+		return
+	}
+
+	output, ok := result.(usecase.PreviewRecurringRuleOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"rule":        output.Rule,
+		"occurrences": output.Occurrences,
+	})
+}
+
+// Helper methods
+
+// ruleRequest authenticates the caller and extracts the rule ID from the path
+func (c *RecurringRuleController) ruleRequest(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return "", "", false
+	}
+
+	ruleID := c.extractRuleID(r.URL.Path)
+	if ruleID == "" {
+		c.sendError(w, "Invalid recurring rule ID", http.StatusBadRequest)
+		return "", "", false
+	}
+	return userID, ruleID, true
+}
+
+func (c *RecurringRuleController) extractRuleID(path string) string {
+	// Extract rule ID from paths like /api/v1/recurring-rules/{ruleID}[/pause]
+	parts := strings.Split(strings.TrimPrefix(path, "/api/v1/recurring-rules/"), "/")
+	if len(parts) > 0 && parts[0] != "" {
+		decoded, err := url.PathUnescape(parts[0])
+		if err != nil {
+			return parts[0]
+		}
+		return decoded
+	}
+	return ""
+}
+
+func (c *RecurringRuleController) sendCommandResult(w http.ResponseWriter, result common.Output) {
+	if result.GetExitCode() != common.Success {
+		c.sendCommandError(w, result)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// sendCommandError maps a failed recurring rule use case output to an HTTP status
+func (c *RecurringRuleController) sendCommandError(w http.ResponseWriter, output common.Output) {
+	c.sendError(w, output.GetMessage(), failureStatus(output))
+}
+
+func (c *RecurringRuleController) sendSuccess(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *RecurringRuleController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
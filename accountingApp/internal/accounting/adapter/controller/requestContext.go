@@ -0,0 +1,32 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+const requestIDContextKey contextKey = "requestID"
+
+// WithRequestID returns a copy of ctx carrying the request ID.
+// Only the request ID middleware should call this.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, requestID)
+}
+
+// RequestIDFromContext returns the request ID placed by the middleware, or ""
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey).(string)
+	return requestID
+}
+
+// commandMetadata identifies the caller and request for command inputs so that
+// the audit log can record who issued the command and from which request
+func commandMetadata(r *http.Request) usecase.CommandMetadata {
+	actorID, _ := UserIDFromContext(r.Context())
+	return usecase.CommandMetadata{
+		ActorID:   actorID,
+		RequestID: RequestIDFromContext(r.Context()),
+	}
+}
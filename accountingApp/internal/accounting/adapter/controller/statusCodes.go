@@ -0,0 +1,32 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+)
+
+// failureStatus maps the exit code of a failed use case output to an HTTP status.
+// NotFound is 404, which is also what callers get for resources that belong to
+// another user; InvalidInput is the caller's fault (400); Conflict means the
+// optimistic-lock retries were exhausted (409); anything else is 500.
+func failureStatus(output common.Output) int {
+	switch output.GetExitCode() {
+	case common.NotFound:
+		return http.StatusNotFound
+	case common.InvalidInput:
+		return http.StatusBadRequest
+	case common.Conflict:
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// commandOutputStatus is failureStatus for the commands that answered every
+// unclassified failure with 400 before the exit codes existed; they keep that behaviour.
+func commandOutputStatus(output common.Output) int {
+	if status := failureStatus(output); status != http.StatusInternalServerError {
+		return status
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// SuggestionController serves subcategory suggestions learned from the
+// caller's own transaction history
+type SuggestionController struct {
+	getCategorySuggestionsUseCase usecase.GetCategorySuggestionsUseCase
+}
+
+// NewSuggestionController creates a new SuggestionController
+func NewSuggestionController(getCategorySuggestionsUseCase usecase.GetCategorySuggestionsUseCase) *SuggestionController {
+	return &SuggestionController{
+		getCategorySuggestionsUseCase: getCategorySuggestionsUseCase,
+	}
+}
+
+// GetCategorySuggestions handles GET /api/v1/suggestions/category?description=...
+// Optional: type (EXPENSE or INCOME, default EXPENSE) and limit (default 3, at most 10).
+// An empty list means no past transaction resembles the description.
+func (c *SuggestionController) GetCategorySuggestions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	params := r.URL.Query()
+	limit := 0
+	if limitStr := params.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			c.sendError(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	result := c.getCategorySuggestionsUseCase.Execute(usecase.GetCategorySuggestionsInput{
+		UserID:      userID,
+		Description: params.Get("description"),
+		Type:        strings.ToUpper(params.Get("type")),
+		Limit:       limit,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.GetCategorySuggestionsOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, output.Suggestions)
+}
+
+// Helper methods
+
+func (c *SuggestionController) sendSuccess(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *SuggestionController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
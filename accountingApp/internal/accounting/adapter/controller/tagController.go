@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// TagController handles bulk tag edits and the per-tag summary report
+type TagController struct {
+	editTransactionTagsUseCase usecase.EditTransactionTagsUseCase
+	getTagSummaryUseCase       usecase.GetTagSummaryUseCase
+}
+
+// NewTagController creates a new TagController
+func NewTagController(
+	editTransactionTagsUseCase usecase.EditTransactionTagsUseCase,
+	getTagSummaryUseCase usecase.GetTagSummaryUseCase,
+) *TagController {
+	return &TagController{
+		editTransactionTagsUseCase: editTransactionTagsUseCase,
+		getTagSummaryUseCase:       getTagSummaryUseCase,
+	}
+}
+
+// EditTransactionTags handles POST /api/v1/tags/bulk
+// Adds and removes tags on the listed expenses, incomes and transfers; the
+// whole batch fails if any transaction is not found.
+func (c *TagController) EditTransactionTags(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	var req struct {
+		ExpenseIDs  []string `json:"expense_ids"`
+		IncomeIDs   []string `json:"income_ids"`
+		TransferIDs []string `json:"transfer_ids"`
+		Add         []string `json:"add"`
+		Remove      []string `json:"remove"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	result := c.editTransactionTagsUseCase.Execute(usecase.EditTransactionTagsInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		ExpenseIDs:      req.ExpenseIDs,
+		IncomeIDs:       req.IncomeIDs,
+		TransferIDs:     req.TransferIDs,
+		Add:             req.Add,
+		Remove:          req.Remove,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), commandOutputStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.EditTransactionTagsOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	c.sendSuccess(w, http.StatusOK, map[string]interface{}{
+		"updated": output.Updated,
+		"message": output.Message,
+	})
+}
+
+// GetTagSummary handles GET /api/v1/tags/summary[?startDate=&endDate=&tags=a,b&reportingCurrency=]
+// Dates are YYYY-MM-DD and inclusive; totals are reported per tag and currency.
+// A reporting currency adds the totals converted with the rates of endDate (or today).
+func (c *TagController) GetTagSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	input := usecase.GetTagSummaryInput{
+		UserID:            userID,
+		Tags:              splitTagsParam(query.Get("tags")),
+		ReportingCurrency: query.Get("reportingCurrency"),
+	}
+
+	if startDateStr := query.Get("startDate"); startDateStr != "" {
+		startDate, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			c.sendError(w, "Invalid startDate: use YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		input.StartDate = &startDate
+	}
+
+	if endDateStr := query.Get("endDate"); endDateStr != "" {
+		endDate, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			c.sendError(w, "Invalid endDate: use YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		// Include the whole end day
+		endOfDay := endDate.Add(24*time.Hour - time.Nanosecond)
+		input.EndDate = &endOfDay
+	}
+
+	result := c.getTagSummaryUseCase.Execute(input)
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	output, ok := result.(usecase.GetTagSummaryOutput)
+	if !ok {
+		c.sendError(w, "Internal error: invalid output type", http.StatusInternalServerError)
+		return
+	}
+
+	if output.ReportingCurrency == "" {
+		c.sendSuccess(w, http.StatusOK, output.Tags)
+		return
+	}
+
+	// With a reporting currency the per-tag totals in that currency are
+	// reported next to the rows
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    output.Tags,
+		"reporting": map[string]interface{}{
+			"currency": output.ReportingCurrency,
+			"totals":   output.ReportingTotals,
+		},
+	})
+}
+
+// splitTagsParam splits a comma-separated tags query parameter, skipping empty items
+func splitTagsParam(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func (c *TagController) sendSuccess(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *TagController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
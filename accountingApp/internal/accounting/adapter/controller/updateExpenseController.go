@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// UpdateExpenseController handles expense record corrections
+type UpdateExpenseController struct {
+	updateExpenseUseCase usecase.UpdateExpenseUseCase
+}
+
+// NewUpdateExpenseController creates a new UpdateExpenseController
+func NewUpdateExpenseController(updateExpenseUseCase usecase.UpdateExpenseUseCase) *UpdateExpenseController {
+	return &UpdateExpenseController{
+		updateExpenseUseCase: updateExpenseUseCase,
+	}
+}
+
+// UpdateExpense handles PUT /api/v1/expenses/{expenseID}
+func (c *UpdateExpenseController) UpdateExpense(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	expenseID := c.extractExpenseID(r.URL.Path)
+	if expenseID == "" {
+		c.sendError(w, "Invalid expense ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		SubcategoryID string                `json:"subcategory_id"`
+		Amount        int64                 `json:"amount"`
+		Currency      string                `json:"currency"`
+		Description   string                `json:"description"`
+		Date          time.Time             `json:"date"`
+		Splits        []expenseSplitRequest `json:"splits"` // Replaces the split lines; omit to record a single subcategory
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// Validate required fields
+	if req.SubcategoryID == "" && len(req.Splits) == 0 {
+		c.sendError(w, "subcategory_id is required", http.StatusBadRequest)
+		return
+	}
+	if req.Amount <= 0 {
+		c.sendError(w, "amount must be positive", http.StatusBadRequest)
+		return
+	}
+	if req.Currency == "" {
+		c.sendError(w, "currency is required", http.StatusBadRequest)
+		return
+	}
+
+	result := c.updateExpenseUseCase.Execute(usecase.UpdateExpenseInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		ExpenseID:       expenseID,
+		SubcategoryID:   req.SubcategoryID,
+		Amount:          req.Amount,
+		Currency:        req.Currency,
+		Description:     req.Description,
+		Date:            req.Date,
+		Splits:          toExpenseSplitInputs(req.Splits),
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	c.sendSuccess(w, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// Helper methods
+func (c *UpdateExpenseController) extractExpenseID(path string) string {
+	// Extract expense ID from paths like /api/v1/expenses/{expenseID}
+	parts := strings.Split(strings.TrimPrefix(path, "/api/v1/expenses/"), "/")
+	if len(parts) > 0 && parts[0] != "" {
+		// URL decode in case there are special characters
+		decoded, err := url.QueryUnescape(parts[0])
+		if err != nil {
+			return parts[0] // fallback to original if decode fails
+		}
+		return decoded
+	}
+	return ""
+}
+
+func (c *UpdateExpenseController) sendSuccess(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *UpdateExpenseController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// UpdateIncomeController handles income record corrections
+type UpdateIncomeController struct {
+	updateIncomeUseCase usecase.UpdateIncomeUseCase
+}
+
+// NewUpdateIncomeController creates a new UpdateIncomeController
+func NewUpdateIncomeController(updateIncomeUseCase usecase.UpdateIncomeUseCase) *UpdateIncomeController {
+	return &UpdateIncomeController{
+		updateIncomeUseCase: updateIncomeUseCase,
+	}
+}
+
+// UpdateIncome handles PUT /api/v1/incomes/{incomeID}
+func (c *UpdateIncomeController) UpdateIncome(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		c.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
+	incomeID := c.extractIncomeID(r.URL.Path)
+	if incomeID == "" {
+		c.sendError(w, "Invalid income ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		SubcategoryID string    `json:"subcategory_id"`
+		Amount        int64     `json:"amount"`
+		Currency      string    `json:"currency"`
+		Description   string    `json:"description"`
+		Date          time.Time `json:"date"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// Validate required fields
+	if req.SubcategoryID == "" {
+		c.sendError(w, "subcategory_id is required", http.StatusBadRequest)
+		return
+	}
+	if req.Amount <= 0 {
+		c.sendError(w, "amount must be positive", http.StatusBadRequest)
+		return
+	}
+	if req.Currency == "" {
+		c.sendError(w, "currency is required", http.StatusBadRequest)
+		return
+	}
+
+	result := c.updateIncomeUseCase.Execute(usecase.UpdateIncomeInput{
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		IncomeID:        incomeID,
+		SubcategoryID:   req.SubcategoryID,
+		Amount:          req.Amount,
+		Currency:        req.Currency,
+		Description:     req.Description,
+		Date:            req.Date,
+	})
+
+	if result.GetExitCode() != common.Success {
+		c.sendError(w, result.GetMessage(), failureStatus(result))
+		return
+	}
+
+	c.sendSuccess(w, map[string]interface{}{
+		"id":      result.GetID(),
+		"message": result.GetMessage(),
+	})
+}
+
+// Helper methods
+func (c *UpdateIncomeController) extractIncomeID(path string) string {
+	// Extract income ID from paths like /api/v1/incomes/{incomeID}
+	parts := strings.Split(strings.TrimPrefix(path, "/api/v1/incomes/"), "/")
+	if len(parts) > 0 && parts[0] != "" {
+		// URL decode in case there are special characters
+		decoded, err := url.QueryUnescape(parts[0])
+		if err != nil {
+			return parts[0] // fallback to original if decode fails
+		}
+		return decoded
+	}
+	return ""
+}
+
+func (c *UpdateIncomeController) sendSuccess(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func (c *UpdateIncomeController) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
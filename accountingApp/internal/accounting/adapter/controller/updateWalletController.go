@@ -29,6 +29,11 @@ func (c *UpdateWalletController) UpdateWallet(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	userID, ok := authenticatedUser(w, r, "")
+	if !ok {
+		return
+	}
+
 	walletID := c.extractWalletID(r.URL.Path)
 	if walletID == "" {
 		c.sendError(w, "Invalid wallet ID", http.StatusBadRequest)
@@ -71,21 +76,16 @@ func (c *UpdateWalletController) UpdateWallet(w http.ResponseWriter, r *http.Req
 	}
 
 	result := c.updateWalletUseCase.Execute(usecase.UpdateWalletInput{
-		WalletID: walletID,
-		Name:     name,
-		Type:     walletType,
-		Currency: currency,
+		CommandMetadata: commandMetadata(r),
+		UserID:          userID,
+		WalletID:        walletID,
+		Name:            name,
+		Type:            walletType,
+		Currency:        currency,
 	})
 
 	if result.GetExitCode() != common.Success {
-		message := result.GetMessage()
-		if message == "Wallet not found" {
-			c.sendError(w, message, http.StatusNotFound)
-		} else if strings.Contains(message, "Invalid") {
-			c.sendError(w, message, http.StatusBadRequest)
-		} else {
-			c.sendError(w, message, http.StatusInternalServerError)
-		}
+		c.sendError(w, result.GetMessage(), failureStatus(result))
 		return
 	}
 
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+)
+
+// PgAPIKeyRepositoryPeerAdapter API金鑰的 Layer 3 (Adapter) 實現
+type PgAPIKeyRepositoryPeerAdapter struct {
+	apiKeyStore store.QueryAggregateStore[mapper.APIKeyData]
+}
+
+// NewPgAPIKeyRepositoryPeerAdapter 創建PostgreSQL API金鑰儲存實現
+func NewPgAPIKeyRepositoryPeerAdapter(apiKeyStore store.QueryAggregateStore[mapper.APIKeyData]) repository.APIKeyRepositoryPeer {
+	return &PgAPIKeyRepositoryPeerAdapter{apiKeyStore: apiKeyStore}
+}
+
+// SaveData 儲存API金鑰資料
+func (p *PgAPIKeyRepositoryPeerAdapter) SaveData(data mapper.APIKeyData) error {
+	return p.apiKeyStore.Save(data)
+}
+
+// FindDataByID 根據ID查找API金鑰，找不到時回傳 (nil, nil)
+func (p *PgAPIKeyRepositoryPeerAdapter) FindDataByID(id string) (*mapper.APIKeyData, error) {
+	return p.apiKeyStore.FindByID(id)
+}
+
+// FindDataByKeyHash 根據金鑰雜湊查找API金鑰，找不到時回傳 (nil, nil)
+func (p *PgAPIKeyRepositoryPeerAdapter) FindDataByKeyHash(keyHash string) (*mapper.APIKeyData, error) {
+	keys, err := p.apiKeyStore.FindBy(map[string]interface{}{
+		"key_hash": keyHash,
+	})
+	if err != nil || len(keys) == 0 {
+		return nil, err
+	}
+	return &keys[0], nil
+}
+
+// FindDataByUserID 根據用戶ID查找所有API金鑰
+func (p *PgAPIKeyRepositoryPeerAdapter) FindDataByUserID(userID string) ([]mapper.APIKeyData, error) {
+	return p.apiKeyStore.FindBy(map[string]interface{}{
+		"user_id": userID,
+	})
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"sort"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+)
+
+// PgAttachmentRepositoryPeerAdapter 附件中繼資料的 Layer 3 (Adapter) 實現
+type PgAttachmentRepositoryPeerAdapter struct {
+	attachmentStore store.QueryAggregateStore[mapper.AttachmentData]
+}
+
+// NewPgAttachmentRepositoryPeerAdapter 創建PostgreSQL附件儲存實現
+func NewPgAttachmentRepositoryPeerAdapter(attachmentStore store.QueryAggregateStore[mapper.AttachmentData]) repository.AttachmentRepositoryPeer {
+	return &PgAttachmentRepositoryPeerAdapter{attachmentStore: attachmentStore}
+}
+
+// SaveData 儲存附件資料
+func (p *PgAttachmentRepositoryPeerAdapter) SaveData(data mapper.AttachmentData) error {
+	return p.attachmentStore.Save(data)
+}
+
+// FindDataByID 根據ID查找附件，找不到時回傳 (nil, nil)
+func (p *PgAttachmentRepositoryPeerAdapter) FindDataByID(id string) (*mapper.AttachmentData, error) {
+	return p.attachmentStore.FindByID(id)
+}
+
+// FindDataByRecord 根據交易記錄查找所有附件，依上傳時間排序
+func (p *PgAttachmentRepositoryPeerAdapter) FindDataByRecord(recordType, recordID string) ([]mapper.AttachmentData, error) {
+	attachments, err := p.attachmentStore.FindBy(map[string]interface{}{
+		"record_type": recordType,
+		"record_id":   recordID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(attachments, func(i, j int) bool {
+		return attachments[i].CreatedAt.Before(attachments[j].CreatedAt)
+	})
+	return attachments, nil
+}
+
+// FindDataByWalletID 根據錢包ID查找所有附件
+func (p *PgAttachmentRepositoryPeerAdapter) FindDataByWalletID(walletID string) ([]mapper.AttachmentData, error) {
+	return p.attachmentStore.FindBy(map[string]interface{}{
+		"wallet_id": walletID,
+	})
+}
+
+// DeleteData 根據ID刪除附件
+func (p *PgAttachmentRepositoryPeerAdapter) DeleteData(id string) error {
+	return p.attachmentStore.Delete(id)
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/database"
+)
+
+// PgAuditLogRepository 以 audit_log 資料表實作 AuditLogRepository
+// 資料表由觸發器禁止 UPDATE/DELETE，這裡也只會 INSERT
+type PgAuditLogRepository struct {
+	dbClient database.DatabaseClient
+}
+
+// NewPgAuditLogRepository 創建PostgreSQL稽核紀錄儲存庫
+func NewPgAuditLogRepository(dbClient database.DatabaseClient) repository.AuditLogRepository {
+	return &PgAuditLogRepository{dbClient: dbClient}
+}
+
+// Append 在獨立的交易中寫入一筆紀錄 (錢包的紀錄由Peer在儲存錢包的交易中寫入)
+func (r *PgAuditLogRepository) Append(entry mapper.AuditEntryData) (*mapper.AuditEntryData, error) {
+	tx, err := r.dbClient.BeginTx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var chained *mapper.AuditEntryData
+	if chained, err = appendAuditEntry(tx, entry); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit audit entry: %w", err)
+	}
+	return chained, nil
+}
+
+// appendAuditEntry 在交易中鎖定資料表、讀取鏈尾並寫入新紀錄
+// EXCLUSIVE 鎖讓同時寫入的請求依序取得鏈尾，但不阻擋查詢；鎖到交易結束才釋放，呼叫端應在提交前最後才寫入
+func appendAuditEntry(tx database.Transaction, entry mapper.AuditEntryData) (*mapper.AuditEntryData, error) {
+	if _, err := tx.Exec("LOCK TABLE audit_log IN EXCLUSIVE MODE"); err != nil {
+		return nil, fmt.Errorf("failed to lock audit log: %w", err)
+	}
+
+	previous, err := lastAuditEntry(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log tail: %w", err)
+	}
+
+	chained := mapper.ChainAuditEntry(entry, previous)
+	placeholders := make([]string, len(database.AuditLogColumns))
+	for i := range placeholders {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	query := fmt.Sprintf("INSERT INTO audit_log (%s) VALUES (%s)",
+		strings.Join(database.AuditLogColumns, ", "), strings.Join(placeholders, ", "))
+	if _, err := tx.Exec(query, database.AuditEntryValues(chained)...); err != nil {
+		return nil, fmt.Errorf("failed to append audit entry: %w", err)
+	}
+	return &chained, nil
+}
+
+// Find 依條件查詢，依序號由新到舊排序
+func (r *PgAuditLogRepository) Find(filter repository.AuditLogFilter) ([]mapper.AuditEntryData, error) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.ActorID != "" {
+		addCondition("actor_id = $%d", filter.ActorID)
+	}
+	if filter.Command != "" {
+		addCondition("command = $%d", filter.Command)
+	}
+	if filter.AggregateType != "" {
+		addCondition("aggregate_type = $%d", filter.AggregateType)
+	}
+	if filter.AggregateID != "" {
+		addCondition("aggregate_id = $%d", filter.AggregateID)
+	}
+	if filter.RequestID != "" {
+		addCondition("request_id = $%d", filter.RequestID)
+	}
+	if filter.From != nil {
+		addCondition("occurred_at >= $%d", filter.From.UTC())
+	}
+	if filter.To != nil {
+		addCondition("occurred_at < $%d", filter.To.UTC())
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM audit_log", strings.Join(database.AuditLogColumns, ", "))
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY sequence DESC LIMIT $%d", len(args))
+
+	return queryAuditEntries(r.dbClient, query, args...)
+}
+
+// FindAfterSequence 依序號由舊到新取得序號大於afterSequence的紀錄
+func (r *PgAuditLogRepository) FindAfterSequence(afterSequence int64, limit int) ([]mapper.AuditEntryData, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM audit_log
+		WHERE sequence > $1
+		ORDER BY sequence
+		LIMIT $2
+	`, strings.Join(database.AuditLogColumns, ", "))
+
+	return queryAuditEntries(r.dbClient, query, afterSequence, limit)
+}
+
+// lastAuditEntry 取得鏈尾紀錄，沒有紀錄時回傳nil
+func lastAuditEntry(client database.DatabaseClient) (*mapper.AuditEntryData, error) {
+	query := fmt.Sprintf("SELECT %s FROM audit_log ORDER BY sequence DESC LIMIT 1",
+		strings.Join(database.AuditLogColumns, ", "))
+	entries, err := queryAuditEntries(client, query)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	return &entries[0], nil
+}
+
+func queryAuditEntries(client database.DatabaseClient, query string, args ...interface{}) ([]mapper.AuditEntryData, error) {
+	rows, err := client.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []mapper.AuditEntryData
+	for rows.Next() {
+		entry, err := database.ScanAuditEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entries = append(entries, *entry)
+	}
+	return entries, nil
+}
+
+// 確保PgAuditLogRepository實現AuditLogRepository介面
+var _ repository.AuditLogRepository = (*PgAuditLogRepository)(nil)
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+)
+
+// PgBudgetRepositoryPeerAdapter 預算的 Layer 3 (Adapter) 實現
+type PgBudgetRepositoryPeerAdapter struct {
+	budgetStore store.QueryAggregateStore[mapper.BudgetData]
+}
+
+// NewPgBudgetRepositoryPeerAdapter 創建PostgreSQL預算儲存實現
+func NewPgBudgetRepositoryPeerAdapter(budgetStore store.QueryAggregateStore[mapper.BudgetData]) repository.BudgetRepositoryPeer {
+	return &PgBudgetRepositoryPeerAdapter{budgetStore: budgetStore}
+}
+
+// SaveData 儲存預算資料
+func (p *PgBudgetRepositoryPeerAdapter) SaveData(data mapper.BudgetData) error {
+	return p.budgetStore.Save(data)
+}
+
+// FindDataByID 根據ID查找預算，找不到時回傳 (nil, nil)
+func (p *PgBudgetRepositoryPeerAdapter) FindDataByID(id string) (*mapper.BudgetData, error) {
+	return p.budgetStore.FindByID(id)
+}
+
+// FindDataByUserID 根據用戶ID查找所有預算
+func (p *PgBudgetRepositoryPeerAdapter) FindDataByUserID(userID string) ([]mapper.BudgetData, error) {
+	return p.budgetStore.FindBy(map[string]interface{}{
+		"user_id": userID,
+	})
+}
+
+// DeleteData 根據ID刪除預算
+func (p *PgBudgetRepositoryPeerAdapter) DeleteData(id string) error {
+	return p.budgetStore.Delete(id)
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+)
+
+// PgCategorizationRuleRepositoryPeerAdapter 分類規則的 Layer 3 (Adapter) 實現
+type PgCategorizationRuleRepositoryPeerAdapter struct {
+	ruleStore store.QueryAggregateStore[mapper.CategorizationRuleData]
+}
+
+// NewPgCategorizationRuleRepositoryPeerAdapter 創建PostgreSQL分類規則儲存實現
+func NewPgCategorizationRuleRepositoryPeerAdapter(ruleStore store.QueryAggregateStore[mapper.CategorizationRuleData]) repository.CategorizationRuleRepositoryPeer {
+	return &PgCategorizationRuleRepositoryPeerAdapter{ruleStore: ruleStore}
+}
+
+// SaveData 儲存分類規則資料
+func (p *PgCategorizationRuleRepositoryPeerAdapter) SaveData(data mapper.CategorizationRuleData) error {
+	return p.ruleStore.Save(data)
+}
+
+// FindDataByID 根據ID查找分類規則，找不到時回傳 (nil, nil)
+func (p *PgCategorizationRuleRepositoryPeerAdapter) FindDataByID(id string) (*mapper.CategorizationRuleData, error) {
+	return p.ruleStore.FindByID(id)
+}
+
+// FindDataByUserID 根據用戶ID查找所有分類規則
+func (p *PgCategorizationRuleRepositoryPeerAdapter) FindDataByUserID(userID string) ([]mapper.CategorizationRuleData, error) {
+	return p.ruleStore.FindBy(map[string]interface{}{
+		"user_id": userID,
+	})
+}
+
+// DeleteData 根據ID刪除分類規則
+func (p *PgCategorizationRuleRepositoryPeerAdapter) DeleteData(id string) error {
+	return p.ruleStore.Delete(id)
+}
@@ -0,0 +1,310 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/database"
+)
+
+// PgExpenseCategoryRepositoryPeerAdapter 支出分類的 Layer 3 (Adapter) 實現
+// 分類主體透過QueryAggregateStore存取，子分類在同一事務中同步至 expense_subcategories
+type PgExpenseCategoryRepositoryPeerAdapter struct {
+	categoryStore store.QueryAggregateStore[mapper.ExpenseCategoryData]
+	dbClient      database.DatabaseClient
+}
+
+// NewPgExpenseCategoryRepositoryPeerAdapter 創建PostgreSQL支出分類儲存實現
+func NewPgExpenseCategoryRepositoryPeerAdapter(
+	categoryStore store.QueryAggregateStore[mapper.ExpenseCategoryData],
+	dbClient database.DatabaseClient,
+) repository.ExpenseCategoryRepositoryPeer {
+	return &PgExpenseCategoryRepositoryPeerAdapter{
+		categoryStore: categoryStore,
+		dbClient:      dbClient,
+	}
+}
+
+// SaveData 在事務中儲存分類主體並同步其子分類
+func (p *PgExpenseCategoryRepositoryPeerAdapter) SaveData(data mapper.ExpenseCategoryData) error {
+	subcategories := make([]subcategoryRow, len(data.Subcategories))
+	for i, subcategory := range data.Subcategories {
+		subcategories[i] = subcategoryRow{ID: subcategory.ID, Name: subcategory.Name}
+	}
+
+	return saveCategoryWithTransaction(p.dbClient, "expense_categories", "expense_subcategories",
+		categoryRow{ID: data.ID, UserID: data.UserID, Name: data.Name, CreatedAt: data.CreatedAt, UpdatedAt: data.UpdatedAt},
+		subcategories)
+}
+
+// FindDataByID 根據ID查找分類並載入其子分類，找不到時回傳 (nil, nil)
+func (p *PgExpenseCategoryRepositoryPeerAdapter) FindDataByID(id string) (*mapper.ExpenseCategoryData, error) {
+	data, err := p.categoryStore.FindByID(id)
+	if err != nil || data == nil {
+		return data, err
+	}
+
+	if err := p.loadSubcategories(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// FindDataBySubcategoryID 根據子分類ID查找所屬分類，找不到時回傳 (nil, nil)
+func (p *PgExpenseCategoryRepositoryPeerAdapter) FindDataBySubcategoryID(subcategoryID string) (*mapper.ExpenseCategoryData, error) {
+	parentID, err := findSubcategoryParentID(p.dbClient, "expense_subcategories", subcategoryID)
+	if err != nil || parentID == "" {
+		return nil, err
+	}
+
+	return p.FindDataByID(parentID)
+}
+
+// FindDataByUserID 根據用戶ID查找所有分類並載入其子分類
+func (p *PgExpenseCategoryRepositoryPeerAdapter) FindDataByUserID(userID string) ([]mapper.ExpenseCategoryData, error) {
+	categories, err := p.categoryStore.FindBy(map[string]interface{}{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range categories {
+		if err := p.loadSubcategories(&categories[i]); err != nil {
+			return nil, err
+		}
+	}
+	return categories, nil
+}
+
+// DeleteData 刪除分類，子分類由資料庫 ON DELETE CASCADE 一併刪除
+func (p *PgExpenseCategoryRepositoryPeerAdapter) DeleteData(id string) error {
+	return p.categoryStore.Delete(id)
+}
+
+func (p *PgExpenseCategoryRepositoryPeerAdapter) loadSubcategories(data *mapper.ExpenseCategoryData) error {
+	rows, err := loadSubcategoryRows(p.dbClient, "expense_subcategories", data.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load subcategories for category %s: %w", data.ID, err)
+	}
+
+	data.Subcategories = make([]mapper.ExpenseSubcategoryData, len(rows))
+	for i, row := range rows {
+		data.Subcategories[i] = mapper.ExpenseSubcategoryData{ID: row.ID, Name: row.Name, ParentID: data.ID}
+	}
+	return nil
+}
+
+// PgIncomeCategoryRepositoryPeerAdapter 收入分類的 Layer 3 (Adapter) 實現
+// 分類主體透過QueryAggregateStore存取，子分類在同一事務中同步至 income_subcategories
+type PgIncomeCategoryRepositoryPeerAdapter struct {
+	categoryStore store.QueryAggregateStore[mapper.IncomeCategoryData]
+	dbClient      database.DatabaseClient
+}
+
+// NewPgIncomeCategoryRepositoryPeerAdapter 創建PostgreSQL收入分類儲存實現
+func NewPgIncomeCategoryRepositoryPeerAdapter(
+	categoryStore store.QueryAggregateStore[mapper.IncomeCategoryData],
+	dbClient database.DatabaseClient,
+) repository.IncomeCategoryRepositoryPeer {
+	return &PgIncomeCategoryRepositoryPeerAdapter{
+		categoryStore: categoryStore,
+		dbClient:      dbClient,
+	}
+}
+
+// SaveData 在事務中儲存分類主體並同步其子分類
+func (p *PgIncomeCategoryRepositoryPeerAdapter) SaveData(data mapper.IncomeCategoryData) error {
+	subcategories := make([]subcategoryRow, len(data.Subcategories))
+	for i, subcategory := range data.Subcategories {
+		subcategories[i] = subcategoryRow{ID: subcategory.ID, Name: subcategory.Name}
+	}
+
+	return saveCategoryWithTransaction(p.dbClient, "income_categories", "income_subcategories",
+		categoryRow{ID: data.ID, UserID: data.UserID, Name: data.Name, CreatedAt: data.CreatedAt, UpdatedAt: data.UpdatedAt},
+		subcategories)
+}
+
+// FindDataByID 根據ID查找分類並載入其子分類，找不到時回傳 (nil, nil)
+func (p *PgIncomeCategoryRepositoryPeerAdapter) FindDataByID(id string) (*mapper.IncomeCategoryData, error) {
+	data, err := p.categoryStore.FindByID(id)
+	if err != nil || data == nil {
+		return data, err
+	}
+
+	if err := p.loadSubcategories(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// FindDataBySubcategoryID 根據子分類ID查找所屬分類，找不到時回傳 (nil, nil)
+func (p *PgIncomeCategoryRepositoryPeerAdapter) FindDataBySubcategoryID(subcategoryID string) (*mapper.IncomeCategoryData, error) {
+	parentID, err := findSubcategoryParentID(p.dbClient, "income_subcategories", subcategoryID)
+	if err != nil || parentID == "" {
+		return nil, err
+	}
+
+	return p.FindDataByID(parentID)
+}
+
+// FindDataByUserID 根據用戶ID查找所有分類並載入其子分類
+func (p *PgIncomeCategoryRepositoryPeerAdapter) FindDataByUserID(userID string) ([]mapper.IncomeCategoryData, error) {
+	categories, err := p.categoryStore.FindBy(map[string]interface{}{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range categories {
+		if err := p.loadSubcategories(&categories[i]); err != nil {
+			return nil, err
+		}
+	}
+	return categories, nil
+}
+
+// DeleteData 刪除分類，子分類由資料庫 ON DELETE CASCADE 一併刪除
+func (p *PgIncomeCategoryRepositoryPeerAdapter) DeleteData(id string) error {
+	return p.categoryStore.Delete(id)
+}
+
+func (p *PgIncomeCategoryRepositoryPeerAdapter) loadSubcategories(data *mapper.IncomeCategoryData) error {
+	rows, err := loadSubcategoryRows(p.dbClient, "income_subcategories", data.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load subcategories for category %s: %w", data.ID, err)
+	}
+
+	data.Subcategories = make([]mapper.IncomeSubcategoryData, len(rows))
+	for i, row := range rows {
+		data.Subcategories[i] = mapper.IncomeSubcategoryData{ID: row.ID, Name: row.Name, ParentID: data.ID}
+	}
+	return nil
+}
+
+// categoryRow 支出與收入分類共用的主體欄位
+type categoryRow struct {
+	ID        string
+	UserID    string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// subcategoryRow 支出與收入子分類共用的欄位
+type subcategoryRow struct {
+	ID   string
+	Name string
+}
+
+// saveCategoryWithTransaction 在同一事務中upsert分類主體，並讓子分類表與聚合內容一致
+func saveCategoryWithTransaction(dbClient database.DatabaseClient, categoryTable, subcategoryTable string, category categoryRow, subcategories []subcategoryRow) error {
+	tx, err := dbClient.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// 1. 保存分類主體
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, user_id, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			updated_at = EXCLUDED.updated_at
+	`, categoryTable)
+	_, err = tx.Exec(query, category.ID, category.UserID, category.Name, category.CreatedAt, category.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save category: %w", err)
+	}
+
+	// 2. 刪除已從聚合中移除的子分類
+	err = deleteRemovedSubcategories(tx, subcategoryTable, category.ID, subcategories)
+	if err != nil {
+		return fmt.Errorf("failed to delete removed subcategories: %w", err)
+	}
+
+	// 3. 新增或更新其餘子分類
+	query = fmt.Sprintf(`
+		INSERT INTO %s (id, parent_id, name)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name
+	`, subcategoryTable)
+	for _, subcategory := range subcategories {
+		_, err = tx.Exec(query, subcategory.ID, category.ID, subcategory.Name)
+		if err != nil {
+			return fmt.Errorf("failed to save subcategory %s: %w", subcategory.ID, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// deleteRemovedSubcategories 刪除此分類下不在保留清單中的子分類
+func deleteRemovedSubcategories(tx database.Transaction, table, parentID string, kept []subcategoryRow) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE parent_id = $1", table)
+	args := []interface{}{parentID}
+
+	if len(kept) > 0 {
+		placeholders := make([]string, len(kept))
+		for i, subcategory := range kept {
+			placeholders[i] = fmt.Sprintf("$%d", i+2)
+			args = append(args, subcategory.ID)
+		}
+		query += fmt.Sprintf(" AND id NOT IN (%s)", strings.Join(placeholders, ", "))
+	}
+
+	_, err := tx.Exec(query, args...)
+	return err
+}
+
+// loadSubcategoryRows 載入指定分類的所有子分類
+func loadSubcategoryRows(dbClient database.DatabaseClient, table, parentID string) ([]subcategoryRow, error) {
+	query := fmt.Sprintf("SELECT id, name FROM %s WHERE parent_id = $1 ORDER BY name", table)
+
+	rows, err := dbClient.Query(query, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []subcategoryRow
+	for rows.Next() {
+		var row subcategoryRow
+		if err := rows.Scan(&row.ID, &row.Name); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+
+	return result, nil
+}
+
+// findSubcategoryParentID 查出子分類所屬的分類ID，找不到時回傳空字串
+func findSubcategoryParentID(dbClient database.DatabaseClient, table, subcategoryID string) (string, error) {
+	var parentID string
+	err := dbClient.QueryRow(fmt.Sprintf("SELECT parent_id FROM %s WHERE id = $1", table), subcategoryID).Scan(&parentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+	return parentID, nil
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+)
+
+// PgDuplicateFlagRepositoryPeerAdapter 重複標記的 Layer 3 (Adapter) 實現
+type PgDuplicateFlagRepositoryPeerAdapter struct {
+	flagStore store.QueryAggregateStore[mapper.DuplicateFlagData]
+}
+
+// NewPgDuplicateFlagRepositoryPeerAdapter 創建PostgreSQL重複標記儲存實現
+func NewPgDuplicateFlagRepositoryPeerAdapter(flagStore store.QueryAggregateStore[mapper.DuplicateFlagData]) repository.DuplicateFlagRepositoryPeer {
+	return &PgDuplicateFlagRepositoryPeerAdapter{flagStore: flagStore}
+}
+
+// SaveData 儲存重複標記資料
+func (p *PgDuplicateFlagRepositoryPeerAdapter) SaveData(data mapper.DuplicateFlagData) error {
+	return p.flagStore.Save(data)
+}
+
+// FindDataByID 根據ID查找重複標記，找不到時回傳 (nil, nil)
+func (p *PgDuplicateFlagRepositoryPeerAdapter) FindDataByID(id string) (*mapper.DuplicateFlagData, error) {
+	return p.flagStore.FindByID(id)
+}
+
+// FindDataByUserID 根據用戶ID與狀態查找重複標記
+func (p *PgDuplicateFlagRepositoryPeerAdapter) FindDataByUserID(userID, status string) ([]mapper.DuplicateFlagData, error) {
+	return p.flagStore.FindBy(map[string]interface{}{
+		"user_id": userID,
+		"status":  status,
+	})
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/database"
+)
+
+// PgExchangeRateRepositoryPeerAdapter 匯率的 Layer 3 (Adapter) 實現
+// 以 exchange_rates 資料表保存每日匯率，同一組貨幣同一天重複寫入時覆蓋
+type PgExchangeRateRepositoryPeerAdapter struct {
+	dbClient database.DatabaseClient
+}
+
+// NewPgExchangeRateRepositoryPeerAdapter 創建PostgreSQL匯率儲存實現
+func NewPgExchangeRateRepositoryPeerAdapter(dbClient database.DatabaseClient) repository.ExchangeRateRepositoryPeer {
+	return &PgExchangeRateRepositoryPeerAdapter{dbClient: dbClient}
+}
+
+// SaveAllData 在同一事務中upsert所有匯率，任一筆失敗時全部不寫入
+func (p *PgExchangeRateRepositoryPeerAdapter) SaveAllData(dataList []mapper.ExchangeRateData) error {
+	tx, err := p.dbClient.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	columns := database.ExchangeRateColumns
+	placeholders := make([]string, len(columns))
+	updates := make([]string, 0, len(columns))
+	for i, column := range columns {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		if column != "id" {
+			updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", column, column))
+		}
+	}
+	query := fmt.Sprintf(`
+		INSERT INTO exchange_rates (%s)
+		VALUES (%s)
+		ON CONFLICT (id) DO UPDATE SET %s
+	`, strings.Join(columns, ", "), strings.Join(placeholders, ", "), strings.Join(updates, ", "))
+
+	for _, data := range dataList {
+		if _, err = tx.Exec(query, database.ExchangeRateValues(data)...); err != nil {
+			return fmt.Errorf("failed to save exchange rate %s: %w", data.ID, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// FindDataLatest 查找指定日期 (含) 之前最近的匯率，找不到時回傳 (nil, nil)
+func (p *PgExchangeRateRepositoryPeerAdapter) FindDataLatest(base, quote string, onOrBefore time.Time) (*mapper.ExchangeRateData, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM exchange_rates
+		WHERE base_currency = $1 AND quote_currency = $2 AND rate_date <= $3
+		ORDER BY rate_date DESC
+		LIMIT 1
+	`, strings.Join(database.ExchangeRateColumns, ", "))
+
+	rates, err := p.query(query, base, quote, onOrBefore)
+	if err != nil || len(rates) == 0 {
+		return nil, err
+	}
+	return &rates[0], nil
+}
+
+// FindDataByPair 依日期由新到舊列出一組貨幣的所有匯率
+func (p *PgExchangeRateRepositoryPeerAdapter) FindDataByPair(base, quote string) ([]mapper.ExchangeRateData, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM exchange_rates
+		WHERE base_currency = $1 AND quote_currency = $2
+		ORDER BY rate_date DESC
+	`, strings.Join(database.ExchangeRateColumns, ", "))
+
+	return p.query(query, base, quote)
+}
+
+// FindDataLatestPerPair 每組貨幣在指定日期 (含) 之前最近的一筆匯率
+func (p *PgExchangeRateRepositoryPeerAdapter) FindDataLatestPerPair(onOrBefore time.Time) ([]mapper.ExchangeRateData, error) {
+	query := fmt.Sprintf(`
+		SELECT DISTINCT ON (base_currency, quote_currency) %s FROM exchange_rates
+		WHERE rate_date <= $1
+		ORDER BY base_currency, quote_currency, rate_date DESC
+	`, strings.Join(database.ExchangeRateColumns, ", "))
+
+	return p.query(query, onOrBefore)
+}
+
+func (p *PgExchangeRateRepositoryPeerAdapter) query(query string, args ...interface{}) ([]mapper.ExchangeRateData, error) {
+	rows, err := p.dbClient.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []mapper.ExchangeRateData
+	for rows.Next() {
+		rate, err := database.ScanExchangeRate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exchange rate: %w", err)
+		}
+		rates = append(rates, *rate)
+	}
+	return rates, nil
+}
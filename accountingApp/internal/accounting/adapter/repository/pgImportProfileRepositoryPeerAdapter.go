@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+)
+
+// PgImportProfileRepositoryPeerAdapter 匯入設定的 Layer 3 (Adapter) 實現
+type PgImportProfileRepositoryPeerAdapter struct {
+	profileStore store.QueryAggregateStore[mapper.ImportProfileData]
+}
+
+// NewPgImportProfileRepositoryPeerAdapter 創建PostgreSQL匯入設定儲存實現
+func NewPgImportProfileRepositoryPeerAdapter(profileStore store.QueryAggregateStore[mapper.ImportProfileData]) repository.ImportProfileRepositoryPeer {
+	return &PgImportProfileRepositoryPeerAdapter{profileStore: profileStore}
+}
+
+// SaveData 儲存匯入設定資料
+func (p *PgImportProfileRepositoryPeerAdapter) SaveData(data mapper.ImportProfileData) error {
+	return p.profileStore.Save(data)
+}
+
+// FindDataByID 根據ID查找匯入設定，找不到時回傳 (nil, nil)
+func (p *PgImportProfileRepositoryPeerAdapter) FindDataByID(id string) (*mapper.ImportProfileData, error) {
+	return p.profileStore.FindByID(id)
+}
+
+// FindDataByUserID 根據用戶ID查找所有匯入設定
+func (p *PgImportProfileRepositoryPeerAdapter) FindDataByUserID(userID string) ([]mapper.ImportProfileData, error) {
+	return p.profileStore.FindBy(map[string]interface{}{
+		"user_id": userID,
+	})
+}
+
+// DeleteData 根據ID刪除匯入設定
+func (p *PgImportProfileRepositoryPeerAdapter) DeleteData(id string) error {
+	return p.profileStore.Delete(id)
+}
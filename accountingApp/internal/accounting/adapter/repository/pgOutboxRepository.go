@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/database"
+)
+
+// PgOutboxRepository 以 outbox 資料表實作 OutboxRepository
+// 單筆讀寫透過QueryAggregateStore，批次取得與排序查詢直接使用DatabaseClient
+type PgOutboxRepository struct {
+	outboxStore store.QueryAggregateStore[mapper.OutboxMessageData]
+	dbClient    database.DatabaseClient
+}
+
+// NewPgOutboxRepository 創建PostgreSQL Outbox儲存庫
+func NewPgOutboxRepository(outboxStore store.QueryAggregateStore[mapper.OutboxMessageData], dbClient database.DatabaseClient) repository.OutboxRepository {
+	return &PgOutboxRepository{
+		outboxStore: outboxStore,
+		dbClient:    dbClient,
+	}
+}
+
+// ClaimDue 以 FOR UPDATE SKIP LOCKED 取得到期訊息並延後下次嘗試時間
+func (r *PgOutboxRepository) ClaimDue(now time.Time, lease time.Duration, limit int) ([]mapper.OutboxMessageData, error) {
+	query := fmt.Sprintf(`
+		UPDATE outbox SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE status = $3 AND next_attempt_at <= $1
+			ORDER BY occurred_at, created_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %s
+	`, strings.Join(database.OutboxColumns, ", "))
+
+	messages, err := r.query(query, now, now.Add(lease), mapper.OutboxStatusPending, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+
+	// RETURNING 不保證順序，依事件發生順序投遞
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].OccurredAt.Before(messages[j].OccurredAt)
+	})
+	return messages, nil
+}
+
+// FindByID 根據ID查找訊息
+func (r *PgOutboxRepository) FindByID(id string) (*mapper.OutboxMessageData, error) {
+	return r.outboxStore.FindByID(id)
+}
+
+// FindStuck 查找dead letter或仍在重試中的訊息
+func (r *PgOutboxRepository) FindStuck(limit int) ([]mapper.OutboxMessageData, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM outbox
+		WHERE status = $1 OR (status = $2 AND attempts > 0)
+		ORDER BY occurred_at, created_at
+		LIMIT $3
+	`, strings.Join(database.OutboxColumns, ", "))
+
+	return r.query(query, mapper.OutboxStatusDeadLetter, mapper.OutboxStatusPending, limit)
+}
+
+// FindByStatus 依狀態查找訊息
+func (r *PgOutboxRepository) FindByStatus(status string, limit int) ([]mapper.OutboxMessageData, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM outbox
+		WHERE status = $1
+		ORDER BY occurred_at, created_at
+		LIMIT $2
+	`, strings.Join(database.OutboxColumns, ", "))
+
+	return r.query(query, status, limit)
+}
+
+// Update 更新訊息的投遞狀態
+func (r *PgOutboxRepository) Update(message mapper.OutboxMessageData) error {
+	return r.outboxStore.Save(message)
+}
+
+// DeleteDelivered 刪除投遞時間早於before的已投遞訊息
+func (r *PgOutboxRepository) DeleteDelivered(before time.Time) (int, error) {
+	result, err := r.dbClient.Exec(`DELETE FROM outbox WHERE status = $1 AND delivered_at < $2`, mapper.OutboxStatusDelivered, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete delivered outbox messages: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete delivered outbox messages: %w", err)
+	}
+	return int(deleted), nil
+}
+
+func (r *PgOutboxRepository) query(query string, args ...interface{}) ([]mapper.OutboxMessageData, error) {
+	rows, err := r.dbClient.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []mapper.OutboxMessageData
+	for rows.Next() {
+		message, err := database.ScanOutboxMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+		messages = append(messages, *message)
+	}
+	return messages, nil
+}
+
+// 確保PgOutboxRepository實現OutboxRepository介面
+var _ repository.OutboxRepository = (*PgOutboxRepository)(nil)
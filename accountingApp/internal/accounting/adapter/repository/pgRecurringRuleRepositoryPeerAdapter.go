@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/database"
+)
+
+// PgRecurringRuleRepositoryPeerAdapter 週期規則的 Layer 3 (Adapter) 實現
+// 規則主體透過QueryAggregateStore讀取；儲存時在同一事務中以樂觀鎖更新主體並同步 recurring_occurrences
+type PgRecurringRuleRepositoryPeerAdapter struct {
+	ruleStore store.QueryAggregateStore[mapper.RecurringRuleData]
+	dbClient  database.DatabaseClient
+}
+
+// NewPgRecurringRuleRepositoryPeerAdapter 創建PostgreSQL週期規則儲存實現
+func NewPgRecurringRuleRepositoryPeerAdapter(
+	ruleStore store.QueryAggregateStore[mapper.RecurringRuleData],
+	dbClient database.DatabaseClient,
+) repository.RecurringRuleRepositoryPeer {
+	return &PgRecurringRuleRepositoryPeerAdapter{
+		ruleStore: ruleStore,
+		dbClient:  dbClient,
+	}
+}
+
+// SaveData 在事務中儲存規則主體 (條件式upsert) 並upsert其發生日記錄
+// 發生日記錄只會新增或更新，不會從聚合中移除
+func (p *PgRecurringRuleRepositoryPeerAdapter) SaveData(data mapper.RecurringRuleData) error {
+	tx, err := p.dbClient.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// 1. 保存規則主體，版本不符時不更新任何資料列
+	if err = p.saveRuleInTransaction(tx, data); err != nil {
+		return err
+	}
+
+	// 2. 同步發生日記錄，(rule_id, occurrence_date) 唯一
+	query := `
+		INSERT INTO recurring_occurrences (
+			rule_id, occurrence_date, status, transaction_id, attempts, last_error, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (rule_id, occurrence_date) DO UPDATE SET
+			status = EXCLUDED.status,
+			transaction_id = EXCLUDED.transaction_id,
+			attempts = EXCLUDED.attempts,
+			last_error = EXCLUDED.last_error,
+			updated_at = EXCLUDED.updated_at
+	`
+	for _, occurrence := range data.Occurrences {
+		_, err = tx.Exec(query,
+			data.ID, occurrence.Date, occurrence.Status, occurrence.TransactionID,
+			occurrence.Attempts, occurrence.LastError, occurrence.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save occurrence %s: %w", occurrence.Date.Format("2006-01-02"), err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// FindDataByID 根據ID查找規則並載入其發生日記錄，找不到時回傳 (nil, nil)
+func (p *PgRecurringRuleRepositoryPeerAdapter) FindDataByID(id string) (*mapper.RecurringRuleData, error) {
+	data, err := p.ruleStore.FindByID(id)
+	if err != nil || data == nil {
+		return data, err
+	}
+
+	if err := p.loadOccurrences(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// FindDataByUserID 根據用戶ID查找所有規則並載入其發生日記錄
+func (p *PgRecurringRuleRepositoryPeerAdapter) FindDataByUserID(userID string) ([]mapper.RecurringRuleData, error) {
+	return p.findWithOccurrences(map[string]interface{}{
+		"user_id": userID,
+	})
+}
+
+// FindDataByStatus 查找指定狀態的所有規則並載入其發生日記錄
+func (p *PgRecurringRuleRepositoryPeerAdapter) FindDataByStatus(status string) ([]mapper.RecurringRuleData, error) {
+	return p.findWithOccurrences(map[string]interface{}{
+		"status": status,
+	})
+}
+
+// DeleteData 刪除規則，發生日記錄由資料庫 ON DELETE CASCADE 一併刪除
+func (p *PgRecurringRuleRepositoryPeerAdapter) DeleteData(id string) error {
+	return p.ruleStore.Delete(id)
+}
+
+// saveRuleInTransaction 條件式upsert (樂觀鎖)：只有資料庫中的版本仍等於載入時的版本才會更新，
+// 否則沒有任何資料列受影響，回傳ErrConcurrencyConflict
+func (p *PgRecurringRuleRepositoryPeerAdapter) saveRuleInTransaction(tx database.Transaction, data mapper.RecurringRuleData) error {
+	columns := database.RecurringRuleColumns
+	placeholders := make([]string, len(columns))
+	updates := make([]string, 0, len(columns))
+	for i, column := range columns {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		if column != "id" && column != "user_id" && column != "created_at" {
+			updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", column, column))
+		}
+	}
+	query := fmt.Sprintf(`
+		INSERT INTO recurring_rules (%s)
+		VALUES (%s)
+		ON CONFLICT (id) DO UPDATE SET %s
+		WHERE recurring_rules.version = $%d
+	`, strings.Join(columns, ", "), strings.Join(placeholders, ", "), strings.Join(updates, ", "), len(columns)+1)
+
+	saved := data
+	saved.Version = data.Version + 1
+	args := append(database.RecurringRuleValues(saved), data.Version)
+
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to save recurring rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: recurring rule %s was modified since version %d", repository.ErrConcurrencyConflict, data.ID, data.Version)
+	}
+	return nil
+}
+
+func (p *PgRecurringRuleRepositoryPeerAdapter) findWithOccurrences(criteria map[string]interface{}) ([]mapper.RecurringRuleData, error) {
+	rules, err := p.ruleStore.FindBy(criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rules {
+		if err := p.loadOccurrences(&rules[i]); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+// loadOccurrences 依日期載入規則的所有發生日記錄
+func (p *PgRecurringRuleRepositoryPeerAdapter) loadOccurrences(data *mapper.RecurringRuleData) error {
+	query := `
+		SELECT rule_id, occurrence_date, status, transaction_id, attempts, last_error, updated_at
+		FROM recurring_occurrences
+		WHERE rule_id = $1
+		ORDER BY occurrence_date
+	`
+
+	rows, err := p.dbClient.Query(query, data.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load occurrences for recurring rule %s: %w", data.ID, err)
+	}
+	defer rows.Close()
+
+	data.Occurrences = nil
+	for rows.Next() {
+		var occurrence mapper.RecurringOccurrenceData
+		err := rows.Scan(&occurrence.RuleID, &occurrence.Date, &occurrence.Status, &occurrence.TransactionID,
+			&occurrence.Attempts, &occurrence.LastError, &occurrence.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to scan occurrence for recurring rule %s: %w", data.ID, err)
+		}
+		data.Occurrences = append(data.Occurrences, occurrence)
+	}
+	return nil
+}
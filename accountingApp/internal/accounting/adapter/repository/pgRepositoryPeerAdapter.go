@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
@@ -56,36 +59,62 @@ func (p *PgWalletRepositoryPeerAdapter) saveWithTransaction(data mapper.WalletDa
 		}
 	}()
 
+	// 0. 稽核紀錄的前快照：鎖定並讀取儲存前的錢包欄位 (不載入子實體，變更前的子實體由data.Audit提供)
+	var previous *mapper.WalletData
+	if data.Audit != nil {
+		previous, err = p.lockWalletRow(tx, data.ID)
+		if err != nil {
+			return fmt.Errorf("failed to lock wallet: %w", err)
+		}
+	}
+
 	// 1. 保存錢包主體實體
 	err = p.saveWalletInTransaction(tx, data)
 	if err != nil {
 		return fmt.Errorf("failed to save wallet: %w", err)
 	}
 
-	// 2. 保存子實體 - 收入記錄
-	if len(data.IncomeRecords) > 0 {
-		err = p.saveIncomeRecords(tx, data.IncomeRecords)
+	// 2. 保存子實體變更 - 收入記錄
+	if !data.IncomeRecordChanges.IsEmpty() {
+		err = p.saveIncomeRecords(tx, data.ID, data.IncomeRecords, data.IncomeRecordChanges)
 		if err != nil {
 			return fmt.Errorf("failed to save income records: %w", err)
 		}
 	}
 
-	// 3. 保存子實體 - 支出記錄
-	if len(data.ExpenseRecords) > 0 {
-		err = p.saveExpenseRecords(tx, data.ExpenseRecords)
+	// 3. 保存子實體變更 - 支出記錄
+	if !data.ExpenseRecordChanges.IsEmpty() {
+		err = p.saveExpenseRecords(tx, data.ID, data.ExpenseRecords, data.ExpenseRecordChanges)
 		if err != nil {
 			return fmt.Errorf("failed to save expense records: %w", err)
 		}
 	}
 
-	// 4. 保存子實體 - 轉帳記錄
-	if len(data.Transfers) > 0 {
-		err = p.saveTransfers(tx, data.Transfers)
+	// 4. 保存子實體變更 - 轉帳記錄
+	if !data.TransferChanges.IsEmpty() {
+		err = p.saveTransfers(tx, data.ID, data.Transfers, data.TransferChanges)
 		if err != nil {
 			return fmt.Errorf("failed to save transfers: %w", err)
 		}
 	}
 
+	// 5. 寫入outbox - 與錢包狀態同一個交易，提交後由Relay投遞
+	if len(data.OutboxMessages) > 0 {
+		err = p.saveOutboxMessages(tx, data.OutboxMessages)
+		if err != nil {
+			return fmt.Errorf("failed to save outbox messages: %w", err)
+		}
+	}
+
+	// 6. 寫入稽核紀錄 - 與錢包狀態同一個交易，寫入失敗時整個儲存回滾
+	// 稽核紀錄表的鎖到提交才釋放，因此放在最後
+	if data.Audit != nil {
+		err = p.saveAuditEntry(tx, *data.Audit, previous)
+		if err != nil {
+			return fmt.Errorf("failed to save audit entry: %w", err)
+		}
+	}
+
 	// 提交事務
 	err = tx.Commit()
 	if err != nil {
@@ -113,6 +142,43 @@ func (p *PgWalletRepositoryPeerAdapter) Delete(id string) error {
 	return p.walletStore.Delete(id)
 }
 
+// DeleteWithAudit 在交易中刪除錢包並寫入稽核紀錄 (實現WalletRepositoryPeer介面)
+// 前快照為刪除前鎖定的錢包欄位；子實體由資料庫連帶刪除，不列入快照
+func (p *PgWalletRepositoryPeerAdapter) DeleteWithAudit(id string, audit mapper.WalletAuditData) error {
+	tx, err := p.dbClient.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var previous *mapper.WalletData
+	previous, err = p.lockWalletRow(tx, id)
+	if err != nil {
+		return fmt.Errorf("failed to lock wallet: %w", err)
+	}
+	if previous == nil {
+		err = fmt.Errorf("aggregate with id %s not found in table wallets", id)
+		return err
+	}
+
+	if _, err = tx.Exec("DELETE FROM wallets WHERE id = $1", id); err != nil {
+		return fmt.Errorf("failed to delete wallet: %w", err)
+	}
+
+	if err = p.saveAuditEntry(tx, audit, previous); err != nil {
+		return fmt.Errorf("failed to save audit entry: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
 // FindByUserID 根據UserID查找用戶的所有錢包聚合狀態 (實現WalletRepositoryPeer介面)
 func (p *PgWalletRepositoryPeerAdapter) FindByUserID(userID string) ([]mapper.WalletData, error) {
 	// 使用QueryAggregateStore的FindBy方法查詢用戶的所有錢包
@@ -153,17 +219,110 @@ func (p *PgWalletRepositoryPeerAdapter) FindByIDWithChildEntities(id string) (*m
 	return walletData, nil
 }
 
+// FindByExpenseRecordID 查找包含指定支出記錄的錢包並完整載入所有子實體
+func (p *PgWalletRepositoryPeerAdapter) FindByExpenseRecordID(expenseID string) (*mapper.WalletData, error) {
+	return p.findByChildEntity("SELECT wallet_id FROM expense_records WHERE id = $1", expenseID)
+}
+
+// FindByIncomeRecordID 查找包含指定收入記錄的錢包並完整載入所有子實體
+func (p *PgWalletRepositoryPeerAdapter) FindByIncomeRecordID(incomeID string) (*mapper.WalletData, error) {
+	return p.findByChildEntity("SELECT wallet_id FROM income_records WHERE id = $1", incomeID)
+}
+
+// FindByTransferID 查找轉帳的來源錢包並完整載入所有子實體
+func (p *PgWalletRepositoryPeerAdapter) FindByTransferID(transferID string) (*mapper.WalletData, error) {
+	return p.findByChildEntity("SELECT from_wallet_id FROM transfers WHERE id = $1", transferID)
+}
+
+// findByChildEntity 以子實體ID查出所屬錢包ID，再載入完整聚合；找不到時回傳 (nil, nil)
+func (p *PgWalletRepositoryPeerAdapter) findByChildEntity(query, childID string) (*mapper.WalletData, error) {
+	var walletID string
+	err := p.dbClient.QueryRow(query, childID).Scan(&walletID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return p.FindByIDWithChildEntities(walletID)
+}
+
+// SumExpensesByUserID 依子分類、幣別與日期加總用戶所有錢包在 [from, to) 的支出
+// 拆帳支出以明細的子分類與金額計入，不載入錢包聚合
+func (p *PgWalletRepositoryPeerAdapter) SumExpensesByUserID(userID string, from, to time.Time) ([]mapper.ExpenseTotalData, error) {
+	query := `
+		SELECT COALESCE(s.category_id, e.category_id), COALESCE(s.currency, e.currency),
+		       date_trunc('day', e.date), SUM(COALESCE(s.amount, e.amount))
+		FROM expense_records e
+		JOIN wallets w ON w.id = e.wallet_id
+		LEFT JOIN expense_splits s ON s.expense_id = e.id
+		WHERE w.user_id = $1 AND e.date >= $2 AND e.date < $3
+		GROUP BY 1, 2, 3
+		ORDER BY 3
+	`
+
+	rows, err := p.dbClient.Query(query, userID, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expense totals: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []mapper.ExpenseTotalData
+	for rows.Next() {
+		var total mapper.ExpenseTotalData
+		err = rows.Scan(&total.SubcategoryID, &total.Currency, &total.Date, &total.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan expense total: %w", err)
+		}
+		totals = append(totals, total)
+	}
+
+	return totals, nil
+}
+
+// FindRecordsByDateRange 查找錢包在 [from, to) 的支出與收入記錄，不載入錢包與轉帳
+func (p *PgWalletRepositoryPeerAdapter) FindRecordsByDateRange(walletID string, from, to time.Time) ([]mapper.ExpenseRecordData, []mapper.IncomeRecordData, error) {
+	filter := recordFilter{walletID: walletID, from: from, to: to}
+
+	expenseRecords, err := p.loadExpenseRecords(filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load expense records: %w", err)
+	}
+	incomeRecords, err := p.loadIncomeRecords(filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load income records: %w", err)
+	}
+
+	return expenseRecords, incomeRecords, nil
+}
+
+// recordFilter 收入與支出記錄的查詢條件：錢包ID，from不為零值時只取日期在 [from, to) 的記錄
+type recordFilter struct {
+	walletID string
+	from, to time.Time
+}
+
+// where 回傳以alias為記錄資料表別名的查詢條件與參數
+func (f recordFilter) where(alias string) (string, []interface{}) {
+	if f.from.IsZero() {
+		return alias + ".wallet_id = $1", []interface{}{f.walletID}
+	}
+	return fmt.Sprintf("%[1]s.wallet_id = $1 AND %[1]s.date >= $2 AND %[1]s.date < $3", alias),
+		[]interface{}{f.walletID, f.from.UTC(), f.to.UTC()}
+}
+
 // loadChildEntities 載入錢包的所有子實體
 func (p *PgWalletRepositoryPeerAdapter) loadChildEntities(walletData *mapper.WalletData) error {
 	// 載入收入記錄
-	incomeRecords, err := p.loadIncomeRecords(walletData.ID)
+	incomeRecords, err := p.loadIncomeRecords(recordFilter{walletID: walletData.ID})
 	if err != nil {
 		return fmt.Errorf("failed to load income records: %w", err)
 	}
 	walletData.IncomeRecords = incomeRecords
 
 	// 載入支出記錄
-	expenseRecords, err := p.loadExpenseRecords(walletData.ID)
+	expenseRecords, err := p.loadExpenseRecords(recordFilter{walletID: walletData.ID})
 	if err != nil {
 		return fmt.Errorf("failed to load expense records: %w", err)
 	}
@@ -180,137 +339,311 @@ func (p *PgWalletRepositoryPeerAdapter) loadChildEntities(walletData *mapper.Wal
 }
 
 // saveWalletInTransaction 在事務中保存錢包主體實體
+// 條件式upsert (樂觀鎖)：只有資料庫中的版本仍等於載入時的版本才會更新，
+// 否則沒有任何資料列受影響，回傳ErrConcurrencyConflict
 func (p *PgWalletRepositoryPeerAdapter) saveWalletInTransaction(tx database.Transaction, data mapper.WalletData) error {
 	query := `
 		INSERT INTO wallets (
 			id, user_id, name, type, currency, 
-			balance_amount, balance_currency, created_at, updated_at
+			balance_amount, balance_currency, created_at, updated_at, version
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		ON CONFLICT (id) DO UPDATE SET
 			name = EXCLUDED.name,
 			type = EXCLUDED.type,
 			currency = EXCLUDED.currency,
 			balance_amount = EXCLUDED.balance_amount,
 			balance_currency = EXCLUDED.balance_currency,
-			updated_at = EXCLUDED.updated_at
+			updated_at = EXCLUDED.updated_at,
+			version = EXCLUDED.version
+		WHERE wallets.version = $11
 	`
 	
-	_, err := tx.Exec(query,
+	result, err := tx.Exec(query,
 		data.ID, data.UserID, data.Name, data.Type, data.Currency,
-		data.BalanceAmount, data.BalanceCurrency, data.CreatedAt, data.UpdatedAt)
-	
-	return err
+		data.BalanceAmount, data.BalanceCurrency, data.CreatedAt, data.UpdatedAt,
+		data.Version+1, data.Version)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: wallet %s was modified since version %d", repository.ErrConcurrencyConflict, data.ID, data.Version)
+	}
+
+	return nil
 }
 
-// saveIncomeRecords 在事務中批次保存收入記錄
-func (p *PgWalletRepositoryPeerAdapter) saveIncomeRecords(tx database.Transaction, records []mapper.IncomeRecordData) error {
-	if len(records) == 0 {
-		return nil
+// saveIncomeRecords 在事務中保存收入記錄的變更 (只寫入差異)
+func (p *PgWalletRepositoryPeerAdapter) saveIncomeRecords(tx database.Transaction, walletID string, records []mapper.IncomeRecordData, changes mapper.ChildEntityChanges) error {
+	if err := p.deleteChildRows(tx, "income_records", "wallet_id = $1", walletID, changes.Removed); err != nil {
+		return fmt.Errorf("failed to delete removed income records: %w", err)
 	}
 
-	// Use individual upserts to preserve existing records and only insert new ones
-	// This prevents overwriting existing income records when adding new ones
 	query := `
 		INSERT INTO income_records (
-			id, wallet_id, category_id, amount, currency, description, date, created_at
+			id, wallet_id, category_id, amount, currency, description, date, created_at, import_id, occurrence_key
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		ON CONFLICT (id) DO NOTHING
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO UPDATE SET
+			category_id = EXCLUDED.category_id,
+			amount = EXCLUDED.amount,
+			currency = EXCLUDED.currency,
+			description = EXCLUDED.description,
+			date = EXCLUDED.date,
+			import_id = EXCLUDED.import_id,
+			occurrence_key = EXCLUDED.occurrence_key
 	`
 
+	upserted := changes.Upserted()
+	modified := modifiedIDs(changes)
 	for _, record := range records {
+		if !upserted[record.ID] {
+			continue
+		}
 		_, err := tx.Exec(query,
 			record.ID, record.WalletID, record.SubcategoryID, record.Amount,
-			record.Currency, record.Description, record.Date, record.CreatedAt)
+			record.Currency, record.Description, record.Date, record.CreatedAt, record.ImportID, record.OccurrenceKey)
 		if err != nil {
 			return fmt.Errorf("failed to save income record %s: %w", record.ID, err)
 		}
+		if err := p.saveTags(tx, "income_record_tags", "income_id", record.ID, record.Tags, modified[record.ID]); err != nil {
+			return fmt.Errorf("failed to save tags of income record %s: %w", record.ID, err)
+		}
 	}
 
 	return nil
 }
 
-// saveExpenseRecords 在事務中批次保存支出記錄
-func (p *PgWalletRepositoryPeerAdapter) saveExpenseRecords(tx database.Transaction, records []mapper.ExpenseRecordData) error {
-	if len(records) == 0 {
-		return nil
-	}
-
-	// 先清除該錢包的現有支出記錄
-	walletID := records[0].WalletID
-	deleteQuery := "DELETE FROM expense_records WHERE wallet_id = $1"
-	_, err := tx.Exec(deleteQuery, walletID)
-	if err != nil {
-		return fmt.Errorf("failed to delete existing expense records: %w", err)
+// saveExpenseRecords 在事務中保存支出記錄的變更 (只寫入差異)
+func (p *PgWalletRepositoryPeerAdapter) saveExpenseRecords(tx database.Transaction, walletID string, records []mapper.ExpenseRecordData, changes mapper.ChildEntityChanges) error {
+	if err := p.deleteChildRows(tx, "expense_records", "wallet_id = $1", walletID, changes.Removed); err != nil {
+		return fmt.Errorf("failed to delete removed expense records: %w", err)
 	}
 
-	// 批次插入新記錄
 	query := `
 		INSERT INTO expense_records (
-			id, wallet_id, category_id, amount, currency, description, date, created_at
+			id, wallet_id, category_id, amount, currency, description, date, created_at, import_id, occurrence_key
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO UPDATE SET
+			category_id = EXCLUDED.category_id,
+			amount = EXCLUDED.amount,
+			currency = EXCLUDED.currency,
+			description = EXCLUDED.description,
+			date = EXCLUDED.date,
+			import_id = EXCLUDED.import_id,
+			occurrence_key = EXCLUDED.occurrence_key
 	`
 
+	upserted := changes.Upserted()
+	modified := modifiedIDs(changes)
 	for _, record := range records {
-		_, err = tx.Exec(query,
+		if !upserted[record.ID] {
+			continue
+		}
+		_, err := tx.Exec(query,
 			record.ID, record.WalletID, record.SubcategoryID, record.Amount,
-			record.Currency, record.Description, record.Date, record.CreatedAt)
+			record.Currency, record.Description, record.Date, record.CreatedAt, record.ImportID, record.OccurrenceKey)
 		if err != nil {
 			return fmt.Errorf("failed to save expense record %s: %w", record.ID, err)
 		}
+		if err := p.saveExpenseSplits(tx, record, modified[record.ID]); err != nil {
+			return fmt.Errorf("failed to save splits of expense record %s: %w", record.ID, err)
+		}
+		if err := p.saveTags(tx, "expense_record_tags", "expense_id", record.ID, record.Tags, modified[record.ID]); err != nil {
+			return fmt.Errorf("failed to save tags of expense record %s: %w", record.ID, err)
+		}
 	}
 
 	return nil
 }
 
-// saveTransfers 在事務中批次保存轉帳記錄
-func (p *PgWalletRepositoryPeerAdapter) saveTransfers(tx database.Transaction, transfers []mapper.TransferData) error {
-	if len(transfers) == 0 {
-		return nil
+// saveExpenseSplits 寫入支出的拆帳明細；修改過的支出先刪除舊明細 (修改可能改變或移除拆帳)
+func (p *PgWalletRepositoryPeerAdapter) saveExpenseSplits(tx database.Transaction, record mapper.ExpenseRecordData, replace bool) error {
+	if replace {
+		if _, err := tx.Exec("DELETE FROM expense_splits WHERE expense_id = $1", record.ID); err != nil {
+			return err
+		}
 	}
 
-	// 清除相關的轉帳記錄（FROM 或 TO 此錢包的轉帳）
-	walletID := transfers[0].FromWalletID // 假設所有轉帳都是從同一個錢包
-	deleteQuery := "DELETE FROM transfers WHERE from_wallet_id = $1 OR to_wallet_id = $1"
-	_, err := tx.Exec(deleteQuery, walletID)
-	if err != nil {
-		return fmt.Errorf("failed to delete existing transfers: %w", err)
+	query := `
+		INSERT INTO expense_splits (
+			expense_id, line_no, category_id, amount, currency, memo
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	for _, split := range record.Splits {
+		_, err := tx.Exec(query,
+			record.ID, split.LineNo, split.SubcategoryID, split.Amount, split.Currency, split.Memo)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveTransfers 在事務中保存轉帳記錄的變更 (只寫入差異)
+// 轉帳同時屬於來源與目標錢包，兩邊儲存同一筆轉帳時以upsert避免重複
+func (p *PgWalletRepositoryPeerAdapter) saveTransfers(tx database.Transaction, walletID string, transfers []mapper.TransferData, changes mapper.ChildEntityChanges) error {
+	if err := p.deleteChildRows(tx, "transfers", "(from_wallet_id = $1 OR to_wallet_id = $1)", walletID, changes.Removed); err != nil {
+		return fmt.Errorf("failed to delete removed transfers: %w", err)
 	}
 
-	// 批次插入新記錄
 	query := `
 		INSERT INTO transfers (
-			id, from_wallet_id, to_wallet_id, amount, currency, 
+			id, from_wallet_id, to_wallet_id, amount, currency, to_amount, to_currency,
 			fee_amount, fee_currency, description, date, created_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (id) DO UPDATE SET
+			amount = EXCLUDED.amount,
+			to_amount = EXCLUDED.to_amount,
+			fee_amount = EXCLUDED.fee_amount,
+			fee_currency = EXCLUDED.fee_currency,
+			description = EXCLUDED.description,
+			date = EXCLUDED.date
 	`
 
+	upserted := changes.Upserted()
+	modified := modifiedIDs(changes)
 	for _, transfer := range transfers {
-		_, err = tx.Exec(query,
+		if !upserted[transfer.ID] {
+			continue
+		}
+		_, err := tx.Exec(query,
 			transfer.ID, transfer.FromWalletID, transfer.ToWalletID,
-			transfer.Amount, transfer.Currency, transfer.Fee, transfer.Currency,
+			transfer.Amount, transfer.Currency, transfer.ToAmount, transfer.ToCurrency, transfer.Fee, transfer.FeeCurrency,
 			transfer.Description, transfer.Date, transfer.CreatedAt)
 		if err != nil {
 			return fmt.Errorf("failed to save transfer %s: %w", transfer.ID, err)
 		}
+		if err := p.saveTags(tx, "transfer_tags", "transfer_id", transfer.ID, transfer.Tags, modified[transfer.ID]); err != nil {
+			return fmt.Errorf("failed to save tags of transfer %s: %w", transfer.ID, err)
+		}
 	}
 
 	return nil
 }
 
-// loadIncomeRecords 載入特定錢包的所有收入記錄
-func (p *PgWalletRepositoryPeerAdapter) loadIncomeRecords(walletID string) ([]mapper.IncomeRecordData, error) {
+// saveTags 寫入交易記錄的標籤；修改過的記錄先刪除舊標籤
+// 轉帳的兩個錢包會各寫入一次相同的標籤，因此重複的標籤直接略過
+func (p *PgWalletRepositoryPeerAdapter) saveTags(tx database.Transaction, table, ownerColumn, ownerID string, tags []string, replace bool) error {
+	if replace {
+		if _, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s = $1", table, ownerColumn), ownerID); err != nil {
+			return err
+		}
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s, tag) VALUES ($1, $2) ON CONFLICT DO NOTHING", table, ownerColumn)
+	for _, tag := range tags {
+		if _, err := tx.Exec(query, ownerID, tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// modifiedIDs 已持久化但被修改的子實體ID集合 (其關聯資料需要先清除再寫入)
+func modifiedIDs(changes mapper.ChildEntityChanges) map[string]bool {
+	ids := make(map[string]bool, len(changes.Modified))
+	for _, id := range changes.Modified {
+		ids[id] = true
+	}
+	return ids
+}
+
+// lockWalletRow 在事務中鎖定並讀取錢包欄位 (不含子實體)，錢包不存在時回傳nil
+func (p *PgWalletRepositoryPeerAdapter) lockWalletRow(tx database.Transaction, id string) (*mapper.WalletData, error) {
 	query := `
-		SELECT id, wallet_id, category_id, amount, currency, description, date, created_at
-		FROM income_records
-		WHERE wallet_id = $1
-		ORDER BY date DESC, created_at DESC
+		SELECT id, user_id, name, type, currency,
+			balance_amount, balance_currency, created_at, updated_at, version
+		FROM wallets
+		WHERE id = $1
+		FOR UPDATE
 	`
 
-	rows, err := p.dbClient.Query(query, walletID)
+	var data mapper.WalletData
+	err := tx.QueryRow(query, id).Scan(
+		&data.ID, &data.UserID, &data.Name, &data.Type, &data.Currency,
+		&data.BalanceAmount, &data.BalanceCurrency, &data.CreatedAt, &data.UpdatedAt, &data.Version,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &data, nil
+}
+
+// saveAuditEntry 在事務中以儲存前的錢包欄位補齊快照，並接在稽核紀錄鏈尾
+func (p *PgWalletRepositoryPeerAdapter) saveAuditEntry(tx database.Transaction, audit mapper.WalletAuditData, previous *mapper.WalletData) error {
+	entry, err := audit.Complete(previous)
+	if err != nil {
+		return err
+	}
+	_, err = appendAuditEntry(tx, entry)
+	return err
+}
+
+// saveOutboxMessages 在事務中寫入待投遞的outbox訊息
+func (p *PgWalletRepositoryPeerAdapter) saveOutboxMessages(tx database.Transaction, messages []mapper.OutboxMessageData) error {
+	query := `
+		INSERT INTO outbox (
+			id, aggregate_type, aggregate_id, event_type, payload, status,
+			attempts, last_error, occurred_at, next_attempt_at, delivered_at, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	for _, message := range messages {
+		_, err := tx.Exec(query,
+			message.ID, message.AggregateType, message.AggregateID, message.EventType,
+			message.Payload, message.Status, message.Attempts, message.LastError,
+			message.OccurredAt, message.NextAttemptAt, message.DeliveredAt, message.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save outbox message %s: %w", message.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteChildRows 刪除屬於此錢包 (ownerClause以$1綁定walletID) 的指定子實體
+func (p *PgWalletRepositoryPeerAdapter) deleteChildRows(tx database.Transaction, table, ownerClause, walletID string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, 0, len(ids)+1)
+	args = append(args, walletID)
+	for i, id := range ids {
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s AND id IN (%s)", table, ownerClause, strings.Join(placeholders, ", "))
+	_, err := tx.Exec(query, args...)
+	return err
+}
+
+// loadIncomeRecords 載入特定錢包符合條件的收入記錄
+func (p *PgWalletRepositoryPeerAdapter) loadIncomeRecords(filter recordFilter) ([]mapper.IncomeRecordData, error) {
+	where, args := filter.where("r")
+	query := `
+		SELECT r.id, r.wallet_id, r.category_id, r.amount, r.currency, r.description, r.date, r.created_at, r.import_id, r.occurrence_key
+		FROM income_records r
+		WHERE ` + where + `
+		ORDER BY r.date DESC, r.created_at DESC
+	`
+
+	rows, err := p.dbClient.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query income records: %w", err)
 	}
@@ -322,7 +655,7 @@ func (p *PgWalletRepositoryPeerAdapter) loadIncomeRecords(walletID string) ([]ma
 		err = rows.Scan(
 			&record.ID, &record.WalletID, &record.SubcategoryID,
 			&record.Amount, &record.Currency, &record.Description,
-			&record.Date, &record.CreatedAt,
+			&record.Date, &record.CreatedAt, &record.ImportID, &record.OccurrenceKey,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan income record: %w", err)
@@ -330,19 +663,32 @@ func (p *PgWalletRepositoryPeerAdapter) loadIncomeRecords(walletID string) ([]ma
 		records = append(records, record)
 	}
 
+	tags, err := p.loadTags(`
+		SELECT t.income_id, t.tag
+		FROM income_record_tags t
+		JOIN income_records r ON r.id = t.income_id
+		WHERE `+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		records[i].Tags = tags[records[i].ID]
+	}
+
 	return records, nil
 }
 
-// loadExpenseRecords 載入特定錢包的所有支出記錄
-func (p *PgWalletRepositoryPeerAdapter) loadExpenseRecords(walletID string) ([]mapper.ExpenseRecordData, error) {
+// loadExpenseRecords 載入特定錢包符合條件的支出記錄
+func (p *PgWalletRepositoryPeerAdapter) loadExpenseRecords(filter recordFilter) ([]mapper.ExpenseRecordData, error) {
+	where, args := filter.where("e")
 	query := `
-		SELECT id, wallet_id, category_id, amount, currency, description, date, created_at
-		FROM expense_records
-		WHERE wallet_id = $1
-		ORDER BY date DESC, created_at DESC
+		SELECT e.id, e.wallet_id, e.category_id, e.amount, e.currency, e.description, e.date, e.created_at, e.import_id, e.occurrence_key
+		FROM expense_records e
+		WHERE ` + where + `
+		ORDER BY e.date DESC, e.created_at DESC
 	`
 
-	rows, err := p.dbClient.Query(query, walletID)
+	rows, err := p.dbClient.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query expense records: %w", err)
 	}
@@ -354,7 +700,7 @@ func (p *PgWalletRepositoryPeerAdapter) loadExpenseRecords(walletID string) ([]m
 		err = rows.Scan(
 			&record.ID, &record.WalletID, &record.SubcategoryID,
 			&record.Amount, &record.Currency, &record.Description,
-			&record.Date, &record.CreatedAt,
+			&record.Date, &record.CreatedAt, &record.ImportID, &record.OccurrenceKey,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan expense record: %w", err)
@@ -362,14 +708,64 @@ func (p *PgWalletRepositoryPeerAdapter) loadExpenseRecords(walletID string) ([]m
 		records = append(records, record)
 	}
 
+	// 載入拆帳明細並依支出ID歸位
+	splits, err := p.loadExpenseSplits(where, args)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := p.loadTags(`
+		SELECT t.expense_id, t.tag
+		FROM expense_record_tags t
+		JOIN expense_records e ON e.id = t.expense_id
+		WHERE `+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		records[i].Splits = splits[records[i].ID]
+		records[i].Tags = tags[records[i].ID]
+	}
+
 	return records, nil
 }
 
+// loadExpenseSplits 載入符合條件 (支出記錄別名為e) 的支出的拆帳明細，以支出ID分組
+func (p *PgWalletRepositoryPeerAdapter) loadExpenseSplits(where string, args []interface{}) (map[string][]mapper.ExpenseSplitData, error) {
+	query := `
+		SELECT s.expense_id, s.line_no, s.category_id, s.amount, s.currency, COALESCE(s.memo, '')
+		FROM expense_splits s
+		JOIN expense_records e ON e.id = s.expense_id
+		WHERE ` + where + `
+		ORDER BY s.expense_id, s.line_no
+	`
+
+	rows, err := p.dbClient.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expense splits: %w", err)
+	}
+	defer rows.Close()
+
+	splits := make(map[string][]mapper.ExpenseSplitData)
+	for rows.Next() {
+		var split mapper.ExpenseSplitData
+		err = rows.Scan(
+			&split.ExpenseID, &split.LineNo, &split.SubcategoryID,
+			&split.Amount, &split.Currency, &split.Memo,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan expense split: %w", err)
+		}
+		splits[split.ExpenseID] = append(splits[split.ExpenseID], split)
+	}
+
+	return splits, nil
+}
+
 // loadTransfers 載入特定錢包相關的所有轉帳記錄
 func (p *PgWalletRepositoryPeerAdapter) loadTransfers(walletID string) ([]mapper.TransferData, error) {
 	query := `
-		SELECT id, from_wallet_id, to_wallet_id, amount, currency, 
-			   fee_amount as fee, description, date, created_at
+		SELECT id, from_wallet_id, to_wallet_id, amount, currency, to_amount, to_currency,
+			   fee_amount as fee, fee_currency, description, date, created_at
 		FROM transfers
 		WHERE from_wallet_id = $1 OR to_wallet_id = $1
 		ORDER BY date DESC, created_at DESC
@@ -386,7 +782,8 @@ func (p *PgWalletRepositoryPeerAdapter) loadTransfers(walletID string) ([]mapper
 		var transfer mapper.TransferData
 		err = rows.Scan(
 			&transfer.ID, &transfer.FromWalletID, &transfer.ToWalletID,
-			&transfer.Amount, &transfer.Currency, &transfer.Fee,
+			&transfer.Amount, &transfer.Currency, &transfer.ToAmount, &transfer.ToCurrency,
+			&transfer.Fee, &transfer.FeeCurrency,
 			&transfer.Description, &transfer.Date, &transfer.CreatedAt,
 		)
 		if err != nil {
@@ -395,5 +792,38 @@ func (p *PgWalletRepositoryPeerAdapter) loadTransfers(walletID string) ([]mapper
 		transfers = append(transfers, transfer)
 	}
 
+	tags, err := p.loadTags(`
+		SELECT t.transfer_id, t.tag
+		FROM transfer_tags t
+		JOIN transfers x ON x.id = t.transfer_id
+		WHERE x.from_wallet_id = $1 OR x.to_wallet_id = $1
+	`, walletID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range transfers {
+		transfers[i].Tags = tags[transfers[i].ID]
+	}
+
 	return transfers, nil
 }
+
+// loadTags 執行回傳 (記錄ID, 標籤) 的查詢，以記錄ID分組
+func (p *PgWalletRepositoryPeerAdapter) loadTags(query string, args ...interface{}) (map[string][]string, error) {
+	rows, err := p.dbClient.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make(map[string][]string)
+	for rows.Next() {
+		var recordID, tag string
+		if err := rows.Scan(&recordID, &tag); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags[recordID] = append(tags[recordID], tag)
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/event"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/database"
+)
+
+// PgUnitOfWork 以 DatabaseClient.BeginTx 實作 UnitOfWork
+// 每次Do都開啟一個新交易，並以該交易建立Store/Peer/Repository
+// 交易內儲存的領域事件先暫存，提交成功後才發布
+type PgUnitOfWork struct {
+	dbClient  database.DatabaseClient
+	publisher event.Publisher
+	audit     *repository.AuditContext // 不為nil時，交易內儲存的錢包寫入稽核紀錄
+}
+
+// NewPgUnitOfWork 創建PostgreSQL Unit of Work，publisher可為nil
+func NewPgUnitOfWork(dbClient database.DatabaseClient, publisher event.Publisher) repository.AuditableUnitOfWork {
+	return &PgUnitOfWork{dbClient: dbClient, publisher: publisher}
+}
+
+// Do 在單一交易中執行fn
+func (u *PgUnitOfWork) Do(fn func(scope repository.TransactionScope) error) error {
+	tx, err := u.dbClient.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	committed := false
+	defer func() {
+		// fn panic 或提交前返回時確保回滾
+		if !committed {
+			tx.Rollback()
+		}
+	}()
+
+	events := event.NewBuffer()
+	if err := fn(newPgTransactionScope(tx, events, u.audit)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+
+	events.FlushTo(u.publisher)
+	return nil
+}
+
+// WithAudit 回傳交易內儲存與刪除錢包時，在同一個交易中寫入ctx稽核紀錄的Unit of Work
+func (u *PgUnitOfWork) WithAudit(ctx repository.AuditContext) repository.UnitOfWork {
+	audited := *u
+	audited.audit = &ctx
+	return &audited
+}
+
+// pgTransactionScope 綁定單一交易的Repository集合
+type pgTransactionScope struct {
+	tx      database.Transaction
+	events  *event.Buffer
+	audit   *repository.AuditContext
+	wallets repository.WalletRepository
+}
+
+func newPgTransactionScope(tx database.Transaction, events *event.Buffer, audit *repository.AuditContext) *pgTransactionScope {
+	return &pgTransactionScope{tx: tx, events: events, audit: audit}
+}
+
+// Wallets 回傳使用此交易的錢包Repository
+func (s *pgTransactionScope) Wallets() repository.WalletRepository {
+	if s.wallets == nil {
+		peer := NewPgWalletRepositoryPeerAdapter(
+			database.NewPgWalletStore(s.tx),
+			s.tx,
+			database.NewPgIncomeRecordStore(s.tx),
+			database.NewPgExpenseRecordStore(s.tx),
+			database.NewPgTransferStore(s.tx),
+		)
+		wallets := repository.NewWalletRepositoryImpl(peer, s.events)
+		s.wallets = wallets
+		if s.audit != nil {
+			s.wallets = wallets.WithAudit(*s.audit)
+		}
+	}
+	return s.wallets
+}
+
+// 確保PgUnitOfWork實現AuditableUnitOfWork介面
+var _ repository.AuditableUnitOfWork = (*PgUnitOfWork)(nil)
@@ -0,0 +1,172 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// Input Command的輸入，必須內嵌 usecase.CommandMetadata
+type Input interface {
+	Metadata() usecase.CommandMetadata
+}
+
+// Executor 任何以I為輸入的Command Use Case
+type Executor[I Input] interface {
+	Execute(input I) common.Output
+}
+
+// Spec 描述一個Command要稽核的內容
+type Spec[I Input] struct {
+	Command   string      // Command名稱，例如 "AddExpense"
+	Aggregate Snapshotter // 受影響聚合的類型與快照來源
+
+	// Targets 在執行前解析受影響的聚合ID (執行後聚合可能已被刪除)
+	// 為nil時以執行結果的ID作為聚合ID，用於建立聚合的Command
+	Targets func(input I) []string
+}
+
+// Recorder 將稽核紀錄寫入AuditLogRepository
+type Recorder struct {
+	repo repository.AuditLogRepository
+
+	// OnError 寫入或快照失敗時呼叫，預設寫入log
+	OnError func(entry mapper.AuditEntryData, err error)
+}
+
+// NewRecorder 創建稽核紀錄器
+func NewRecorder(repo repository.AuditLogRepository) *Recorder {
+	return &Recorder{
+		repo: repo,
+		OnError: func(entry mapper.AuditEntryData, err error) {
+			log.Printf("audit: failed to record %s on %s %s (request %s): %v",
+				entry.Command, entry.AggregateType, entry.AggregateID, entry.RequestID, err)
+		},
+	}
+}
+
+// Record 寫入一筆稽核紀錄
+func (r *Recorder) Record(entry mapper.AuditEntryData) {
+	if _, err := r.repo.Append(entry); err != nil {
+		r.reportError(entry, err)
+	}
+}
+
+func (r *Recorder) reportError(entry mapper.AuditEntryData, err error) {
+	if r.OnError != nil {
+		r.OnError(entry, err)
+	}
+}
+
+// Command 以稽核包裝Command Use Case
+// 執行成功後為每個受影響的聚合寫入一筆含前後快照的紀錄；失敗的Command不會改變資料，因此不記錄。
+// 稽核紀錄寫入失敗只回報給OnError，不會讓已完成的Command變成失敗；
+// 修改錢包的Command改用WalletCommand，在儲存錢包的同一個交易中寫入
+type Command[I Input] struct {
+	next     Executor[I]
+	recorder *Recorder
+	spec     Spec[I]
+}
+
+// NewCommand 創建稽核包裝，回傳值滿足與next相同的Use Case介面
+func NewCommand[I Input](next Executor[I], recorder *Recorder, spec Spec[I]) *Command[I] {
+	return &Command[I]{next: next, recorder: recorder, spec: spec}
+}
+
+func (c *Command[I]) Execute(input I) common.Output {
+	var targets []string
+	if c.spec.Targets != nil {
+		targets = c.spec.Targets(input)
+	}
+	before := make([][]byte, len(targets))
+	for i, aggregateID := range targets {
+		before[i] = c.snapshot(aggregateID)
+	}
+
+	output := c.next.Execute(input)
+	if output.GetExitCode() != common.Success {
+		return output
+	}
+
+	if c.spec.Targets == nil && output.GetID() != "" {
+		targets = []string{output.GetID()}
+		before = [][]byte{nil}
+	}
+
+	metadata := input.Metadata()
+	for i, aggregateID := range targets {
+		c.recorder.Record(mapper.AuditEntryData{
+			ID:            uuid.NewString(),
+			ActorID:       metadata.ActorID,
+			Command:       c.spec.Command,
+			AggregateType: c.spec.Aggregate.AggregateType(),
+			AggregateID:   aggregateID,
+			Before:        before[i],
+			After:         c.snapshot(aggregateID),
+			RequestID:     metadata.RequestID,
+			OccurredAt:    time.Now(),
+		})
+	}
+	return output
+}
+
+// snapshot 取得聚合快照的JSON；失敗時回報並以nil記錄，仍保留誰在何時做了什麼
+func (c *Command[I]) snapshot(aggregateID string) []byte {
+	data, err := c.spec.Aggregate.Snapshot(aggregateID)
+	if err == nil && data != nil {
+		var snapshot []byte
+		if snapshot, err = json.Marshal(data); err == nil {
+			return snapshot
+		}
+	}
+	if err != nil {
+		c.recorder.reportError(mapper.AuditEntryData{
+			Command:       c.spec.Command,
+			AggregateType: c.spec.Aggregate.AggregateType(),
+			AggregateID:   aggregateID,
+		}, fmt.Errorf("snapshot failed: %w", err))
+	}
+	return nil
+}
+
+// WalletRepositories 帶有稽核資訊的錢包儲存庫與Unit of Work，透過它們儲存的錢包都會寫入稽核紀錄
+type WalletRepositories struct {
+	Wallets    repository.WalletRepository
+	UnitOfWork repository.UnitOfWork
+}
+
+// WalletCommand 以稽核包裝修改錢包的Command Use Case
+// 每次執行以帶有Command資訊的儲存庫建立Use Case：每次儲存或刪除錢包都在同一個交易中寫入一筆紀錄，
+// 快照取自儲存中的聚合 (錢包欄位與此次變更的交易記錄)。紀錄寫入失敗時儲存一併回滾，Command回傳失敗
+type WalletCommand[I Input] struct {
+	command    string
+	wallets    repository.AuditableWalletRepository
+	unitOfWork repository.AuditableUnitOfWork
+	build      func(repos WalletRepositories) Executor[I]
+}
+
+// NewWalletCommand 創建錢包Command的稽核包裝，build以帶有稽核資訊的儲存庫建立Use Case
+// 不使用Unit of Work的Command，unitOfWork可為nil
+func NewWalletCommand[I Input](command string, wallets repository.AuditableWalletRepository, unitOfWork repository.AuditableUnitOfWork,
+	build func(repos WalletRepositories) Executor[I]) *WalletCommand[I] {
+	return &WalletCommand[I]{command: command, wallets: wallets, unitOfWork: unitOfWork, build: build}
+}
+
+func (c *WalletCommand[I]) Execute(input I) common.Output {
+	metadata := input.Metadata()
+	ctx := repository.AuditContext{Command: c.command, ActorID: metadata.ActorID, RequestID: metadata.RequestID}
+
+	repos := WalletRepositories{Wallets: c.wallets.WithAudit(ctx)}
+	if c.unitOfWork != nil {
+		repos.UnitOfWork = c.unitOfWork.WithAudit(ctx)
+	}
+	return c.build(repos).Execute(input)
+}
@@ -0,0 +1,242 @@
+package audit
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+)
+
+// Snapshotter 取得聚合目前狀態的快照 (mapper資料結構)
+type Snapshotter interface {
+	// AggregateType 稽核紀錄上的聚合類型 (mapper.AuditAggregate*)
+	AggregateType() string
+
+	// Snapshot 回傳聚合的持久化資料，聚合不存在時回傳nil
+	Snapshot(aggregateID string) (interface{}, error)
+}
+
+// ExpenseCategorySnapshots 以 mapper.ExpenseCategoryData 作為支出分類快照
+type ExpenseCategorySnapshots struct {
+	repo   repository.ExpenseCategoryRepository
+	mapper *mapper.ExpenseCategoryMapper
+}
+
+// NewExpenseCategorySnapshots 創建支出分類快照來源
+func NewExpenseCategorySnapshots(repo repository.ExpenseCategoryRepository) *ExpenseCategorySnapshots {
+	return &ExpenseCategorySnapshots{repo: repo, mapper: mapper.NewExpenseCategoryMapper()}
+}
+
+func (s *ExpenseCategorySnapshots) AggregateType() string {
+	return mapper.AuditAggregateExpenseCategory
+}
+
+func (s *ExpenseCategorySnapshots) Snapshot(categoryID string) (interface{}, error) {
+	category, err := s.repo.FindByID(categoryID)
+	if err != nil || category == nil {
+		return nil, err
+	}
+	return s.mapper.ToData(category), nil
+}
+
+// IncomeCategorySnapshots 以 mapper.IncomeCategoryData 作為收入分類快照
+type IncomeCategorySnapshots struct {
+	repo   repository.IncomeCategoryRepository
+	mapper *mapper.IncomeCategoryMapper
+}
+
+// NewIncomeCategorySnapshots 創建收入分類快照來源
+func NewIncomeCategorySnapshots(repo repository.IncomeCategoryRepository) *IncomeCategorySnapshots {
+	return &IncomeCategorySnapshots{repo: repo, mapper: mapper.NewIncomeCategoryMapper()}
+}
+
+func (s *IncomeCategorySnapshots) AggregateType() string {
+	return mapper.AuditAggregateIncomeCategory
+}
+
+func (s *IncomeCategorySnapshots) Snapshot(categoryID string) (interface{}, error) {
+	category, err := s.repo.FindByID(categoryID)
+	if err != nil || category == nil {
+		return nil, err
+	}
+	return s.mapper.ToData(category), nil
+}
+
+// UserCategoriesSnapshot 使用者全部分類的快照
+type UserCategoriesSnapshot struct {
+	Expense []mapper.ExpenseCategoryData
+	Income  []mapper.IncomeCategoryData
+}
+
+// UserCategoriesSnapshots 以使用者ID為聚合ID，快照其全部分類 (用於一次建立多個分類的Command)
+type UserCategoriesSnapshots struct {
+	expenseRepo   repository.ExpenseCategoryRepository
+	incomeRepo    repository.IncomeCategoryRepository
+	expenseMapper *mapper.ExpenseCategoryMapper
+	incomeMapper  *mapper.IncomeCategoryMapper
+}
+
+// NewUserCategoriesSnapshots 創建使用者分類快照來源
+func NewUserCategoriesSnapshots(expenseRepo repository.ExpenseCategoryRepository, incomeRepo repository.IncomeCategoryRepository) *UserCategoriesSnapshots {
+	return &UserCategoriesSnapshots{
+		expenseRepo:   expenseRepo,
+		incomeRepo:    incomeRepo,
+		expenseMapper: mapper.NewExpenseCategoryMapper(),
+		incomeMapper:  mapper.NewIncomeCategoryMapper(),
+	}
+}
+
+func (s *UserCategoriesSnapshots) AggregateType() string {
+	return mapper.AuditAggregateUserCategories
+}
+
+func (s *UserCategoriesSnapshots) Snapshot(userID string) (interface{}, error) {
+	expenseCategories, err := s.expenseRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	incomeCategories, err := s.incomeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := UserCategoriesSnapshot{
+		Expense: make([]mapper.ExpenseCategoryData, len(expenseCategories)),
+		Income:  make([]mapper.IncomeCategoryData, len(incomeCategories)),
+	}
+	for i, category := range expenseCategories {
+		snapshot.Expense[i] = s.expenseMapper.ToData(category)
+	}
+	for i, category := range incomeCategories {
+		snapshot.Income[i] = s.incomeMapper.ToData(category)
+	}
+	return snapshot, nil
+}
+
+// BudgetSnapshots 以 mapper.BudgetData 作為預算快照
+type BudgetSnapshots struct {
+	repo   repository.BudgetRepository
+	mapper *mapper.BudgetMapper
+}
+
+// NewBudgetSnapshots 創建預算快照來源
+func NewBudgetSnapshots(repo repository.BudgetRepository) *BudgetSnapshots {
+	return &BudgetSnapshots{repo: repo, mapper: mapper.NewBudgetMapper()}
+}
+
+func (s *BudgetSnapshots) AggregateType() string {
+	return mapper.AuditAggregateBudget
+}
+
+func (s *BudgetSnapshots) Snapshot(budgetID string) (interface{}, error) {
+	budget, err := s.repo.FindByID(budgetID)
+	if err != nil || budget == nil {
+		return nil, err
+	}
+	return s.mapper.ToData(budget), nil
+}
+
+// RecurringRuleSnapshots 以 mapper.RecurringRuleData (含發生日記錄) 作為週期規則快照
+type RecurringRuleSnapshots struct {
+	repo   repository.RecurringRuleRepository
+	mapper *mapper.RecurringRuleMapper
+}
+
+// NewRecurringRuleSnapshots 創建週期規則快照來源
+func NewRecurringRuleSnapshots(repo repository.RecurringRuleRepository) *RecurringRuleSnapshots {
+	return &RecurringRuleSnapshots{repo: repo, mapper: mapper.NewRecurringRuleMapper()}
+}
+
+func (s *RecurringRuleSnapshots) AggregateType() string {
+	return mapper.AuditAggregateRecurringRule
+}
+
+func (s *RecurringRuleSnapshots) Snapshot(ruleID string) (interface{}, error) {
+	rule, err := s.repo.FindByID(ruleID)
+	if err != nil || rule == nil {
+		return nil, err
+	}
+	return s.mapper.ToData(rule), nil
+}
+
+// AttachmentSnapshots 以 mapper.AttachmentData 作為附件快照 (只含中繼資料，不含檔案內容)
+type AttachmentSnapshots struct {
+	repo   repository.AttachmentRepository
+	mapper *mapper.AttachmentMapper
+}
+
+// NewAttachmentSnapshots 創建附件快照來源
+func NewAttachmentSnapshots(repo repository.AttachmentRepository) *AttachmentSnapshots {
+	return &AttachmentSnapshots{repo: repo, mapper: mapper.NewAttachmentMapper()}
+}
+
+func (s *AttachmentSnapshots) AggregateType() string {
+	return mapper.AuditAggregateAttachment
+}
+
+func (s *AttachmentSnapshots) Snapshot(attachmentID string) (interface{}, error) {
+	attachment, err := s.repo.FindByID(attachmentID)
+	if err != nil || attachment == nil {
+		return nil, err
+	}
+	return s.mapper.ToData(attachment), nil
+}
+
+// ImportProfileSnapshots 以 mapper.ImportProfileData 作為匯入設定快照
+type ImportProfileSnapshots struct {
+	repo   repository.ImportProfileRepository
+	mapper *mapper.ImportProfileMapper
+}
+
+// NewImportProfileSnapshots 創建匯入設定快照來源
+func NewImportProfileSnapshots(repo repository.ImportProfileRepository) *ImportProfileSnapshots {
+	return &ImportProfileSnapshots{repo: repo, mapper: mapper.NewImportProfileMapper()}
+}
+
+func (s *ImportProfileSnapshots) AggregateType() string {
+	return mapper.AuditAggregateImportProfile
+}
+
+func (s *ImportProfileSnapshots) Snapshot(profileID string) (interface{}, error) {
+	profile, err := s.repo.FindByID(profileID)
+	if err != nil || profile == nil {
+		return nil, err
+	}
+	return s.mapper.ToData(profile), nil
+}
+
+// CategorizationRuleSnapshots 以 mapper.CategorizationRuleData 作為分類規則快照
+type CategorizationRuleSnapshots struct {
+	repo   repository.CategorizationRuleRepository
+	mapper *mapper.CategorizationRuleMapper
+}
+
+// NewCategorizationRuleSnapshots 創建分類規則快照來源
+func NewCategorizationRuleSnapshots(repo repository.CategorizationRuleRepository) *CategorizationRuleSnapshots {
+	return &CategorizationRuleSnapshots{repo: repo, mapper: mapper.NewCategorizationRuleMapper()}
+}
+
+func (s *CategorizationRuleSnapshots) AggregateType() string {
+	return mapper.AuditAggregateCategorizationRule
+}
+
+func (s *CategorizationRuleSnapshots) Snapshot(ruleID string) (interface{}, error) {
+	rule, err := s.repo.FindByID(ruleID)
+	if err != nil || rule == nil {
+		return nil, err
+	}
+	return s.mapper.ToData(rule), nil
+}
+
+// WithoutSnapshot 只記錄聚合ID、不保存快照 (例如API金鑰，避免把金鑰雜湊寫進稽核紀錄)
+func WithoutSnapshot(aggregateType string) Snapshotter {
+	return noSnapshot(aggregateType)
+}
+
+type noSnapshot string
+
+func (s noSnapshot) AggregateType() string {
+	return string(s)
+}
+
+func (s noSnapshot) Snapshot(string) (interface{}, error) {
+	return nil, nil
+}
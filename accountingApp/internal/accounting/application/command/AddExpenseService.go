@@ -2,6 +2,8 @@ package command
 
 import (
 	"fmt"
+	"log"
+
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
@@ -9,54 +11,207 @@ import (
 )
 
 type AddExpenseService struct {
-	walletRepo repository.WalletRepository
+	walletRepo    repository.WalletRepository
+	categoryRepo  repository.ExpenseCategoryRepository    // 可為nil：不驗證子分類是否存在
+	budgetChecker usecase.CheckBudgetWarningsUseCase      // 可為nil：不檢查預算
+	ruleRepo      repository.CategorizationRuleRepository // 可為nil：未指定子分類時不自動分類
 }
 
-func NewAddExpenseService(walletRepo repository.WalletRepository) *AddExpenseService {
+func NewAddExpenseService(walletRepo repository.WalletRepository, categoryRepo repository.ExpenseCategoryRepository, budgetChecker usecase.CheckBudgetWarningsUseCase, ruleRepo repository.CategorizationRuleRepository) *AddExpenseService {
 	return &AddExpenseService{
-		walletRepo: walletRepo,
+		walletRepo:    walletRepo,
+		categoryRepo:  categoryRepo,
+		budgetChecker: budgetChecker,
+		ruleRepo:      ruleRepo,
 	}
 }
 
 func (s *AddExpenseService) Execute(input usecase.AddExpenseInput) common.Output {
+	input, failure := s.categorize(input)
+	if failure != nil {
+		return failure
+	}
+
+	added := true
+	output := retryOnConflict(func() common.Output {
+		var output common.Output
+		output, added = s.execute(input)
+		return output
+	})
+	if output.GetExitCode() != common.Success || !added || s.budgetChecker == nil {
+		return output
+	}
+
+	// 6. 支出已儲存，檢查是否使預算跨過警告門檻；檢查失敗不影響支出本身
+	result := usecase.AddExpenseOutput{
+		ID:       output.GetID(),
+		ExitCode: output.GetExitCode(),
+		Message:  output.GetMessage(),
+	}
+	check := s.budgetChecker.Execute(usecase.CheckBudgetWarningsInput{
+		UserID:        input.UserID,
+		SubcategoryID: input.SubcategoryID,
+		Amount:        input.Amount,
+		Currency:      input.Currency,
+		Date:          input.Date,
+		Splits:        input.Splits,
+	})
+	if warnings, ok := check.(usecase.CheckBudgetWarningsOutput); ok && check.GetExitCode() == common.Success {
+		result.Warnings = warnings.Warnings
+	} else {
+		log.Printf("budget check after expense %s failed: %s", output.GetID(), check.GetMessage())
+	}
+	return result
+}
+
+// categorize 未指定子分類 (也沒有拆帳) 時，以第一條符合的分類規則填入子分類並加上規則的標籤
+func (s *AddExpenseService) categorize(input usecase.AddExpenseInput) (usecase.AddExpenseInput, common.Output) {
+	if s.ruleRepo == nil || input.SubcategoryID != "" || len(input.Splits) > 0 {
+		return input, nil
+	}
+
+	rules, err := s.ruleRepo.FindByUserID(input.UserID)
+	if err != nil {
+		return input, common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find categorization rules: %v", err),
+		}
+	}
+	rule := model.NewCategorizationRuleSet(rules).Match(model.RuleSample{
+		Type:        model.CategorizeExpense,
+		WalletID:    input.WalletID,
+		Description: input.Description,
+		Amount:      model.Money{Amount: input.Amount, Currency: input.Currency},
+		Date:        input.Date,
+	})
+	if rule == nil {
+		return input, common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  "subcategory_id is required: no categorization rule matches the expense",
+		}
+	}
+
+	input.SubcategoryID = rule.SubcategoryID
+	input.Tags = append(append([]string(nil), input.Tags...), rule.Tags...)
+	return input, nil
+}
+
+// execute 新增並儲存支出；added為false表示發生日已有支出，回傳的是既有支出
+func (s *AddExpenseService) execute(input usecase.AddExpenseInput) (output common.Output, added bool) {
 	// 1. 透過Repository取得錢包 (可能需要完整聚合取決於業務需求)
 	wallet, err := s.walletRepo.FindByIDWithTransactions(input.WalletID)
 	if err != nil {
 		return common.UseCaseOutput{
 			ExitCode: common.Failure,
 			Message:  fmt.Sprintf("wallet not found: %v", err),
-		}
+		}, false
+	}
+	if wallet == nil || !common.IsAccessibleBy(wallet.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Wallet not found",
+		}, false
 	}
 
-	// 2. 建立金額物件
+	// 2. 驗證子分類存在 (拆帳時驗證每一行)
+	if failure := s.validateSubcategories(input); failure != nil {
+		return failure, false
+	}
+
+	// 3. 建立金額物件
 	amount, err := model.NewMoney(input.Amount, input.Currency)
 	if err != nil {
 		return common.UseCaseOutput{
 			ExitCode: common.Failure,
 			Message:  fmt.Sprintf("invalid amount: %v", err),
-		}
+		}, false
+	}
+	tags, err := initialTags(input.Tags)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("invalid tags: %v", err),
+		}, false
 	}
 
-	// 3. 透過Domain Model執行業務邏輯
-	expense, err := wallet.AddExpense(*amount, input.SubcategoryID, input.Description, input.Date)
+	// 4. 透過Domain Model執行業務邏輯 (有拆帳明細時分攤到各子分類)
+	var expense *model.ExpenseRecord
+	if len(input.Splits) > 0 {
+		var splits []model.ExpenseSplit
+		if splits, err = toExpenseSplits(input.SubcategoryID, input.Splits, input.Currency); err != nil {
+			return common.UseCaseOutput{
+				ExitCode: common.Failure,
+				Message:  fmt.Sprintf("invalid split: %v", err),
+			}, false
+		}
+		expense, err = wallet.AddSplitExpense(*amount, splits, input.Description, input.Date)
+	} else if input.OccurrenceKey != "" {
+		expense, added, err = wallet.AddRecurringExpense(input.OccurrenceKey, *amount, input.SubcategoryID, input.Description, input.Date)
+		if err == nil && !added {
+			return common.UseCaseOutput{
+				ID:       expense.ID,
+				ExitCode: common.Success,
+				Message:  "Expense already added for this occurrence",
+			}, false
+		}
+	} else {
+		expense, err = wallet.AddExpense(*amount, input.SubcategoryID, input.Description, input.Date)
+	}
 	if err != nil {
 		return common.UseCaseOutput{
 			ExitCode: common.Failure,
 			Message:  fmt.Sprintf("failed to add expense: %v", err),
-		}
+		}, false
+	}
+	if _, err := wallet.EditExpenseTags(expense.ID, tags); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("invalid tags: %v", err),
+		}, false
 	}
 
-	// 4. 儲存完整聚合 (包含新的交易記錄)
+	// 5. 儲存完整聚合 (包含新的交易記錄)
 	if err := s.walletRepo.Save(wallet); err != nil {
 		return common.UseCaseOutput{
-			ExitCode: common.Failure,
+			ExitCode: saveFailureExitCode(err),
 			Message:  fmt.Sprintf("failed to save wallet: %v", err),
-		}
+		}, false
 	}
 
 	return common.UseCaseOutput{
 		ID:       expense.ID,
 		ExitCode: common.Success,
 		Message:  "Expense added successfully",
+	}, true
+}
+
+// validateSubcategories 確認子分類屬於呼叫者的某個支出分類
+func (s *AddExpenseService) validateSubcategories(input usecase.AddExpenseInput) common.Output {
+	if s.categoryRepo == nil {
+		return nil
+	}
+
+	subcategoryIDs := []string{input.SubcategoryID}
+	if len(input.Splits) > 0 {
+		subcategoryIDs = subcategoryIDs[:0]
+		for _, split := range input.Splits {
+			subcategoryIDs = append(subcategoryIDs, split.SubcategoryID)
+		}
+	}
+	for _, subcategoryID := range subcategoryIDs {
+		category, err := s.categoryRepo.FindBySubcategoryID(subcategoryID)
+		if err != nil {
+			return common.UseCaseOutput{
+				ExitCode: common.Failure,
+				Message:  fmt.Sprintf("Failed to find category for subcategory: %v", err),
+			}
+		}
+		if category == nil || !common.IsAccessibleBy(category.UserID, input.UserID) {
+			return common.UseCaseOutput{
+				ExitCode: common.NotFound,
+				Message:  "Subcategory not found in any category",
+			}
+		}
 	}
+	return nil
 }
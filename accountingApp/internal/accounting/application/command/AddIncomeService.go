@@ -9,54 +9,109 @@ import (
 )
 
 type AddIncomeService struct {
-	walletRepo repository.WalletRepository
+	walletRepo   repository.WalletRepository
+	categoryRepo repository.IncomeCategoryRepository // 可為nil：不驗證子分類是否存在
 }
 
-func NewAddIncomeService(walletRepo repository.WalletRepository) *AddIncomeService {
+func NewAddIncomeService(walletRepo repository.WalletRepository, categoryRepo repository.IncomeCategoryRepository) *AddIncomeService {
 	return &AddIncomeService{
-		walletRepo: walletRepo,
+		walletRepo:   walletRepo,
+		categoryRepo: categoryRepo,
 	}
 }
 
 func (s *AddIncomeService) Execute(input usecase.AddIncomeInput) common.Output {
-	// 1. 驗證錢包存在
-	wallet, err := s.walletRepo.FindByID(input.WalletID)
+	return retryOnConflict(func() common.Output {
+		return s.execute(input)
+	})
+}
+
+func (s *AddIncomeService) execute(input usecase.AddIncomeInput) common.Output {
+	// 1. 驗證錢包存在 (週期規則的發生日需要載入交易記錄以找回已產生的收入)
+	findWallet := s.walletRepo.FindByID
+	if input.OccurrenceKey != "" {
+		findWallet = s.walletRepo.FindByIDWithTransactions
+	}
+	wallet, err := findWallet(input.WalletID)
 	if err != nil {
 		return common.UseCaseOutput{
 			ExitCode: common.Failure,
 			Message:  fmt.Sprintf("Failed to find wallet: %v", err),
 		}
 	}
-	if wallet == nil {
+	if wallet == nil || !common.IsAccessibleBy(wallet.UserID, input.UserID) {
 		return common.UseCaseOutput{
-			ExitCode: common.Failure,
+			ExitCode: common.NotFound,
 			Message:  "Wallet not found",
 		}
 	}
 
-	// 2. 建立金額 Value Object
+	// 2. 驗證子分類屬於呼叫者的某個收入分類
+	if s.categoryRepo != nil {
+		category, err := s.categoryRepo.FindBySubcategoryID(input.SubcategoryID)
+		if err != nil {
+			return common.UseCaseOutput{
+				ExitCode: common.Failure,
+				Message:  fmt.Sprintf("Failed to find category for subcategory: %v", err),
+			}
+		}
+		if category == nil || !common.IsAccessibleBy(category.UserID, input.UserID) {
+			return common.UseCaseOutput{
+				ExitCode: common.NotFound,
+				Message:  "Subcategory not found in any category",
+			}
+		}
+	}
+
+	// 3. 建立金額 Value Object
 	amount, err := model.NewMoney(input.Amount, input.Currency)
 	if err != nil {
 		return common.UseCaseOutput{
-			ExitCode: common.Failure,
+			ExitCode: common.InvalidInput,
 			Message:  fmt.Sprintf("Invalid amount: %v", err),
 		}
 	}
+	tags, err := initialTags(input.Tags)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid tags: %v", err),
+		}
+	}
 
-	// 3. 透過錢包聚合根新增收入
-	income, err := wallet.AddIncome(*amount, input.SubcategoryID, input.Description, input.Date)
+	// 4. 透過錢包聚合根新增收入
+	var income *model.IncomeRecord
+	if input.OccurrenceKey != "" {
+		var added bool
+		income, added, err = wallet.AddRecurringIncome(input.OccurrenceKey, *amount, input.SubcategoryID, input.Description, input.Date)
+		if err == nil && !added {
+			return common.UseCaseOutput{
+				ID:       income.ID,
+				ExitCode: common.Success,
+				Message:  "Income already added for this occurrence",
+			}
+		}
+	} else {
+		income, err = wallet.AddIncome(*amount, input.SubcategoryID, input.Description, input.Date)
+	}
 	if err != nil {
 		return common.UseCaseOutput{
 			ExitCode: common.Failure,
 			Message:  fmt.Sprintf("Adding income failed: %v", err),
 		}
 	}
+	if _, err := wallet.EditIncomeTags(income.ID, tags); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid tags: %v", err),
+		}
+	}
 
-	// 4. 持久化錢包聚合 (包括新增的收入記錄)
+	// 5. 持久化錢包聚合 (包括新增的收入記錄)
 	err = s.walletRepo.Save(wallet)
 	if err != nil {
 		return common.UseCaseOutput{
-			ExitCode: common.Failure,
+			ExitCode: saveFailureExitCode(err),
 			Message:  fmt.Sprintf("Saving wallet failed: %v", err),
 		}
 	}
@@ -0,0 +1,65 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+type AddIncomeSubcategoryService struct {
+	repo repository.IncomeCategoryRepository
+}
+
+func NewAddIncomeSubcategoryService(repo repository.IncomeCategoryRepository) *AddIncomeSubcategoryService {
+	return &AddIncomeSubcategoryService{repo: repo}
+}
+
+func (s *AddIncomeSubcategoryService) Execute(input usecase.AddIncomeSubcategoryInput) common.Output {
+	// 1. 載入分類聚合
+	category, err := s.repo.FindByID(input.CategoryID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find income category: %v", err),
+		}
+	}
+	if category == nil || !common.IsAccessibleBy(category.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Income category not found",
+		}
+	}
+
+	// 2. 透過聚合根新增子分類 (名稱不可重複)
+	categoryName, err := model.NewCategoryName(input.Name)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid category name: %v", err),
+		}
+	}
+
+	subcategory, err := category.AddSubcategory(*categoryName)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Adding subcategory failed: %v", err),
+		}
+	}
+
+	// 3. 儲存聚合
+	if err := s.repo.Save(category); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving income category failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       subcategory.ID,
+		ExitCode: common.Success,
+		Message:  "Subcategory added successfully",
+	}
+}
@@ -0,0 +1,107 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/statement"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// CommitImportService 將帳單 (CSV、OFX/QFX、QIF) 的資料列記錄為錢包的支出與收入
+// 所有可匯入的列在同一次儲存中寫入；先前已匯入的交易略過，無法解析或記錄的列 (例如餘額不足) 回報為拒絕
+type CommitImportService struct {
+	walletRepo repository.WalletRepository
+	importer   *statement.Importer
+}
+
+func NewCommitImportService(
+	walletRepo repository.WalletRepository,
+	profileRepo repository.ImportProfileRepository,
+	expenseCategoryRepo repository.ExpenseCategoryRepository,
+	incomeCategoryRepo repository.IncomeCategoryRepository,
+	ruleRepo repository.CategorizationRuleRepository,
+) *CommitImportService {
+	return &CommitImportService{
+		walletRepo: walletRepo,
+		importer:   statement.NewImporter(profileRepo, expenseCategoryRepo, incomeCategoryRepo, ruleRepo),
+	}
+}
+
+func (s *CommitImportService) Execute(input usecase.CommitImportInput) common.Output {
+	// 1. 取得格式、對應設定與分類規則
+	format, mapping, output := s.importer.Mapping(input.UserID, input.StatementFile)
+	if output != nil {
+		return output
+	}
+	rules, output := s.importer.Rules(input.UserID)
+	if output != nil {
+		return output
+	}
+
+	// 2. 確認錢包並依錢包幣別解析帳單 (內容只能讀取一次，重試時沿用解析結果)
+	wallet, err := s.walletRepo.FindByID(input.WalletID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find wallet: %v", err),
+		}
+	}
+	if wallet == nil || !common.IsAccessibleBy(wallet.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Wallet not found",
+		}
+	}
+	parsed, output := statement.Parse(format, input.StatementFile, mapping, wallet.Currency())
+	if output != nil {
+		return output
+	}
+
+	// 3. 記錄到錢包，樂觀鎖衝突時重新載入並重試
+	return retryOnConflict(func() common.Output {
+		return s.record(input, mapping, rules, parsed)
+	})
+}
+
+// record 載入完整錢包 (判斷重複匯入需要既有交易)、逐列新增交易後一次儲存
+func (s *CommitImportService) record(input usecase.CommitImportInput, mapping model.ImportMapping, rules model.CategorizationRuleSet, parsed *statement.Result) common.Output {
+	wallet, err := s.walletRepo.FindByIDWithTransactions(input.WalletID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find wallet: %v", err),
+		}
+	}
+	if wallet == nil || !common.IsAccessibleBy(wallet.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Wallet not found",
+		}
+	}
+
+	applied := statement.Apply(wallet, mapping, rules, parsed)
+
+	// 沒有新增的列時不儲存
+	if len(applied.Rows) > 0 {
+		if err := s.walletRepo.Save(wallet); err != nil {
+			return common.UseCaseOutput{
+				ExitCode: saveFailureExitCode(err),
+				Message:  fmt.Sprintf("failed to save wallet: %v", err),
+			}
+		}
+	}
+
+	return usecase.CommitImportOutput{
+		ID:         wallet.ID,
+		ExitCode:   common.Success,
+		Message:    fmt.Sprintf("Imported %d rows, skipped %d already imported, rejected %d", len(applied.Rows), len(applied.Skipped), len(applied.Rejected)),
+		Imported:   len(applied.Rows),
+		Rows:       applied.Rows,
+		Skipped:    applied.Skipped,
+		Rejected:   applied.Rejected,
+		Statements: applied.Statements,
+	}
+}
@@ -0,0 +1,42 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// CreateAPIKeyService 為使用者產生個人API金鑰，明文只在此輸出中回傳一次
+type CreateAPIKeyService struct {
+	repo repository.APIKeyRepository
+}
+
+func NewCreateAPIKeyService(repo repository.APIKeyRepository) *CreateAPIKeyService {
+	return &CreateAPIKeyService{repo: repo}
+}
+
+func (s *CreateAPIKeyService) Execute(input usecase.CreateAPIKeyInput) common.Output {
+	key, plaintext, err := model.NewAPIKey(input.UserID, input.Name)
+	if err != nil {
+		return usecase.CreateAPIKeyOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid API key: %v", err),
+		}
+	}
+
+	if err := s.repo.Save(key); err != nil {
+		return usecase.CreateAPIKeyOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving API key failed: %v", err),
+		}
+	}
+
+	return usecase.CreateAPIKeyOutput{
+		ID:       key.ID,
+		ExitCode: common.Success,
+		Message:  "API key created successfully",
+		Key:      plaintext,
+	}
+}
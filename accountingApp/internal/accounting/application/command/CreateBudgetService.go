@@ -0,0 +1,112 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// CreateBudgetService 建立分類或子分類的預算，範圍必須是使用者自己的支出分類
+type CreateBudgetService struct {
+	repo         repository.BudgetRepository
+	categoryRepo repository.ExpenseCategoryRepository
+}
+
+func NewCreateBudgetService(repo repository.BudgetRepository, categoryRepo repository.ExpenseCategoryRepository) *CreateBudgetService {
+	return &CreateBudgetService{repo: repo, categoryRepo: categoryRepo}
+}
+
+func (s *CreateBudgetService) Execute(input usecase.CreateBudgetInput) common.Output {
+	// 1. 驗證週期與金額
+	period, err := model.ParseBudgetPeriod(input.Period)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid budget: %v", err),
+		}
+	}
+
+	amount, err := model.NewMoney(input.Amount, input.Currency)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid budget amount: %v", err),
+		}
+	}
+
+	// 2. 範圍必須屬於使用者
+	scope := model.BudgetScope{CategoryID: input.CategoryID, SubcategoryID: input.SubcategoryID}
+	if output := verifyBudgetScope(s.categoryRepo, scope, input.UserID); output != nil {
+		return output
+	}
+
+	// 3. 建立預算聚合
+	budget, err := model.NewBudget(input.UserID, input.Name, period, scope, *amount, input.StartDate, input.EndDate)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid budget: %v", err),
+		}
+	}
+	budget.SetRollover(input.Rollover)
+	if input.WarningThreshold != nil {
+		if err := budget.SetWarningThreshold(*input.WarningThreshold); err != nil {
+			return common.UseCaseOutput{
+				ExitCode: common.InvalidInput,
+				Message:  fmt.Sprintf("Invalid budget: %v", err),
+			}
+		}
+	}
+
+	// 4. 儲存
+	if err := s.repo.Save(budget); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving budget failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       budget.ID,
+		ExitCode: common.Success,
+		Message:  "Budget created successfully",
+	}
+}
+
+// verifyBudgetScope 確認預算範圍的分類或子分類存在且屬於使用者，通過時回傳nil
+func verifyBudgetScope(categoryRepo repository.ExpenseCategoryRepository, scope model.BudgetScope, userID string) common.Output {
+	var category *model.ExpenseCategory
+	var err error
+	switch {
+	case scope.CategoryID != "" && scope.SubcategoryID != "":
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  "Invalid budget: specify either a category or a subcategory, not both",
+		}
+	case scope.CategoryID != "":
+		category, err = categoryRepo.FindByID(scope.CategoryID)
+	case scope.SubcategoryID != "":
+		category, err = categoryRepo.FindBySubcategoryID(scope.SubcategoryID)
+	default:
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  "Invalid budget: a category or subcategory is required",
+		}
+	}
+
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find expense category: %v", err),
+		}
+	}
+	if category == nil || !common.IsAccessibleBy(category.UserID, userID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Expense category not found",
+		}
+	}
+	return nil
+}
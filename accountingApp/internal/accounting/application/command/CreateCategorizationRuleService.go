@@ -0,0 +1,72 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// CreateCategorizationRuleService 建立分類規則；指定的子分類與條件中的錢包必須屬於使用者
+type CreateCategorizationRuleService struct {
+	repo    repository.CategorizationRuleRepository
+	targets ruleTargets
+}
+
+func NewCreateCategorizationRuleService(
+	repo repository.CategorizationRuleRepository,
+	walletRepo repository.WalletRepository,
+	expenseCategoryRepo repository.ExpenseCategoryRepository,
+	incomeCategoryRepo repository.IncomeCategoryRepository,
+) *CreateCategorizationRuleService {
+	return &CreateCategorizationRuleService{
+		repo:    repo,
+		targets: ruleTargets{walletRepo: walletRepo, expenseCategoryRepo: expenseCategoryRepo, incomeCategoryRepo: incomeCategoryRepo},
+	}
+}
+
+func (s *CreateCategorizationRuleService) Execute(input usecase.CreateCategorizationRuleInput) common.Output {
+	// 1. 建立分類規則聚合 (檢查條件與正規表示式)
+	ruleType, err := model.ParseCategorizationRuleType(input.Type)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid categorization rule: %v", err),
+		}
+	}
+	conditions, err := toRuleConditions(input.Conditions)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid categorization rule: %v", err),
+		}
+	}
+	rule, err := model.NewCategorizationRule(input.UserID, input.Name, ruleType, input.Priority, conditions, input.SubcategoryID, input.Tags)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid categorization rule: %v", err),
+		}
+	}
+
+	// 2. 子分類與錢包必須屬於使用者
+	if output := s.targets.verify(rule, input.UserID); output != nil {
+		return output
+	}
+
+	// 3. 儲存
+	if err := s.repo.Save(rule); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving categorization rule failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       rule.ID,
+		ExitCode: common.Success,
+		Message:  "Categorization rule created successfully",
+	}
+}
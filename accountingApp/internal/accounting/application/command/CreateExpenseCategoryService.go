@@ -21,7 +21,7 @@ func (s *CreateExpenseCategoryService) Execute(input usecase.CreateExpenseCatego
 	categoryName, err := model.NewCategoryName(input.Name)
 	if err != nil {
 		return common.UseCaseOutput{
-			ExitCode: common.Failure,
+			ExitCode: common.InvalidInput,
 			Message:  fmt.Sprintf("Invalid category name: %v", err),
 		}
 	}
@@ -0,0 +1,60 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/statement"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// CreateImportProfileService 儲存使用者的帳單欄位對應，子分類必須是使用者自己的
+type CreateImportProfileService struct {
+	repo                repository.ImportProfileRepository
+	expenseCategoryRepo repository.ExpenseCategoryRepository
+	incomeCategoryRepo  repository.IncomeCategoryRepository
+}
+
+func NewCreateImportProfileService(
+	repo repository.ImportProfileRepository,
+	expenseCategoryRepo repository.ExpenseCategoryRepository,
+	incomeCategoryRepo repository.IncomeCategoryRepository,
+) *CreateImportProfileService {
+	return &CreateImportProfileService{
+		repo:                repo,
+		expenseCategoryRepo: expenseCategoryRepo,
+		incomeCategoryRepo:  incomeCategoryRepo,
+	}
+}
+
+func (s *CreateImportProfileService) Execute(input usecase.CreateImportProfileInput) common.Output {
+	// 1. 建立匯入設定聚合 (檢查欄位對應與日期格式)
+	profile, err := model.NewImportProfile(input.UserID, input.Name, toImportMapping(input.Mapping))
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid import profile: %v", err),
+		}
+	}
+
+	// 2. 子分類必須屬於使用者
+	if output := statement.VerifySubcategories(s.expenseCategoryRepo, s.incomeCategoryRepo, profile.Mapping, input.UserID); output != nil {
+		return output
+	}
+
+	// 3. 儲存
+	if err := s.repo.Save(profile); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving import profile failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       profile.ID,
+		ExitCode: common.Success,
+		Message:  "Import profile created successfully",
+	}
+}
@@ -20,7 +20,7 @@ func (s *CreateIncomeCategoryService) Execute(input usecase.CreateIncomeCategory
 	categoryName, err := model.NewCategoryName(input.Name)
 	if err != nil {
 		return common.UseCaseOutput{
-			ExitCode: common.Failure,
+			ExitCode: common.InvalidInput,
 			Message:  fmt.Sprintf("Invalid category name: %v", err),
 		}
 	}
@@ -0,0 +1,151 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// CreateRecurringRuleService 建立週期規則；目標錢包與子分類必須屬於使用者，幣別必須與錢包相同
+type CreateRecurringRuleService struct {
+	repo                repository.RecurringRuleRepository
+	walletRepo          repository.WalletRepository
+	expenseCategoryRepo repository.ExpenseCategoryRepository
+	incomeCategoryRepo  repository.IncomeCategoryRepository
+}
+
+func NewCreateRecurringRuleService(
+	repo repository.RecurringRuleRepository,
+	walletRepo repository.WalletRepository,
+	expenseCategoryRepo repository.ExpenseCategoryRepository,
+	incomeCategoryRepo repository.IncomeCategoryRepository,
+) *CreateRecurringRuleService {
+	return &CreateRecurringRuleService{
+		repo:                repo,
+		walletRepo:          walletRepo,
+		expenseCategoryRepo: expenseCategoryRepo,
+		incomeCategoryRepo:  incomeCategoryRepo,
+	}
+}
+
+func (s *CreateRecurringRuleService) Execute(input usecase.CreateRecurringRuleInput) common.Output {
+	// 1. 驗證交易類型、頻率與金額
+	txType, err := model.ParseRecurringTransactionType(input.Type)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid recurring rule: %v", err),
+		}
+	}
+	frequency, err := model.ParseRecurrenceFrequency(input.Frequency)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid recurring rule: %v", err),
+		}
+	}
+	amount, err := model.NewMoney(input.Amount, input.Currency)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid recurring rule amount: %v", err),
+		}
+	}
+
+	// 2. 目標錢包與子分類必須屬於使用者
+	wallet, err := s.walletRepo.FindByID(input.WalletID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find wallet: %v", err),
+		}
+	}
+	if wallet == nil || !common.IsAccessibleBy(wallet.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Wallet not found",
+		}
+	}
+	if wallet.Currency() != amount.Currency {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid recurring rule: currency %s does not match wallet currency %s", amount.Currency, wallet.Currency()),
+		}
+	}
+	if output := s.verifySubcategory(txType, input.SubcategoryID, input.UserID); output != nil {
+		return output
+	}
+
+	// 3. 建立週期規則聚合
+	schedule := model.RecurrenceSchedule{
+		Frequency:       frequency,
+		Interval:        input.Interval,
+		DayOfMonth:      input.DayOfMonth,
+		LastBusinessDay: input.LastBusinessDay,
+		StartDate:       input.StartDate,
+		EndDate:         input.EndDate,
+		Count:           input.Count,
+	}
+	rule, err := model.NewRecurringRule(input.UserID, input.Name, txType, wallet.ID, input.SubcategoryID, *amount, input.Description, schedule)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid recurring rule: %v", err),
+		}
+	}
+
+	// 4. 儲存
+	if err := s.repo.Save(rule); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving recurring rule failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       rule.ID,
+		ExitCode: common.Success,
+		Message:  "Recurring rule created successfully",
+	}
+}
+
+// verifySubcategory 確認子分類存在、屬於使用者且與交易類型相符，通過時回傳nil
+func (s *CreateRecurringRuleService) verifySubcategory(txType model.RecurringTransactionType, subcategoryID, userID string) common.Output {
+	if subcategoryID == "" {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  "Invalid recurring rule: a subcategory is required",
+		}
+	}
+
+	var ownerID string
+	var err error
+	if txType == model.RecurringExpense {
+		var category *model.ExpenseCategory
+		if category, err = s.expenseCategoryRepo.FindBySubcategoryID(subcategoryID); category != nil {
+			ownerID = category.UserID
+		}
+	} else {
+		var category *model.IncomeCategory
+		if category, err = s.incomeCategoryRepo.FindBySubcategoryID(subcategoryID); category != nil {
+			ownerID = category.UserID
+		}
+	}
+
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find category: %v", err),
+		}
+	}
+	if ownerID == "" || !common.IsAccessibleBy(ownerID, userID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Subcategory not found",
+		}
+	}
+	return nil
+}
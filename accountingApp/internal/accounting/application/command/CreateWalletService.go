@@ -9,11 +9,12 @@ import (
 )
 
 type CreateWalletService struct {
-	repo repository.WalletRepository
+	repo                repository.WalletRepository
+	categoryInitializer usecase.InitializeDefaultCategoriesUseCase // 可為nil：不自動建立預設分類
 }
 
-func NewCreateWalletService(repo repository.WalletRepository) *CreateWalletService {
-	return &CreateWalletService{repo: repo}
+func NewCreateWalletService(repo repository.WalletRepository, categoryInitializer usecase.InitializeDefaultCategoriesUseCase) *CreateWalletService {
+	return &CreateWalletService{repo: repo, categoryInitializer: categoryInitializer}
 }
 
 func (s *CreateWalletService) Execute(input usecase.CreateWalletInput) common.Output {
@@ -39,6 +40,15 @@ func (s *CreateWalletService) Execute(input usecase.CreateWalletInput) common.Ou
 		}
 	}
 
+	// 使用者的第一個錢包會觸發預設分類初始化
+	isFirstWallet, err := s.isFirstWallet(input.UserID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to check existing wallets: %v", err),
+		}
+	}
+
 	err = s.repo.Save(wallet)
 	if err != nil {
 		return common.UseCaseOutput{
@@ -47,8 +57,34 @@ func (s *CreateWalletService) Execute(input usecase.CreateWalletInput) common.Ou
 		}
 	}
 
-	return common.UseCaseOutput{
+	output := common.UseCaseOutput{
 		ID:       wallet.ID,
 		ExitCode: common.Success,
 	}
+
+	if isFirstWallet {
+		// 初始化可重複執行，失敗時不影響已建立的錢包
+		result := s.categoryInitializer.Execute(usecase.InitializeDefaultCategoriesInput{
+			CommandMetadata: input.CommandMetadata,
+			UserID:          input.UserID,
+			Locale:          input.Locale,
+		})
+		if result.GetExitCode() != common.Success {
+			output.Message = fmt.Sprintf("Wallet created, but default categories were not initialized: %s", result.GetMessage())
+		}
+	}
+
+	return output
+}
+
+func (s *CreateWalletService) isFirstWallet(userID string) (bool, error) {
+	if s.categoryInitializer == nil {
+		return false, nil
+	}
+
+	wallets, err := s.repo.FindByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+	return len(wallets) == 0, nil
 }
@@ -0,0 +1,59 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// DeleteAttachmentService 刪除附件的檔案與中繼資料
+type DeleteAttachmentService struct {
+	attachmentRepo repository.AttachmentRepository
+	blobStore      repository.BlobStore
+}
+
+func NewDeleteAttachmentService(attachmentRepo repository.AttachmentRepository, blobStore repository.BlobStore) *DeleteAttachmentService {
+	return &DeleteAttachmentService{
+		attachmentRepo: attachmentRepo,
+		blobStore:      blobStore,
+	}
+}
+
+func (s *DeleteAttachmentService) Execute(input usecase.DeleteAttachmentInput) common.Output {
+	attachment, err := s.attachmentRepo.FindByID(input.AttachmentID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find attachment: %v", err),
+		}
+	}
+
+	if attachment == nil || !common.IsAccessibleBy(attachment.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Attachment not found",
+		}
+	}
+
+	// 先刪除檔案：失敗時保留中繼資料，可以再重試而不會留下無人引用的檔案
+	if err := s.blobStore.Delete(attachment.StorageKey); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to delete attachment content: %v", err),
+		}
+	}
+	if err := s.attachmentRepo.Delete(attachment.ID); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to delete attachment: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       attachment.ID,
+		ExitCode: common.Success,
+		Message:  "Attachment deleted successfully",
+	}
+}
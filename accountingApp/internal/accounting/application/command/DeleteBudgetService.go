@@ -0,0 +1,47 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+type DeleteBudgetService struct {
+	repo repository.BudgetRepository
+}
+
+func NewDeleteBudgetService(repo repository.BudgetRepository) *DeleteBudgetService {
+	return &DeleteBudgetService{repo: repo}
+}
+
+func (s *DeleteBudgetService) Execute(input usecase.DeleteBudgetInput) common.Output {
+	// 1. 載入預算聚合
+	budget, err := s.repo.FindByID(input.BudgetID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find budget: %v", err),
+		}
+	}
+	if budget == nil || !common.IsAccessibleBy(budget.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Budget not found",
+		}
+	}
+
+	// 2. 刪除預算 (支出記錄不受影響)
+	if err := s.repo.Delete(budget.ID); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Deleting budget failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       budget.ID,
+		ExitCode: common.Success,
+		Message:  "Budget deleted successfully",
+	}
+}
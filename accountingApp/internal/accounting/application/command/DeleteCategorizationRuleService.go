@@ -0,0 +1,48 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+type DeleteCategorizationRuleService struct {
+	repo repository.CategorizationRuleRepository
+}
+
+func NewDeleteCategorizationRuleService(repo repository.CategorizationRuleRepository) *DeleteCategorizationRuleService {
+	return &DeleteCategorizationRuleService{repo: repo}
+}
+
+func (s *DeleteCategorizationRuleService) Execute(input usecase.DeleteCategorizationRuleInput) common.Output {
+	// 1. 載入分類規則聚合
+	rule, err := s.repo.FindByID(input.RuleID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find categorization rule: %v", err),
+		}
+	}
+	if rule == nil || !common.IsAccessibleBy(rule.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Categorization rule not found",
+		}
+	}
+
+	// 2. 刪除規則 (已分類的交易不受影響)
+	if err := s.repo.Delete(rule.ID); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Deleting categorization rule failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       rule.ID,
+		ExitCode: common.Success,
+		Message:  "Categorization rule deleted successfully",
+	}
+}
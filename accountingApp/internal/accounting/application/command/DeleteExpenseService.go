@@ -0,0 +1,70 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+type DeleteExpenseService struct {
+	walletRepo  repository.WalletRepository
+	attachments attachmentCleanup // attachmentRepo可為nil：不清理附件
+}
+
+func NewDeleteExpenseService(walletRepo repository.WalletRepository, attachmentRepo repository.AttachmentRepository, blobStore repository.BlobStore) *DeleteExpenseService {
+	return &DeleteExpenseService{
+		walletRepo:  walletRepo,
+		attachments: attachmentCleanup{attachmentRepo: attachmentRepo, blobStore: blobStore},
+	}
+}
+
+func (s *DeleteExpenseService) Execute(input usecase.DeleteExpenseInput) common.Output {
+	output := retryOnConflict(func() common.Output {
+		return s.execute(input)
+	})
+	if output.GetExitCode() == common.Success {
+		s.attachments.removeRecordAttachments(model.AttachmentExpense, input.ExpenseID)
+	}
+	return output
+}
+
+func (s *DeleteExpenseService) execute(input usecase.DeleteExpenseInput) common.Output {
+	// 1. 透過支出記錄找到所屬錢包 (完整聚合)
+	wallet, err := s.walletRepo.FindByExpenseRecordID(input.ExpenseID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find wallet: %v", err),
+		}
+	}
+	if wallet == nil || !common.IsAccessibleBy(wallet.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Expense record not found",
+		}
+	}
+
+	// 2. 透過Domain Model刪除支出並退回餘額
+	if err := wallet.RemoveExpense(input.ExpenseID); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Deleting expense failed: %v", err),
+		}
+	}
+
+	// 3. 儲存聚合 (只刪除被移除的記錄)
+	if err := s.walletRepo.Save(wallet); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: saveFailureExitCode(err),
+			Message:  fmt.Sprintf("Saving wallet failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       input.ExpenseID,
+		ExitCode: common.Success,
+		Message:  "Expense deleted successfully",
+	}
+}
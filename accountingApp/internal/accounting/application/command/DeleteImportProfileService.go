@@ -0,0 +1,48 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+type DeleteImportProfileService struct {
+	repo repository.ImportProfileRepository
+}
+
+func NewDeleteImportProfileService(repo repository.ImportProfileRepository) *DeleteImportProfileService {
+	return &DeleteImportProfileService{repo: repo}
+}
+
+func (s *DeleteImportProfileService) Execute(input usecase.DeleteImportProfileInput) common.Output {
+	// 1. 載入匯入設定聚合
+	profile, err := s.repo.FindByID(input.ProfileID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find import profile: %v", err),
+		}
+	}
+	if profile == nil || !common.IsAccessibleBy(profile.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Import profile not found",
+		}
+	}
+
+	// 2. 刪除設定 (已匯入的交易不受影響)
+	if err := s.repo.Delete(profile.ID); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Deleting import profile failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       profile.ID,
+		ExitCode: common.Success,
+		Message:  "Import profile deleted successfully",
+	}
+}
@@ -0,0 +1,47 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+type DeleteIncomeCategoryService struct {
+	repo repository.IncomeCategoryRepository
+}
+
+func NewDeleteIncomeCategoryService(repo repository.IncomeCategoryRepository) *DeleteIncomeCategoryService {
+	return &DeleteIncomeCategoryService{repo: repo}
+}
+
+func (s *DeleteIncomeCategoryService) Execute(input usecase.DeleteIncomeCategoryInput) common.Output {
+	// 1. 載入分類聚合
+	category, err := s.repo.FindByID(input.CategoryID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find income category: %v", err),
+		}
+	}
+	if category == nil || !common.IsAccessibleBy(category.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Income category not found",
+		}
+	}
+
+	// 2. 刪除分類 (子分類一併刪除)
+	if err := s.repo.Delete(category.ID); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Deleting income category failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       category.ID,
+		ExitCode: common.Success,
+		Message:  "Income category deleted successfully",
+	}
+}
@@ -0,0 +1,70 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+type DeleteIncomeService struct {
+	walletRepo  repository.WalletRepository
+	attachments attachmentCleanup // attachmentRepo可為nil：不清理附件
+}
+
+func NewDeleteIncomeService(walletRepo repository.WalletRepository, attachmentRepo repository.AttachmentRepository, blobStore repository.BlobStore) *DeleteIncomeService {
+	return &DeleteIncomeService{
+		walletRepo:  walletRepo,
+		attachments: attachmentCleanup{attachmentRepo: attachmentRepo, blobStore: blobStore},
+	}
+}
+
+func (s *DeleteIncomeService) Execute(input usecase.DeleteIncomeInput) common.Output {
+	output := retryOnConflict(func() common.Output {
+		return s.execute(input)
+	})
+	if output.GetExitCode() == common.Success {
+		s.attachments.removeRecordAttachments(model.AttachmentIncome, input.IncomeID)
+	}
+	return output
+}
+
+func (s *DeleteIncomeService) execute(input usecase.DeleteIncomeInput) common.Output {
+	// 1. 透過收入記錄找到所屬錢包 (完整聚合)
+	wallet, err := s.walletRepo.FindByIncomeRecordID(input.IncomeID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find wallet: %v", err),
+		}
+	}
+	if wallet == nil || !common.IsAccessibleBy(wallet.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Income record not found",
+		}
+	}
+
+	// 2. 透過Domain Model刪除收入並扣回餘額 (餘額不足時拒絕)
+	if err := wallet.RemoveIncome(input.IncomeID); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Deleting income failed: %v", err),
+		}
+	}
+
+	// 3. 儲存聚合 (只刪除被移除的記錄)
+	if err := s.walletRepo.Save(wallet); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: saveFailureExitCode(err),
+			Message:  fmt.Sprintf("Saving wallet failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       input.IncomeID,
+		ExitCode: common.Success,
+		Message:  "Income deleted successfully",
+	}
+}
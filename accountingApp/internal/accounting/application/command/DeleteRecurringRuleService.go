@@ -0,0 +1,47 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+type DeleteRecurringRuleService struct {
+	repo repository.RecurringRuleRepository
+}
+
+func NewDeleteRecurringRuleService(repo repository.RecurringRuleRepository) *DeleteRecurringRuleService {
+	return &DeleteRecurringRuleService{repo: repo}
+}
+
+func (s *DeleteRecurringRuleService) Execute(input usecase.DeleteRecurringRuleInput) common.Output {
+	// 1. 載入週期規則聚合
+	rule, err := s.repo.FindByID(input.RuleID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find recurring rule: %v", err),
+		}
+	}
+	if rule == nil || !common.IsAccessibleBy(rule.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Recurring rule not found",
+		}
+	}
+
+	// 2. 刪除規則 (已產生的交易不受影響)
+	if err := s.repo.Delete(rule.ID); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Deleting recurring rule failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       rule.ID,
+		ExitCode: common.Success,
+		Message:  "Recurring rule deleted successfully",
+	}
+}
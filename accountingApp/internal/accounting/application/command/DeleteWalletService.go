@@ -5,14 +5,16 @@ import (
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
 )
 
 type DeleteWalletService struct {
-	repo repository.WalletRepository
+	repo        repository.WalletRepository
+	attachments attachmentCleanup // attachmentRepo可為nil：不清理附件
 }
 
-func NewDeleteWalletService(repo repository.WalletRepository) *DeleteWalletService {
-	return &DeleteWalletService{repo: repo}
+func NewDeleteWalletService(repo repository.WalletRepository, attachmentRepo repository.AttachmentRepository, blobStore repository.BlobStore) *DeleteWalletService {
+	return &DeleteWalletService{repo: repo, attachments: attachmentCleanup{attachmentRepo: attachmentRepo, blobStore: blobStore}}
 }
 
 func (s *DeleteWalletService) Execute(input usecase.DeleteWalletInput) common.Output {
@@ -25,13 +27,25 @@ func (s *DeleteWalletService) Execute(input usecase.DeleteWalletInput) common.Ou
 		}
 	}
 
-	if wallet == nil {
+	if wallet == nil || !common.IsAccessibleBy(wallet.UserID, input.UserID) {
 		return common.UseCaseOutput{
-			ExitCode: common.Failure,
+			ExitCode: common.NotFound,
 			Message:  "Wallet not found",
 		}
 	}
 
+	// Find the attachments of the wallet's records before they are deleted with it
+	var attachments []*model.Attachment
+	if s.attachments.attachmentRepo != nil {
+		attachments, err = s.attachments.attachmentRepo.FindByWalletID(input.WalletID)
+		if err != nil {
+			return common.UseCaseOutput{
+				ExitCode: common.Failure,
+				Message:  fmt.Sprintf("Failed to retrieve attachments: %v", err),
+			}
+		}
+	}
+
 	// Delete the wallet
 	if err := s.repo.Delete(input.WalletID); err != nil {
 		return common.UseCaseOutput{
@@ -40,9 +54,11 @@ func (s *DeleteWalletService) Execute(input usecase.DeleteWalletInput) common.Ou
 		}
 	}
 
+	s.attachments.removeAttachments(attachments)
+
 	return common.UseCaseOutput{
 		ID:       input.WalletID,
 		ExitCode: common.Success,
 		Message:  "Wallet deleted successfully",
 	}
-}
\ No newline at end of file
+}
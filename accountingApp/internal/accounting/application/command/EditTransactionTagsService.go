@@ -0,0 +1,232 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// maxTagEditBatch 單次批次修改標籤的交易數上限
+const maxTagEditBatch = 500
+
+// EditTransactionTagsService - 批次修改多筆交易的標籤；涉及的錢包在同一個交易中儲存
+type EditTransactionTagsService struct {
+	uow repository.UnitOfWork
+}
+
+func NewEditTransactionTagsService(uow repository.UnitOfWork) *EditTransactionTagsService {
+	return &EditTransactionTagsService{
+		uow: uow,
+	}
+}
+
+func (s *EditTransactionTagsService) Execute(input usecase.EditTransactionTagsInput) common.Output {
+	edit, err := model.NewTagEdit(input.Add, input.Remove)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid tags: %v", err),
+		}
+	}
+
+	total := len(input.ExpenseIDs) + len(input.IncomeIDs) + len(input.TransferIDs)
+	if total == 0 {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  "Invalid request: no transactions selected",
+		}
+	}
+	if total > maxTagEditBatch {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid request: at most %d transactions can be edited at once", maxTagEditBatch),
+		}
+	}
+
+	return retryOnConflict(func() common.Output {
+		return s.execute(input, edit)
+	})
+}
+
+func (s *EditTransactionTagsService) execute(input usecase.EditTransactionTagsInput, edit model.TagEdit) common.Output {
+	var updated int
+
+	// 任何一筆交易找不到或修改失敗時整批回滾
+	err := s.uow.Do(func(scope repository.TransactionScope) error {
+		batch := newTagEditBatch(scope.Wallets(), input.UserID)
+		updated = 0
+
+		// 1. 支出與收入記錄：找到所屬錢包 (每個錢包只載入一次) 並套用修改
+		for _, expenseID := range input.ExpenseIDs {
+			wallet, err := batch.walletOf(expenseID, batch.walletRepo.FindByExpenseRecordID)
+			if err != nil {
+				return err
+			}
+			if wallet == nil {
+				return &notFoundError{kind: "expense record", id: expenseID}
+			}
+			changed, err := wallet.EditExpenseTags(expenseID, edit)
+			if err != nil {
+				return err
+			}
+			updated += batch.track(wallet, changed)
+		}
+
+		for _, incomeID := range input.IncomeIDs {
+			wallet, err := batch.walletOf(incomeID, batch.walletRepo.FindByIncomeRecordID)
+			if err != nil {
+				return err
+			}
+			if wallet == nil {
+				return &notFoundError{kind: "income record", id: incomeID}
+			}
+			changed, err := wallet.EditIncomeTags(incomeID, edit)
+			if err != nil {
+				return err
+			}
+			updated += batch.track(wallet, changed)
+		}
+
+		// 2. 轉帳：來源與目標錢包中的記錄套用相同修改，只計算一次
+		for _, transferID := range input.TransferIDs {
+			fromWallet, err := batch.walletOf(transferID, batch.walletRepo.FindByTransferID)
+			if err != nil {
+				return err
+			}
+			toWallet, err := batch.transferDestination(fromWallet, transferID)
+			if err != nil {
+				return err
+			}
+			if fromWallet == nil || toWallet == nil {
+				return &notFoundError{kind: "transfer", id: transferID}
+			}
+
+			changed, err := fromWallet.EditTransferTags(transferID, edit)
+			if err != nil {
+				return err
+			}
+			if _, err := toWallet.EditTransferTags(transferID, edit); err != nil {
+				return err
+			}
+			updated += batch.track(fromWallet, changed)
+			batch.track(toWallet, changed)
+		}
+
+		// 3. 儲存標籤有變更的錢包
+		return batch.save()
+	})
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: saveFailureExitCode(err),
+			Message:  err.Error(),
+		}
+	}
+
+	return usecase.EditTransactionTagsOutput{
+		ExitCode: common.Success,
+		Message:  "Tags updated successfully",
+		Updated:  updated,
+	}
+}
+
+// tagEditBatch 批次修改期間載入的錢包；同一錢包的多筆交易共用一個聚合實例
+type tagEditBatch struct {
+	walletRepo repository.WalletRepository
+	userID     string
+	wallets    map[string]*model.Wallet
+	loadOrder  []string          // 錢包載入順序，依序儲存
+	owners     map[string]string // 交易記錄ID -> 所屬錢包ID (轉帳為來源錢包)
+	changed    map[string]bool
+}
+
+func newTagEditBatch(walletRepo repository.WalletRepository, userID string) *tagEditBatch {
+	return &tagEditBatch{
+		walletRepo: walletRepo,
+		userID:     userID,
+		wallets:    make(map[string]*model.Wallet),
+		owners:     make(map[string]string),
+		changed:    make(map[string]bool),
+	}
+}
+
+// walletOf 回傳交易記錄所屬的錢包，已載入的錢包不再查詢；找不到或不屬於使用者時回傳nil
+func (b *tagEditBatch) walletOf(recordID string, findByRecord func(string) (*model.Wallet, error)) (*model.Wallet, error) {
+	if walletID, ok := b.owners[recordID]; ok {
+		return b.wallets[walletID], nil
+	}
+	wallet, err := findByRecord(recordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find wallet: %v", err)
+	}
+	return b.add(wallet), nil
+}
+
+// transferDestination 回傳轉帳的目標錢包 (fromWallet為來源錢包)
+func (b *tagEditBatch) transferDestination(fromWallet *model.Wallet, transferID string) (*model.Wallet, error) {
+	if fromWallet == nil {
+		return nil, nil
+	}
+	for _, transfer := range fromWallet.GetTransfers() {
+		if transfer.ID != transferID {
+			continue
+		}
+		if wallet, ok := b.wallets[transfer.ToWalletID]; ok {
+			return wallet, nil
+		}
+		wallet, err := b.walletRepo.FindByIDWithTransactions(transfer.ToWalletID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find wallet: %v", err)
+		}
+		return b.add(wallet), nil
+	}
+	return nil, nil
+}
+
+// add 快取錢包並索引其交易記錄；不屬於使用者的錢包視為不存在
+func (b *tagEditBatch) add(wallet *model.Wallet) *model.Wallet {
+	if wallet == nil || !common.IsAccessibleBy(wallet.UserID, b.userID) {
+		return nil
+	}
+	if cached, ok := b.wallets[wallet.ID]; ok {
+		return cached
+	}
+
+	b.wallets[wallet.ID] = wallet
+	b.loadOrder = append(b.loadOrder, wallet.ID)
+	for _, record := range wallet.GetExpenseRecords() {
+		b.owners[record.ID] = wallet.ID
+	}
+	for _, record := range wallet.GetIncomeRecords() {
+		b.owners[record.ID] = wallet.ID
+	}
+	for _, transfer := range wallet.GetTransfers() {
+		if transfer.FromWalletID == wallet.ID {
+			b.owners[transfer.ID] = wallet.ID
+		}
+	}
+	return wallet
+}
+
+// track 記錄錢包是否需要儲存，回傳計入更新數的筆數
+func (b *tagEditBatch) track(wallet *model.Wallet, changed bool) int {
+	if !changed {
+		return 0
+	}
+	b.changed[wallet.ID] = true
+	return 1
+}
+
+func (b *tagEditBatch) save() error {
+	for _, walletID := range b.loadOrder {
+		if !b.changed[walletID] {
+			continue
+		}
+		if err := b.walletRepo.Save(b.wallets[walletID]); err != nil {
+			return fmt.Errorf("failed to save wallet: %w", err)
+		}
+	}
+	return nil
+}
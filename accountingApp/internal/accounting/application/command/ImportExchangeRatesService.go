@@ -0,0 +1,64 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// 匯入CSV的匯率來源名稱
+const exchangeRateCSVSource = "csv"
+
+// ImportExchangeRatesService 從CSV檔匯入每日匯率，任一行有誤時全部不匯入
+type ImportExchangeRatesService struct {
+	rateRepo repository.ExchangeRateRepository
+}
+
+func NewImportExchangeRatesService(rateRepo repository.ExchangeRateRepository) *ImportExchangeRatesService {
+	return &ImportExchangeRatesService{rateRepo: rateRepo}
+}
+
+func (s *ImportExchangeRatesService) Execute(input usecase.ImportExchangeRatesInput) common.Output {
+	if input.Content == nil {
+		return usecase.ImportExchangeRatesOutput{
+			ExitCode: common.InvalidInput,
+			Message:  "Invalid exchange rate file: file is required",
+		}
+	}
+
+	rates, err := exchange.ParseCSV(input.Content, exchangeRateCSVSource)
+	if err != nil {
+		return usecase.ImportExchangeRatesOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid exchange rate file: %v", err),
+		}
+	}
+
+	return saveExchangeRates(s.rateRepo, exchangeRateCSVSource, rates)
+}
+
+// saveExchangeRates 儲存一批匯率並回傳儲存結果，ID為匯率來源
+func saveExchangeRates(rateRepo repository.ExchangeRateRepository, source string, rates []*model.ExchangeRate) common.Output {
+	if err := rateRepo.SaveAll(rates); err != nil {
+		return usecase.ImportExchangeRatesOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving exchange rates failed: %v", err),
+		}
+	}
+
+	data := make([]usecase.ExchangeRateData, 0, len(rates))
+	for _, rate := range rates {
+		data = append(data, exchange.ToRateData(rate))
+	}
+	return usecase.ImportExchangeRatesOutput{
+		ID:       source,
+		ExitCode: common.Success,
+		Message:  fmt.Sprintf("Imported %d exchange rates", len(rates)),
+		Imported: len(rates),
+		Rates:    data,
+	}
+}
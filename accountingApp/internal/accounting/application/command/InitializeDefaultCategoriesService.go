@@ -0,0 +1,192 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// InitializeDefaultCategoriesService 將預設分類範本複製為使用者自己的分類聚合
+// 以名稱比對既有分類與子分類，只建立缺少的部分，因此可重複執行
+type InitializeDefaultCategoriesService struct {
+	expenseCategoryRepo repository.ExpenseCategoryRepository
+	incomeCategoryRepo  repository.IncomeCategoryRepository
+}
+
+func NewInitializeDefaultCategoriesService(
+	expenseCategoryRepo repository.ExpenseCategoryRepository,
+	incomeCategoryRepo repository.IncomeCategoryRepository,
+) *InitializeDefaultCategoriesService {
+	return &InitializeDefaultCategoriesService{
+		expenseCategoryRepo: expenseCategoryRepo,
+		incomeCategoryRepo:  incomeCategoryRepo,
+	}
+}
+
+func (s *InitializeDefaultCategoriesService) Execute(input usecase.InitializeDefaultCategoriesInput) common.Output {
+	if input.UserID == "" {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  "Invalid user ID: user ID cannot be empty",
+		}
+	}
+
+	template, err := model.FindDefaultCategoryTemplate(input.Locale)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid locale: %v", err),
+		}
+	}
+
+	expenseCreated, err := s.applyExpenseTemplates(input.UserID, template.Expense)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Initializing expense categories failed: %v", err),
+		}
+	}
+
+	incomeCreated, err := s.applyIncomeTemplates(input.UserID, template.Income)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Initializing income categories failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       input.UserID,
+		ExitCode: common.Success,
+		Message: fmt.Sprintf("Default categories initialized from %s template v%d (%d expense, %d income categories created)",
+			template.Locale, template.Version, expenseCreated, incomeCreated),
+	}
+}
+
+// applyExpenseTemplates 補上缺少的支出分類與子分類，回傳新建立的分類數
+func (s *InitializeDefaultCategoriesService) applyExpenseTemplates(userID string, templates []model.CategoryTemplate) (int, error) {
+	existing, err := s.expenseCategoryRepo.FindByUserID(userID)
+	if err != nil {
+		return 0, err
+	}
+	byName := make(map[string]*model.ExpenseCategory, len(existing))
+	for _, category := range existing {
+		byName[category.Name.Value] = category
+	}
+
+	created := 0
+	for _, template := range templates {
+		category, exists := byName[template.Name]
+		if !exists {
+			name, err := model.NewCategoryName(template.Name)
+			if err != nil {
+				return created, err
+			}
+			category, err = model.NewExpenseCategory(userID, *name)
+			if err != nil {
+				return created, err
+			}
+		}
+
+		changed := !exists
+		for _, subcategoryName := range template.Subcategories {
+			name, err := model.NewCategoryName(subcategoryName)
+			if err != nil {
+				return created, err
+			}
+			if hasExpenseSubcategory(category, *name) {
+				continue
+			}
+			if _, err := category.AddSubcategory(*name); err != nil {
+				return created, err
+			}
+			changed = true
+		}
+
+		if !changed {
+			continue
+		}
+		if err := s.expenseCategoryRepo.Save(category); err != nil {
+			return created, err
+		}
+		if !exists {
+			created++
+		}
+	}
+
+	return created, nil
+}
+
+// applyIncomeTemplates 補上缺少的收入分類與子分類，回傳新建立的分類數
+func (s *InitializeDefaultCategoriesService) applyIncomeTemplates(userID string, templates []model.CategoryTemplate) (int, error) {
+	existing, err := s.incomeCategoryRepo.FindByUserID(userID)
+	if err != nil {
+		return 0, err
+	}
+	byName := make(map[string]*model.IncomeCategory, len(existing))
+	for _, category := range existing {
+		byName[category.Name.Value] = category
+	}
+
+	created := 0
+	for _, template := range templates {
+		category, exists := byName[template.Name]
+		if !exists {
+			name, err := model.NewCategoryName(template.Name)
+			if err != nil {
+				return created, err
+			}
+			category, err = model.NewIncomeCategory(userID, *name)
+			if err != nil {
+				return created, err
+			}
+		}
+
+		changed := !exists
+		for _, subcategoryName := range template.Subcategories {
+			name, err := model.NewCategoryName(subcategoryName)
+			if err != nil {
+				return created, err
+			}
+			if hasIncomeSubcategory(category, *name) {
+				continue
+			}
+			if _, err := category.AddSubcategory(*name); err != nil {
+				return created, err
+			}
+			changed = true
+		}
+
+		if !changed {
+			continue
+		}
+		if err := s.incomeCategoryRepo.Save(category); err != nil {
+			return created, err
+		}
+		if !exists {
+			created++
+		}
+	}
+
+	return created, nil
+}
+
+func hasExpenseSubcategory(category *model.ExpenseCategory, name model.CategoryName) bool {
+	for _, subcategory := range category.Subcategories {
+		if subcategory.Name.Equals(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasIncomeSubcategory(category *model.IncomeCategory, name model.CategoryName) bool {
+	for _, subcategory := range category.Subcategories {
+		if subcategory.Name.Equals(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package command
+
+import (
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// PauseRecurringRuleService 暫停週期規則，排程器不再產生交易
+type PauseRecurringRuleService struct {
+	repo repository.RecurringRuleRepository
+}
+
+func NewPauseRecurringRuleService(repo repository.RecurringRuleRepository) *PauseRecurringRuleService {
+	return &PauseRecurringRuleService{repo: repo}
+}
+
+func (s *PauseRecurringRuleService) Execute(input usecase.PauseRecurringRuleInput) common.Output {
+	return changeRecurringRule(s.repo, input.RuleID, input.UserID, func(rule *model.RecurringRule) error {
+		return rule.Pause(time.Now())
+	}, "Recurring rule paused successfully")
+}
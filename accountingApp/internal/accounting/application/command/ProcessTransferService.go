@@ -1,108 +1,119 @@
 package command
 
 import (
+	"errors"
 	"fmt"
+	"time"
+
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
 	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
-	"time"
 )
 
-type ProcessTransferInput struct {
-	FromWalletID string    // 來源錢包ID
-	ToWalletID   string    // 目標錢包ID
-	Amount       int64     // 轉帳金額 (cents)
-	Currency     string    // 貨幣
-	Fee          int64     // 手續費 (cents)
-	Description  string    // 描述
-	Date         time.Time // 轉帳日期
-}
-
-// ProcessTransferService - 只依賴Repository
+// ProcessTransferService - 透過UnitOfWork在同一個交易中修改兩個錢包聚合
+// 跨幣別轉帳未指定目標金額或匯率時，以converter取轉帳日期的匯率換算 (converter可為nil)
 type ProcessTransferService struct {
-	walletRepo repository.WalletRepository
+	uow       repository.UnitOfWork
+	converter *exchange.Converter
 }
 
-func NewProcessTransferService(walletRepo repository.WalletRepository) *ProcessTransferService {
+func NewProcessTransferService(uow repository.UnitOfWork, converter *exchange.Converter) *ProcessTransferService {
 	return &ProcessTransferService{
-		walletRepo: walletRepo,
+		uow:       uow,
+		converter: converter,
 	}
 }
 
-func (s *ProcessTransferService) Execute(input ProcessTransferInput) common.Output {
-	// 1. 取得兩個錢包 (載入完整聚合)
-	fromWallet, err := s.walletRepo.FindByIDWithTransactions(input.FromWalletID)
-	if err != nil {
-		return common.UseCaseOutput{
-			ExitCode: common.Failure,
-			Message:  fmt.Sprintf("from wallet not found: %v", err),
+func (s *ProcessTransferService) Execute(input usecase.ProcessTransferInput) common.Output {
+	// 衝突時整個交易回滾，重試會在新的交易中重新載入兩個錢包
+	return retryOnConflict(func() common.Output {
+		return s.execute(input)
+	})
+}
+
+func (s *ProcessTransferService) execute(input usecase.ProcessTransferInput) common.Output {
+	var transfer *model.Transfer
+
+	// 兩個錢包的讀取與儲存都在同一個交易中，任何一步失敗整體回滾
+	err := s.uow.Do(func(scope repository.TransactionScope) error {
+		walletRepo := scope.Wallets()
+
+		// 1. 取得兩個錢包 (載入完整聚合)
+		fromWallet, err := walletRepo.FindByIDWithTransactions(input.FromWalletID)
+		if err != nil {
+			return fmt.Errorf("failed to find from wallet: %v", err)
+		}
+		if fromWallet == nil || !common.IsAccessibleBy(fromWallet.UserID, input.UserID) {
+			return &notFoundError{kind: "from wallet", id: input.FromWalletID}
 		}
-	}
 
-	toWallet, err := s.walletRepo.FindByIDWithTransactions(input.ToWalletID)
-	if err != nil {
-		return common.UseCaseOutput{
-			ExitCode: common.Failure,
-			Message:  fmt.Sprintf("to wallet not found: %v", err),
+		toWallet, err := walletRepo.FindByIDWithTransactions(input.ToWalletID)
+		if err != nil {
+			return fmt.Errorf("failed to find to wallet: %v", err)
+		}
+		if toWallet == nil || !common.IsAccessibleBy(toWallet.UserID, input.UserID) {
+			return &notFoundError{kind: "to wallet", id: input.ToWalletID}
 		}
-	}
 
-	// 2. 建立金額物件
-	amount, err := model.NewMoney(input.Amount, input.Currency)
-	if err != nil {
-		return common.UseCaseOutput{
-			ExitCode: common.Failure,
-			Message:  fmt.Sprintf("invalid amount: %v", err),
+		// 2. 建立金額物件
+		amount, err := model.NewMoney(input.Amount, input.Currency)
+		if err != nil {
+			return fmt.Errorf("invalid amount: %v", err)
 		}
-	}
 
-	fee, err := model.NewMoney(input.Fee, input.Currency)
-	if err != nil {
-		return common.UseCaseOutput{
-			ExitCode: common.Failure,
-			Message:  fmt.Sprintf("invalid fee: %v", err),
+		toAmount, err := s.toAmount(input, *amount, toWallet.Currency())
+		if err != nil {
+			return err
 		}
-	}
 
-	// 3. 透過Domain Model處理轉帳 (雙邊操作)
-	err = fromWallet.ProcessOutgoingTransfer(*amount, *fee)
-	if err != nil {
-		return common.UseCaseOutput{
-			ExitCode: common.Failure,
-			Message:  fmt.Sprintf("transfer failed: %v", err),
+		feeCurrency := input.FeeCurrency
+		if feeCurrency == "" {
+			feeCurrency = input.Currency
+		}
+		fee, err := model.NewMoney(input.Fee, feeCurrency)
+		if err != nil {
+			return fmt.Errorf("invalid fee: %v", err)
 		}
-	}
 
-	err = toWallet.ProcessIncomingTransfer(*amount)
-	if err != nil {
-		return common.UseCaseOutput{
-			ExitCode: common.Failure,
-			Message:  fmt.Sprintf("transfer failed: %v", err),
+		tags, err := initialTags(input.Tags)
+		if err != nil {
+			return fmt.Errorf("invalid tags: %v", err)
 		}
-	}
 
-	// 4. 建立轉帳記錄 (在來源錢包中)
-	transfer, err := fromWallet.CreateTransfer(input.ToWalletID, *amount, *fee, input.Description, input.Date)
-	if err != nil {
-		return common.UseCaseOutput{
-			ExitCode: common.Failure,
-			Message:  fmt.Sprintf("failed to create transfer record: %v", err),
+		// 3. 透過Domain Model處理轉帳 (雙邊操作)：來源錢包扣款並記錄轉出，目標錢包以自己的幣別入帳及記錄轉入
+		transfer, err = fromWallet.SendTransfer(input.ToWalletID, *amount, toAmount, *fee, input.Description, input.Date)
+		if err != nil {
+			return fmt.Errorf("transfer failed: %v", err)
 		}
-	}
 
-	// 5. 儲存兩個錢包 (應該在同一個資料庫交易中)
-	// TODO: 實作交易管理 (Transaction Manager)
-	if err := s.walletRepo.Save(fromWallet); err != nil {
-		return common.UseCaseOutput{
-			ExitCode: common.Failure,
-			Message:  fmt.Sprintf("failed to save from wallet: %v", err),
+		if err := toWallet.ReceiveTransfer(*transfer); err != nil {
+			return fmt.Errorf("transfer failed: %v", err)
+		}
+
+		// 轉帳記錄在兩個錢包中各有一份，標籤須保持一致
+		for _, wallet := range []*model.Wallet{fromWallet, toWallet} {
+			if _, err := wallet.EditTransferTags(transfer.ID, tags); err != nil {
+				return fmt.Errorf("invalid tags: %v", err)
+			}
+		}
+
+		// 4. 儲存兩個錢包 (同一個資料庫交易)
+		if err := walletRepo.Save(fromWallet); err != nil {
+			return fmt.Errorf("failed to save from wallet: %w", err)
 		}
-	}
 
-	if err := s.walletRepo.Save(toWallet); err != nil {
+		if err := walletRepo.Save(toWallet); err != nil {
+			return fmt.Errorf("failed to save to wallet: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
 		return common.UseCaseOutput{
-			ExitCode: common.Failure,
-			Message:  fmt.Sprintf("failed to save to wallet: %v", err),
+			ExitCode: saveFailureExitCode(err),
+			Message:  err.Error(),
 		}
 	}
 
@@ -111,4 +122,53 @@ func (s *ProcessTransferService) Execute(input ProcessTransferInput) common.Outp
 		ExitCode: common.Success,
 		Message:  "Transfer processed successfully",
 	}
-}
\ No newline at end of file
+}
+
+// toAmount 目標錢包收到的金額：同幣別即為轉帳金額，跨幣別依序採用輸入的目標金額、輸入的匯率或匯率表的匯率
+func (s *ProcessTransferService) toAmount(input usecase.ProcessTransferInput, amount model.Money, toCurrency string) (model.Money, error) {
+	if input.ToAmount != 0 && input.Rate != "" {
+		return model.Money{}, errors.New("invalid transfer: give either a destination amount or a rate, not both")
+	}
+	if amount.Currency == toCurrency {
+		if input.Rate != "" {
+			return model.Money{}, errors.New("invalid transfer: a rate is only allowed between wallets in different currencies")
+		}
+		if input.ToAmount != 0 && input.ToAmount != amount.Amount {
+			return model.Money{}, errors.New("invalid transfer: the destination amount must equal the amount between wallets in the same currency")
+		}
+		return amount, nil
+	}
+
+	if input.ToAmount != 0 {
+		toAmount, err := model.NewMoney(input.ToAmount, toCurrency)
+		if err != nil {
+			return model.Money{}, fmt.Errorf("invalid destination amount: %v", err)
+		}
+		return *toAmount, nil
+	}
+
+	date := input.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	if input.Rate != "" {
+		rate, err := model.NewExchangeRate(amount.Currency, toCurrency, input.Rate, date, "transfer")
+		if err != nil {
+			return model.Money{}, fmt.Errorf("invalid rate: %v", err)
+		}
+		rounding := model.RoundHalfUp
+		if s.converter != nil {
+			rounding = s.converter.Rounding()
+		}
+		return rate.Convert(amount, rounding)
+	}
+
+	if s.converter == nil {
+		return model.Money{}, fmt.Errorf("invalid transfer: a destination amount or rate is required from %s to %s", amount.Currency, toCurrency)
+	}
+	conversion, err := s.converter.Convert(amount, toCurrency, date)
+	if err != nil {
+		return model.Money{}, fmt.Errorf("invalid transfer: %v; give a destination amount or rate", err)
+	}
+	return conversion.Converted, nil
+}
@@ -0,0 +1,47 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// RefreshExchangeRatesService 從設定的匯率來源取得某一天的匯率並儲存
+type RefreshExchangeRatesService struct {
+	rateRepo repository.ExchangeRateRepository
+	provider exchange.RateProvider // 未設定時為nil
+}
+
+func NewRefreshExchangeRatesService(rateRepo repository.ExchangeRateRepository, provider exchange.RateProvider) *RefreshExchangeRatesService {
+	return &RefreshExchangeRatesService{
+		rateRepo: rateRepo,
+		provider: provider,
+	}
+}
+
+func (s *RefreshExchangeRatesService) Execute(input usecase.RefreshExchangeRatesInput) common.Output {
+	if s.provider == nil {
+		return usecase.ImportExchangeRatesOutput{
+			ExitCode: common.Failure,
+			Message:  "No exchange rate provider configured",
+		}
+	}
+
+	date := input.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	rates, err := s.provider.FetchRates(date)
+	if err != nil {
+		return usecase.ImportExchangeRatesOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to fetch exchange rates from %s: %v", s.provider.Name(), err),
+		}
+	}
+
+	return saveExchangeRates(s.rateRepo, s.provider.Name(), rates)
+}
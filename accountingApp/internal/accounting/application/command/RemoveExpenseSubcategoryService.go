@@ -0,0 +1,62 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+type RemoveExpenseSubcategoryService struct {
+	repo repository.ExpenseCategoryRepository
+}
+
+func NewRemoveExpenseSubcategoryService(repo repository.ExpenseCategoryRepository) *RemoveExpenseSubcategoryService {
+	return &RemoveExpenseSubcategoryService{repo: repo}
+}
+
+func (s *RemoveExpenseSubcategoryService) Execute(input usecase.RemoveExpenseSubcategoryInput) common.Output {
+	// 1. 載入分類聚合
+	category, err := s.repo.FindByID(input.CategoryID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find expense category: %v", err),
+		}
+	}
+	if category == nil || !common.IsAccessibleBy(category.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Expense category not found",
+		}
+	}
+
+	// 2. 透過聚合根移除子分類
+	if err := category.ValidateSubcategoryExists(input.SubcategoryID); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Subcategory not found",
+		}
+	}
+
+	if err := category.RemoveSubcategory(input.SubcategoryID); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Removing subcategory failed: %v", err),
+		}
+	}
+
+	// 3. 儲存聚合
+	if err := s.repo.Save(category); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving expense category failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       input.SubcategoryID,
+		ExitCode: common.Success,
+		Message:  "Subcategory removed successfully",
+	}
+}
@@ -0,0 +1,58 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+type RenameExpenseCategoryService struct {
+	repo repository.ExpenseCategoryRepository
+}
+
+func NewRenameExpenseCategoryService(repo repository.ExpenseCategoryRepository) *RenameExpenseCategoryService {
+	return &RenameExpenseCategoryService{repo: repo}
+}
+
+func (s *RenameExpenseCategoryService) Execute(input usecase.RenameExpenseCategoryInput) common.Output {
+	// 1. 載入分類聚合
+	category, err := s.repo.FindByID(input.CategoryID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find expense category: %v", err),
+		}
+	}
+	if category == nil || !common.IsAccessibleBy(category.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Expense category not found",
+		}
+	}
+
+	// 2. 透過Domain Model重新命名
+	categoryName, err := model.NewCategoryName(input.Name)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid category name: %v", err),
+		}
+	}
+	category.Rename(*categoryName)
+
+	// 3. 儲存聚合
+	if err := s.repo.Save(category); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving expense category failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       category.ID,
+		ExitCode: common.Success,
+		Message:  "Expense category renamed successfully",
+	}
+}
@@ -0,0 +1,71 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+type RenameExpenseSubcategoryService struct {
+	repo repository.ExpenseCategoryRepository
+}
+
+func NewRenameExpenseSubcategoryService(repo repository.ExpenseCategoryRepository) *RenameExpenseSubcategoryService {
+	return &RenameExpenseSubcategoryService{repo: repo}
+}
+
+func (s *RenameExpenseSubcategoryService) Execute(input usecase.RenameExpenseSubcategoryInput) common.Output {
+	// 1. 載入分類聚合
+	category, err := s.repo.FindByID(input.CategoryID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find expense category: %v", err),
+		}
+	}
+	if category == nil || !common.IsAccessibleBy(category.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Expense category not found",
+		}
+	}
+
+	// 2. 透過聚合根更新子分類名稱 (名稱不可重複)
+	if err := category.ValidateSubcategoryExists(input.SubcategoryID); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Subcategory not found",
+		}
+	}
+
+	categoryName, err := model.NewCategoryName(input.Name)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid category name: %v", err),
+		}
+	}
+
+	if err := category.UpdateSubcategoryName(input.SubcategoryID, *categoryName); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Updating subcategory failed: %v", err),
+		}
+	}
+
+	// 3. 儲存聚合
+	if err := s.repo.Save(category); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving expense category failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       input.SubcategoryID,
+		ExitCode: common.Success,
+		Message:  "Subcategory renamed successfully",
+	}
+}
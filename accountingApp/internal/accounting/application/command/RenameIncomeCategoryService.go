@@ -0,0 +1,58 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+type RenameIncomeCategoryService struct {
+	repo repository.IncomeCategoryRepository
+}
+
+func NewRenameIncomeCategoryService(repo repository.IncomeCategoryRepository) *RenameIncomeCategoryService {
+	return &RenameIncomeCategoryService{repo: repo}
+}
+
+func (s *RenameIncomeCategoryService) Execute(input usecase.RenameIncomeCategoryInput) common.Output {
+	// 1. 載入分類聚合
+	category, err := s.repo.FindByID(input.CategoryID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find income category: %v", err),
+		}
+	}
+	if category == nil || !common.IsAccessibleBy(category.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Income category not found",
+		}
+	}
+
+	// 2. 透過Domain Model重新命名
+	categoryName, err := model.NewCategoryName(input.Name)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid category name: %v", err),
+		}
+	}
+	category.Rename(*categoryName)
+
+	// 3. 儲存聚合
+	if err := s.repo.Save(category); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving income category failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       category.ID,
+		ExitCode: common.Success,
+		Message:  "Income category renamed successfully",
+	}
+}
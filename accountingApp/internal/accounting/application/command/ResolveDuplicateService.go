@@ -0,0 +1,161 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+type ResolveDuplicateService struct {
+	flagRepo       repository.DuplicateFlagRepository
+	walletRepo     repository.WalletRepository
+	attachmentRepo repository.AttachmentRepository
+}
+
+func NewResolveDuplicateService(
+	flagRepo repository.DuplicateFlagRepository,
+	walletRepo repository.WalletRepository,
+	attachmentRepo repository.AttachmentRepository,
+) *ResolveDuplicateService {
+	return &ResolveDuplicateService{
+		flagRepo:       flagRepo,
+		walletRepo:     walletRepo,
+		attachmentRepo: attachmentRepo,
+	}
+}
+
+func (s *ResolveDuplicateService) Execute(input usecase.ResolveDuplicateInput) common.Output {
+	// 1. 驗證處理方式並載入標記
+	resolution, err := model.ParseDuplicateResolution(input.Resolution)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid resolution: %v", err),
+		}
+	}
+
+	flag, err := s.flagRepo.FindByID(input.FlagID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find duplicate flag: %v", err),
+		}
+	}
+	if flag == nil || !common.IsAccessibleBy(flag.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Duplicate flag not found",
+		}
+	}
+	if !flag.IsPending() {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Resolving duplicate failed: %v", model.ErrDuplicateResolved),
+		}
+	}
+
+	// 2. 合併或刪除時先透過Domain Model變更錢包記錄 (保留兩筆時不變更錢包)
+	if resolution != model.ResolveKeepBoth {
+		output := retryOnConflict(func() common.Output {
+			return s.applyToWallet(flag, resolution)
+		})
+		if output.GetExitCode() != common.Success {
+			return output
+		}
+	}
+
+	// 3. 記錄處理結果，標記離開審查佇列
+	if err := flag.Resolve(resolution); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Resolving duplicate failed: %v", err),
+		}
+	}
+	if err := s.flagRepo.Save(flag); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving duplicate flag failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       flag.ID,
+		ExitCode: common.Success,
+		Message:  fmt.Sprintf("Duplicate resolved: %s", flag.Status),
+	}
+}
+
+func (s *ResolveDuplicateService) applyToWallet(flag *model.DuplicateFlag, resolution model.DuplicateResolution) common.Output {
+	wallet, err := s.walletRepo.FindByIDWithTransactions(flag.WalletID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find wallet: %v", err),
+		}
+	}
+	if wallet == nil {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Wallet not found",
+		}
+	}
+
+	switch {
+	case resolution == model.ResolveMerge && flag.RecordType == model.DuplicateIncome:
+		_, err = wallet.MergeIncome(flag.DuplicateOfID, flag.RecordID)
+	case resolution == model.ResolveMerge:
+		_, err = wallet.MergeExpense(flag.DuplicateOfID, flag.RecordID)
+	case flag.RecordType == model.DuplicateIncome:
+		err = wallet.RemoveIncome(flag.RecordID)
+	default:
+		err = wallet.RemoveExpense(flag.RecordID)
+	}
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Resolving duplicate failed: %v", err),
+		}
+	}
+
+	// 合併時附件移到保留的記錄；在儲存錢包前移動，儲存失敗時附件仍指向存在的記錄
+	if resolution == model.ResolveMerge {
+		if err := s.moveAttachments(flag); err != nil {
+			return common.UseCaseOutput{
+				ExitCode: common.Failure,
+				Message:  fmt.Sprintf("Moving attachments failed: %v", err),
+			}
+		}
+	}
+
+	if err := s.walletRepo.Save(wallet); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: saveFailureExitCode(err),
+			Message:  fmt.Sprintf("Saving wallet failed: %v", err),
+		}
+	}
+	return common.UseCaseOutput{ID: wallet.ID, ExitCode: common.Success}
+}
+
+func (s *ResolveDuplicateService) moveAttachments(flag *model.DuplicateFlag) error {
+	recordType := model.AttachmentExpense
+	if flag.RecordType == model.DuplicateIncome {
+		recordType = model.AttachmentIncome
+	}
+	attachments, err := s.attachmentRepo.FindByRecord(recordType, flag.RecordID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, attachment := range attachments {
+		attachment.RecordID = flag.DuplicateOfID
+		if err := s.attachmentRepo.Save(attachment); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,25 @@
+package command
+
+import (
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// ResumeRecurringRuleService 恢復暫停的週期規則；暫停期間到期的日期會被略過
+type ResumeRecurringRuleService struct {
+	repo repository.RecurringRuleRepository
+}
+
+func NewResumeRecurringRuleService(repo repository.RecurringRuleRepository) *ResumeRecurringRuleService {
+	return &ResumeRecurringRuleService{repo: repo}
+}
+
+func (s *ResumeRecurringRuleService) Execute(input usecase.ResumeRecurringRuleInput) common.Output {
+	return changeRecurringRule(s.repo, input.RuleID, input.UserID, func(rule *model.RecurringRule) error {
+		return rule.Resume(time.Now())
+	}, "Recurring rule resumed successfully")
+}
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// RetryOutboxMessageService 將卡住的Outbox訊息重新排入投遞 (重置嘗試次數)
+type RetryOutboxMessageService struct {
+	repo repository.OutboxRepository
+}
+
+func NewRetryOutboxMessageService(repo repository.OutboxRepository) *RetryOutboxMessageService {
+	return &RetryOutboxMessageService{repo: repo}
+}
+
+func (s *RetryOutboxMessageService) Execute(input usecase.RetryOutboxMessageInput) common.Output {
+	message, err := s.repo.FindByID(input.MessageID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find outbox message: %v", err),
+		}
+	}
+
+	if message == nil {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Outbox message not found",
+		}
+	}
+
+	if message.Status == mapper.OutboxStatusDelivered {
+		return common.UseCaseOutput{
+			ID:       message.ID,
+			ExitCode: common.Conflict,
+			Message:  "Outbox message already delivered",
+		}
+	}
+
+	// 保留LastError供追查，下一輪Relay立即重新投遞
+	message.Status = mapper.OutboxStatusPending
+	message.Attempts = 0
+	message.NextAttemptAt = time.Now()
+
+	if err := s.repo.Update(*message); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving outbox message failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       message.ID,
+		ExitCode: common.Success,
+		Message:  "Outbox message requeued successfully",
+	}
+}
@@ -0,0 +1,49 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// RevokeAPIKeyService 撤銷使用者自己的API金鑰 (保留記錄，不刪除)
+type RevokeAPIKeyService struct {
+	repo repository.APIKeyRepository
+}
+
+func NewRevokeAPIKeyService(repo repository.APIKeyRepository) *RevokeAPIKeyService {
+	return &RevokeAPIKeyService{repo: repo}
+}
+
+func (s *RevokeAPIKeyService) Execute(input usecase.RevokeAPIKeyInput) common.Output {
+	key, err := s.repo.FindByID(input.KeyID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find API key: %v", err),
+		}
+	}
+
+	if key == nil || !common.IsAccessibleBy(key.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "API key not found",
+		}
+	}
+
+	key.Revoke()
+
+	if err := s.repo.Save(key); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving API key failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       key.ID,
+		ExitCode: common.Success,
+		Message:  "API key revoked successfully",
+	}
+}
@@ -0,0 +1,25 @@
+package command
+
+import (
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// SkipRecurringOccurrenceService 略過週期規則的單一發生日
+type SkipRecurringOccurrenceService struct {
+	repo repository.RecurringRuleRepository
+}
+
+func NewSkipRecurringOccurrenceService(repo repository.RecurringRuleRepository) *SkipRecurringOccurrenceService {
+	return &SkipRecurringOccurrenceService{repo: repo}
+}
+
+func (s *SkipRecurringOccurrenceService) Execute(input usecase.SkipRecurringOccurrenceInput) common.Output {
+	return changeRecurringRule(s.repo, input.RuleID, input.UserID, func(rule *model.RecurringRule) error {
+		return rule.Skip(input.Date, time.Now())
+	}, "Recurring occurrence skipped successfully")
+}
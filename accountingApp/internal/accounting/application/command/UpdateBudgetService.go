@@ -0,0 +1,95 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// UpdateBudgetService 修改預算的名稱、金額、累積與警告設定或結束日
+type UpdateBudgetService struct {
+	repo repository.BudgetRepository
+}
+
+func NewUpdateBudgetService(repo repository.BudgetRepository) *UpdateBudgetService {
+	return &UpdateBudgetService{repo: repo}
+}
+
+func (s *UpdateBudgetService) Execute(input usecase.UpdateBudgetInput) common.Output {
+	// 1. 載入預算聚合
+	budget, err := s.repo.FindByID(input.BudgetID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find budget: %v", err),
+		}
+	}
+	if budget == nil || !common.IsAccessibleBy(budget.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Budget not found",
+		}
+	}
+
+	// 2. 透過Domain Model套用變更
+	if err := applyBudgetChanges(budget, input); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid budget: %v", err),
+		}
+	}
+
+	// 3. 儲存
+	if err := s.repo.Save(budget); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving budget failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       budget.ID,
+		ExitCode: common.Success,
+		Message:  "Budget updated successfully",
+	}
+}
+
+func applyBudgetChanges(budget *model.Budget, input usecase.UpdateBudgetInput) error {
+	if input.Name != nil {
+		if err := budget.Rename(*input.Name); err != nil {
+			return err
+		}
+	}
+	if input.Amount != nil || input.Currency != nil {
+		amount, currency := budget.Amount.Amount, budget.Amount.Currency
+		if input.Amount != nil {
+			amount = *input.Amount
+		}
+		if input.Currency != nil {
+			currency = *input.Currency
+		}
+		money, err := model.NewMoney(amount, currency)
+		if err != nil {
+			return err
+		}
+		if err := budget.ChangeAmount(*money); err != nil {
+			return err
+		}
+	}
+	if input.Rollover != nil {
+		budget.SetRollover(*input.Rollover)
+	}
+	if input.WarningThreshold != nil {
+		if err := budget.SetWarningThreshold(*input.WarningThreshold); err != nil {
+			return err
+		}
+	}
+	if input.EndDate != nil {
+		if err := budget.ChangeEndDate(input.EndDate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// UpdateCategorizationRuleService 以新的名稱、優先順序、條件與指定的分類取代分類規則
+type UpdateCategorizationRuleService struct {
+	repo    repository.CategorizationRuleRepository
+	targets ruleTargets
+}
+
+func NewUpdateCategorizationRuleService(
+	repo repository.CategorizationRuleRepository,
+	walletRepo repository.WalletRepository,
+	expenseCategoryRepo repository.ExpenseCategoryRepository,
+	incomeCategoryRepo repository.IncomeCategoryRepository,
+) *UpdateCategorizationRuleService {
+	return &UpdateCategorizationRuleService{
+		repo:    repo,
+		targets: ruleTargets{walletRepo: walletRepo, expenseCategoryRepo: expenseCategoryRepo, incomeCategoryRepo: incomeCategoryRepo},
+	}
+}
+
+func (s *UpdateCategorizationRuleService) Execute(input usecase.UpdateCategorizationRuleInput) common.Output {
+	// 1. 載入分類規則聚合
+	rule, err := s.repo.FindByID(input.RuleID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find categorization rule: %v", err),
+		}
+	}
+	if rule == nil || !common.IsAccessibleBy(rule.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Categorization rule not found",
+		}
+	}
+
+	// 2. 透過Domain Model套用變更
+	conditions, err := toRuleConditions(input.Conditions)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid categorization rule: %v", err),
+		}
+	}
+	if err := rule.Update(input.Name, input.Priority, conditions, input.SubcategoryID, input.Tags); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid categorization rule: %v", err),
+		}
+	}
+	if output := s.targets.verify(rule, input.UserID); output != nil {
+		return output
+	}
+
+	// 3. 儲存
+	if err := s.repo.Save(rule); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving categorization rule failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       rule.ID,
+		ExitCode: common.Success,
+		Message:  "Categorization rule updated successfully",
+	}
+}
@@ -0,0 +1,86 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+type UpdateExpenseService struct {
+	walletRepo repository.WalletRepository
+}
+
+func NewUpdateExpenseService(walletRepo repository.WalletRepository) *UpdateExpenseService {
+	return &UpdateExpenseService{
+		walletRepo: walletRepo,
+	}
+}
+
+func (s *UpdateExpenseService) Execute(input usecase.UpdateExpenseInput) common.Output {
+	return retryOnConflict(func() common.Output {
+		return s.execute(input)
+	})
+}
+
+func (s *UpdateExpenseService) execute(input usecase.UpdateExpenseInput) common.Output {
+	// 1. 透過支出記錄找到所屬錢包 (完整聚合)
+	wallet, err := s.walletRepo.FindByExpenseRecordID(input.ExpenseID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find wallet: %v", err),
+		}
+	}
+	if wallet == nil || !common.IsAccessibleBy(wallet.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Expense record not found",
+		}
+	}
+
+	// 2. 建立金額物件
+	amount, err := model.NewMoney(input.Amount, input.Currency)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid amount: %v", err),
+		}
+	}
+
+	// 3. 透過Domain Model修改支出並重新計算餘額 (有拆帳明細時取代原本的明細)
+	var expense *model.ExpenseRecord
+	if len(input.Splits) > 0 {
+		var splits []model.ExpenseSplit
+		if splits, err = toExpenseSplits(input.SubcategoryID, input.Splits, input.Currency); err != nil {
+			return common.UseCaseOutput{
+				ExitCode: common.InvalidInput,
+				Message:  fmt.Sprintf("Invalid split: %v", err),
+			}
+		}
+		expense, err = wallet.UpdateSplitExpense(input.ExpenseID, *amount, splits, input.Description, input.Date)
+	} else {
+		expense, err = wallet.UpdateExpense(input.ExpenseID, *amount, input.SubcategoryID, input.Description, input.Date)
+	}
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Updating expense failed: %v", err),
+		}
+	}
+
+	// 4. 儲存聚合 (只寫入修改的記錄)
+	if err := s.walletRepo.Save(wallet); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: saveFailureExitCode(err),
+			Message:  fmt.Sprintf("Saving wallet failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       expense.ID,
+		ExitCode: common.Success,
+		Message:  "Expense updated successfully",
+	}
+}
@@ -0,0 +1,71 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/statement"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// UpdateImportProfileService 以新的名稱與欄位對應取代匯入設定
+type UpdateImportProfileService struct {
+	repo                repository.ImportProfileRepository
+	expenseCategoryRepo repository.ExpenseCategoryRepository
+	incomeCategoryRepo  repository.IncomeCategoryRepository
+}
+
+func NewUpdateImportProfileService(
+	repo repository.ImportProfileRepository,
+	expenseCategoryRepo repository.ExpenseCategoryRepository,
+	incomeCategoryRepo repository.IncomeCategoryRepository,
+) *UpdateImportProfileService {
+	return &UpdateImportProfileService{
+		repo:                repo,
+		expenseCategoryRepo: expenseCategoryRepo,
+		incomeCategoryRepo:  incomeCategoryRepo,
+	}
+}
+
+func (s *UpdateImportProfileService) Execute(input usecase.UpdateImportProfileInput) common.Output {
+	// 1. 載入匯入設定聚合
+	profile, err := s.repo.FindByID(input.ProfileID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find import profile: %v", err),
+		}
+	}
+	if profile == nil || !common.IsAccessibleBy(profile.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Import profile not found",
+		}
+	}
+
+	// 2. 透過Domain Model套用變更
+	if err := profile.Update(input.Name, toImportMapping(input.Mapping)); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid import profile: %v", err),
+		}
+	}
+	if output := statement.VerifySubcategories(s.expenseCategoryRepo, s.incomeCategoryRepo, profile.Mapping, input.UserID); output != nil {
+		return output
+	}
+
+	// 3. 儲存
+	if err := s.repo.Save(profile); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving import profile failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       profile.ID,
+		ExitCode: common.Success,
+		Message:  "Import profile updated successfully",
+	}
+}
@@ -0,0 +1,74 @@
+package command
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+type UpdateIncomeService struct {
+	walletRepo repository.WalletRepository
+}
+
+func NewUpdateIncomeService(walletRepo repository.WalletRepository) *UpdateIncomeService {
+	return &UpdateIncomeService{
+		walletRepo: walletRepo,
+	}
+}
+
+func (s *UpdateIncomeService) Execute(input usecase.UpdateIncomeInput) common.Output {
+	return retryOnConflict(func() common.Output {
+		return s.execute(input)
+	})
+}
+
+func (s *UpdateIncomeService) execute(input usecase.UpdateIncomeInput) common.Output {
+	// 1. 透過收入記錄找到所屬錢包 (完整聚合)
+	wallet, err := s.walletRepo.FindByIncomeRecordID(input.IncomeID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find wallet: %v", err),
+		}
+	}
+	if wallet == nil || !common.IsAccessibleBy(wallet.UserID, input.UserID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Income record not found",
+		}
+	}
+
+	// 2. 建立金額物件
+	amount, err := model.NewMoney(input.Amount, input.Currency)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid amount: %v", err),
+		}
+	}
+
+	// 3. 透過Domain Model修改收入並重新計算餘額
+	income, err := wallet.UpdateIncome(input.IncomeID, *amount, input.SubcategoryID, input.Description, input.Date)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Updating income failed: %v", err),
+		}
+	}
+
+	// 4. 儲存聚合 (只寫入修改的記錄)
+	if err := s.walletRepo.Save(wallet); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: saveFailureExitCode(err),
+			Message:  fmt.Sprintf("Saving wallet failed: %v", err),
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       income.ID,
+		ExitCode: common.Success,
+		Message:  "Income updated successfully",
+	}
+}
@@ -17,6 +17,12 @@ func NewUpdateWalletService(repo repository.WalletRepository) *UpdateWalletServi
 }
 
 func (s *UpdateWalletService) Execute(input usecase.UpdateWalletInput) common.Output {
+	return retryOnConflict(func() common.Output {
+		return s.execute(input)
+	})
+}
+
+func (s *UpdateWalletService) execute(input usecase.UpdateWalletInput) common.Output {
 	// Get existing wallet
 	wallet, err := s.repo.FindByID(input.WalletID)
 	if err != nil {
@@ -26,9 +32,9 @@ func (s *UpdateWalletService) Execute(input usecase.UpdateWalletInput) common.Ou
 		}
 	}
 
-	if wallet == nil {
+	if wallet == nil || !common.IsAccessibleBy(wallet.UserID, input.UserID) {
 		return common.UseCaseOutput{
-			ExitCode: common.Failure,
+			ExitCode: common.NotFound,
 			Message:  "Wallet not found",
 		}
 	}
@@ -39,7 +45,7 @@ func (s *UpdateWalletService) Execute(input usecase.UpdateWalletInput) common.Ou
 	if input.Name != nil && *input.Name != wallet.Name {
 		if err := wallet.UpdateName(*input.Name); err != nil {
 			return common.UseCaseOutput{
-				ExitCode: common.Failure,
+				ExitCode: common.InvalidInput,
 				Message:  fmt.Sprintf("Invalid wallet name: %v", err),
 			}
 		}
@@ -50,7 +56,7 @@ func (s *UpdateWalletService) Execute(input usecase.UpdateWalletInput) common.Ou
 		walletType, err := model.ParseWalletType(*input.Type)
 		if err != nil {
 			return common.UseCaseOutput{
-				ExitCode: common.Failure,
+				ExitCode: common.InvalidInput,
 				Message:  fmt.Sprintf("Invalid wallet type: %v", err),
 			}
 		}
@@ -68,7 +74,7 @@ func (s *UpdateWalletService) Execute(input usecase.UpdateWalletInput) common.Ou
 	if updated {
 		if err := s.repo.Save(wallet); err != nil {
 			return common.UseCaseOutput{
-				ExitCode: common.Failure,
+				ExitCode: saveFailureExitCode(err),
 				Message:  fmt.Sprintf("Failed to update wallet: %v", err),
 			}
 		}
@@ -0,0 +1,148 @@
+package command
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// UploadAttachmentService 上傳支出或收入記錄的附件：檢查類型與大小、計算checksum，
+// 先寫入BlobStore再儲存中繼資料
+type UploadAttachmentService struct {
+	walletRepo     repository.WalletRepository
+	attachmentRepo repository.AttachmentRepository
+	blobStore      repository.BlobStore
+}
+
+func NewUploadAttachmentService(walletRepo repository.WalletRepository, attachmentRepo repository.AttachmentRepository, blobStore repository.BlobStore) *UploadAttachmentService {
+	return &UploadAttachmentService{
+		walletRepo:     walletRepo,
+		attachmentRepo: attachmentRepo,
+		blobStore:      blobStore,
+	}
+}
+
+func (s *UploadAttachmentService) Execute(input usecase.UploadAttachmentInput) common.Output {
+	recordType, err := model.ParseAttachmentRecordType(input.RecordType)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid attachment: %v", err),
+		}
+	}
+	if input.Content == nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  "Invalid attachment: content is required",
+		}
+	}
+
+	// 1. 確認記錄存在且屬於使用者，並取得所屬錢包
+	walletID, output := s.findRecordWallet(input.UserID, recordType, input.RecordID)
+	if output != nil {
+		return output
+	}
+
+	// 2. 讀取內容 (最多比上限多讀1 byte以判斷是否超過)
+	content, err := io.ReadAll(io.LimitReader(input.Content, model.MaxAttachmentSize+1))
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to read attachment: %v", err),
+		}
+	}
+	if int64(len(content)) > model.MaxAttachmentSize {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid attachment: exceeds the %d byte limit", model.MaxAttachmentSize),
+		}
+	}
+
+	// 3. 宣告的類型必須與內容相符，避免以允許的類型上傳其他檔案
+	contentType := model.DetectAttachmentContentType(content)
+	if input.ContentType != "" {
+		declared, _, err := mime.ParseMediaType(input.ContentType)
+		if err != nil || declared != contentType {
+			return common.UseCaseOutput{
+				ExitCode: common.InvalidInput,
+				Message:  fmt.Sprintf("Invalid attachment: content does not match declared type %s", input.ContentType),
+			}
+		}
+	}
+
+	sum := sha256.Sum256(content)
+	attachment, err := model.NewAttachment(input.UserID, walletID, recordType, input.RecordID,
+		input.FileName, contentType, int64(len(content)), hex.EncodeToString(sum[:]))
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid attachment: %v", err),
+		}
+	}
+
+	// 4. 先寫入檔案再儲存中繼資料；中繼資料儲存失敗時刪除已寫入的檔案
+	if err := s.blobStore.Put(attachment.StorageKey, bytes.NewReader(content)); err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to store attachment: %v", err),
+		}
+	}
+	if err := s.attachmentRepo.Save(attachment); err != nil {
+		if deleteErr := s.blobStore.Delete(attachment.StorageKey); deleteErr != nil {
+			log.Printf("failed to remove blob %s after metadata save failed: %v", attachment.StorageKey, deleteErr)
+		}
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Saving attachment failed: %v", err),
+		}
+	}
+
+	return usecase.UploadAttachmentOutput{
+		ID:       attachment.ID,
+		ExitCode: common.Success,
+		Message:  "Attachment uploaded successfully",
+		Attachment: usecase.AttachmentData{
+			ID:          attachment.ID,
+			RecordType:  string(attachment.RecordType),
+			RecordID:    attachment.RecordID,
+			FileName:    attachment.FileName,
+			ContentType: attachment.ContentType,
+			Size:        attachment.Size,
+			Checksum:    attachment.Checksum,
+			CreatedAt:   attachment.CreatedAt.Format(time.RFC3339),
+		},
+	}
+}
+
+// findRecordWallet 回傳記錄所屬的錢包ID；記錄不存在或不屬於使用者時回傳失敗結果
+func (s *UploadAttachmentService) findRecordWallet(userID string, recordType model.AttachmentRecordType, recordID string) (string, common.Output) {
+	findByRecord, notFound := s.walletRepo.FindByExpenseRecordID, "Expense record not found"
+	if recordType == model.AttachmentIncome {
+		findByRecord, notFound = s.walletRepo.FindByIncomeRecordID, "Income record not found"
+	}
+
+	wallet, err := findByRecord(recordID)
+	if err != nil {
+		return "", common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find wallet: %v", err),
+		}
+	}
+	if wallet == nil || !common.IsAccessibleBy(wallet.UserID, userID) {
+		return "", common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  notFound,
+		}
+	}
+	return wallet.ID, nil
+}
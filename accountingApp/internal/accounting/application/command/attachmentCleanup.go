@@ -0,0 +1,41 @@
+package command
+
+import (
+	"log"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// attachmentCleanup 清理已刪除的錢包或交易記錄的附件；attachmentRepo為nil時不清理
+type attachmentCleanup struct {
+	attachmentRepo repository.AttachmentRepository
+	blobStore      repository.BlobStore
+}
+
+// removeRecordAttachments 清理已刪除記錄的附件；記錄已刪除，失敗只記錄不回報
+func (c attachmentCleanup) removeRecordAttachments(recordType model.AttachmentRecordType, recordID string) {
+	if c.attachmentRepo == nil {
+		return
+	}
+	attachments, err := c.attachmentRepo.FindByRecord(recordType, recordID)
+	if err != nil {
+		log.Printf("failed to find attachments of %s %s: %v", recordType, recordID, err)
+		return
+	}
+	c.removeAttachments(attachments)
+}
+
+// removeAttachments 刪除附件的檔案與中繼資料，失敗只記錄不回報。
+// 檔案刪除失敗時保留中繼資料，作為孤兒檔案的記錄
+func (c attachmentCleanup) removeAttachments(attachments []*model.Attachment) {
+	for _, attachment := range attachments {
+		if err := c.blobStore.Delete(attachment.StorageKey); err != nil {
+			log.Printf("failed to delete blob %s of attachment %s: %v", attachment.StorageKey, attachment.ID, err)
+			continue
+		}
+		if err := c.attachmentRepo.Delete(attachment.ID); err != nil {
+			log.Printf("failed to delete attachment %s: %v", attachment.ID, err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// toRuleConditions 將輸入的規則條件轉換為Domain Model (星期名稱解析為time.Weekday)
+func toRuleConditions(data usecase.RuleConditionsData) (model.RuleConditions, error) {
+	weekdays := make([]time.Weekday, 0, len(data.Weekdays))
+	for _, name := range data.Weekdays {
+		day, err := model.ParseWeekday(name)
+		if err != nil {
+			return model.RuleConditions{}, err
+		}
+		weekdays = append(weekdays, day)
+	}
+	return model.RuleConditions{
+		DescriptionPattern: data.DescriptionPattern,
+		MinAmount:          data.MinAmount,
+		MaxAmount:          data.MaxAmount,
+		Currency:           data.Currency,
+		WalletID:           data.WalletID,
+		Weekdays:           weekdays,
+	}, nil
+}
+
+// ruleTargets 檢查分類規則參照的子分類與錢包
+type ruleTargets struct {
+	walletRepo          repository.WalletRepository
+	expenseCategoryRepo repository.ExpenseCategoryRepository
+	incomeCategoryRepo  repository.IncomeCategoryRepository
+}
+
+// verify 確認規則指定的子分類與條件中的錢包屬於使用者，且子分類與交易類型相符，通過時回傳nil
+func (t ruleTargets) verify(rule *model.CategorizationRule, userID string) common.Output {
+	if walletID := rule.Conditions.WalletID; walletID != "" {
+		wallet, err := t.walletRepo.FindByID(walletID)
+		if err != nil {
+			return common.UseCaseOutput{
+				ExitCode: common.Failure,
+				Message:  fmt.Sprintf("Failed to find wallet: %v", err),
+			}
+		}
+		if wallet == nil || !common.IsAccessibleBy(wallet.UserID, userID) {
+			return common.UseCaseOutput{
+				ExitCode: common.NotFound,
+				Message:  "Wallet not found",
+			}
+		}
+	}
+
+	var ownerID string
+	var err error
+	if rule.Type == model.CategorizeExpense {
+		var category *model.ExpenseCategory
+		if category, err = t.expenseCategoryRepo.FindBySubcategoryID(rule.SubcategoryID); category != nil {
+			ownerID = category.UserID
+		}
+	} else {
+		var category *model.IncomeCategory
+		if category, err = t.incomeCategoryRepo.FindBySubcategoryID(rule.SubcategoryID); category != nil {
+			ownerID = category.UserID
+		}
+	}
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find category: %v", err),
+		}
+	}
+	if ownerID == "" || !common.IsAccessibleBy(ownerID, userID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Subcategory not found",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package command
+
+import "fmt"
+
+// notFoundError 交易中的資源不存在或不屬於使用者，ExitCode為NotFound
+type notFoundError struct {
+	kind string
+	id   string
+}
+
+func (e *notFoundError) Error() string {
+	return fmt.Sprintf("%s not found: %s", e.kind, e.id)
+}
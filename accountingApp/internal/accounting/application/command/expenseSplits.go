@@ -0,0 +1,31 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// toExpenseSplits 將拆帳明細輸入轉為領域物件；明細與總額同幣別，加總由Wallet驗證
+// 拆帳時不可同時指定subcategoryID，避免呼叫端誤以為整筆支出屬於該子分類
+func toExpenseSplits(subcategoryID string, inputs []usecase.ExpenseSplitInput, currency string) ([]model.ExpenseSplit, error) {
+	if subcategoryID != "" {
+		return nil, errors.New("use either a subcategory or split lines, not both")
+	}
+
+	splits := make([]model.ExpenseSplit, len(inputs))
+	for i, input := range inputs {
+		amount, err := model.NewMoney(input.Amount, currency)
+		if err != nil {
+			return nil, fmt.Errorf("split line %d: %w", i+1, err)
+		}
+		splits[i] = model.ExpenseSplit{
+			SubcategoryID: input.SubcategoryID,
+			Amount:        *amount,
+			Memo:          input.Memo,
+		}
+	}
+	return splits, nil
+}
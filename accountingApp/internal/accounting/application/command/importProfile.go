@@ -0,0 +1,25 @@
+package command
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// toImportMapping 將輸入的欄位對應轉換為Domain Model
+func toImportMapping(data usecase.ImportMappingData) model.ImportMapping {
+	return model.ImportMapping{
+		Delimiter:            data.Delimiter,
+		Encoding:             model.ImportEncoding(data.Encoding),
+		HasHeader:            data.HasHeader,
+		SkipRows:             data.SkipRows,
+		DateColumn:           data.DateColumn,
+		DateFormat:           data.DateFormat,
+		DescriptionColumn:    data.DescriptionColumn,
+		AmountColumn:         data.AmountColumn,
+		SignConvention:       model.AmountSignConvention(data.SignConvention),
+		DebitColumn:          data.DebitColumn,
+		CreditColumn:         data.CreditColumn,
+		ExpenseSubcategoryID: data.ExpenseSubcategoryID,
+		IncomeSubcategoryID:  data.IncomeSubcategoryID,
+	}
+}
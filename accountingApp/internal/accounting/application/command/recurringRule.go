@@ -0,0 +1,52 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// changeRecurringRule 載入使用者的週期規則、套用change並儲存
+// 排程器可能同時更新同一條規則，樂觀鎖衝突時重新載入並重試
+func changeRecurringRule(repo repository.RecurringRuleRepository, ruleID, userID string, change func(rule *model.RecurringRule) error, successMessage string) common.Output {
+	return retryOnConflict(func() common.Output {
+		// 1. 載入週期規則聚合
+		rule, err := repo.FindByID(ruleID)
+		if err != nil {
+			return common.UseCaseOutput{
+				ExitCode: common.Failure,
+				Message:  fmt.Sprintf("Failed to find recurring rule: %v", err),
+			}
+		}
+		if rule == nil || !common.IsAccessibleBy(rule.UserID, userID) {
+			return common.UseCaseOutput{
+				ExitCode: common.NotFound,
+				Message:  "Recurring rule not found",
+			}
+		}
+
+		// 2. 透過Domain Model套用變更
+		if err := change(rule); err != nil {
+			return common.UseCaseOutput{
+				ExitCode: common.InvalidInput,
+				Message:  fmt.Sprintf("Invalid recurring rule operation: %v", err),
+			}
+		}
+
+		// 3. 儲存
+		if err := repo.Save(rule); err != nil {
+			return common.UseCaseOutput{
+				ExitCode: saveFailureExitCode(err),
+				Message:  fmt.Sprintf("Saving recurring rule failed: %v", err),
+			}
+		}
+
+		return common.UseCaseOutput{
+			ID:       rule.ID,
+			ExitCode: common.Success,
+			Message:  successMessage,
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package command
+
+import (
+	"errors"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+)
+
+// maxConcurrencyRetries 樂觀鎖衝突時最多重新執行的次數
+const maxConcurrencyRetries = 3
+
+// retryOnConflict 執行attempt，遇到樂觀鎖衝突 (ExitCode為Conflict) 時重新載入並重試
+// 每次attempt都必須從Repository重新讀取聚合；重試用盡時回傳最後一次的Conflict輸出
+func retryOnConflict(attempt func() common.Output) common.Output {
+	output := attempt()
+	for retries := 0; retries < maxConcurrencyRetries && output.GetExitCode() == common.Conflict; retries++ {
+		output = attempt()
+	}
+	return output
+}
+
+// saveFailureExitCode 依儲存錯誤決定ExitCode，樂觀鎖衝突回傳Conflict以觸發重試，找不到資源回傳NotFound
+func saveFailureExitCode(err error) common.ExitCode {
+	if errors.Is(err, repository.ErrConcurrencyConflict) {
+		return common.Conflict
+	}
+	var notFound *notFoundError
+	if errors.As(err, &notFound) {
+		return common.NotFound
+	}
+	return common.Failure
+}
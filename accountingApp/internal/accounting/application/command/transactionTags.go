@@ -0,0 +1,13 @@
+package command
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// initialTags 將新交易的標籤輸入轉為標籤修改；沒有標籤時回傳零值，套用後不會有任何變更
+func initialTags(tags []string) (model.TagEdit, error) {
+	if len(tags) == 0 {
+		return model.TagEdit{}, nil
+	}
+	return model.NewTagEdit(tags, nil)
+}
@@ -5,4 +5,7 @@ type ExitCode int
 const (
 	Success ExitCode = iota
 	Failure
+	Conflict     // 樂觀鎖衝突重試用盡
+	NotFound     // 資源不存在，或屬於其他使用者
+	InvalidInput // 輸入不合法
 )
@@ -0,0 +1,15 @@
+package common
+
+// SystemActor 系統內部呼叫 (不經過HTTP認證，例如維運工具) 使用的呼叫者ID，不做擁有者檢查；
+// 排程與匯入以資源擁有者的身分執行，不使用此ID
+const SystemActor = "system"
+
+// IsAccessibleBy 判斷呼叫者是否可存取屬於 ownerID 的資源
+// requesterID 為空時一律拒絕，以免漏填 UserID 的呼叫取得他人的資源；
+// 經認證的請求一律帶入使用者ID，存取他人的資源時服務應回報「找不到」以免洩漏資源存在與否
+func IsAccessibleBy(ownerID, requesterID string) bool {
+	if requesterID == "" {
+		return false
+	}
+	return requesterID == SystemActor || ownerID == requesterID
+}
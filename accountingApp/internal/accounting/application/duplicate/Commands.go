@@ -0,0 +1,108 @@
+package duplicate
+
+import (
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// AddExpenseCommand 以重複偵測包裝AddExpense：新增成功後將可能重複的記錄附在輸出上
+type AddExpenseCommand struct {
+	next    usecase.AddExpenseUseCase
+	flagger *Flagger
+}
+
+// NewAddExpenseCommand 創建含重複偵測的新增支出Command
+func NewAddExpenseCommand(next usecase.AddExpenseUseCase, flagger *Flagger) *AddExpenseCommand {
+	return &AddExpenseCommand{next: next, flagger: flagger}
+}
+
+func (c *AddExpenseCommand) Execute(input usecase.AddExpenseInput) common.Output {
+	output := c.next.Execute(input)
+	if output.GetExitCode() != common.Success {
+		return output
+	}
+
+	duplicates := c.flagger.flagOrLog(input.WalletID, input.Date, input.Date, []string{output.GetID()}, nil)
+	if len(duplicates) == 0 {
+		return output
+	}
+	result, ok := output.(usecase.AddExpenseOutput)
+	if !ok {
+		result = usecase.AddExpenseOutput{ID: output.GetID(), ExitCode: output.GetExitCode(), Message: output.GetMessage()}
+	}
+	result.Duplicates = duplicates
+	return result
+}
+
+// AddIncomeCommand 以重複偵測包裝AddIncome：新增成功後將可能重複的記錄附在輸出上
+type AddIncomeCommand struct {
+	next    usecase.AddIncomeUseCase
+	flagger *Flagger
+}
+
+// NewAddIncomeCommand 創建含重複偵測的新增收入Command
+func NewAddIncomeCommand(next usecase.AddIncomeUseCase, flagger *Flagger) *AddIncomeCommand {
+	return &AddIncomeCommand{next: next, flagger: flagger}
+}
+
+func (c *AddIncomeCommand) Execute(input usecase.AddIncomeInput) common.Output {
+	output := c.next.Execute(input)
+	if output.GetExitCode() != common.Success {
+		return output
+	}
+
+	duplicates := c.flagger.flagOrLog(input.WalletID, input.Date, input.Date, nil, []string{output.GetID()})
+	if len(duplicates) == 0 {
+		return output
+	}
+	return usecase.AddIncomeOutput{
+		ID:         output.GetID(),
+		ExitCode:   output.GetExitCode(),
+		Message:    output.GetMessage(),
+		Duplicates: duplicates,
+	}
+}
+
+// CommitImportCommand 以重複偵測包裝CommitImport：匯入的記錄與錢包中手動新增的記錄比對
+// (重複匯入同一筆銀行交易已由匯入識別略過)
+type CommitImportCommand struct {
+	next    usecase.CommitImportUseCase
+	flagger *Flagger
+}
+
+// NewCommitImportCommand 創建含重複偵測的匯入Command
+func NewCommitImportCommand(next usecase.CommitImportUseCase, flagger *Flagger) *CommitImportCommand {
+	return &CommitImportCommand{next: next, flagger: flagger}
+}
+
+func (c *CommitImportCommand) Execute(input usecase.CommitImportInput) common.Output {
+	output := c.next.Execute(input)
+	result, ok := output.(usecase.CommitImportOutput)
+	if !ok || result.ExitCode != common.Success {
+		return output
+	}
+
+	var expenseIDs, incomeIDs []string
+	var first, last time.Time
+	for _, row := range result.Rows {
+		if row.RecordID == "" {
+			continue
+		}
+		if row.Type == string(model.ImportIncome) {
+			incomeIDs = append(incomeIDs, row.RecordID)
+		} else {
+			expenseIDs = append(expenseIDs, row.RecordID)
+		}
+		if first.IsZero() || row.Date.Before(first) {
+			first = row.Date
+		}
+		if row.Date.After(last) {
+			last = row.Date
+		}
+	}
+	result.Duplicates = c.flagger.flagOrLog(input.WalletID, first, last, expenseIDs, incomeIDs)
+	return result
+}
@@ -0,0 +1,202 @@
+package duplicate
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+)
+
+// maxCompareRunes 描述比對時最多使用的字元數，避免過長的描述拖慢比對
+const maxCompareRunes = 64
+
+// DetectorConfig 判斷兩筆交易可能重複的條件
+type DetectorConfig struct {
+	WindowDays      int     // 兩筆交易的日期最多相差的天數
+	AmountTolerance float64 // 金額可相差的比例 (0.01 為較大金額的1%)
+	MinSimilarity   float64 // 描述的最低相似度 (0-1)
+}
+
+// DefaultDetectorConfig 回傳預設設定
+func DefaultDetectorConfig() DetectorConfig {
+	return DetectorConfig{
+		WindowDays:      3,
+		AmountTolerance: 0.01,
+		MinSimilarity:   0.6,
+	}
+}
+
+// Match 一筆新記錄與錢包中最相似的另一筆記錄
+type Match struct {
+	RecordID      string
+	DuplicateOfID string
+	Score         float64 // 描述的相似度 (0-1)
+}
+
+// Detector 在同一錢包的記錄中找出可能重複的交易：金額相近、日期接近且描述相似
+// 兩筆都有匯入識別且不同時 (銀行認定為不同的交易) 不視為重複
+type Detector struct {
+	config DetectorConfig
+}
+
+// NewDetector 創建重複交易偵測器
+func NewDetector(config DetectorConfig) *Detector {
+	return &Detector{config: config}
+}
+
+// ExpenseDuplicates 為newIDs中的每筆支出找出最相似的其他支出
+func (d *Detector) ExpenseDuplicates(records []mapper.ExpenseRecordData, newIDs []string) []Match {
+	candidates := make([]candidate, len(records))
+	for i, record := range records {
+		candidates[i] = newCandidate(record.ID, record.Amount, record.Date, record.Description, record.ImportID)
+	}
+	return d.find(candidates, newIDs)
+}
+
+// IncomeDuplicates 為newIDs中的每筆收入找出最相似的其他收入
+func (d *Detector) IncomeDuplicates(records []mapper.IncomeRecordData, newIDs []string) []Match {
+	candidates := make([]candidate, len(records))
+	for i, record := range records {
+		candidates[i] = newCandidate(record.ID, record.Amount, record.Date, record.Description, record.ImportID)
+	}
+	return d.find(candidates, newIDs)
+}
+
+// Range 與日期在 [first, last] 之間的新記錄比對時需要的其他記錄的日期範圍 [from, to)
+// 前後各多取一天，涵蓋記錄日期所在時區與UTC的差異
+func (d *Detector) Range(first, last time.Time) (from, to time.Time) {
+	days := d.config.WindowDays + 1
+	from = time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -days)
+	to = time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, days+1)
+	return from, to
+}
+
+type candidate struct {
+	id          string
+	amount      int64
+	day         int64 // 日期 (不含時間) 的日數
+	description []rune
+	importID    string
+}
+
+func newCandidate(id string, amount int64, date time.Time, description, importID string) candidate {
+	return candidate{
+		id:          id,
+		amount:      amount,
+		day:         time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400,
+		description: normalizeDescription(description),
+		importID:    importID,
+	}
+}
+
+func (d *Detector) find(records []candidate, newIDs []string) []Match {
+	order := make(map[string]int, len(newIDs))
+	for i, id := range newIDs {
+		order[id] = i
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].day < records[j].day })
+
+	window := int64(d.config.WindowDays)
+	var matches []Match
+	for _, record := range records {
+		position, isNew := order[record.id]
+		if !isNew {
+			continue
+		}
+
+		best := Match{RecordID: record.id}
+		start := sort.Search(len(records), func(i int) bool { return records[i].day >= record.day-window })
+		for _, other := range records[start:] {
+			if other.day > record.day+window {
+				break
+			}
+			// 同一批新增的記錄只和批次中較早的比對，避免兩筆互相標記
+			if otherPosition, otherIsNew := order[other.id]; other.id == record.id || (otherIsNew && otherPosition >= position) {
+				continue
+			}
+			if !d.sameTransaction(record, other) {
+				continue
+			}
+			if score := similarity(record.description, other.description); score >= d.config.MinSimilarity && score > best.Score {
+				best.DuplicateOfID, best.Score = other.id, score
+			}
+		}
+		if best.DuplicateOfID != "" {
+			matches = append(matches, best)
+		}
+	}
+	return matches
+}
+
+// sameTransaction 金額相近且不是銀行認定為不同的兩筆交易
+func (d *Detector) sameTransaction(a, b candidate) bool {
+	if a.importID != "" && b.importID != "" && a.importID != b.importID {
+		return false
+	}
+	difference := a.amount - b.amount
+	if difference < 0 {
+		difference = -difference
+	}
+	larger := a.amount
+	if b.amount > larger {
+		larger = b.amount
+	}
+	return float64(difference) <= float64(larger)*d.config.AmountTolerance
+}
+
+// normalizeDescription 轉為小寫，只保留文字與數字 (其他字元視為空白)
+func normalizeDescription(description string) []rune {
+	fields := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	normalized := []rune(strings.Join(fields, " "))
+	if len(normalized) > maxCompareRunes {
+		normalized = normalized[:maxCompareRunes]
+	}
+	return normalized
+}
+
+// similarity 兩個已正規化描述的相似度：一方包含另一方 (例如手動輸入的店名與銀行的完整摘要) 為0.9，
+// 否則為 1 - 編輯距離/較長的長度；兩者皆空白視為相同
+func similarity(a, b []rune) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		if len(a) == len(b) {
+			return 1
+		}
+		return 0
+	}
+
+	longer, shorter := a, b
+	if len(shorter) > len(longer) {
+		longer, shorter = shorter, longer
+	}
+	score := 1 - float64(editDistance(a, b))/float64(len(longer))
+	if score < 0.9 && len(shorter) >= 3 && strings.Contains(string(longer), string(shorter)) {
+		score = 0.9
+	}
+	return math.Round(score*100) / 100
+}
+
+// editDistance Levenshtein距離 (以字元計算)
+func editDistance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,138 @@
+package duplicate
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// Flagger 在新增或匯入交易後比對同一錢包的記錄，將可能重複的交易加入審查佇列
+type Flagger struct {
+	walletRepo   repository.WalletRepository
+	flagRepo     repository.DuplicateFlagRepository
+	detector     *Detector
+	walletMapper *mapper.WalletMapper
+}
+
+// NewFlagger 創建重複交易標記器
+func NewFlagger(walletRepo repository.WalletRepository, flagRepo repository.DuplicateFlagRepository, detector *Detector) *Flagger {
+	return &Flagger{
+		walletRepo:   walletRepo,
+		flagRepo:     flagRepo,
+		detector:     detector,
+		walletMapper: mapper.NewWalletMapper(),
+	}
+}
+
+// Flag 比對錢包中剛新增的支出與收入 (日期在 [first, last] 之間)，儲存並回傳新的重複標記
+// 只查詢偵測範圍內的記錄，不載入錢包聚合
+func (f *Flagger) Flag(walletID string, first, last time.Time, expenseIDs, incomeIDs []string) ([]usecase.DuplicateData, error) {
+	if len(expenseIDs) == 0 && len(incomeIDs) == 0 {
+		return nil, nil
+	}
+
+	wallet, err := f.walletRepo.FindByID(walletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet == nil {
+		return nil, fmt.Errorf("wallet %s no longer exists", walletID)
+	}
+	from, to := f.detector.Range(first, last)
+	expenses, incomes, err := f.walletRepo.FindRecordsByDateRange(walletID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	data := mapper.WalletData{ID: wallet.ID, UserID: wallet.UserID}
+	data.ExpenseRecords, data.IncomeRecords = f.walletMapper.ToRecordsData(expenses, incomes)
+
+	var duplicates []usecase.DuplicateData
+	save := func(recordType model.DuplicateRecordType, matches []Match) error {
+		for _, match := range matches {
+			flag, err := model.NewDuplicateFlag(wallet.UserID, wallet.ID, recordType, match.RecordID, match.DuplicateOfID, match.Score)
+			if err != nil {
+				return err
+			}
+			if err := f.flagRepo.Save(flag); err != nil {
+				return err
+			}
+			duplicates = append(duplicates, Present(flag, data))
+		}
+		return nil
+	}
+	if err := save(model.DuplicateExpense, f.detector.ExpenseDuplicates(data.ExpenseRecords, expenseIDs)); err != nil {
+		return duplicates, err
+	}
+	if err := save(model.DuplicateIncome, f.detector.IncomeDuplicates(data.IncomeRecords, incomeIDs)); err != nil {
+		return duplicates, err
+	}
+	return duplicates, nil
+}
+
+// flagOrLog 標記失敗只寫入log，交易已經記錄，不影響Command的結果
+func (f *Flagger) flagOrLog(walletID string, first, last time.Time, expenseIDs, incomeIDs []string) []usecase.DuplicateData {
+	duplicates, err := f.Flag(walletID, first, last, expenseIDs, incomeIDs)
+	if err != nil {
+		log.Printf("duplicate detection: wallet %s: %v", walletID, err)
+	}
+	return duplicates
+}
+
+// Present 將重複標記與錢包中兩筆記錄的資料轉為API回應；已不存在的記錄留空
+func Present(flag *model.DuplicateFlag, wallet mapper.WalletData) usecase.DuplicateData {
+	duplicate := usecase.DuplicateData{
+		ID:        flag.ID,
+		WalletID:  flag.WalletID,
+		Type:      string(flag.RecordType),
+		Status:    string(flag.Status),
+		Score:     flag.Score,
+		CreatedAt: flag.CreatedAt.Format(time.RFC3339),
+	}
+	if flag.ResolvedAt != nil {
+		duplicate.ResolvedAt = flag.ResolvedAt.Format(time.RFC3339)
+	}
+	duplicate.Record = findRecord(flag.RecordType, flag.RecordID, wallet)
+	duplicate.DuplicateOf = findRecord(flag.RecordType, flag.DuplicateOfID, wallet)
+	return duplicate
+}
+
+func findRecord(recordType model.DuplicateRecordType, recordID string, wallet mapper.WalletData) *usecase.DuplicateRecordData {
+	if recordType == model.DuplicateIncome {
+		for _, record := range wallet.IncomeRecords {
+			if record.ID == recordID {
+				return &usecase.DuplicateRecordData{
+					ID:            record.ID,
+					SubcategoryID: record.SubcategoryID,
+					Amount:        record.Amount,
+					Currency:      record.Currency,
+					Description:   record.Description,
+					Date:          record.Date,
+					Tags:          record.Tags,
+					Imported:      record.ImportID != "",
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, record := range wallet.ExpenseRecords {
+		if record.ID == recordID {
+			return &usecase.DuplicateRecordData{
+				ID:            record.ID,
+				SubcategoryID: record.SubcategoryID,
+				Amount:        record.Amount,
+				Currency:      record.Currency,
+				Description:   record.Description,
+				Date:          record.Date,
+				Tags:          record.Tags,
+				Imported:      record.ImportID != "",
+			}
+		}
+	}
+	return nil
+}
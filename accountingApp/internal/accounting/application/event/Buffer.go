@@ -0,0 +1,37 @@
+package event
+
+import "github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+
+// Buffer 暫存交易中儲存的聚合事件
+// UnitOfWork 交易內的Repository發布到Buffer，提交成功後才轉交真正的Publisher，
+// 回滾時直接丟棄，訂閱者不會看到未提交的變更
+type Buffer struct {
+	events []model.DomainEvent
+}
+
+// NewBuffer 創建事件暫存
+func NewBuffer() *Buffer {
+	return &Buffer{}
+}
+
+// Publish 暫存事件
+func (b *Buffer) Publish(events []model.DomainEvent) {
+	b.events = append(b.events, events...)
+}
+
+// Events 目前暫存的事件
+func (b *Buffer) Events() []model.DomainEvent {
+	return b.events
+}
+
+// FlushTo 把暫存事件交給publisher並清空；publisher為nil時只清空
+func (b *Buffer) FlushTo(publisher Publisher) {
+	events := b.events
+	b.events = nil
+	if publisher != nil && len(events) > 0 {
+		publisher.Publish(events)
+	}
+}
+
+// 確保Buffer實現Publisher介面
+var _ Publisher = (*Buffer)(nil)
@@ -0,0 +1,91 @@
+package event
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// Publisher 發布已持久化聚合的領域事件
+// Repository在儲存成功後呼叫，事件發布失敗不影響已完成的儲存
+type Publisher interface {
+	Publish(events []model.DomainEvent)
+}
+
+// Handler 領域事件訂閱者
+type Handler interface {
+	Handle(event model.DomainEvent) error
+}
+
+// HandlerFunc 讓一般函式可以作為Handler
+type HandlerFunc func(event model.DomainEvent) error
+
+func (f HandlerFunc) Handle(event model.DomainEvent) error {
+	return f(event)
+}
+
+// Dispatcher 行程內的同步事件分派器
+// 依事件名稱把事件交給訂閱者；單一訂閱者失敗或panic不影響其他訂閱者
+type Dispatcher struct {
+	mu          sync.RWMutex
+	handlers    map[string][]Handler
+	allHandlers []Handler
+
+	// OnError 訂閱者失敗時呼叫，預設寫入log
+	OnError func(event model.DomainEvent, err error)
+}
+
+// NewDispatcher 創建事件分派器
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		handlers: make(map[string][]Handler),
+		OnError: func(event model.DomainEvent, err error) {
+			log.Printf("domain event handler failed for %s (%s): %v", event.EventName(), event.AggregateID(), err)
+		},
+	}
+}
+
+// Subscribe 訂閱指定名稱的事件 (例如 model.EventExpenseAdded)
+func (d *Dispatcher) Subscribe(eventName string, handler Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[eventName] = append(d.handlers[eventName], handler)
+}
+
+// SubscribeAll 訂閱所有事件
+func (d *Dispatcher) SubscribeAll(handler Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.allHandlers = append(d.allHandlers, handler)
+}
+
+// Publish 依記錄順序把事件交給訂閱者
+func (d *Dispatcher) Publish(events []model.DomainEvent) {
+	for _, event := range events {
+		d.mu.RLock()
+		handlers := make([]Handler, 0, len(d.handlers[event.EventName()])+len(d.allHandlers))
+		handlers = append(handlers, d.handlers[event.EventName()]...)
+		handlers = append(handlers, d.allHandlers...)
+		d.mu.RUnlock()
+
+		for _, handler := range handlers {
+			if err := d.handle(handler, event); err != nil && d.OnError != nil {
+				d.OnError(event, err)
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) handle(handler Handler, event model.DomainEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+	return handler.Handle(event)
+}
+
+// 確保Dispatcher實現Publisher介面
+var _ Publisher = (*Dispatcher)(nil)
@@ -0,0 +1,100 @@
+package exchange
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// RateNotFoundError 指定日期 (含) 之前沒有兩種貨幣之間的匯率
+type RateNotFoundError struct {
+	From string
+	To   string
+	Date time.Time
+}
+
+func (e *RateNotFoundError) Error() string {
+	return fmt.Sprintf("no exchange rate from %s to %s on or before %s", e.From, e.To, e.Date.Format("2006-01-02"))
+}
+
+// Conversion 換算結果；Rate為實際使用的匯率 (方向為原幣別到目標幣別)，相同幣別時為nil
+type Conversion struct {
+	Original  model.Money
+	Converted model.Money
+	Rate      *model.ExchangeRate
+}
+
+// Converter 依儲存的每日匯率換算金額
+// 使用指定日期 (含) 之前最近的匯率；只有反向匯率 (例如只存USD/TWD卻要換TWD到USD) 時取其倒數，
+// 換算後依設定的捨入方式只捨入一次
+type Converter struct {
+	rateRepo repository.ExchangeRateRepository
+	rounding model.RoundingMode
+}
+
+func NewConverter(rateRepo repository.ExchangeRateRepository, rounding model.RoundingMode) *Converter {
+	if rounding == "" {
+		rounding = model.RoundHalfUp
+	}
+	return &Converter{
+		rateRepo: rateRepo,
+		rounding: rounding,
+	}
+}
+
+// Rounding 換算使用的捨入方式
+func (c *Converter) Rounding() model.RoundingMode {
+	return c.rounding
+}
+
+// Rate 查詢from到to在指定日期 (含) 之前最近的匯率，直接與反向匯率同一天時優先使用直接匯率
+func (c *Converter) Rate(from, to string, date time.Time) (*model.ExchangeRate, error) {
+	direct, err := c.rateRepo.FindLatest(from, to, date)
+	if err != nil {
+		return nil, err
+	}
+	inverse, err := c.rateRepo.FindLatest(to, from, date)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case direct != nil && (inverse == nil || !inverse.Date.After(direct.Date)):
+		return direct, nil
+	case inverse != nil:
+		return inverse.Inverse(), nil
+	default:
+		return nil, &RateNotFoundError{From: from, To: to, Date: date}
+	}
+}
+
+// Convert 將金額換算為目標幣別
+func (c *Converter) Convert(amount model.Money, to string, date time.Time) (Conversion, error) {
+	if amount.Currency == to {
+		return Conversion{Original: amount, Converted: amount}, nil
+	}
+
+	rate, err := c.Rate(amount.Currency, to, date)
+	if err != nil {
+		return Conversion{}, err
+	}
+	converted, err := rate.Convert(amount, c.rounding)
+	if err != nil {
+		return Conversion{}, err
+	}
+	return Conversion{Original: amount, Converted: converted, Rate: rate}, nil
+}
+
+// ToRateData 將匯率轉換為API回應的資料結構
+func ToRateData(rate *model.ExchangeRate) usecase.ExchangeRateData {
+	return usecase.ExchangeRateData{
+		Base:   rate.Base,
+		Quote:  rate.Quote,
+		Rate:   rate.RateString(),
+		Date:   rate.Date.Format("2006-01-02"),
+		Source: rate.Source,
+	}
+}
@@ -0,0 +1,56 @@
+package exchange
+
+import (
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// RateProvider 外部匯率來源 (例如央行或匯率API)，由RefreshExchangeRatesService定期或手動呼叫
+type RateProvider interface {
+	// Name 來源名稱，記錄於匯率的Source
+	Name() string
+
+	// FetchRates 取得指定日期的匯率
+	FetchRates(date time.Time) ([]*model.ExchangeRate, error)
+}
+
+// StubProvider 本機固定匯率，供開發與測試使用，不連線外部服務
+type StubProvider struct {
+	base  string
+	rates map[string]string // 1單位base可兌換的各幣別數量
+}
+
+func NewStubProvider() *StubProvider {
+	return &StubProvider{
+		base: "USD",
+		rates: map[string]string{
+			"TWD": "32.5",
+			"EUR": "0.92",
+			"GBP": "0.79",
+			"JPY": "150",
+			"CNY": "7.2",
+			"KRW": "1350",
+		},
+	}
+}
+
+func (p *StubProvider) Name() string {
+	return "stub"
+}
+
+// FetchRates 每個日期都回傳相同的匯率
+func (p *StubProvider) FetchRates(date time.Time) ([]*model.ExchangeRate, error) {
+	rates := make([]*model.ExchangeRate, 0, len(p.rates))
+	for quote, value := range p.rates {
+		rate, err := model.NewExchangeRate(p.base, quote, value, date, p.Name())
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+// 確保StubProvider實現RateProvider介面
+var _ RateProvider = (*StubProvider)(nil)
@@ -0,0 +1,94 @@
+package exchange
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// MaxFileSize 匯率CSV的大小上限
+const MaxFileSize = 1 << 20
+
+// 匯率CSV的必要欄位，標題不分大小寫、順序不限，其他欄位略過
+var csvColumns = []string{"date", "base", "quote", "rate"}
+
+// ParseCSV 解析匯率CSV，例如:
+//
+//	date,base,quote,rate
+//	2026-03-10,USD,TWD,32.45
+//
+// 任一資料列有誤時回傳包含行號的error，不回傳部分結果
+func ParseCSV(r io.Reader, source string) ([]*model.ExchangeRate, error) {
+	content, err := io.ReadAll(io.LimitReader(r, MaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > MaxFileSize {
+		return nil, fmt.Errorf("file exceeds %d bytes", MaxFileSize)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i // 略過試算表匯出的BOM
+	}
+	for _, column := range csvColumns {
+		if _, ok := index[column]; !ok {
+			return nil, fmt.Errorf("missing column %q (expected header: %s)", column, strings.Join(csvColumns, ","))
+		}
+	}
+
+	var rates []*model.ExchangeRate
+	seen := make(map[string]int)
+	for {
+		cells, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		cell := func(column string) string {
+			if i := index[column]; i < len(cells) {
+				return strings.TrimSpace(cells[i])
+			}
+			return ""
+		}
+
+		date, err := time.Parse("2006-01-02", cell("date"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q: use YYYY-MM-DD", line, cell("date"))
+		}
+		rate, err := model.NewExchangeRate(cell("base"), cell("quote"), cell("rate"), date, source)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if previous, ok := seen[rate.ID]; ok {
+			return nil, fmt.Errorf("line %d: duplicate %s/%s rate for %s (also on line %d)",
+				line, rate.Base, rate.Quote, cell("date"), previous)
+		}
+		seen[rate.ID] = line
+		rates = append(rates, rate)
+	}
+
+	if len(rates) == 0 {
+		return nil, errors.New("no exchange rates in file")
+	}
+	return rates, nil
+}
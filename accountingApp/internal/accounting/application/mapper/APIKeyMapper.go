@@ -0,0 +1,60 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// APIKeyData API金鑰的持久化資料結構
+type APIKeyData struct {
+	ID        string     `db:"id"`
+	UserID    string     `db:"user_id"`
+	Name      string     `db:"name"`
+	KeyHash   string     `db:"key_hash"`
+	CreatedAt time.Time  `db:"created_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+func (d APIKeyData) GetID() string {
+	return d.ID
+}
+
+// APIKeyMapper API金鑰聚合的資料轉換器
+type APIKeyMapper struct{}
+
+func NewAPIKeyMapper() *APIKeyMapper {
+	return &APIKeyMapper{}
+}
+
+// ToData 將APIKey Domain Model轉換為APIKeyData
+func (m *APIKeyMapper) ToData(key *model.APIKey) APIKeyData {
+	return APIKeyData{
+		ID:        key.ID,
+		UserID:    key.UserID,
+		Name:      key.Name,
+		KeyHash:   key.KeyHash,
+		CreatedAt: key.CreatedAt,
+		RevokedAt: key.RevokedAt,
+	}
+}
+
+// ToDomain 將APIKeyData轉換為APIKey Domain Model
+func (m *APIKeyMapper) ToDomain(data APIKeyData) (*model.APIKey, error) {
+	return &model.APIKey{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		Name:      data.Name,
+		KeyHash:   data.KeyHash,
+		CreatedAt: data.CreatedAt,
+		RevokedAt: data.RevokedAt,
+	}, nil
+}
+
+// 確保APIKeyData實現AggregateData介面
+var _ store.AggregateData = (*APIKeyData)(nil)
+
+// 確保APIKeyMapper實現Mapper介面
+var _ Mapper[*model.APIKey, APIKeyData] = (*APIKeyMapper)(nil)
+var _ store.AggregateMapper[*model.APIKey, APIKeyData] = (*APIKeyMapper)(nil)
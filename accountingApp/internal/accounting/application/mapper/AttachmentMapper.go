@@ -0,0 +1,80 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// AttachmentData 附件中繼資料的持久化資料結構
+type AttachmentData struct {
+	ID          string    `db:"id"`
+	UserID      string    `db:"user_id"`
+	WalletID    string    `db:"wallet_id"`
+	RecordType  string    `db:"record_type"`
+	RecordID    string    `db:"record_id"`
+	FileName    string    `db:"file_name"`
+	ContentType string    `db:"content_type"`
+	Size        int64     `db:"size"`
+	Checksum    string    `db:"checksum"`
+	StorageKey  string    `db:"storage_key"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (d AttachmentData) GetID() string {
+	return d.ID
+}
+
+// AttachmentMapper 附件聚合的資料轉換器
+type AttachmentMapper struct{}
+
+func NewAttachmentMapper() *AttachmentMapper {
+	return &AttachmentMapper{}
+}
+
+// ToData 將Attachment Domain Model轉換為AttachmentData
+func (m *AttachmentMapper) ToData(attachment *model.Attachment) AttachmentData {
+	return AttachmentData{
+		ID:          attachment.ID,
+		UserID:      attachment.UserID,
+		WalletID:    attachment.WalletID,
+		RecordType:  string(attachment.RecordType),
+		RecordID:    attachment.RecordID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Checksum:    attachment.Checksum,
+		StorageKey:  attachment.StorageKey,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+// ToDomain 將AttachmentData轉換為Attachment Domain Model
+func (m *AttachmentMapper) ToDomain(data AttachmentData) (*model.Attachment, error) {
+	recordType, err := model.ParseAttachmentRecordType(data.RecordType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Attachment{
+		ID:          data.ID,
+		UserID:      data.UserID,
+		WalletID:    data.WalletID,
+		RecordType:  recordType,
+		RecordID:    data.RecordID,
+		FileName:    data.FileName,
+		ContentType: data.ContentType,
+		Size:        data.Size,
+		Checksum:    data.Checksum,
+		StorageKey:  data.StorageKey,
+		CreatedAt:   data.CreatedAt,
+	}, nil
+}
+
+// 確保AttachmentData實現AggregateData介面
+var _ store.AggregateData = (*AttachmentData)(nil)
+
+// 確保AttachmentMapper實現Mapper介面
+var _ Mapper[*model.Attachment, AttachmentData] = (*AttachmentMapper)(nil)
+var _ store.AggregateMapper[*model.Attachment, AttachmentData] = (*AttachmentMapper)(nil)
@@ -0,0 +1,126 @@
+package mapper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"strconv"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+)
+
+// AuditGenesisHash 雜湊鏈第一筆紀錄的前一筆雜湊
+const AuditGenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// 稽核紀錄的聚合類型
+const (
+	AuditAggregateWallet             = "wallet"
+	AuditAggregateExpenseCategory    = "expense_category"
+	AuditAggregateIncomeCategory     = "income_category"
+	AuditAggregateUserCategories     = "user_categories" // 使用者的全部分類 (初始化預設分類)
+	AuditAggregateAPIKey             = "api_key"
+	AuditAggregateOutboxMessage      = "outbox_message"
+	AuditAggregateBudget             = "budget"
+	AuditAggregateRecurringRule      = "recurring_rule"
+	AuditAggregateAttachment         = "attachment"
+	AuditAggregateImportProfile      = "import_profile"
+	AuditAggregateCategorizationRule = "categorization_rule"
+	AuditAggregateExchangeRates      = "exchange_rates" // 匯率表 (匯入或更新的一批匯率)
+)
+
+// AuditEntryData 稽核紀錄的持久化資料結構 (只能新增)
+// Hash = SHA-256(PrevHash與其他欄位)，任何一筆被修改或刪除都會使之後的鏈驗證失敗
+type AuditEntryData struct {
+	Sequence      int64     `db:"sequence"` // 由儲存庫依寫入順序指定
+	ID            string    `db:"id"`
+	ActorID       string    `db:"actor_id"`
+	Command       string    `db:"command"`
+	AggregateType string    `db:"aggregate_type"`
+	AggregateID   string    `db:"aggregate_id"`
+	Before        []byte    `db:"before_snapshot"` // 執行前的聚合資料JSON，nil表示尚不存在
+	After         []byte    `db:"after_snapshot"`  // 執行後的聚合資料JSON，nil表示已刪除
+	RequestID     string    `db:"request_id"`
+	OccurredAt    time.Time `db:"occurred_at"`
+	PrevHash      string    `db:"prev_hash"`
+	Hash          string    `db:"hash"`
+}
+
+func (d AuditEntryData) GetID() string {
+	return d.ID
+}
+
+// ComputeHash 計算本筆紀錄的雜湊 (不含Hash欄位本身)
+// 每個欄位以「長度:內容」寫入，避免欄位邊界被移動而產生相同雜湊
+func (d AuditEntryData) ComputeHash() string {
+	h := sha256.New()
+	writeHashField(h, d.PrevHash)
+	writeHashField(h, strconv.FormatInt(d.Sequence, 10))
+	writeHashField(h, d.ID)
+	writeHashField(h, d.ActorID)
+	writeHashField(h, d.Command)
+	writeHashField(h, d.AggregateType)
+	writeHashField(h, d.AggregateID)
+	writeHashField(h, string(d.Before))
+	writeHashField(h, string(d.After))
+	writeHashField(h, d.RequestID)
+	writeHashField(h, d.OccurredAt.UTC().Format(time.RFC3339Nano))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ChainAuditEntry 將紀錄接在前一筆之後：指定序號、前一筆雜湊並計算本筆雜湊
+// previous為nil表示鏈上還沒有紀錄
+func ChainAuditEntry(entry AuditEntryData, previous *AuditEntryData) AuditEntryData {
+	entry.Sequence = 1
+	entry.PrevHash = AuditGenesisHash
+	if previous != nil {
+		entry.Sequence = previous.Sequence + 1
+		entry.PrevHash = previous.Hash
+	}
+	// 資料庫時間精度為微秒且不含時區，先正規化才能在讀回後重算出相同雜湊
+	entry.OccurredAt = entry.OccurredAt.UTC().Truncate(time.Microsecond)
+	entry.Hash = entry.ComputeHash()
+	return entry
+}
+
+// WalletAuditData 儲存或刪除錢包時，在同一個交易中寫入的稽核紀錄
+// 快照取自儲存中的聚合，只包含錢包欄位與此次變更的子實體，不重新載入聚合
+type WalletAuditData struct {
+	Entry  AuditEntryData // 紀錄欄位，快照由Complete填入
+	Before WalletData     // 修改或刪除前的子實體；錢包欄位由Peer在交易中取得後補上
+	After  *WalletData    // 儲存後的錢包欄位與新增或修改後的子實體，刪除錢包時為nil
+}
+
+// Complete 以交易中鎖定的儲存前錢包欄位 (錢包尚不存在時為nil) 補齊前快照，並序列化前後快照
+func (a WalletAuditData) Complete(previous *WalletData) (AuditEntryData, error) {
+	entry := a.Entry
+	if previous != nil {
+		before := *previous
+		before.IncomeRecords = a.Before.IncomeRecords
+		before.ExpenseRecords = a.Before.ExpenseRecords
+		before.Transfers = a.Before.Transfers
+
+		snapshot, err := json.Marshal(before)
+		if err != nil {
+			return entry, fmt.Errorf("failed to encode before snapshot: %w", err)
+		}
+		entry.Before = snapshot
+	}
+	if a.After != nil {
+		snapshot, err := json.Marshal(*a.After)
+		if err != nil {
+			return entry, fmt.Errorf("failed to encode after snapshot: %w", err)
+		}
+		entry.After = snapshot
+	}
+	return entry, nil
+}
+
+func writeHashField(h hash.Hash, value string) {
+	fmt.Fprintf(h, "%d:%s", len(value), value)
+}
+
+// 確保AuditEntryData實現AggregateData介面
+var _ store.AggregateData = (*AuditEntryData)(nil)
@@ -0,0 +1,107 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// BudgetData 預算的持久化資料結構
+// CategoryID 與 SubcategoryID 只有一個有值，另一個為NULL
+type BudgetData struct {
+	ID               string     `db:"id"`
+	UserID           string     `db:"user_id"`
+	Name             string     `db:"name"`
+	Period           string     `db:"period"`
+	CategoryID       *string    `db:"category_id"`
+	SubcategoryID    *string    `db:"subcategory_id"`
+	Amount           int64      `db:"amount"`
+	Currency         string     `db:"currency"`
+	Rollover         bool       `db:"rollover"`
+	WarningThreshold int        `db:"warning_threshold"`
+	StartDate        time.Time  `db:"start_date"`
+	EndDate          *time.Time `db:"end_date"`
+	CreatedAt        time.Time  `db:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at"`
+}
+
+func (d BudgetData) GetID() string {
+	return d.ID
+}
+
+// BudgetMapper 預算聚合的資料轉換器
+type BudgetMapper struct{}
+
+func NewBudgetMapper() *BudgetMapper {
+	return &BudgetMapper{}
+}
+
+// ToData 將Budget Domain Model轉換為BudgetData
+func (m *BudgetMapper) ToData(budget *model.Budget) BudgetData {
+	return BudgetData{
+		ID:               budget.ID,
+		UserID:           budget.UserID,
+		Name:             budget.Name,
+		Period:           string(budget.Period),
+		CategoryID:       nullableString(budget.Scope.CategoryID),
+		SubcategoryID:    nullableString(budget.Scope.SubcategoryID),
+		Amount:           budget.Amount.Amount,
+		Currency:         budget.Amount.Currency,
+		Rollover:         budget.Rollover,
+		WarningThreshold: budget.WarningThreshold,
+		StartDate:        budget.StartDate,
+		EndDate:          budget.EndDate,
+		CreatedAt:        budget.CreatedAt,
+		UpdatedAt:        budget.UpdatedAt,
+	}
+}
+
+// ToDomain 將BudgetData轉換為Budget Domain Model
+func (m *BudgetMapper) ToDomain(data BudgetData) (*model.Budget, error) {
+	period, err := model.ParseBudgetPeriod(data.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	budget := &model.Budget{
+		ID:     data.ID,
+		UserID: data.UserID,
+		Name:   data.Name,
+		Period: period,
+		Amount: model.Money{
+			Amount:   data.Amount,
+			Currency: data.Currency,
+		},
+		Rollover:         data.Rollover,
+		WarningThreshold: data.WarningThreshold,
+		StartDate:        data.StartDate.UTC(),
+		CreatedAt:        data.CreatedAt,
+		UpdatedAt:        data.UpdatedAt,
+	}
+	if data.CategoryID != nil {
+		budget.Scope.CategoryID = *data.CategoryID
+	}
+	if data.SubcategoryID != nil {
+		budget.Scope.SubcategoryID = *data.SubcategoryID
+	}
+	if data.EndDate != nil {
+		endDate := data.EndDate.UTC()
+		budget.EndDate = &endDate
+	}
+	return budget, nil
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// 確保BudgetData實現AggregateData介面
+var _ store.AggregateData = (*BudgetData)(nil)
+
+// 確保BudgetMapper實現Mapper介面
+var _ Mapper[*model.Budget, BudgetData] = (*BudgetMapper)(nil)
+var _ store.AggregateMapper[*model.Budget, BudgetData] = (*BudgetMapper)(nil)
@@ -0,0 +1,112 @@
+package mapper
+
+import (
+	"strings"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// CategorizationRuleData 分類規則的持久化資料結構
+// 星期以位元遮罩 (bit 0 為星期日) 儲存，標籤以逗號串接 (標籤不可包含逗號)
+type CategorizationRuleData struct {
+	ID                 string    `db:"id"`
+	UserID             string    `db:"user_id"`
+	Name               string    `db:"name"`
+	RuleType           string    `db:"rule_type"`
+	Priority           int       `db:"priority"`
+	DescriptionPattern string    `db:"description_pattern"`
+	MinAmount          *int64    `db:"min_amount"`
+	MaxAmount          *int64    `db:"max_amount"`
+	Currency           string    `db:"currency"`
+	WalletID           string    `db:"wallet_id"`
+	WeekdayMask        int       `db:"weekday_mask"`
+	SubcategoryID      string    `db:"subcategory_id"`
+	Tags               string    `db:"tags"`
+	CreatedAt          time.Time `db:"created_at"`
+	UpdatedAt          time.Time `db:"updated_at"`
+}
+
+func (d CategorizationRuleData) GetID() string {
+	return d.ID
+}
+
+// CategorizationRuleMapper 分類規則聚合的資料轉換器
+type CategorizationRuleMapper struct{}
+
+func NewCategorizationRuleMapper() *CategorizationRuleMapper {
+	return &CategorizationRuleMapper{}
+}
+
+// ToData 將CategorizationRule Domain Model轉換為CategorizationRuleData
+func (m *CategorizationRuleMapper) ToData(rule *model.CategorizationRule) CategorizationRuleData {
+	conditions := rule.Conditions
+	mask := 0
+	for _, day := range conditions.Weekdays {
+		mask |= 1 << uint(day)
+	}
+	return CategorizationRuleData{
+		ID:                 rule.ID,
+		UserID:             rule.UserID,
+		Name:               rule.Name,
+		RuleType:           string(rule.Type),
+		Priority:           rule.Priority,
+		DescriptionPattern: conditions.DescriptionPattern,
+		MinAmount:          conditions.MinAmount,
+		MaxAmount:          conditions.MaxAmount,
+		Currency:           conditions.Currency,
+		WalletID:           conditions.WalletID,
+		WeekdayMask:        mask,
+		SubcategoryID:      rule.SubcategoryID,
+		Tags:               strings.Join(rule.Tags, ","),
+		CreatedAt:          rule.CreatedAt,
+		UpdatedAt:          rule.UpdatedAt,
+	}
+}
+
+// ToDomain 將CategorizationRuleData轉換為CategorizationRule Domain Model
+func (m *CategorizationRuleMapper) ToDomain(data CategorizationRuleData) (*model.CategorizationRule, error) {
+	ruleType, err := model.ParseCategorizationRuleType(data.RuleType)
+	if err != nil {
+		return nil, err
+	}
+
+	var weekdays []time.Weekday
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if data.WeekdayMask&(1<<uint(day)) != 0 {
+			weekdays = append(weekdays, day)
+		}
+	}
+	var tags []string
+	if data.Tags != "" {
+		tags = strings.Split(data.Tags, ",")
+	}
+
+	return &model.CategorizationRule{
+		ID:       data.ID,
+		UserID:   data.UserID,
+		Name:     data.Name,
+		Type:     ruleType,
+		Priority: data.Priority,
+		Conditions: model.RuleConditions{
+			DescriptionPattern: data.DescriptionPattern,
+			MinAmount:          data.MinAmount,
+			MaxAmount:          data.MaxAmount,
+			Currency:           data.Currency,
+			WalletID:           data.WalletID,
+			Weekdays:           weekdays,
+		},
+		SubcategoryID: data.SubcategoryID,
+		Tags:          tags,
+		CreatedAt:     data.CreatedAt,
+		UpdatedAt:     data.UpdatedAt,
+	}, nil
+}
+
+// 確保CategorizationRuleData實現AggregateData介面
+var _ store.AggregateData = (*CategorizationRuleData)(nil)
+
+// 確保CategorizationRuleMapper實現Mapper介面
+var _ Mapper[*model.CategorizationRule, CategorizationRuleData] = (*CategorizationRuleMapper)(nil)
+var _ store.AggregateMapper[*model.CategorizationRule, CategorizationRuleData] = (*CategorizationRuleMapper)(nil)
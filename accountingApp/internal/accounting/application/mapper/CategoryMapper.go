@@ -13,6 +13,9 @@ type ExpenseCategoryData struct {
 	Name      string    `db:"name"`
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
+
+	// 子分類資料 (不映射到資料庫欄位，透過 expense_subcategories 表處理)
+	Subcategories []ExpenseSubcategoryData `db:"-"`
 }
 
 func (ecd ExpenseCategoryData) GetID() string {
@@ -33,6 +36,9 @@ type IncomeCategoryData struct {
 	Name      string    `db:"name"`
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
+
+	// 子分類資料 (不映射到資料庫欄位，透過 income_subcategories 表處理)
+	Subcategories []IncomeSubcategoryData `db:"-"`
 }
 
 func (icd IncomeCategoryData) GetID() string {
@@ -55,12 +61,18 @@ func NewExpenseCategoryMapper() *ExpenseCategoryMapper {
 
 // ToData 將ExpenseCategory Domain Model轉換為ExpenseCategoryData
 func (m *ExpenseCategoryMapper) ToData(category *model.ExpenseCategory) ExpenseCategoryData {
+	subcategories := make([]ExpenseSubcategoryData, len(category.Subcategories))
+	for i, subcategory := range category.Subcategories {
+		subcategories[i] = m.ToSubcategoryData(subcategory, category.ID)
+	}
+
 	return ExpenseCategoryData{
-		ID:        category.ID,
-		UserID:    category.UserID,
-		Name:      category.Name.Value,
-		CreatedAt: category.CreatedAt,
-		UpdatedAt: category.UpdatedAt,
+		ID:            category.ID,
+		UserID:        category.UserID,
+		Name:          category.Name.Value,
+		CreatedAt:     category.CreatedAt,
+		UpdatedAt:     category.UpdatedAt,
+		Subcategories: subcategories,
 	}
 }
 
@@ -71,11 +83,20 @@ func (m *ExpenseCategoryMapper) ToDomain(data ExpenseCategoryData) (*model.Expen
 		return nil, err
 	}
 	
+	subcategories := make([]model.ExpenseSubcategory, 0, len(data.Subcategories))
+	for _, subcategoryData := range data.Subcategories {
+		subcategory, err := m.ToSubcategoryDomain(subcategoryData)
+		if err != nil {
+			return nil, err
+		}
+		subcategories = append(subcategories, *subcategory)
+	}
+
 	return &model.ExpenseCategory{
 		ID:            data.ID,
 		UserID:        data.UserID,
 		Name:          *categoryName,
-		Subcategories: make([]model.ExpenseSubcategory, 0), // 子分類需要另外查詢
+		Subcategories: subcategories,
 		CreatedAt:     data.CreatedAt,
 		UpdatedAt:     data.UpdatedAt,
 	}, nil
@@ -114,12 +135,18 @@ func NewIncomeCategoryMapper() *IncomeCategoryMapper {
 
 // ToData 將IncomeCategory Domain Model轉換為IncomeCategoryData
 func (m *IncomeCategoryMapper) ToData(category *model.IncomeCategory) IncomeCategoryData {
+	subcategories := make([]IncomeSubcategoryData, len(category.Subcategories))
+	for i, subcategory := range category.Subcategories {
+		subcategories[i] = m.ToSubcategoryData(subcategory, category.ID)
+	}
+
 	return IncomeCategoryData{
-		ID:        category.ID,
-		UserID:    category.UserID,
-		Name:      category.Name.Value,
-		CreatedAt: category.CreatedAt,
-		UpdatedAt: category.UpdatedAt,
+		ID:            category.ID,
+		UserID:        category.UserID,
+		Name:          category.Name.Value,
+		CreatedAt:     category.CreatedAt,
+		UpdatedAt:     category.UpdatedAt,
+		Subcategories: subcategories,
 	}
 }
 
@@ -130,11 +157,20 @@ func (m *IncomeCategoryMapper) ToDomain(data IncomeCategoryData) (*model.IncomeC
 		return nil, err
 	}
 	
+	subcategories := make([]model.IncomeSubcategory, 0, len(data.Subcategories))
+	for _, subcategoryData := range data.Subcategories {
+		subcategory, err := m.ToSubcategoryDomain(subcategoryData)
+		if err != nil {
+			return nil, err
+		}
+		subcategories = append(subcategories, *subcategory)
+	}
+
 	return &model.IncomeCategory{
 		ID:            data.ID,
 		UserID:        data.UserID,
 		Name:          *categoryName,
-		Subcategories: make([]model.IncomeSubcategory, 0), // 子分類需要另外查詢
+		Subcategories: subcategories,
 		CreatedAt:     data.CreatedAt,
 		UpdatedAt:     data.UpdatedAt,
 	}, nil
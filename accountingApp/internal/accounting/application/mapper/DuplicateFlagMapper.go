@@ -0,0 +1,81 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// DuplicateFlagData 重複標記的持久化資料結構
+type DuplicateFlagData struct {
+	ID            string     `db:"id"`
+	UserID        string     `db:"user_id"`
+	WalletID      string     `db:"wallet_id"`
+	RecordType    string     `db:"record_type"`
+	RecordID      string     `db:"record_id"`
+	DuplicateOfID string     `db:"duplicate_of_id"`
+	Score         float64    `db:"score"`
+	Status        string     `db:"status"`
+	CreatedAt     time.Time  `db:"created_at"`
+	ResolvedAt    *time.Time `db:"resolved_at"`
+}
+
+func (d DuplicateFlagData) GetID() string {
+	return d.ID
+}
+
+// DuplicateFlagMapper 重複標記聚合的資料轉換器
+type DuplicateFlagMapper struct{}
+
+func NewDuplicateFlagMapper() *DuplicateFlagMapper {
+	return &DuplicateFlagMapper{}
+}
+
+// ToData 將DuplicateFlag Domain Model轉換為DuplicateFlagData
+func (m *DuplicateFlagMapper) ToData(flag *model.DuplicateFlag) DuplicateFlagData {
+	return DuplicateFlagData{
+		ID:            flag.ID,
+		UserID:        flag.UserID,
+		WalletID:      flag.WalletID,
+		RecordType:    string(flag.RecordType),
+		RecordID:      flag.RecordID,
+		DuplicateOfID: flag.DuplicateOfID,
+		Score:         flag.Score,
+		Status:        string(flag.Status),
+		CreatedAt:     flag.CreatedAt,
+		ResolvedAt:    flag.ResolvedAt,
+	}
+}
+
+// ToDomain 將DuplicateFlagData轉換為DuplicateFlag Domain Model
+func (m *DuplicateFlagMapper) ToDomain(data DuplicateFlagData) (*model.DuplicateFlag, error) {
+	recordType, err := model.ParseDuplicateRecordType(data.RecordType)
+	if err != nil {
+		return nil, err
+	}
+	status, err := model.ParseDuplicateStatus(data.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.DuplicateFlag{
+		ID:            data.ID,
+		UserID:        data.UserID,
+		WalletID:      data.WalletID,
+		RecordType:    recordType,
+		RecordID:      data.RecordID,
+		DuplicateOfID: data.DuplicateOfID,
+		Score:         data.Score,
+		Status:        status,
+		CreatedAt:     data.CreatedAt,
+		ResolvedAt:    data.ResolvedAt,
+	}, nil
+}
+
+// 確保DuplicateFlagData實現AggregateData介面
+var _ store.AggregateData = (*DuplicateFlagData)(nil)
+
+// 確保DuplicateFlagMapper實現Mapper介面
+var _ Mapper[*model.DuplicateFlag, DuplicateFlagData] = (*DuplicateFlagMapper)(nil)
+var _ store.AggregateMapper[*model.DuplicateFlag, DuplicateFlagData] = (*DuplicateFlagMapper)(nil)
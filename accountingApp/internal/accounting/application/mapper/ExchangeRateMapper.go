@@ -0,0 +1,61 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// ExchangeRateData 匯率的持久化資料結構，(base, quote, rate_date) 唯一
+type ExchangeRateData struct {
+	ID        string    `db:"id"`
+	Base      string    `db:"base_currency"`
+	Quote     string    `db:"quote_currency"`
+	Rate      string    `db:"rate"` // 十進位字串，避免浮點誤差
+	Date      time.Time `db:"rate_date"`
+	Source    string    `db:"source"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (d ExchangeRateData) GetID() string {
+	return d.ID
+}
+
+// ExchangeRateMapper 匯率的資料轉換器
+type ExchangeRateMapper struct{}
+
+func NewExchangeRateMapper() *ExchangeRateMapper {
+	return &ExchangeRateMapper{}
+}
+
+// ToData 將ExchangeRate Domain Model轉換為ExchangeRateData
+func (m *ExchangeRateMapper) ToData(rate *model.ExchangeRate) ExchangeRateData {
+	return ExchangeRateData{
+		ID:        rate.ID,
+		Base:      rate.Base,
+		Quote:     rate.Quote,
+		Rate:      rate.RateString(),
+		Date:      rate.Date,
+		Source:    rate.Source,
+		UpdatedAt: rate.UpdatedAt,
+	}
+}
+
+// ToDomain 將ExchangeRateData轉換為ExchangeRate Domain Model，重新驗證幣別與匯率
+func (m *ExchangeRateMapper) ToDomain(data ExchangeRateData) (*model.ExchangeRate, error) {
+	rate, err := model.NewExchangeRate(data.Base, data.Quote, data.Rate, data.Date, data.Source)
+	if err != nil {
+		return nil, err
+	}
+	rate.ID = data.ID
+	rate.UpdatedAt = data.UpdatedAt
+	return rate, nil
+}
+
+// 確保ExchangeRateData實現AggregateData介面
+var _ store.AggregateData = (*ExchangeRateData)(nil)
+
+// 確保ExchangeRateMapper實現Mapper介面
+var _ Mapper[*model.ExchangeRate, ExchangeRateData] = (*ExchangeRateMapper)(nil)
+var _ store.AggregateMapper[*model.ExchangeRate, ExchangeRateData] = (*ExchangeRateMapper)(nil)
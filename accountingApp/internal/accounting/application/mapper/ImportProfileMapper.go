@@ -0,0 +1,105 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// ImportProfileData 帳單匯入設定的持久化資料結構
+// 未使用的欄位對應存為空字串
+type ImportProfileData struct {
+	ID                   string    `db:"id"`
+	UserID               string    `db:"user_id"`
+	Name                 string    `db:"name"`
+	Delimiter            string    `db:"delimiter"`
+	Encoding             string    `db:"encoding"`
+	HasHeader            bool      `db:"has_header"`
+	SkipRows             int       `db:"skip_rows"`
+	DateColumn           string    `db:"date_column"`
+	DateFormat           string    `db:"date_format"`
+	DescriptionColumn    string    `db:"description_column"`
+	AmountColumn         string    `db:"amount_column"`
+	SignConvention       string    `db:"sign_convention"`
+	DebitColumn          string    `db:"debit_column"`
+	CreditColumn         string    `db:"credit_column"`
+	ExpenseSubcategoryID string    `db:"expense_subcategory_id"`
+	IncomeSubcategoryID  string    `db:"income_subcategory_id"`
+	CreatedAt            time.Time `db:"created_at"`
+	UpdatedAt            time.Time `db:"updated_at"`
+}
+
+func (d ImportProfileData) GetID() string {
+	return d.ID
+}
+
+// ImportProfileMapper 匯入設定聚合的資料轉換器
+type ImportProfileMapper struct{}
+
+func NewImportProfileMapper() *ImportProfileMapper {
+	return &ImportProfileMapper{}
+}
+
+// ToData 將ImportProfile Domain Model轉換為ImportProfileData
+func (m *ImportProfileMapper) ToData(profile *model.ImportProfile) ImportProfileData {
+	mapping := profile.Mapping
+	return ImportProfileData{
+		ID:                   profile.ID,
+		UserID:               profile.UserID,
+		Name:                 profile.Name,
+		Delimiter:            mapping.Delimiter,
+		Encoding:             string(mapping.Encoding),
+		HasHeader:            mapping.HasHeader,
+		SkipRows:             mapping.SkipRows,
+		DateColumn:           mapping.DateColumn,
+		DateFormat:           mapping.DateFormat,
+		DescriptionColumn:    mapping.DescriptionColumn,
+		AmountColumn:         mapping.AmountColumn,
+		SignConvention:       string(mapping.SignConvention),
+		DebitColumn:          mapping.DebitColumn,
+		CreditColumn:         mapping.CreditColumn,
+		ExpenseSubcategoryID: mapping.ExpenseSubcategoryID,
+		IncomeSubcategoryID:  mapping.IncomeSubcategoryID,
+		CreatedAt:            profile.CreatedAt,
+		UpdatedAt:            profile.UpdatedAt,
+	}
+}
+
+// ToDomain 將ImportProfileData轉換為ImportProfile Domain Model
+func (m *ImportProfileMapper) ToDomain(data ImportProfileData) (*model.ImportProfile, error) {
+	encoding, err := model.ParseImportEncoding(data.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ImportProfile{
+		ID:     data.ID,
+		UserID: data.UserID,
+		Name:   data.Name,
+		Mapping: model.ImportMapping{
+			Delimiter:            data.Delimiter,
+			Encoding:             encoding,
+			HasHeader:            data.HasHeader,
+			SkipRows:             data.SkipRows,
+			DateColumn:           data.DateColumn,
+			DateFormat:           data.DateFormat,
+			DescriptionColumn:    data.DescriptionColumn,
+			AmountColumn:         data.AmountColumn,
+			SignConvention:       model.AmountSignConvention(data.SignConvention),
+			DebitColumn:          data.DebitColumn,
+			CreditColumn:         data.CreditColumn,
+			ExpenseSubcategoryID: data.ExpenseSubcategoryID,
+			IncomeSubcategoryID:  data.IncomeSubcategoryID,
+		},
+		CreatedAt: data.CreatedAt,
+		UpdatedAt: data.UpdatedAt,
+	}, nil
+}
+
+// 確保ImportProfileData實現AggregateData介面
+var _ store.AggregateData = (*ImportProfileData)(nil)
+
+// 確保ImportProfileMapper實現Mapper介面
+var _ Mapper[*model.ImportProfile, ImportProfileData] = (*ImportProfileMapper)(nil)
+var _ store.AggregateMapper[*model.ImportProfile, ImportProfileData] = (*ImportProfileMapper)(nil)
@@ -0,0 +1,74 @@
+package mapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// Outbox訊息狀態
+const (
+	OutboxStatusPending    = "pending"     // 等待投遞或等待重試
+	OutboxStatusDelivered  = "delivered"   // 所有處理器皆已成功
+	OutboxStatusDeadLetter = "dead_letter" // 超過最大嘗試次數，需人工處理
+)
+
+// 產生Outbox訊息的聚合類型
+const AggregateTypeWallet = "wallet"
+
+// OutboxMessageData Outbox訊息的持久化資料結構
+// 與產生事件的聚合在同一個交易中寫入，由背景Relay投遞
+type OutboxMessageData struct {
+	ID            string     `db:"id"`
+	AggregateType string     `db:"aggregate_type"`
+	AggregateID   string     `db:"aggregate_id"`
+	EventType     string     `db:"event_type"`
+	Payload       []byte     `db:"payload"` // 領域事件的JSON
+	Status        string     `db:"status"`
+	Attempts      int        `db:"attempts"`
+	LastError     string     `db:"last_error"`
+	OccurredAt    time.Time  `db:"occurred_at"`
+	NextAttemptAt time.Time  `db:"next_attempt_at"`
+	DeliveredAt   *time.Time `db:"delivered_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+func (d OutboxMessageData) GetID() string {
+	return d.ID
+}
+
+// ToOutboxMessages 將聚合的領域事件序列化為待投遞的Outbox訊息
+func ToOutboxMessages(aggregateType string, events []model.DomainEvent) ([]OutboxMessageData, error) {
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	messages := make([]OutboxMessageData, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize event %s: %w", event.EventName(), err)
+		}
+		messages = append(messages, OutboxMessageData{
+			ID:            uuid.NewString(),
+			AggregateType: aggregateType,
+			AggregateID:   event.AggregateID(),
+			EventType:     event.EventName(),
+			Payload:       payload,
+			Status:        OutboxStatusPending,
+			OccurredAt:    event.OccurredAt(),
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+	return messages, nil
+}
+
+// 確保OutboxMessageData實現AggregateData介面
+var _ store.AggregateData = (*OutboxMessageData)(nil)
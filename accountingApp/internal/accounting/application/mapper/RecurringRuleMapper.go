@@ -0,0 +1,158 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// RecurringRuleData 週期規則的持久化資料結構
+type RecurringRuleData struct {
+	ID              string                    `db:"id"`
+	UserID          string                    `db:"user_id"`
+	Name            string                    `db:"name"`
+	Type            string                    `db:"type"`
+	WalletID        string                    `db:"wallet_id"`
+	SubcategoryID   string                    `db:"subcategory_id"`
+	Amount          int64                     `db:"amount"`
+	Currency        string                    `db:"currency"`
+	Description     string                    `db:"description"`
+	Frequency       string                    `db:"frequency"`
+	Interval        int                       `db:"interval_count"`
+	DayOfMonth      int                       `db:"day_of_month"`
+	LastBusinessDay bool                      `db:"last_business_day"`
+	StartDate       time.Time                 `db:"start_date"`
+	EndDate         *time.Time                `db:"end_date"`
+	Count           int                       `db:"count_limit"`
+	Status          string                    `db:"status"`
+	PausedAt        *time.Time                `db:"paused_at"`
+	CreatedAt       time.Time                 `db:"created_at"`
+	UpdatedAt       time.Time                 `db:"updated_at"`
+	Version         int64                     `db:"version"` // 樂觀鎖版本號
+	Occurrences     []RecurringOccurrenceData `db:"-"`       // 子實體，存於 recurring_occurrences
+}
+
+func (d RecurringRuleData) GetID() string {
+	return d.ID
+}
+
+// RecurringOccurrenceData 發生日記錄的持久化資料結構，(rule_id, occurrence_date) 唯一
+type RecurringOccurrenceData struct {
+	RuleID        string    `db:"rule_id"`
+	Date          time.Time `db:"occurrence_date"`
+	Status        string    `db:"status"`
+	TransactionID string    `db:"transaction_id"`
+	Attempts      int       `db:"attempts"`
+	LastError     string    `db:"last_error"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+// RecurringRuleMapper 週期規則聚合的資料轉換器
+type RecurringRuleMapper struct{}
+
+func NewRecurringRuleMapper() *RecurringRuleMapper {
+	return &RecurringRuleMapper{}
+}
+
+// ToData 將RecurringRule Domain Model轉換為RecurringRuleData
+func (m *RecurringRuleMapper) ToData(rule *model.RecurringRule) RecurringRuleData {
+	data := RecurringRuleData{
+		ID:              rule.ID,
+		UserID:          rule.UserID,
+		Name:            rule.Name,
+		Type:            string(rule.Type),
+		WalletID:        rule.WalletID,
+		SubcategoryID:   rule.SubcategoryID,
+		Amount:          rule.Amount.Amount,
+		Currency:        rule.Amount.Currency,
+		Description:     rule.Description,
+		Frequency:       string(rule.Schedule.Frequency),
+		Interval:        rule.Schedule.Interval,
+		DayOfMonth:      rule.Schedule.DayOfMonth,
+		LastBusinessDay: rule.Schedule.LastBusinessDay,
+		StartDate:       rule.Schedule.StartDate,
+		EndDate:         rule.Schedule.EndDate,
+		Count:           rule.Schedule.Count,
+		Status:          string(rule.Status),
+		PausedAt:        rule.PausedAt,
+		CreatedAt:       rule.CreatedAt,
+		UpdatedAt:       rule.UpdatedAt,
+		Version:         rule.Version,
+		Occurrences:     make([]RecurringOccurrenceData, len(rule.Occurrences)),
+	}
+	for i, occurrence := range rule.Occurrences {
+		data.Occurrences[i] = RecurringOccurrenceData{
+			RuleID:        rule.ID,
+			Date:          occurrence.Date,
+			Status:        string(occurrence.Status),
+			TransactionID: occurrence.TransactionID,
+			Attempts:      occurrence.Attempts,
+			LastError:     occurrence.LastError,
+			UpdatedAt:     occurrence.UpdatedAt,
+		}
+	}
+	return data
+}
+
+// ToDomain 將RecurringRuleData轉換為RecurringRule Domain Model
+func (m *RecurringRuleMapper) ToDomain(data RecurringRuleData) (*model.RecurringRule, error) {
+	txType, err := model.ParseRecurringTransactionType(data.Type)
+	if err != nil {
+		return nil, err
+	}
+	frequency, err := model.ParseRecurrenceFrequency(data.Frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &model.RecurringRule{
+		ID:            data.ID,
+		UserID:        data.UserID,
+		Name:          data.Name,
+		Type:          txType,
+		WalletID:      data.WalletID,
+		SubcategoryID: data.SubcategoryID,
+		Amount: model.Money{
+			Amount:   data.Amount,
+			Currency: data.Currency,
+		},
+		Description: data.Description,
+		Schedule: model.RecurrenceSchedule{
+			Frequency:       frequency,
+			Interval:        data.Interval,
+			DayOfMonth:      data.DayOfMonth,
+			LastBusinessDay: data.LastBusinessDay,
+			StartDate:       data.StartDate.UTC(),
+			Count:           data.Count,
+		},
+		Status:      model.RecurringRuleStatus(data.Status),
+		PausedAt:    data.PausedAt,
+		CreatedAt:   data.CreatedAt,
+		UpdatedAt:   data.UpdatedAt,
+		Version:     data.Version,
+		Occurrences: make([]model.RecurringOccurrence, len(data.Occurrences)),
+	}
+	if data.EndDate != nil {
+		endDate := data.EndDate.UTC()
+		rule.Schedule.EndDate = &endDate
+	}
+	for i, occurrence := range data.Occurrences {
+		rule.Occurrences[i] = model.RecurringOccurrence{
+			Date:          occurrence.Date.UTC(),
+			Status:        model.OccurrenceStatus(occurrence.Status),
+			TransactionID: occurrence.TransactionID,
+			Attempts:      occurrence.Attempts,
+			LastError:     occurrence.LastError,
+			UpdatedAt:     occurrence.UpdatedAt,
+		}
+	}
+	return rule, nil
+}
+
+// 確保RecurringRuleData實現AggregateData介面
+var _ store.AggregateData = (*RecurringRuleData)(nil)
+
+// 確保RecurringRuleMapper實現Mapper介面
+var _ Mapper[*model.RecurringRule, RecurringRuleData] = (*RecurringRuleMapper)(nil)
+var _ store.AggregateMapper[*model.RecurringRule, RecurringRuleData] = (*RecurringRuleMapper)(nil)
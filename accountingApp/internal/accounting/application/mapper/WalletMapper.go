@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"sort"
 	"time"
 	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
 	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
@@ -17,12 +18,50 @@ type WalletData struct {
 	BalanceCurrency string    `db:"balance_currency"`
 	CreatedAt       time.Time `db:"created_at"`
 	UpdatedAt       time.Time `db:"updated_at"`
+	Version         int64     `db:"version"` // 樂觀鎖版本號
 	
 	// 子實體資料 (不映射到資料庫欄位，透過關聯表處理)
-	IncomeRecords  []IncomeRecordData  `db:"-"`
-	ExpenseRecords []ExpenseRecordData `db:"-"`
-	Transfers      []TransferData      `db:"-"`
-	IsFullyLoaded  bool                `db:"-"`
+	IncomeRecords  []IncomeRecordData  `db:"-" json:",omitempty"`
+	ExpenseRecords []ExpenseRecordData `db:"-" json:",omitempty"`
+	Transfers      []TransferData      `db:"-" json:",omitempty"`
+	IsFullyLoaded  bool                `db:"-" json:"-"`
+
+	// 子實體變更 (Dirty Tracking)，Peer據此只持久化差異
+	// 與outbox訊息同為儲存過程的暫存資料，不屬於稽核快照
+	IncomeRecordChanges  ChildEntityChanges `db:"-" json:"-"`
+	ExpenseRecordChanges ChildEntityChanges `db:"-" json:"-"`
+	TransferChanges      ChildEntityChanges `db:"-" json:"-"`
+
+	// 待寫入outbox的訊息，Peer在儲存錢包的同一個交易中寫入
+	OutboxMessages []OutboxMessageData `db:"-" json:"-"`
+
+	// 待寫入的稽核紀錄 (透過WithAudit的Repository儲存時)，Peer在同一個交易中寫入
+	Audit *WalletAuditData `db:"-" json:"-"`
+}
+
+// ChildEntityChanges 子實體自上次持久化後的變更ID
+// Added/Modified的資料可在對應的子實體列表中以ID找到
+type ChildEntityChanges struct {
+	Added    []string
+	Modified []string
+	Removed  []string
+}
+
+// IsEmpty 是否沒有任何變更
+func (c ChildEntityChanges) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Modified) == 0 && len(c.Removed) == 0
+}
+
+// Upserted 需要寫入 (新增或修改) 的ID集合
+func (c ChildEntityChanges) Upserted() map[string]bool {
+	ids := make(map[string]bool, len(c.Added)+len(c.Modified))
+	for _, id := range c.Added {
+		ids[id] = true
+	}
+	for _, id := range c.Modified {
+		ids[id] = true
+	}
+	return ids
 }
 
 // IncomeRecordData Income Record的持久化資料結構  
@@ -35,6 +74,11 @@ type IncomeRecordData struct {
 	Description   string    `db:"description"`
 	Date          time.Time `db:"date"`
 	CreatedAt     time.Time `db:"created_at"`
+	ImportID      string    `db:"import_id"`      // 帳單匯入的識別，手動新增時為空字串
+	OccurrenceKey string    `db:"occurrence_key"` // 週期規則發生日的識別，其他記錄為空字串
+
+	// 標籤 (存於 income_record_tags)
+	Tags []string `db:"-" json:",omitempty"`
 }
 
 // ExpenseRecordData Expense Record的持久化資料結構
@@ -47,6 +91,32 @@ type ExpenseRecordData struct {
 	Description   string    `db:"description"`
 	Date          time.Time `db:"date"`
 	CreatedAt     time.Time `db:"created_at"`
+	ImportID      string    `db:"import_id"`      // 帳單匯入的識別，手動新增時為空字串
+	OccurrenceKey string    `db:"occurrence_key"` // 週期規則發生日的識別，其他記錄為空字串
+
+	// 拆帳明細 (存於 expense_splits)，未拆帳時為空
+	Splits []ExpenseSplitData `db:"-" json:",omitempty"`
+
+	// 標籤 (存於 expense_record_tags)
+	Tags []string `db:"-" json:",omitempty"`
+}
+
+// ExpenseSplitData 拆帳明細的持久化資料結構，LineNo 保留明細順序
+type ExpenseSplitData struct {
+	ExpenseID     string `db:"expense_id"`
+	LineNo        int    `db:"line_no"`
+	SubcategoryID string `db:"category_id"`
+	Amount        int64  `db:"amount"`
+	Currency      string `db:"currency"`
+	Memo          string `db:"memo"`
+}
+
+// ExpenseTotalData 用戶某個子分類在某天以某幣別支出的加總，拆帳支出依明細計入
+type ExpenseTotalData struct {
+	SubcategoryID string    `db:"category_id"`
+	Currency      string    `db:"currency"`
+	Date          time.Time `db:"date"` // 當天00:00 UTC
+	Amount        int64     `db:"amount"`
 }
 
 // TransferData Transfer的持久化資料結構
@@ -56,10 +126,16 @@ type TransferData struct {
 	ToWalletID      string    `db:"to_wallet_id"`
 	Amount          int64     `db:"amount"`
 	Currency        string    `db:"currency"`
+	ToAmount        int64     `db:"to_amount"`   // 目標錢包幣別的金額
+	ToCurrency      string    `db:"to_currency"` // 目標錢包的幣別
 	Fee             int64     `db:"fee"`
+	FeeCurrency     string    `db:"fee_currency"` // 來源或目標錢包的幣別
 	Description     string    `db:"description"`
 	Date            time.Time `db:"date"`
 	CreatedAt       time.Time `db:"created_at"`
+
+	// 標籤 (存於 transfer_tags)
+	Tags []string `db:"-" json:",omitempty"`
 }
 
 func (wd WalletData) GetID() string {
@@ -98,61 +174,266 @@ func (m *WalletMapper) ToData(wallet *model.Wallet) WalletData {
 		BalanceCurrency: wallet.Balance.Currency,
 		CreatedAt:       wallet.CreatedAt,
 		UpdatedAt:       wallet.UpdatedAt,
+		Version:         wallet.Version,
 		IsFullyLoaded:   wallet.IsFullyLoaded(),
+
+		IncomeRecordChanges:  toChildEntityChanges(wallet.IncomeRecordChanges()),
+		ExpenseRecordChanges: toChildEntityChanges(wallet.ExpenseRecordChanges()),
+		TransferChanges:      toChildEntityChanges(wallet.TransferChanges()),
 	}
 
 	// 映射 IncomeRecords
 	incomeRecords := wallet.GetIncomeRecords()
 	walletData.IncomeRecords = make([]IncomeRecordData, len(incomeRecords))
 	for i, income := range incomeRecords {
-		walletData.IncomeRecords[i] = IncomeRecordData{
-			ID:            income.ID,
-			WalletID:      income.WalletID,
-			SubcategoryID: income.SubcategoryID,
-			Amount:        income.Amount.Amount,
-			Currency:      income.Amount.Currency,
-			Description:   income.Description,
-			Date:          income.Date,
-			CreatedAt:     income.CreatedAt,
-		}
+		walletData.IncomeRecords[i] = toIncomeRecordData(income)
 	}
 
 	// 映射 ExpenseRecords
 	expenseRecords := wallet.GetExpenseRecords()
 	walletData.ExpenseRecords = make([]ExpenseRecordData, len(expenseRecords))
 	for i, expense := range expenseRecords {
-		walletData.ExpenseRecords[i] = ExpenseRecordData{
-			ID:            expense.ID,
-			WalletID:      expense.WalletID,
-			SubcategoryID: expense.SubcategoryID,
-			Amount:        expense.Amount.Amount,
-			Currency:      expense.Amount.Currency,
-			Description:   expense.Description,
-			Date:          expense.Date,
-			CreatedAt:     expense.CreatedAt,
-		}
+		walletData.ExpenseRecords[i] = toExpenseRecordData(expense)
 	}
 
 	// 映射 Transfers
 	transfers := wallet.GetTransfers()
 	walletData.Transfers = make([]TransferData, len(transfers))
 	for i, transfer := range transfers {
-		walletData.Transfers[i] = TransferData{
-			ID:           transfer.ID,
-			FromWalletID: transfer.FromWalletID,
-			ToWalletID:   transfer.ToWalletID,
-			Amount:       transfer.Amount.Amount,
-			Currency:     transfer.Amount.Currency,
-			Fee:          transfer.Fee.Amount,
-			Description:  transfer.Description,
-			Date:         transfer.Date,
-			CreatedAt:    transfer.CreatedAt,
-		}
+		walletData.Transfers[i] = toTransferData(transfer)
 	}
 
 	return walletData
 }
 
+// ToAuditSnapshots 由儲存中的聚合建立稽核快照，只包含此次變更的子實體
+// after 為儲存後的錢包欄位與新增或修改後的子實體；before 為修改或刪除前的子實體 (取自領域事件)，
+// 同一個子實體只保留第一次變更前的內容，錢包欄位由Peer在交易中補上
+func (m *WalletMapper) ToAuditSnapshots(data WalletData, events []model.DomainEvent) (before, after WalletData) {
+	after = WalletData{
+		ID:              data.ID,
+		UserID:          data.UserID,
+		Name:            data.Name,
+		Type:            data.Type,
+		Currency:        data.Currency,
+		BalanceAmount:   data.BalanceAmount,
+		BalanceCurrency: data.BalanceCurrency,
+		CreatedAt:       data.CreatedAt,
+		UpdatedAt:       data.UpdatedAt,
+		Version:         data.Version + 1, // 儲存後的版本
+		IncomeRecords:   changedChildren(data.IncomeRecords, data.IncomeRecordChanges),
+		ExpenseRecords:  changedChildren(data.ExpenseRecords, data.ExpenseRecordChanges),
+		Transfers:       changedChildren(data.Transfers, data.TransferChanges),
+	}
+
+	previous := newPreviousChildren(data)
+	for _, event := range events {
+		switch e := event.(type) {
+		case model.ExpenseUpdated:
+			previous.expense(e.Previous)
+		case model.ExpenseRemoved:
+			previous.expense(e.Expense)
+		case model.IncomeUpdated:
+			previous.income(e.Previous)
+		case model.IncomeRemoved:
+			previous.income(e.Income)
+		case model.TagsChanged:
+			previous.tagsEdited(e.TransactionID, e.Previous)
+		}
+	}
+	return previous.resolve(after), after
+}
+
+// changedChildren 依變更記錄篩出新增或修改的子實體
+func changedChildren[T store.AggregateData](children []T, changes ChildEntityChanges) []T {
+	upserted := changes.Upserted()
+	var changed []T
+	for _, child := range children {
+		if upserted[child.GetID()] {
+			changed = append(changed, child)
+		}
+	}
+	return changed
+}
+
+// previousChildren 收集子實體在此次儲存前的內容
+type previousChildren struct {
+	data WalletData
+	done map[string]bool     // 已收集或此次才新增 (儲存前不存在) 的子實體
+	tags map[string][]string // 尚未找到其他變更的標籤修改：子實體ID → 修改前的標籤
+}
+
+func newPreviousChildren(data WalletData) *previousChildren {
+	previous := &previousChildren{done: make(map[string]bool), tags: make(map[string][]string)}
+	for _, changes := range []ChildEntityChanges{data.IncomeRecordChanges, data.ExpenseRecordChanges, data.TransferChanges} {
+		for _, id := range changes.Added {
+			previous.done[id] = true
+		}
+	}
+	return previous
+}
+
+func (p *previousChildren) expense(record model.ExpenseRecord) {
+	if p.done[record.ID] {
+		return
+	}
+	p.done[record.ID] = true
+	data := toExpenseRecordData(record)
+	if tags, edited := p.tags[record.ID]; edited {
+		data.Tags = copyTags(tags)
+		delete(p.tags, record.ID)
+	}
+	p.data.ExpenseRecords = append(p.data.ExpenseRecords, data)
+}
+
+func (p *previousChildren) income(record model.IncomeRecord) {
+	if p.done[record.ID] {
+		return
+	}
+	p.done[record.ID] = true
+	data := toIncomeRecordData(record)
+	if tags, edited := p.tags[record.ID]; edited {
+		data.Tags = copyTags(tags)
+		delete(p.tags, record.ID)
+	}
+	p.data.IncomeRecords = append(p.data.IncomeRecords, data)
+}
+
+// tagsEdited 記下第一次標籤修改前的標籤；之後若有修改或刪除事件，以其修改前的內容搭配這些標籤
+func (p *previousChildren) tagsEdited(id string, tags []string) {
+	if p.done[id] {
+		return
+	}
+	if _, edited := p.tags[id]; !edited {
+		p.tags[id] = tags
+	}
+}
+
+// resolve 只修改了標籤的子實體，以儲存後的內容換回修改前的標籤
+func (p *previousChildren) resolve(after WalletData) WalletData {
+	for _, record := range after.ExpenseRecords {
+		if tags, edited := p.tags[record.ID]; edited {
+			record.Tags = copyTags(tags)
+			p.data.ExpenseRecords = append(p.data.ExpenseRecords, record)
+		}
+	}
+	for _, record := range after.IncomeRecords {
+		if tags, edited := p.tags[record.ID]; edited {
+			record.Tags = copyTags(tags)
+			p.data.IncomeRecords = append(p.data.IncomeRecords, record)
+		}
+	}
+	for _, transfer := range after.Transfers {
+		if tags, edited := p.tags[transfer.ID]; edited {
+			transfer.Tags = copyTags(tags)
+			p.data.Transfers = append(p.data.Transfers, transfer)
+		}
+	}
+	return p.data
+}
+
+func toIncomeRecordData(income model.IncomeRecord) IncomeRecordData {
+	return IncomeRecordData{
+		ID:            income.ID,
+		WalletID:      income.WalletID,
+		SubcategoryID: income.SubcategoryID,
+		Amount:        income.Amount.Amount,
+		Currency:      income.Amount.Currency,
+		Description:   income.Description,
+		Date:          income.Date,
+		CreatedAt:     income.CreatedAt,
+		Tags:          copyTags(income.Tags),
+		ImportID:      income.ImportID,
+		OccurrenceKey: income.OccurrenceKey,
+	}
+}
+
+func toExpenseRecordData(expense model.ExpenseRecord) ExpenseRecordData {
+	return ExpenseRecordData{
+		ID:            expense.ID,
+		WalletID:      expense.WalletID,
+		SubcategoryID: expense.SubcategoryID,
+		Amount:        expense.Amount.Amount,
+		Currency:      expense.Amount.Currency,
+		Description:   expense.Description,
+		Date:          expense.Date,
+		CreatedAt:     expense.CreatedAt,
+		Splits:        toExpenseSplitData(expense),
+		Tags:          copyTags(expense.Tags),
+		ImportID:      expense.ImportID,
+		OccurrenceKey: expense.OccurrenceKey,
+	}
+}
+
+func toIncomeRecord(data IncomeRecordData) (model.IncomeRecord, error) {
+	amount, err := model.NewMoney(data.Amount, data.Currency)
+	if err != nil {
+		return model.IncomeRecord{}, err
+	}
+	return model.IncomeRecord{
+		ID:            data.ID,
+		WalletID:      data.WalletID,
+		SubcategoryID: data.SubcategoryID,
+		Amount:        *amount,
+		Description:   data.Description,
+		Date:          data.Date,
+		CreatedAt:     data.CreatedAt,
+		Tags:          copyTags(data.Tags),
+		ImportID:      data.ImportID,
+		OccurrenceKey: data.OccurrenceKey,
+	}, nil
+}
+
+func toExpenseRecord(data ExpenseRecordData) (model.ExpenseRecord, error) {
+	amount, err := model.NewMoney(data.Amount, data.Currency)
+	if err != nil {
+		return model.ExpenseRecord{}, err
+	}
+	splits, err := toExpenseSplits(data.Splits)
+	if err != nil {
+		return model.ExpenseRecord{}, err
+	}
+	return model.ExpenseRecord{
+		ID:            data.ID,
+		WalletID:      data.WalletID,
+		SubcategoryID: data.SubcategoryID,
+		Amount:        *amount,
+		Description:   data.Description,
+		Date:          data.Date,
+		CreatedAt:     data.CreatedAt,
+		Splits:        splits,
+		Tags:          copyTags(data.Tags),
+		ImportID:      data.ImportID,
+		OccurrenceKey: data.OccurrenceKey,
+	}, nil
+}
+
+func toTransferData(transfer model.Transfer) TransferData {
+	return TransferData{
+		ID:           transfer.ID,
+		FromWalletID: transfer.FromWalletID,
+		ToWalletID:   transfer.ToWalletID,
+		Amount:       transfer.Amount.Amount,
+		Currency:     transfer.Amount.Currency,
+		ToAmount:     transfer.ToAmount.Amount,
+		ToCurrency:   transfer.ToAmount.Currency,
+		Fee:          transfer.Fee.Amount,
+		FeeCurrency:  transfer.Fee.Currency,
+		Description:  transfer.Description,
+		Date:         transfer.Date,
+		CreatedAt:    transfer.CreatedAt,
+		Tags:         copyTags(transfer.Tags),
+	}
+}
+
+func toChildEntityChanges(changes model.EntityChanges) ChildEntityChanges {
+	return ChildEntityChanges{
+		Added:    changes.Added,
+		Modified: changes.Modified,
+		Removed:  changes.Removed,
+	}
+}
+
 // ToDomain 將WalletData轉換為Wallet Domain Model (包含子實體)
 func (m *WalletMapper) ToDomain(data WalletData) (*model.Wallet, error) {
 	walletType, err := model.ParseWalletType(data.Type)
@@ -174,27 +455,18 @@ func (m *WalletMapper) ToDomain(data WalletData) (*model.Wallet, error) {
 		Balance:   *balance,
 		CreatedAt: data.CreatedAt,
 		UpdatedAt: data.UpdatedAt,
+		Version:   data.Version,
 	}
 
 	// 如果有子實體資料，重建完整聚合
 	if data.IsFullyLoaded {
 		// 重建 IncomeRecords
 		for _, incomeData := range data.IncomeRecords {
-			amount, err := model.NewMoney(incomeData.Amount, incomeData.Currency)
+			incomeRecord, err := toIncomeRecord(incomeData)
 			if err != nil {
 				return nil, err
 			}
 			
-			incomeRecord := model.IncomeRecord{
-				ID:            incomeData.ID,
-				WalletID:      incomeData.WalletID,
-				SubcategoryID: incomeData.SubcategoryID,
-				Amount:        *amount,
-				Description:   incomeData.Description,
-				Date:          incomeData.Date,
-				CreatedAt:     incomeData.CreatedAt,
-			}
-			
 			// 透過聚合方法添加到錢包 (這會驗證業務規則)
 			err = wallet.LoadIncomeRecord(incomeRecord)
 			if err != nil {
@@ -204,21 +476,11 @@ func (m *WalletMapper) ToDomain(data WalletData) (*model.Wallet, error) {
 		
 		// 重建 ExpenseRecords (類似處理)
 		for _, expenseData := range data.ExpenseRecords {
-			amount, err := model.NewMoney(expenseData.Amount, expenseData.Currency)
+			expenseRecord, err := toExpenseRecord(expenseData)
 			if err != nil {
 				return nil, err
 			}
 			
-			expenseRecord := model.ExpenseRecord{
-				ID:            expenseData.ID,
-				WalletID:      expenseData.WalletID,
-				SubcategoryID: expenseData.SubcategoryID,
-				Amount:        *amount,
-				Description:   expenseData.Description,
-				Date:          expenseData.Date,
-				CreatedAt:     expenseData.CreatedAt,
-			}
-			
 			err = wallet.LoadExpenseRecord(expenseRecord)
 			if err != nil {
 				return nil, err
@@ -231,7 +493,11 @@ func (m *WalletMapper) ToDomain(data WalletData) (*model.Wallet, error) {
 			if err != nil {
 				return nil, err
 			}
-			fee, err := model.NewMoney(transferData.Fee, transferData.Currency)
+			toAmount, err := model.NewMoney(transferData.ToAmount, transferData.ToCurrency)
+			if err != nil {
+				return nil, err
+			}
+			fee, err := model.NewMoney(transferData.Fee, transferData.FeeCurrency)
 			if err != nil {
 				return nil, err
 			}
@@ -241,10 +507,12 @@ func (m *WalletMapper) ToDomain(data WalletData) (*model.Wallet, error) {
 				FromWalletID: transferData.FromWalletID,
 				ToWalletID:   transferData.ToWalletID,
 				Amount:       *amount,
+				ToAmount:     *toAmount,
 				Fee:          *fee,
 				Description:  transferData.Description,
 				Date:         transferData.Date,
 				CreatedAt:    transferData.CreatedAt,
+				Tags:         copyTags(transferData.Tags),
 			}
 			
 			err = wallet.LoadTransfer(transfer)
@@ -252,6 +520,8 @@ func (m *WalletMapper) ToDomain(data WalletData) (*model.Wallet, error) {
 				return nil, err
 			}
 		}
+
+		wallet.MarkAsFullyLoaded()
 	}
 	
 	return wallet, nil
@@ -267,4 +537,103 @@ var _ store.AggregateData = (*TransferData)(nil)
 
 // 確保WalletMapper實現Mapper介面和AggregateMapper介面
 var _ Mapper[*model.Wallet, WalletData] = (*WalletMapper)(nil)
-var _ store.AggregateMapper[*model.Wallet, WalletData] = (*WalletMapper)(nil)
\ No newline at end of file
+var _ store.AggregateMapper[*model.Wallet, WalletData] = (*WalletMapper)(nil)
+
+// ToSpentAmounts 將支出加總轉換為Domain的子分類支出金額
+func (m *WalletMapper) ToSpentAmounts(data []ExpenseTotalData) ([]model.SpentAmount, error) {
+	amounts := make([]model.SpentAmount, len(data))
+	for i, total := range data {
+		money, err := model.NewMoney(total.Amount, total.Currency)
+		if err != nil {
+			return nil, err
+		}
+		amounts[i] = model.SpentAmount{SubcategoryID: total.SubcategoryID, Date: total.Date, Amount: *money}
+	}
+	return amounts, nil
+}
+
+// ToRecords 將不含聚合的支出與收入記錄資料轉換為Domain記錄
+func (m *WalletMapper) ToRecords(expenseData []ExpenseRecordData, incomeData []IncomeRecordData) ([]model.ExpenseRecord, []model.IncomeRecord, error) {
+	expenses := make([]model.ExpenseRecord, len(expenseData))
+	for i, data := range expenseData {
+		expense, err := toExpenseRecord(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		expenses[i] = expense
+	}
+	incomes := make([]model.IncomeRecord, len(incomeData))
+	for i, data := range incomeData {
+		income, err := toIncomeRecord(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		incomes[i] = income
+	}
+	return expenses, incomes, nil
+}
+
+// ToRecordsData 將Domain的支出與收入記錄轉換為資料結構
+func (m *WalletMapper) ToRecordsData(expenses []model.ExpenseRecord, incomes []model.IncomeRecord) ([]ExpenseRecordData, []IncomeRecordData) {
+	expenseData := make([]ExpenseRecordData, len(expenses))
+	for i, expense := range expenses {
+		expenseData[i] = toExpenseRecordData(expense)
+	}
+	incomeData := make([]IncomeRecordData, len(incomes))
+	for i, income := range incomes {
+		incomeData[i] = toIncomeRecordData(income)
+	}
+	return expenseData, incomeData
+}
+
+// toExpenseSplitData 映射支出的拆帳明細，未拆帳時回傳nil
+func toExpenseSplitData(expense model.ExpenseRecord) []ExpenseSplitData {
+	if !expense.IsSplit() {
+		return nil
+	}
+	splits := make([]ExpenseSplitData, len(expense.Splits))
+	for i, split := range expense.Splits {
+		splits[i] = ExpenseSplitData{
+			ExpenseID:     expense.ID,
+			LineNo:        i + 1,
+			SubcategoryID: split.SubcategoryID,
+			Amount:        split.Amount.Amount,
+			Currency:      split.Amount.Currency,
+			Memo:          split.Memo,
+		}
+	}
+	return splits
+}
+
+// toExpenseSplits 依LineNo順序重建拆帳明細
+func toExpenseSplits(data []ExpenseSplitData) ([]model.ExpenseSplit, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	sorted := append([]ExpenseSplitData(nil), data...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LineNo < sorted[j].LineNo })
+
+	splits := make([]model.ExpenseSplit, len(sorted))
+	for i, splitData := range sorted {
+		amount, err := model.NewMoney(splitData.Amount, splitData.Currency)
+		if err != nil {
+			return nil, err
+		}
+		splits[i] = model.ExpenseSplit{
+			SubcategoryID: splitData.SubcategoryID,
+			Amount:        *amount,
+			Memo:          splitData.Memo,
+		}
+	}
+	return splits, nil
+}
+
+// copyTags 複製標籤並依字母排序 (資料庫載入的順序不一定相同)，沒有標籤時回傳nil
+func copyTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	copied := append([]string(nil), tags...)
+	sort.Strings(copied)
+	return copied
+}
@@ -0,0 +1,245 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+)
+
+// Handler Outbox訊息的處理器 (例如 webhook、通知)
+// 投遞保證為at-least-once：同一筆訊息可能被處理多次，處理器必須以訊息ID做冪等
+type Handler interface {
+	Handle(message mapper.OutboxMessageData) error
+}
+
+// HandlerFunc 讓一般函式可以作為Handler
+type HandlerFunc func(message mapper.OutboxMessageData) error
+
+func (f HandlerFunc) Handle(message mapper.OutboxMessageData) error {
+	return f(message)
+}
+
+// LogHandler 將每筆訊息寫入日誌的處理器
+// 尚未接上其他整合時作為預設處理器，讓訊息標記為delivered並在保留期限後清除
+var LogHandler = HandlerFunc(func(message mapper.OutboxMessageData) error {
+	log.Printf("outbox event %s: %s %s (message %s)", message.EventType, message.AggregateType, message.AggregateID, message.ID)
+	return nil
+})
+
+// RelayConfig Relay的輪詢與重試設定
+type RelayConfig struct {
+	PollInterval time.Duration // 沒有到期訊息時的等待時間
+	BatchSize    int           // 每次取得的訊息數
+	Lease        time.Duration // 取得後在此期間內不會被其他Relay取得
+	BaseBackoff  time.Duration // 第一次失敗後的等待時間，之後每次加倍
+	MaxBackoff   time.Duration // 重試等待時間上限
+	MaxAttempts  int           // 達到此嘗試次數仍失敗即進入dead letter
+	Retention    time.Duration // 已投遞訊息的保留時間，超過即刪除；0表示不刪除
+}
+
+// purgeInterval Run 清除過期已投遞訊息的間隔
+const purgeInterval = time.Hour
+
+// DefaultRelayConfig 回傳預設設定
+func DefaultRelayConfig() RelayConfig {
+	return RelayConfig{
+		PollInterval: 5 * time.Second,
+		BatchSize:    50,
+		Lease:        time.Minute,
+		BaseBackoff:  5 * time.Second,
+		MaxBackoff:   30 * time.Minute,
+		MaxAttempts:  10,
+		Retention:    7 * 24 * time.Hour,
+	}
+}
+
+// Backoff 第attempts次失敗後到下次嘗試的等待時間 (指數退避，有上限)
+func (c RelayConfig) Backoff(attempts int) time.Duration {
+	backoff := c.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= c.MaxBackoff {
+			return c.MaxBackoff
+		}
+	}
+	return backoff
+}
+
+// Relay 背景投遞Outbox訊息到已註冊的處理器
+// 訊息的所有處理器都成功才標記為delivered；任一失敗則整筆依退避時間重試
+// 尚未註冊任何處理器時不取得訊息；事件類型沒有對應的處理器時訊息維持pending，
+// 直到有處理器可以接收，不會被標記為delivered而遺失
+type Relay struct {
+	repo   repository.OutboxRepository
+	config RelayConfig
+
+	mu          sync.RWMutex
+	handlers    map[string][]Handler
+	allHandlers []Handler
+}
+
+// NewRelay 創建Outbox Relay
+func NewRelay(repo repository.OutboxRepository, config RelayConfig) *Relay {
+	return &Relay{
+		repo:     repo,
+		config:   config,
+		handlers: make(map[string][]Handler),
+	}
+}
+
+// Register 註冊指定事件類型的處理器 (例如 model.EventExpenseAdded)
+func (r *Relay) Register(eventType string, handler Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[eventType] = append(r.handlers[eventType], handler)
+}
+
+// RegisterAll 註冊接收所有事件的處理器
+func (r *Relay) RegisterAll(handler Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.allHandlers = append(r.allHandlers, handler)
+}
+
+// HasHandlers 是否已註冊任何處理器
+func (r *Relay) HasHandlers() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.handlers) > 0 || len(r.allHandlers) > 0
+}
+
+// ProcessBatch 取得一批到期訊息並投遞，回傳狀態已更新的訊息數
+// 單筆訊息更新失敗不影響同批其他訊息，所有失敗合併回傳；未更新的訊息在租約到期後重新取得
+func (r *Relay) ProcessBatch(now time.Time) (int, error) {
+	if !r.HasHandlers() {
+		return 0, nil
+	}
+
+	messages, err := r.repo.ClaimDue(now, r.config.Lease, r.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	var errs []error
+	for _, message := range messages {
+		handlers := r.handlersFor(message.EventType)
+		if len(handlers) == 0 {
+			continue // 維持pending，租約到期後再取得
+		}
+		if err := r.deliver(message, handlers, now); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		processed++
+	}
+	return processed, errors.Join(errs...)
+}
+
+// Purge 刪除投遞時間早於保留期限的訊息，回傳刪除數；Retention為0時不刪除
+func (r *Relay) Purge(now time.Time) (int, error) {
+	if r.config.Retention <= 0 {
+		return 0, nil
+	}
+	return r.repo.DeleteDelivered(now.Add(-r.config.Retention))
+}
+
+// Run 持續投遞直到ctx結束；有積壓時連續處理，否則每PollInterval輪詢一次
+// 每purgeInterval清除一次過期的已投遞訊息
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	var lastPurge time.Time
+	for {
+		now := time.Now()
+		if now.Sub(lastPurge) >= purgeInterval {
+			if purged, err := r.Purge(now); err != nil {
+				log.Printf("outbox relay: failed to purge delivered messages: %v", err)
+			} else if purged > 0 {
+				log.Printf("outbox relay: purged %d delivered messages", purged)
+			}
+			lastPurge = now
+		}
+
+		processed, err := r.ProcessBatch(now)
+		if err != nil {
+			log.Printf("outbox relay: %v", err)
+		}
+
+		// 滿批代表可能還有積壓，不等待直接處理下一批
+		if err == nil && processed == r.config.BatchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliver 投遞單筆訊息並更新其狀態
+func (r *Relay) deliver(message mapper.OutboxMessageData, handlers []Handler, now time.Time) error {
+	message.Attempts++
+
+	if err := dispatch(handlers, message); err != nil {
+		message.LastError = err.Error()
+		if message.Attempts >= r.config.MaxAttempts {
+			message.Status = mapper.OutboxStatusDeadLetter
+			log.Printf("outbox relay: message %s (%s) moved to dead letter after %d attempts: %v",
+				message.ID, message.EventType, message.Attempts, err)
+		} else {
+			message.NextAttemptAt = now.Add(r.config.Backoff(message.Attempts))
+		}
+	} else {
+		delivered := now
+		message.Status = mapper.OutboxStatusDelivered
+		message.DeliveredAt = &delivered
+		message.LastError = ""
+	}
+
+	if err := r.repo.Update(message); err != nil {
+		return fmt.Errorf("failed to update outbox message %s: %w", message.ID, err)
+	}
+	return nil
+}
+
+// handlersFor 回傳接收指定事件類型的處理器
+func (r *Relay) handlersFor(eventType string) []Handler {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	handlers := make([]Handler, 0, len(r.handlers[eventType])+len(r.allHandlers))
+	handlers = append(handlers, r.handlers[eventType]...)
+	return append(handlers, r.allHandlers...)
+}
+
+// dispatch 依序呼叫處理器，收集所有失敗
+func dispatch(handlers []Handler, message mapper.OutboxMessageData) error {
+	var errs []error
+	for _, handler := range handlers {
+		if err := handle(handler, message); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func handle(handler Handler, message mapper.OutboxMessageData) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+	return handler.Handle(message)
+}
@@ -0,0 +1,51 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// AuthenticateAPIKeyService 以明文金鑰的雜湊查找金鑰，成功時輸出ID為金鑰擁有者的使用者ID
+type AuthenticateAPIKeyService struct {
+	repo repository.APIKeyRepository
+}
+
+func NewAuthenticateAPIKeyService(repo repository.APIKeyRepository) *AuthenticateAPIKeyService {
+	return &AuthenticateAPIKeyService{repo: repo}
+}
+
+func (s *AuthenticateAPIKeyService) Execute(input usecase.AuthenticateAPIKeyInput) common.Output {
+	if !strings.HasPrefix(input.Key, model.APIKeyPrefix) {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  "Invalid API key",
+		}
+	}
+
+	key, err := s.repo.FindByKeyHash(model.HashAPIKey(input.Key))
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find API key: %v", err),
+		}
+	}
+
+	// 不存在與已撤銷回傳相同訊息，避免洩漏金鑰狀態
+	if key == nil || !key.IsActive() {
+		return common.UseCaseOutput{
+			ExitCode: common.InvalidInput,
+			Message:  "Invalid API key",
+		}
+	}
+
+	return common.UseCaseOutput{
+		ID:       key.UserID,
+		ExitCode: common.Success,
+		Message:  "API key authenticated",
+	}
+}
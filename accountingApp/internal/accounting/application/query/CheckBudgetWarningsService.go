@@ -0,0 +1,106 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// CheckBudgetWarningsService 找出剛記錄的支出使哪些預算跨過警告門檻或超支
+// 只在跨過時警告一次：支出前已超過門檻的預算不會重複警告，除非這筆支出使其超支
+// 預算幣別與支出不同時，以支出當天的匯率換算
+type CheckBudgetWarningsService struct {
+	budgetRepo repository.BudgetRepository
+	spending   budgetSpending
+}
+
+func NewCheckBudgetWarningsService(
+	budgetRepo repository.BudgetRepository,
+	walletRepo repository.WalletRepository,
+	categoryRepo repository.ExpenseCategoryRepository,
+	converter *exchange.Converter,
+) *CheckBudgetWarningsService {
+	return &CheckBudgetWarningsService{
+		budgetRepo: budgetRepo,
+		spending:   budgetSpending{walletRepo: walletRepo, categoryRepo: categoryRepo, converter: converter},
+	}
+}
+
+func (s *CheckBudgetWarningsService) Execute(input usecase.CheckBudgetWarningsInput) common.Output {
+	budgets, err := s.budgetRepo.FindByUserID(input.UserID)
+	if err != nil {
+		return usecase.CheckBudgetWarningsOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to retrieve budgets: %v", err),
+		}
+	}
+
+	warnings := make([]usecase.BudgetWarning, 0)
+	for _, budget := range budgets {
+		if _, active := budget.WindowAt(input.Date); !active {
+			continue
+		}
+
+		subcategoryIDs, err := s.spending.categorySubcategories(budget)
+		if err != nil {
+			return s.failure(err)
+		}
+		covered := coveredAmount(budget, subcategoryIDs, input)
+		if covered == 0 {
+			continue
+		}
+		amount, ok, err := s.spending.convert(model.Money{Amount: covered, Currency: input.Currency}, budget.Amount.Currency, input.Date)
+		if err != nil {
+			return s.failure(err)
+		}
+		if !ok {
+			continue
+		}
+
+		after, err := s.spending.status(budget, subcategoryIDs, input.Date)
+		if err != nil {
+			return s.failure(err)
+		}
+		before := after
+		before.Spent -= amount.Amount
+		before.Remaining += amount.Amount
+
+		var message string
+		switch {
+		case after.IsOverBudget() && !before.IsOverBudget():
+			message = fmt.Sprintf("Budget %q is over budget: spent %d of %d %s",
+				budget.Name, after.Spent, after.Available, after.Currency)
+		case after.ReachedThreshold(budget.WarningThreshold) && !before.ReachedThreshold(budget.WarningThreshold):
+			message = fmt.Sprintf("Budget %q reached %d%% of its limit: spent %d of %d %s",
+				budget.Name, budget.WarningThreshold, after.Spent, after.Available, after.Currency)
+		default:
+			continue
+		}
+
+		warnings = append(warnings, usecase.BudgetWarning{
+			BudgetID:   budget.ID,
+			BudgetName: budget.Name,
+			Threshold:  budget.WarningThreshold,
+			Message:    message,
+			Status:     toBudgetStatusData(budget, after),
+		})
+	}
+
+	return usecase.CheckBudgetWarningsOutput{
+		ID:       input.UserID,
+		ExitCode: common.Success,
+		Message:  fmt.Sprintf("%d budget warning(s)", len(warnings)),
+		Warnings: warnings,
+	}
+}
+
+func (s *CheckBudgetWarningsService) failure(err error) common.Output {
+	return usecase.CheckBudgetWarningsOutput{
+		ExitCode: conversionFailureExitCode(err),
+		Message:  fmt.Sprintf("Failed to compute budget status: %v", err),
+	}
+}
@@ -0,0 +1,82 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// ConvertMoneyService 以儲存的匯率換算金額
+type ConvertMoneyService struct {
+	converter *exchange.Converter
+}
+
+func NewConvertMoneyService(converter *exchange.Converter) *ConvertMoneyService {
+	return &ConvertMoneyService{converter: converter}
+}
+
+func (s *ConvertMoneyService) Execute(input usecase.ConvertMoneyInput) common.Output {
+	// 1. 檢查輸入
+	from, err := model.NormalizeCurrencyCode(input.From)
+	if err != nil {
+		return usecase.ConvertMoneyOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid from currency: %v", err),
+		}
+	}
+	to, err := model.NormalizeCurrencyCode(input.To)
+	if err != nil {
+		return usecase.ConvertMoneyOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid to currency: %v", err),
+		}
+	}
+	amount, err := model.NewMoney(input.Amount, from)
+	if err != nil {
+		return usecase.ConvertMoneyOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid amount: %v", err),
+		}
+	}
+	date := input.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	// 2. 換算
+	conversion, err := s.converter.Convert(*amount, to, date)
+	var notFound *exchange.RateNotFoundError
+	if errors.As(err, &notFound) {
+		return usecase.ConvertMoneyOutput{
+			ExitCode: common.NotFound,
+			Message:  fmt.Sprintf("Exchange rate not found: %v", err),
+		}
+	}
+	if err != nil {
+		return usecase.ConvertMoneyOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to convert: %v", err),
+		}
+	}
+
+	converted := usecase.ConvertedMoneyData{
+		Amount:   conversion.Converted.Amount,
+		Currency: conversion.Converted.Currency,
+	}
+	if conversion.Rate != nil {
+		converted.Rate = conversion.Rate.RateString()
+		converted.RateDate = conversion.Rate.Date.Format("2006-01-02")
+	}
+	return usecase.ConvertMoneyOutput{
+		ExitCode:  common.Success,
+		Message:   "Amount converted successfully",
+		Original:  usecase.MoneyData{Amount: amount.Amount, Currency: amount.Currency},
+		Converted: converted,
+		Rounding:  string(s.converter.Rounding()),
+	}
+}
@@ -0,0 +1,49 @@
+package query
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+type GetAPIKeysService struct {
+	repo repository.APIKeyRepository
+}
+
+func NewGetAPIKeysService(repo repository.APIKeyRepository) *GetAPIKeysService {
+	return &GetAPIKeysService{repo: repo}
+}
+
+func (s *GetAPIKeysService) Execute(input usecase.GetAPIKeysInput) common.Output {
+	keys, err := s.repo.FindByUserID(input.UserID)
+	if err != nil {
+		return usecase.GetAPIKeysOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to retrieve API keys: %v", err),
+		}
+	}
+
+	// 只回傳中繼資料，不包含金鑰雜湊
+	keysData := make([]usecase.APIKeyData, len(keys))
+	for i, key := range keys {
+		keysData[i] = usecase.APIKeyData{
+			ID:        key.ID,
+			Name:      key.Name,
+			Active:    key.IsActive(),
+			CreatedAt: key.CreatedAt.Format(time.RFC3339),
+		}
+		if key.RevokedAt != nil {
+			keysData[i].RevokedAt = key.RevokedAt.Format(time.RFC3339)
+		}
+	}
+
+	return usecase.GetAPIKeysOutput{
+		ID:       input.UserID,
+		ExitCode: common.Success,
+		Message:  "API keys retrieved successfully",
+		Keys:     keysData,
+	}
+}
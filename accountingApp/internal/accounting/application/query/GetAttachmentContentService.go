@@ -0,0 +1,61 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+type GetAttachmentContentService struct {
+	attachmentRepo repository.AttachmentRepository
+	blobStore      repository.BlobStore
+}
+
+func NewGetAttachmentContentService(attachmentRepo repository.AttachmentRepository, blobStore repository.BlobStore) *GetAttachmentContentService {
+	return &GetAttachmentContentService{
+		attachmentRepo: attachmentRepo,
+		blobStore:      blobStore,
+	}
+}
+
+func (s *GetAttachmentContentService) Execute(input usecase.GetAttachmentContentInput) common.Output {
+	attachment, err := s.attachmentRepo.FindByID(input.AttachmentID)
+	if err != nil {
+		return usecase.GetAttachmentContentOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find attachment: %v", err),
+		}
+	}
+
+	if attachment == nil || !common.IsAccessibleBy(attachment.UserID, input.UserID) {
+		return usecase.GetAttachmentContentOutput{
+			ExitCode: common.NotFound,
+			Message:  "Attachment not found",
+		}
+	}
+
+	content, err := s.blobStore.Get(attachment.StorageKey)
+	if errors.Is(err, repository.ErrBlobNotFound) {
+		return usecase.GetAttachmentContentOutput{
+			ExitCode: common.NotFound,
+			Message:  "Attachment content not found",
+		}
+	}
+	if err != nil {
+		return usecase.GetAttachmentContentOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to read attachment: %v", err),
+		}
+	}
+
+	return usecase.GetAttachmentContentOutput{
+		ID:         attachment.ID,
+		ExitCode:   common.Success,
+		Message:    "Attachment retrieved successfully",
+		Attachment: toAttachmentData(attachment),
+		Content:    content,
+	}
+}
@@ -0,0 +1,66 @@
+package query
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+type GetAttachmentsService struct {
+	attachmentRepo repository.AttachmentRepository
+}
+
+func NewGetAttachmentsService(attachmentRepo repository.AttachmentRepository) *GetAttachmentsService {
+	return &GetAttachmentsService{attachmentRepo: attachmentRepo}
+}
+
+func (s *GetAttachmentsService) Execute(input usecase.GetAttachmentsInput) common.Output {
+	recordType, err := model.ParseAttachmentRecordType(input.RecordType)
+	if err != nil || input.RecordID == "" {
+		return usecase.GetAttachmentsOutput{
+			ExitCode: common.InvalidInput,
+			Message:  "Invalid record: recordType (EXPENSE or INCOME) and recordId are required",
+		}
+	}
+
+	attachments, err := s.attachmentRepo.FindByRecord(recordType, input.RecordID)
+	if err != nil {
+		return usecase.GetAttachmentsOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to retrieve attachments: %v", err),
+		}
+	}
+
+	// 其他使用者的記錄視為沒有附件
+	data := make([]usecase.AttachmentData, 0, len(attachments))
+	for _, attachment := range attachments {
+		if common.IsAccessibleBy(attachment.UserID, input.UserID) {
+			data = append(data, toAttachmentData(attachment))
+		}
+	}
+
+	return usecase.GetAttachmentsOutput{
+		ID:          input.RecordID,
+		ExitCode:    common.Success,
+		Message:     "Attachments retrieved successfully",
+		Attachments: data,
+	}
+}
+
+// toAttachmentData 附件中繼資料的API回應格式 (不含儲存位置)
+func toAttachmentData(attachment *model.Attachment) usecase.AttachmentData {
+	return usecase.AttachmentData{
+		ID:          attachment.ID,
+		RecordType:  string(attachment.RecordType),
+		RecordID:    attachment.RecordID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Checksum:    attachment.Checksum,
+		CreatedAt:   attachment.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,82 @@
+package query
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+const (
+	defaultAuditEntryLimit = 100
+	maxAuditEntryLimit     = 1000
+)
+
+// GetAuditLogService 依條件查詢稽核紀錄，由新到舊排序
+type GetAuditLogService struct {
+	repo repository.AuditLogRepository
+}
+
+func NewGetAuditLogService(repo repository.AuditLogRepository) *GetAuditLogService {
+	return &GetAuditLogService{repo: repo}
+}
+
+func (s *GetAuditLogService) Execute(input usecase.GetAuditLogInput) common.Output {
+	if input.From != nil && input.To != nil && !input.From.Before(*input.To) {
+		return usecase.GetAuditLogOutput{
+			ExitCode: common.InvalidInput,
+			Message:  "Invalid time range: from must be before to",
+		}
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultAuditEntryLimit
+	}
+	if limit > maxAuditEntryLimit {
+		limit = maxAuditEntryLimit
+	}
+
+	entries, err := s.repo.Find(repository.AuditLogFilter{
+		ActorID:       input.ActorID,
+		Command:       input.Command,
+		AggregateType: input.AggregateType,
+		AggregateID:   input.AggregateID,
+		RequestID:     input.RequestID,
+		From:          input.From,
+		To:            input.To,
+		Limit:         limit,
+	})
+	if err != nil {
+		return usecase.GetAuditLogOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to retrieve audit log: %v", err),
+		}
+	}
+
+	entriesData := make([]usecase.AuditEntryData, len(entries))
+	for i, entry := range entries {
+		entriesData[i] = usecase.AuditEntryData{
+			Sequence:      entry.Sequence,
+			ID:            entry.ID,
+			ActorID:       entry.ActorID,
+			Command:       entry.Command,
+			AggregateType: entry.AggregateType,
+			AggregateID:   entry.AggregateID,
+			Before:        entry.Before,
+			After:         entry.After,
+			RequestID:     entry.RequestID,
+			OccurredAt:    entry.OccurredAt.UTC().Format(time.RFC3339Nano),
+			PrevHash:      entry.PrevHash,
+			Hash:          entry.Hash,
+		}
+	}
+
+	return usecase.GetAuditLogOutput{
+		ExitCode: common.Success,
+		Message:  "Audit log retrieved successfully",
+		Entries:  entriesData,
+	}
+}
@@ -0,0 +1,80 @@
+package query
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// GetBudgetStatusService 計算預算在指定日期所在期間的花費與剩餘金額
+// 其他幣別的支出以支出當天的匯率換算為預算幣別
+type GetBudgetStatusService struct {
+	budgetRepo repository.BudgetRepository
+	spending   budgetSpending
+}
+
+func NewGetBudgetStatusService(
+	budgetRepo repository.BudgetRepository,
+	walletRepo repository.WalletRepository,
+	categoryRepo repository.ExpenseCategoryRepository,
+	converter *exchange.Converter,
+) *GetBudgetStatusService {
+	return &GetBudgetStatusService{
+		budgetRepo: budgetRepo,
+		spending:   budgetSpending{walletRepo: walletRepo, categoryRepo: categoryRepo, converter: converter},
+	}
+}
+
+func (s *GetBudgetStatusService) Execute(input usecase.GetBudgetStatusInput) common.Output {
+	budget, err := s.budgetRepo.FindByID(input.BudgetID)
+	if err != nil {
+		return usecase.GetBudgetStatusOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find budget: %v", err),
+		}
+	}
+	if budget == nil || !common.IsAccessibleBy(budget.UserID, input.UserID) {
+		return usecase.GetBudgetStatusOutput{
+			ExitCode: common.NotFound,
+			Message:  "Budget not found",
+		}
+	}
+
+	at := input.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	budgetData := toBudgetData(budget)
+	output := usecase.GetBudgetStatusOutput{
+		ID:       budget.ID,
+		ExitCode: common.Success,
+		Message:  "Budget status retrieved successfully",
+		Budget:   &budgetData,
+	}
+	if _, active := budget.WindowAt(at); !active {
+		output.Message = "Budget is not active on the requested date"
+		return output
+	}
+
+	subcategoryIDs, err := s.spending.categorySubcategories(budget)
+	var status model.BudgetStatus
+	if err == nil {
+		status, err = s.spending.status(budget, subcategoryIDs, at)
+	}
+	if err != nil {
+		return usecase.GetBudgetStatusOutput{
+			ExitCode: conversionFailureExitCode(err),
+			Message:  fmt.Sprintf("Failed to compute budget status: %v", err),
+		}
+	}
+
+	statusData := toBudgetStatusData(budget, status)
+	output.Status = &statusData
+	return output
+}
@@ -0,0 +1,39 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+type GetBudgetsService struct {
+	repo repository.BudgetRepository
+}
+
+func NewGetBudgetsService(repo repository.BudgetRepository) *GetBudgetsService {
+	return &GetBudgetsService{repo: repo}
+}
+
+func (s *GetBudgetsService) Execute(input usecase.GetBudgetsInput) common.Output {
+	budgets, err := s.repo.FindByUserID(input.UserID)
+	if err != nil {
+		return usecase.GetBudgetsOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to retrieve budgets: %v", err),
+		}
+	}
+
+	budgetsData := make([]usecase.BudgetData, len(budgets))
+	for i, budget := range budgets {
+		budgetsData[i] = toBudgetData(budget)
+	}
+
+	return usecase.GetBudgetsOutput{
+		ID:       input.UserID,
+		ExitCode: common.Success,
+		Message:  "Budgets retrieved successfully",
+		Budgets:  budgetsData,
+	}
+}
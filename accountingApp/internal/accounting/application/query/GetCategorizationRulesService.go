@@ -0,0 +1,86 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// GetCategorizationRulesService 依套用順序列出使用者的分類規則
+type GetCategorizationRulesService struct {
+	repo repository.CategorizationRuleRepository
+}
+
+func NewGetCategorizationRulesService(repo repository.CategorizationRuleRepository) *GetCategorizationRulesService {
+	return &GetCategorizationRulesService{repo: repo}
+}
+
+func (s *GetCategorizationRulesService) Execute(input usecase.GetCategorizationRulesInput) common.Output {
+	var ruleType model.CategorizationRuleType
+	if input.Type != "" {
+		var err error
+		if ruleType, err = model.ParseCategorizationRuleType(input.Type); err != nil {
+			return usecase.GetCategorizationRulesOutput{
+				ExitCode: common.InvalidInput,
+				Message:  fmt.Sprintf("Invalid type: %v", err),
+			}
+		}
+	}
+
+	rules, err := s.repo.FindByUserID(input.UserID)
+	if err != nil {
+		return usecase.GetCategorizationRulesOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to retrieve categorization rules: %v", err),
+		}
+	}
+
+	data := []usecase.CategorizationRuleData{}
+	for _, rule := range model.NewCategorizationRuleSet(rules) {
+		if ruleType == "" || rule.Type == ruleType {
+			data = append(data, toCategorizationRuleData(rule))
+		}
+	}
+
+	return usecase.GetCategorizationRulesOutput{
+		ID:       input.UserID,
+		ExitCode: common.Success,
+		Message:  "Categorization rules retrieved successfully",
+		Rules:    data,
+	}
+}
+
+func toCategorizationRuleData(rule *model.CategorizationRule) usecase.CategorizationRuleData {
+	conditions := rule.Conditions
+	weekdays := make([]string, len(conditions.Weekdays))
+	for i, day := range conditions.Weekdays {
+		weekdays[i] = strings.ToUpper(day.String())
+	}
+	tags := rule.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return usecase.CategorizationRuleData{
+		ID:       rule.ID,
+		Name:     rule.Name,
+		Type:     string(rule.Type),
+		Priority: rule.Priority,
+		Conditions: usecase.RuleConditionsData{
+			DescriptionPattern: conditions.DescriptionPattern,
+			MinAmount:          conditions.MinAmount,
+			MaxAmount:          conditions.MaxAmount,
+			Currency:           conditions.Currency,
+			WalletID:           conditions.WalletID,
+			Weekdays:           weekdays,
+		},
+		SubcategoryID: rule.SubcategoryID,
+		Tags:          tags,
+		CreatedAt:     rule.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     rule.UpdatedAt.Format(time.RFC3339),
+	}
+}
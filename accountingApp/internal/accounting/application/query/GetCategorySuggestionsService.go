@@ -0,0 +1,136 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/suggestion"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// 建議的候選數
+const (
+	defaultSuggestionLimit = 3
+	maxSuggestionLimit     = 10
+)
+
+// GetCategorySuggestionsService 依使用者過去的記錄建議描述的子分類
+type GetCategorySuggestionsService struct {
+	suggester           *suggestion.Suggester
+	expenseCategoryRepo repository.ExpenseCategoryRepository
+	incomeCategoryRepo  repository.IncomeCategoryRepository
+}
+
+func NewGetCategorySuggestionsService(
+	suggester *suggestion.Suggester,
+	expenseCategoryRepo repository.ExpenseCategoryRepository,
+	incomeCategoryRepo repository.IncomeCategoryRepository,
+) *GetCategorySuggestionsService {
+	return &GetCategorySuggestionsService{
+		suggester:           suggester,
+		expenseCategoryRepo: expenseCategoryRepo,
+		incomeCategoryRepo:  incomeCategoryRepo,
+	}
+}
+
+func (s *GetCategorySuggestionsService) Execute(input usecase.GetCategorySuggestionsInput) common.Output {
+	// 1. 檢查輸入
+	if strings.TrimSpace(input.Description) == "" {
+		return usecase.GetCategorySuggestionsOutput{
+			ExitCode: common.InvalidInput,
+			Message:  "Invalid description: description is required",
+		}
+	}
+	recordType := model.CategorizeExpense
+	if input.Type != "" {
+		parsed, err := model.ParseCategorizationRuleType(input.Type)
+		if err != nil {
+			return usecase.GetCategorySuggestionsOutput{
+				ExitCode: common.InvalidInput,
+				Message:  fmt.Sprintf("Invalid type: %v", err),
+			}
+		}
+		recordType = parsed
+	}
+	limit := input.Limit
+	if limit == 0 {
+		limit = defaultSuggestionLimit
+	}
+	if limit < 0 || limit > maxSuggestionLimit {
+		return usecase.GetCategorySuggestionsOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid limit: must be between 1 and %d", maxSuggestionLimit),
+		}
+	}
+
+	// 2. 所有候選依機率排序
+	predictions, err := s.suggester.Suggest(input.UserID, recordType, input.Description, 0)
+	if err != nil {
+		return usecase.GetCategorySuggestionsOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to suggest categories: %v", err),
+		}
+	}
+
+	// 3. 略過已刪除的子分類，直到取得足夠的候選
+	suggestions := make([]usecase.CategorySuggestionData, 0, limit)
+	for _, prediction := range predictions {
+		if len(suggestions) == limit {
+			break
+		}
+		data, found, err := s.describe(input.UserID, recordType, prediction.SubcategoryID)
+		if err != nil {
+			return usecase.GetCategorySuggestionsOutput{
+				ExitCode: common.Failure,
+				Message:  fmt.Sprintf("Failed to load category: %v", err),
+			}
+		}
+		if !found {
+			continue
+		}
+		data.Confidence = prediction.Confidence
+		suggestions = append(suggestions, data)
+	}
+
+	message := "Categories suggested successfully"
+	if len(suggestions) == 0 {
+		message = "No past transactions resemble the description"
+	}
+	return usecase.GetCategorySuggestionsOutput{
+		ID:          input.UserID,
+		ExitCode:    common.Success,
+		Message:     message,
+		Suggestions: suggestions,
+	}
+}
+
+// describe 查詢子分類與所屬分類的名稱；子分類已刪除或不屬於使用者時found為false
+func (s *GetCategorySuggestionsService) describe(userID string, recordType model.CategorizationRuleType, subcategoryID string) (usecase.CategorySuggestionData, bool, error) {
+	data := usecase.CategorySuggestionData{SubcategoryID: subcategoryID}
+	if recordType == model.CategorizeIncome {
+		category, err := s.incomeCategoryRepo.FindBySubcategoryID(subcategoryID)
+		if err != nil || category == nil || category.UserID != userID {
+			return data, false, err
+		}
+		subcategory, err := category.GetSubcategory(subcategoryID)
+		if err != nil {
+			return data, false, nil
+		}
+		data.SubcategoryName, data.CategoryID, data.CategoryName = subcategory.Name.Value, category.ID, category.Name.Value
+		return data, true, nil
+	}
+
+	category, err := s.expenseCategoryRepo.FindBySubcategoryID(subcategoryID)
+	if err != nil || category == nil || category.UserID != userID {
+		return data, false, err
+	}
+	subcategory, err := category.GetSubcategory(subcategoryID)
+	if err != nil {
+		return data, false, nil
+	}
+	data.SubcategoryName, data.CategoryID, data.CategoryName = subcategory.Name.Value, category.ID, category.Name.Value
+	return data, true, nil
+}
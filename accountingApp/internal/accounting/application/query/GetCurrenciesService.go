@@ -0,0 +1,43 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// GetCurrenciesService 列出 ISO 4217 貨幣登錄，並以指定語系寫出範例金額
+type GetCurrenciesService struct{}
+
+func NewGetCurrenciesService() *GetCurrenciesService {
+	return &GetCurrenciesService{}
+}
+
+func (s *GetCurrenciesService) Execute(input usecase.GetCurrenciesInput) common.Output {
+	locale := model.ResolveMoneyLocale(input.Locale)
+
+	currencies := model.Currencies()
+	data := make([]usecase.CurrencyData, 0, len(currencies))
+	for _, currency := range currencies {
+		// 範例金額為 1234.56，依貨幣的小數位數換算成最小單位
+		subdivision := currency.Subdivision()
+		example := model.Money{Amount: 1234*subdivision + 56*subdivision/100, Currency: currency.Code}
+		data = append(data, usecase.CurrencyData{
+			Code:       currency.Code,
+			Number:     currency.Number,
+			Name:       currency.Name,
+			Symbol:     currency.Symbol,
+			MinorUnits: currency.MinorUnits,
+			Example:    example.Format(locale),
+		})
+	}
+
+	return usecase.GetCurrenciesOutput{
+		ExitCode:   common.Success,
+		Message:    fmt.Sprintf("Retrieved %d currencies", len(data)),
+		Locale:     locale,
+		Currencies: data,
+	}
+}
@@ -0,0 +1,92 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/duplicate"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+type GetDuplicatesService struct {
+	flagRepo     repository.DuplicateFlagRepository
+	walletRepo   repository.WalletRepository
+	walletMapper *mapper.WalletMapper
+}
+
+func NewGetDuplicatesService(flagRepo repository.DuplicateFlagRepository, walletRepo repository.WalletRepository) *GetDuplicatesService {
+	return &GetDuplicatesService{
+		flagRepo:     flagRepo,
+		walletRepo:   walletRepo,
+		walletMapper: mapper.NewWalletMapper(),
+	}
+}
+
+func (s *GetDuplicatesService) Execute(input usecase.GetDuplicatesInput) common.Output {
+	status := model.DuplicatePending
+	if input.Status != "" {
+		parsed, err := model.ParseDuplicateStatus(strings.ToUpper(input.Status))
+		if err != nil {
+			return usecase.GetDuplicatesOutput{
+				ExitCode: common.InvalidInput,
+				Message:  fmt.Sprintf("Invalid status: %v", err),
+			}
+		}
+		status = parsed
+	}
+
+	flags, err := s.flagRepo.FindByUserID(input.UserID, status)
+	if err != nil {
+		return usecase.GetDuplicatesOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to retrieve duplicates: %v", err),
+		}
+	}
+	sort.SliceStable(flags, func(i, j int) bool { return flags[i].CreatedAt.Before(flags[j].CreatedAt) })
+
+	// 每個錢包只載入一次；已刪除的錢包的標記隨錢包一併刪除
+	wallets := make(map[string]*mapper.WalletData)
+	duplicates := make([]usecase.DuplicateData, 0, len(flags))
+	for _, flag := range flags {
+		if input.WalletID != "" && flag.WalletID != input.WalletID {
+			continue
+		}
+		walletData, loaded := wallets[flag.WalletID]
+		if !loaded {
+			wallet, err := s.walletRepo.FindByIDWithTransactions(flag.WalletID)
+			if err != nil {
+				return usecase.GetDuplicatesOutput{
+					ExitCode: common.Failure,
+					Message:  fmt.Sprintf("Failed to load wallet: %v", err),
+				}
+			}
+			if wallet != nil {
+				data := s.walletMapper.ToData(wallet)
+				walletData = &data
+			}
+			wallets[flag.WalletID] = walletData
+		}
+		if walletData == nil {
+			continue
+		}
+
+		data := duplicate.Present(flag, *walletData)
+		// 任一筆記錄已不存在 (已刪除或在其他標記中合併) 時，待審查的標記不再需要處理
+		if flag.IsPending() && (data.Record == nil || data.DuplicateOf == nil) {
+			continue
+		}
+		duplicates = append(duplicates, data)
+	}
+
+	return usecase.GetDuplicatesOutput{
+		ID:         input.UserID,
+		ExitCode:   common.Success,
+		Message:    "Duplicates retrieved successfully",
+		Duplicates: duplicates,
+	}
+}
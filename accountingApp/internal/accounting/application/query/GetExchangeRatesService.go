@@ -0,0 +1,63 @@
+package query
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// GetExchangeRatesService 列出每組貨幣最近的匯率，或一組貨幣的歷史匯率
+type GetExchangeRatesService struct {
+	rateRepo repository.ExchangeRateRepository
+}
+
+func NewGetExchangeRatesService(rateRepo repository.ExchangeRateRepository) *GetExchangeRatesService {
+	return &GetExchangeRatesService{rateRepo: rateRepo}
+}
+
+func (s *GetExchangeRatesService) Execute(input usecase.GetExchangeRatesInput) common.Output {
+	var (
+		rates []*model.ExchangeRate
+		err   error
+	)
+	if input.Base != "" || input.Quote != "" {
+		// 1. 一組貨幣的歷史匯率
+		base, baseErr := model.NormalizeCurrencyCode(input.Base)
+		quote, quoteErr := model.NormalizeCurrencyCode(input.Quote)
+		if baseErr != nil || quoteErr != nil {
+			return usecase.GetExchangeRatesOutput{
+				ExitCode: common.InvalidInput,
+				Message:  "Invalid currency pair: base and quote must both be 3-letter currency codes",
+			}
+		}
+		rates, err = s.rateRepo.FindByPair(base, quote)
+	} else {
+		// 2. 每組貨幣在指定日期之前最近的匯率
+		date := input.Date
+		if date.IsZero() {
+			date = time.Now()
+		}
+		rates, err = s.rateRepo.FindLatestPerPair(date)
+	}
+	if err != nil {
+		return usecase.GetExchangeRatesOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to retrieve exchange rates: %v", err),
+		}
+	}
+
+	data := make([]usecase.ExchangeRateData, 0, len(rates))
+	for _, rate := range rates {
+		data = append(data, exchange.ToRateData(rate))
+	}
+	return usecase.GetExchangeRatesOutput{
+		ExitCode: common.Success,
+		Message:  fmt.Sprintf("Retrieved %d exchange rates", len(data)),
+		Rates:    data,
+	}
+}
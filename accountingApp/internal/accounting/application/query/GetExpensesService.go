@@ -6,6 +6,7 @@ import (
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
 	"time"
 )
 
@@ -20,6 +21,15 @@ func NewGetExpensesService(walletRepo repository.WalletRepository) *GetExpensesS
 }
 
 func (s *GetExpensesService) Execute(input usecase.GetExpensesInput) common.Output {
+	tags, err := newTagFilter(input.Tags, input.TagMatch)
+	if err != nil {
+		return usecase.GetExpensesOutput{
+			ID:       input.UserID,
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid tag filter: %v", err),
+		}
+	}
+
 	// Get user's wallets to extract expense records
 	wallets, err := s.walletRepo.FindByUserID(input.UserID)
 	if err != nil {
@@ -60,8 +70,16 @@ func (s *GetExpensesService) Execute(input usecase.GetExpensesInput) common.Outp
 			if input.WalletID != nil && *input.WalletID != record.WalletID {
 				continue
 			}
-			if input.CategoryID != nil && *input.CategoryID != record.SubcategoryID {
-				continue
+			// 拆帳支出只要有一筆明細屬於該子分類即符合，並回報該部分的金額
+			var categoryAmount *int64
+			if input.CategoryID != nil {
+				amount := record.AmountFor(func(subcategoryID string) bool {
+					return subcategoryID == *input.CategoryID
+				})
+				if amount == 0 {
+					continue
+				}
+				categoryAmount = &amount
 			}
 			if input.StartDate != nil && record.Date.Before(*input.StartDate) {
 				continue
@@ -75,6 +93,9 @@ func (s *GetExpensesService) Execute(input usecase.GetExpensesInput) common.Outp
 			if input.MaxAmount != nil && record.Amount.Amount > *input.MaxAmount {
 				continue
 			}
+			if !tags.matches(record.Tags) {
+				continue
+			}
 			if input.Description != nil && *input.Description != "" {
 				// Simple contains check for description filter
 				// In production, you might want more sophisticated text search
@@ -98,9 +119,12 @@ func (s *GetExpensesService) Execute(input usecase.GetExpensesInput) common.Outp
 					Amount:   record.Amount.Amount,
 					Currency: record.Amount.Currency,
 				},
-				Description: record.Description,
-				Date:        record.Date.Format(time.RFC3339),
-				CreatedAt:   record.CreatedAt.Format(time.RFC3339),
+				Description:    record.Description,
+				Date:           record.Date.Format(time.RFC3339),
+				CreatedAt:      record.CreatedAt.Format(time.RFC3339),
+				Tags:           record.Tags,
+				Splits:         toExpenseSplitData(record.Splits),
+				CategoryAmount: categoryAmount,
 			}
 
 			allExpenseRecords = append(allExpenseRecords, expenseData)
@@ -116,3 +140,18 @@ func (s *GetExpensesService) Execute(input usecase.GetExpensesInput) common.Outp
 	}
 }
 
+func toExpenseSplitData(splits []model.ExpenseSplit) []usecase.ExpenseSplitData {
+	if len(splits) == 0 {
+		return nil
+	}
+	data := make([]usecase.ExpenseSplitData, len(splits))
+	for i, split := range splits {
+		data[i] = usecase.ExpenseSplitData{
+			SubcategoryID: split.SubcategoryID,
+			Amount:        split.Amount.Amount,
+			Memo:          split.Memo,
+		}
+	}
+	return data
+}
+
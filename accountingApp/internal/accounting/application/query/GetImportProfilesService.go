@@ -0,0 +1,68 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+type GetImportProfilesService struct {
+	repo repository.ImportProfileRepository
+}
+
+func NewGetImportProfilesService(repo repository.ImportProfileRepository) *GetImportProfilesService {
+	return &GetImportProfilesService{repo: repo}
+}
+
+func (s *GetImportProfilesService) Execute(input usecase.GetImportProfilesInput) common.Output {
+	profiles, err := s.repo.FindByUserID(input.UserID)
+	if err != nil {
+		return usecase.GetImportProfilesOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to retrieve import profiles: %v", err),
+		}
+	}
+
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	data := make([]usecase.ImportProfileData, len(profiles))
+	for i, profile := range profiles {
+		data[i] = toImportProfileData(profile)
+	}
+
+	return usecase.GetImportProfilesOutput{
+		ID:       input.UserID,
+		ExitCode: common.Success,
+		Message:  "Import profiles retrieved successfully",
+		Profiles: data,
+	}
+}
+
+func toImportProfileData(profile *model.ImportProfile) usecase.ImportProfileData {
+	mapping := profile.Mapping
+	return usecase.ImportProfileData{
+		ID:   profile.ID,
+		Name: profile.Name,
+		Mapping: usecase.ImportMappingData{
+			Delimiter:            mapping.Delimiter,
+			Encoding:             string(mapping.Encoding),
+			HasHeader:            mapping.HasHeader,
+			SkipRows:             mapping.SkipRows,
+			DateColumn:           mapping.DateColumn,
+			DateFormat:           mapping.DateFormat,
+			DescriptionColumn:    mapping.DescriptionColumn,
+			AmountColumn:         mapping.AmountColumn,
+			SignConvention:       string(mapping.SignConvention),
+			DebitColumn:          mapping.DebitColumn,
+			CreditColumn:         mapping.CreditColumn,
+			ExpenseSubcategoryID: mapping.ExpenseSubcategoryID,
+			IncomeSubcategoryID:  mapping.IncomeSubcategoryID,
+		},
+		CreatedAt: profile.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: profile.UpdatedAt.Format(time.RFC3339),
+	}
+}
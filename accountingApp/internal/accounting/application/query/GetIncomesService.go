@@ -19,6 +19,15 @@ func NewGetIncomesService(walletRepo repository.WalletRepository) *GetIncomesSer
 }
 
 func (s *GetIncomesService) Execute(input usecase.GetIncomesInput) common.Output {
+	tags, err := newTagFilter(input.Tags, input.TagMatch)
+	if err != nil {
+		return usecase.GetIncomesOutput{
+			ID:       input.UserID,
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid tag filter: %v", err),
+		}
+	}
+
 	// Get user's wallets to extract income records
 	wallets, err := s.walletRepo.FindByUserID(input.UserID)
 	if err != nil {
@@ -74,6 +83,9 @@ func (s *GetIncomesService) Execute(input usecase.GetIncomesInput) common.Output
 			if input.MaxAmount != nil && record.Amount.Amount > *input.MaxAmount {
 				continue
 			}
+			if !tags.matches(record.Tags) {
+				continue
+			}
 			if input.Description != nil && *input.Description != "" {
 				// Simple contains check for description filter
 				// In production, you might want more sophisticated text search
@@ -100,6 +112,7 @@ func (s *GetIncomesService) Execute(input usecase.GetIncomesInput) common.Output
 				Description: record.Description,
 				Date:        record.Date.Format(time.RFC3339),
 				CreatedAt:   record.CreatedAt.Format(time.RFC3339),
+				Tags:        record.Tags,
 			}
 
 			allIncomeRecords = append(allIncomeRecords, incomeData)
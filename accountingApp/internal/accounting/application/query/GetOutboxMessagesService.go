@@ -0,0 +1,80 @@
+package query
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+const (
+	defaultOutboxMessageLimit = 50
+	maxOutboxMessageLimit     = 500
+)
+
+// GetOutboxMessagesService 查詢Outbox訊息，供管理者檢查卡住的投遞
+type GetOutboxMessagesService struct {
+	repo repository.OutboxRepository
+}
+
+func NewGetOutboxMessagesService(repo repository.OutboxRepository) *GetOutboxMessagesService {
+	return &GetOutboxMessagesService{repo: repo}
+}
+
+func (s *GetOutboxMessagesService) Execute(input usecase.GetOutboxMessagesInput) common.Output {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultOutboxMessageLimit
+	}
+	if limit > maxOutboxMessageLimit {
+		limit = maxOutboxMessageLimit
+	}
+
+	var messages []mapper.OutboxMessageData
+	var err error
+	switch input.Status {
+	case "":
+		messages, err = s.repo.FindStuck(limit)
+	case mapper.OutboxStatusPending, mapper.OutboxStatusDelivered, mapper.OutboxStatusDeadLetter:
+		messages, err = s.repo.FindByStatus(input.Status, limit)
+	default:
+		return usecase.GetOutboxMessagesOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid status: %s", input.Status),
+		}
+	}
+	if err != nil {
+		return usecase.GetOutboxMessagesOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to retrieve outbox messages: %v", err),
+		}
+	}
+
+	messagesData := make([]usecase.OutboxMessageData, len(messages))
+	for i, message := range messages {
+		messagesData[i] = usecase.OutboxMessageData{
+			ID:            message.ID,
+			AggregateType: message.AggregateType,
+			AggregateID:   message.AggregateID,
+			EventType:     message.EventType,
+			Status:        message.Status,
+			Attempts:      message.Attempts,
+			LastError:     message.LastError,
+			Payload:       message.Payload,
+			OccurredAt:    message.OccurredAt.Format(time.RFC3339),
+			NextAttemptAt: message.NextAttemptAt.Format(time.RFC3339),
+		}
+		if message.DeliveredAt != nil {
+			messagesData[i].DeliveredAt = message.DeliveredAt.Format(time.RFC3339)
+		}
+	}
+
+	return usecase.GetOutboxMessagesOutput{
+		ExitCode: common.Success,
+		Message:  "Outbox messages retrieved successfully",
+		Messages: messagesData,
+	}
+}
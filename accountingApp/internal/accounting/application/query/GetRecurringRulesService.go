@@ -0,0 +1,74 @@
+package query
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+type GetRecurringRulesService struct {
+	repo repository.RecurringRuleRepository
+}
+
+func NewGetRecurringRulesService(repo repository.RecurringRuleRepository) *GetRecurringRulesService {
+	return &GetRecurringRulesService{repo: repo}
+}
+
+func (s *GetRecurringRulesService) Execute(input usecase.GetRecurringRulesInput) common.Output {
+	rules, err := s.repo.FindByUserID(input.UserID)
+	if err != nil {
+		return usecase.GetRecurringRulesOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to retrieve recurring rules: %v", err),
+		}
+	}
+
+	now := time.Now()
+	rulesData := make([]usecase.RecurringRuleData, len(rules))
+	for i, rule := range rules {
+		rulesData[i] = toRecurringRuleData(rule, now)
+	}
+
+	return usecase.GetRecurringRulesOutput{
+		ID:       input.UserID,
+		ExitCode: common.Success,
+		Message:  "Recurring rules retrieved successfully",
+		Rules:    rulesData,
+	}
+}
+
+func toRecurringRuleData(rule *model.RecurringRule, now time.Time) usecase.RecurringRuleData {
+	data := usecase.RecurringRuleData{
+		ID:              rule.ID,
+		Name:            rule.Name,
+		Type:            string(rule.Type),
+		WalletID:        rule.WalletID,
+		SubcategoryID:   rule.SubcategoryID,
+		Amount:          rule.Amount.Amount,
+		Currency:        rule.Amount.Currency,
+		Description:     rule.Description,
+		Frequency:       string(rule.Schedule.Frequency),
+		Interval:        rule.Schedule.Interval,
+		DayOfMonth:      rule.Schedule.DayOfMonth,
+		LastBusinessDay: rule.Schedule.LastBusinessDay,
+		StartDate:       rule.Schedule.StartDate.Format("2006-01-02"),
+		Count:           rule.Schedule.Count,
+		Status:          string(rule.Status),
+		CreatedAt:       rule.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       rule.UpdatedAt.Format(time.RFC3339),
+	}
+	if rule.Schedule.EndDate != nil {
+		data.EndDate = rule.Schedule.EndDate.Format("2006-01-02")
+	}
+	if rule.PausedAt != nil {
+		data.PausedAt = rule.PausedAt.Format(time.RFC3339)
+	}
+	if next, ok := rule.NextScheduled(now); ok {
+		data.NextOccurrence = next.Format("2006-01-02")
+	}
+	return data
+}
@@ -0,0 +1,201 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+type GetTagSummaryService struct {
+	walletRepo repository.WalletRepository
+	converter  *exchange.Converter // Optional - required only for a reporting currency
+}
+
+func NewGetTagSummaryService(walletRepo repository.WalletRepository, converter *exchange.Converter) *GetTagSummaryService {
+	return &GetTagSummaryService{
+		walletRepo: walletRepo,
+		converter:  converter,
+	}
+}
+
+// tagTotalsKey groups the summary by tag and currency; amounts in different
+// currencies are never added together
+type tagTotalsKey struct {
+	tag      string
+	currency string
+}
+
+func (s *GetTagSummaryService) Execute(input usecase.GetTagSummaryInput) common.Output {
+	only, err := model.NormalizeTags(input.Tags)
+	if err != nil {
+		return usecase.GetTagSummaryOutput{
+			ID:       input.UserID,
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid tag filter: %v", err),
+		}
+	}
+
+	var conversion *reportingConversion
+	if input.ReportingCurrency != "" {
+		rateDate := time.Now()
+		if input.EndDate != nil {
+			rateDate = *input.EndDate
+		}
+		if conversion, err = newReportingConversion(s.converter, input.ReportingCurrency, rateDate); err != nil {
+			return usecase.GetTagSummaryOutput{
+				ID:       input.UserID,
+				ExitCode: common.InvalidInput,
+				Message:  fmt.Sprintf("Invalid reporting currency: %v", err),
+			}
+		}
+	}
+
+	wallets, err := s.walletRepo.FindByUserID(input.UserID)
+	if err != nil {
+		return usecase.GetTagSummaryOutput{
+			ID:       input.UserID,
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to retrieve wallets: %v", err),
+		}
+	}
+
+	totals := make(map[tagTotalsKey]*usecase.TagSummaryData)
+	add := func(tags []string, money model.Money, date time.Time, addAmount func(*usecase.TagSummaryData, int64)) {
+		if input.StartDate != nil && date.Before(*input.StartDate) {
+			return
+		}
+		if input.EndDate != nil && date.After(*input.EndDate) {
+			return
+		}
+		for _, tag := range tags {
+			if len(only) > 0 && !model.HasTag(only, tag) {
+				continue
+			}
+			key := tagTotalsKey{tag: tag, currency: money.Currency}
+			row, ok := totals[key]
+			if !ok {
+				row = &usecase.TagSummaryData{Tag: tag, Currency: money.Currency}
+				totals[key] = row
+			}
+			addAmount(row, money.Amount)
+			row.Count++
+		}
+	}
+
+	// A transfer between two of the user's wallets is part of both aggregates,
+	// so it is counted only once
+	seenTransfers := make(map[string]bool)
+	for _, wallet := range wallets {
+		fullyLoadedWallet, err := s.walletRepo.FindByIDWithTransactions(wallet.ID)
+		if err != nil {
+			return usecase.GetTagSummaryOutput{
+				ID:       input.UserID,
+				ExitCode: common.Failure,
+				Message:  fmt.Sprintf("Failed to load wallet %s: %v", wallet.ID, err),
+			}
+		}
+		if fullyLoadedWallet == nil {
+			continue
+		}
+
+		for _, record := range fullyLoadedWallet.GetExpenseRecords() {
+			add(record.Tags, record.Amount, record.Date, func(row *usecase.TagSummaryData, amount int64) {
+				row.Expenses += amount
+			})
+		}
+		for _, record := range fullyLoadedWallet.GetIncomeRecords() {
+			add(record.Tags, record.Amount, record.Date, func(row *usecase.TagSummaryData, amount int64) {
+				row.Incomes += amount
+			})
+		}
+		for _, transfer := range fullyLoadedWallet.GetTransfers() {
+			if seenTransfers[transfer.ID] {
+				continue
+			}
+			seenTransfers[transfer.ID] = true
+			add(transfer.Tags, transfer.Amount, transfer.Date, func(row *usecase.TagSummaryData, amount int64) {
+				row.Transfers += amount
+			})
+		}
+	}
+
+	rows := make([]usecase.TagSummaryData, 0, len(totals))
+	for _, row := range totals {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Tag != rows[j].Tag {
+			return rows[i].Tag < rows[j].Tag
+		}
+		return rows[i].Currency < rows[j].Currency
+	})
+
+	output := usecase.GetTagSummaryOutput{
+		ID:       input.UserID,
+		ExitCode: common.Success,
+		Message:  fmt.Sprintf("Successfully summarized %d tags", len(rows)),
+		Tags:     rows,
+	}
+	if conversion != nil {
+		if err := convertTagSummary(&output, conversion); err != nil {
+			return usecase.GetTagSummaryOutput{
+				ID:       input.UserID,
+				ExitCode: conversionFailureExitCode(err),
+				Message:  conversionFailureMessage(err),
+			}
+		}
+	}
+	return output
+}
+
+// convertTagSummary converts every row into the reporting currency and adds
+// the converted rows of each tag together. Each total is rounded on its own.
+func convertTagSummary(output *usecase.GetTagSummaryOutput, conversion *reportingConversion) error {
+	reportingRows := make(map[string]*usecase.TagSummaryData)
+	var tags []string
+	for i := range output.Tags {
+		row := &output.Tags[i]
+		converted := usecase.ConvertedTagTotalsData{Currency: conversion.currency}
+		for _, total := range []struct {
+			amount int64
+			target *int64
+		}{
+			{row.Expenses, &converted.Expenses},
+			{row.Incomes, &converted.Incomes},
+			{row.Transfers, &converted.Transfers},
+		} {
+			money, err := conversion.convert(model.Money{Amount: total.amount, Currency: row.Currency})
+			if err != nil {
+				return err
+			}
+			*total.target = money.Amount
+			converted.Rate, converted.RateDate = money.Rate, money.RateDate
+		}
+		row.Converted = &converted
+
+		reportingRow, ok := reportingRows[row.Tag]
+		if !ok {
+			reportingRow = &usecase.TagSummaryData{Tag: row.Tag, Currency: conversion.currency}
+			reportingRows[row.Tag] = reportingRow
+			tags = append(tags, row.Tag)
+		}
+		reportingRow.Expenses += converted.Expenses
+		reportingRow.Incomes += converted.Incomes
+		reportingRow.Transfers += converted.Transfers
+		reportingRow.Count += row.Count
+	}
+
+	// Rows are sorted by tag, so the tags are already in order
+	output.ReportingCurrency = conversion.currency
+	output.ReportingTotals = make([]usecase.TagSummaryData, 0, len(tags))
+	for _, tag := range tags {
+		output.ReportingTotals = append(output.ReportingTotals, *reportingRows[tag])
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+type GetTransfersService struct {
+	walletRepo repository.WalletRepository
+}
+
+func NewGetTransfersService(walletRepo repository.WalletRepository) *GetTransfersService {
+	return &GetTransfersService{
+		walletRepo: walletRepo,
+	}
+}
+
+func (s *GetTransfersService) Execute(input usecase.GetTransfersInput) common.Output {
+	// Get user's wallets to extract transfers
+	wallets, err := s.walletRepo.FindByUserID(input.UserID)
+	if err != nil {
+		return usecase.GetTransfersOutput{
+			ID:       input.UserID,
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to retrieve wallets: %v", err),
+		}
+	}
+
+	if len(wallets) == 0 {
+		return usecase.GetTransfersOutput{
+			ID:       input.UserID,
+			ExitCode: common.Success,
+			Message:  "No wallets found. Please create a wallet first.",
+			Data:     []usecase.TransferRecordData{},
+			Count:    0,
+		}
+	}
+
+	// A transfer between two of the user's wallets is part of both aggregates,
+	// so collect by transfer ID to report it only once
+	seen := make(map[string]bool)
+	allTransfers := make([]model.Transfer, 0)
+
+	for _, wallet := range wallets {
+		// Load wallet with transactions to get complete aggregate
+		fullyLoadedWallet, err := s.walletRepo.FindByIDWithTransactions(wallet.ID)
+		if err != nil || fullyLoadedWallet == nil {
+			// Fallback to basic wallet if transaction loading fails
+			fullyLoadedWallet = wallet
+		}
+
+		for _, transfer := range fullyLoadedWallet.GetTransfers() {
+			if seen[transfer.ID] || !matchesTransferFilters(transfer, input) {
+				continue
+			}
+			seen[transfer.ID] = true
+			allTransfers = append(allTransfers, transfer)
+		}
+	}
+
+	// Most recent transfers first
+	sort.SliceStable(allTransfers, func(i, j int) bool {
+		return allTransfers[i].Date.After(allTransfers[j].Date)
+	})
+
+	transferData := make([]usecase.TransferRecordData, 0, len(allTransfers))
+	for _, transfer := range allTransfers {
+		transferData = append(transferData, toTransferRecordData(transfer, input.WalletID))
+	}
+
+	return usecase.GetTransfersOutput{
+		ID:       input.UserID,
+		ExitCode: common.Success,
+		Message:  fmt.Sprintf("Successfully retrieved %d transfers", len(transferData)),
+		Data:     transferData,
+		Count:    len(transferData),
+	}
+}
+
+func matchesTransferFilters(transfer model.Transfer, input usecase.GetTransfersInput) bool {
+	if input.WalletID != nil && *input.WalletID != transfer.FromWalletID && *input.WalletID != transfer.ToWalletID {
+		return false
+	}
+	if input.StartDate != nil && transfer.Date.Before(*input.StartDate) {
+		return false
+	}
+	if input.EndDate != nil && transfer.Date.After(*input.EndDate) {
+		return false
+	}
+	if input.MinAmount != nil && transfer.Amount.Amount < *input.MinAmount {
+		return false
+	}
+	if input.MaxAmount != nil && transfer.Amount.Amount > *input.MaxAmount {
+		return false
+	}
+	if input.Description != nil && *input.Description != "" &&
+		!strings.Contains(transfer.Description, *input.Description) {
+		return false
+	}
+	return true
+}
+
+func toTransferRecordData(transfer model.Transfer, walletID *string) usecase.TransferRecordData {
+	data := usecase.TransferRecordData{
+		ID:           transfer.ID,
+		FromWalletID: transfer.FromWalletID,
+		ToWalletID:   transfer.ToWalletID,
+		Description:  transfer.Description,
+		Date:         transfer.Date.Format(time.RFC3339),
+		CreatedAt:    transfer.CreatedAt.Format(time.RFC3339),
+		Tags:         transfer.Tags,
+	}
+	data.Amount.Amount = transfer.Amount.Amount
+	data.Amount.Currency = transfer.Amount.Currency
+	data.ToAmount = usecase.MoneyData{Amount: transfer.ToAmount.Amount, Currency: transfer.ToAmount.Currency}
+	data.Fee.Amount = transfer.Fee.Amount
+	data.Fee.Currency = transfer.Fee.Currency
+	if rate := transfer.ImpliedRate(); rate != nil {
+		data.Rate = rate.RateString()
+	}
+
+	// Direction is only meaningful relative to a specific wallet
+	if walletID != nil {
+		if *walletID == transfer.ToWalletID {
+			data.Direction = "incoming"
+		} else {
+			data.Direction = "outgoing"
+		}
+	}
+	return data
+}
@@ -24,6 +24,12 @@ func (s *GetWalletBalanceService) Execute(input usecase.GetWalletBalanceInput) c
 			Message:  fmt.Sprintf("wallet not found: %v", err),
 		}
 	}
+	if wallet == nil || !common.IsAccessibleBy(wallet.UserID, input.UserID) {
+		return usecase.GetWalletBalanceOutput{
+			ExitCode: common.NotFound,
+			Message:  "Wallet not found",
+		}
+	}
 
 	return usecase.GetWalletBalanceOutput{
 		ID:       wallet.ID,
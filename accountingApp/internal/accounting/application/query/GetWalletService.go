@@ -33,9 +33,9 @@ func (s *GetWalletService) Execute(input usecase.GetWalletInput) common.Output {
 		}
 	}
 
-	if wallet == nil {
+	if wallet == nil || !common.IsAccessibleBy(wallet.UserID, input.UserID) {
 		return usecase.GetWalletOutput{
-			ExitCode: common.Failure,
+			ExitCode: common.NotFound,
 			Message:  "Wallet not found",
 		}
 	}
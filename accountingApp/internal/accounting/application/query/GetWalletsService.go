@@ -2,20 +2,35 @@ package query
 
 import (
 	"fmt"
+	"time"
+
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
 )
 
 type GetWalletsService struct {
 	walletRepo repository.WalletRepository
+	converter  *exchange.Converter // Optional - required only for a reporting currency
 }
 
-func NewGetWalletsService(walletRepo repository.WalletRepository) *GetWalletsService {
-	return &GetWalletsService{walletRepo: walletRepo}
+func NewGetWalletsService(walletRepo repository.WalletRepository, converter *exchange.Converter) *GetWalletsService {
+	return &GetWalletsService{walletRepo: walletRepo, converter: converter}
 }
 
 func (s *GetWalletsService) Execute(input usecase.GetWalletsInput) common.Output {
+	var conversion *reportingConversion
+	if input.ReportingCurrency != "" {
+		var err error
+		if conversion, err = newReportingConversion(s.converter, input.ReportingCurrency, time.Now()); err != nil {
+			return usecase.GetWalletsOutput{
+				ExitCode: common.InvalidInput,
+				Message:  fmt.Sprintf("Invalid reporting currency: %v", err),
+			}
+		}
+	}
+
 	wallets, err := s.walletRepo.FindByUserID(input.UserID)
 	if err != nil {
 		return usecase.GetWalletsOutput{
@@ -24,10 +39,32 @@ func (s *GetWalletsService) Execute(input usecase.GetWalletsInput) common.Output
 		}
 	}
 
-	return usecase.GetWalletsOutput{
+	output := usecase.GetWalletsOutput{
 		ID:       input.UserID,
 		ExitCode: common.Success,
 		Message:  "Wallets retrieved successfully",
 		Wallets:  wallets,
 	}
-}
\ No newline at end of file
+	if conversion == nil {
+		return output
+	}
+
+	// Each balance is converted with today's rate and rounded on its own;
+	// the total is the sum of the rounded balances
+	output.ReportingCurrency = conversion.currency
+	output.ConvertedBalances = make(map[string]usecase.ConvertedMoneyData, len(wallets))
+	total := usecase.MoneyData{Currency: conversion.currency}
+	for _, wallet := range wallets {
+		converted, err := conversion.convert(wallet.Balance)
+		if err != nil {
+			return usecase.GetWalletsOutput{
+				ExitCode: conversionFailureExitCode(err),
+				Message:  conversionFailureMessage(err),
+			}
+		}
+		output.ConvertedBalances[wallet.ID] = converted
+		total.Amount += converted.Amount
+	}
+	output.TotalBalance = &total
+	return output
+}
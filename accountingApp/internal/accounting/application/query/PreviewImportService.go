@@ -0,0 +1,95 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/statement"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// PreviewImportService 匯入帳單的試算：解析資料列並套用到錢包的複本，不儲存任何變更
+// 因此預覽與實際匯入會略過與拒絕相同的資料列 (例如已匯入過或餘額不足)
+type PreviewImportService struct {
+	walletRepo   repository.WalletRepository
+	importer     *statement.Importer
+	walletMapper *mapper.WalletMapper
+}
+
+func NewPreviewImportService(
+	walletRepo repository.WalletRepository,
+	profileRepo repository.ImportProfileRepository,
+	expenseCategoryRepo repository.ExpenseCategoryRepository,
+	incomeCategoryRepo repository.IncomeCategoryRepository,
+	ruleRepo repository.CategorizationRuleRepository,
+) *PreviewImportService {
+	return &PreviewImportService{
+		walletRepo:   walletRepo,
+		importer:     statement.NewImporter(profileRepo, expenseCategoryRepo, incomeCategoryRepo, ruleRepo),
+		walletMapper: mapper.NewWalletMapper(),
+	}
+}
+
+func (s *PreviewImportService) Execute(input usecase.PreviewImportInput) common.Output {
+	format, mapping, output := s.importer.Mapping(input.UserID, input.StatementFile)
+	if output != nil {
+		return output
+	}
+	rules, output := s.importer.Rules(input.UserID)
+	if output != nil {
+		return output
+	}
+
+	wallet, err := s.walletRepo.FindByIDWithTransactions(input.WalletID)
+	if err != nil {
+		return usecase.PreviewImportOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find wallet: %v", err),
+		}
+	}
+	if wallet == nil || !common.IsAccessibleBy(wallet.UserID, input.UserID) {
+		return usecase.PreviewImportOutput{
+			ExitCode: common.NotFound,
+			Message:  "Wallet not found",
+		}
+	}
+
+	parsed, output := statement.Parse(format, input.StatementFile, mapping, wallet.Currency())
+	if output != nil {
+		return output
+	}
+
+	// 在錢包的複本上套用，Repository中的聚合不會被修改
+	draft, err := s.walletMapper.ToDomain(s.walletMapper.ToData(wallet))
+	if err != nil {
+		return usecase.PreviewImportOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to load wallet: %v", err),
+		}
+	}
+	applied := statement.Apply(draft, mapping, rules, parsed)
+
+	// 預覽不回傳記錄ID (複本中的記錄不會被儲存)
+	rows := applied.Rows
+	result := usecase.PreviewImportOutput{
+		ID:         wallet.ID,
+		ExitCode:   common.Success,
+		Message:    fmt.Sprintf("%d rows ready to import, %d already imported, %d rejected", len(rows), len(applied.Skipped), len(applied.Rejected)),
+		Rows:       rows,
+		Skipped:    applied.Skipped,
+		Rejected:   applied.Rejected,
+		Statements: applied.Statements,
+	}
+	for i := range rows {
+		rows[i].RecordID = ""
+		if rows[i].Type == string(model.ImportExpense) {
+			result.TotalExpenses += rows[i].Amount
+		} else {
+			result.TotalIncomes += rows[i].Amount
+		}
+	}
+	return result
+}
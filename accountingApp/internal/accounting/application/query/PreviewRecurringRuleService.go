@@ -0,0 +1,77 @@
+package query
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+const (
+	defaultRecurringPreviewLimit = 10
+	maxRecurringPreviewLimit     = 100
+)
+
+// PreviewRecurringRuleService 列出週期規則接下來的發生日及其狀態 (含已略過或已產生的日期)
+type PreviewRecurringRuleService struct {
+	repo repository.RecurringRuleRepository
+}
+
+func NewPreviewRecurringRuleService(repo repository.RecurringRuleRepository) *PreviewRecurringRuleService {
+	return &PreviewRecurringRuleService{repo: repo}
+}
+
+func (s *PreviewRecurringRuleService) Execute(input usecase.PreviewRecurringRuleInput) common.Output {
+	if input.Limit < 0 || input.Limit > maxRecurringPreviewLimit {
+		return usecase.PreviewRecurringRuleOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid limit: must be between 1 and %d", maxRecurringPreviewLimit),
+		}
+	}
+
+	rule, err := s.repo.FindByID(input.RuleID)
+	if err != nil {
+		return usecase.PreviewRecurringRuleOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find recurring rule: %v", err),
+		}
+	}
+	if rule == nil || !common.IsAccessibleBy(rule.UserID, input.UserID) {
+		return usecase.PreviewRecurringRuleOutput{
+			ExitCode: common.NotFound,
+			Message:  "Recurring rule not found",
+		}
+	}
+
+	now := time.Now()
+	from := input.From
+	if from.IsZero() {
+		from = now
+	}
+	limit := input.Limit
+	if limit == 0 {
+		limit = defaultRecurringPreviewLimit
+	}
+
+	preview := rule.Preview(from, limit)
+	occurrences := make([]usecase.RecurringOccurrenceData, len(preview))
+	for i, occurrence := range preview {
+		occurrences[i] = usecase.RecurringOccurrenceData{
+			Date:          occurrence.Date.Format("2006-01-02"),
+			Status:        string(occurrence.Status),
+			TransactionID: occurrence.TransactionID,
+			Error:         occurrence.LastError,
+		}
+	}
+
+	ruleData := toRecurringRuleData(rule, now)
+	return usecase.PreviewRecurringRuleOutput{
+		ID:          rule.ID,
+		ExitCode:    common.Success,
+		Message:     "Recurring rule preview retrieved successfully",
+		Rule:        &ruleData,
+		Occurrences: occurrences,
+	}
+}
@@ -0,0 +1,74 @@
+package query
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// TestCategorizationRuleService 以範例交易試跑使用者的分類規則，回報會套用的規則與所有符合的規則
+type TestCategorizationRuleService struct {
+	repo repository.CategorizationRuleRepository
+}
+
+func NewTestCategorizationRuleService(repo repository.CategorizationRuleRepository) *TestCategorizationRuleService {
+	return &TestCategorizationRuleService{repo: repo}
+}
+
+func (s *TestCategorizationRuleService) Execute(input usecase.TestCategorizationRuleInput) common.Output {
+	// 1. 建立範例交易
+	ruleType, err := model.ParseCategorizationRuleType(input.Type)
+	if err != nil {
+		return usecase.TestCategorizationRuleOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid sample: %v", err),
+		}
+	}
+	amount, err := model.NewMoney(input.Amount, input.Currency)
+	if err != nil {
+		return usecase.TestCategorizationRuleOutput{
+			ExitCode: common.InvalidInput,
+			Message:  fmt.Sprintf("Invalid sample amount: %v", err),
+		}
+	}
+	date := input.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	sample := model.RuleSample{
+		Type:        ruleType,
+		WalletID:    input.WalletID,
+		Description: input.Description,
+		Amount:      *amount,
+		Date:        date,
+	}
+
+	// 2. 依套用順序比對所有規則
+	rules, err := s.repo.FindByUserID(input.UserID)
+	if err != nil {
+		return usecase.TestCategorizationRuleOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to retrieve categorization rules: %v", err),
+		}
+	}
+	matching := model.NewCategorizationRuleSet(rules).MatchAll(sample)
+
+	result := usecase.TestCategorizationRuleOutput{
+		ID:       input.UserID,
+		ExitCode: common.Success,
+		Message:  "No categorization rule matches the sample",
+		Matching: make([]usecase.CategorizationRuleData, len(matching)),
+	}
+	for i, rule := range matching {
+		result.Matching[i] = toCategorizationRuleData(rule)
+	}
+	if len(matching) > 0 {
+		result.Matched = &result.Matching[0]
+		result.Message = fmt.Sprintf("Rule %q matches the sample", matching[0].Name)
+	}
+	return result
+}
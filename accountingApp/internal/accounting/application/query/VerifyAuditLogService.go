@@ -0,0 +1,85 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+const auditVerifyBatchSize = 500
+
+// VerifyAuditLogService 從第一筆開始重算雜湊鏈
+// 內容被修改 (雜湊不符)、紀錄被刪除或插入 (序號或前一筆雜湊不連續) 都會被偵測到
+type VerifyAuditLogService struct {
+	repo repository.AuditLogRepository
+}
+
+func NewVerifyAuditLogService(repo repository.AuditLogRepository) *VerifyAuditLogService {
+	return &VerifyAuditLogService{repo: repo}
+}
+
+func (s *VerifyAuditLogService) Execute(input usecase.VerifyAuditLogInput) common.Output {
+	var checked int64
+	var previous *mapper.AuditEntryData
+
+	for {
+		var afterSequence int64
+		if previous != nil {
+			afterSequence = previous.Sequence
+		}
+		entries, err := s.repo.FindAfterSequence(afterSequence, auditVerifyBatchSize)
+		if err != nil {
+			return usecase.VerifyAuditLogOutput{
+				ExitCode: common.Failure,
+				Message:  fmt.Sprintf("Failed to read audit log: %v", err),
+			}
+		}
+
+		for i := range entries {
+			entry := entries[i]
+			if reason := brokenLink(entry, previous); reason != "" {
+				return usecase.VerifyAuditLogOutput{
+					ExitCode:         common.Success,
+					Message:          fmt.Sprintf("Audit log hash chain is broken at sequence %d: %s", entry.Sequence, reason),
+					Valid:            false,
+					EntriesChecked:   checked,
+					BrokenAtSequence: entry.Sequence,
+				}
+			}
+			checked++
+			previous = &entry
+		}
+
+		if len(entries) < auditVerifyBatchSize {
+			break
+		}
+	}
+
+	return usecase.VerifyAuditLogOutput{
+		ExitCode:       common.Success,
+		Message:        fmt.Sprintf("Audit log hash chain is intact (%d entries)", checked),
+		Valid:          true,
+		EntriesChecked: checked,
+	}
+}
+
+// brokenLink 檢查紀錄是否正確接在previous之後，回傳不符的原因
+func brokenLink(entry mapper.AuditEntryData, previous *mapper.AuditEntryData) string {
+	expectedSequence, expectedPrevHash := int64(1), mapper.AuditGenesisHash
+	if previous != nil {
+		expectedSequence, expectedPrevHash = previous.Sequence+1, previous.Hash
+	}
+
+	switch {
+	case entry.Sequence != expectedSequence:
+		return fmt.Sprintf("expected sequence %d", expectedSequence)
+	case entry.PrevHash != expectedPrevHash:
+		return "previous hash does not match"
+	case entry.Hash != entry.ComputeHash():
+		return "entry hash does not match its content"
+	}
+	return ""
+}
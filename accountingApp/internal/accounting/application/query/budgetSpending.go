@@ -0,0 +1,142 @@
+package query
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// budgetSpending 由支出加總計算預算狀態，供預算狀態查詢與超支警告共用
+type budgetSpending struct {
+	walletRepo   repository.WalletRepository
+	categoryRepo repository.ExpenseCategoryRepository
+	converter    *exchange.Converter // 可為nil：不計入其他幣別的支出
+}
+
+// status 計算預算在t所在期間的狀態：只查詢SpendingRange內的支出加總，
+// 其他幣別的支出以支出當天的匯率換算為預算幣別
+func (s budgetSpending) status(budget *model.Budget, subcategoryIDs map[string]bool, t time.Time) (model.BudgetStatus, error) {
+	window, active := budget.SpendingRange(t)
+	if !active {
+		return model.BudgetStatus{}, nil
+	}
+
+	amounts, err := s.walletRepo.SumExpensesByUserID(budget.UserID, window.Start, window.End)
+	if err != nil {
+		return model.BudgetStatus{}, fmt.Errorf("failed to sum expenses: %w", err)
+	}
+
+	covered := make([]model.SpentAmount, 0, len(amounts))
+	for _, amount := range amounts {
+		if !budget.Covers(amount.SubcategoryID, subcategoryIDs) {
+			continue
+		}
+		converted, ok, err := s.convert(amount.Amount, budget.Amount.Currency, amount.Date)
+		if err != nil {
+			return model.BudgetStatus{}, err
+		}
+		if ok {
+			amount.Amount = converted
+			covered = append(covered, amount)
+		}
+	}
+
+	status, _ := budget.StatusFromSpending(t, covered)
+	return status, nil
+}
+
+// convert 將金額換算為預算幣別；沒有匯率換算服務時不計入其他幣別 (回傳false)
+func (s budgetSpending) convert(amount model.Money, currency string, date time.Time) (model.Money, bool, error) {
+	if amount.Currency == currency {
+		return amount, true, nil
+	}
+	if s.converter == nil {
+		return model.Money{}, false, nil
+	}
+
+	conversion, err := s.converter.Convert(amount, currency, date)
+	if err != nil {
+		return model.Money{}, false, err
+	}
+	return conversion.Converted, true, nil
+}
+
+// categorySubcategories 分類範圍的預算回傳該分類目前的所有子分類ID；子分類範圍回傳nil
+// 已刪除的子分類不再計入
+func (s budgetSpending) categorySubcategories(budget *model.Budget) (map[string]bool, error) {
+	if budget.Scope.CategoryID == "" {
+		return nil, nil
+	}
+
+	category, err := s.categoryRepo.FindByID(budget.Scope.CategoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find category: %w", err)
+	}
+	subcategoryIDs := make(map[string]bool)
+	if category != nil {
+		for _, subcategory := range category.Subcategories {
+			subcategoryIDs[subcategory.ID] = true
+		}
+	}
+	return subcategoryIDs, nil
+}
+
+// coveredAmount 新支出中落在預算範圍內的金額；拆帳支出只計入範圍內的明細
+func coveredAmount(budget *model.Budget, subcategoryIDs map[string]bool, input usecase.CheckBudgetWarningsInput) int64 {
+	if len(input.Splits) == 0 {
+		if budget.Covers(input.SubcategoryID, subcategoryIDs) {
+			return input.Amount
+		}
+		return 0
+	}
+
+	var amount int64
+	for _, split := range input.Splits {
+		if budget.Covers(split.SubcategoryID, subcategoryIDs) {
+			amount += split.Amount
+		}
+	}
+	return amount
+}
+
+func toBudgetData(budget *model.Budget) usecase.BudgetData {
+	data := usecase.BudgetData{
+		ID:               budget.ID,
+		Name:             budget.Name,
+		Period:           string(budget.Period),
+		CategoryID:       budget.Scope.CategoryID,
+		SubcategoryID:    budget.Scope.SubcategoryID,
+		Amount:           budget.Amount.Amount,
+		Currency:         budget.Amount.Currency,
+		Rollover:         budget.Rollover,
+		WarningThreshold: budget.WarningThreshold,
+		StartDate:        budget.StartDate.Format("2006-01-02"),
+		CreatedAt:        budget.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        budget.UpdatedAt.Format(time.RFC3339),
+	}
+	if budget.EndDate != nil {
+		data.EndDate = budget.EndDate.Format("2006-01-02")
+	}
+	return data
+}
+
+func toBudgetStatusData(budget *model.Budget, status model.BudgetStatus) usecase.BudgetStatusData {
+	return usecase.BudgetStatusData{
+		BudgetID:    budget.ID,
+		PeriodStart: status.Window.Start.Format("2006-01-02"),
+		PeriodEnd:   status.Window.End.Format("2006-01-02"),
+		Currency:    status.Currency,
+		Budgeted:    status.Budgeted,
+		Carryover:   status.Carryover,
+		Available:   status.Available,
+		Spent:       status.Spent,
+		Remaining:   status.Remaining,
+		PercentUsed: status.PercentUsed(),
+		Warning:     status.ReachedThreshold(budget.WarningThreshold),
+		OverBudget:  status.IsOverBudget(),
+	}
+}
@@ -0,0 +1,83 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// reportingConversion 以同一天的匯率將報表中各幣別的金額換算為報表幣別，每個幣別只查詢一次匯率
+type reportingConversion struct {
+	converter *exchange.Converter
+	currency  string
+	date      time.Time
+	rates     map[string]*model.ExchangeRate
+}
+
+// newReportingConversion 檢查報表幣別
+func newReportingConversion(converter *exchange.Converter, currency string, date time.Time) (*reportingConversion, error) {
+	code, err := model.NormalizeCurrencyCode(currency)
+	if err != nil {
+		return nil, err
+	}
+	if converter == nil {
+		return nil, errors.New("currency conversion is not available")
+	}
+	return &reportingConversion{
+		converter: converter,
+		currency:  code,
+		date:      date,
+		rates:     make(map[string]*model.ExchangeRate),
+	}, nil
+}
+
+// convert 換算金額，沒有匯率時回傳RateNotFoundError
+func (c *reportingConversion) convert(money model.Money) (usecase.ConvertedMoneyData, error) {
+	if money.Currency == c.currency {
+		return usecase.ConvertedMoneyData{Amount: money.Amount, Currency: c.currency}, nil
+	}
+
+	rate, ok := c.rates[money.Currency]
+	if !ok {
+		var err error
+		rate, err = c.converter.Rate(money.Currency, c.currency, c.date)
+		if err != nil {
+			return usecase.ConvertedMoneyData{}, err
+		}
+		c.rates[money.Currency] = rate
+	}
+
+	converted, err := rate.Convert(money, c.converter.Rounding())
+	if err != nil {
+		return usecase.ConvertedMoneyData{}, err
+	}
+	return usecase.ConvertedMoneyData{
+		Amount:   converted.Amount,
+		Currency: converted.Currency,
+		Rate:     rate.RateString(),
+		RateDate: rate.Date.Format("2006-01-02"),
+	}, nil
+}
+
+// conversionFailureExitCode 沒有匯率視為報表幣別無效 (InvalidInput)，其他錯誤為查詢失敗
+func conversionFailureExitCode(err error) common.ExitCode {
+	var notFound *exchange.RateNotFoundError
+	if errors.As(err, &notFound) {
+		return common.InvalidInput
+	}
+	return common.Failure
+}
+
+// conversionFailureMessage 換算失敗的訊息，與conversionFailureExitCode對應
+func conversionFailureMessage(err error) string {
+	var notFound *exchange.RateNotFoundError
+	if errors.As(err, &notFound) {
+		return fmt.Sprintf("Invalid reporting currency: %v", err)
+	}
+	return fmt.Sprintf("Failed to convert to the reporting currency: %v", err)
+}
@@ -0,0 +1,27 @@
+package query
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// tagFilter 交易查詢的標籤篩選條件；沒有標籤時不篩選
+type tagFilter struct {
+	tags  []string
+	match model.TagMatch
+}
+
+func newTagFilter(tags []string, match string) (tagFilter, error) {
+	normalized, err := model.NormalizeTags(tags)
+	if err != nil {
+		return tagFilter{}, err
+	}
+	tagMatch, err := model.ParseTagMatch(match)
+	if err != nil {
+		return tagFilter{}, err
+	}
+	return tagFilter{tags: normalized, match: tagMatch}, nil
+}
+
+func (f tagFilter) matches(tags []string) bool {
+	return f.match.Matches(tags, f.tags)
+}
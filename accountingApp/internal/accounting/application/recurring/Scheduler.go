@@ -0,0 +1,186 @@
+package recurring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// maxRecordRetries 記錄結果遇到樂觀鎖衝突時最多重新載入的次數
+const maxRecordRetries = 3
+
+// SchedulerConfig 排程器的輪詢與重試設定
+type SchedulerConfig struct {
+	PollInterval time.Duration // 檢查到期發生日的間隔
+	MaxAttempts  int           // 產生交易失敗時最多嘗試的次數，之後保持FAILED
+	ClaimLease   time.Duration // 認領後超過此時間仍未記錄結果時，視為排程器中止而再次處理
+}
+
+// DefaultSchedulerConfig 回傳預設設定
+func DefaultSchedulerConfig() SchedulerConfig {
+	return SchedulerConfig{
+		PollInterval: time.Minute,
+		MaxAttempts:  3,
+		ClaimLease:   10 * time.Minute,
+	}
+}
+
+// Scheduler 背景將週期規則到期的發生日產生為支出或收入
+// 交易透過AddExpense/AddIncome Use Case建立，與使用者手動新增的交易走相同的驗證、稽核與事件流程。
+// 每個發生日先認領並儲存 (樂觀鎖) 才產生交易，因此多個排程器不會同時處理同一天；
+// 認領後中止的發生日在ClaimLease過後再次處理，交易帶有規則的OccurrenceKey，
+// 已產生過的交易會被找回而不會重複新增 (恰好一次)
+type Scheduler struct {
+	repo       repository.RecurringRuleRepository
+	addExpense usecase.AddExpenseUseCase
+	addIncome  usecase.AddIncomeUseCase
+	config     SchedulerConfig
+}
+
+// NewScheduler 創建週期規則排程器
+func NewScheduler(
+	repo repository.RecurringRuleRepository,
+	addExpense usecase.AddExpenseUseCase,
+	addIncome usecase.AddIncomeUseCase,
+	config SchedulerConfig,
+) *Scheduler {
+	return &Scheduler{
+		repo:       repo,
+		addExpense: addExpense,
+		addIncome:  addIncome,
+		config:     config,
+	}
+}
+
+// RunOnce 處理所有啟用中規則到now為止的到期發生日，回傳嘗試產生的交易數
+// 單一規則的錯誤只寫入log，不影響其他規則
+func (s *Scheduler) RunOnce(now time.Time) (int, error) {
+	rules, err := s.repo.FindActive()
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, rule := range rules {
+		count, err := s.processRule(rule, now)
+		processed += count
+		if err != nil {
+			log.Printf("recurring scheduler: rule %s: %v", rule.ID, err)
+		}
+	}
+	return processed, nil
+}
+
+// Run 每PollInterval處理一次到期發生日，直到ctx結束
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunOnce(time.Now()); err != nil {
+			log.Printf("recurring scheduler: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// processRule 依日期順序產生規則的到期發生日
+func (s *Scheduler) processRule(rule *model.RecurringRule, now time.Time) (int, error) {
+	processed := 0
+	for _, date := range rule.Due(now, s.config.MaxAttempts, s.config.ClaimLease) {
+		// 1. 認領並儲存；衝突代表規則已被其他排程器或使用者修改，留待下次輪詢
+		if err := rule.Claim(date, now, s.config.ClaimLease); err != nil {
+			return processed, err
+		}
+		if err := s.repo.Save(rule); err != nil {
+			if errors.Is(err, repository.ErrConcurrencyConflict) {
+				return processed, nil
+			}
+			return processed, fmt.Errorf("failed to claim %s: %w", date.Format("2006-01-02"), err)
+		}
+
+		// 2. 產生交易並記錄結果
+		output := s.generate(rule, date)
+		processed++
+		reloaded, err := s.recordOutcome(rule, date, output, now)
+		if err != nil {
+			return processed, fmt.Errorf("failed to record occurrence %s: %w", date.Format("2006-01-02"), err)
+		}
+		if reloaded {
+			// 規則在產生交易期間被修改 (例如暫停)，其餘發生日留待下次輪詢依最新狀態處理
+			return processed, nil
+		}
+	}
+	return processed, nil
+}
+
+// recordOutcome 記錄產生交易的結果；儲存衝突時重新載入規則並再次記錄
+// 回傳reloaded表示rule已不是最新版本
+func (s *Scheduler) recordOutcome(rule *model.RecurringRule, date time.Time, output common.Output, now time.Time) (reloaded bool, err error) {
+	for attempt := 0; ; attempt++ {
+		if output.GetExitCode() == common.Success {
+			rule.MarkGenerated(date, output.GetID(), now)
+		} else {
+			rule.MarkFailed(date, output.GetMessage(), now)
+		}
+
+		err = s.repo.Save(rule)
+		if err == nil || !errors.Is(err, repository.ErrConcurrencyConflict) || attempt >= maxRecordRetries {
+			return reloaded, err
+		}
+
+		if rule, err = s.repo.FindByID(rule.ID); err != nil || rule == nil {
+			return true, err // 規則已被刪除時不需要再記錄
+		}
+		reloaded = true
+	}
+}
+
+// generate 以規則擁有者的身分新增支出或收入；發生日已有交易時回傳該交易
+func (s *Scheduler) generate(rule *model.RecurringRule, date time.Time) common.Output {
+	metadata := usecase.CommandMetadata{
+		ActorID:   rule.UserID,
+		RequestID: "recurring:" + rule.OccurrenceKey(date),
+	}
+	description := rule.Description
+	if description == "" {
+		description = rule.Name
+	}
+
+	if rule.Type == model.RecurringIncome {
+		return s.addIncome.Execute(usecase.AddIncomeInput{
+			CommandMetadata: metadata,
+			UserID:          rule.UserID,
+			WalletID:        rule.WalletID,
+			SubcategoryID:   rule.SubcategoryID,
+			Amount:          rule.Amount.Amount,
+			Currency:        rule.Amount.Currency,
+			Description:     description,
+			Date:            date,
+			OccurrenceKey:   rule.OccurrenceKey(date),
+		})
+	}
+	return s.addExpense.Execute(usecase.AddExpenseInput{
+		CommandMetadata: metadata,
+		UserID:          rule.UserID,
+		WalletID:        rule.WalletID,
+		SubcategoryID:   rule.SubcategoryID,
+		Amount:          rule.Amount.Amount,
+		Currency:        rule.Amount.Currency,
+		Description:     description,
+		Date:            date,
+		OccurrenceKey:   rule.OccurrenceKey(date),
+	})
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// APIKeyRepositoryImpl API金鑰倉庫實作
+type APIKeyRepositoryImpl struct {
+	peer   APIKeyRepositoryPeer
+	mapper *mapper.APIKeyMapper
+}
+
+// NewAPIKeyRepositoryImpl 建立新的API金鑰倉庫實作
+func NewAPIKeyRepositoryImpl(peer APIKeyRepositoryPeer) APIKeyRepository {
+	return &APIKeyRepositoryImpl{
+		peer:   peer,
+		mapper: mapper.NewAPIKeyMapper(),
+	}
+}
+
+// Save 儲存API金鑰聚合
+func (r *APIKeyRepositoryImpl) Save(key *model.APIKey) error {
+	if key == nil {
+		return fmt.Errorf("API key cannot be nil")
+	}
+
+	return r.peer.SaveData(r.mapper.ToData(key))
+}
+
+// FindByID 根據ID查找API金鑰聚合
+func (r *APIKeyRepositoryImpl) FindByID(id string) (*model.APIKey, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+
+	data, err := r.peer.FindDataByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find API key by ID: %w", err)
+	}
+	if data == nil {
+		return nil, nil // Not found
+	}
+
+	return r.mapper.ToDomain(*data)
+}
+
+// FindByKeyHash 根據金鑰雜湊查找API金鑰聚合
+func (r *APIKeyRepositoryImpl) FindByKeyHash(keyHash string) (*model.APIKey, error) {
+	if keyHash == "" {
+		return nil, fmt.Errorf("key hash cannot be empty")
+	}
+
+	data, err := r.peer.FindDataByKeyHash(keyHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find API key by hash: %w", err)
+	}
+	if data == nil {
+		return nil, nil // Not found
+	}
+
+	return r.mapper.ToDomain(*data)
+}
+
+// FindByUserID 根據用戶ID查找用戶的所有API金鑰聚合
+func (r *APIKeyRepositoryImpl) FindByUserID(userID string) ([]*model.APIKey, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	dataList, err := r.peer.FindDataByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find API keys by user ID: %w", err)
+	}
+
+	keys := make([]*model.APIKey, 0, len(dataList))
+	for _, data := range dataList {
+		key, err := r.mapper.ToDomain(data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
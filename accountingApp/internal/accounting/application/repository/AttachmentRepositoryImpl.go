@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// AttachmentRepositoryImpl 附件倉庫實作
+type AttachmentRepositoryImpl struct {
+	peer   AttachmentRepositoryPeer
+	mapper *mapper.AttachmentMapper
+}
+
+// NewAttachmentRepositoryImpl 建立新的附件倉庫實作
+func NewAttachmentRepositoryImpl(peer AttachmentRepositoryPeer) AttachmentRepository {
+	return &AttachmentRepositoryImpl{
+		peer:   peer,
+		mapper: mapper.NewAttachmentMapper(),
+	}
+}
+
+// Save 儲存附件聚合
+func (r *AttachmentRepositoryImpl) Save(attachment *model.Attachment) error {
+	if attachment == nil {
+		return fmt.Errorf("attachment cannot be nil")
+	}
+
+	return r.peer.SaveData(r.mapper.ToData(attachment))
+}
+
+// FindByID 根據ID查找附件聚合
+func (r *AttachmentRepositoryImpl) FindByID(id string) (*model.Attachment, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+
+	data, err := r.peer.FindDataByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find attachment by ID: %w", err)
+	}
+	if data == nil {
+		return nil, nil // Not found
+	}
+
+	return r.mapper.ToDomain(*data)
+}
+
+// FindByRecord 根據交易記錄查找其所有附件聚合
+func (r *AttachmentRepositoryImpl) FindByRecord(recordType model.AttachmentRecordType, recordID string) ([]*model.Attachment, error) {
+	if recordID == "" {
+		return nil, fmt.Errorf("record ID cannot be empty")
+	}
+
+	dataList, err := r.peer.FindDataByRecord(string(recordType), recordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find attachments by record: %w", err)
+	}
+
+	return r.toDomainList(dataList)
+}
+
+// FindByWalletID 根據錢包ID查找其記錄的所有附件聚合
+func (r *AttachmentRepositoryImpl) FindByWalletID(walletID string) ([]*model.Attachment, error) {
+	if walletID == "" {
+		return nil, fmt.Errorf("wallet ID cannot be empty")
+	}
+
+	dataList, err := r.peer.FindDataByWalletID(walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find attachments by wallet ID: %w", err)
+	}
+
+	return r.toDomainList(dataList)
+}
+
+// Delete 根據ID刪除附件聚合
+func (r *AttachmentRepositoryImpl) Delete(id string) error {
+	if id == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+
+	return r.peer.DeleteData(id)
+}
+
+func (r *AttachmentRepositoryImpl) toDomainList(dataList []mapper.AttachmentData) ([]*model.Attachment, error) {
+	attachments := make([]*model.Attachment, 0, len(dataList))
+	for _, data := range dataList {
+		attachment, err := r.mapper.ToDomain(data)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
@@ -0,0 +1,16 @@
+package repository
+
+import "io"
+
+// BlobStore 附件檔案內容的儲存介面，實作可為本機檔案系統或物件儲存
+// 鍵由應用層產生，只包含英數字、'-' 與 '/'
+type BlobStore interface {
+	// Put 寫入檔案內容，已存在時覆蓋
+	Put(key string, content io.Reader) error
+
+	// Get 讀取檔案內容，不存在時回傳 ErrBlobNotFound；呼叫端負責關閉
+	Get(key string) (io.ReadCloser, error)
+
+	// Delete 刪除檔案，不存在時視為成功
+	Delete(key string) error
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// BudgetRepositoryImpl 預算倉庫實作
+type BudgetRepositoryImpl struct {
+	peer   BudgetRepositoryPeer
+	mapper *mapper.BudgetMapper
+}
+
+// NewBudgetRepositoryImpl 建立新的預算倉庫實作
+func NewBudgetRepositoryImpl(peer BudgetRepositoryPeer) BudgetRepository {
+	return &BudgetRepositoryImpl{
+		peer:   peer,
+		mapper: mapper.NewBudgetMapper(),
+	}
+}
+
+// Save 儲存預算聚合
+func (r *BudgetRepositoryImpl) Save(budget *model.Budget) error {
+	if budget == nil {
+		return fmt.Errorf("budget cannot be nil")
+	}
+
+	return r.peer.SaveData(r.mapper.ToData(budget))
+}
+
+// FindByID 根據ID查找預算聚合
+func (r *BudgetRepositoryImpl) FindByID(id string) (*model.Budget, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+
+	data, err := r.peer.FindDataByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find budget by ID: %w", err)
+	}
+	if data == nil {
+		return nil, nil // Not found
+	}
+
+	return r.mapper.ToDomain(*data)
+}
+
+// FindByUserID 根據用戶ID查找用戶的所有預算聚合
+func (r *BudgetRepositoryImpl) FindByUserID(userID string) ([]*model.Budget, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	dataList, err := r.peer.FindDataByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find budgets by user ID: %w", err)
+	}
+
+	budgets := make([]*model.Budget, 0, len(dataList))
+	for _, data := range dataList {
+		budget, err := r.mapper.ToDomain(data)
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, budget)
+	}
+
+	return budgets, nil
+}
+
+// Delete 根據ID刪除預算聚合
+func (r *BudgetRepositoryImpl) Delete(id string) error {
+	if id == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+
+	return r.peer.DeleteData(id)
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// CategorizationRuleRepositoryImpl 分類規則倉庫實作
+type CategorizationRuleRepositoryImpl struct {
+	peer   CategorizationRuleRepositoryPeer
+	mapper *mapper.CategorizationRuleMapper
+}
+
+// NewCategorizationRuleRepositoryImpl 建立新的分類規則倉庫實作
+func NewCategorizationRuleRepositoryImpl(peer CategorizationRuleRepositoryPeer) CategorizationRuleRepository {
+	return &CategorizationRuleRepositoryImpl{
+		peer:   peer,
+		mapper: mapper.NewCategorizationRuleMapper(),
+	}
+}
+
+// Save 儲存分類規則聚合
+func (r *CategorizationRuleRepositoryImpl) Save(rule *model.CategorizationRule) error {
+	if rule == nil {
+		return fmt.Errorf("categorization rule cannot be nil")
+	}
+
+	return r.peer.SaveData(r.mapper.ToData(rule))
+}
+
+// FindByID 根據ID查找分類規則聚合
+func (r *CategorizationRuleRepositoryImpl) FindByID(id string) (*model.CategorizationRule, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+
+	data, err := r.peer.FindDataByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find categorization rule by ID: %w", err)
+	}
+	if data == nil {
+		return nil, nil // Not found
+	}
+
+	return r.mapper.ToDomain(*data)
+}
+
+// FindByUserID 根據用戶ID查找用戶的所有分類規則聚合
+func (r *CategorizationRuleRepositoryImpl) FindByUserID(userID string) ([]*model.CategorizationRule, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	dataList, err := r.peer.FindDataByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find categorization rules by user ID: %w", err)
+	}
+
+	rules := make([]*model.CategorizationRule, 0, len(dataList))
+	for _, data := range dataList {
+		rule, err := r.mapper.ToDomain(data)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// Delete 根據ID刪除分類規則聚合
+func (r *CategorizationRuleRepositoryImpl) Delete(id string) error {
+	if id == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+
+	return r.peer.DeleteData(id)
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// DuplicateFlagRepositoryImpl 重複標記倉庫實作
+type DuplicateFlagRepositoryImpl struct {
+	peer   DuplicateFlagRepositoryPeer
+	mapper *mapper.DuplicateFlagMapper
+}
+
+// NewDuplicateFlagRepositoryImpl 建立新的重複標記倉庫實作
+func NewDuplicateFlagRepositoryImpl(peer DuplicateFlagRepositoryPeer) DuplicateFlagRepository {
+	return &DuplicateFlagRepositoryImpl{
+		peer:   peer,
+		mapper: mapper.NewDuplicateFlagMapper(),
+	}
+}
+
+// Save 儲存重複標記聚合
+func (r *DuplicateFlagRepositoryImpl) Save(flag *model.DuplicateFlag) error {
+	if flag == nil {
+		return fmt.Errorf("duplicate flag cannot be nil")
+	}
+
+	return r.peer.SaveData(r.mapper.ToData(flag))
+}
+
+// FindByID 根據ID查找重複標記聚合
+func (r *DuplicateFlagRepositoryImpl) FindByID(id string) (*model.DuplicateFlag, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+
+	data, err := r.peer.FindDataByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate flag by ID: %w", err)
+	}
+	if data == nil {
+		return nil, nil // Not found
+	}
+
+	return r.mapper.ToDomain(*data)
+}
+
+// FindByUserID 根據用戶ID查找用戶指定狀態的重複標記聚合
+func (r *DuplicateFlagRepositoryImpl) FindByUserID(userID string, status model.DuplicateStatus) ([]*model.DuplicateFlag, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	dataList, err := r.peer.FindDataByUserID(userID, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate flags by user ID: %w", err)
+	}
+
+	flags := make([]*model.DuplicateFlag, 0, len(dataList))
+	for _, data := range dataList {
+		flag, err := r.mapper.ToDomain(data)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, flag)
+	}
+
+	return flags, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// ExchangeRateRepositoryImpl 匯率倉庫實作
+type ExchangeRateRepositoryImpl struct {
+	peer   ExchangeRateRepositoryPeer
+	mapper *mapper.ExchangeRateMapper
+}
+
+// NewExchangeRateRepositoryImpl 建立新的匯率倉庫實作
+func NewExchangeRateRepositoryImpl(peer ExchangeRateRepositoryPeer) ExchangeRateRepository {
+	return &ExchangeRateRepositoryImpl{
+		peer:   peer,
+		mapper: mapper.NewExchangeRateMapper(),
+	}
+}
+
+// SaveAll 儲存一批匯率
+func (r *ExchangeRateRepositoryImpl) SaveAll(rates []*model.ExchangeRate) error {
+	dataList := make([]mapper.ExchangeRateData, 0, len(rates))
+	for _, rate := range rates {
+		if rate == nil {
+			return fmt.Errorf("exchange rate cannot be nil")
+		}
+		dataList = append(dataList, r.mapper.ToData(rate))
+	}
+	if len(dataList) == 0 {
+		return nil
+	}
+
+	return r.peer.SaveAllData(dataList)
+}
+
+// FindLatest 查找指定日期 (含) 之前最近的匯率
+func (r *ExchangeRateRepositoryImpl) FindLatest(base, quote string, onOrBefore time.Time) (*model.ExchangeRate, error) {
+	data, err := r.peer.FindDataLatest(base, quote, onOrBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find exchange rate %s/%s: %w", base, quote, err)
+	}
+	if data == nil {
+		return nil, nil // Not found
+	}
+
+	return r.mapper.ToDomain(*data)
+}
+
+// FindByPair 依日期由新到舊列出一組貨幣的所有匯率
+func (r *ExchangeRateRepositoryImpl) FindByPair(base, quote string) ([]*model.ExchangeRate, error) {
+	dataList, err := r.peer.FindDataByPair(base, quote)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find exchange rates %s/%s: %w", base, quote, err)
+	}
+
+	return r.toDomainList(dataList)
+}
+
+// FindLatestPerPair 每組貨幣在指定日期 (含) 之前最近的一筆匯率
+func (r *ExchangeRateRepositoryImpl) FindLatestPerPair(onOrBefore time.Time) ([]*model.ExchangeRate, error) {
+	dataList, err := r.peer.FindDataLatestPerPair(onOrBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find latest exchange rates: %w", err)
+	}
+
+	return r.toDomainList(dataList)
+}
+
+func (r *ExchangeRateRepositoryImpl) toDomainList(dataList []mapper.ExchangeRateData) ([]*model.ExchangeRate, error) {
+	rates := make([]*model.ExchangeRate, 0, len(dataList))
+	for _, data := range dataList {
+		rate, err := r.mapper.ToDomain(data)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/event"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// ExpenseCategoryRepositoryImpl 支出分類倉庫實作
+type ExpenseCategoryRepositoryImpl struct {
+	peer      ExpenseCategoryRepositoryPeer
+	mapper    *mapper.ExpenseCategoryMapper
+	publisher event.Publisher // 儲存成功後發布領域事件，nil表示不發布
+}
+
+// NewExpenseCategoryRepositoryImpl 建立新的支出分類倉庫實作
+func NewExpenseCategoryRepositoryImpl(peer ExpenseCategoryRepositoryPeer, publisher event.Publisher) ExpenseCategoryRepository {
+	return &ExpenseCategoryRepositoryImpl{
+		peer:      peer,
+		mapper:    mapper.NewExpenseCategoryMapper(),
+		publisher: publisher,
+	}
+}
+
+// Save 儲存支出分類聚合
+func (r *ExpenseCategoryRepositoryImpl) Save(category *model.ExpenseCategory) error {
+	if category == nil {
+		return fmt.Errorf("category cannot be nil")
+	}
+
+	// 轉換Domain Model為Data Model
+	data := r.mapper.ToData(category)
+
+	// 透過Peer儲存資料
+	if err := r.peer.SaveData(data); err != nil {
+		return err
+	}
+
+	// 儲存成功後才發布領域事件，並清除避免重複發布
+	events := category.DomainEvents()
+	category.ClearDomainEvents()
+	if r.publisher != nil && len(events) > 0 {
+		r.publisher.Publish(events)
+	}
+	return nil
+}
+
+// FindByID 根據ID查找支出分類聚合
+func (r *ExpenseCategoryRepositoryImpl) FindByID(id string) (*model.ExpenseCategory, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+
+	// 透過Peer查找資料
+	data, err := r.peer.FindDataByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expense category by ID: %w", err)
+	}
+	if data == nil {
+		return nil, nil // Not found
+	}
+
+	// 轉換Data Model為Domain Model
+	return r.mapper.ToDomain(*data)
+}
+
+// Delete 根據ID刪除支出分類聚合
+func (r *ExpenseCategoryRepositoryImpl) Delete(id string) error {
+	if id == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+
+	return r.peer.DeleteData(id)
+}
+
+// FindBySubcategoryID 根據子分類ID查找包含它的支出分類聚合
+func (r *ExpenseCategoryRepositoryImpl) FindBySubcategoryID(subcategoryID string) (*model.ExpenseCategory, error) {
+	if subcategoryID == "" {
+		return nil, fmt.Errorf("subcategory ID cannot be empty")
+	}
+
+	// 透過Peer查找包含該子分類的分類資料
+	data, err := r.peer.FindDataBySubcategoryID(subcategoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expense category by subcategory ID: %w", err)
+	}
+	if data == nil {
+		return nil, nil // Not found
+	}
+
+	// 轉換Data Model為Domain Model
+	return r.mapper.ToDomain(*data)
+}
+
+// FindByUserID 根據用戶ID查找用戶的所有支出分類聚合
+func (r *ExpenseCategoryRepositoryImpl) FindByUserID(userID string) ([]*model.ExpenseCategory, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	dataList, err := r.peer.FindDataByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expense categories by user ID: %w", err)
+	}
+
+	categories := make([]*model.ExpenseCategory, 0, len(dataList))
+	for _, data := range dataList {
+		category, err := r.mapper.ToDomain(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map expense category %s: %w", data.ID, err)
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
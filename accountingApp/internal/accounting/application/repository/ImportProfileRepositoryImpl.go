@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// ImportProfileRepositoryImpl 匯入設定倉庫實作
+type ImportProfileRepositoryImpl struct {
+	peer   ImportProfileRepositoryPeer
+	mapper *mapper.ImportProfileMapper
+}
+
+// NewImportProfileRepositoryImpl 建立新的匯入設定倉庫實作
+func NewImportProfileRepositoryImpl(peer ImportProfileRepositoryPeer) ImportProfileRepository {
+	return &ImportProfileRepositoryImpl{
+		peer:   peer,
+		mapper: mapper.NewImportProfileMapper(),
+	}
+}
+
+// Save 儲存匯入設定聚合
+func (r *ImportProfileRepositoryImpl) Save(profile *model.ImportProfile) error {
+	if profile == nil {
+		return fmt.Errorf("import profile cannot be nil")
+	}
+
+	return r.peer.SaveData(r.mapper.ToData(profile))
+}
+
+// FindByID 根據ID查找匯入設定聚合
+func (r *ImportProfileRepositoryImpl) FindByID(id string) (*model.ImportProfile, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+
+	data, err := r.peer.FindDataByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find import profile by ID: %w", err)
+	}
+	if data == nil {
+		return nil, nil // Not found
+	}
+
+	return r.mapper.ToDomain(*data)
+}
+
+// FindByUserID 根據用戶ID查找用戶的所有匯入設定聚合
+func (r *ImportProfileRepositoryImpl) FindByUserID(userID string) ([]*model.ImportProfile, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	dataList, err := r.peer.FindDataByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find import profiles by user ID: %w", err)
+	}
+
+	profiles := make([]*model.ImportProfile, 0, len(dataList))
+	for _, data := range dataList {
+		profile, err := r.mapper.ToDomain(data)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+// Delete 根據ID刪除匯入設定聚合
+func (r *ImportProfileRepositoryImpl) Delete(id string) error {
+	if id == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+
+	return r.peer.DeleteData(id)
+}
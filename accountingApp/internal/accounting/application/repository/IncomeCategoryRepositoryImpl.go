@@ -2,21 +2,24 @@ package repository
 
 import (
 	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/event"
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
 	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
 )
 
 // IncomeCategoryRepositoryImpl 收入分類倉庫實作
 type IncomeCategoryRepositoryImpl struct {
-	peer   IncomeCategoryRepositoryPeer
-	mapper *mapper.IncomeCategoryMapper
+	peer      IncomeCategoryRepositoryPeer
+	mapper    *mapper.IncomeCategoryMapper
+	publisher event.Publisher // 儲存成功後發布領域事件，nil表示不發布
 }
 
 // NewIncomeCategoryRepositoryImpl 建立新的收入分類倉庫實作
-func NewIncomeCategoryRepositoryImpl(peer IncomeCategoryRepositoryPeer) IncomeCategoryRepository {
+func NewIncomeCategoryRepositoryImpl(peer IncomeCategoryRepositoryPeer, publisher event.Publisher) IncomeCategoryRepository {
 	return &IncomeCategoryRepositoryImpl{
-		peer:   peer,
-		mapper: mapper.NewIncomeCategoryMapper(),
+		peer:      peer,
+		mapper:    mapper.NewIncomeCategoryMapper(),
+		publisher: publisher,
 	}
 }
 
@@ -30,7 +33,17 @@ func (r *IncomeCategoryRepositoryImpl) Save(category *model.IncomeCategory) erro
 	data := r.mapper.ToData(category)
 	
 	// 透過Peer儲存資料
-	return r.peer.SaveData(data)
+	if err := r.peer.SaveData(data); err != nil {
+		return err
+	}
+
+	// 儲存成功後才發布領域事件，並清除避免重複發布
+	events := category.DomainEvents()
+	category.ClearDomainEvents()
+	if r.publisher != nil && len(events) > 0 {
+		r.publisher.Publish(events)
+	}
+	return nil
 }
 
 // FindByID 根據ID查找收入分類聚合
@@ -86,31 +99,19 @@ func (r *IncomeCategoryRepositoryImpl) FindByUserID(userID string) ([]*model.Inc
 		return nil, fmt.Errorf("user ID cannot be empty")
 	}
 
-	// 臨時實現：由於Peer層尚未支持FindDataByUserID，先返回預設分類
-	// 根據default_categories.sql，預設收入分類的ID範圍是 default-income-1 到 default-income-4
-	defaultCategoryIDs := []string{
-		"default-income-1", // 薪資
-		"default-income-2", // 投資  
-		"default-income-3", // 副業
-		"default-income-4", // 其他收入
+	dataList, err := r.peer.FindDataByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find income categories by user ID: %w", err)
 	}
 
-	var categories []*model.IncomeCategory
-	for _, categoryID := range defaultCategoryIDs {
-		data, err := r.peer.FindDataByID(categoryID)
+	categories := make([]*model.IncomeCategory, 0, len(dataList))
+	for _, data := range dataList {
+		category, err := r.mapper.ToDomain(data)
 		if err != nil {
-			// 如果單個分類找不到，記錄但繼續處理其他分類
-			continue
-		}
-		if data != nil {
-			category, err := r.mapper.ToDomain(*data)
-			if err != nil {
-				// 轉換失敗，記錄但繼續處理其他分類
-				continue
-			}
-			categories = append(categories, category)
+			return nil, fmt.Errorf("failed to map income category %s: %w", data.ID, err)
 		}
+		categories = append(categories, category)
 	}
 
 	return categories, nil
-}
\ No newline at end of file
+}
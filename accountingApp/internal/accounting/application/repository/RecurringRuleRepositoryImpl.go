@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// RecurringRuleRepositoryImpl 週期規則倉庫實作
+type RecurringRuleRepositoryImpl struct {
+	peer   RecurringRuleRepositoryPeer
+	mapper *mapper.RecurringRuleMapper
+}
+
+// NewRecurringRuleRepositoryImpl 建立新的週期規則倉庫實作
+func NewRecurringRuleRepositoryImpl(peer RecurringRuleRepositoryPeer) RecurringRuleRepository {
+	return &RecurringRuleRepositoryImpl{
+		peer:   peer,
+		mapper: mapper.NewRecurringRuleMapper(),
+	}
+}
+
+// Save 儲存週期規則聚合，成功後同步版本號讓同一個實例可以再次儲存
+func (r *RecurringRuleRepositoryImpl) Save(rule *model.RecurringRule) error {
+	if rule == nil {
+		return fmt.Errorf("recurring rule cannot be nil")
+	}
+
+	data := r.mapper.ToData(rule)
+	if err := r.peer.SaveData(data); err != nil {
+		return err
+	}
+
+	rule.Version = data.Version + 1
+	return nil
+}
+
+// FindByID 根據ID查找週期規則聚合
+func (r *RecurringRuleRepositoryImpl) FindByID(id string) (*model.RecurringRule, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+
+	data, err := r.peer.FindDataByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recurring rule by ID: %w", err)
+	}
+	if data == nil {
+		return nil, nil // Not found
+	}
+
+	return r.mapper.ToDomain(*data)
+}
+
+// FindByUserID 根據用戶ID查找用戶的所有週期規則聚合
+func (r *RecurringRuleRepositoryImpl) FindByUserID(userID string) ([]*model.RecurringRule, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	dataList, err := r.peer.FindDataByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recurring rules by user ID: %w", err)
+	}
+
+	return r.toDomainList(dataList)
+}
+
+// FindActive 查找所有啟用中的週期規則聚合
+func (r *RecurringRuleRepositoryImpl) FindActive() ([]*model.RecurringRule, error) {
+	dataList, err := r.peer.FindDataByStatus(string(model.RecurringRuleActive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active recurring rules: %w", err)
+	}
+
+	return r.toDomainList(dataList)
+}
+
+// Delete 根據ID刪除週期規則聚合
+func (r *RecurringRuleRepositoryImpl) Delete(id string) error {
+	if id == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+
+	return r.peer.DeleteData(id)
+}
+
+func (r *RecurringRuleRepositoryImpl) toDomainList(dataList []mapper.RecurringRuleData) ([]*model.RecurringRule, error) {
+	rules := make([]*model.RecurringRule, 0, len(dataList))
+	for _, data := range dataList {
+		rule, err := r.mapper.ToDomain(data)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
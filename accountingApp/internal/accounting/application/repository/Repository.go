@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
 	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
 )
@@ -22,6 +24,8 @@ type Repository[T any] interface {
 // 使用AggregateStore抽象，不直接依賴具體的數據庫實現
 type WalletRepositoryPeer interface {
 	// Save 儲存錢包聚合狀態
+	// data.Version為載入時的版本，儲存後版本為data.Version+1；
+	// 版本不符 (已被其他請求修改) 時回傳包裝ErrConcurrencyConflict的錯誤
 	Save(data mapper.WalletData) error
 
 	// FindByID 根據ID查找錢包聚合狀態（僅載入基本資料）
@@ -33,9 +37,27 @@ type WalletRepositoryPeer interface {
 	// FindByUserID 根據UserID查找用戶的所有錢包聚合狀態（僅載入基本資料）
 	FindByUserID(userID string) ([]mapper.WalletData, error)
 
+	// FindByExpenseRecordID 查找包含指定支出記錄的錢包並完整載入所有子實體
+	FindByExpenseRecordID(expenseID string) (*mapper.WalletData, error)
+
+	// FindByIncomeRecordID 查找包含指定收入記錄的錢包並完整載入所有子實體
+	FindByIncomeRecordID(incomeID string) (*mapper.WalletData, error)
+
+	// FindByTransferID 查找指定轉帳的來源錢包並完整載入所有子實體
+	FindByTransferID(transferID string) (*mapper.WalletData, error)
+
+	// SumExpensesByUserID 依子分類、幣別與日期加總用戶所有錢包在 [from, to) 的支出，拆帳支出依明細計入
+	SumExpensesByUserID(userID string, from, to time.Time) ([]mapper.ExpenseTotalData, error)
+
+	// FindRecordsByDateRange 查找錢包在 [from, to) 的支出 (含拆帳明細與標籤) 與收入記錄，不載入聚合
+	FindRecordsByDateRange(walletID string, from, to time.Time) ([]mapper.ExpenseRecordData, []mapper.IncomeRecordData, error)
+
 	// Delete 根據ID刪除錢包聚合狀態
 	Delete(id string) error
 
+	// DeleteWithAudit 刪除錢包，並在同一個交易中以被刪除的錢包欄位為前快照寫入audit
+	DeleteWithAudit(id string, audit mapper.WalletAuditData) error
+
 	// Note: Use FindByID() for existence checks - returns nil if not found
 }
 
@@ -50,6 +72,30 @@ type WalletRepository interface {
 	// 必要的Domain查詢
 	FindByIDWithTransactions(id string) (*model.Wallet, error) // 載入完整聚合
 	FindByUserID(userID string) ([]*model.Wallet, error)       // 用戶的所有錢包
+
+	// 透過子實體找回所屬聚合 (載入完整聚合)
+	FindByExpenseRecordID(expenseID string) (*model.Wallet, error)
+	FindByIncomeRecordID(incomeID string) (*model.Wallet, error)
+	FindByTransferID(transferID string) (*model.Wallet, error) // 轉帳的來源錢包
+
+	// 不載入聚合的查詢 (拆帳支出依明細計入)
+	SumExpensesByUserID(userID string, from, to time.Time) ([]model.SpentAmount, error)                              // 用戶在 [from, to) 的支出
+	FindRecordsByDateRange(walletID string, from, to time.Time) ([]model.ExpenseRecord, []model.IncomeRecord, error) // 錢包在 [from, to) 的支出與收入記錄
+}
+
+// AuditContext 稽核紀錄上的Command資訊
+type AuditContext struct {
+	Command   string
+	ActorID   string
+	RequestID string
+}
+
+// AuditableWalletRepository 可在儲存錢包的同一個交易中寫入稽核紀錄的錢包儲存庫
+type AuditableWalletRepository interface {
+	WalletRepository
+
+	// WithAudit 回傳共用同一個peer的儲存庫，其Save與Delete會在同一個交易中為錢包寫入一筆ctx的稽核紀錄
+	WithAudit(ctx AuditContext) WalletRepository
 }
 
 // ExpenseCategoryRepositoryPeer 支出分類第二層儲存實現的橋接介面
@@ -63,6 +109,9 @@ type ExpenseCategoryRepositoryPeer interface {
 	// FindDataBySubcategoryID 根據子分類ID查找支出分類資料結構
 	FindDataBySubcategoryID(subcategoryID string) (*mapper.ExpenseCategoryData, error)
 
+	// FindDataByUserID 根據用戶ID查找該用戶的所有支出分類資料結構
+	FindDataByUserID(userID string) ([]mapper.ExpenseCategoryData, error)
+
 	// DeleteData 根據ID刪除支出分類資料
 	DeleteData(id string) error
 }
@@ -90,6 +139,9 @@ type IncomeCategoryRepositoryPeer interface {
 	// FindDataBySubcategoryID 根據子分類ID查找收入分類資料結構
 	FindDataBySubcategoryID(subcategoryID string) (*mapper.IncomeCategoryData, error)
 
+	// FindDataByUserID 根據用戶ID查找該用戶的所有收入分類資料結構
+	FindDataByUserID(userID string) ([]mapper.IncomeCategoryData, error)
+
 	// DeleteData 根據ID刪除收入分類資料
 	DeleteData(id string) error
 }
@@ -105,3 +157,264 @@ type IncomeCategoryRepository interface {
 	FindBySubcategoryID(subcategoryID string) (*model.IncomeCategory, error) // 透過子分類找父分類
 	FindByUserID(userID string) ([]*model.IncomeCategory, error)             // 用戶的所有分類
 }
+
+// APIKeyRepositoryPeer API金鑰第二層儲存實現的橋接介面
+type APIKeyRepositoryPeer interface {
+	// SaveData 儲存API金鑰資料結構
+	SaveData(data mapper.APIKeyData) error
+
+	// FindDataByID 根據ID查找API金鑰資料結構
+	FindDataByID(id string) (*mapper.APIKeyData, error)
+
+	// FindDataByKeyHash 根據金鑰雜湊查找API金鑰資料結構
+	FindDataByKeyHash(keyHash string) (*mapper.APIKeyData, error)
+
+	// FindDataByUserID 根據用戶ID查找該用戶的所有API金鑰資料結構
+	FindDataByUserID(userID string) ([]mapper.APIKeyData, error)
+}
+
+// APIKeyRepository API金鑰專用儲存庫介面
+type APIKeyRepository interface {
+	Save(key *model.APIKey) error
+	FindByID(id string) (*model.APIKey, error)
+	FindByKeyHash(keyHash string) (*model.APIKey, error) // 認證時以明文雜湊查找
+	FindByUserID(userID string) ([]*model.APIKey, error)
+}
+
+// AttachmentRepositoryPeer 附件中繼資料第二層儲存實現的橋接介面
+type AttachmentRepositoryPeer interface {
+	// SaveData 儲存附件資料結構
+	SaveData(data mapper.AttachmentData) error
+
+	// FindDataByID 根據ID查找附件資料結構
+	FindDataByID(id string) (*mapper.AttachmentData, error)
+
+	// FindDataByRecord 根據交易記錄查找其所有附件資料結構
+	FindDataByRecord(recordType, recordID string) ([]mapper.AttachmentData, error)
+
+	// FindDataByWalletID 根據錢包ID查找其記錄的所有附件資料結構
+	FindDataByWalletID(walletID string) ([]mapper.AttachmentData, error)
+
+	// DeleteData 根據ID刪除附件資料
+	DeleteData(id string) error
+}
+
+// AttachmentRepository 附件中繼資料專用儲存庫介面，檔案內容存放在 BlobStore
+type AttachmentRepository interface {
+	// 基本CRUD操作
+	Save(attachment *model.Attachment) error
+	FindByID(id string) (*model.Attachment, error)
+	Delete(id string) error
+
+	// 必要的Domain查詢
+	FindByRecord(recordType model.AttachmentRecordType, recordID string) ([]*model.Attachment, error) // 記錄的所有附件
+	FindByWalletID(walletID string) ([]*model.Attachment, error)                                      // 刪除錢包時清理
+}
+
+// BudgetRepositoryPeer 預算第二層儲存實現的橋接介面
+type BudgetRepositoryPeer interface {
+	// SaveData 儲存預算資料結構
+	SaveData(data mapper.BudgetData) error
+
+	// FindDataByID 根據ID查找預算資料結構
+	FindDataByID(id string) (*mapper.BudgetData, error)
+
+	// FindDataByUserID 根據用戶ID查找該用戶的所有預算資料結構
+	FindDataByUserID(userID string) ([]mapper.BudgetData, error)
+
+	// DeleteData 根據ID刪除預算資料
+	DeleteData(id string) error
+}
+
+// BudgetRepository 預算專用儲存庫介面
+type BudgetRepository interface {
+	// 基本CRUD操作
+	Save(budget *model.Budget) error
+	FindByID(id string) (*model.Budget, error)
+	Delete(id string) error
+
+	// 必要的Domain查詢
+	FindByUserID(userID string) ([]*model.Budget, error) // 用戶的所有預算
+}
+
+// ImportProfileRepositoryPeer 匯入設定第二層儲存實現的橋接介面
+type ImportProfileRepositoryPeer interface {
+	// SaveData 儲存匯入設定資料結構
+	SaveData(data mapper.ImportProfileData) error
+
+	// FindDataByID 根據ID查找匯入設定資料結構
+	FindDataByID(id string) (*mapper.ImportProfileData, error)
+
+	// FindDataByUserID 根據用戶ID查找該用戶的所有匯入設定資料結構
+	FindDataByUserID(userID string) ([]mapper.ImportProfileData, error)
+
+	// DeleteData 根據ID刪除匯入設定資料
+	DeleteData(id string) error
+}
+
+// ImportProfileRepository 匯入設定專用儲存庫介面
+type ImportProfileRepository interface {
+	// 基本CRUD操作
+	Save(profile *model.ImportProfile) error
+	FindByID(id string) (*model.ImportProfile, error)
+	Delete(id string) error
+
+	// 必要的Domain查詢
+	FindByUserID(userID string) ([]*model.ImportProfile, error) // 用戶的所有匯入設定
+}
+
+// DuplicateFlagRepositoryPeer 重複標記第二層儲存實現的橋接介面
+type DuplicateFlagRepositoryPeer interface {
+	// SaveData 儲存重複標記資料結構
+	SaveData(data mapper.DuplicateFlagData) error
+
+	// FindDataByID 根據ID查找重複標記資料結構
+	FindDataByID(id string) (*mapper.DuplicateFlagData, error)
+
+	// FindDataByUserID 根據用戶ID查找該用戶指定狀態的重複標記資料結構
+	FindDataByUserID(userID, status string) ([]mapper.DuplicateFlagData, error)
+}
+
+// DuplicateFlagRepository 重複標記 (審查佇列) 專用儲存庫介面
+// 錢包刪除時其標記由資料庫一併刪除
+type DuplicateFlagRepository interface {
+	// 基本CRUD操作
+	Save(flag *model.DuplicateFlag) error
+	FindByID(id string) (*model.DuplicateFlag, error)
+
+	// 必要的Domain查詢
+	FindByUserID(userID string, status model.DuplicateStatus) ([]*model.DuplicateFlag, error) // 用戶指定狀態的標記
+}
+
+// CategorizationRuleRepositoryPeer 分類規則第二層儲存實現的橋接介面
+type CategorizationRuleRepositoryPeer interface {
+	// SaveData 儲存分類規則資料結構
+	SaveData(data mapper.CategorizationRuleData) error
+
+	// FindDataByID 根據ID查找分類規則資料結構
+	FindDataByID(id string) (*mapper.CategorizationRuleData, error)
+
+	// FindDataByUserID 根據用戶ID查找該用戶的所有分類規則資料結構
+	FindDataByUserID(userID string) ([]mapper.CategorizationRuleData, error)
+
+	// DeleteData 根據ID刪除分類規則資料
+	DeleteData(id string) error
+}
+
+// CategorizationRuleRepository 分類規則專用儲存庫介面
+type CategorizationRuleRepository interface {
+	// 基本CRUD操作
+	Save(rule *model.CategorizationRule) error
+	FindByID(id string) (*model.CategorizationRule, error)
+	Delete(id string) error
+
+	// 必要的Domain查詢
+	FindByUserID(userID string) ([]*model.CategorizationRule, error) // 用戶的所有分類規則
+}
+
+// ExchangeRateRepositoryPeer 匯率第二層儲存實現的橋接介面
+type ExchangeRateRepositoryPeer interface {
+	// SaveAllData 在同一事務中儲存一批匯率，同一組貨幣同一天的匯率會被覆蓋
+	SaveAllData(dataList []mapper.ExchangeRateData) error
+
+	// FindDataLatest 查找指定日期 (含) 之前最近的匯率
+	FindDataLatest(base, quote string, onOrBefore time.Time) (*mapper.ExchangeRateData, error)
+
+	// FindDataByPair 依日期由新到舊列出一組貨幣的所有匯率
+	FindDataByPair(base, quote string) ([]mapper.ExchangeRateData, error)
+
+	// FindDataLatestPerPair 每組貨幣在指定日期 (含) 之前最近的一筆匯率
+	FindDataLatestPerPair(onOrBefore time.Time) ([]mapper.ExchangeRateData, error)
+}
+
+// ExchangeRateRepository 匯率專用儲存庫介面
+// 匯率為全站共用資料，不屬於任何使用者
+type ExchangeRateRepository interface {
+	SaveAll(rates []*model.ExchangeRate) error // 全部成功或全部不寫入
+
+	// 必要的Domain查詢
+	FindLatest(base, quote string, onOrBefore time.Time) (*model.ExchangeRate, error) // 找不到時回傳 (nil, nil)
+	FindByPair(base, quote string) ([]*model.ExchangeRate, error)                     // 由新到舊
+	FindLatestPerPair(onOrBefore time.Time) ([]*model.ExchangeRate, error)             // 每組貨幣最近的一筆
+}
+
+// RecurringRuleRepositoryPeer 週期規則第二層儲存實現的橋接介面
+type RecurringRuleRepositoryPeer interface {
+	// SaveData 在同一事務中儲存規則與其發生日記錄
+	// data.Version為載入時的版本，儲存後版本為data.Version+1；
+	// 版本不符 (已被其他請求或排程器修改) 時回傳包裝ErrConcurrencyConflict的錯誤
+	SaveData(data mapper.RecurringRuleData) error
+
+	// FindDataByID 根據ID查找規則並載入其發生日記錄
+	FindDataByID(id string) (*mapper.RecurringRuleData, error)
+
+	// FindDataByUserID 根據用戶ID查找該用戶的所有規則
+	FindDataByUserID(userID string) ([]mapper.RecurringRuleData, error)
+
+	// FindDataByStatus 查找指定狀態的所有規則 (供排程器使用)
+	FindDataByStatus(status string) ([]mapper.RecurringRuleData, error)
+
+	// DeleteData 刪除規則，發生日記錄一併刪除
+	DeleteData(id string) error
+}
+
+// RecurringRuleRepository 週期規則專用儲存庫介面
+type RecurringRuleRepository interface {
+	// 基本CRUD操作
+	Save(rule *model.RecurringRule) error
+	FindByID(id string) (*model.RecurringRule, error)
+	Delete(id string) error
+
+	// 必要的Domain查詢
+	FindByUserID(userID string) ([]*model.RecurringRule, error) // 用戶的所有規則
+	FindActive() ([]*model.RecurringRule, error)                // 排程器要處理的規則
+}
+
+// OutboxRepository Outbox訊息的投遞端儲存庫
+// 訊息由WalletRepositoryPeer在儲存錢包的同一個交易中寫入，這裡只負責讀取與更新投遞狀態
+type OutboxRepository interface {
+	// ClaimDue 取得到期的待投遞訊息，並把下次嘗試時間延後lease，
+	// 避免多個Relay同時處理同一筆；處理中途當機時lease到期後會再被取得
+	ClaimDue(now time.Time, lease time.Duration, limit int) ([]mapper.OutboxMessageData, error)
+
+	// FindByID 根據ID查找訊息，不存在時回傳 (nil, nil)
+	FindByID(id string) (*mapper.OutboxMessageData, error)
+
+	// FindStuck 查找卡住的訊息：已進入dead letter，或仍在重試中 (attempts > 0)
+	FindStuck(limit int) ([]mapper.OutboxMessageData, error)
+
+	// FindByStatus 依狀態查找訊息，依發生時間排序
+	FindByStatus(status string, limit int) ([]mapper.OutboxMessageData, error)
+
+	// Update 更新訊息的投遞狀態
+	Update(message mapper.OutboxMessageData) error
+
+	// DeleteDelivered 刪除投遞時間早於before的已投遞訊息，回傳刪除數
+	DeleteDelivered(before time.Time) (int, error)
+}
+
+// AuditLogFilter 稽核紀錄查詢條件，空值表示不限制
+type AuditLogFilter struct {
+	ActorID       string
+	Command       string
+	AggregateType string
+	AggregateID   string
+	RequestID     string
+	From          *time.Time // 包含
+	To            *time.Time // 不包含
+	Limit         int
+}
+
+// AuditLogRepository 稽核紀錄儲存庫
+// 只提供新增與查詢；紀錄一經寫入不可修改或刪除
+type AuditLogRepository interface {
+	// Append 將紀錄接在雜湊鏈尾端 (見 mapper.ChainAuditEntry)，回傳含序號與雜湊的紀錄
+	// 同時寫入的請求必須串行化，確保鏈不分岔
+	Append(entry mapper.AuditEntryData) (*mapper.AuditEntryData, error)
+
+	// Find 依條件查詢，依序號由新到舊排序
+	Find(filter AuditLogFilter) ([]mapper.AuditEntryData, error)
+
+	// FindAfterSequence 依序號由舊到新取得序號大於afterSequence的紀錄，供驗證雜湊鏈
+	FindAfterSequence(afterSequence int64, limit int) ([]mapper.AuditEntryData, error)
+}
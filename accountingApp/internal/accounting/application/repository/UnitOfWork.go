@@ -0,0 +1,22 @@
+package repository
+
+// UnitOfWork 交易邊界抽象 (Transaction Manager)
+// 讓一個Command在同一個交易中修改多個聚合，全部提交或全部回滾
+type UnitOfWork interface {
+	// Do 在單一交易中執行fn；fn回傳錯誤 (或panic) 時回滾，否則提交
+	Do(fn func(scope TransactionScope) error) error
+}
+
+// AuditableUnitOfWork 交易範圍內儲存的錢包都寫入稽核紀錄的UnitOfWork
+type AuditableUnitOfWork interface {
+	UnitOfWork
+
+	// WithAudit 回傳透過scope.Wallets()儲存與刪除錢包時，在同一個交易中寫入ctx稽核紀錄的UnitOfWork
+	WithAudit(ctx AuditContext) UnitOfWork
+}
+
+// TransactionScope 交易範圍內可用的Repository
+// 透過scope取得的Repository所有讀寫都在同一個交易中執行
+type TransactionScope interface {
+	Wallets() WalletRepository
+}
@@ -1,21 +1,29 @@
 package repository
 
 import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/event"
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
 	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
 )
 
 // WalletRepositoryImpl Layer 2 (Application) 錢包儲存庫實現
 type WalletRepositoryImpl struct {
-	peer   WalletRepositoryPeer // 橋接到Layer 3的實現
-	mapper *mapper.WalletMapper // AggregateMapper：Domain ↔ Data轉換
+	peer      WalletRepositoryPeer // 橋接到Layer 3的實現
+	mapper    *mapper.WalletMapper // AggregateMapper：Domain ↔ Data轉換
+	publisher event.Publisher      // 儲存成功後發布領域事件，nil表示不發布
+	audit     *AuditContext        // 不為nil時，儲存與刪除在同一個交易中寫入稽核紀錄
 }
 
 // NewWalletRepositoryImpl 創建錢包儲存庫實現
-func NewWalletRepositoryImpl(peer WalletRepositoryPeer) WalletRepository {
+func NewWalletRepositoryImpl(peer WalletRepositoryPeer, publisher event.Publisher) AuditableWalletRepository {
 	return &WalletRepositoryImpl{
-		peer:   peer,
-		mapper: mapper.NewWalletMapper(),
+		peer:      peer,
+		mapper:    mapper.NewWalletMapper(),
+		publisher: publisher,
 	}
 }
 
@@ -25,15 +33,38 @@ func (r *WalletRepositoryImpl) Save(wallet *model.Wallet) error {
 	// 使用AggregateMapper: Domain Aggregate → AggregateData
 	aggregateData := r.mapper.ToData(wallet)
 
+	// 領域事件序列化為outbox訊息，與聚合狀態一起持久化
+	outboxMessages, err := mapper.ToOutboxMessages(mapper.AggregateTypeWallet, wallet.DomainEvents())
+	if err != nil {
+		return err
+	}
+	aggregateData.OutboxMessages = outboxMessages
+
+	// 稽核快照取自儲存中的聚合：錢包欄位與此次變更的子實體，沒有變更時不記錄
+	if r.audit != nil && len(wallet.DomainEvents()) > 0 {
+		before, after := r.mapper.ToAuditSnapshots(aggregateData, wallet.DomainEvents())
+		aggregateData.Audit = &mapper.WalletAuditData{Entry: r.auditEntry(wallet.ID), Before: before, After: &after}
+	}
+
 	// 透過peer介面橋接到Layer 3 → Layer 4的AggregateStore
-	err := r.peer.Save(aggregateData)
+	err = r.peer.Save(aggregateData)
 	if err != nil {
 		// TODO: 包裝為Repository專用異常
 		return err
 	}
 
-	// 清除領域事件（如果將來添加事件源）
-	// wallet.ClearDomainEvents()
+	// 儲存成功後同步版本號，讓同一個聚合實例可以再次儲存
+	wallet.Version = aggregateData.Version + 1
+
+	// 變更已持久化，清除子實體的Dirty Tracking
+	wallet.ClearChanges()
+
+	// 儲存成功後才發布領域事件，並清除避免重複發布
+	events := wallet.DomainEvents()
+	wallet.ClearDomainEvents()
+	if r.publisher != nil && len(events) > 0 {
+		r.publisher.Publish(events)
+	}
 
 	return nil
 }
@@ -54,10 +85,48 @@ func (r *WalletRepositoryImpl) FindByID(id string) (*model.Wallet, error) {
 	return r.mapper.ToDomain(*aggregateData)
 }
 
-// Delete 刪除錢包
+// Delete 刪除錢包，成功後發布WalletDeleted (只發布給訂閱者，不寫入outbox)
 func (r *WalletRepositoryImpl) Delete(id string) error {
-	// 透過peer介面橋接到AggregateStore刪除聚合狀態
-	return r.peer.Delete(id)
+	// 事件需要錢包的擁有者，刪除前先取得錢包基本資料
+	wallet, err := r.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	if r.audit != nil {
+		err = r.peer.DeleteWithAudit(id, mapper.WalletAuditData{Entry: r.auditEntry(id)})
+	} else {
+		// 透過peer介面橋接到AggregateStore刪除聚合狀態
+		err = r.peer.Delete(id)
+	}
+	if err != nil {
+		return err
+	}
+
+	if r.publisher != nil && wallet != nil {
+		r.publisher.Publish([]model.DomainEvent{model.NewWalletDeleted(wallet)})
+	}
+	return nil
+}
+
+// WithAudit 回傳共用同一個peer與publisher的儲存庫，儲存與刪除時在同一個交易中寫入ctx的稽核紀錄
+func (r *WalletRepositoryImpl) WithAudit(ctx AuditContext) WalletRepository {
+	audited := *r
+	audited.audit = &ctx
+	return &audited
+}
+
+// auditEntry 建立錢包的稽核紀錄欄位 (不含快照)
+func (r *WalletRepositoryImpl) auditEntry(walletID string) mapper.AuditEntryData {
+	return mapper.AuditEntryData{
+		ID:            uuid.NewString(),
+		ActorID:       r.audit.ActorID,
+		Command:       r.audit.Command,
+		AggregateType: mapper.AuditAggregateWallet,
+		AggregateID:   walletID,
+		RequestID:     r.audit.RequestID,
+		OccurredAt:    time.Now(),
+	}
 }
 
 // FindByIDWithTransactions 根據ID查找錢包及所有交易記錄 (載入完整聚合)
@@ -97,6 +166,68 @@ func (r *WalletRepositoryImpl) FindByUserID(userID string) ([]*model.Wallet, err
 	return wallets, nil
 }
 
+// FindByExpenseRecordID 查找包含指定支出記錄的錢包 (載入完整聚合)
+func (r *WalletRepositoryImpl) FindByExpenseRecordID(expenseID string) (*model.Wallet, error) {
+	aggregateData, err := r.peer.FindByExpenseRecordID(expenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if aggregateData == nil {
+		return nil, nil // 記錄不存在
+	}
+
+	return r.mapper.ToDomain(*aggregateData)
+}
+
+// FindByIncomeRecordID 查找包含指定收入記錄的錢包 (載入完整聚合)
+func (r *WalletRepositoryImpl) FindByIncomeRecordID(incomeID string) (*model.Wallet, error) {
+	aggregateData, err := r.peer.FindByIncomeRecordID(incomeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if aggregateData == nil {
+		return nil, nil // 記錄不存在
+	}
+
+	return r.mapper.ToDomain(*aggregateData)
+}
+
+// FindByTransferID 查找轉帳的來源錢包 (載入完整聚合)
+func (r *WalletRepositoryImpl) FindByTransferID(transferID string) (*model.Wallet, error) {
+	aggregateData, err := r.peer.FindByTransferID(transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if aggregateData == nil {
+		return nil, nil // 記錄不存在
+	}
+
+	return r.mapper.ToDomain(*aggregateData)
+}
+
+// SumExpensesByUserID 用戶所有錢包在 [from, to) 的支出，依子分類、幣別與日期加總
+func (r *WalletRepositoryImpl) SumExpensesByUserID(userID string, from, to time.Time) ([]model.SpentAmount, error) {
+	totals, err := r.peer.SumExpensesByUserID(userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.mapper.ToSpentAmounts(totals)
+}
+
+// FindRecordsByDateRange 錢包在 [from, to) 的支出與收入記錄，不載入聚合
+func (r *WalletRepositoryImpl) FindRecordsByDateRange(walletID string, from, to time.Time) ([]model.ExpenseRecord, []model.IncomeRecord, error) {
+	expenseData, incomeData, err := r.peer.FindRecordsByDateRange(walletID, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return r.mapper.ToRecords(expenseData, incomeData)
+}
+
 // 注意：移除了直接實現WalletRepositoryPeer介面的方法
 // Repository Impl (Layer 2) 只應該通過peer介面與Layer 3溝通
 // 避免破壞分層架構的依賴規則
@@ -0,0 +1,10 @@
+package repository
+
+import "errors"
+
+// ErrConcurrencyConflict 樂觀鎖衝突：聚合在載入後已被其他請求修改
+// Peer實作以此錯誤包裝 (fmt.Errorf("%w", ...))，呼叫端以errors.Is判斷
+var ErrConcurrencyConflict = errors.New("concurrency conflict")
+
+// ErrBlobNotFound BlobStore 中沒有指定鍵的檔案
+var ErrBlobNotFound = errors.New("blob not found")
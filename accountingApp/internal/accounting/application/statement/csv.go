@@ -0,0 +1,101 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// ParseCSV 依欄位對應解析CSV帳單，金額以currency的精度計算
+// 個別資料列的錯誤記錄在Rejected；整個檔案無法使用 (過大、編碼或格式錯誤、找不到欄位) 時回傳error
+func ParseCSV(r io.Reader, mapping model.ImportMapping, currency string) (*Result, error) {
+	content, err := readContent(r)
+	if err != nil {
+		return nil, err
+	}
+	if content, err = decode(content, mapping.Encoding); err != nil {
+		return nil, err
+	}
+
+	delimiter, err := mapping.DelimiterRune()
+	if err != nil {
+		return nil, err
+	}
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1 // 帳單開頭的說明與結尾的合計列欄數常不同
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	// 略過開頭的說明列，再讀取標題
+	for i := 0; i < mapping.SkipRows; i++ {
+		if _, err := reader.Read(); err != nil {
+			return nil, readError(err)
+		}
+	}
+	var header []string
+	if mapping.HasHeader {
+		if header, err = reader.Read(); err != nil {
+			return nil, readError(err)
+		}
+	}
+	columns, err := mapping.ResolveColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := Statement{}
+	for {
+		cells, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, readError(err)
+		}
+		line, _ := reader.FieldPos(0)
+		if isBlank(cells) {
+			continue
+		}
+		if len(statement.Transactions)+len(statement.Rejected) >= MaxRows {
+			return nil, fmt.Errorf("file has more than %d rows", MaxRows)
+		}
+
+		tx, err := columns.ParseRow(line, cells, currency)
+		if err != nil {
+			statement.Rejected = append(statement.Rejected, RejectedRow{Line: line, Reason: err.Error()})
+			continue
+		}
+		statement.Transactions = append(statement.Transactions, tx)
+	}
+
+	if len(statement.Transactions)+len(statement.Rejected) == 0 {
+		return nil, errors.New("file has no rows")
+	}
+	return &Result{Statements: []Statement{statement}}, nil
+}
+
+func readError(err error) error {
+	if err == io.EOF {
+		return errors.New("file has no rows")
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return fmt.Errorf("line %d: %v", parseErr.Line, parseErr.Err)
+	}
+	return fmt.Errorf("failed to read file: %w", err)
+}
+
+func isBlank(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,252 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// Importer 帳單匯入的共用流程：決定對應設定與分類規則、解析檔案並套用到錢包
+// 預覽與實際匯入使用相同的流程，因此兩者的結果一致
+type Importer struct {
+	profileRepo         repository.ImportProfileRepository
+	expenseCategoryRepo repository.ExpenseCategoryRepository
+	incomeCategoryRepo  repository.IncomeCategoryRepository
+	ruleRepo            repository.CategorizationRuleRepository
+}
+
+func NewImporter(
+	profileRepo repository.ImportProfileRepository,
+	expenseCategoryRepo repository.ExpenseCategoryRepository,
+	incomeCategoryRepo repository.IncomeCategoryRepository,
+	ruleRepo repository.CategorizationRuleRepository,
+) *Importer {
+	return &Importer{
+		profileRepo:         profileRepo,
+		expenseCategoryRepo: expenseCategoryRepo,
+		incomeCategoryRepo:  incomeCategoryRepo,
+		ruleRepo:            ruleRepo,
+	}
+}
+
+// Mapping 取得帳單的格式與對應設定 (CSV為匯入設定，OFX/QIF為指定的子分類)
+// 失敗時回傳的Output說明原因，成功時為nil
+func (i *Importer) Mapping(userID string, file usecase.StatementFile) (Format, model.ImportMapping, common.Output) {
+	format, err := DetectFormat(file.Format, file.FileName)
+	if err != nil {
+		return "", model.ImportMapping{}, invalidStatement(err)
+	}
+
+	var mapping model.ImportMapping
+	if format == FormatCSV {
+		profile, err := i.profileRepo.FindByID(file.ProfileID)
+		if err != nil {
+			return "", model.ImportMapping{}, common.UseCaseOutput{
+				ExitCode: common.Failure,
+				Message:  fmt.Sprintf("Failed to find import profile: %v", err),
+			}
+		}
+		if profile == nil || !common.IsAccessibleBy(profile.UserID, userID) {
+			return "", model.ImportMapping{}, common.UseCaseOutput{
+				ExitCode: common.NotFound,
+				Message:  "Import profile not found",
+			}
+		}
+		mapping = profile.Mapping
+	} else {
+		if file.ExpenseSubcategoryID == "" || file.IncomeSubcategoryID == "" {
+			return "", model.ImportMapping{}, invalidStatement(fmt.Errorf("expense and income subcategories are required for %s files", format))
+		}
+		encoding, err := model.ParseImportEncoding(file.Encoding)
+		if err != nil {
+			return "", model.ImportMapping{}, invalidStatement(err)
+		}
+		mapping = model.ImportMapping{
+			Encoding:             encoding,
+			ExpenseSubcategoryID: file.ExpenseSubcategoryID,
+			IncomeSubcategoryID:  file.IncomeSubcategoryID,
+		}
+	}
+
+	// 子分類可能在建立設定後被刪除，匯入前再確認
+	if output := VerifySubcategories(i.expenseCategoryRepo, i.incomeCategoryRepo, mapping, userID); output != nil {
+		return "", model.ImportMapping{}, output
+	}
+	return format, mapping, nil
+}
+
+// Rules 使用者的分類規則 (依套用順序)
+// 子分類在建立規則後被刪除的規則略過，該列改用對應設定的子分類
+func (i *Importer) Rules(userID string) (model.CategorizationRuleSet, common.Output) {
+	rules, err := i.ruleRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find categorization rules: %v", err),
+		}
+	}
+
+	usable := make([]*model.CategorizationRule, 0, len(rules))
+	exists := make(map[string]bool)
+	for _, rule := range rules {
+		found, checked := exists[rule.SubcategoryID]
+		if !checked {
+			var ownerID string
+			if rule.Type == model.CategorizeExpense {
+				var category *model.ExpenseCategory
+				if category, err = i.expenseCategoryRepo.FindBySubcategoryID(rule.SubcategoryID); category != nil {
+					ownerID = category.UserID
+				}
+			} else {
+				var category *model.IncomeCategory
+				if category, err = i.incomeCategoryRepo.FindBySubcategoryID(rule.SubcategoryID); category != nil {
+					ownerID = category.UserID
+				}
+			}
+			if err != nil {
+				return nil, common.UseCaseOutput{
+					ExitCode: common.Failure,
+					Message:  fmt.Sprintf("Failed to find category: %v", err),
+				}
+			}
+			found = ownerID != "" && common.IsAccessibleBy(ownerID, userID)
+			exists[rule.SubcategoryID] = found
+		}
+		if found {
+			usable = append(usable, rule)
+		}
+	}
+	return model.NewCategorizationRuleSet(usable), nil
+}
+
+// Parse 依格式解析帳單內容，金額以錢包幣別計算
+func Parse(format Format, file usecase.StatementFile, mapping model.ImportMapping, currency string) (*Result, common.Output) {
+	var result *Result
+	var err error
+	switch format {
+	case FormatOFX:
+		result, err = ParseOFX(file.Content, currency)
+	case FormatQIF:
+		result, err = ParseQIF(file.Content, mapping.Encoding, currency)
+	default:
+		result, err = ParseCSV(file.Content, mapping, currency)
+	}
+	if err != nil {
+		return nil, invalidStatement(err)
+	}
+	return result, nil
+}
+
+func invalidStatement(err error) common.Output {
+	return common.UseCaseOutput{
+		ExitCode: common.InvalidInput,
+		Message:  fmt.Sprintf("Invalid statement: %v", err),
+	}
+}
+
+// Applied 帳單套用到錢包的結果，各清單依列號排序
+type Applied struct {
+	Rows       []usecase.ImportRowData       // 已新增的交易
+	Skipped    []usecase.ImportRejectionData // 先前已匯入的交易
+	Rejected   []usecase.ImportRejectionData // 無法解析或記錄的資料列
+	Statements []usecase.ImportStatementData
+}
+
+// Apply 將解析結果逐筆記錄到錢包；符合分類規則的列使用規則的子分類與標籤
+// 先前已匯入的交易略過，無法記錄的 (例如餘額不足) 拒絕
+func Apply(wallet *model.Wallet, mapping model.ImportMapping, rules model.CategorizationRuleSet, result *Result) *Applied {
+	applied := &Applied{
+		Rows:       []usecase.ImportRowData{},
+		Skipped:    []usecase.ImportRejectionData{},
+		Rejected:   []usecase.ImportRejectionData{},
+		Statements: make([]usecase.ImportStatementData, 0, len(result.Statements)),
+	}
+
+	for _, statement := range result.Statements {
+		summary := usecase.ImportStatementData{Account: statement.Account, Rejected: len(statement.Rejected)}
+		for _, row := range statement.Rejected {
+			applied.Rejected = append(applied.Rejected, usecase.ImportRejectionData{Line: row.Line, Reason: row.Reason})
+		}
+
+		for _, tx := range statement.Transactions {
+			rule := rules.Match(tx.RuleSample(wallet.ID, wallet.Currency()))
+			if rule != nil {
+				tx.Categorize(rule)
+			}
+			recordID, err := model.RecordImportedTransaction(wallet, mapping, tx)
+			switch {
+			case errors.Is(err, model.ErrAlreadyImported):
+				summary.Skipped++
+				applied.Skipped = append(applied.Skipped, usecase.ImportRejectionData{Line: tx.Line, Reason: err.Error()})
+			case err != nil:
+				summary.Rejected++
+				applied.Rejected = append(applied.Rejected, usecase.ImportRejectionData{Line: tx.Line, Reason: err.Error()})
+			default:
+				summary.Created++
+				row := usecase.ImportRowData{
+					Line:          tx.Line,
+					Date:          tx.Date,
+					Description:   tx.Description,
+					Type:          string(tx.Type),
+					Amount:        tx.Amount,
+					Currency:      wallet.Currency(),
+					RecordID:      recordID,
+					SubcategoryID: mapping.ExpenseSubcategoryID,
+				}
+				if tx.Type == model.ImportIncome {
+					row.SubcategoryID = mapping.IncomeSubcategoryID
+				}
+				if rule != nil {
+					row.SubcategoryID, row.Tags, row.RuleID = rule.SubcategoryID, tx.Tags, rule.ID
+				}
+				applied.Rows = append(applied.Rows, row)
+			}
+		}
+		applied.Statements = append(applied.Statements, summary)
+	}
+
+	sort.Slice(applied.Rejected, func(i, j int) bool { return applied.Rejected[i].Line < applied.Rejected[j].Line })
+	return applied
+}
+
+// VerifySubcategories 確認匯入使用的支出與收入子分類存在且屬於使用者，通過時回傳nil
+func VerifySubcategories(
+	expenseCategoryRepo repository.ExpenseCategoryRepository,
+	incomeCategoryRepo repository.IncomeCategoryRepository,
+	mapping model.ImportMapping,
+	userID string,
+) common.Output {
+	expenseCategory, err := expenseCategoryRepo.FindBySubcategoryID(mapping.ExpenseSubcategoryID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find expense category: %v", err),
+		}
+	}
+	if expenseCategory == nil || !common.IsAccessibleBy(expenseCategory.UserID, userID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Expense subcategory not found",
+		}
+	}
+
+	incomeCategory, err := incomeCategoryRepo.FindBySubcategoryID(mapping.IncomeSubcategoryID)
+	if err != nil {
+		return common.UseCaseOutput{
+			ExitCode: common.Failure,
+			Message:  fmt.Sprintf("Failed to find income category: %v", err),
+		}
+	}
+	if incomeCategory == nil || !common.IsAccessibleBy(incomeCategory.UserID, userID) {
+		return common.UseCaseOutput{
+			ExitCode: common.NotFound,
+			Message:  "Income subcategory not found",
+		}
+	}
+	return nil
+}
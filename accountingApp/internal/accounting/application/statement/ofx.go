@@ -0,0 +1,239 @@
+package statement
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// ofxXMLEncoding OFX 2.x (XML) 宣告的編碼
+var ofxXMLEncoding = regexp.MustCompile(`(?i)encoding\s*=\s*["']([^"']+)["']`)
+
+// ParseOFX 解析OFX/QFX帳單 (1.x的SGML與2.x的XML)，金額以currency的精度計算
+// 只匯入銀行 (STMTRS) 與信用卡 (CCSTMTRS) 帳戶的交易，每個帳戶各為一個Statement；
+// FITID與帳號組成匯入識別，沒有FITID的交易改以內容雜湊判斷重複
+func ParseOFX(r io.Reader, currency string) (*Result, error) {
+	content, err := readContent(r)
+	if err != nil {
+		return nil, err
+	}
+	if content, err = decode(content, ofxEncoding(content)); err != nil {
+		return nil, err
+	}
+	text := string(content)
+	if !strings.Contains(strings.ToUpper(text), "<OFX>") {
+		return nil, errors.New("file is not an OFX statement")
+	}
+
+	parser := &ofxParser{currency: currency, result: &Result{}, contentIDs: newContentImportIDs("ofx:")}
+	if err := parser.parse(text); err != nil {
+		return nil, err
+	}
+	if len(parser.result.Statements) == 0 {
+		return nil, errors.New("file has no bank or credit card statements")
+	}
+	return parser.result, nil
+}
+
+// ofxEncoding 依OFX標頭判斷文字編碼
+// 2.x為XML宣告的encoding；1.x為ENCODING與CHARSET標頭 (CHARSET:1252最常見)
+func ofxEncoding(content []byte) model.ImportEncoding {
+	header := content
+	if index := bytes.Index(bytes.ToUpper(content), []byte("<OFX>")); index >= 0 {
+		header = content[:index]
+	}
+	upper := strings.ToUpper(string(header))
+
+	var charset string
+	if match := ofxXMLEncoding.FindStringSubmatch(string(header)); match != nil {
+		charset = strings.ToUpper(match[1])
+	} else {
+		if strings.Contains(upper, "ENCODING:UTF-8") {
+			return model.ImportEncodingUTF8
+		}
+		for _, line := range strings.Split(upper, "\n") {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(line), "CHARSET:"); ok {
+				charset = strings.TrimSpace(value)
+			}
+		}
+	}
+
+	switch charset {
+	case "1252", "WINDOWS-1252", "ISO-8859-1", "8859-1":
+		return model.ImportEncodingWindows1252
+	case "950", "BIG5":
+		return model.ImportEncodingBig5
+	case "UTF-8", "UTF8":
+		return model.ImportEncodingUTF8
+	}
+	// 未宣告 (NONE)：不是有效的UTF-8時視為Windows-1252
+	if utf8.Valid(bytes.TrimPrefix(content, utf8BOM)) {
+		return model.ImportEncodingUTF8
+	}
+	return model.ImportEncodingWindows1252
+}
+
+// ofxParser 依序讀取標籤，不建立完整的樹狀結構
+// SGML的末端元素沒有結束標籤，因此以「開始標籤後的文字」作為值，兩種版本可共用
+type ofxParser struct {
+	currency   string
+	result     *Result
+	contentIDs *contentImportIDs
+	rows       int
+
+	statement         *Statement
+	statementCurrency string
+	transaction       map[string]string // 目前的STMTTRN，nil表示不在交易中
+	transactionLine   int
+}
+
+func (p *ofxParser) parse(text string) error {
+	line, scanned := 1, 0
+	for pos := 0; pos < len(text); {
+		start := strings.IndexByte(text[pos:], '<')
+		if start < 0 {
+			break
+		}
+		start += pos
+		end := strings.IndexByte(text[start:], '>')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		line += strings.Count(text[scanned:start], "\n")
+		scanned = start
+
+		tag := strings.TrimSpace(text[start+1 : end])
+		pos = end + 1
+		if tag == "" || tag[0] == '?' || tag[0] == '!' {
+			continue
+		}
+
+		valueEnd := strings.IndexByte(text[pos:], '<')
+		if valueEnd < 0 {
+			valueEnd = len(text) - pos
+		}
+		value := strings.TrimSpace(html.UnescapeString(text[pos : pos+valueEnd]))
+
+		if name, closing := strings.CutPrefix(tag, "/"); closing {
+			if err := p.close(strings.ToUpper(strings.TrimSpace(name))); err != nil {
+				return err
+			}
+			continue
+		}
+		p.open(strings.ToUpper(strings.TrimSuffix(tag, "/")), value, line)
+	}
+	return nil
+}
+
+func (p *ofxParser) open(name, value string, line int) {
+	if name == "STMTRS" || name == "CCSTMTRS" {
+		p.statement = &Statement{}
+		p.statementCurrency = ""
+		return
+	}
+	// 投資帳戶 (INVSTMTRS) 等其他區塊中的交易不匯入
+	if p.statement == nil {
+		return
+	}
+	if name == "STMTTRN" {
+		p.transaction = make(map[string]string)
+		p.transactionLine = line
+		return
+	}
+	if value == "" {
+		return
+	}
+
+	switch {
+	case p.transaction != nil:
+		if _, exists := p.transaction[name]; !exists {
+			p.transaction[name] = value
+		}
+	case name == "CURDEF":
+		p.statementCurrency = strings.ToUpper(value)
+	case name == "ACCTID" && p.statement.Account == "":
+		p.statement.Account = value
+	}
+}
+
+func (p *ofxParser) close(name string) error {
+	switch {
+	case name == "STMTTRN" && p.statement != nil && p.transaction != nil:
+		if p.rows >= MaxRows {
+			return fmt.Errorf("file has more than %d transactions", MaxRows)
+		}
+		p.rows++
+
+		tx, err := p.toTransaction()
+		if err != nil {
+			p.statement.Rejected = append(p.statement.Rejected, RejectedRow{Line: p.transactionLine, Reason: err.Error()})
+		} else {
+			p.statement.Transactions = append(p.statement.Transactions, tx)
+		}
+		p.transaction = nil
+	case (name == "STMTRS" || name == "CCSTMTRS") && p.statement != nil:
+		p.result.Statements = append(p.result.Statements, *p.statement)
+		p.statement = nil
+		p.transaction = nil
+	}
+	return nil
+}
+
+// toTransaction 將STMTTRN的欄位轉換為交易；TRNAMT為負數時是支出
+func (p *ofxParser) toTransaction() (model.ImportedTransaction, error) {
+	fields := p.transaction
+	if p.statementCurrency != "" && p.statementCurrency != p.currency {
+		return model.ImportedTransaction{}, fmt.Errorf("statement currency %s does not match wallet currency %s", p.statementCurrency, p.currency)
+	}
+
+	posted := fields["DTPOSTED"]
+	if len(posted) < 8 {
+		return model.ImportedTransaction{}, fmt.Errorf("invalid date %q", posted)
+	}
+	date, err := time.Parse("20060102", posted[:8])
+	if err != nil {
+		return model.ImportedTransaction{}, fmt.Errorf("invalid date %q", posted)
+	}
+
+	// OFX允許以逗號作為小數點
+	amountText := fields["TRNAMT"]
+	if strings.Contains(amountText, ",") && !strings.Contains(amountText, ".") {
+		amountText = strings.Replace(amountText, ",", ".", 1)
+	}
+	amount, err := model.ParseStatementAmount(amountText, p.currency)
+	if err != nil {
+		return model.ImportedTransaction{}, err
+	}
+	if amount == 0 {
+		return model.ImportedTransaction{}, errors.New("amount is zero")
+	}
+
+	tx := model.ImportedTransaction{
+		Line:        p.transactionLine,
+		Date:        date,
+		Description: joinDescription(fields["NAME"], fields["MEMO"]),
+		Type:        model.ImportIncome,
+		Amount:      amount,
+	}
+	if amount < 0 {
+		tx.Type = model.ImportExpense
+		tx.Amount = -amount
+	}
+
+	if fitID := fields["FITID"]; fitID != "" {
+		tx.ImportID = boundedImportID("ofx:", p.statement.Account+":"+fitID)
+	} else {
+		tx.ImportID = p.contentIDs.next(p.statement.Account, tx)
+	}
+	return tx, nil
+}
@@ -0,0 +1,205 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// qifDateLayouts QIF的日期依Quicken慣例為 月/日/年，'表示2000年後的兩位數年份 (例如 1/ 5'24)
+var qifDateLayouts = []string{"1/2/2006", "1/2/06", "2006-01-02", "2006/1/2", "1-2-2006", "1-2-06"}
+
+// qifTransactionTypes 可匯入的QIF帳戶類型；投資 (Invst)、分類清單等其他區塊略過
+var qifTransactionTypes = map[string]bool{
+	"BANK":  true,
+	"CASH":  true,
+	"CCARD": true,
+	"OTH A": true,
+	"OTH L": true,
+}
+
+// ParseQIF 解析QIF帳單，金額以currency的精度計算
+// 每個 !Type 區塊為一個Statement (帳戶名稱取自前面的 !Account)；QIF沒有交易識別碼，
+// 以帳戶、日期、金額、收款人、備註與支票號碼的雜湊作為匯入識別
+func ParseQIF(r io.Reader, encoding model.ImportEncoding, currency string) (*Result, error) {
+	content, err := readContent(r)
+	if err != nil {
+		return nil, err
+	}
+	if content, err = decode(content, encoding); err != nil {
+		return nil, err
+	}
+
+	parser := &qifParser{currency: currency, result: &Result{}, contentIDs: newContentImportIDs("qif:")}
+	for i, raw := range strings.Split(string(content), "\n") {
+		if err := parser.readLine(i+1, strings.TrimRight(raw, "\r")); err != nil {
+			return nil, err
+		}
+	}
+	if err := parser.endRecord(); err != nil {
+		return nil, err
+	}
+	parser.endStatement()
+
+	if !parser.sawTransactions {
+		return nil, errors.New("file is not a QIF statement: no !Type:Bank, CCard, Cash or Oth section")
+	}
+	return parser.result, nil
+}
+
+type qifSection int
+
+const (
+	qifSkipped qifSection = iota
+	qifAccount
+	qifTransactions
+)
+
+type qifParser struct {
+	currency        string
+	result          *Result
+	contentIDs      *contentImportIDs
+	rows            int
+	sawTransactions bool
+
+	section    qifSection
+	account    string
+	statement  *Statement
+	fields     map[byte]string // 目前記錄的欄位 (重複的欄位例如拆帳明細只保留第一個)
+	recordLine int
+}
+
+func (p *qifParser) readLine(line int, text string) error {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return nil
+	}
+
+	if strings.HasPrefix(trimmed, "!") {
+		if err := p.endRecord(); err != nil {
+			return err
+		}
+		p.readHeader(strings.ToUpper(trimmed))
+		return nil
+	}
+	if trimmed == "^" {
+		return p.endRecord()
+	}
+
+	if p.fields == nil {
+		p.fields = make(map[byte]string)
+		p.recordLine = line
+	}
+	code := trimmed[0]
+	if _, exists := p.fields[code]; !exists {
+		p.fields[code] = strings.TrimSpace(trimmed[1:])
+	}
+	return nil
+}
+
+func (p *qifParser) readHeader(header string) {
+	switch {
+	case header == "!ACCOUNT":
+		p.endStatement()
+		p.section = qifAccount
+	case strings.HasPrefix(header, "!TYPE:"):
+		p.endStatement()
+		if qifTransactionTypes[strings.TrimSpace(strings.TrimPrefix(header, "!TYPE:"))] {
+			p.section = qifTransactions
+			p.statement = &Statement{Account: p.account}
+			p.sawTransactions = true
+		} else {
+			p.section = qifSkipped
+		}
+	case strings.HasPrefix(header, "!OPTION:"), strings.HasPrefix(header, "!CLEAR:"):
+	default:
+		p.endStatement()
+		p.section = qifSkipped
+	}
+}
+
+// endRecord 處理以 ^ 結束 (或檔案結束) 的記錄
+func (p *qifParser) endRecord() error {
+	fields, line := p.fields, p.recordLine
+	p.fields = nil
+	if fields == nil {
+		return nil
+	}
+
+	switch p.section {
+	case qifAccount:
+		p.account = fields['N']
+	case qifTransactions:
+		if p.rows >= MaxRows {
+			return fmt.Errorf("file has more than %d transactions", MaxRows)
+		}
+		p.rows++
+
+		tx, err := p.toTransaction(fields, line)
+		if err != nil {
+			p.statement.Rejected = append(p.statement.Rejected, RejectedRow{Line: line, Reason: err.Error()})
+			return nil
+		}
+		p.statement.Transactions = append(p.statement.Transactions, tx)
+	}
+	return nil
+}
+
+func (p *qifParser) endStatement() {
+	if p.statement != nil {
+		p.result.Statements = append(p.result.Statements, *p.statement)
+		p.statement = nil
+	}
+}
+
+// toTransaction 將記錄轉換為交易：D日期、T (或U) 金額、P收款人、M備註、N支票號碼；負數金額為支出
+func (p *qifParser) toTransaction(fields map[byte]string, line int) (model.ImportedTransaction, error) {
+	dateText, ok := fields['D']
+	if !ok {
+		return model.ImportedTransaction{}, errors.New("date is missing")
+	}
+	date, err := parseQIFDate(dateText)
+	if err != nil {
+		return model.ImportedTransaction{}, err
+	}
+
+	amountText, ok := fields['T']
+	if !ok {
+		amountText = fields['U']
+	}
+	amount, err := model.ParseStatementAmount(amountText, p.currency)
+	if err != nil {
+		return model.ImportedTransaction{}, err
+	}
+	if amount == 0 {
+		return model.ImportedTransaction{}, errors.New("amount is zero")
+	}
+
+	tx := model.ImportedTransaction{
+		Line:        line,
+		Date:        date,
+		Description: joinDescription(fields['P'], fields['M']),
+		Type:        model.ImportIncome,
+		Amount:      amount,
+	}
+	if amount < 0 {
+		tx.Type = model.ImportExpense
+		tx.Amount = -amount
+	}
+	tx.ImportID = p.contentIDs.next(p.statement.Account, tx, fields['N'])
+	return tx, nil
+}
+
+func parseQIFDate(text string) (time.Time, error) {
+	normalized := strings.ReplaceAll(strings.ReplaceAll(strings.TrimSpace(text), " ", ""), "'", "/")
+	for _, layout := range qifDateLayouts {
+		if date, err := time.Parse(layout, normalized); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q: expected month/day/year", text)
+}
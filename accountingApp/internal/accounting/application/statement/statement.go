@@ -0,0 +1,179 @@
+package statement
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/traditionalchinese"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// MaxFileSize 帳單檔案的大小上限 (bytes)
+const MaxFileSize int64 = 5 << 20
+
+// MaxRows 單一帳單可匯入的資料列上限
+const MaxRows = 5000
+
+// utf8BOM Excel匯出的UTF-8檔案開頭常帶有BOM
+var utf8BOM = []byte("\xEF\xBB\xBF")
+
+// Format 帳單檔案格式
+type Format string
+
+const (
+	FormatCSV Format = "CSV"
+	FormatOFX Format = "OFX" // 包含QFX (Quicken使用的OFX)
+	FormatQIF Format = "QIF"
+)
+
+// DetectFormat 依指定的格式或檔名副檔名判斷帳單格式，無法判斷時視為CSV
+func DetectFormat(format, fileName string) (Format, error) {
+	switch strings.ToUpper(strings.TrimSpace(format)) {
+	case "":
+	case "CSV":
+		return FormatCSV, nil
+	case "OFX", "QFX":
+		return FormatOFX, nil
+	case "QIF":
+		return FormatQIF, nil
+	default:
+		return "", fmt.Errorf("unsupported statement format: %s", format)
+	}
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".ofx", ".qfx":
+		return FormatOFX, nil
+	case ".qif":
+		return FormatQIF, nil
+	default:
+		return FormatCSV, nil
+	}
+}
+
+// RejectedRow 無法匯入的資料列與原因
+type RejectedRow struct {
+	Line   int // 在檔案中的列號 (從1開始)
+	Reason string
+}
+
+// Statement 檔案中一個帳戶的交易；Transactions與Rejected皆依列號排序
+type Statement struct {
+	Account      string // OFX的ACCTID或QIF的!Account名稱，CSV為空
+	Transactions []model.ImportedTransaction
+	Rejected     []RejectedRow
+}
+
+// Result 帳單解析結果；CSV只有一個Statement，OFX與QIF每個帳戶各一個
+type Result struct {
+	Statements []Statement
+}
+
+// rowCount 已解析的交易與拒絕列總數
+func (r *Result) rowCount() int {
+	count := 0
+	for _, statement := range r.Statements {
+		count += len(statement.Transactions) + len(statement.Rejected)
+	}
+	return count
+}
+
+// readContent 讀取檔案內容，超過MaxFileSize時回傳error
+func readContent(r io.Reader) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(r, MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if int64(len(content)) > MaxFileSize {
+		return nil, fmt.Errorf("file exceeds the %d byte limit", MaxFileSize)
+	}
+	return content, nil
+}
+
+// decode 將檔案內容轉換為UTF-8
+func decode(content []byte, encoding model.ImportEncoding) ([]byte, error) {
+	switch encoding {
+	case model.ImportEncodingBig5:
+		decoded, err := traditionalchinese.Big5.NewDecoder().Bytes(content)
+		if err != nil {
+			return nil, fmt.Errorf("file is not valid BIG5: %w", err)
+		}
+		return decoded, nil
+	case model.ImportEncodingWindows1252:
+		return charmap.Windows1252.NewDecoder().Bytes(content)
+	}
+
+	content = bytes.TrimPrefix(content, utf8BOM)
+	if !utf8.Valid(content) {
+		return nil, errors.New("file is not valid UTF-8; check the encoding")
+	}
+	return content, nil
+}
+
+// contentImportIDs 沒有交易識別碼的格式以內容雜湊作為匯入識別
+// 同一份檔案中內容完全相同的交易 (例如同一天兩杯相同金額的咖啡) 以出現次序區分
+type contentImportIDs struct {
+	prefix string
+	seen   map[string]int
+}
+
+func newContentImportIDs(prefix string) *contentImportIDs {
+	return &contentImportIDs{prefix: prefix, seen: make(map[string]int)}
+}
+
+func (c *contentImportIDs) next(account string, tx model.ImportedTransaction, extra ...string) string {
+	parts := append([]string{
+		account,
+		tx.Date.Format("2006-01-02"),
+		string(tx.Type),
+		fmt.Sprint(tx.Amount),
+		tx.Description,
+	}, extra...)
+	key := strings.Join(parts, "\x1f")
+	c.seen[key]++
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x1f%d", key, c.seen[key])))
+	return c.prefix + hex.EncodeToString(sum[:])
+}
+
+// maxImportIDLength 匯入識別的長度上限 (import_id欄位)，較長的識別以雜湊代替
+const maxImportIDLength = 255
+
+// boundedImportID 過長的匯入識別改以其雜湊表示
+func boundedImportID(prefix, id string) string {
+	if len(prefix)+len(id) <= maxImportIDLength {
+		return prefix + id
+	}
+	sum := sha256.Sum256([]byte(id))
+	return prefix + hex.EncodeToString(sum[:])
+}
+
+// joinDescription 以不重複的非空白欄位 (例如收款人與備註) 組成交易說明
+func joinDescription(parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		duplicate := false
+		for _, k := range kept {
+			if strings.EqualFold(k, part) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, " - ")
+}
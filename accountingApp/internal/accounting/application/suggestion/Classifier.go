@@ -0,0 +1,168 @@
+package suggestion
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Prediction 一個候選子分類與其機率 (0-1，所有候選合計為1)
+type Prediction struct {
+	SubcategoryID string
+	Confidence    float64
+}
+
+// classStats 一個子分類的訓練資料
+type classStats struct {
+	documents int            // 訓練的記錄數
+	tokens    int            // 詞彙出現的總次數
+	counts    map[string]int // 詞彙出現次數
+}
+
+// Classifier 以描述詞彙訓練的多項式樸素貝氏分類器 (Laplace平滑)
+// 每筆記錄的同一詞彙只計一次；模型只存計數，可逐筆Learn與Forget增量更新
+type Classifier struct {
+	classes    map[string]*classStats
+	vocabulary map[string]int // 詞彙在所有子分類中出現的總次數，歸零時移除
+	documents  int
+}
+
+// NewClassifier 創建空的分類器
+func NewClassifier() *Classifier {
+	return &Classifier{
+		classes:    make(map[string]*classStats),
+		vocabulary: make(map[string]int),
+	}
+}
+
+// Learn 以一筆記錄的描述訓練子分類；沒有可用詞彙的描述不影響模型
+func (c *Classifier) Learn(subcategoryID, description string) {
+	tokens := Tokenize(description)
+	if subcategoryID == "" || len(tokens) == 0 {
+		return
+	}
+	stats, ok := c.classes[subcategoryID]
+	if !ok {
+		stats = &classStats{counts: make(map[string]int)}
+		c.classes[subcategoryID] = stats
+	}
+	stats.documents++
+	stats.tokens += len(tokens)
+	for _, token := range tokens {
+		stats.counts[token]++
+		c.vocabulary[token]++
+	}
+	c.documents++
+}
+
+// Forget 撤銷一筆先前以Learn訓練的記錄 (記錄被修改或刪除時)
+func (c *Classifier) Forget(subcategoryID, description string) {
+	tokens := Tokenize(description)
+	stats, ok := c.classes[subcategoryID]
+	if !ok || len(tokens) == 0 {
+		return
+	}
+	for _, token := range tokens {
+		if stats.counts[token] == 0 {
+			continue
+		}
+		stats.counts[token]--
+		stats.tokens--
+		if stats.counts[token] == 0 {
+			delete(stats.counts, token)
+		}
+		if c.vocabulary[token]--; c.vocabulary[token] <= 0 {
+			delete(c.vocabulary, token)
+		}
+	}
+	stats.documents--
+	c.documents--
+	if stats.documents <= 0 {
+		delete(c.classes, subcategoryID)
+	}
+}
+
+// Predict 依機率由高至低回傳候選子分類；描述中沒有任何學過的詞彙時回傳nil
+// limit <= 0 時回傳所有子分類
+func (c *Classifier) Predict(description string, limit int) []Prediction {
+	var known []string
+	for _, token := range Tokenize(description) {
+		if c.vocabulary[token] > 0 {
+			known = append(known, token)
+		}
+	}
+	if len(known) == 0 {
+		return nil
+	}
+
+	// 對數機率：log P(子分類) + Σ log P(詞彙 | 子分類)
+	vocabularySize := float64(len(c.vocabulary))
+	predictions := make([]Prediction, 0, len(c.classes))
+	scores := make([]float64, 0, len(c.classes))
+	best := math.Inf(-1)
+	for subcategoryID, stats := range c.classes {
+		score := math.Log(float64(stats.documents) / float64(c.documents))
+		for _, token := range known {
+			score += math.Log((float64(stats.counts[token]) + 1) / (float64(stats.tokens) + vocabularySize))
+		}
+		predictions = append(predictions, Prediction{SubcategoryID: subcategoryID})
+		scores = append(scores, score)
+		best = math.Max(best, score)
+	}
+
+	// 正規化為合計為1的機率
+	total := 0.0
+	for i, score := range scores {
+		scores[i] = math.Exp(score - best)
+		total += scores[i]
+	}
+	for i := range predictions {
+		predictions[i].Confidence = scores[i] / total
+	}
+
+	sort.Slice(predictions, func(i, j int) bool {
+		if predictions[i].Confidence != predictions[j].Confidence {
+			return predictions[i].Confidence > predictions[j].Confidence
+		}
+		return predictions[i].SubcategoryID < predictions[j].SubcategoryID
+	})
+	if limit > 0 && len(predictions) > limit {
+		predictions = predictions[:limit]
+	}
+	return predictions
+}
+
+// Tokenize 將描述切成不重複的小寫詞彙：英數字以非字母數字分隔 (忽略純數字與單一字元)，
+// 中日韓文字每個字各為一個詞彙
+func Tokenize(description string) []string {
+	seen := make(map[string]bool)
+	var tokens []string
+	add := func(token string) {
+		if !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+
+	var word []rune
+	flush := func() {
+		if len(word) > 1 && strings.IndexFunc(string(word), unicode.IsLetter) >= 0 {
+			add(string(word))
+		}
+		word = word[:0]
+	}
+	for _, r := range strings.ToLower(description) {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flush()
+			add(string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word = append(word, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
@@ -0,0 +1,230 @@
+package suggestion
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/event"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// SuggesterConfig 分類器快取的設定
+type SuggesterConfig struct {
+	MaxUsers int // 最多保存分類器的使用者數量，超過時移除最久未查詢的使用者
+}
+
+// DefaultSuggesterConfig 回傳預設設定
+func DefaultSuggesterConfig() SuggesterConfig {
+	return SuggesterConfig{MaxUsers: 1000}
+}
+
+// userModels 一個使用者的支出與收入分類器
+type userModels struct {
+	expense *Classifier
+	income  *Classifier
+	used    *list.Element // 在最近查詢順序中的位置
+}
+
+// training 一個使用者進行中的訓練，同一使用者同時的查詢等待同一次訓練
+type training struct {
+	done   chan struct{} // 訓練結束時關閉
+	models *userModels
+	err    error
+	stale  bool // 訓練期間收到該使用者的記錄事件，結果可能缺少變更而不保存
+}
+
+// Suggester 依使用者自己的支出與收入記錄建議子分類
+// 分類器在使用者第一次查詢時由所有錢包的記錄訓練，之後由記錄的領域事件增量更新；
+// 拆帳支出的描述涵蓋多個子分類，不用於訓練。
+// 訓練在鎖外進行，不阻擋其他使用者的查詢與事件處理；錢包被刪除時移除該使用者的分類器，下次查詢重新訓練
+type Suggester struct {
+	walletRepo repository.WalletRepository
+	config     SuggesterConfig
+
+	mu       sync.Mutex
+	models   map[string]*userModels // 以使用者ID為鍵，只保存查詢過的使用者
+	recent   *list.List             // 使用者ID，最近查詢的在前
+	training map[string]*training
+}
+
+// NewSuggester 創建子分類建議器；需以Subscribe訂閱記錄事件才會增量更新
+func NewSuggester(walletRepo repository.WalletRepository, config SuggesterConfig) *Suggester {
+	if config.MaxUsers <= 0 {
+		config.MaxUsers = DefaultSuggesterConfig().MaxUsers
+	}
+	return &Suggester{
+		walletRepo: walletRepo,
+		config:     config,
+		models:     make(map[string]*userModels),
+		recent:     list.New(),
+		training:   make(map[string]*training),
+	}
+}
+
+// Subscribe 訂閱支出與收入的新增、修改與刪除事件，以及錢包的刪除事件
+func (s *Suggester) Subscribe(dispatcher *event.Dispatcher) {
+	for _, name := range []string{
+		model.EventExpenseAdded, model.EventExpenseUpdated, model.EventExpenseRemoved,
+		model.EventIncomeAdded, model.EventIncomeUpdated, model.EventIncomeRemoved,
+		model.EventWalletDeleted,
+	} {
+		dispatcher.Subscribe(name, s)
+	}
+}
+
+// Suggest 依描述回傳候選子分類，機率由高至低；limit <= 0 時回傳所有候選
+func (s *Suggester) Suggest(userID string, recordType model.CategorizationRuleType, description string, limit int) ([]Prediction, error) {
+	models, err := s.load(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if recordType == model.CategorizeIncome {
+		return models.income.Predict(description, limit), nil
+	}
+	return models.expense.Predict(description, limit), nil
+}
+
+// load 取得使用者的分類器，尚未訓練時在鎖外訓練；同一使用者同時的查詢共用一次訓練
+func (s *Suggester) load(userID string) (*userModels, error) {
+	s.mu.Lock()
+	if models, ok := s.models[userID]; ok {
+		s.recent.MoveToFront(models.used)
+		s.mu.Unlock()
+		return models, nil
+	}
+	current, inProgress := s.training[userID]
+	if !inProgress {
+		current = &training{done: make(chan struct{})}
+		s.training[userID] = current
+	}
+	s.mu.Unlock()
+
+	if inProgress {
+		<-current.done
+		return current.models, current.err
+	}
+
+	models, err := s.train(userID)
+
+	s.mu.Lock()
+	current.models, current.err = models, err
+	delete(s.training, userID)
+	if err == nil && !current.stale {
+		s.store(userID, models)
+	}
+	s.mu.Unlock()
+	close(current.done)
+	return models, err
+}
+
+// train 以使用者所有錢包的記錄訓練分類器
+func (s *Suggester) train(userID string) (*userModels, error) {
+	wallets, err := s.walletRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve wallets: %w", err)
+	}
+	models := &userModels{expense: NewClassifier(), income: NewClassifier()}
+	for _, summary := range wallets {
+		wallet, err := s.walletRepo.FindByIDWithTransactions(summary.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load wallet %s: %w", summary.ID, err)
+		}
+		if wallet == nil {
+			continue
+		}
+		for _, expense := range wallet.GetExpenseRecords() {
+			learnExpense(models.expense, expense)
+		}
+		for _, income := range wallet.GetIncomeRecords() {
+			models.income.Learn(income.SubcategoryID, income.Description)
+		}
+	}
+	return models, nil
+}
+
+// store 保存使用者的分類器，超過MaxUsers時移除最久未查詢的使用者 (呼叫者須持有鎖)
+func (s *Suggester) store(userID string, models *userModels) {
+	models.used = s.recent.PushFront(userID)
+	s.models[userID] = models
+	for s.recent.Len() > s.config.MaxUsers {
+		s.evict(s.recent.Back().Value.(string))
+	}
+}
+
+// trained 回傳使用者已訓練的分類器；該使用者正在訓練時，訓練結果可能缺少此變更而不保存 (呼叫者須持有鎖)
+func (s *Suggester) trained(userID string) (*userModels, bool) {
+	if current, ok := s.training[userID]; ok {
+		current.stale = true
+	}
+	models, ok := s.models[userID]
+	return models, ok
+}
+
+// evict 移除使用者的分類器，並讓進行中的訓練結果不被保存 (呼叫者須持有鎖)
+func (s *Suggester) evict(userID string) {
+	if models, ok := s.models[userID]; ok {
+		s.recent.Remove(models.used)
+		delete(s.models, userID)
+	}
+	if current, ok := s.training[userID]; ok {
+		current.stale = true
+	}
+}
+
+// Handle 以記錄事件更新已訓練的分類器；尚未查詢過的使用者在第一次查詢時才由歷史訓練
+func (s *Suggester) Handle(e model.DomainEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch e := e.(type) {
+	case model.WalletDeleted:
+		s.evict(e.UserID)
+	case model.ExpenseAdded:
+		if models, ok := s.trained(e.UserID); ok {
+			learnExpense(models.expense, e.Expense)
+		}
+	case model.ExpenseUpdated:
+		if models, ok := s.trained(e.UserID); ok {
+			forgetExpense(models.expense, e.Previous)
+			learnExpense(models.expense, e.Expense)
+		}
+	case model.ExpenseRemoved:
+		if models, ok := s.trained(e.UserID); ok {
+			forgetExpense(models.expense, e.Expense)
+		}
+	case model.IncomeAdded:
+		if models, ok := s.trained(e.UserID); ok {
+			models.income.Learn(e.Income.SubcategoryID, e.Income.Description)
+		}
+	case model.IncomeUpdated:
+		if models, ok := s.trained(e.UserID); ok {
+			models.income.Forget(e.Previous.SubcategoryID, e.Previous.Description)
+			models.income.Learn(e.Income.SubcategoryID, e.Income.Description)
+		}
+	case model.IncomeRemoved:
+		if models, ok := s.trained(e.UserID); ok {
+			models.income.Forget(e.Income.SubcategoryID, e.Income.Description)
+		}
+	}
+	return nil
+}
+
+func learnExpense(classifier *Classifier, expense model.ExpenseRecord) {
+	if len(expense.Splits) == 0 {
+		classifier.Learn(expense.SubcategoryID, expense.Description)
+	}
+}
+
+func forgetExpense(classifier *Classifier, expense model.ExpenseRecord) {
+	if len(expense.Splits) == 0 {
+		classifier.Forget(expense.SubcategoryID, expense.Description)
+	}
+}
+
+// 確保Suggester實現event.Handler介面
+var _ event.Handler = (*Suggester)(nil)
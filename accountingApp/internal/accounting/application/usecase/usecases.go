@@ -1,53 +1,228 @@
 package usecase
 
 import (
+	"encoding/json"
+	"io"
+	"time"
+
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
 	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
-	"time"
 )
 
 // =============================================================================
 // INPUT/OUTPUT CONTRACTS
 // =============================================================================
 
+// CommandMetadata identifies who issued a command and the HTTP request it came
+// from; it is embedded in every command input and recorded in the audit log.
+type CommandMetadata struct {
+	ActorID   string // Authenticated caller (the administrator for admin commands)
+	RequestID string // X-Request-ID of the originating request
+}
+
+// Metadata returns the metadata embedded in a command input
+func (m CommandMetadata) Metadata() CommandMetadata { return m }
+
 // Command Inputs
+//
+// UserID is the authenticated caller taken from the request context; services
+// report resources owned by other users as not found.
 type CreateWalletInput struct {
+	CommandMetadata
 	UserID         string
 	Name           string
 	Type           string
 	Currency       string
 	InitialBalance *int64 // Optional initial balance in cents/smallest currency unit
+	Locale         string // Optional - default category template locale for the user's first wallet
 }
 
+// AddExpenseInput records an expense in one subcategory, or split across several
+// when Splits is set (SubcategoryID must then be empty). Without either, the
+// caller's categorization rules pick the subcategory when the service has them.
 type AddExpenseInput struct {
+	CommandMetadata
+	UserID        string
 	WalletID      string
 	SubcategoryID string
 	Amount        int64
 	Currency      string
 	Description   string
 	Date          time.Time
+	Splits        []ExpenseSplitInput // Optional - at least two lines that sum to Amount
+	Tags          []string            // Optional - free-form tags, normalized to lower case
+
+	// OccurrenceKey is set by the recurring scheduler; an expense already added for the
+	// occurrence is returned instead of adding another
+	OccurrenceKey string
+}
+
+// ExpenseSplitInput is one line of a split expense, in the expense's currency
+type ExpenseSplitInput struct {
+	SubcategoryID string
+	Amount        int64
+	Memo          string
 }
 
 type AddIncomeInput struct {
+	CommandMetadata
+	UserID        string
 	WalletID      string
 	SubcategoryID string
 	Amount        int64
 	Currency      string
 	Description   string
 	Date          time.Time
+	Tags          []string // Optional - free-form tags, normalized to lower case
+
+	// OccurrenceKey is set by the recurring scheduler; an income already added for the
+	// occurrence is returned instead of adding another
+	OccurrenceKey string
+}
+
+// UpdateExpenseInput replaces an expense; without Splits any existing split lines are removed.
+type UpdateExpenseInput struct {
+	CommandMetadata
+	UserID        string
+	ExpenseID     string
+	SubcategoryID string
+	Amount        int64
+	Currency      string
+	Description   string
+	Date          time.Time
+	Splits        []ExpenseSplitInput // Optional - at least two lines that sum to Amount
+}
+
+type DeleteExpenseInput struct {
+	CommandMetadata
+	UserID    string
+	ExpenseID string
+}
+
+type UpdateIncomeInput struct {
+	CommandMetadata
+	UserID        string
+	IncomeID      string
+	SubcategoryID string
+	Amount        int64
+	Currency      string
+	Description   string
+	Date          time.Time
+}
+
+type DeleteIncomeInput struct {
+	CommandMetadata
+	UserID   string
+	IncomeID string
+}
+
+type ProcessTransferInput struct {
+	CommandMetadata
+	UserID       string
+	FromWalletID string    // 來源錢包ID
+	ToWalletID   string    // 目標錢包ID
+	Amount       int64     // 轉帳金額 (cents)
+	Currency     string    // 貨幣 (來源錢包的幣別)
+	ToAmount     int64     // 目標錢包幣別的金額 (跨幣別時選填)
+	Rate         string    // 1單位來源幣別兌換的目標幣別 (跨幣別時選填，與ToAmount擇一)
+	Fee          int64     // 手續費 (cents)
+	FeeCurrency  string    // 手續費幣別，來源或目標錢包的幣別 (預設為Currency)
+	Description  string    // 描述
+	Date         time.Time // 轉帳日期
+	Tags         []string  // 標籤 (選填)
 }
 
 type CreateExpenseCategoryInput struct {
+	CommandMetadata
 	UserID string
 	Name   string
 }
 
 type CreateIncomeCategoryInput struct {
+	CommandMetadata
 	UserID string
 	Name   string
 }
 
+type InitializeDefaultCategoriesInput struct {
+	CommandMetadata
+	UserID string
+	Locale string // Optional - template locale such as "zh-TW" or "en" (defaults to zh-TW)
+}
+
+type RenameExpenseCategoryInput struct {
+	CommandMetadata
+	UserID     string
+	CategoryID string
+	Name       string
+}
+
+type DeleteExpenseCategoryInput struct {
+	CommandMetadata
+	UserID     string
+	CategoryID string
+}
+
+type AddExpenseSubcategoryInput struct {
+	CommandMetadata
+	UserID     string
+	CategoryID string
+	Name       string
+}
+
+type RenameExpenseSubcategoryInput struct {
+	CommandMetadata
+	UserID        string
+	CategoryID    string
+	SubcategoryID string
+	Name          string
+}
+
+type RemoveExpenseSubcategoryInput struct {
+	CommandMetadata
+	UserID        string
+	CategoryID    string
+	SubcategoryID string
+}
+
+type RenameIncomeCategoryInput struct {
+	CommandMetadata
+	UserID     string
+	CategoryID string
+	Name       string
+}
+
+type DeleteIncomeCategoryInput struct {
+	CommandMetadata
+	UserID     string
+	CategoryID string
+}
+
+type AddIncomeSubcategoryInput struct {
+	CommandMetadata
+	UserID     string
+	CategoryID string
+	Name       string
+}
+
+type RenameIncomeSubcategoryInput struct {
+	CommandMetadata
+	UserID        string
+	CategoryID    string
+	SubcategoryID string
+	Name          string
+}
+
+type RemoveIncomeSubcategoryInput struct {
+	CommandMetadata
+	UserID        string
+	CategoryID    string
+	SubcategoryID string
+}
+
 type UpdateWalletInput struct {
+	CommandMetadata
+	UserID   string
 	WalletID string
 	Name     *string // Optional - only update if provided
 	Type     *string // Optional - only update if provided
@@ -55,21 +230,266 @@ type UpdateWalletInput struct {
 }
 
 type DeleteWalletInput struct {
+	CommandMetadata
+	UserID   string
 	WalletID string
 }
 
+type CreateAPIKeyInput struct {
+	CommandMetadata
+	UserID string
+	Name   string
+}
+
+type RevokeAPIKeyInput struct {
+	CommandMetadata
+	UserID string
+	KeyID  string
+}
+
+// CreateBudgetInput creates a budget for an expense category (all of its
+// subcategories) or a single subcategory; exactly one of the two must be set.
+type CreateBudgetInput struct {
+	CommandMetadata
+	UserID           string
+	Name             string
+	Period           string // MONTHLY, WEEKLY or CUSTOM
+	CategoryID       string
+	SubcategoryID    string
+	Amount           int64 // Per-period amount in the smallest currency unit
+	Currency         string
+	Rollover         bool       // Carry unused amount into the next period
+	WarningThreshold *int // Optional - percent of the budget that triggers a warning (default 80)
+	StartDate        time.Time
+	EndDate          *time.Time // Exclusive; required for CUSTOM, optional otherwise
+}
+
+// UpdateBudgetInput changes a budget; nil fields are left unchanged.
+// Period and scope are fixed once created.
+type UpdateBudgetInput struct {
+	CommandMetadata
+	UserID           string
+	BudgetID         string
+	Name             *string
+	Amount           *int64
+	Currency         *string
+	Rollover         *bool
+	WarningThreshold *int
+	EndDate          *time.Time
+}
+
+type DeleteBudgetInput struct {
+	CommandMetadata
+	UserID   string
+	BudgetID string
+}
+
+// CreateRecurringRuleInput creates a schedule that generates an expense or income
+// in a wallet. EndDate and Count are mutually exclusive; omit both for no end.
+type CreateRecurringRuleInput struct {
+	CommandMetadata
+	UserID          string
+	Name            string
+	Type            string // EXPENSE or INCOME
+	WalletID        string
+	SubcategoryID   string
+	Amount          int64 // In the smallest currency unit
+	Currency        string
+	Description     string
+	Frequency       string // DAILY, WEEKLY, MONTHLY or YEARLY
+	Interval        int    // Every N frequency units (default 1)
+	DayOfMonth      int    // MONTHLY only - 1-31, clamped to the month's last day (default: the start date's day)
+	LastBusinessDay bool   // MONTHLY only - last Monday-Friday of the month
+	StartDate       time.Time
+	EndDate         *time.Time // Inclusive
+	Count           int        // Total number of occurrences, 0 for unlimited
+}
+
+type PauseRecurringRuleInput struct {
+	CommandMetadata
+	UserID string
+	RuleID string
+}
+
+// ResumeRecurringRuleInput reactivates a paused rule; dates that fell due between
+// the pause and the day before resuming are skipped rather than generated.
+type ResumeRecurringRuleInput struct {
+	CommandMetadata
+	UserID string
+	RuleID string
+}
+
+type SkipRecurringOccurrenceInput struct {
+	CommandMetadata
+	UserID string
+	RuleID string
+	Date   time.Time // Must be an occurrence of the rule that has not been generated
+}
+
+type DeleteRecurringRuleInput struct {
+	CommandMetadata
+	UserID string
+	RuleID string
+}
+
+// RetryOutboxMessageInput requeues a stuck outbox message (administrators only)
+type RetryOutboxMessageInput struct {
+	CommandMetadata
+	MessageID string
+}
+
+// EditTransactionTagsInput adds and removes tags on many transactions at once.
+// A transfer's tags are shared by both of its wallets.
+type EditTransactionTagsInput struct {
+	CommandMetadata
+	UserID      string
+	ExpenseIDs  []string
+	IncomeIDs   []string
+	TransferIDs []string
+	Add         []string
+	Remove      []string
+}
+
+// UploadAttachmentInput attaches a receipt or document to an expense or income
+// record. Content is read once and rejected beyond model.MaxAttachmentSize.
+type UploadAttachmentInput struct {
+	CommandMetadata
+	UserID      string
+	RecordType  string // EXPENSE or INCOME
+	RecordID    string
+	FileName    string
+	ContentType string // Declared type; must match the sniffed type of Content
+	Content     io.Reader
+}
+
+type DeleteAttachmentInput struct {
+	CommandMetadata
+	UserID       string
+	AttachmentID string
+}
+
+// CreateImportProfileInput saves a column mapping for a bank's statement CSV
+type CreateImportProfileInput struct {
+	CommandMetadata
+	UserID  string
+	Name    string
+	Mapping ImportMappingData
+}
+
+// UpdateImportProfileInput replaces a profile's name and column mapping
+type UpdateImportProfileInput struct {
+	CommandMetadata
+	UserID    string
+	ProfileID string
+	Name      string
+	Mapping   ImportMappingData
+}
+
+type DeleteImportProfileInput struct {
+	CommandMetadata
+	UserID    string
+	ProfileID string
+}
+
+// StatementFile is an uploaded statement and what is needed to read it.
+// Content is read once, up to statement.MaxFileSize.
+type StatementFile struct {
+	Format   string // CSV, OFX (also QFX) or QIF; detected from FileName when empty
+	FileName string
+	Content  io.Reader
+
+	// CSV: the import profile with the column mapping and subcategories
+	ProfileID string
+
+	// OFX and QIF: the subcategories of the created expenses and incomes, and
+	// the QIF text encoding (OFX files declare their own)
+	ExpenseSubcategoryID string
+	IncomeSubcategoryID  string
+	Encoding             string
+}
+
+// CommitImportInput records the rows of a statement as expenses and incomes in
+// a wallet in one save. Rows that cannot be parsed or recorded are reported as
+// rejected; rows imported before (same FITID or QIF content) are skipped.
+type CommitImportInput struct {
+	CommandMetadata
+	StatementFile
+	UserID   string
+	WalletID string
+}
+
+// CreateCategorizationRuleInput saves a rule that assigns SubcategoryID and Tags
+// to imported or entered transactions matching all of its Conditions
+type CreateCategorizationRuleInput struct {
+	CommandMetadata
+	UserID        string
+	Name          string
+	Type          string // EXPENSE or INCOME
+	Priority      int    // Lower runs first; ties go to the older rule
+	Conditions    RuleConditionsData
+	SubcategoryID string
+	Tags          []string
+}
+
+// UpdateCategorizationRuleInput replaces a rule's name, priority, conditions and
+// assignment; the transaction type cannot change
+type UpdateCategorizationRuleInput struct {
+	CommandMetadata
+	UserID        string
+	RuleID        string
+	Name          string
+	Priority      int
+	Conditions    RuleConditionsData
+	SubcategoryID string
+	Tags          []string
+}
+
+type DeleteCategorizationRuleInput struct {
+	CommandMetadata
+	UserID string
+	RuleID string
+}
+
+// ResolveDuplicateInput settles a flagged duplicate: MERGE folds the newer record
+// into the one already in the wallet, KEEP_BOTH changes nothing and DELETE
+// removes the newer record.
+type ResolveDuplicateInput struct {
+	CommandMetadata
+	UserID     string
+	FlagID     string
+	Resolution string
+}
+
+// ImportExchangeRatesInput loads dated exchange rates from a CSV file with the
+// header date,base,quote,rate (administrators only). A rate already stored for
+// the same pair and day is replaced; nothing is stored when any line is invalid.
+type ImportExchangeRatesInput struct {
+	CommandMetadata
+	Content io.Reader
+}
+
+// RefreshExchangeRatesInput stores the rates of a day fetched from the
+// configured rate provider (administrators only)
+type RefreshExchangeRatesInput struct {
+	CommandMetadata
+	Date time.Time // Zero means today
+}
+
 // Query Inputs
 type GetWalletInput struct {
+	UserID              string
 	WalletID            string
 	IncludeTransactions bool
 }
 
 type GetWalletBalanceInput struct {
+	UserID   string
 	WalletID string
 }
 
 type GetWalletsInput struct {
-	UserID string
+	UserID            string
+	ReportingCurrency string // Optional - also reports each balance and the total in this currency
 }
 
 type GetExpenseCategoriesInput struct {
@@ -89,6 +509,8 @@ type GetIncomesInput struct {
 	MinAmount    *int64  // Optional amount range filter (in cents)
 	MaxAmount    *int64  // Optional amount range filter (in cents)
 	Description  *string // Optional description search filter
+	Tags         []string // Optional tag filter
+	TagMatch     string   // "any" (default) or "all" of Tags
 }
 
 type GetExpensesInput struct {
@@ -100,8 +522,178 @@ type GetExpensesInput struct {
 	MinAmount    *int64  // Optional amount range filter (in cents)
 	MaxAmount    *int64  // Optional amount range filter (in cents)
 	Description  *string // Optional description search filter
+	Tags         []string // Optional tag filter
+	TagMatch     string   // "any" (default) or "all" of Tags
 }
 
+type GetTransfersInput struct {
+	UserID      string
+	WalletID    *string    // Optional filter (matches source or destination wallet)
+	StartDate   *time.Time // Optional date range filter
+	EndDate     *time.Time // Optional date range filter
+	MinAmount   *int64     // Optional amount range filter (in cents)
+	MaxAmount   *int64     // Optional amount range filter (in cents)
+	Description *string    // Optional description search filter
+}
+
+type GetAPIKeysInput struct {
+	UserID string
+}
+
+type AuthenticateAPIKeyInput struct {
+	Key string // Plaintext key as sent in the X-API-Key header
+}
+
+type GetBudgetsInput struct {
+	UserID string
+}
+
+type GetBudgetStatusInput struct {
+	UserID   string
+	BudgetID string
+	At       time.Time // Period to report on; zero means now
+}
+
+type GetRecurringRulesInput struct {
+	UserID string
+}
+
+type PreviewRecurringRuleInput struct {
+	UserID string
+	RuleID string
+	From   time.Time // Zero means today
+	Limit  int       // Number of occurrences, 0 for the default
+}
+
+// GetTagSummaryInput totals the caller's tagged transactions per tag and currency;
+// an empty Tags reports every tag.
+type GetTagSummaryInput struct {
+	UserID    string
+	StartDate *time.Time // Optional - inclusive
+	EndDate   *time.Time // Optional - inclusive
+	Tags      []string
+
+	// Optional - also reports the totals in this currency, converted with the
+	// rates of EndDate (today when EndDate is not set)
+	ReportingCurrency string
+}
+
+type GetAttachmentsInput struct {
+	UserID     string
+	RecordType string // EXPENSE or INCOME
+	RecordID   string
+}
+
+type GetAttachmentContentInput struct {
+	UserID       string
+	AttachmentID string
+}
+
+type GetImportProfilesInput struct {
+	UserID string
+}
+
+// PreviewImportInput parses a statement without recording anything
+type PreviewImportInput struct {
+	StatementFile
+	UserID   string
+	WalletID string // Amounts are parsed in the wallet's currency
+}
+
+// GetDuplicatesInput lists the caller's duplicate flags; an empty Status lists
+// the review queue (PENDING) and an empty WalletID every wallet.
+type GetDuplicatesInput struct {
+	UserID   string
+	WalletID string
+	Status   string
+}
+
+// GetCategorizationRulesInput lists the caller's rules in priority order; an
+// empty Type lists both expense and income rules
+type GetCategorizationRulesInput struct {
+	UserID string
+	Type   string
+}
+
+// TestCategorizationRuleInput is a sample transaction to run the caller's rules
+// against without recording anything
+type TestCategorizationRuleInput struct {
+	UserID      string
+	Type        string // EXPENSE or INCOME
+	WalletID    string // Optional
+	Description string
+	Amount      int64
+	Currency    string
+	Date        time.Time // Zero means today
+}
+
+// GetCategorySuggestionsInput asks for the subcategories the caller's own
+// history suggests for a description
+type GetCategorySuggestionsInput struct {
+	UserID      string
+	Description string
+	Type        string // EXPENSE or INCOME; empty means EXPENSE
+	Limit       int    // Maximum number of candidates; zero means the default
+}
+
+// GetExchangeRatesInput lists the latest stored rate of every currency pair on
+// or before Date, or the full history of one pair when Base and Quote are set
+type GetExchangeRatesInput struct {
+	Base  string
+	Quote string
+	Date  time.Time // Zero means today
+}
+
+// ConvertMoneyInput converts an amount in the smallest unit of From into To
+// with the latest rate on or before Date
+type ConvertMoneyInput struct {
+	Amount int64
+	From   string
+	To     string
+	Date   time.Time // Zero means today
+}
+
+// GetCurrenciesInput lists the ISO 4217 currency registry; Locale (e.g. "de-DE")
+// selects how the example amounts are written and falls back to a supported
+// locale of the same language, then to English
+type GetCurrenciesInput struct {
+	Locale string
+}
+
+// CheckBudgetWarningsInput describes an expense that has just been recorded;
+// budgets it pushed past their warning threshold or limit are reported.
+type CheckBudgetWarningsInput struct {
+	UserID        string
+	SubcategoryID string
+	Amount        int64
+	Currency      string
+	Date          time.Time
+	Splits        []ExpenseSplitInput // Set for split expenses; each budget counts only the lines it covers
+}
+
+// GetOutboxMessagesInput lists outbox messages; an empty Status returns stuck messages
+// (dead letters and messages still being retried)
+type GetOutboxMessagesInput struct {
+	Status string
+	Limit  int
+}
+
+// GetAuditLogInput filters the audit log; empty fields match everything.
+// Non-administrators may only query their own entries (ActorID is forced to the caller).
+type GetAuditLogInput struct {
+	ActorID       string
+	Command       string
+	AggregateType string
+	AggregateID   string
+	RequestID     string
+	From          *time.Time // Optional - inclusive
+	To            *time.Time // Optional - exclusive
+	Limit         int
+}
+
+// VerifyAuditLogInput re-computes the audit log hash chain (administrators only)
+type VerifyAuditLogInput struct{}
+
 // Query Outputs (specialized outputs for queries that return data)
 type GetWalletOutput struct {
 	ID       string          `json:"id"`
@@ -131,6 +723,11 @@ type GetWalletsOutput struct {
 	ExitCode common.ExitCode `json:"exit_code"`
 	Message  string          `json:"message"`
 	Wallets  []*model.Wallet `json:"wallets,omitempty"`
+
+	// Set when a reporting currency was requested
+	ReportingCurrency string                        `json:"reporting_currency,omitempty"`
+	ConvertedBalances map[string]ConvertedMoneyData `json:"converted_balances,omitempty"` // Keyed by wallet ID
+	TotalBalance      *MoneyData                    `json:"total_balance,omitempty"`      // Sum of the converted balances
 }
 
 func (o GetWalletsOutput) GetID() string                { return o.ID }
@@ -164,6 +761,7 @@ type IncomeRecordData struct {
 	Description string `json:"description"`
 	Date        string `json:"date"`        // ISO format
 	CreatedAt   string `json:"created_at"`  // ISO format
+	Tags        []string `json:"tags,omitempty"`
 }
 
 // Expense record structure for API responses
@@ -178,6 +776,42 @@ type ExpenseRecordData struct {
 	Description string `json:"description"`
 	Date        string `json:"date"`        // ISO format
 	CreatedAt   string `json:"created_at"`  // ISO format
+	Tags        []string `json:"tags,omitempty"`
+
+	// Splits lists the lines of a split expense; SubcategoryID is then the first line's
+	Splits []ExpenseSplitData `json:"splits,omitempty"`
+	// CategoryAmount is the part of Amount in the filtered subcategory, set only
+	// when filtering by category
+	CategoryAmount *int64 `json:"category_amount,omitempty"`
+}
+
+// Split line structure for API responses
+type ExpenseSplitData struct {
+	SubcategoryID string `json:"subcategory_id"`
+	Amount        int64  `json:"amount"` // Amount in cents, in the expense's currency
+	Memo          string `json:"memo,omitempty"`
+}
+
+// Transfer record structure for API responses
+type TransferRecordData struct {
+	ID           string `json:"id"`
+	FromWalletID string `json:"from_wallet_id"`
+	ToWalletID   string `json:"to_wallet_id"`
+	Amount       struct {
+		Amount   int64  `json:"amount"`   // Amount in cents
+		Currency string `json:"currency"`
+	} `json:"amount"`
+	ToAmount MoneyData `json:"to_amount"` // Amount received, in the destination wallet's currency
+	Fee struct {
+		Amount   int64  `json:"amount"`   // Fee in cents
+		Currency string `json:"currency"`
+	} `json:"fee"`
+	Rate        string `json:"rate,omitempty"`      // Implied rate of a cross-currency transfer
+	Direction   string `json:"direction,omitempty"` // "outgoing" or "incoming", relative to the walletID filter
+	Description string `json:"description"`
+	Date        string `json:"date"`        // ISO format
+	CreatedAt   string `json:"created_at"`  // ISO format
+	Tags        []string `json:"tags,omitempty"`
 }
 
 type GetExpenseCategoriesOutput struct {
@@ -226,6 +860,673 @@ func (o GetExpensesOutput) GetID() string                { return o.ID }
 func (o GetExpensesOutput) GetExitCode() common.ExitCode { return o.ExitCode }
 func (o GetExpensesOutput) GetMessage() string           { return o.Message }
 
+type GetTransfersOutput struct {
+	ID       string               `json:"id"`
+	ExitCode common.ExitCode      `json:"exit_code"`
+	Message  string               `json:"message"`
+	Data     []TransferRecordData `json:"data,omitempty"`
+	Count    int                  `json:"count"`
+}
+
+func (o GetTransfersOutput) GetID() string                { return o.ID }
+func (o GetTransfersOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o GetTransfersOutput) GetMessage() string           { return o.Message }
+
+// CreateAPIKeyOutput carries the plaintext key, which is only available at creation time
+type CreateAPIKeyOutput struct {
+	ID       string          `json:"id"`
+	ExitCode common.ExitCode `json:"exit_code"`
+	Message  string          `json:"message"`
+	Key      string          `json:"key,omitempty"`
+}
+
+func (o CreateAPIKeyOutput) GetID() string                { return o.ID }
+func (o CreateAPIKeyOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o CreateAPIKeyOutput) GetMessage() string           { return o.Message }
+
+// API key structure for API responses (never includes the key or its hash)
+type APIKeyData struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Active    bool   `json:"active"`
+	CreatedAt string `json:"created_at"`           // ISO format
+	RevokedAt string `json:"revoked_at,omitempty"` // ISO format
+}
+
+type GetAPIKeysOutput struct {
+	ID       string          `json:"id"`
+	ExitCode common.ExitCode `json:"exit_code"`
+	Message  string          `json:"message"`
+	Keys     []APIKeyData    `json:"keys"`
+}
+
+func (o GetAPIKeysOutput) GetID() string                { return o.ID }
+func (o GetAPIKeysOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o GetAPIKeysOutput) GetMessage() string           { return o.Message }
+
+// AddExpenseOutput reports budgets the new expense pushed past their warning
+// threshold and records it looks like a duplicate of; the expense is recorded
+// either way.
+type AddExpenseOutput struct {
+	ID         string          `json:"id"`
+	ExitCode   common.ExitCode `json:"exit_code"`
+	Message    string          `json:"message"`
+	Warnings   []BudgetWarning `json:"warnings,omitempty"`
+	Duplicates []DuplicateData `json:"duplicates,omitempty"`
+}
+
+func (o AddExpenseOutput) GetID() string                { return o.ID }
+func (o AddExpenseOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o AddExpenseOutput) GetMessage() string           { return o.Message }
+
+// AddIncomeOutput reports records the new income looks like a duplicate of;
+// the income is recorded either way.
+type AddIncomeOutput struct {
+	ID         string          `json:"id"`
+	ExitCode   common.ExitCode `json:"exit_code"`
+	Message    string          `json:"message"`
+	Duplicates []DuplicateData `json:"duplicates,omitempty"`
+}
+
+func (o AddIncomeOutput) GetID() string                { return o.ID }
+func (o AddIncomeOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o AddIncomeOutput) GetMessage() string           { return o.Message }
+
+// Budget structure for API responses
+type BudgetData struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	Period           string `json:"period"`
+	CategoryID       string `json:"category_id,omitempty"`
+	SubcategoryID    string `json:"subcategory_id,omitempty"`
+	Amount           int64  `json:"amount"` // Per-period amount in the smallest currency unit
+	Currency         string `json:"currency"`
+	Rollover         bool   `json:"rollover"`
+	WarningThreshold int    `json:"warning_threshold"`  // Percent
+	StartDate        string `json:"start_date"`         // YYYY-MM-DD
+	EndDate          string `json:"end_date,omitempty"` // YYYY-MM-DD, exclusive
+	CreatedAt        string `json:"created_at"`         // ISO format
+	UpdatedAt        string `json:"updated_at"`         // ISO format
+}
+
+// Budget status for a single period; amounts are in the smallest currency unit
+type BudgetStatusData struct {
+	BudgetID    string  `json:"budget_id"`
+	PeriodStart string  `json:"period_start"` // YYYY-MM-DD
+	PeriodEnd   string  `json:"period_end"`   // YYYY-MM-DD, exclusive
+	Currency    string  `json:"currency"`
+	Budgeted    int64   `json:"budgeted"`
+	Carryover   int64   `json:"carryover"` // Unused amount rolled over from earlier periods
+	Available   int64   `json:"available"` // budgeted + carryover
+	Spent       int64   `json:"spent"`
+	Remaining   int64   `json:"remaining"` // Negative when over budget
+	PercentUsed float64 `json:"percent_used"`
+	Warning     bool    `json:"warning"` // percent_used reached the warning threshold
+	OverBudget  bool    `json:"over_budget"`
+}
+
+// BudgetWarning is raised when an expense pushes a budget past its warning
+// threshold, or past its limit
+type BudgetWarning struct {
+	BudgetID   string           `json:"budget_id"`
+	BudgetName string           `json:"budget_name"`
+	Threshold  int              `json:"threshold"`
+	Message    string           `json:"message"`
+	Status     BudgetStatusData `json:"status"`
+}
+
+type GetBudgetsOutput struct {
+	ID       string          `json:"id"`
+	ExitCode common.ExitCode `json:"exit_code"`
+	Message  string          `json:"message"`
+	Budgets  []BudgetData    `json:"budgets"`
+}
+
+func (o GetBudgetsOutput) GetID() string                { return o.ID }
+func (o GetBudgetsOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o GetBudgetsOutput) GetMessage() string           { return o.Message }
+
+type GetBudgetStatusOutput struct {
+	ID       string            `json:"id"`
+	ExitCode common.ExitCode   `json:"exit_code"`
+	Message  string            `json:"message"`
+	Budget   *BudgetData       `json:"budget,omitempty"`
+	Status   *BudgetStatusData `json:"status,omitempty"` // nil when the date is outside the budget's active range
+}
+
+func (o GetBudgetStatusOutput) GetID() string                { return o.ID }
+func (o GetBudgetStatusOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o GetBudgetStatusOutput) GetMessage() string           { return o.Message }
+
+type CheckBudgetWarningsOutput struct {
+	ID       string          `json:"id"`
+	ExitCode common.ExitCode `json:"exit_code"`
+	Message  string          `json:"message"`
+	Warnings []BudgetWarning `json:"warnings"`
+}
+
+func (o CheckBudgetWarningsOutput) GetID() string                { return o.ID }
+func (o CheckBudgetWarningsOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o CheckBudgetWarningsOutput) GetMessage() string           { return o.Message }
+
+// Recurring rule structure for API responses
+type RecurringRuleData struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+	WalletID        string `json:"wallet_id"`
+	SubcategoryID   string `json:"subcategory_id"`
+	Amount          int64  `json:"amount"`
+	Currency        string `json:"currency"`
+	Description     string `json:"description"`
+	Frequency       string `json:"frequency"`
+	Interval        int    `json:"interval"`
+	DayOfMonth      int    `json:"day_of_month,omitempty"`
+	LastBusinessDay bool   `json:"last_business_day,omitempty"`
+	StartDate       string `json:"start_date"`         // YYYY-MM-DD
+	EndDate         string `json:"end_date,omitempty"` // YYYY-MM-DD, inclusive
+	Count           int    `json:"count,omitempty"`
+	Status          string `json:"status"`
+	PausedAt        string `json:"paused_at,omitempty"`       // ISO format
+	NextOccurrence  string `json:"next_occurrence,omitempty"` // YYYY-MM-DD, first date not yet processed
+	CreatedAt       string `json:"created_at"`                // ISO format
+	UpdatedAt       string `json:"updated_at"`                // ISO format
+}
+
+// A single date of a recurring rule: SCHEDULED, GENERATED, SKIPPED or FAILED
+type RecurringOccurrenceData struct {
+	Date          string `json:"date"` // YYYY-MM-DD
+	Status        string `json:"status"`
+	TransactionID string `json:"transaction_id,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+type GetRecurringRulesOutput struct {
+	ID       string              `json:"id"`
+	ExitCode common.ExitCode     `json:"exit_code"`
+	Message  string              `json:"message"`
+	Rules    []RecurringRuleData `json:"rules"`
+}
+
+func (o GetRecurringRulesOutput) GetID() string                { return o.ID }
+func (o GetRecurringRulesOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o GetRecurringRulesOutput) GetMessage() string           { return o.Message }
+
+type PreviewRecurringRuleOutput struct {
+	ID          string                    `json:"id"`
+	ExitCode    common.ExitCode           `json:"exit_code"`
+	Message     string                    `json:"message"`
+	Rule        *RecurringRuleData        `json:"rule,omitempty"`
+	Occurrences []RecurringOccurrenceData `json:"occurrences"`
+}
+
+func (o PreviewRecurringRuleOutput) GetID() string                { return o.ID }
+func (o PreviewRecurringRuleOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o PreviewRecurringRuleOutput) GetMessage() string           { return o.Message }
+
+// Outbox message structure for the admin API
+type OutboxMessageData struct {
+	ID            string          `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"last_error,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    string          `json:"occurred_at"`            // ISO format
+	NextAttemptAt string          `json:"next_attempt_at"`        // ISO format
+	DeliveredAt   string          `json:"delivered_at,omitempty"` // ISO format
+}
+
+type GetOutboxMessagesOutput struct {
+	ID       string              `json:"id"`
+	ExitCode common.ExitCode     `json:"exit_code"`
+	Message  string              `json:"message"`
+	Messages []OutboxMessageData `json:"messages"`
+}
+
+func (o GetOutboxMessagesOutput) GetID() string                { return o.ID }
+func (o GetOutboxMessagesOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o GetOutboxMessagesOutput) GetMessage() string           { return o.Message }
+
+// EditTransactionTagsOutput reports how many transactions had their tags changed;
+// transactions that already matched the edit are not counted.
+type EditTransactionTagsOutput struct {
+	ID       string          `json:"id"`
+	ExitCode common.ExitCode `json:"exit_code"`
+	Message  string          `json:"message"`
+	Updated  int             `json:"updated"`
+}
+
+func (o EditTransactionTagsOutput) GetID() string                { return o.ID }
+func (o EditTransactionTagsOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o EditTransactionTagsOutput) GetMessage() string           { return o.Message }
+
+// Totals of one tag in one currency; amounts are in the smallest currency unit.
+// A transaction with several tags counts towards each of them.
+type TagSummaryData struct {
+	Tag       string `json:"tag"`
+	Currency  string `json:"currency"`
+	Expenses  int64  `json:"expenses"`
+	Incomes   int64  `json:"incomes"`
+	Transfers int64  `json:"transfers"`
+	Count     int    `json:"count"` // Number of tagged transactions
+
+	// Set when a reporting currency was requested: the same totals converted into it
+	Converted *ConvertedTagTotalsData `json:"converted,omitempty"`
+}
+
+// Tag totals converted into the reporting currency; each total is rounded once
+type ConvertedTagTotalsData struct {
+	Currency  string `json:"currency"`
+	Expenses  int64  `json:"expenses"`
+	Incomes   int64  `json:"incomes"`
+	Transfers int64  `json:"transfers"`
+	Rate      string `json:"rate,omitempty"`      // Empty when the row is already in the reporting currency
+	RateDate  string `json:"rate_date,omitempty"` // YYYY-MM-DD
+}
+
+type GetTagSummaryOutput struct {
+	ID       string           `json:"id"`
+	ExitCode common.ExitCode  `json:"exit_code"`
+	Message  string           `json:"message"`
+	Tags     []TagSummaryData `json:"tags"`
+
+	// Set when a reporting currency was requested: one row per tag with the
+	// converted totals of all its currencies added together
+	ReportingCurrency string           `json:"reporting_currency,omitempty"`
+	ReportingTotals   []TagSummaryData `json:"reporting_totals,omitempty"`
+}
+
+func (o GetTagSummaryOutput) GetID() string                { return o.ID }
+func (o GetTagSummaryOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o GetTagSummaryOutput) GetMessage() string           { return o.Message }
+
+// Attachment metadata structure for API responses
+type AttachmentData struct {
+	ID          string `json:"id"`
+	RecordType  string `json:"record_type"`
+	RecordID    string `json:"record_id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`       // Bytes
+	Checksum    string `json:"checksum"`   // SHA-256, hex
+	CreatedAt   string `json:"created_at"` // ISO format
+}
+
+type UploadAttachmentOutput struct {
+	ID         string          `json:"id"`
+	ExitCode   common.ExitCode `json:"exit_code"`
+	Message    string          `json:"message"`
+	Attachment AttachmentData  `json:"attachment"`
+}
+
+func (o UploadAttachmentOutput) GetID() string                { return o.ID }
+func (o UploadAttachmentOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o UploadAttachmentOutput) GetMessage() string           { return o.Message }
+
+type GetAttachmentsOutput struct {
+	ID          string           `json:"id"`
+	ExitCode    common.ExitCode  `json:"exit_code"`
+	Message     string           `json:"message"`
+	Attachments []AttachmentData `json:"attachments"`
+}
+
+func (o GetAttachmentsOutput) GetID() string                { return o.ID }
+func (o GetAttachmentsOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o GetAttachmentsOutput) GetMessage() string           { return o.Message }
+
+// GetAttachmentContentOutput streams the stored file; the caller must close Content
+type GetAttachmentContentOutput struct {
+	ID         string          `json:"id"`
+	ExitCode   common.ExitCode `json:"exit_code"`
+	Message    string          `json:"message"`
+	Attachment AttachmentData  `json:"attachment"`
+	Content    io.ReadCloser   `json:"-"`
+}
+
+func (o GetAttachmentContentOutput) GetID() string                { return o.ID }
+func (o GetAttachmentContentOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o GetAttachmentContentOutput) GetMessage() string           { return o.Message }
+
+// ImportMappingData describes how the columns of a statement CSV map to
+// transactions. Columns are header names or 1-based column numbers; amounts come
+// from AmountColumn or from the DebitColumn/CreditColumn pair.
+type ImportMappingData struct {
+	Delimiter            string `json:"delimiter,omitempty"` // Single character, default ","
+	Encoding             string `json:"encoding"`            // UTF-8 (default) or BIG5
+	HasHeader            bool   `json:"has_header"`
+	SkipRows             int    `json:"skip_rows"` // Lines before the header or first row
+	DateColumn           string `json:"date_column"`
+	DateFormat           string `json:"date_format"` // e.g. YYYY/MM/DD
+	DescriptionColumn    string `json:"description_column,omitempty"`
+	AmountColumn         string `json:"amount_column,omitempty"`
+	SignConvention       string `json:"sign_convention,omitempty"` // NEGATIVE_EXPENSE (default) or POSITIVE_EXPENSE
+	DebitColumn          string `json:"debit_column,omitempty"`
+	CreditColumn         string `json:"credit_column,omitempty"`
+	ExpenseSubcategoryID string `json:"expense_subcategory_id"`
+	IncomeSubcategoryID  string `json:"income_subcategory_id"`
+}
+
+// Import profile structure for API responses
+type ImportProfileData struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Mapping   ImportMappingData `json:"mapping"`
+	CreatedAt string            `json:"created_at"` // ISO format
+	UpdatedAt string            `json:"updated_at"` // ISO format
+}
+
+// ImportRowData is a statement row parsed into a transaction; amounts are in
+// the smallest currency unit
+type ImportRowData struct {
+	Line        int       `json:"line"`
+	Date        time.Time `json:"date"`
+	Description string    `json:"description"`
+	Type        string    `json:"type"` // EXPENSE or INCOME
+	Amount      int64     `json:"amount"`
+	Currency    string    `json:"currency"`
+	RecordID    string    `json:"record_id,omitempty"` // Set once committed
+
+	SubcategoryID string   `json:"subcategory_id"`
+	Tags          []string `json:"tags,omitempty"`
+	RuleID        string   `json:"rule_id,omitempty"` // The categorization rule that assigned the subcategory and tags
+}
+
+// ImportRejectionData is a statement row that was not imported
+type ImportRejectionData struct {
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+// ImportStatementData summarizes one statement of a file; OFX and QIF files
+// can hold several accounts
+type ImportStatementData struct {
+	Account  string `json:"account,omitempty"`
+	Created  int    `json:"created"` // Rows recorded, or that a preview would record
+	Skipped  int    `json:"skipped"` // Rows imported before
+	Rejected int    `json:"rejected"`
+}
+
+type GetImportProfilesOutput struct {
+	ID       string              `json:"id"`
+	ExitCode common.ExitCode     `json:"exit_code"`
+	Message  string              `json:"message"`
+	Profiles []ImportProfileData `json:"profiles"`
+}
+
+func (o GetImportProfilesOutput) GetID() string                { return o.ID }
+func (o GetImportProfilesOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o GetImportProfilesOutput) GetMessage() string           { return o.Message }
+
+type PreviewImportOutput struct {
+	ID            string                `json:"id"`
+	ExitCode      common.ExitCode       `json:"exit_code"`
+	Message       string                `json:"message"`
+	Rows          []ImportRowData       `json:"rows"`
+	Skipped       []ImportRejectionData `json:"skipped"`
+	Rejected      []ImportRejectionData `json:"rejected"`
+	Statements    []ImportStatementData `json:"statements"`
+	TotalExpenses int64                 `json:"total_expenses"`
+	TotalIncomes  int64                 `json:"total_incomes"`
+}
+
+func (o PreviewImportOutput) GetID() string                { return o.ID }
+func (o PreviewImportOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o PreviewImportOutput) GetMessage() string           { return o.Message }
+
+type CommitImportOutput struct {
+	ID       string                `json:"id"`
+	ExitCode common.ExitCode       `json:"exit_code"`
+	Message  string                `json:"message"`
+	Imported   int                   `json:"imported"`
+	Rows       []ImportRowData       `json:"rows"`
+	Skipped    []ImportRejectionData `json:"skipped"`
+	Rejected   []ImportRejectionData `json:"rejected"`
+	Statements []ImportStatementData `json:"statements"`
+	Duplicates []DuplicateData       `json:"duplicates,omitempty"` // Imported rows flagged as likely duplicates
+}
+
+func (o CommitImportOutput) GetID() string                { return o.ID }
+func (o CommitImportOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o CommitImportOutput) GetMessage() string           { return o.Message }
+
+// DuplicateRecordData is one side of a flagged duplicate; amounts are in the
+// smallest currency unit
+type DuplicateRecordData struct {
+	ID            string    `json:"id"`
+	SubcategoryID string    `json:"subcategory_id"`
+	Amount        int64     `json:"amount"`
+	Currency      string    `json:"currency"`
+	Description   string    `json:"description"`
+	Date          time.Time `json:"date"`
+	Tags          []string  `json:"tags,omitempty"`
+	Imported      bool      `json:"imported"` // Carries an OFX/QFX or QIF import ID
+}
+
+// Duplicate flag structure for API responses; Record is the newer of the two
+// records, and a side is omitted once its record no longer exists
+type DuplicateData struct {
+	ID          string               `json:"id"`
+	WalletID    string               `json:"wallet_id"`
+	Type        string               `json:"type"` // EXPENSE or INCOME
+	Status      string               `json:"status"`
+	Score       float64              `json:"score"` // Description similarity, 0-1
+	Record      *DuplicateRecordData `json:"record,omitempty"`
+	DuplicateOf *DuplicateRecordData `json:"duplicate_of,omitempty"`
+	CreatedAt   string               `json:"created_at"`            // ISO format
+	ResolvedAt  string               `json:"resolved_at,omitempty"` // ISO format
+}
+
+type GetDuplicatesOutput struct {
+	ID         string          `json:"id"`
+	ExitCode   common.ExitCode `json:"exit_code"`
+	Message    string          `json:"message"`
+	Duplicates []DuplicateData `json:"duplicates"`
+}
+
+func (o GetDuplicatesOutput) GetID() string                { return o.ID }
+func (o GetDuplicatesOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o GetDuplicatesOutput) GetMessage() string           { return o.Message }
+
+// RuleConditionsData are the conditions of a categorization rule; unset
+// conditions do not restrict and at least one is required. Amounts are in the
+// smallest currency unit and inclusive.
+type RuleConditionsData struct {
+	DescriptionPattern string   `json:"description_pattern,omitempty"` // Case-insensitive regular expression
+	MinAmount          *int64   `json:"min_amount,omitempty"`
+	MaxAmount          *int64   `json:"max_amount,omitempty"`
+	Currency           string   `json:"currency,omitempty"` // Required with an amount range
+	WalletID           string   `json:"wallet_id,omitempty"`
+	Weekdays           []string `json:"weekdays,omitempty"` // MONDAY or MON, ...
+}
+
+// Categorization rule structure for API responses
+type CategorizationRuleData struct {
+	ID            string             `json:"id"`
+	Name          string             `json:"name"`
+	Type          string             `json:"type"` // EXPENSE or INCOME
+	Priority      int                `json:"priority"`
+	Conditions    RuleConditionsData `json:"conditions"`
+	SubcategoryID string             `json:"subcategory_id"`
+	Tags          []string           `json:"tags"`
+	CreatedAt     string             `json:"created_at"` // ISO format
+	UpdatedAt     string             `json:"updated_at"` // ISO format
+}
+
+type GetCategorizationRulesOutput struct {
+	ID       string                   `json:"id"`
+	ExitCode common.ExitCode          `json:"exit_code"`
+	Message  string                   `json:"message"`
+	Rules    []CategorizationRuleData `json:"rules"`
+}
+
+func (o GetCategorizationRulesOutput) GetID() string                { return o.ID }
+func (o GetCategorizationRulesOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o GetCategorizationRulesOutput) GetMessage() string           { return o.Message }
+
+// TestCategorizationRuleOutput reports the rule that would categorize the
+// sample (nil when none matches) and every matching rule in priority order
+type TestCategorizationRuleOutput struct {
+	ID       string                   `json:"id"`
+	ExitCode common.ExitCode          `json:"exit_code"`
+	Message  string                   `json:"message"`
+	Matched  *CategorizationRuleData  `json:"matched"`
+	Matching []CategorizationRuleData `json:"matching"`
+}
+
+func (o TestCategorizationRuleOutput) GetID() string                { return o.ID }
+func (o TestCategorizationRuleOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o TestCategorizationRuleOutput) GetMessage() string           { return o.Message }
+
+// Category suggestion structure for API responses
+type CategorySuggestionData struct {
+	SubcategoryID   string  `json:"subcategory_id"`
+	SubcategoryName string  `json:"subcategory_name"`
+	CategoryID      string  `json:"category_id"`
+	CategoryName    string  `json:"category_name"`
+	Confidence      float64 `json:"confidence"` // 0-1; the scores of all candidates add up to 1
+}
+
+type GetCategorySuggestionsOutput struct {
+	ID          string                   `json:"id"`
+	ExitCode    common.ExitCode          `json:"exit_code"`
+	Message     string                   `json:"message"`
+	Suggestions []CategorySuggestionData `json:"suggestions"`
+}
+
+func (o GetCategorySuggestionsOutput) GetID() string                { return o.ID }
+func (o GetCategorySuggestionsOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o GetCategorySuggestionsOutput) GetMessage() string           { return o.Message }
+
+// Exchange rate structure for API responses; Rate is the amount of Quote for
+// one major unit of Base
+type ExchangeRateData struct {
+	Base   string `json:"base"`
+	Quote  string `json:"quote"`
+	Rate   string `json:"rate"`
+	Date   string `json:"date"` // YYYY-MM-DD
+	Source string `json:"source"`
+}
+
+// Amount in the smallest unit of Currency
+type MoneyData struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// Amount converted into another currency, in its smallest unit, with the rate
+// used; Rate and RateDate are empty when the amount was already in Currency
+type ConvertedMoneyData struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	Rate     string `json:"rate,omitempty"`
+	RateDate string `json:"rate_date,omitempty"` // YYYY-MM-DD
+}
+
+// Currency structure for API responses; MinorUnits is the number of decimals
+// of the smallest unit in which amounts of this currency are stored
+type CurrencyData struct {
+	Code       string `json:"code"`
+	Number     string `json:"number"`
+	Name       string `json:"name"`
+	Symbol     string `json:"symbol"`
+	MinorUnits int    `json:"minor_units"`
+	Example    string `json:"example"` // 1234.56 written in the locale
+}
+
+type GetCurrenciesOutput struct {
+	ID         string          `json:"id"`
+	ExitCode   common.ExitCode `json:"exit_code"`
+	Message    string          `json:"message"`
+	Locale     string          `json:"locale"` // The supported locale actually used
+	Currencies []CurrencyData  `json:"currencies"`
+}
+
+func (o GetCurrenciesOutput) GetID() string                { return o.ID }
+func (o GetCurrenciesOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o GetCurrenciesOutput) GetMessage() string           { return o.Message }
+
+type GetExchangeRatesOutput struct {
+	ID       string             `json:"id"`
+	ExitCode common.ExitCode    `json:"exit_code"`
+	Message  string             `json:"message"`
+	Rates    []ExchangeRateData `json:"rates"`
+}
+
+func (o GetExchangeRatesOutput) GetID() string                { return o.ID }
+func (o GetExchangeRatesOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o GetExchangeRatesOutput) GetMessage() string           { return o.Message }
+
+// ImportExchangeRatesOutput reports the rates stored by an import or a refresh
+type ImportExchangeRatesOutput struct {
+	ID       string             `json:"id"`
+	ExitCode common.ExitCode    `json:"exit_code"`
+	Message  string             `json:"message"`
+	Imported int                `json:"imported"`
+	Rates    []ExchangeRateData `json:"rates"`
+}
+
+func (o ImportExchangeRatesOutput) GetID() string                { return o.ID }
+func (o ImportExchangeRatesOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o ImportExchangeRatesOutput) GetMessage() string           { return o.Message }
+
+type ConvertMoneyOutput struct {
+	ID        string             `json:"id"`
+	ExitCode  common.ExitCode    `json:"exit_code"`
+	Message   string             `json:"message"`
+	Original  MoneyData          `json:"original"`
+	Converted ConvertedMoneyData `json:"converted"`
+	Rounding  string             `json:"rounding"` // HALF_UP, HALF_EVEN or DOWN
+}
+
+func (o ConvertMoneyOutput) GetID() string                { return o.ID }
+func (o ConvertMoneyOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o ConvertMoneyOutput) GetMessage() string           { return o.Message }
+
+// Audit log entry structure for API responses
+type AuditEntryData struct {
+	Sequence      int64           `json:"sequence"`
+	ID            string          `json:"id"`
+	ActorID       string          `json:"actor_id"`
+	Command       string          `json:"command"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	Before        json.RawMessage `json:"before"` // null when the aggregate did not exist yet
+	After         json.RawMessage `json:"after"`  // null when the aggregate was deleted
+	RequestID     string          `json:"request_id,omitempty"`
+	OccurredAt    string          `json:"occurred_at"` // ISO format
+	PrevHash      string          `json:"prev_hash"`
+	Hash          string          `json:"hash"`
+}
+
+type GetAuditLogOutput struct {
+	ID       string           `json:"id"`
+	ExitCode common.ExitCode  `json:"exit_code"`
+	Message  string           `json:"message"`
+	Entries  []AuditEntryData `json:"entries"`
+}
+
+func (o GetAuditLogOutput) GetID() string                { return o.ID }
+func (o GetAuditLogOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o GetAuditLogOutput) GetMessage() string           { return o.Message }
+
+// VerifyAuditLogOutput reports whether the hash chain is intact. When it is not,
+// BrokenAtSequence is the first entry whose hash or link does not match.
+type VerifyAuditLogOutput struct {
+	ID               string          `json:"id"`
+	ExitCode         common.ExitCode `json:"exit_code"`
+	Message          string          `json:"message"`
+	Valid            bool            `json:"valid"`
+	EntriesChecked   int64           `json:"entries_checked"`
+	BrokenAtSequence int64           `json:"broken_at_sequence,omitempty"`
+}
+
+func (o VerifyAuditLogOutput) GetID() string                { return o.ID }
+func (o VerifyAuditLogOutput) GetExitCode() common.ExitCode { return o.ExitCode }
+func (o VerifyAuditLogOutput) GetMessage() string           { return o.Message }
+
 // =============================================================================
 // USE CASE INTERFACES
 // =============================================================================
@@ -247,6 +1548,31 @@ type AddIncomeUseCase interface {
 	Execute(input AddIncomeInput) common.Output
 }
 
+// UpdateExpenseUseCase defines the interface for correcting an expense record
+type UpdateExpenseUseCase interface {
+	Execute(input UpdateExpenseInput) common.Output
+}
+
+// DeleteExpenseUseCase defines the interface for deleting an expense record
+type DeleteExpenseUseCase interface {
+	Execute(input DeleteExpenseInput) common.Output
+}
+
+// UpdateIncomeUseCase defines the interface for correcting an income record
+type UpdateIncomeUseCase interface {
+	Execute(input UpdateIncomeInput) common.Output
+}
+
+// DeleteIncomeUseCase defines the interface for deleting an income record
+type DeleteIncomeUseCase interface {
+	Execute(input DeleteIncomeInput) common.Output
+}
+
+// ProcessTransferUseCase defines the interface for transferring money between wallets
+type ProcessTransferUseCase interface {
+	Execute(input ProcessTransferInput) common.Output
+}
+
 // CreateExpenseCategoryUseCase defines the interface for creating expense categories
 type CreateExpenseCategoryUseCase interface {
 	Execute(input CreateExpenseCategoryInput) common.Output
@@ -257,6 +1583,61 @@ type CreateIncomeCategoryUseCase interface {
 	Execute(input CreateIncomeCategoryInput) common.Output
 }
 
+// InitializeDefaultCategoriesUseCase defines the interface for provisioning a user's default categories
+type InitializeDefaultCategoriesUseCase interface {
+	Execute(input InitializeDefaultCategoriesInput) common.Output
+}
+
+// RenameExpenseCategoryUseCase defines the interface for renaming expense categories
+type RenameExpenseCategoryUseCase interface {
+	Execute(input RenameExpenseCategoryInput) common.Output
+}
+
+// DeleteExpenseCategoryUseCase defines the interface for deleting expense categories
+type DeleteExpenseCategoryUseCase interface {
+	Execute(input DeleteExpenseCategoryInput) common.Output
+}
+
+// AddExpenseSubcategoryUseCase defines the interface for adding subcategories to expense categories
+type AddExpenseSubcategoryUseCase interface {
+	Execute(input AddExpenseSubcategoryInput) common.Output
+}
+
+// RenameExpenseSubcategoryUseCase defines the interface for renaming expense subcategories
+type RenameExpenseSubcategoryUseCase interface {
+	Execute(input RenameExpenseSubcategoryInput) common.Output
+}
+
+// RemoveExpenseSubcategoryUseCase defines the interface for removing expense subcategories
+type RemoveExpenseSubcategoryUseCase interface {
+	Execute(input RemoveExpenseSubcategoryInput) common.Output
+}
+
+// RenameIncomeCategoryUseCase defines the interface for renaming income categories
+type RenameIncomeCategoryUseCase interface {
+	Execute(input RenameIncomeCategoryInput) common.Output
+}
+
+// DeleteIncomeCategoryUseCase defines the interface for deleting income categories
+type DeleteIncomeCategoryUseCase interface {
+	Execute(input DeleteIncomeCategoryInput) common.Output
+}
+
+// AddIncomeSubcategoryUseCase defines the interface for adding subcategories to income categories
+type AddIncomeSubcategoryUseCase interface {
+	Execute(input AddIncomeSubcategoryInput) common.Output
+}
+
+// RenameIncomeSubcategoryUseCase defines the interface for renaming income subcategories
+type RenameIncomeSubcategoryUseCase interface {
+	Execute(input RenameIncomeSubcategoryInput) common.Output
+}
+
+// RemoveIncomeSubcategoryUseCase defines the interface for removing income subcategories
+type RemoveIncomeSubcategoryUseCase interface {
+	Execute(input RemoveIncomeSubcategoryInput) common.Output
+}
+
 // UpdateWalletUseCase defines the interface for updating wallet information
 type UpdateWalletUseCase interface {
 	Execute(input UpdateWalletInput) common.Output
@@ -267,6 +1648,126 @@ type DeleteWalletUseCase interface {
 	Execute(input DeleteWalletInput) common.Output
 }
 
+// CreateAPIKeyUseCase defines the interface for issuing personal API keys
+type CreateAPIKeyUseCase interface {
+	Execute(input CreateAPIKeyInput) common.Output
+}
+
+// RevokeAPIKeyUseCase defines the interface for revoking personal API keys
+type RevokeAPIKeyUseCase interface {
+	Execute(input RevokeAPIKeyInput) common.Output
+}
+
+// CreateBudgetUseCase defines the interface for creating budgets
+type CreateBudgetUseCase interface {
+	Execute(input CreateBudgetInput) common.Output
+}
+
+// UpdateBudgetUseCase defines the interface for changing budgets
+type UpdateBudgetUseCase interface {
+	Execute(input UpdateBudgetInput) common.Output
+}
+
+// DeleteBudgetUseCase defines the interface for deleting budgets
+type DeleteBudgetUseCase interface {
+	Execute(input DeleteBudgetInput) common.Output
+}
+
+// CreateRecurringRuleUseCase defines the interface for creating recurring transaction rules
+type CreateRecurringRuleUseCase interface {
+	Execute(input CreateRecurringRuleInput) common.Output
+}
+
+// PauseRecurringRuleUseCase defines the interface for pausing recurring rules
+type PauseRecurringRuleUseCase interface {
+	Execute(input PauseRecurringRuleInput) common.Output
+}
+
+// ResumeRecurringRuleUseCase defines the interface for resuming paused recurring rules
+type ResumeRecurringRuleUseCase interface {
+	Execute(input ResumeRecurringRuleInput) common.Output
+}
+
+// SkipRecurringOccurrenceUseCase defines the interface for skipping a single occurrence
+type SkipRecurringOccurrenceUseCase interface {
+	Execute(input SkipRecurringOccurrenceInput) common.Output
+}
+
+// DeleteRecurringRuleUseCase defines the interface for deleting recurring rules
+type DeleteRecurringRuleUseCase interface {
+	Execute(input DeleteRecurringRuleInput) common.Output
+}
+
+// RetryOutboxMessageUseCase defines the interface for requeueing a stuck outbox message
+type RetryOutboxMessageUseCase interface {
+	Execute(input RetryOutboxMessageInput) common.Output
+}
+
+// EditTransactionTagsUseCase defines the interface for bulk editing transaction tags
+type EditTransactionTagsUseCase interface {
+	Execute(input EditTransactionTagsInput) common.Output
+}
+
+// UploadAttachmentUseCase defines the interface for attaching files to records
+type UploadAttachmentUseCase interface {
+	Execute(input UploadAttachmentInput) common.Output
+}
+
+// DeleteAttachmentUseCase defines the interface for removing attachments
+type DeleteAttachmentUseCase interface {
+	Execute(input DeleteAttachmentInput) common.Output
+}
+
+// CreateImportProfileUseCase defines the interface for saving statement import profiles
+type CreateImportProfileUseCase interface {
+	Execute(input CreateImportProfileInput) common.Output
+}
+
+// UpdateImportProfileUseCase defines the interface for changing statement import profiles
+type UpdateImportProfileUseCase interface {
+	Execute(input UpdateImportProfileInput) common.Output
+}
+
+// DeleteImportProfileUseCase defines the interface for removing statement import profiles
+type DeleteImportProfileUseCase interface {
+	Execute(input DeleteImportProfileInput) common.Output
+}
+
+// CommitImportUseCase defines the interface for importing statement rows into a wallet
+type CommitImportUseCase interface {
+	Execute(input CommitImportInput) common.Output
+}
+
+// CreateCategorizationRuleUseCase defines the interface for saving categorization rules
+type CreateCategorizationRuleUseCase interface {
+	Execute(input CreateCategorizationRuleInput) common.Output
+}
+
+// UpdateCategorizationRuleUseCase defines the interface for changing categorization rules
+type UpdateCategorizationRuleUseCase interface {
+	Execute(input UpdateCategorizationRuleInput) common.Output
+}
+
+// DeleteCategorizationRuleUseCase defines the interface for removing categorization rules
+type DeleteCategorizationRuleUseCase interface {
+	Execute(input DeleteCategorizationRuleInput) common.Output
+}
+
+// ResolveDuplicateUseCase defines the interface for settling a flagged duplicate
+type ResolveDuplicateUseCase interface {
+	Execute(input ResolveDuplicateInput) common.Output
+}
+
+// ImportExchangeRatesUseCase defines the interface for loading exchange rates from a CSV file
+type ImportExchangeRatesUseCase interface {
+	Execute(input ImportExchangeRatesInput) common.Output
+}
+
+// RefreshExchangeRatesUseCase defines the interface for fetching exchange rates from the rate provider
+type RefreshExchangeRatesUseCase interface {
+	Execute(input RefreshExchangeRatesInput) common.Output
+}
+
 // Query Use Case Interfaces
 
 // GetWalletBalanceUseCase defines the interface for querying wallet balance
@@ -302,4 +1803,119 @@ type GetIncomesUseCase interface {
 // GetExpensesUseCase defines the interface for querying expense records
 type GetExpensesUseCase interface {
 	Execute(input GetExpensesInput) common.Output
-}
\ No newline at end of file
+}
+
+// GetTransfersUseCase defines the interface for querying transfer records
+type GetTransfersUseCase interface {
+	Execute(input GetTransfersInput) common.Output
+}
+
+// GetAPIKeysUseCase defines the interface for listing a user's API keys
+type GetAPIKeysUseCase interface {
+	Execute(input GetAPIKeysInput) common.Output
+}
+
+// AuthenticateAPIKeyUseCase resolves an API key to its owner; the output ID is the user ID
+type AuthenticateAPIKeyUseCase interface {
+	Execute(input AuthenticateAPIKeyInput) common.Output
+}
+
+// GetBudgetsUseCase defines the interface for listing a user's budgets
+type GetBudgetsUseCase interface {
+	Execute(input GetBudgetsInput) common.Output
+}
+
+// GetBudgetStatusUseCase defines the interface for computing a budget's spent and remaining amounts
+type GetBudgetStatusUseCase interface {
+	Execute(input GetBudgetStatusInput) common.Output
+}
+
+// CheckBudgetWarningsUseCase defines the interface for finding budgets a new expense pushed past their threshold
+type CheckBudgetWarningsUseCase interface {
+	Execute(input CheckBudgetWarningsInput) common.Output
+}
+
+// GetRecurringRulesUseCase defines the interface for listing a user's recurring rules
+type GetRecurringRulesUseCase interface {
+	Execute(input GetRecurringRulesInput) common.Output
+}
+
+// PreviewRecurringRuleUseCase defines the interface for listing a rule's upcoming occurrences
+type PreviewRecurringRuleUseCase interface {
+	Execute(input PreviewRecurringRuleInput) common.Output
+}
+
+// GetOutboxMessagesUseCase defines the interface for inspecting outbox messages
+type GetOutboxMessagesUseCase interface {
+	Execute(input GetOutboxMessagesInput) common.Output
+}
+
+// GetAuditLogUseCase defines the interface for querying the audit log
+type GetAuditLogUseCase interface {
+	Execute(input GetAuditLogInput) common.Output
+}
+
+// VerifyAuditLogUseCase defines the interface for checking the audit log hash chain
+type VerifyAuditLogUseCase interface {
+	Execute(input VerifyAuditLogInput) common.Output
+}
+
+// GetTagSummaryUseCase defines the interface for the per-tag totals report
+type GetTagSummaryUseCase interface {
+	Execute(input GetTagSummaryInput) common.Output
+}
+
+// GetAttachmentsUseCase defines the interface for listing a record's attachments
+type GetAttachmentsUseCase interface {
+	Execute(input GetAttachmentsInput) common.Output
+}
+
+// GetAttachmentContentUseCase defines the interface for downloading an attachment
+type GetAttachmentContentUseCase interface {
+	Execute(input GetAttachmentContentInput) common.Output
+}
+
+// GetImportProfilesUseCase defines the interface for listing statement import profiles
+type GetImportProfilesUseCase interface {
+	Execute(input GetImportProfilesInput) common.Output
+}
+
+// PreviewImportUseCase defines the interface for a dry run of a statement import
+type PreviewImportUseCase interface {
+	Execute(input PreviewImportInput) common.Output
+}
+
+// GetCategorizationRulesUseCase defines the interface for listing categorization rules
+type GetCategorizationRulesUseCase interface {
+	Execute(input GetCategorizationRulesInput) common.Output
+}
+
+// TestCategorizationRuleUseCase defines the interface for running categorization rules against a sample
+type TestCategorizationRuleUseCase interface {
+	Execute(input TestCategorizationRuleInput) common.Output
+}
+
+// GetCategorySuggestionsUseCase defines the interface for learned subcategory suggestions
+type GetCategorySuggestionsUseCase interface {
+	Execute(input GetCategorySuggestionsInput) common.Output
+}
+
+// GetDuplicatesUseCase defines the interface for the duplicate review queue
+type GetDuplicatesUseCase interface {
+	Execute(input GetDuplicatesInput) common.Output
+}
+
+// GetExchangeRatesUseCase defines the interface for listing stored exchange rates
+type GetExchangeRatesUseCase interface {
+	Execute(input GetExchangeRatesInput) common.Output
+}
+
+// ConvertMoneyUseCase defines the interface for converting an amount between currencies
+type ConvertMoneyUseCase interface {
+	Execute(input ConvertMoneyInput) common.Output
+}
+
+// GetCurrenciesUseCase defines the interface for listing the supported currencies
+type GetCurrenciesUseCase interface {
+	Execute(input GetCurrenciesInput) common.Output
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix 個人API金鑰的明文前綴，方便辨識與掃描外洩
+const APIKeyPrefix = "ak_"
+
+// APIKey 使用者的長期個人API金鑰 (聚合根)
+// 只保存金鑰雜湊，明文只在建立時回傳一次
+type APIKey struct {
+	ID        string
+	UserID    string
+	Name      string
+	KeyHash   string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+// NewAPIKey 產生新的API金鑰，回傳聚合與唯一一次可取得的明文金鑰
+func NewAPIKey(userID, name string) (*APIKey, string, error) {
+	if userID == "" {
+		return nil, "", errors.New("user ID cannot be empty")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", errors.New("API key name cannot be empty")
+	}
+	if len(name) > 100 {
+		return nil, "", errors.New("API key name cannot exceed 100 characters")
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	plaintext := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	return &APIKey{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Name:      name,
+		KeyHash:   HashAPIKey(plaintext),
+		CreatedAt: time.Now(),
+	}, plaintext, nil
+}
+
+// HashAPIKey 計算明文金鑰的雜湊 (SHA-256, hex)
+// 金鑰本身為高熵隨機值，因此不需要加鹽或慢速雜湊
+func HashAPIKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+// Revoke 撤銷金鑰，重複撤銷不會改變原本的撤銷時間
+func (k *APIKey) Revoke() {
+	if k.RevokedAt != nil {
+		return
+	}
+	now := time.Now()
+	k.RevokedAt = &now
+}
+
+// IsActive 金鑰是否仍可用於認證
+func (k *APIKey) IsActive() bool {
+	return k.RevokedAt == nil
+}
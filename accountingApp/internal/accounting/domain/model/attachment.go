@@ -0,0 +1,145 @@
+package model
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxAttachmentSize 單一附件的大小上限 (bytes)
+const MaxAttachmentSize int64 = 10 << 20
+
+// maxAttachmentFileNameLength 附件檔名的長度上限
+const maxAttachmentFileNameLength = 255
+
+// allowedAttachmentContentTypes 可上傳的附件類型：收據照片與PDF
+var allowedAttachmentContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/webp":      true,
+	"image/heic":      true,
+	"application/pdf": true,
+}
+
+// IsAllowedAttachmentContentType 附件類型是否在允許清單中 (不含參數，例如 charset)
+func IsAllowedAttachmentContentType(contentType string) bool {
+	return allowedAttachmentContentTypes[contentType]
+}
+
+// heicBrands HEIC/HEIF 檔案 ftyp box 中的品牌
+var heicBrands = []string{"heic", "heix", "hevc", "hevx", "mif1", "msf1"}
+
+// DetectAttachmentContentType 依檔案開頭的 magic bytes 判斷附件類型
+// 不是允許的類型時回傳 "application/octet-stream"
+func DetectAttachmentContentType(head []byte) string {
+	switch {
+	case bytes.HasPrefix(head, []byte("\xFF\xD8\xFF")):
+		return "image/jpeg"
+	case bytes.HasPrefix(head, []byte("\x89PNG\r\n\x1a\n")):
+		return "image/png"
+	case len(head) >= 12 && bytes.Equal(head[:4], []byte("RIFF")) && bytes.Equal(head[8:12], []byte("WEBP")):
+		return "image/webp"
+	case bytes.HasPrefix(head, []byte("%PDF-")):
+		return "application/pdf"
+	case len(head) >= 12 && bytes.Equal(head[4:8], []byte("ftyp")):
+		for _, brand := range heicBrands {
+			if string(head[8:12]) == brand {
+				return "image/heic"
+			}
+		}
+	}
+	return "application/octet-stream"
+}
+
+// AttachmentRecordType 附件所屬的交易記錄類型
+type AttachmentRecordType string
+
+const (
+	AttachmentExpense AttachmentRecordType = "EXPENSE"
+	AttachmentIncome  AttachmentRecordType = "INCOME"
+)
+
+func ParseAttachmentRecordType(s string) (AttachmentRecordType, error) {
+	switch AttachmentRecordType(s) {
+	case AttachmentExpense, AttachmentIncome:
+		return AttachmentRecordType(s), nil
+	default:
+		return "", fmt.Errorf("invalid attachment record type: %s", s)
+	}
+}
+
+// Attachment 支出或收入記錄的附件 (聚合根)
+// 只保存中繼資料，檔案內容以 StorageKey 存放在 BlobStore
+type Attachment struct {
+	ID          string
+	UserID      string
+	WalletID    string // 記錄所屬的錢包，刪除錢包時用來清理檔案
+	RecordType  AttachmentRecordType
+	RecordID    string
+	FileName    string
+	ContentType string
+	Size        int64
+	Checksum    string // 檔案內容的 SHA-256 (hex)
+	StorageKey  string
+	CreatedAt   time.Time
+}
+
+// NewAttachment 建立附件中繼資料並檢查類型與大小限制
+func NewAttachment(userID, walletID string, recordType AttachmentRecordType, recordID, fileName, contentType string, size int64, checksum string) (*Attachment, error) {
+	if userID == "" {
+		return nil, errors.New("user ID cannot be empty")
+	}
+	if walletID == "" || recordID == "" {
+		return nil, errors.New("attachment must belong to a record")
+	}
+	if _, err := ParseAttachmentRecordType(string(recordType)); err != nil {
+		return nil, err
+	}
+	if !IsAllowedAttachmentContentType(contentType) {
+		return nil, fmt.Errorf("unsupported content type: %s", contentType)
+	}
+	if size <= 0 {
+		return nil, errors.New("attachment cannot be empty")
+	}
+	if size > MaxAttachmentSize {
+		return nil, fmt.Errorf("attachment exceeds the %d byte limit", MaxAttachmentSize)
+	}
+	if checksum == "" {
+		return nil, errors.New("checksum cannot be empty")
+	}
+
+	// 只保留檔名本身，去除用戶端送來的路徑
+	fileName = strings.TrimSpace(path.Base(strings.ReplaceAll(fileName, "\\", "/")))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		fileName = "attachment"
+	}
+	if len(fileName) > maxAttachmentFileNameLength {
+		return nil, fmt.Errorf("file name cannot exceed %d characters", maxAttachmentFileNameLength)
+	}
+
+	id := uuid.NewString()
+	return &Attachment{
+		ID:          id,
+		UserID:      userID,
+		WalletID:    walletID,
+		RecordType:  recordType,
+		RecordID:    recordID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		Checksum:    checksum,
+		StorageKey:  AttachmentStorageKey(id),
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// AttachmentStorageKey 附件在 BlobStore 中的鍵，以ID前兩碼分目錄
+// 只由伺服器產生的ID組成，不含使用者輸入
+func AttachmentStorageKey(attachmentID string) string {
+	return "attachments/" + attachmentID[:2] + "/" + attachmentID
+}
@@ -0,0 +1,312 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BudgetPeriod 預算的週期
+type BudgetPeriod string
+
+const (
+	BudgetPeriodMonthly BudgetPeriod = "MONTHLY" // 自然月
+	BudgetPeriodWeekly  BudgetPeriod = "WEEKLY"  // 週一開始的一週
+	BudgetPeriodCustom  BudgetPeriod = "CUSTOM"  // 單一期間 [StartDate, EndDate)
+)
+
+func ParseBudgetPeriod(s string) (BudgetPeriod, error) {
+	switch BudgetPeriod(s) {
+	case BudgetPeriodMonthly, BudgetPeriodWeekly, BudgetPeriodCustom:
+		return BudgetPeriod(s), nil
+	default:
+		return "", fmt.Errorf("invalid budget period: %s", s)
+	}
+}
+
+// DefaultBudgetWarningThreshold 預設在花費達預算80%時警告
+const DefaultBudgetWarningThreshold = 80
+
+// BudgetScope 預算的分類範圍：整個支出分類或單一子分類，兩者擇一
+type BudgetScope struct {
+	CategoryID    string // 指向 ExpenseCategory.ID，包含其所有子分類
+	SubcategoryID string // 指向 ExpenseSubcategory.ID
+}
+
+// Budget 使用者對某個支出分類在每個期間的預算 (聚合根)
+// 期間以UTC日期計算；花費由錢包的支出記錄即時計算，不存於聚合內
+type Budget struct {
+	ID               string
+	UserID           string
+	Name             string
+	Period           BudgetPeriod
+	Scope            BudgetScope
+	Amount           Money
+	Rollover         bool       // 上一期未用完的金額是否累積到下一期
+	WarningThreshold int        // 花費達預算的百分比時警告 (1-100)
+	StartDate        time.Time  // 第一期的開始日 (當天00:00 UTC)
+	EndDate          *time.Time // 不包含；CUSTOM必填，其他週期為nil表示持續
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// BudgetWindow 預算的一個期間 [Start, End)
+type BudgetWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Contains 時間是否落在期間內
+func (w BudgetWindow) Contains(t time.Time) bool {
+	return !t.Before(w.Start) && t.Before(w.End)
+}
+
+// BudgetStatus 預算在某一期間的使用狀況 (金額單位同Money.Amount)
+type BudgetStatus struct {
+	Window    BudgetWindow
+	Currency  string
+	Budgeted  int64 // 本期預算金額
+	Carryover int64 // 由上一期累積的未使用金額
+	Available int64 // Budgeted + Carryover
+	Spent     int64
+	Remaining int64 // Available - Spent，超支時為負數
+}
+
+// PercentUsed 已使用的百分比 (可超過100)
+func (s BudgetStatus) PercentUsed() float64 {
+	if s.Available <= 0 {
+		if s.Spent > 0 {
+			return 100
+		}
+		return 0
+	}
+	return float64(s.Spent) * 100 / float64(s.Available)
+}
+
+// IsOverBudget 是否已超支
+func (s BudgetStatus) IsOverBudget() bool {
+	return s.Remaining < 0
+}
+
+// ReachedThreshold 花費是否達到警告門檻
+func (s BudgetStatus) ReachedThreshold(threshold int) bool {
+	return s.PercentUsed() >= float64(threshold)
+}
+
+// NewBudget 建立預算；CUSTOM週期必須提供endDate
+func NewBudget(userID, name string, period BudgetPeriod, scope BudgetScope, amount Money, startDate time.Time, endDate *time.Time) (*Budget, error) {
+	if userID == "" {
+		return nil, errors.New("user ID cannot be empty")
+	}
+	if _, err := ParseBudgetPeriod(string(period)); err != nil {
+		return nil, err
+	}
+	if (scope.CategoryID == "") == (scope.SubcategoryID == "") {
+		return nil, errors.New("budget must target exactly one category or subcategory")
+	}
+	if startDate.IsZero() {
+		return nil, errors.New("budget start date cannot be empty")
+	}
+
+	now := time.Now()
+	budget := &Budget{
+		ID:               uuid.NewString(),
+		UserID:           userID,
+		Period:           period,
+		Scope:            scope,
+		WarningThreshold: DefaultBudgetWarningThreshold,
+		StartDate:        truncateToDay(startDate),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := budget.Rename(name); err != nil {
+		return nil, err
+	}
+	if err := budget.ChangeAmount(amount); err != nil {
+		return nil, err
+	}
+	if err := budget.ChangeEndDate(endDate); err != nil {
+		return nil, err
+	}
+	return budget, nil
+}
+
+// Rename 修改預算名稱
+func (b *Budget) Rename(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("budget name cannot be empty")
+	}
+	if len(name) > 100 {
+		return errors.New("budget name cannot exceed 100 characters")
+	}
+	b.Name = name
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// ChangeAmount 修改每期預算金額
+func (b *Budget) ChangeAmount(amount Money) error {
+	if amount.Amount <= 0 {
+		return errors.New("budget amount must be positive")
+	}
+	if len(amount.Currency) != 3 {
+		return errors.New("currency must be 3 characters (ISO 4217)")
+	}
+	if _, exists := LookupCurrency(amount.Currency); !exists {
+		return fmt.Errorf("unknown currency code: %s (ISO 4217)", amount.Currency)
+	}
+	b.Amount = amount
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// ChangeEndDate 修改結束日 (不包含)；CUSTOM週期不可移除結束日
+func (b *Budget) ChangeEndDate(endDate *time.Time) error {
+	if endDate == nil {
+		if b.Period == BudgetPeriodCustom {
+			return errors.New("custom budget requires an end date")
+		}
+		b.EndDate = nil
+		b.UpdatedAt = time.Now()
+		return nil
+	}
+	end := truncateToDay(*endDate)
+	if !end.After(b.StartDate) {
+		return errors.New("budget end date must be after start date")
+	}
+	b.EndDate = &end
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetRollover 設定是否累積未使用金額
+func (b *Budget) SetRollover(rollover bool) {
+	b.Rollover = rollover
+	b.UpdatedAt = time.Now()
+}
+
+// SetWarningThreshold 設定警告門檻百分比
+func (b *Budget) SetWarningThreshold(percent int) error {
+	if percent < 1 || percent > 100 {
+		return errors.New("warning threshold must be between 1 and 100")
+	}
+	b.WarningThreshold = percent
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// Covers 支出記錄的子分類是否在預算範圍內
+// categorySubcategoryIDs 為分類範圍時該分類的所有子分類ID
+func (b *Budget) Covers(subcategoryID string, categorySubcategoryIDs map[string]bool) bool {
+	if b.Scope.SubcategoryID != "" {
+		return b.Scope.SubcategoryID == subcategoryID
+	}
+	return categorySubcategoryIDs[subcategoryID]
+}
+
+// WindowAt 回傳t所在的期間；t不在預算有效範圍內時回傳false
+// 第一期從StartDate開始，最後一期在EndDate結束
+func (b *Budget) WindowAt(t time.Time) (BudgetWindow, bool) {
+	t = t.UTC()
+	if t.Before(b.StartDate) || (b.EndDate != nil && !t.Before(*b.EndDate)) {
+		return BudgetWindow{}, false
+	}
+
+	var window BudgetWindow
+	switch b.Period {
+	case BudgetPeriodMonthly:
+		window.Start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		window.End = window.Start.AddDate(0, 1, 0)
+	case BudgetPeriodWeekly:
+		day := truncateToDay(t)
+		offset := (int(day.Weekday()) + 6) % 7 // 週一為0
+		window.Start = day.AddDate(0, 0, -offset)
+		window.End = window.Start.AddDate(0, 0, 7)
+	default:
+		window.Start = b.StartDate
+		window.End = *b.EndDate
+	}
+
+	if window.Start.Before(b.StartDate) {
+		window.Start = b.StartDate
+	}
+	if b.EndDate != nil && window.End.After(*b.EndDate) {
+		window.End = *b.EndDate
+	}
+	return window, true
+}
+
+// SpendingRange StatusFromSpending需要的支出期間：t所在的期間，啟用Rollover時從第一期開始
+// t不在預算有效範圍內時回傳false
+func (b *Budget) SpendingRange(t time.Time) (BudgetWindow, bool) {
+	window, ok := b.WindowAt(t)
+	if !ok {
+		return BudgetWindow{}, false
+	}
+	if b.Rollover {
+		window.Start = b.StartDate
+	}
+	return window, true
+}
+
+// StatusAt 以支出記錄計算t所在期間的預算狀態
+// expenses 須已依範圍篩選 (見Covers)，每筆記錄的Amount為範圍內的金額
+func (b *Budget) StatusAt(t time.Time, expenses []ExpenseRecord) (BudgetStatus, bool) {
+	spent := make([]SpentAmount, len(expenses))
+	for i, expense := range expenses {
+		spent[i] = SpentAmount{SubcategoryID: expense.SubcategoryID, Date: expense.Date, Amount: expense.Amount}
+	}
+	return b.StatusFromSpending(t, spent)
+}
+
+// StatusFromSpending 計算t所在期間的預算狀態
+// spent 須已依範圍篩選 (見Covers) 並涵蓋SpendingRange；幣別與預算不同的金額不計入。
+// 啟用Rollover時，從第一期開始逐期累積未使用金額 (超支不會扣減下一期)
+func (b *Budget) StatusFromSpending(t time.Time, spent []SpentAmount) (BudgetStatus, bool) {
+	current, ok := b.WindowAt(t)
+	if !ok {
+		return BudgetStatus{}, false
+	}
+
+	windows := []BudgetWindow{current}
+	if b.Rollover {
+		windows = windows[:0]
+		for start := b.StartDate; start.Before(current.End); {
+			window, _ := b.WindowAt(start)
+			windows = append(windows, window)
+			start = window.End
+		}
+	}
+
+	var status BudgetStatus
+	var carryover int64
+	for _, window := range windows {
+		status = BudgetStatus{
+			Window:    window,
+			Currency:  b.Amount.Currency,
+			Budgeted:  b.Amount.Amount,
+			Carryover: carryover,
+			Available: b.Amount.Amount + carryover,
+		}
+		for _, amount := range spent {
+			if amount.Amount.Currency == b.Amount.Currency && window.Contains(amount.Date.UTC()) {
+				status.Spent += amount.Amount.Amount
+			}
+		}
+		status.Remaining = status.Available - status.Spent
+		carryover = 0
+		if status.Remaining > 0 {
+			carryover = status.Remaining
+		}
+	}
+	return status, true
+}
+
+func truncateToDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
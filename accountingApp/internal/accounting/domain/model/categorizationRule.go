@@ -0,0 +1,287 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// CategorizationRuleType 分類規則適用的交易類型
+type CategorizationRuleType string
+
+const (
+	CategorizeExpense CategorizationRuleType = "EXPENSE"
+	CategorizeIncome  CategorizationRuleType = "INCOME"
+)
+
+func ParseCategorizationRuleType(s string) (CategorizationRuleType, error) {
+	switch CategorizationRuleType(s) {
+	case CategorizeExpense, CategorizeIncome:
+		return CategorizationRuleType(s), nil
+	default:
+		return "", fmt.Errorf("invalid categorization rule type: %s", s)
+	}
+}
+
+// ParseWeekday 解析星期名稱 (例如 MONDAY、mon)，不分大小寫
+func ParseWeekday(s string) (time.Weekday, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	if len(name) >= 3 {
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			full := strings.ToUpper(day.String())
+			if name == full || name == full[:3] {
+				return day, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday: %s", s)
+}
+
+// 分類規則的限制
+const (
+	maxCategorizationRuleNameLength = 100
+	maxDescriptionPatternLength     = 200
+)
+
+// RuleConditions 分類規則的比對條件，未設定的條件不限制；至少要設定一個條件
+type RuleConditions struct {
+	DescriptionPattern string         // 正規表示式，不分大小寫，符合描述的任一部分即可
+	MinAmount          *int64         // 包含；最小貨幣單位
+	MaxAmount          *int64         // 包含；最小貨幣單位
+	Currency           string         // 設定金額範圍時必填，只比對相同幣別的交易
+	WalletID           string         // 只比對此錢包的交易
+	Weekdays           []time.Weekday // 交易日期的星期幾
+}
+
+// RuleSample 要分類的交易
+type RuleSample struct {
+	Type        CategorizationRuleType
+	WalletID    string
+	Description string
+	Amount      Money
+	Date        time.Time
+}
+
+// CategorizationRule 使用者定義的自動分類規則 (聚合根)
+// 符合條件的交易被指定為SubcategoryID並加上Tags；多條規則符合時Priority較小的優先
+type CategorizationRule struct {
+	ID            string
+	UserID        string
+	Name          string
+	Type          CategorizationRuleType
+	Priority      int // 數字小者優先，相同時較早建立的優先
+	Conditions    RuleConditions
+	SubcategoryID string
+	Tags          []string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	pattern *regexp.Regexp // 編譯後的描述條件，第一次比對時建立
+}
+
+// NewCategorizationRule 建立分類規則並檢查條件
+func NewCategorizationRule(userID, name string, ruleType CategorizationRuleType, priority int, conditions RuleConditions, subcategoryID string, tags []string) (*CategorizationRule, error) {
+	if userID == "" {
+		return nil, errors.New("user ID cannot be empty")
+	}
+	if _, err := ParseCategorizationRuleType(string(ruleType)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rule := &CategorizationRule{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Type:      ruleType,
+		CreatedAt: now,
+	}
+	if err := rule.Update(name, priority, conditions, subcategoryID, tags); err != nil {
+		return nil, err
+	}
+	rule.UpdatedAt = now
+	return rule, nil
+}
+
+// Update 修改名稱、優先順序、條件與指定的分類 (交易類型不可變更)
+func (r *CategorizationRule) Update(name string, priority int, conditions RuleConditions, subcategoryID string, tags []string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("rule name cannot be empty")
+	}
+	if utf8.RuneCountInString(name) > maxCategorizationRuleNameLength {
+		return fmt.Errorf("rule name cannot exceed %d characters", maxCategorizationRuleNameLength)
+	}
+	if priority < 0 {
+		return errors.New("priority cannot be negative")
+	}
+	pattern, err := conditions.normalize()
+	if err != nil {
+		return err
+	}
+	if subcategoryID == "" {
+		return errors.New("a subcategory is required")
+	}
+	normalizedTags, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+	if len(normalizedTags) > MaxTagsPerTransaction {
+		return fmt.Errorf("a rule can assign at most %d tags", MaxTagsPerTransaction)
+	}
+
+	r.Name = name
+	r.Priority = priority
+	r.Conditions = conditions
+	r.SubcategoryID = subcategoryID
+	r.Tags = normalizedTags
+	r.pattern = pattern
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// normalize 檢查條件、排序星期並編譯描述的正規表示式
+func (c *RuleConditions) normalize() (*regexp.Regexp, error) {
+	c.DescriptionPattern = strings.TrimSpace(c.DescriptionPattern)
+	c.Currency = strings.ToUpper(strings.TrimSpace(c.Currency))
+
+	hasAmount := c.MinAmount != nil || c.MaxAmount != nil
+	if c.DescriptionPattern == "" && !hasAmount && c.WalletID == "" && len(c.Weekdays) == 0 {
+		return nil, errors.New("a rule needs at least one condition")
+	}
+
+	var pattern *regexp.Regexp
+	if c.DescriptionPattern != "" {
+		if utf8.RuneCountInString(c.DescriptionPattern) > maxDescriptionPatternLength {
+			return nil, fmt.Errorf("description pattern cannot exceed %d characters", maxDescriptionPatternLength)
+		}
+		var err error
+		if pattern, err = compileDescriptionPattern(c.DescriptionPattern); err != nil {
+			return nil, fmt.Errorf("invalid description pattern: %w", err)
+		}
+	}
+
+	if hasAmount {
+		if c.Currency == "" {
+			return nil, errors.New("a currency is required with an amount range")
+		}
+		if c.MinAmount != nil && *c.MinAmount < 0 || c.MaxAmount != nil && *c.MaxAmount < 0 {
+			return nil, errors.New("amount range cannot be negative")
+		}
+		if c.MinAmount != nil && c.MaxAmount != nil && *c.MinAmount > *c.MaxAmount {
+			return nil, errors.New("minimum amount cannot exceed maximum amount")
+		}
+	} else {
+		c.Currency = ""
+	}
+
+	if len(c.Weekdays) > 0 {
+		seen := make(map[time.Weekday]bool, len(c.Weekdays))
+		weekdays := make([]time.Weekday, 0, len(c.Weekdays))
+		for _, day := range c.Weekdays {
+			if day < time.Sunday || day > time.Saturday {
+				return nil, fmt.Errorf("invalid weekday: %d", day)
+			}
+			if !seen[day] {
+				seen[day] = true
+				weekdays = append(weekdays, day)
+			}
+		}
+		sort.Slice(weekdays, func(i, j int) bool { return weekdays[i] < weekdays[j] })
+		c.Weekdays = weekdays
+	}
+	return pattern, nil
+}
+
+func compileDescriptionPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("(?i)" + pattern)
+}
+
+// Matches 交易是否符合規則的所有條件
+func (r *CategorizationRule) Matches(sample RuleSample) bool {
+	if sample.Type != r.Type {
+		return false
+	}
+	c := r.Conditions
+	if c.WalletID != "" && c.WalletID != sample.WalletID {
+		return false
+	}
+	if c.MinAmount != nil || c.MaxAmount != nil {
+		if sample.Amount.Currency != c.Currency {
+			return false
+		}
+		if c.MinAmount != nil && sample.Amount.Amount < *c.MinAmount || c.MaxAmount != nil && sample.Amount.Amount > *c.MaxAmount {
+			return false
+		}
+	}
+	if len(c.Weekdays) > 0 && !containsWeekday(c.Weekdays, sample.Date.Weekday()) {
+		return false
+	}
+	if c.DescriptionPattern != "" {
+		if r.pattern == nil {
+			pattern, err := compileDescriptionPattern(c.DescriptionPattern)
+			if err != nil {
+				return false // 建立時已檢查，只可能是資料庫中的資料損毀
+			}
+			r.pattern = pattern
+		}
+		if !r.pattern.MatchString(sample.Description) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsWeekday(weekdays []time.Weekday, day time.Weekday) bool {
+	for _, d := range weekdays {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// CategorizationRuleSet 依優先順序排列的一組規則
+type CategorizationRuleSet []*CategorizationRule
+
+// NewCategorizationRuleSet 依Priority、建立時間與ID排序 (不修改傳入的slice)
+func NewCategorizationRuleSet(rules []*CategorizationRule) CategorizationRuleSet {
+	set := make(CategorizationRuleSet, len(rules))
+	copy(set, rules)
+	sort.SliceStable(set, func(i, j int) bool {
+		if set[i].Priority != set[j].Priority {
+			return set[i].Priority < set[j].Priority
+		}
+		if !set[i].CreatedAt.Equal(set[j].CreatedAt) {
+			return set[i].CreatedAt.Before(set[j].CreatedAt)
+		}
+		return set[i].ID < set[j].ID
+	})
+	return set
+}
+
+// Match 第一條符合的規則，沒有時回傳nil
+func (s CategorizationRuleSet) Match(sample RuleSample) *CategorizationRule {
+	for _, rule := range s {
+		if rule.Matches(sample) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// MatchAll 所有符合的規則 (依優先順序)，第一條即為Match套用的規則
+func (s CategorizationRuleSet) MatchAll(sample RuleSample) []*CategorizationRule {
+	var matched []*CategorizationRule
+	for _, rule := range s {
+		if rule.Matches(sample) {
+			matched = append(matched, rule)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,83 @@
+package model
+
+import "time"
+
+// 分類聚合的事件名稱
+const (
+	EventCategoryCreated    = "category.created"
+	EventCategoryRenamed    = "category.renamed"
+	EventSubcategoryAdded   = "category.subcategory_added"
+	EventSubcategoryRenamed = "category.subcategory_renamed"
+	EventSubcategoryRemoved = "category.subcategory_removed"
+)
+
+// 分類種類，讓訂閱者區分支出與收入分類
+const (
+	CategoryKindExpense = "expense"
+	CategoryKindIncome  = "income"
+)
+
+// CategoryEvent 分類事件的共同欄位
+type CategoryEvent struct {
+	CategoryID string
+	UserID     string
+	Kind       string // CategoryKindExpense 或 CategoryKindIncome
+	OccurredOn time.Time
+}
+
+func newCategoryEvent(categoryID, userID, kind string) CategoryEvent {
+	return CategoryEvent{CategoryID: categoryID, UserID: userID, Kind: kind, OccurredOn: time.Now()}
+}
+
+func (e CategoryEvent) AggregateID() string {
+	return e.CategoryID
+}
+
+func (e CategoryEvent) OccurredAt() time.Time {
+	return e.OccurredOn
+}
+
+// CategoryCreated 新分類建立
+type CategoryCreated struct {
+	CategoryEvent
+	Name string
+}
+
+func (CategoryCreated) EventName() string { return EventCategoryCreated }
+
+// CategoryRenamed 分類名稱變更
+type CategoryRenamed struct {
+	CategoryEvent
+	OldName string
+	NewName string
+}
+
+func (CategoryRenamed) EventName() string { return EventCategoryRenamed }
+
+// SubcategoryAdded 新增子分類
+type SubcategoryAdded struct {
+	CategoryEvent
+	SubcategoryID string
+	Name          string
+}
+
+func (SubcategoryAdded) EventName() string { return EventSubcategoryAdded }
+
+// SubcategoryRenamed 子分類名稱變更
+type SubcategoryRenamed struct {
+	CategoryEvent
+	SubcategoryID string
+	OldName       string
+	NewName       string
+}
+
+func (SubcategoryRenamed) EventName() string { return EventSubcategoryRenamed }
+
+// SubcategoryRemoved 移除子分類
+type SubcategoryRemoved struct {
+	CategoryEvent
+	SubcategoryID string
+	Name          string
+}
+
+func (SubcategoryRemoved) EventName() string { return EventSubcategoryRemoved }
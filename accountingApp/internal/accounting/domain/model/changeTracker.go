@@ -0,0 +1,76 @@
+package model
+
+// EntityChanges 聚合內某一類子實體自上次持久化後的變更 (以ID表示)
+type EntityChanges struct {
+	Added    []string // 新增，尚未持久化
+	Modified []string // 已持久化但內容被修改
+	Removed  []string // 已持久化但被移除
+}
+
+// IsEmpty 是否沒有任何變更
+func (c EntityChanges) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Modified) == 0 && len(c.Removed) == 0
+}
+
+// changeTracker 子實體的Dirty Tracking
+// 零值即可使用，透過Load*載入的子實體不會被追蹤
+type changeTracker struct {
+	added    []string
+	modified []string
+	removed  []string
+}
+
+func (t *changeTracker) markAdded(id string) {
+	t.added = append(t.added, id)
+}
+
+func (t *changeTracker) markModified(id string) {
+	// 尚未持久化的新實體只需要插入一次
+	if containsID(t.added, id) || containsID(t.modified, id) {
+		return
+	}
+	t.modified = append(t.modified, id)
+}
+
+func (t *changeTracker) markRemoved(id string) {
+	// 尚未持久化就被移除的實體不需要任何資料庫操作
+	if containsID(t.added, id) {
+		t.added = withoutID(t.added, id)
+		return
+	}
+	t.modified = withoutID(t.modified, id)
+	t.removed = append(t.removed, id)
+}
+
+func (t *changeTracker) changes() EntityChanges {
+	return EntityChanges{
+		Added:    append([]string(nil), t.added...),
+		Modified: append([]string(nil), t.modified...),
+		Removed:  append([]string(nil), t.removed...),
+	}
+}
+
+func (t *changeTracker) reset() {
+	t.added = nil
+	t.modified = nil
+	t.removed = nil
+}
+
+func containsID(ids []string, id string) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutID(ids []string, id string) []string {
+	result := ids[:0]
+	for _, existing := range ids {
+		if existing != id {
+			result = append(result, existing)
+		}
+	}
+	return result
+}
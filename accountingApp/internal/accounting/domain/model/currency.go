@@ -0,0 +1,88 @@
+package model
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Currency ISO 4217 貨幣：代碼、數字代碼、最小單位的小數位數、英文名稱與符號
+type Currency struct {
+	Code       string
+	Number     string // 三位數字代碼，例如 "840"
+	MinorUnits int    // 最小單位的小數位數，金額以此單位的整數儲存
+	Name       string
+	Symbol     string // 英文語系的符號，例如 "$"、"NT$"；沒有慣用符號時為代碼
+}
+
+// Subdivision 1個主要單位等於幾個最小單位，例如美元為100
+func (c Currency) Subdivision() int64 {
+	subdivision := int64(1)
+	for i := 0; i < c.MinorUnits; i++ {
+		subdivision *= 10
+	}
+	return subdivision
+}
+
+// iso4217.csv 為 ISO 4217 現行貨幣 (code,number,minor_units,name,symbol)
+// 沒有最小單位的代碼 (貴金屬、測試用的X代碼等) 無法以整數最小單位記帳，不收錄
+//
+//go:embed iso4217.csv
+var iso4217CSV string
+
+// minorUnitOverrides 與 ISO 4217 不同的最小單位：
+// 新台幣在 ISO 4217 中有2位小數，但實務上不使用角、分，本系統一向以元為單位儲存
+var minorUnitOverrides = map[string]int{
+	"TWD": 0,
+}
+
+var currencies = loadCurrencies(iso4217CSV)
+
+func loadCurrencies(content string) map[string]Currency {
+	records, err := csv.NewReader(strings.NewReader(content)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("invalid ISO 4217 registry: %v", err))
+	}
+
+	registry := make(map[string]Currency, len(records))
+	for i, record := range records[1:] {
+		minorUnits, err := strconv.Atoi(record[2])
+		if err != nil || !currencyCodePattern.MatchString(record[0]) {
+			panic(fmt.Sprintf("invalid ISO 4217 registry line %d: %v", i+2, record))
+		}
+		if override, exists := minorUnitOverrides[record[0]]; exists {
+			minorUnits = override
+		}
+		symbol := record[4]
+		if symbol == "" {
+			symbol = record[0]
+		}
+		registry[record[0]] = Currency{
+			Code:       record[0],
+			Number:     record[1],
+			MinorUnits: minorUnits,
+			Name:       record[3],
+			Symbol:     symbol,
+		}
+	}
+	return registry
+}
+
+// LookupCurrency 依代碼 (大寫) 查詢貨幣
+func LookupCurrency(code string) (Currency, bool) {
+	currency, exists := currencies[code]
+	return currency, exists
+}
+
+// Currencies 回傳所有貨幣，依代碼排序
+func Currencies() []Currency {
+	result := make([]Currency, 0, len(currencies))
+	for _, currency := range currencies {
+		result = append(result, currency)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Code < result[j].Code })
+	return result
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultCategoryLocale 未指定語系時使用的預設分類範本語系
+const DefaultCategoryLocale = "zh-TW"
+
+// CategoryTemplate 範本中的一個分類及其子分類名稱
+type CategoryTemplate struct {
+	Name          string
+	Subcategories []string
+}
+
+// DefaultCategoryTemplate 新用戶的預設分類範本 (值物件)
+// Version 在範本內容變更時遞增；套用時以名稱比對，只補上使用者尚未擁有的分類
+type DefaultCategoryTemplate struct {
+	Locale  string
+	Version int
+	Expense []CategoryTemplate
+	Income  []CategoryTemplate
+}
+
+var defaultCategoryTemplates = map[string]DefaultCategoryTemplate{
+	"zh-TW": {
+		Locale:  "zh-TW",
+		Version: 1,
+		Expense: []CategoryTemplate{
+			{Name: "餐飲", Subcategories: []string{"早餐", "午餐", "晚餐", "飲料", "外食"}},
+			{Name: "交通", Subcategories: []string{"捷運/公車", "計程車", "停車費", "油費", "汽機車維修"}},
+			{Name: "購物", Subcategories: []string{"生活用品", "服飾", "3C產品", "書籍"}},
+			{Name: "娛樂", Subcategories: []string{"電影", "遊戲", "運動", "旅遊"}},
+			{Name: "醫療", Subcategories: []string{"看診費", "藥費", "健康檢查"}},
+			{Name: "教育", Subcategories: []string{"學費", "補習費", "教材"}},
+			{Name: "居住", Subcategories: []string{"房租", "水電費", "網路費", "家具"}},
+			{Name: "其他", Subcategories: []string{"雜項支出"}},
+		},
+		Income: []CategoryTemplate{
+			{Name: "薪資", Subcategories: []string{"本薪", "獎金", "加班費", "年終獎金"}},
+			{Name: "投資", Subcategories: []string{"股票股利", "基金收益", "租金收入", "利息收入"}},
+			{Name: "副業", Subcategories: []string{"兼職", "接案", "網拍", "教學"}},
+			{Name: "其他收入", Subcategories: []string{"發票中獎", "禮金", "退稅", "其他"}},
+		},
+	},
+	"en": {
+		Locale:  "en",
+		Version: 1,
+		Expense: []CategoryTemplate{
+			{Name: "Food & Dining", Subcategories: []string{"Breakfast", "Lunch", "Dinner", "Drinks", "Eating Out"}},
+			{Name: "Transportation", Subcategories: []string{"Public Transit", "Taxi", "Parking", "Fuel", "Vehicle Maintenance"}},
+			{Name: "Shopping", Subcategories: []string{"Household", "Clothing", "Electronics", "Books"}},
+			{Name: "Entertainment", Subcategories: []string{"Movies", "Games", "Sports", "Travel"}},
+			{Name: "Healthcare", Subcategories: []string{"Doctor Visits", "Medicine", "Checkups"}},
+			{Name: "Education", Subcategories: []string{"Tuition", "Tutoring", "Materials"}},
+			{Name: "Housing", Subcategories: []string{"Rent", "Utilities", "Internet", "Furniture"}},
+			{Name: "Other", Subcategories: []string{"Miscellaneous"}},
+		},
+		Income: []CategoryTemplate{
+			{Name: "Salary", Subcategories: []string{"Base Pay", "Bonus", "Overtime", "Year-End Bonus"}},
+			{Name: "Investment", Subcategories: []string{"Dividends", "Fund Returns", "Rental Income", "Interest"}},
+			{Name: "Side Business", Subcategories: []string{"Part-Time", "Freelance", "Online Sales", "Teaching"}},
+			{Name: "Other Income", Subcategories: []string{"Lottery", "Gifts", "Tax Refund", "Other"}},
+		},
+	},
+}
+
+// FindDefaultCategoryTemplate 取得指定語系的預設分類範本，空字串使用預設語系
+func FindDefaultCategoryTemplate(locale string) (DefaultCategoryTemplate, error) {
+	if locale == "" {
+		locale = DefaultCategoryLocale
+	}
+
+	template, exists := defaultCategoryTemplates[locale]
+	if !exists {
+		return DefaultCategoryTemplate{}, fmt.Errorf("unsupported category template locale: %s", locale)
+	}
+	return template, nil
+}
+
+// SupportedCategoryLocales 回傳所有可用的範本語系
+func SupportedCategoryLocales() []string {
+	locales := make([]string, 0, len(defaultCategoryTemplates))
+	for locale := range defaultCategoryTemplates {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
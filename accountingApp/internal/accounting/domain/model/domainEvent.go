@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// DomainEvent 聚合狀態變更時記錄的領域事件
+// 事件由聚合在業務方法中記錄，Repository儲存成功後才交給應用層發布
+type DomainEvent interface {
+	EventName() string
+	AggregateID() string
+	OccurredAt() time.Time
+}
+
+// eventRecorder 聚合內待發布的領域事件
+// 零值即可使用，透過Load*或Mapper重建聚合時不會記錄事件
+type eventRecorder struct {
+	events []DomainEvent
+}
+
+func (r *eventRecorder) record(event DomainEvent) {
+	r.events = append(r.events, event)
+}
+
+// pending 回傳事件的複本，避免呼叫端修改聚合內部狀態
+func (r *eventRecorder) pending() []DomainEvent {
+	if len(r.events) == 0 {
+		return nil
+	}
+	events := make([]DomainEvent, len(r.events))
+	copy(events, r.events)
+	return events
+}
+
+func (r *eventRecorder) reset() {
+	r.events = nil
+}
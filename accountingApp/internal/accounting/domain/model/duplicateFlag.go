@@ -0,0 +1,141 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DuplicateRecordType 重複標記的交易類型
+type DuplicateRecordType string
+
+const (
+	DuplicateExpense DuplicateRecordType = "EXPENSE"
+	DuplicateIncome  DuplicateRecordType = "INCOME"
+)
+
+func ParseDuplicateRecordType(s string) (DuplicateRecordType, error) {
+	switch DuplicateRecordType(s) {
+	case DuplicateExpense, DuplicateIncome:
+		return DuplicateRecordType(s), nil
+	default:
+		return "", fmt.Errorf("invalid duplicate record type: %s", s)
+	}
+}
+
+// DuplicateStatus 重複標記的審查狀態
+type DuplicateStatus string
+
+const (
+	DuplicatePending DuplicateStatus = "PENDING" // 等待使用者審查
+	DuplicateMerged  DuplicateStatus = "MERGED"  // 新記錄已併入既有記錄
+	DuplicateKept    DuplicateStatus = "KEPT"    // 兩筆都是真實的交易
+	DuplicateDeleted DuplicateStatus = "DELETED" // 新記錄已刪除
+)
+
+func ParseDuplicateStatus(s string) (DuplicateStatus, error) {
+	switch DuplicateStatus(s) {
+	case DuplicatePending, DuplicateMerged, DuplicateKept, DuplicateDeleted:
+		return DuplicateStatus(s), nil
+	default:
+		return "", fmt.Errorf("invalid duplicate status: %s", s)
+	}
+}
+
+// DuplicateResolution 使用者對重複標記的處理方式
+type DuplicateResolution string
+
+const (
+	ResolveMerge    DuplicateResolution = "MERGE"     // 將新記錄的標籤、描述與匯入識別併入既有記錄後刪除新記錄
+	ResolveKeepBoth DuplicateResolution = "KEEP_BOTH" // 不變更任何記錄
+	ResolveDelete   DuplicateResolution = "DELETE"    // 刪除新記錄
+)
+
+func ParseDuplicateResolution(s string) (DuplicateResolution, error) {
+	switch DuplicateResolution(s) {
+	case ResolveMerge, ResolveKeepBoth, ResolveDelete:
+		return DuplicateResolution(s), nil
+	default:
+		return "", fmt.Errorf("invalid duplicate resolution: %s (use MERGE, KEEP_BOTH or DELETE)", s)
+	}
+}
+
+// ErrDuplicateResolved 重複標記已經處理過
+var ErrDuplicateResolved = errors.New("duplicate flag is already resolved")
+
+// DuplicateFlag 新增或匯入時發現的可能重複：同一錢包中金額相近、日期接近且描述相似的兩筆交易
+type DuplicateFlag struct {
+	ID            string
+	UserID        string
+	WalletID      string
+	RecordType    DuplicateRecordType
+	RecordID      string  // 新增或匯入時被標記的記錄
+	DuplicateOfID string  // 錢包中相似的既有記錄
+	Score         float64 // 描述的相似度 (0-1)
+	Status        DuplicateStatus
+	CreatedAt     time.Time
+	ResolvedAt    *time.Time
+}
+
+func NewDuplicateFlag(userID, walletID string, recordType DuplicateRecordType, recordID, duplicateOfID string, score float64) (*DuplicateFlag, error) {
+	if userID == "" {
+		return nil, errors.New("user ID cannot be empty")
+	}
+	if walletID == "" {
+		return nil, errors.New("wallet ID cannot be empty")
+	}
+	if _, err := ParseDuplicateRecordType(string(recordType)); err != nil {
+		return nil, err
+	}
+	if recordID == "" || duplicateOfID == "" {
+		return nil, errors.New("record IDs cannot be empty")
+	}
+	if recordID == duplicateOfID {
+		return nil, errors.New("a record cannot duplicate itself")
+	}
+	if score < 0 || score > 1 {
+		return nil, errors.New("duplicate score must be between 0 and 1")
+	}
+
+	return &DuplicateFlag{
+		ID:            uuid.NewString(),
+		UserID:        userID,
+		WalletID:      walletID,
+		RecordType:    recordType,
+		RecordID:      recordID,
+		DuplicateOfID: duplicateOfID,
+		Score:         score,
+		Status:        DuplicatePending,
+		CreatedAt:     time.Now(),
+	}, nil
+}
+
+// IsPending 是否仍在審查佇列中
+func (f *DuplicateFlag) IsPending() bool {
+	return f.Status == DuplicatePending
+}
+
+// Resolve 記錄使用者的處理方式；錢包記錄的變更由呼叫端透過Wallet完成
+func (f *DuplicateFlag) Resolve(resolution DuplicateResolution) error {
+	if !f.IsPending() {
+		return ErrDuplicateResolved
+	}
+
+	switch resolution {
+	case ResolveMerge:
+		f.Status = DuplicateMerged
+	case ResolveKeepBoth:
+		f.Status = DuplicateKept
+	case ResolveDelete:
+		f.Status = DuplicateDeleted
+	default:
+		_, err := ParseDuplicateResolution(string(resolution))
+		return err
+	}
+
+	now := time.Now()
+	f.ResolvedAt = &now
+	return nil
+}
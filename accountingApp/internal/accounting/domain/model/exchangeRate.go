@@ -0,0 +1,164 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// RoundingMode 換算後不足最小貨幣單位的部分的捨入方式
+type RoundingMode string
+
+const (
+	RoundHalfUp   RoundingMode = "HALF_UP"   // 四捨五入 (0.5 遠離零)
+	RoundHalfEven RoundingMode = "HALF_EVEN" // 銀行家捨入 (0.5 取最近的偶數)
+	RoundDown     RoundingMode = "DOWN"      // 無條件捨去 (朝零)
+)
+
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	switch RoundingMode(s) {
+	case RoundHalfUp, RoundHalfEven, RoundDown:
+		return RoundingMode(s), nil
+	default:
+		return "", fmt.Errorf("invalid rounding mode: %s", s)
+	}
+}
+
+// Round 將有理數捨入為整數
+func (m RoundingMode) Round(x *big.Rat) int64 {
+	negative := x.Sign() < 0
+	abs := new(big.Rat).Abs(x)
+
+	quotient, remainder := new(big.Int).QuoRem(abs.Num(), abs.Denom(), new(big.Int))
+	if remainder.Sign() != 0 && m != RoundDown {
+		// 比較餘數的兩倍與分母，判斷是否超過一半
+		switch new(big.Int).Lsh(remainder, 1).Cmp(abs.Denom()) {
+		case 1:
+			quotient.Add(quotient, big.NewInt(1))
+		case 0:
+			if m == RoundHalfUp || quotient.Bit(0) == 1 {
+				quotient.Add(quotient, big.NewInt(1))
+			}
+		}
+	}
+	if negative {
+		quotient.Neg(quotient)
+	}
+	return quotient.Int64()
+}
+
+// 匯率的限制
+const maxRateDecimals = 10
+
+var ratePattern = regexp.MustCompile(`^[0-9]+(\.[0-9]{1,10})?$`)
+
+// ExchangeRate 某一天1單位Base (主要單位，例如1美元) 可兌換的Quote數量
+// 同一組貨幣每天只有一個匯率，ID由貨幣與日期組成，重複匯入時覆蓋
+type ExchangeRate struct {
+	ID        string
+	Base      string
+	Quote     string
+	Rate      *big.Rat // 精確值，避免浮點誤差
+	Date      time.Time
+	Source    string // 匯率來源，例如 "csv" 或提供者名稱
+	UpdatedAt time.Time
+}
+
+// ExchangeRateID 貨幣組合與日期的識別，例如 USD-TWD-2026-03-10
+func ExchangeRateID(base, quote string, date time.Time) string {
+	return fmt.Sprintf("%s-%s-%s", base, quote, date.Format("2006-01-02"))
+}
+
+// NewExchangeRate 建立匯率；rate為十進位字串 (最多10位小數)，日期只保留年月日
+func NewExchangeRate(base, quote, rate string, date time.Time, source string) (*ExchangeRate, error) {
+	base, err := NormalizeCurrencyCode(base)
+	if err != nil {
+		return nil, err
+	}
+	quote, err = NormalizeCurrencyCode(quote)
+	if err != nil {
+		return nil, err
+	}
+	if base == quote {
+		return nil, errors.New("base and quote currencies must differ")
+	}
+	rate = strings.TrimSpace(rate)
+	if !ratePattern.MatchString(rate) {
+		return nil, fmt.Errorf("invalid rate %q: use a decimal number with at most %d decimal places", rate, maxRateDecimals)
+	}
+	value, _ := new(big.Rat).SetString(rate)
+	if value.Sign() <= 0 {
+		return nil, errors.New("rate must be positive")
+	}
+	if date.IsZero() {
+		return nil, errors.New("rate date is required")
+	}
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	return &ExchangeRate{
+		ID:        ExchangeRateID(base, quote, day),
+		Base:      base,
+		Quote:     quote,
+		Rate:      value,
+		Date:      day,
+		Source:    strings.TrimSpace(source),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+// RateString 匯率的十進位表示，去除多餘的零 (反向匯率取10位小數)
+func (r *ExchangeRate) RateString() string {
+	s := r.Rate.FloatString(maxRateDecimals)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// Inverse 反向匯率 (Quote換Base)，日期與來源不變
+func (r *ExchangeRate) Inverse() *ExchangeRate {
+	return &ExchangeRate{
+		ID:        ExchangeRateID(r.Quote, r.Base, r.Date),
+		Base:      r.Quote,
+		Quote:     r.Base,
+		Rate:      new(big.Rat).Inv(r.Rate),
+		Date:      r.Date,
+		Source:    r.Source,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+// Convert 將Base的金額換算為Quote，依兩種貨幣的最小單位換算後只捨入一次
+func (r *ExchangeRate) Convert(amount Money, rounding RoundingMode) (Money, error) {
+	if amount.Currency != r.Base {
+		return Money{}, fmt.Errorf("cannot convert %s with a %s/%s rate", amount.Currency, r.Base, r.Quote)
+	}
+	baseSubdivision, err := GetCurrencySubdivision(r.Base)
+	if err != nil {
+		return Money{}, err
+	}
+	quoteSubdivision, err := GetCurrencySubdivision(r.Quote)
+	if err != nil {
+		return Money{}, err
+	}
+	// 最小單位：amount / 來源單位 * rate * 目標單位
+	value := new(big.Rat).SetInt64(amount.Amount)
+	value.Mul(value, r.Rate)
+	value.Mul(value, new(big.Rat).SetFrac64(quoteSubdivision, baseSubdivision))
+	return Money{Amount: rounding.Round(value), Currency: r.Quote}, nil
+}
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// NormalizeCurrencyCode 將幣別轉為大寫並檢查為 ISO 4217 登錄的代碼
+func NormalizeCurrencyCode(code string) (string, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(code))
+	if !currencyCodePattern.MatchString(normalized) {
+		return "", fmt.Errorf("invalid currency code: %q", code)
+	}
+	if _, exists := LookupCurrency(normalized); !exists {
+		return "", fmt.Errorf("unknown currency code: %q", code)
+	}
+	return normalized, nil
+}
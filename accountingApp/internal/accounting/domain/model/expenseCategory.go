@@ -31,6 +31,9 @@ type ExpenseCategory struct {
 	Subcategories []ExpenseSubcategory
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+
+	// 待發布的領域事件 - Repository儲存成功後發布並清除
+	events eventRecorder
 }
 
 func NewExpenseCategory(userID string, name CategoryName) (*ExpenseCategory, error) {
@@ -39,14 +42,26 @@ func NewExpenseCategory(userID string, name CategoryName) (*ExpenseCategory, err
 	}
 
 	now := time.Now()
-	return &ExpenseCategory{
+	category := &ExpenseCategory{
 		ID:            uuid.NewString(),
 		UserID:        userID,
 		Name:          name,
 		Subcategories: make([]ExpenseSubcategory, 0),
 		CreatedAt:     now,
 		UpdatedAt:     now,
-	}, nil
+	}
+	category.events.record(CategoryCreated{CategoryEvent: category.newEvent(), Name: name.String()})
+	return category, nil
+}
+
+// Rename 重新命名分類
+func (ec *ExpenseCategory) Rename(newName CategoryName) {
+	oldName := ec.Name
+	ec.Name = newName
+	ec.UpdatedAt = time.Now()
+	if !oldName.Equals(newName) {
+		ec.events.record(CategoryRenamed{CategoryEvent: ec.newEvent(), OldName: oldName.String(), NewName: newName.String()})
+	}
 }
 
 // AddSubcategory 透過聚合根新增子分類
@@ -62,6 +77,7 @@ func (ec *ExpenseCategory) AddSubcategory(name CategoryName) (*ExpenseSubcategor
 	subcategory := newExpenseSubcategory(name)
 	ec.Subcategories = append(ec.Subcategories, *subcategory)
 	ec.UpdatedAt = time.Now()
+	ec.events.record(SubcategoryAdded{CategoryEvent: ec.newEvent(), SubcategoryID: subcategory.ID, Name: name.String()})
 
 	return subcategory, nil
 }
@@ -71,6 +87,7 @@ func (ec *ExpenseCategory) RemoveSubcategory(subcategoryID string) error {
 		if sub.ID == subcategoryID {
 			ec.Subcategories = append(ec.Subcategories[:i], ec.Subcategories[i+1:]...)
 			ec.UpdatedAt = time.Now()
+			ec.events.record(SubcategoryRemoved{CategoryEvent: ec.newEvent(), SubcategoryID: sub.ID, Name: sub.Name.String()})
 			return nil
 		}
 	}
@@ -116,9 +133,26 @@ func (ec *ExpenseCategory) UpdateSubcategoryName(subcategoryID string, newName C
 		if sub.ID == subcategoryID {
 			ec.Subcategories[i].Name = newName
 			ec.UpdatedAt = time.Now()
+			if !sub.Name.Equals(newName) {
+				ec.events.record(SubcategoryRenamed{CategoryEvent: ec.newEvent(), SubcategoryID: sub.ID, OldName: sub.Name.String(), NewName: newName.String()})
+			}
 			return nil
 		}
 	}
 
 	return errors.New("subcategory not found")
 }
+
+// DomainEvents 自上次持久化後記錄的領域事件
+func (ec *ExpenseCategory) DomainEvents() []DomainEvent {
+	return ec.events.pending()
+}
+
+// ClearDomainEvents 事件發布後清除
+func (ec *ExpenseCategory) ClearDomainEvents() {
+	ec.events.reset()
+}
+
+func (ec *ExpenseCategory) newEvent() CategoryEvent {
+	return newCategoryEvent(ec.ID, ec.UserID, CategoryKindExpense)
+}
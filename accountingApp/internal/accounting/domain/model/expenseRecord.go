@@ -2,6 +2,8 @@ package model
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,11 +12,29 @@ import (
 type ExpenseRecord struct {
 	ID            string
 	WalletID      string
-	SubcategoryID string    // 指向 ExpenseSubcategory.ID
+	SubcategoryID string // 指向 ExpenseSubcategory.ID；拆帳時為第一筆明細的子分類
 	Amount        Money
 	Description   string
 	Date          time.Time
 	CreatedAt     time.Time
+	Splits        []ExpenseSplit // 拆帳明細，未拆帳時為空
+	Tags          []string       // 已正規化的標籤，依字母排序
+	ImportID      string         // 由帳單匯入時的識別 (OFX的FITID或QIF的內容雜湊)，手動新增時為空
+	OccurrenceKey string         // 由週期規則產生時的發生日識別 (見 RecurringRule.OccurrenceKey)，其他記錄為空
+}
+
+// SpentAmount 歸屬於一個子分類的支出金額，供不必載入整筆支出記錄的加總使用 (例如預算狀態)
+type SpentAmount struct {
+	SubcategoryID string
+	Date          time.Time
+	Amount        Money
+}
+
+// ExpenseSplit 拆帳明細：一筆支出中歸屬於某個子分類的部分
+type ExpenseSplit struct {
+	SubcategoryID string // 指向 ExpenseSubcategory.ID
+	Amount        Money
+	Memo          string
 }
 
 func NewExpenseRecord(walletID, subcategoryID string, amount Money, description string, date time.Time) (*ExpenseRecord, error) {
@@ -39,6 +59,78 @@ func NewExpenseRecord(walletID, subcategoryID string, amount Money, description
 	}, nil
 }
 
+// NewSplitExpenseRecord 建立拆帳支出：明細至少兩筆，幣別與總額相同且加總等於總額
+func NewSplitExpenseRecord(walletID string, amount Money, splits []ExpenseSplit, description string, date time.Time) (*ExpenseRecord, error) {
+	if err := validateExpenseSplits(amount, splits); err != nil {
+		return nil, err
+	}
+
+	record, err := NewExpenseRecord(walletID, splits[0].SubcategoryID, amount, description, date)
+	if err != nil {
+		return nil, err
+	}
+	record.Splits = append([]ExpenseSplit(nil), splits...)
+	return record, nil
+}
+
+// IsSplit 是否為拆帳支出
+func (r ExpenseRecord) IsSplit() bool {
+	return len(r.Splits) > 0
+}
+
+// Portions 回傳支出歸屬於各子分類的金額；未拆帳時為整筆金額的單一明細
+func (r ExpenseRecord) Portions() []ExpenseSplit {
+	if r.IsSplit() {
+		return r.Splits
+	}
+	return []ExpenseSplit{{SubcategoryID: r.SubcategoryID, Amount: r.Amount}}
+}
+
+// AmountFor 支出中歸屬於符合條件之子分類的金額
+func (r ExpenseRecord) AmountFor(matches func(subcategoryID string) bool) int64 {
+	var total int64
+	for _, portion := range r.Portions() {
+		if matches(portion.SubcategoryID) {
+			total += portion.Amount.Amount
+		}
+	}
+	return total
+}
+
+// SpentAmounts 支出歸屬於各子分類的金額；拆帳時每筆明細各一筆
+func (r ExpenseRecord) SpentAmounts() []SpentAmount {
+	portions := r.Portions()
+	amounts := make([]SpentAmount, len(portions))
+	for i, portion := range portions {
+		amounts[i] = SpentAmount{SubcategoryID: portion.SubcategoryID, Date: r.Date, Amount: portion.Amount}
+	}
+	return amounts
+}
+
+func validateExpenseSplits(amount Money, splits []ExpenseSplit) error {
+	if len(splits) < 2 {
+		return errors.New("a split expense needs at least two lines")
+	}
+
+	var total int64
+	for i, split := range splits {
+		if split.SubcategoryID == "" {
+			return fmt.Errorf("split line %d: subcategory ID cannot be empty", i+1)
+		}
+		if split.Amount.Currency != amount.Currency {
+			return fmt.Errorf("split line %d: currency %s does not match expense currency %s", i+1, split.Amount.Currency, amount.Currency)
+		}
+		if split.Amount.Amount <= 0 {
+			return fmt.Errorf("split line %d: amount must be positive", i+1)
+		}
+		total += split.Amount.Amount
+	}
+	if total != amount.Amount {
+		return fmt.Errorf("split lines sum to %d but the expense total is %d", total, amount.Amount)
+	}
+	return nil
+}
+
 type IncomeRecord struct {
 	ID            string
 	WalletID      string
@@ -47,6 +139,9 @@ type IncomeRecord struct {
 	Description   string
 	Date          time.Time
 	CreatedAt     time.Time
+	Tags          []string  // 已正規化的標籤，依字母排序
+	ImportID      string    // 由帳單匯入時的識別 (OFX的FITID或QIF的內容雜湊)，手動新增時為空
+	OccurrenceKey string    // 由週期規則產生時的發生日識別 (見 RecurringRule.OccurrenceKey)，其他記錄為空
 }
 
 func NewIncomeRecord(walletID, subcategoryID string, amount Money, description string, date time.Time) (*IncomeRecord, error) {
@@ -71,18 +166,28 @@ func NewIncomeRecord(walletID, subcategoryID string, amount Money, description s
 	}, nil
 }
 
+// Transfer 兩個錢包之間的轉帳；Amount為來源錢包幣別，ToAmount為目標錢包幣別
+// 同幣別轉帳兩者相同，手續費可以是任一方的幣別
 type Transfer struct {
 	ID           string
 	FromWalletID string
 	ToWalletID   string
-	Amount       Money
+	Amount       Money // 來源錢包轉出的金額
+	ToAmount     Money // 目標錢包收到的金額 (扣除目標幣別手續費前)
 	Fee          Money
 	Description  string
 	Date         time.Time
 	CreatedAt    time.Time
+	Tags         []string // 已正規化的標籤，依字母排序；兩個錢包中的副本保持一致
 }
 
+// NewTransfer 建立同幣別的轉帳
 func NewTransfer(fromWalletID, toWalletID string, amount Money, fee Money, description string, date time.Time) (*Transfer, error) {
+	return NewExchangeTransfer(fromWalletID, toWalletID, amount, amount, fee, description, date)
+}
+
+// NewExchangeTransfer 建立轉帳，toAmount為目標錢包幣別的金額，兩者隱含的匯率見ImpliedRate
+func NewExchangeTransfer(fromWalletID, toWalletID string, amount, toAmount, fee Money, description string, date time.Time) (*Transfer, error) {
 	if fromWalletID == "" {
 		return nil, errors.New("from wallet ID cannot be empty")
 	}
@@ -95,18 +200,82 @@ func NewTransfer(fromWalletID, toWalletID string, amount Money, fee Money, descr
 	if amount.Amount <= 0 {
 		return nil, errors.New("transfer amount must be positive")
 	}
+	if toAmount.Amount <= 0 {
+		return nil, errors.New("transfer destination amount must be positive")
+	}
+	if amount.Currency == toAmount.Currency && amount.Amount != toAmount.Amount {
+		return nil, errors.New("transfer destination amount must equal the amount for the same currency")
+	}
 	if fee.Amount < 0 {
 		return nil, errors.New("transfer fee cannot be negative")
 	}
+	if fee.Currency != amount.Currency && fee.Currency != toAmount.Currency {
+		return nil, fmt.Errorf("fee currency %s must be %s or %s", fee.Currency, amount.Currency, toAmount.Currency)
+	}
+	if fee.Currency != amount.Currency && fee.Amount > toAmount.Amount {
+		return nil, errors.New("transfer fee cannot exceed the destination amount")
+	}
 
 	return &Transfer{
 		ID:           uuid.NewString(),
 		FromWalletID: fromWalletID,
 		ToWalletID:   toWalletID,
 		Amount:       amount,
+		ToAmount:     toAmount,
 		Fee:          fee,
 		Description:  description,
 		Date:         date,
 		CreatedAt:    time.Now(),
 	}, nil
 }
+
+// IsCrossCurrency 判斷轉帳是否跨幣別
+func (t Transfer) IsCrossCurrency() bool {
+	return t.Amount.Currency != t.ToAmount.Currency
+}
+
+// SourceFee 來源錢包負擔的手續費；手續費為目標幣別時為零
+// 同幣別轉帳的手續費一律由來源錢包負擔
+func (t Transfer) SourceFee() Money {
+	if t.Fee.Currency == t.Amount.Currency {
+		return t.Fee
+	}
+	return Money{Amount: 0, Currency: t.Amount.Currency}
+}
+
+// CreditedAmount 目標錢包實際入帳的金額：ToAmount扣除目標幣別的手續費
+func (t Transfer) CreditedAmount() Money {
+	if t.Fee.Currency == t.Amount.Currency {
+		return t.ToAmount
+	}
+	return Money{Amount: t.ToAmount.Amount - t.Fee.Amount, Currency: t.ToAmount.Currency}
+}
+
+// ImpliedRate 由兩邊金額推算的匯率 (1單位來源幣別可兌換的目標幣別)；
+// 同幣別或任一幣別不在 ISO 4217 登錄中 (無法得知最小單位) 時為nil
+func (t Transfer) ImpliedRate() *ExchangeRate {
+	if !t.IsCrossCurrency() {
+		return nil
+	}
+	fromSubdivision, err := GetCurrencySubdivision(t.Amount.Currency)
+	if err != nil {
+		return nil
+	}
+	toSubdivision, err := GetCurrencySubdivision(t.ToAmount.Currency)
+	if err != nil {
+		return nil
+	}
+	// 主要單位：(toAmount / 目標單位) / (amount / 來源單位)
+	rate := new(big.Rat).SetFrac64(t.ToAmount.Amount, t.Amount.Amount)
+	rate.Mul(rate, new(big.Rat).SetFrac64(fromSubdivision, toSubdivision))
+	day := time.Date(t.Date.Year(), t.Date.Month(), t.Date.Day(), 0, 0, 0, 0, time.UTC)
+	return &ExchangeRate{
+		ID:        ExchangeRateID(t.Amount.Currency, t.ToAmount.Currency, day),
+		Base:      t.Amount.Currency,
+		Quote:     t.ToAmount.Currency,
+		Rate:      rate,
+		Date:      day,
+		Source:    "transfer",
+		UpdatedAt: t.CreatedAt,
+	}
+}
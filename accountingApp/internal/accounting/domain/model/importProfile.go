@@ -0,0 +1,598 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// ImportEncoding 匯入檔案的文字編碼
+type ImportEncoding string
+
+const (
+	ImportEncodingUTF8        ImportEncoding = "UTF-8"        // 開頭的BOM會被略過
+	ImportEncodingBig5        ImportEncoding = "BIG5"         // 台灣銀行常用的匯出編碼
+	ImportEncodingWindows1252 ImportEncoding = "WINDOWS-1252" // 舊版Quicken與美國銀行的QIF/OFX
+)
+
+func ParseImportEncoding(s string) (ImportEncoding, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "", "UTF-8", "UTF8":
+		return ImportEncodingUTF8, nil
+	case "BIG5", "BIG-5":
+		return ImportEncodingBig5, nil
+	case "WINDOWS-1252", "CP1252", "1252":
+		return ImportEncodingWindows1252, nil
+	default:
+		return "", fmt.Errorf("unsupported encoding: %s", s)
+	}
+}
+
+// AmountSignConvention 單一金額欄位的正負號慣例
+type AmountSignConvention string
+
+const (
+	SignNegativeIsExpense AmountSignConvention = "NEGATIVE_EXPENSE" // 存摺：支出為負數
+	SignPositiveIsExpense AmountSignConvention = "POSITIVE_EXPENSE" // 信用卡帳單：消費為正數、退款為負數
+)
+
+func ParseAmountSignConvention(s string) (AmountSignConvention, error) {
+	switch AmountSignConvention(s) {
+	case "":
+		return SignNegativeIsExpense, nil
+	case SignNegativeIsExpense, SignPositiveIsExpense:
+		return AmountSignConvention(s), nil
+	default:
+		return "", fmt.Errorf("invalid amount sign convention: %s", s)
+	}
+}
+
+// ImportTransactionType 匯入的交易類型
+type ImportTransactionType string
+
+const (
+	ImportExpense ImportTransactionType = "EXPENSE"
+	ImportIncome  ImportTransactionType = "INCOME"
+)
+
+// ImportMapping 帳單CSV的欄位對應
+// 欄位以標題名稱 (需HasHeader) 或從1開始的欄號指定；
+// 金額使用AmountColumn (依SignConvention判斷收支)，或分開的DebitColumn/CreditColumn
+type ImportMapping struct {
+	Delimiter         string // 單一字元，預設 ","
+	Encoding          ImportEncoding
+	HasHeader         bool
+	SkipRows          int // 標題 (或第一筆資料) 前要略過的列數，例如帳單開頭的說明
+	DateColumn        string
+	DateFormat        string // 例如 YYYY/MM/DD、DD.MM.YYYY
+	DescriptionColumn string // 選填
+	AmountColumn      string
+	SignConvention    AmountSignConvention
+	DebitColumn       string // 支出金額
+	CreditColumn      string // 收入金額
+
+	// 匯入的支出與收入記錄使用的子分類
+	ExpenseSubcategoryID string
+	IncomeSubcategoryID  string
+}
+
+// maxImportSkipRows 可略過的開頭列數上限
+const maxImportSkipRows = 100
+
+// Validate 檢查對應設定是否完整
+func (m ImportMapping) Validate() error {
+	if _, err := m.DelimiterRune(); err != nil {
+		return err
+	}
+	if _, err := ParseImportEncoding(string(m.Encoding)); err != nil {
+		return err
+	}
+	if m.SkipRows < 0 || m.SkipRows > maxImportSkipRows {
+		return fmt.Errorf("skip rows must be between 0 and %d", maxImportSkipRows)
+	}
+	if m.DateColumn == "" {
+		return errors.New("date column is required")
+	}
+	if _, err := ImportDateLayout(m.DateFormat); err != nil {
+		return err
+	}
+	if _, err := ParseAmountSignConvention(string(m.SignConvention)); err != nil {
+		return err
+	}
+
+	hasAmount := m.AmountColumn != ""
+	hasDebitCredit := m.DebitColumn != "" || m.CreditColumn != ""
+	switch {
+	case hasAmount && hasDebitCredit:
+		return errors.New("use either an amount column or debit/credit columns, not both")
+	case !hasAmount && !hasDebitCredit:
+		return errors.New("an amount column or debit/credit columns are required")
+	case hasDebitCredit && (m.DebitColumn == "" || m.CreditColumn == ""):
+		return errors.New("both debit and credit columns are required")
+	}
+
+	for _, column := range []string{m.DateColumn, m.DescriptionColumn, m.AmountColumn, m.DebitColumn, m.CreditColumn} {
+		if column == "" {
+			continue
+		}
+		if _, isIndex := columnIndex(column); !isIndex && !m.HasHeader {
+			return fmt.Errorf("column %q must be a 1-based number when the file has no header", column)
+		}
+	}
+
+	if m.ExpenseSubcategoryID == "" || m.IncomeSubcategoryID == "" {
+		return errors.New("expense and income subcategories are required")
+	}
+	return nil
+}
+
+// DelimiterRune 欄位分隔字元，未設定時為逗號
+func (m ImportMapping) DelimiterRune() (rune, error) {
+	if m.Delimiter == "" {
+		return ',', nil
+	}
+	if m.Delimiter == `\t` {
+		return '\t', nil
+	}
+	r, size := utf8.DecodeRuneInString(m.Delimiter)
+	if size != len(m.Delimiter) || r == utf8.RuneError || r == '"' || r == '\r' || r == '\n' {
+		return 0, fmt.Errorf("invalid delimiter: %q", m.Delimiter)
+	}
+	return r, nil
+}
+
+// columnIndex 欄位參照是否為從1開始的欄號，回傳從0開始的索引
+func columnIndex(column string) (int, bool) {
+	n, err := strconv.Atoi(strings.TrimSpace(column))
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n - 1, true
+}
+
+// importDateTokens 日期格式的代號與Go layout，較長的代號在前
+var importDateTokens = []struct{ token, layout string }{
+	{"YYYY", "2006"}, {"YY", "06"}, {"MM", "01"}, {"DD", "02"}, {"M", "1"}, {"D", "2"},
+}
+
+// ImportDateLayout 將 YYYY/MM/DD 這類日期格式轉換為Go的時間layout
+// 代號以外只允許空白、/、-、. 作為分隔
+func ImportDateLayout(format string) (string, error) {
+	if format == "" {
+		return "", errors.New("date format is required")
+	}
+
+	var layout strings.Builder
+	hasYear, hasMonth, hasDay := false, false, false
+	for rest := format; rest != ""; {
+		matched := false
+		for _, t := range importDateTokens {
+			if strings.HasPrefix(rest, t.token) {
+				layout.WriteString(t.layout)
+				rest = rest[len(t.token):]
+				switch t.token[0] {
+				case 'Y':
+					hasYear = true
+				case 'M':
+					hasMonth = true
+				case 'D':
+					hasDay = true
+				}
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		if !strings.ContainsRune(" /-.", rune(rest[0])) {
+			return "", fmt.Errorf("invalid date format %q: use YYYY, YY, MM, M, DD, D and the separators / - . or space", format)
+		}
+		layout.WriteByte(rest[0])
+		rest = rest[1:]
+	}
+	if !hasYear || !hasMonth || !hasDay {
+		return "", fmt.Errorf("invalid date format %q: year, month and day are required", format)
+	}
+	return layout.String(), nil
+}
+
+// ParseStatementAmount 將帳單上的金額文字轉換為最小貨幣單位
+// 接受千分位逗號、貨幣符號 (例如 NT$、$、USD)、前後的正負號與以括號表示的負數；
+// 小數位數超過幣別精度時只允許多出的位數為0 (例如 TWD 的 "1,200.00")；幣別須為 ISO 4217 登錄的代碼
+func ParseStatementAmount(text, currency string) (int64, error) {
+	registered, exists := LookupCurrency(currency)
+	if !exists {
+		return 0, fmt.Errorf("unknown currency code: %s (ISO 4217)", currency)
+	}
+
+	s := strings.TrimSpace(text)
+	if s == "" {
+		return 0, errors.New("amount is empty")
+	}
+
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+
+	// 去除千分位與前後的貨幣符號、幣別代碼 (例如 NT$、USD)，保留數字、小數點與正負號
+	var cleaned strings.Builder
+	seenDigit, afterDigits := false, false // 數字後出現貨幣符號時，之後不可再有數字
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			if afterDigits {
+				return 0, fmt.Errorf("invalid amount %q", text)
+			}
+			seenDigit = true
+			cleaned.WriteRune(r)
+		case r == '.', r == '-', r == '+':
+			cleaned.WriteRune(r)
+		case r == ',':
+		case unicode.IsLetter(r), unicode.IsSpace(r), unicode.Is(unicode.Sc, r):
+			afterDigits = seenDigit
+		default:
+			return 0, fmt.Errorf("invalid amount %q", text)
+		}
+	}
+	s = cleaned.String()
+
+	switch {
+	case strings.HasPrefix(s, "-"), strings.HasSuffix(s, "-"):
+		negative = !negative
+		s = strings.Trim(s, "-")
+	case strings.HasPrefix(s, "+"), strings.HasSuffix(s, "+"):
+		s = strings.Trim(s, "+")
+	}
+
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole == "" && fraction == "" || strings.ContainsAny(whole+fraction, ".-+") {
+		return 0, fmt.Errorf("invalid amount %q", text)
+	}
+
+	decimals := registered.MinorUnits
+	if len(fraction) > decimals {
+		if strings.Trim(fraction[decimals:], "0") != "" {
+			return 0, fmt.Errorf("amount %q has more decimal places than %s allows", text, currency)
+		}
+		fraction = fraction[:decimals]
+	}
+	fraction += strings.Repeat("0", decimals-len(fraction))
+
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", text)
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// ErrAlreadyImported 錢包中已有相同匯入識別的交易 (重複匯入同一份帳單)
+var ErrAlreadyImported = errors.New("transaction already imported")
+
+// ImportedTransaction 帳單中解析出的一筆交易，Amount為正數 (最小貨幣單位)
+type ImportedTransaction struct {
+	Line        int // 在檔案中的列號 (從1開始)
+	Date        time.Time
+	Description string
+	Type        ImportTransactionType
+	Amount      int64
+	ImportID    string // 重複匯入的判斷依據：OFX為帳號與FITID，QIF為內容雜湊；CSV為空 (不檢查)
+
+	// 分類規則指定的子分類與標籤；SubcategoryID為空時使用對應設定的子分類
+	SubcategoryID string
+	Tags          []string
+}
+
+// Categorize 套用分類規則的子分類與標籤
+func (tx *ImportedTransaction) Categorize(rule *CategorizationRule) {
+	tx.SubcategoryID = rule.SubcategoryID
+	tx.Tags = append([]string(nil), rule.Tags...)
+}
+
+// RuleSample 作為分類規則比對對象的交易
+func (tx ImportedTransaction) RuleSample(walletID, currency string) RuleSample {
+	ruleType := CategorizeExpense
+	if tx.Type == ImportIncome {
+		ruleType = CategorizeIncome
+	}
+	return RuleSample{
+		Type:        ruleType,
+		WalletID:    walletID,
+		Description: tx.Description,
+		Amount:      Money{Amount: tx.Amount, Currency: currency},
+		Date:        tx.Date,
+	}
+}
+
+// ImportColumns 對應設定套用到檔案標題後的欄位索引，-1 表示未使用
+type ImportColumns struct {
+	mapping     ImportMapping
+	dateLayout  string
+	date        int
+	description int
+	amount      int
+	debit       int
+	credit      int
+}
+
+// ResolveColumns 依標題列解析欄位；無標題時header為nil，欄位必須是欄號
+func (m ImportMapping) ResolveColumns(header []string) (*ImportColumns, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	layout, _ := ImportDateLayout(m.DateFormat)
+
+	resolve := func(column string) (int, error) {
+		if column == "" {
+			return -1, nil
+		}
+		if index, ok := columnIndex(column); ok {
+			return index, nil
+		}
+		for i, name := range header {
+			if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(column)) {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("column %q is missing from the header", column)
+	}
+
+	columns := &ImportColumns{mapping: m, dateLayout: layout}
+	var err error
+	for _, c := range []struct {
+		target *int
+		column string
+	}{
+		{&columns.date, m.DateColumn},
+		{&columns.description, m.DescriptionColumn},
+		{&columns.amount, m.AmountColumn},
+		{&columns.debit, m.DebitColumn},
+		{&columns.credit, m.CreditColumn},
+	} {
+		if *c.target, err = resolve(c.column); err != nil {
+			return nil, err
+		}
+	}
+	return columns, nil
+}
+
+// ParseRow 將一列資料依對應設定轉換為交易，金額以currency的精度解析
+func (c *ImportColumns) ParseRow(line int, cells []string, currency string) (ImportedTransaction, error) {
+	cell := func(index int) (string, error) {
+		if index < 0 {
+			return "", nil
+		}
+		if index >= len(cells) {
+			return "", fmt.Errorf("row has %d columns, column %d is missing", len(cells), index+1)
+		}
+		return strings.TrimSpace(cells[index]), nil
+	}
+
+	dateText, err := cell(c.date)
+	if err != nil {
+		return ImportedTransaction{}, err
+	}
+	date, err := time.Parse(c.dateLayout, dateText)
+	if err != nil {
+		return ImportedTransaction{}, fmt.Errorf("invalid date %q: expected %s", dateText, c.mapping.DateFormat)
+	}
+
+	description, err := cell(c.description)
+	if err != nil {
+		return ImportedTransaction{}, err
+	}
+
+	txType, amount, err := c.parseAmount(cell, currency)
+	if err != nil {
+		return ImportedTransaction{}, err
+	}
+
+	return ImportedTransaction{
+		Line:        line,
+		Date:        date,
+		Description: description,
+		Type:        txType,
+		Amount:      amount,
+	}, nil
+}
+
+// parseAmount 依金額欄或借貸欄判斷收支類型並回傳正數金額
+func (c *ImportColumns) parseAmount(cell func(int) (string, error), currency string) (ImportTransactionType, int64, error) {
+	if c.amount >= 0 {
+		text, err := cell(c.amount)
+		if err != nil {
+			return "", 0, err
+		}
+		amount, err := ParseStatementAmount(text, currency)
+		if err != nil {
+			return "", 0, err
+		}
+		if amount == 0 {
+			return "", 0, errors.New("amount is zero")
+		}
+
+		isExpense := amount < 0
+		if c.mapping.SignConvention == SignPositiveIsExpense {
+			isExpense = amount > 0
+		}
+		if amount < 0 {
+			amount = -amount
+		}
+		if isExpense {
+			return ImportExpense, amount, nil
+		}
+		return ImportIncome, amount, nil
+	}
+
+	debitText, err := cell(c.debit)
+	if err != nil {
+		return "", 0, err
+	}
+	creditText, err := cell(c.credit)
+	if err != nil {
+		return "", 0, err
+	}
+	debit, credit := int64(0), int64(0)
+	if debitText != "" {
+		if debit, err = ParseStatementAmount(debitText, currency); err != nil {
+			return "", 0, err
+		}
+	}
+	if creditText != "" {
+		if credit, err = ParseStatementAmount(creditText, currency); err != nil {
+			return "", 0, err
+		}
+	}
+
+	// 有些銀行以負數表示借方，只看金額大小
+	if debit < 0 {
+		debit = -debit
+	}
+	if credit < 0 {
+		credit = -credit
+	}
+	switch {
+	case debit != 0 && credit != 0:
+		return "", 0, errors.New("both debit and credit amounts are set")
+	case debit != 0:
+		return ImportExpense, debit, nil
+	case credit != 0:
+		return ImportIncome, credit, nil
+	default:
+		return "", 0, errors.New("amount is zero")
+	}
+}
+
+// RecordImportedTransaction 以分類規則或對應設定的子分類在錢包新增支出或收入，回傳記錄ID
+// 已以相同ImportID匯入過的交易回傳ErrAlreadyImported
+func RecordImportedTransaction(wallet *Wallet, mapping ImportMapping, tx ImportedTransaction) (string, error) {
+	if wallet.HasImportedTransaction(tx.ImportID) {
+		return "", ErrAlreadyImported
+	}
+	amount, err := NewMoney(tx.Amount, wallet.Currency())
+	if err != nil {
+		return "", err
+	}
+
+	if tx.Type == ImportExpense {
+		subcategoryID := tx.SubcategoryID
+		if subcategoryID == "" {
+			subcategoryID = mapping.ExpenseSubcategoryID
+		}
+		expense, err := NewExpenseRecord(wallet.ID, subcategoryID, *amount, tx.Description, tx.Date)
+		if err == nil {
+			expense.ImportID = tx.ImportID
+			expense.Tags = tx.Tags
+			expense, err = wallet.addExpense(expense)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to add expense: %w", err)
+		}
+		return expense.ID, nil
+	}
+
+	subcategoryID := tx.SubcategoryID
+	if subcategoryID == "" {
+		subcategoryID = mapping.IncomeSubcategoryID
+	}
+	income, err := NewIncomeRecord(wallet.ID, subcategoryID, *amount, tx.Description, tx.Date)
+	if err == nil {
+		income.ImportID = tx.ImportID
+		income.Tags = tx.Tags
+		income, err = wallet.addIncome(income)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to add income: %w", err)
+	}
+	return income.ID, nil
+}
+
+// ImportProfile 使用者儲存的帳單匯入設定 (聚合根)
+type ImportProfile struct {
+	ID        string
+	UserID    string
+	Name      string
+	Mapping   ImportMapping
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// maxImportProfileNameLength 設定名稱的長度上限
+const maxImportProfileNameLength = 100
+
+// NewImportProfile 建立匯入設定並檢查欄位對應
+func NewImportProfile(userID, name string, mapping ImportMapping) (*ImportProfile, error) {
+	if userID == "" {
+		return nil, errors.New("user ID cannot be empty")
+	}
+	name, err := validateImportProfileName(name)
+	if err != nil {
+		return nil, err
+	}
+	if mapping, err = normalizeImportMapping(mapping); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &ImportProfile{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Name:      name,
+		Mapping:   mapping,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Update 修改名稱與欄位對應
+func (p *ImportProfile) Update(name string, mapping ImportMapping) error {
+	name, err := validateImportProfileName(name)
+	if err != nil {
+		return err
+	}
+	if mapping, err = normalizeImportMapping(mapping); err != nil {
+		return err
+	}
+
+	p.Name = name
+	p.Mapping = mapping
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func validateImportProfileName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("profile name cannot be empty")
+	}
+	if utf8.RuneCountInString(name) > maxImportProfileNameLength {
+		return "", fmt.Errorf("profile name cannot exceed %d characters", maxImportProfileNameLength)
+	}
+	return name, nil
+}
+
+// normalizeImportMapping 填入預設的編碼與正負號慣例後檢查
+func normalizeImportMapping(mapping ImportMapping) (ImportMapping, error) {
+	encoding, err := ParseImportEncoding(string(mapping.Encoding))
+	if err != nil {
+		return mapping, err
+	}
+	mapping.Encoding = encoding
+	if mapping.AmountColumn != "" {
+		if mapping.SignConvention, err = ParseAmountSignConvention(string(mapping.SignConvention)); err != nil {
+			return mapping, err
+		}
+	}
+	return mapping, mapping.Validate()
+}
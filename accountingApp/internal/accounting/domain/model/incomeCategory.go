@@ -31,6 +31,9 @@ type IncomeCategory struct {
 	Subcategories []IncomeSubcategory
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+
+	// 待發布的領域事件 - Repository儲存成功後發布並清除
+	events eventRecorder
 }
 
 func NewIncomeCategory(userID string, name CategoryName) (*IncomeCategory, error) {
@@ -39,14 +42,26 @@ func NewIncomeCategory(userID string, name CategoryName) (*IncomeCategory, error
 	}
 	
 	now := time.Now()
-	return &IncomeCategory{
+	category := &IncomeCategory{
 		ID:            uuid.NewString(),
 		UserID:        userID,
 		Name:          name,
 		Subcategories: make([]IncomeSubcategory, 0),
 		CreatedAt:     now,
 		UpdatedAt:     now,
-	}, nil
+	}
+	category.events.record(CategoryCreated{CategoryEvent: category.newEvent(), Name: name.String()})
+	return category, nil
+}
+
+// Rename 重新命名分類
+func (ic *IncomeCategory) Rename(newName CategoryName) {
+	oldName := ic.Name
+	ic.Name = newName
+	ic.UpdatedAt = time.Now()
+	if !oldName.Equals(newName) {
+		ic.events.record(CategoryRenamed{CategoryEvent: ic.newEvent(), OldName: oldName.String(), NewName: newName.String()})
+	}
 }
 
 // AddSubcategory 透過聚合根新增子分類
@@ -62,6 +77,7 @@ func (ic *IncomeCategory) AddSubcategory(name CategoryName) (*IncomeSubcategory,
 	subcategory := newIncomeSubcategory(name)
 	ic.Subcategories = append(ic.Subcategories, *subcategory)
 	ic.UpdatedAt = time.Now()
+	ic.events.record(SubcategoryAdded{CategoryEvent: ic.newEvent(), SubcategoryID: subcategory.ID, Name: name.String()})
 	
 	return subcategory, nil
 }
@@ -71,6 +87,7 @@ func (ic *IncomeCategory) RemoveSubcategory(subcategoryID string) error {
 		if sub.ID == subcategoryID {
 			ic.Subcategories = append(ic.Subcategories[:i], ic.Subcategories[i+1:]...)
 			ic.UpdatedAt = time.Now()
+			ic.events.record(SubcategoryRemoved{CategoryEvent: ic.newEvent(), SubcategoryID: sub.ID, Name: sub.Name.String()})
 			return nil
 		}
 	}
@@ -117,6 +134,9 @@ func (ic *IncomeCategory) UpdateSubcategoryName(subcategoryID string, newName Ca
 		if sub.ID == subcategoryID {
 			ic.Subcategories[i].Name = newName
 			ic.UpdatedAt = time.Now()
+			if !sub.Name.Equals(newName) {
+				ic.events.record(SubcategoryRenamed{CategoryEvent: ic.newEvent(), SubcategoryID: sub.ID, OldName: sub.Name.String(), NewName: newName.String()})
+			}
 			return nil
 		}
 	}
@@ -124,3 +144,16 @@ func (ic *IncomeCategory) UpdateSubcategoryName(subcategoryID string, newName Ca
 	return errors.New("subcategory not found")
 }
 
+// DomainEvents 自上次持久化後記錄的領域事件
+func (ic *IncomeCategory) DomainEvents() []DomainEvent {
+	return ic.events.pending()
+}
+
+// ClearDomainEvents 事件發布後清除
+func (ic *IncomeCategory) ClearDomainEvents() {
+	ic.events.reset()
+}
+
+func (ic *IncomeCategory) newEvent() CategoryEvent {
+	return newCategoryEvent(ic.ID, ic.UserID, CategoryKindIncome)
+}
@@ -10,27 +10,14 @@ type Money struct {
 	Currency string
 }
 
-// GetCurrencySubdivision returns the subdivision for different currencies
-// TWD and other whole-number currencies use 1, decimal currencies use 100
-func GetCurrencySubdivision(currency string) int64 {
-	currencySubdivisions := map[string]int64{
-		// Whole number currencies (no subdivision) - TWD as primary
-		"TWD": 1,   // 1 台幣 = 1 台幣 (no cents, base unit)
-		"JPY": 1,   // 1 yen = 1 yen (no sen in practice)
-		"KRW": 1,   // 1 won = 1 won (no subdivision)
-		"VND": 1,   // 1 dong = 1 dong (no subdivision)
-		
-		// Decimal currencies (1 unit = 100 smaller units)
-		"USD": 100, // 1 dollar = 100 cents
-		"EUR": 100, // 1 euro = 100 cents
-		"GBP": 100, // 1 pound = 100 pence
-		"CNY": 100, // 1 yuan = 100 fen
+// GetCurrencySubdivision returns the subdivision from the ISO 4217 registry
+// e.g. 100 for USD, 1 for TWD and JPY, 1000 for KWD; unknown codes are an error
+func GetCurrencySubdivision(currency string) (int64, error) {
+	c, exists := LookupCurrency(currency)
+	if !exists {
+		return 0, fmt.Errorf("unknown currency code: %s (ISO 4217)", currency)
 	}
-	
-	if subdivision, exists := currencySubdivisions[currency]; exists {
-		return subdivision
-	}
-	return 1 // Default to 1 for unknown currencies (like TWD)
+	return c.Subdivision(), nil
 }
 
 func NewMoney(amount int64, currency string) (*Money, error) {
@@ -38,11 +25,14 @@ func NewMoney(amount int64, currency string) (*Money, error) {
 		return nil, errors.New("amount cannot be negative")
 	}
 	if currency == "" {
-		currency = "TWD" // Default to TWD
+		return nil, errors.New("currency cannot be empty")
 	}
 	if len(currency) != 3 {
 		return nil, errors.New("currency must be 3 characters (ISO 4217)")
 	}
+	if _, exists := LookupCurrency(currency); !exists {
+		return nil, fmt.Errorf("unknown currency code: %s (ISO 4217)", currency)
+	}
 	
 	return &Money{
 		Amount:   amount,
@@ -72,14 +62,7 @@ func (m Money) Equals(other Money) bool {
 	return m.Amount == other.Amount && m.Currency == other.Currency
 }
 
+// String formats the amount in the default locale, e.g. "$1,234.56"
 func (m Money) String() string {
-	subdivision := GetCurrencySubdivision(m.Currency)
-	displayAmount := float64(m.Amount) / float64(subdivision)
-	
-	// For whole number currencies, don't show decimal places
-	if subdivision == 1 {
-		return fmt.Sprintf("%.0f %s", displayAmount, m.Currency)
-	}
-	// For decimal currencies, show 2 decimal places
-	return fmt.Sprintf("%.2f %s", displayAmount, m.Currency)
-}
\ No newline at end of file
+	return m.Format(DefaultMoneyLocale)
+}
@@ -0,0 +1,119 @@
+package model
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultMoneyLocale 未指定或不支援的語系時使用的格式
+const DefaultMoneyLocale = "en"
+
+// moneyLocale 一個語系的金額寫法：千分位、小數點、符號位置與當地慣用的符號
+type moneyLocale struct {
+	group       string
+	decimal     string
+	symbolFirst bool
+	spaced      bool              // 符號與數字之間是否有空白
+	symbols     map[string]string // 與英文語系不同的符號，例如台灣的新台幣寫作 "$"
+}
+
+const nbsp = "\u00a0"
+
+var englishMoney = moneyLocale{group: ",", decimal: ".", symbolFirst: true}
+
+var moneyLocales = map[string]moneyLocale{
+	"en":    englishMoney,
+	"en-US": englishMoney,
+	"en-GB": englishMoney,
+	"zh-TW": {group: ",", decimal: ".", symbolFirst: true, symbols: map[string]string{"TWD": "$", "CNY": "CN¥"}},
+	"zh-CN": {group: ",", decimal: ".", symbolFirst: true, symbols: map[string]string{"CNY": "¥", "TWD": "新台币"}},
+	"ja-JP": {group: ",", decimal: ".", symbolFirst: true, symbols: map[string]string{"JPY": "￥", "CNY": "元"}},
+	"ko-KR": {group: ",", decimal: ".", symbolFirst: true},
+	"de-DE": {group: ".", decimal: ",", spaced: true},
+	"fr-FR": {group: "\u202f", decimal: ",", spaced: true},
+	"pt-BR": {group: ".", decimal: ",", symbolFirst: true, spaced: true},
+}
+
+// SupportedMoneyLocales 回傳支援的語系，依名稱排序
+func SupportedMoneyLocales() []string {
+	locales := make([]string, 0, len(moneyLocales))
+	for locale := range moneyLocales {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// ResolveMoneyLocale 找出最接近的支援語系：完全相符、同語言，否則為預設語系
+// 大小寫不拘，"zh_TW" 視同 "zh-TW"
+func ResolveMoneyLocale(locale string) string {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	language := strings.ToLower(strings.SplitN(locale, "-", 2)[0])
+	fallback := ""
+	for _, supported := range SupportedMoneyLocales() {
+		if strings.EqualFold(supported, locale) {
+			return supported
+		}
+		if fallback == "" && strings.ToLower(strings.SplitN(supported, "-", 2)[0]) == language {
+			fallback = supported
+		}
+	}
+	if fallback != "" {
+		return fallback
+	}
+	return DefaultMoneyLocale
+}
+
+// Format 依語系格式化金額，小數位數依 ISO 4217，例如 en 為 "$1,234.56"、de-DE 為 "1.234,56 €"
+func (m Money) Format(locale string) string {
+	conventions := moneyLocales[ResolveMoneyLocale(locale)]
+
+	currency, exists := LookupCurrency(m.Currency)
+	if !exists {
+		currency = Currency{Code: m.Currency, Symbol: m.Currency}
+	}
+	symbol := currency.Symbol
+	if localSymbol, exists := conventions.symbols[currency.Code]; exists {
+		symbol = localSymbol
+	}
+
+	number := conventions.formatNumber(m.Amount, currency)
+	separator := ""
+	if conventions.spaced || symbol == currency.Code {
+		separator = nbsp
+	}
+
+	sign := ""
+	if m.Amount < 0 {
+		sign = "-"
+	}
+	if conventions.symbolFirst {
+		return sign + symbol + separator + number
+	}
+	return sign + number + separator + symbol
+}
+
+// formatNumber 以整數運算寫出金額的絕對值，避免浮點誤差
+func (l moneyLocale) formatNumber(amount int64, currency Currency) string {
+	digits := strconv.FormatInt(amount, 10)
+	digits = strings.TrimPrefix(digits, "-")
+	for len(digits) <= currency.MinorUnits {
+		digits = "0" + digits
+	}
+
+	major := digits[:len(digits)-currency.MinorUnits]
+	minor := digits[len(digits)-currency.MinorUnits:]
+
+	var grouped strings.Builder
+	for i, digit := range major {
+		if i > 0 && (len(major)-i)%3 == 0 {
+			grouped.WriteString(l.group)
+		}
+		grouped.WriteRune(digit)
+	}
+	if minor == "" {
+		return grouped.String()
+	}
+	return grouped.String() + l.decimal + minor
+}
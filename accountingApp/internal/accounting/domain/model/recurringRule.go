@@ -0,0 +1,488 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecurrenceFrequency 週期規則的頻率
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "DAILY"
+	RecurrenceWeekly  RecurrenceFrequency = "WEEKLY"  // 與StartDate同一個星期幾
+	RecurrenceMonthly RecurrenceFrequency = "MONTHLY" // 每月第N天或最後一個工作日
+	RecurrenceYearly  RecurrenceFrequency = "YEARLY"  // 與StartDate同月同日
+)
+
+func ParseRecurrenceFrequency(s string) (RecurrenceFrequency, error) {
+	switch RecurrenceFrequency(s) {
+	case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly, RecurrenceYearly:
+		return RecurrenceFrequency(s), nil
+	default:
+		return "", fmt.Errorf("invalid recurrence frequency: %s", s)
+	}
+}
+
+// RecurringTransactionType 週期規則產生的交易類型
+type RecurringTransactionType string
+
+const (
+	RecurringExpense RecurringTransactionType = "EXPENSE"
+	RecurringIncome  RecurringTransactionType = "INCOME"
+)
+
+func ParseRecurringTransactionType(s string) (RecurringTransactionType, error) {
+	switch RecurringTransactionType(s) {
+	case RecurringExpense, RecurringIncome:
+		return RecurringTransactionType(s), nil
+	default:
+		return "", fmt.Errorf("invalid recurring transaction type: %s", s)
+	}
+}
+
+// RecurringRuleStatus 週期規則的狀態
+type RecurringRuleStatus string
+
+const (
+	RecurringRuleActive RecurringRuleStatus = "ACTIVE"
+	RecurringRulePaused RecurringRuleStatus = "PAUSED" // 暫停期間到期的日期在恢復時標記為略過
+)
+
+// OccurrenceStatus 單一發生日的處理狀態
+type OccurrenceStatus string
+
+const (
+	OccurrenceScheduled OccurrenceStatus = "SCHEDULED" // 尚未處理，只出現在預覽中
+	OccurrenceClaimed   OccurrenceStatus = "CLAIMED"   // 已被排程器認領，正在產生交易
+	OccurrenceGenerated OccurrenceStatus = "GENERATED" // 已產生交易
+	OccurrenceSkipped   OccurrenceStatus = "SKIPPED"
+	OccurrenceFailed    OccurrenceStatus = "FAILED" // 產生交易失敗，排程器會再重試
+)
+
+// RecurrenceSchedule 類似RRULE的排程，日期以UTC的日為單位
+// EndDate (包含) 與 Count 擇一或都不設定 (不會結束)
+type RecurrenceSchedule struct {
+	Frequency       RecurrenceFrequency
+	Interval        int        // 每隔幾個頻率單位發生一次 (>=1)
+	DayOfMonth      int        // MONTHLY: 每月第幾天 (1-31)，超過當月天數時為月底
+	LastBusinessDay bool       // MONTHLY: 每月最後一個工作日 (週一至週五)，與DayOfMonth擇一
+	StartDate       time.Time  // 第一次可能發生的日期
+	EndDate         *time.Time // 包含；最後一次可能發生的日期
+	Count           int        // 總發生次數上限 (含略過的日期)，0表示不限
+}
+
+// Validate 檢查排程設定並補上MONTHLY的預設日期 (StartDate的日)
+func (s *RecurrenceSchedule) Validate() error {
+	if _, err := ParseRecurrenceFrequency(string(s.Frequency)); err != nil {
+		return err
+	}
+	if s.Interval == 0 {
+		s.Interval = 1
+	}
+	if s.Interval < 1 {
+		return errors.New("recurrence interval must be positive")
+	}
+	if s.StartDate.IsZero() {
+		return errors.New("recurrence start date cannot be empty")
+	}
+	s.StartDate = truncateToDay(s.StartDate)
+
+	if s.Frequency == RecurrenceMonthly {
+		if s.LastBusinessDay && s.DayOfMonth != 0 {
+			return errors.New("monthly recurrence cannot use both a day of month and the last business day")
+		}
+		if !s.LastBusinessDay && s.DayOfMonth == 0 {
+			s.DayOfMonth = s.StartDate.Day()
+		}
+		if !s.LastBusinessDay && (s.DayOfMonth < 1 || s.DayOfMonth > 31) {
+			return errors.New("day of month must be between 1 and 31")
+		}
+	} else if s.DayOfMonth != 0 || s.LastBusinessDay {
+		return errors.New("day of month and last business day only apply to monthly recurrence")
+	}
+
+	if s.EndDate != nil && s.Count != 0 {
+		return errors.New("recurrence cannot have both an end date and a count")
+	}
+	if s.Count < 0 {
+		return errors.New("recurrence count cannot be negative")
+	}
+	if s.EndDate != nil {
+		end := truncateToDay(*s.EndDate)
+		if end.Before(s.StartDate) {
+			return errors.New("recurrence end date cannot be before start date")
+		}
+		s.EndDate = &end
+	}
+	return nil
+}
+
+// each 依序列出所有發生日，直到排程結束或fn回傳false
+func (s RecurrenceSchedule) each(fn func(date time.Time) bool) {
+	emitted := 0
+	for k := 0; ; k++ {
+		date := s.candidate(k)
+		if date.Before(s.StartDate) {
+			continue // 第一個週期中早於StartDate的日期 (例如每月1日，但從15日開始)
+		}
+		if s.EndDate != nil && date.After(*s.EndDate) {
+			return
+		}
+		if s.Count > 0 && emitted >= s.Count {
+			return
+		}
+		emitted++
+		if !fn(date) {
+			return
+		}
+	}
+}
+
+// candidate 第k個週期的日期 (可能早於StartDate)
+func (s RecurrenceSchedule) candidate(k int) time.Time {
+	start := s.StartDate
+	switch s.Frequency {
+	case RecurrenceDaily:
+		return start.AddDate(0, 0, k*s.Interval)
+	case RecurrenceWeekly:
+		return start.AddDate(0, 0, 7*k*s.Interval)
+	case RecurrenceMonthly:
+		month := time.Date(start.Year(), start.Month()+time.Month(k*s.Interval), 1, 0, 0, 0, 0, time.UTC)
+		if s.LastBusinessDay {
+			return lastBusinessDayOf(month)
+		}
+		return dayOfMonthClamped(month, s.DayOfMonth)
+	default:
+		year := time.Date(start.Year()+k*s.Interval, start.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return dayOfMonthClamped(year, start.Day())
+	}
+}
+
+// Between 回傳 [from, until] 之間的發生日 (含兩端，以日為單位)
+func (s RecurrenceSchedule) Between(from, until time.Time) []time.Time {
+	from, until = truncateToDay(from), truncateToDay(until)
+	var dates []time.Time
+	s.each(func(date time.Time) bool {
+		if date.After(until) {
+			return false
+		}
+		if !date.Before(from) {
+			dates = append(dates, date)
+		}
+		return true
+	})
+	return dates
+}
+
+// Next 回傳from當天或之後的前limit個發生日
+func (s RecurrenceSchedule) Next(from time.Time, limit int) []time.Time {
+	from = truncateToDay(from)
+	var dates []time.Time
+	s.each(func(date time.Time) bool {
+		if len(dates) >= limit {
+			return false
+		}
+		if !date.Before(from) {
+			dates = append(dates, date)
+		}
+		return len(dates) < limit
+	})
+	return dates
+}
+
+// Includes 日期是否為排程中的發生日
+func (s RecurrenceSchedule) Includes(date time.Time) bool {
+	day := truncateToDay(date)
+	dates := s.Between(day, day)
+	return len(dates) == 1
+}
+
+// RecurringOccurrence 已處理過的發生日 (產生、略過或失敗)
+type RecurringOccurrence struct {
+	Date          time.Time
+	Status        OccurrenceStatus
+	TransactionID string // 產生的支出或收入記錄ID
+	Attempts      int    // 嘗試產生交易的次數
+	LastError     string
+	UpdatedAt     time.Time
+}
+
+// ScheduledOccurrence 預覽中的一個發生日，已處理過時帶有處理結果
+type ScheduledOccurrence struct {
+	Date          time.Time
+	Status        OccurrenceStatus
+	TransactionID string
+	LastError     string
+}
+
+// RecurringRule 週期性交易規則 (聚合根)，例如房租、薪水、訂閱
+// 排程器依規則在指定錢包產生支出或收入；每個發生日的處理結果記錄在Occurrences，
+// 同一天不會產生兩次交易
+type RecurringRule struct {
+	ID            string
+	UserID        string
+	Name          string
+	Type          RecurringTransactionType
+	WalletID      string
+	SubcategoryID string
+	Amount        Money
+	Description   string
+	Schedule      RecurrenceSchedule
+	Status        RecurringRuleStatus
+	PausedAt      *time.Time
+	Occurrences   []RecurringOccurrence // 依日期排序
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	// Version 樂觀鎖版本號，記錄載入時的持久化版本 (新規則為0)
+	Version int64
+}
+
+// NewRecurringRule 建立週期規則
+func NewRecurringRule(userID, name string, txType RecurringTransactionType, walletID, subcategoryID string, amount Money, description string, schedule RecurrenceSchedule) (*RecurringRule, error) {
+	if userID == "" {
+		return nil, errors.New("user ID cannot be empty")
+	}
+	if _, err := ParseRecurringTransactionType(string(txType)); err != nil {
+		return nil, err
+	}
+	if walletID == "" {
+		return nil, errors.New("wallet ID cannot be empty")
+	}
+	if subcategoryID == "" {
+		return nil, errors.New("subcategory ID cannot be empty")
+	}
+	if amount.Amount <= 0 {
+		return nil, errors.New("recurring amount must be positive")
+	}
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("recurring rule name cannot be empty")
+	}
+	if len(name) > 100 {
+		return nil, errors.New("recurring rule name cannot exceed 100 characters")
+	}
+
+	now := time.Now()
+	return &RecurringRule{
+		ID:            uuid.NewString(),
+		UserID:        userID,
+		Name:          name,
+		Type:          txType,
+		WalletID:      walletID,
+		SubcategoryID: subcategoryID,
+		Amount:        amount,
+		Description:   description,
+		Schedule:      schedule,
+		Status:        RecurringRuleActive,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+// Pause 暫停規則，排程器不再產生交易
+func (r *RecurringRule) Pause(now time.Time) error {
+	if r.Status == RecurringRulePaused {
+		return errors.New("recurring rule is already paused")
+	}
+	pausedAt := now
+	r.Status = RecurringRulePaused
+	r.PausedAt = &pausedAt
+	r.UpdatedAt = now
+	return nil
+}
+
+// Resume 恢復規則；暫停當天到昨天之間尚未處理的發生日標記為略過，不會補產生
+func (r *RecurringRule) Resume(now time.Time) error {
+	if r.Status != RecurringRulePaused {
+		return errors.New("recurring rule is not paused")
+	}
+	if r.PausedAt != nil {
+		for _, date := range r.Schedule.Between(*r.PausedAt, truncateToDay(now).AddDate(0, 0, -1)) {
+			if r.findOccurrence(date) == nil {
+				r.setOccurrence(RecurringOccurrence{Date: date, Status: OccurrenceSkipped, UpdatedAt: now})
+			}
+		}
+	}
+	r.Status = RecurringRuleActive
+	r.PausedAt = nil
+	r.UpdatedAt = now
+	return nil
+}
+
+// Skip 略過單一發生日；已產生交易的日期不能略過
+func (r *RecurringRule) Skip(date time.Time, now time.Time) error {
+	date = truncateToDay(date)
+	if !r.Schedule.Includes(date) {
+		return fmt.Errorf("%s is not an occurrence of this rule", date.Format("2006-01-02"))
+	}
+	if existing := r.findOccurrence(date); existing != nil {
+		switch existing.Status {
+		case OccurrenceClaimed:
+			return fmt.Errorf("occurrence on %s is being generated", date.Format("2006-01-02"))
+		case OccurrenceGenerated:
+			return fmt.Errorf("occurrence on %s has already been generated", date.Format("2006-01-02"))
+		case OccurrenceSkipped:
+			return nil
+		}
+	}
+	r.setOccurrence(RecurringOccurrence{Date: date, Status: OccurrenceSkipped, UpdatedAt: now})
+	r.UpdatedAt = now
+	return nil
+}
+
+// Due 回傳到now (含當天) 為止應產生交易的日期
+// 暫停中不回傳；失敗的日期與認領超過lease仍未完成的日期 (排程器在產生途中中止)
+// 在嘗試次數未達maxAttempts前會再次回傳
+func (r *RecurringRule) Due(now time.Time, maxAttempts int, lease time.Duration) []time.Time {
+	if r.Status != RecurringRuleActive {
+		return nil
+	}
+	var due []time.Time
+	for _, date := range r.Schedule.Between(r.Schedule.StartDate, now) {
+		existing := r.findOccurrence(date)
+		if existing == nil || (existing.retryable(now, lease) && existing.Attempts < maxAttempts) {
+			due = append(due, date)
+		}
+	}
+	return due
+}
+
+// Claim 在產生交易前認領發生日 (標記為已認領)
+// 先儲存認領再產生交易，搭配樂觀鎖確保同一時間只有一個排程器處理同一天；
+// 認領超過lease仍未完成時可再次認領，交易以OccurrenceKey找回已產生的記錄，不會重複產生
+func (r *RecurringRule) Claim(date time.Time, now time.Time, lease time.Duration) error {
+	date = truncateToDay(date)
+	attempts := 1
+	if existing := r.findOccurrence(date); existing != nil {
+		if !existing.retryable(now, lease) {
+			return fmt.Errorf("occurrence on %s has already been processed", date.Format("2006-01-02"))
+		}
+		attempts = existing.Attempts + 1
+	}
+	r.setOccurrence(RecurringOccurrence{Date: date, Status: OccurrenceClaimed, Attempts: attempts, UpdatedAt: now})
+	r.UpdatedAt = now
+	return nil
+}
+
+// OccurrenceKey 回傳發生日在錢包中的識別 (規則ID與日期)，產生的交易帶有此識別，
+// 重試時用來找回先前已產生的記錄
+func (r *RecurringRule) OccurrenceKey(date time.Time) string {
+	return r.ID + ":" + truncateToDay(date).Format("2006-01-02")
+}
+
+// MarkGenerated 記錄認領的發生日所產生的交易ID
+func (r *RecurringRule) MarkGenerated(date time.Time, transactionID string, now time.Time) {
+	if occurrence := r.findOccurrence(date); occurrence != nil {
+		occurrence.Status = OccurrenceGenerated
+		occurrence.TransactionID = transactionID
+		occurrence.LastError = ""
+		occurrence.UpdatedAt = now
+		r.UpdatedAt = now
+	}
+}
+
+// MarkFailed 記錄認領的發生日產生交易失敗
+func (r *RecurringRule) MarkFailed(date time.Time, reason string, now time.Time) {
+	if occurrence := r.findOccurrence(date); occurrence != nil {
+		occurrence.Status = OccurrenceFailed
+		occurrence.LastError = reason
+		occurrence.UpdatedAt = now
+		r.UpdatedAt = now
+	}
+}
+
+// Preview 回傳from當天或之後的前limit個發生日及其狀態 (含已略過的日期)
+func (r *RecurringRule) Preview(from time.Time, limit int) []ScheduledOccurrence {
+	dates := r.Schedule.Next(from, limit)
+	preview := make([]ScheduledOccurrence, len(dates))
+	for i, date := range dates {
+		preview[i] = ScheduledOccurrence{Date: date, Status: OccurrenceScheduled}
+		if existing := r.findOccurrence(date); existing != nil {
+			preview[i].Status = existing.Status
+			preview[i].TransactionID = existing.TransactionID
+			preview[i].LastError = existing.LastError
+		}
+	}
+	return preview
+}
+
+// NextScheduled 回傳from當天或之後第一個尚未處理的發生日；暫停中或排程已結束時回傳false
+func (r *RecurringRule) NextScheduled(from time.Time) (time.Time, bool) {
+	if r.Status != RecurringRuleActive {
+		return time.Time{}, false
+	}
+	from = truncateToDay(from)
+	var next time.Time
+	found := false
+	r.Schedule.each(func(date time.Time) bool {
+		if !date.Before(from) && r.findOccurrence(date) == nil {
+			next, found = date, true
+			return false
+		}
+		return true
+	})
+	return next, found
+}
+
+// retryable 失敗或認領已超過lease的發生日可以再次認領
+func (o *RecurringOccurrence) retryable(now time.Time, lease time.Duration) bool {
+	switch o.Status {
+	case OccurrenceFailed:
+		return true
+	case OccurrenceClaimed:
+		return !o.UpdatedAt.Add(lease).After(now)
+	}
+	return false
+}
+
+func (r *RecurringRule) findOccurrence(date time.Time) *RecurringOccurrence {
+	date = truncateToDay(date)
+	for i := range r.Occurrences {
+		if r.Occurrences[i].Date.Equal(date) {
+			return &r.Occurrences[i]
+		}
+	}
+	return nil
+}
+
+// setOccurrence 新增或取代發生日記錄，保持依日期排序
+func (r *RecurringRule) setOccurrence(occurrence RecurringOccurrence) {
+	occurrence.Date = truncateToDay(occurrence.Date)
+	if existing := r.findOccurrence(occurrence.Date); existing != nil {
+		*existing = occurrence
+		return
+	}
+	r.Occurrences = append(r.Occurrences, occurrence)
+	sort.Slice(r.Occurrences, func(i, j int) bool {
+		return r.Occurrences[i].Date.Before(r.Occurrences[j].Date)
+	})
+}
+
+// lastBusinessDayOf 該月最後一個週一至週五 (不考慮國定假日)
+func lastBusinessDayOf(month time.Time) time.Time {
+	day := month.AddDate(0, 1, -1)
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// dayOfMonthClamped 該月第day天，超過當月天數時為月底
+func dayOfMonthClamped(month time.Time, day int) time.Time {
+	last := month.AddDate(0, 1, -1).Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(month.Year(), month.Month(), day, 0, 0, 0, 0, time.UTC)
+}
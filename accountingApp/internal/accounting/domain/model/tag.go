@@ -0,0 +1,157 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 標籤規則
+const (
+	MaxTagLength          = 50 // 單一標籤的字元數上限
+	MaxTagsPerTransaction = 20 // 單筆交易的標籤數上限
+)
+
+// NormalizeTag 正規化標籤名稱：去除前後空白並轉為小寫
+// 標籤是跨分類的自由標記 (例如 "trip-tokyo-2026")，不可包含空白或逗號
+func NormalizeTag(name string) (string, error) {
+	tag := strings.ToLower(strings.TrimSpace(name))
+	if tag == "" {
+		return "", errors.New("tag cannot be empty")
+	}
+	if utf8.RuneCountInString(tag) > MaxTagLength {
+		return "", fmt.Errorf("tag %q is longer than %d characters", tag, MaxTagLength)
+	}
+	for _, r := range tag {
+		if unicode.IsSpace(r) || r == ',' || !unicode.IsPrint(r) {
+			return "", fmt.Errorf("tag %q cannot contain spaces or commas", tag)
+		}
+	}
+	return tag, nil
+}
+
+// NormalizeTags 正規化一組標籤並去除重複，依字母順序排列
+func NormalizeTags(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	seen := make(map[string]bool, len(names))
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		tag, err := NormalizeTag(name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
+
+// HasTag 標籤列表是否包含tag (tag須已正規化)
+func HasTag(tags []string, tag string) bool {
+	for _, existing := range tags {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// TagEdit 對交易標籤的批次修改：加入Add並移除Remove
+type TagEdit struct {
+	Add    []string
+	Remove []string
+}
+
+// NewTagEdit 建立標籤修改，兩個列表皆會正規化；至少要有一個標籤，且同一標籤不可同時加入與移除
+func NewTagEdit(add, remove []string) (TagEdit, error) {
+	normalizedAdd, err := NormalizeTags(add)
+	if err != nil {
+		return TagEdit{}, err
+	}
+	normalizedRemove, err := NormalizeTags(remove)
+	if err != nil {
+		return TagEdit{}, err
+	}
+	if len(normalizedAdd) == 0 && len(normalizedRemove) == 0 {
+		return TagEdit{}, errors.New("no tags to add or remove")
+	}
+	for _, tag := range normalizedAdd {
+		if HasTag(normalizedRemove, tag) {
+			return TagEdit{}, fmt.Errorf("tag %q cannot be both added and removed", tag)
+		}
+	}
+	return TagEdit{Add: normalizedAdd, Remove: normalizedRemove}, nil
+}
+
+// applyTo 回傳套用修改後的標籤 (已排序)，以及是否與原本不同
+func (e TagEdit) applyTo(tags []string) ([]string, bool, error) {
+	result := make([]string, 0, len(tags)+len(e.Add))
+	for _, tag := range tags {
+		if !HasTag(e.Remove, tag) {
+			result = append(result, tag)
+		}
+	}
+	for _, tag := range e.Add {
+		if !HasTag(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	if len(result) > MaxTagsPerTransaction {
+		return nil, false, fmt.Errorf("a transaction can have at most %d tags", MaxTagsPerTransaction)
+	}
+	sort.Strings(result)
+
+	changed := len(result) != len(tags)
+	for i := 0; !changed && i < len(result); i++ {
+		changed = result[i] != tags[i]
+	}
+	if len(result) == 0 {
+		result = nil
+	}
+	return result, changed, nil
+}
+
+// TagMatch 以標籤篩選交易的方式
+type TagMatch string
+
+const (
+	TagMatchAny TagMatch = "any" // 至少包含一個篩選標籤
+	TagMatchAll TagMatch = "all" // 包含所有篩選標籤
+)
+
+// ParseTagMatch 解析篩選方式，空字串為 TagMatchAny
+func ParseTagMatch(s string) (TagMatch, error) {
+	switch TagMatch(strings.ToLower(s)) {
+	case "", TagMatchAny:
+		return TagMatchAny, nil
+	case TagMatchAll:
+		return TagMatchAll, nil
+	default:
+		return "", fmt.Errorf("invalid tag match: %s (use any or all)", s)
+	}
+}
+
+// Matches 交易標籤是否符合篩選標籤，沒有篩選標籤時一律符合
+func (m TagMatch) Matches(tags, filter []string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, tag := range filter {
+		found := HasTag(tags, tag)
+		if found && m != TagMatchAll {
+			return true
+		}
+		if !found && m == TagMatchAll {
+			return false
+		}
+	}
+	return m == TagMatchAll
+}
@@ -35,11 +35,22 @@ type Wallet struct {
 	Balance   Money
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// Version 樂觀鎖版本號，記錄載入時的持久化版本 (新錢包為0)
+	Version int64
 	
 	// 內部Entities - 聚合邊界內的所有交易記錄
 	expenseRecords []ExpenseRecord
 	incomeRecords  []IncomeRecord
 	transfers      []Transfer
+
+	// 子實體變更追蹤 - 讓Repository只持久化差異
+	expenseChanges  changeTracker
+	incomeChanges   changeTracker
+	transferChanges changeTracker
+
+	// 待發布的領域事件 - Repository儲存成功後發布並清除
+	events eventRecorder
 	
 	// 載入狀態標記
 	isFullyLoaded bool // 標記是否已載入所有交易記錄
@@ -69,7 +80,7 @@ func NewWalletWithInitialBalance(userID, name string, walletType WalletType, cur
 	}
 
 	now := time.Now()
-	return &Wallet{
+	wallet := &Wallet{
 		ID:              uuid.NewString(),
 		UserID:          userID,
 		Name:            strings.TrimSpace(name),
@@ -81,7 +92,14 @@ func NewWalletWithInitialBalance(userID, name string, walletType WalletType, cur
 		incomeRecords:   make([]IncomeRecord, 0),
 		transfers:       make([]Transfer, 0),
 		isFullyLoaded:   false,
-	}, nil
+	}
+	wallet.events.record(WalletCreated{
+		WalletEvent:    newWalletEvent(wallet),
+		Name:           wallet.Name,
+		Type:           wallet.Type,
+		InitialBalance: wallet.Balance,
+	})
+	return wallet, nil
 }
 
 // The Currency returns the currency of the wallet's balance
@@ -94,15 +112,23 @@ func (w *Wallet) UpdateName(name string) error {
 	if strings.TrimSpace(name) == "" {
 		return errors.New("wallet name cannot be empty")
 	}
+	oldName := w.Name
 	w.Name = strings.TrimSpace(name)
 	w.UpdatedAt = time.Now()
+	if w.Name != oldName {
+		w.events.record(WalletRenamed{WalletEvent: newWalletEvent(w), OldName: oldName, NewName: w.Name})
+	}
 	return nil
 }
 
 // UpdateType updates the wallet type
 func (w *Wallet) UpdateType(walletType WalletType) error {
+	oldType := w.Type
 	w.Type = walletType
 	w.UpdatedAt = time.Now()
+	if w.Type != oldType {
+		w.events.record(WalletTypeChanged{WalletEvent: newWalletEvent(w), OldType: oldType, NewType: w.Type})
+	}
 	return nil
 }
 
@@ -119,6 +145,24 @@ func (w *Wallet) GetTransfers() []Transfer {
 	return w.transfers
 }
 
+// HasImportedTransaction 錢包中是否已有以此匯入識別記錄的支出或收入
+func (w *Wallet) HasImportedTransaction(importID string) bool {
+	if importID == "" {
+		return false
+	}
+	for _, expense := range w.expenseRecords {
+		if expense.ImportID == importID {
+			return true
+		}
+	}
+	for _, income := range w.incomeRecords {
+		if income.ImportID == importID {
+			return true
+		}
+	}
+	return false
+}
+
 func (w *Wallet) IsFullyLoaded() bool {
 	return w.isFullyLoaded
 }
@@ -129,14 +173,49 @@ func (w *Wallet) SetFullyLoaded(loaded bool) {
 
 func (w *Wallet) AddExpenseRecord(record ExpenseRecord) {
 	w.expenseRecords = append(w.expenseRecords, record)
+	w.expenseChanges.markAdded(record.ID)
 }
 
 func (w *Wallet) AddIncomeRecord(record IncomeRecord) {
 	w.incomeRecords = append(w.incomeRecords, record)
+	w.incomeChanges.markAdded(record.ID)
 }
 
 func (w *Wallet) AddTransfer(transfer Transfer) {
 	w.transfers = append(w.transfers, transfer)
+	w.transferChanges.markAdded(transfer.ID)
+}
+
+// ExpenseRecordChanges 自上次持久化後支出記錄的變更
+func (w *Wallet) ExpenseRecordChanges() EntityChanges {
+	return w.expenseChanges.changes()
+}
+
+// IncomeRecordChanges 自上次持久化後收入記錄的變更
+func (w *Wallet) IncomeRecordChanges() EntityChanges {
+	return w.incomeChanges.changes()
+}
+
+// TransferChanges 自上次持久化後轉帳記錄的變更
+func (w *Wallet) TransferChanges() EntityChanges {
+	return w.transferChanges.changes()
+}
+
+// ClearChanges 持久化成功後清除變更追蹤
+func (w *Wallet) ClearChanges() {
+	w.expenseChanges.reset()
+	w.incomeChanges.reset()
+	w.transferChanges.reset()
+}
+
+// DomainEvents 自上次持久化後記錄的領域事件
+func (w *Wallet) DomainEvents() []DomainEvent {
+	return w.events.pending()
+}
+
+// ClearDomainEvents 事件發布後清除
+func (w *Wallet) ClearDomainEvents() {
+	w.events.reset()
 }
 
 func (w *Wallet) AddExpense(amount Money, subcategoryID, description string, date time.Time) (*ExpenseRecord, error) {
@@ -144,19 +223,63 @@ func (w *Wallet) AddExpense(amount Money, subcategoryID, description string, dat
 		return nil, fmt.Errorf("expense currency %s does not match wallet currency %s", amount.Currency, w.Currency())
 	}
 
-	newBalance, err := w.Balance.Subtract(amount)
+	expense, err := NewExpenseRecord(w.ID, subcategoryID, amount, description, date)
 	if err != nil {
-		return nil, fmt.Errorf("insufficient balance: %w", err)
+		return nil, err
 	}
+	return w.addExpense(expense)
+}
 
-	expense, err := NewExpenseRecord(w.ID, subcategoryID, amount, description, date)
+// AddSplitExpense 新增拆帳支出：一筆金額分攤到多個子分類，明細加總須等於總額
+func (w *Wallet) AddSplitExpense(amount Money, splits []ExpenseSplit, description string, date time.Time) (*ExpenseRecord, error) {
+	if amount.Currency != w.Currency() {
+		return nil, fmt.Errorf("expense currency %s does not match wallet currency %s", amount.Currency, w.Currency())
+	}
+
+	expense, err := NewSplitExpenseRecord(w.ID, amount, splits, description, date)
 	if err != nil {
 		return nil, err
 	}
+	return w.addExpense(expense)
+}
+
+// AddRecurringExpense 新增週期規則一個發生日的支出
+// 錢包中已有該發生日產生的支出時不重複新增，回傳既有記錄與added=false
+func (w *Wallet) AddRecurringExpense(occurrenceKey string, amount Money, subcategoryID, description string, date time.Time) (expense *ExpenseRecord, added bool, err error) {
+	if occurrenceKey == "" {
+		return nil, false, errors.New("occurrence key cannot be empty")
+	}
+	for i := range w.expenseRecords {
+		if w.expenseRecords[i].OccurrenceKey == occurrenceKey {
+			existing := w.expenseRecords[i]
+			return &existing, false, nil
+		}
+	}
+	if amount.Currency != w.Currency() {
+		return nil, false, fmt.Errorf("expense currency %s does not match wallet currency %s", amount.Currency, w.Currency())
+	}
+
+	expense, err = NewExpenseRecord(w.ID, subcategoryID, amount, description, date)
+	if err != nil {
+		return nil, false, err
+	}
+	expense.OccurrenceKey = occurrenceKey
+	expense, err = w.addExpense(expense)
+	return expense, err == nil, err
+}
+
+// addExpense 扣除餘額並記錄已建立的支出
+func (w *Wallet) addExpense(expense *ExpenseRecord) (*ExpenseRecord, error) {
+	newBalance, err := w.Balance.Subtract(expense.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("insufficient balance: %w", err)
+	}
 
 	w.Balance = *newBalance
 	w.expenseRecords = append(w.expenseRecords, *expense)
+	w.expenseChanges.markAdded(expense.ID)
 	w.UpdatedAt = time.Now()
+	w.events.record(ExpenseAdded{WalletEvent: newWalletEvent(w), Expense: *expense})
 	return expense, nil
 }
 
@@ -165,22 +288,361 @@ func (w *Wallet) AddIncome(amount Money, subcategoryID, description string, date
 		return nil, fmt.Errorf("income currency %s does not match wallet currency %s", amount.Currency, w.Currency())
 	}
 
-	newBalance, err := w.Balance.Add(amount)
+	income, err := NewIncomeRecord(w.ID, subcategoryID, amount, description, date)
 	if err != nil {
 		return nil, err
 	}
+	return w.addIncome(income)
+}
 
-	income, err := NewIncomeRecord(w.ID, subcategoryID, amount, description, date)
+// AddRecurringIncome 新增週期規則一個發生日的收入
+// 錢包中已有該發生日產生的收入時不重複新增，回傳既有記錄與added=false
+func (w *Wallet) AddRecurringIncome(occurrenceKey string, amount Money, subcategoryID, description string, date time.Time) (income *IncomeRecord, added bool, err error) {
+	if occurrenceKey == "" {
+		return nil, false, errors.New("occurrence key cannot be empty")
+	}
+	for i := range w.incomeRecords {
+		if w.incomeRecords[i].OccurrenceKey == occurrenceKey {
+			existing := w.incomeRecords[i]
+			return &existing, false, nil
+		}
+	}
+	if amount.Currency != w.Currency() {
+		return nil, false, fmt.Errorf("income currency %s does not match wallet currency %s", amount.Currency, w.Currency())
+	}
+
+	income, err = NewIncomeRecord(w.ID, subcategoryID, amount, description, date)
+	if err != nil {
+		return nil, false, err
+	}
+	income.OccurrenceKey = occurrenceKey
+	income, err = w.addIncome(income)
+	return income, err == nil, err
+}
+
+// addIncome 增加餘額並記錄已建立的收入
+func (w *Wallet) addIncome(income *IncomeRecord) (*IncomeRecord, error) {
+	newBalance, err := w.Balance.Add(income.Amount)
 	if err != nil {
 		return nil, err
 	}
 
 	w.Balance = *newBalance
 	w.incomeRecords = append(w.incomeRecords, *income)
+	w.incomeChanges.markAdded(income.ID)
 	w.UpdatedAt = time.Now()
+	w.events.record(IncomeAdded{WalletEvent: newWalletEvent(w), Income: *income})
 	return income, nil
 }
 
+// UpdateExpense 修改既有支出記錄並依差額重新計算餘額；原本的拆帳明細會被清除
+func (w *Wallet) UpdateExpense(expenseID string, amount Money, subcategoryID, description string, date time.Time) (*ExpenseRecord, error) {
+	return w.updateExpense(expenseID, amount, subcategoryID, nil, description, date)
+}
+
+// UpdateSplitExpense 以新的拆帳明細修改既有支出記錄並依差額重新計算餘額
+func (w *Wallet) UpdateSplitExpense(expenseID string, amount Money, splits []ExpenseSplit, description string, date time.Time) (*ExpenseRecord, error) {
+	if len(splits) == 0 {
+		return nil, errors.New("a split expense needs at least two lines")
+	}
+	return w.updateExpense(expenseID, amount, splits[0].SubcategoryID, splits, description, date)
+}
+
+func (w *Wallet) updateExpense(expenseID string, amount Money, subcategoryID string, splits []ExpenseSplit, description string, date time.Time) (*ExpenseRecord, error) {
+	index := w.findExpenseIndex(expenseID)
+	if index < 0 {
+		return nil, fmt.Errorf("expense record not found: %s", expenseID)
+	}
+	if amount.Currency != w.Currency() {
+		return nil, fmt.Errorf("expense currency %s does not match wallet currency %s", amount.Currency, w.Currency())
+	}
+	if amount.Amount <= 0 {
+		return nil, errors.New("expense amount must be positive")
+	}
+	if subcategoryID == "" {
+		return nil, errors.New("subcategory ID cannot be empty")
+	}
+	if splits != nil {
+		if err := validateExpenseSplits(amount, splits); err != nil {
+			return nil, err
+		}
+		splits = append([]ExpenseSplit(nil), splits...)
+	}
+
+	// 先退回原支出金額，再扣除新金額
+	record := w.expenseRecords[index]
+	previous := record
+	restored, err := w.Balance.Add(record.Amount)
+	if err != nil {
+		return nil, err
+	}
+	newBalance, err := restored.Subtract(amount)
+	if err != nil {
+		return nil, fmt.Errorf("insufficient balance: %w", err)
+	}
+
+	record.Amount = amount
+	record.SubcategoryID = subcategoryID
+	record.Splits = splits
+	record.Description = description
+	record.Date = date
+
+	w.Balance = *newBalance
+	w.expenseRecords[index] = record
+	w.expenseChanges.markModified(record.ID)
+	w.UpdatedAt = time.Now()
+	w.events.record(ExpenseUpdated{WalletEvent: newWalletEvent(w), Previous: previous, Expense: record})
+	return &record, nil
+}
+
+// RemoveExpense 刪除支出記錄並退回金額至餘額
+func (w *Wallet) RemoveExpense(expenseID string) error {
+	index := w.findExpenseIndex(expenseID)
+	if index < 0 {
+		return fmt.Errorf("expense record not found: %s", expenseID)
+	}
+
+	removed := w.expenseRecords[index]
+	newBalance, err := w.Balance.Add(removed.Amount)
+	if err != nil {
+		return err
+	}
+
+	w.Balance = *newBalance
+	w.expenseRecords = append(w.expenseRecords[:index], w.expenseRecords[index+1:]...)
+	w.expenseChanges.markRemoved(expenseID)
+	w.UpdatedAt = time.Now()
+	w.events.record(ExpenseRemoved{WalletEvent: newWalletEvent(w), Expense: removed})
+	return nil
+}
+
+// UpdateIncome 修改既有收入記錄並依差額重新計算餘額
+func (w *Wallet) UpdateIncome(incomeID string, amount Money, subcategoryID, description string, date time.Time) (*IncomeRecord, error) {
+	index := w.findIncomeIndex(incomeID)
+	if index < 0 {
+		return nil, fmt.Errorf("income record not found: %s", incomeID)
+	}
+	if amount.Currency != w.Currency() {
+		return nil, fmt.Errorf("income currency %s does not match wallet currency %s", amount.Currency, w.Currency())
+	}
+	if amount.Amount <= 0 {
+		return nil, errors.New("income amount must be positive")
+	}
+	if subcategoryID == "" {
+		return nil, errors.New("subcategory ID cannot be empty")
+	}
+
+	// 先扣除原收入金額 (可能已被花用)，再加上新金額
+	record := w.incomeRecords[index]
+	previous := record
+	increased, err := w.Balance.Add(amount)
+	if err != nil {
+		return nil, err
+	}
+	newBalance, err := increased.Subtract(record.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("insufficient balance: %w", err)
+	}
+
+	record.Amount = amount
+	record.SubcategoryID = subcategoryID
+	record.Description = description
+	record.Date = date
+
+	w.Balance = *newBalance
+	w.incomeRecords[index] = record
+	w.incomeChanges.markModified(record.ID)
+	w.UpdatedAt = time.Now()
+	w.events.record(IncomeUpdated{WalletEvent: newWalletEvent(w), Previous: previous, Income: record})
+	return &record, nil
+}
+
+// RemoveIncome 刪除收入記錄並自餘額扣回金額，餘額不足時拒絕
+func (w *Wallet) RemoveIncome(incomeID string) error {
+	index := w.findIncomeIndex(incomeID)
+	if index < 0 {
+		return fmt.Errorf("income record not found: %s", incomeID)
+	}
+
+	removed := w.incomeRecords[index]
+	newBalance, err := w.Balance.Subtract(removed.Amount)
+	if err != nil {
+		return fmt.Errorf("insufficient balance: %w", err)
+	}
+
+	w.Balance = *newBalance
+	w.incomeRecords = append(w.incomeRecords[:index], w.incomeRecords[index+1:]...)
+	w.incomeChanges.markRemoved(incomeID)
+	w.UpdatedAt = time.Now()
+	w.events.record(IncomeRemoved{WalletEvent: newWalletEvent(w), Income: removed})
+	return nil
+}
+
+// MergeExpense 將重複的支出併入保留的支出：加入其標籤、補上空白的描述、匯入識別與發生日識別 (之後重新匯入或重新產生仍會略過)，
+// 再刪除重複的支出並退回其金額
+func (w *Wallet) MergeExpense(keepID, duplicateID string) (*ExpenseRecord, error) {
+	keepIndex, duplicateIndex := w.findExpenseIndex(keepID), w.findExpenseIndex(duplicateID)
+	if keepIndex < 0 || duplicateIndex < 0 || keepID == duplicateID {
+		return nil, fmt.Errorf("expense records to merge not found: %s, %s", keepID, duplicateID)
+	}
+
+	duplicate := w.expenseRecords[duplicateIndex]
+	merged := w.expenseRecords[keepIndex]
+	tags, _, err := TagEdit{Add: duplicate.Tags}.applyTo(merged.Tags)
+	if err != nil {
+		return nil, err
+	}
+	previous := merged
+	merged.Tags = tags
+	if merged.Description == "" {
+		merged.Description = duplicate.Description
+	}
+	if merged.ImportID == "" {
+		merged.ImportID = duplicate.ImportID
+	}
+	if merged.OccurrenceKey == "" {
+		merged.OccurrenceKey = duplicate.OccurrenceKey
+	}
+
+	if err := w.RemoveExpense(duplicateID); err != nil {
+		return nil, err
+	}
+	w.expenseRecords[w.findExpenseIndex(keepID)] = merged
+	w.expenseChanges.markModified(keepID)
+	w.events.record(ExpenseUpdated{WalletEvent: newWalletEvent(w), Previous: previous, Expense: merged})
+	return &merged, nil
+}
+
+// MergeIncome 將重複的收入併入保留的收入 (規則同MergeExpense)；扣回重複收入後餘額不足時拒絕
+func (w *Wallet) MergeIncome(keepID, duplicateID string) (*IncomeRecord, error) {
+	keepIndex, duplicateIndex := w.findIncomeIndex(keepID), w.findIncomeIndex(duplicateID)
+	if keepIndex < 0 || duplicateIndex < 0 || keepID == duplicateID {
+		return nil, fmt.Errorf("income records to merge not found: %s, %s", keepID, duplicateID)
+	}
+
+	duplicate := w.incomeRecords[duplicateIndex]
+	merged := w.incomeRecords[keepIndex]
+	tags, _, err := TagEdit{Add: duplicate.Tags}.applyTo(merged.Tags)
+	if err != nil {
+		return nil, err
+	}
+	previous := merged
+	merged.Tags = tags
+	if merged.Description == "" {
+		merged.Description = duplicate.Description
+	}
+	if merged.ImportID == "" {
+		merged.ImportID = duplicate.ImportID
+	}
+	if merged.OccurrenceKey == "" {
+		merged.OccurrenceKey = duplicate.OccurrenceKey
+	}
+
+	if err := w.RemoveIncome(duplicateID); err != nil {
+		return nil, err
+	}
+	w.incomeRecords[w.findIncomeIndex(keepID)] = merged
+	w.incomeChanges.markModified(keepID)
+	w.events.record(IncomeUpdated{WalletEvent: newWalletEvent(w), Previous: previous, Income: merged})
+	return &merged, nil
+}
+
+// EditExpenseTags 依edit修改支出記錄的標籤，回傳標籤是否有變更
+func (w *Wallet) EditExpenseTags(expenseID string, edit TagEdit) (bool, error) {
+	index := w.findExpenseIndex(expenseID)
+	if index < 0 {
+		return false, fmt.Errorf("expense record not found: %s", expenseID)
+	}
+
+	record := &w.expenseRecords[index]
+	tags, changed, err := edit.applyTo(record.Tags)
+	if err != nil || !changed {
+		return false, err
+	}
+
+	w.recordTagsChanged("expense", record.ID, record.Tags, tags)
+	record.Tags = tags
+	w.expenseChanges.markModified(record.ID)
+	return true, nil
+}
+
+// EditIncomeTags 依edit修改收入記錄的標籤，回傳標籤是否有變更
+func (w *Wallet) EditIncomeTags(incomeID string, edit TagEdit) (bool, error) {
+	index := w.findIncomeIndex(incomeID)
+	if index < 0 {
+		return false, fmt.Errorf("income record not found: %s", incomeID)
+	}
+
+	record := &w.incomeRecords[index]
+	tags, changed, err := edit.applyTo(record.Tags)
+	if err != nil || !changed {
+		return false, err
+	}
+
+	w.recordTagsChanged("income", record.ID, record.Tags, tags)
+	record.Tags = tags
+	w.incomeChanges.markModified(record.ID)
+	return true, nil
+}
+
+// EditTransferTags 依edit修改轉帳記錄的標籤，回傳標籤是否有變更
+// 轉帳同時存在於來源與目標錢包，呼叫端須對兩個錢包套用相同的修改
+func (w *Wallet) EditTransferTags(transferID string, edit TagEdit) (bool, error) {
+	index := w.findTransferIndex(transferID)
+	if index < 0 {
+		return false, fmt.Errorf("transfer not found: %s", transferID)
+	}
+
+	transfer := &w.transfers[index]
+	tags, changed, err := edit.applyTo(transfer.Tags)
+	if err != nil || !changed {
+		return false, err
+	}
+
+	w.recordTagsChanged("transfer", transfer.ID, transfer.Tags, tags)
+	transfer.Tags = tags
+	w.transferChanges.markModified(transfer.ID)
+	return true, nil
+}
+
+func (w *Wallet) recordTagsChanged(transactionType, transactionID string, previous, tags []string) {
+	w.UpdatedAt = time.Now()
+	w.events.record(TagsChanged{
+		WalletEvent:     newWalletEvent(w),
+		TransactionType: transactionType,
+		TransactionID:   transactionID,
+		Previous:        previous,
+		Tags:            tags,
+	})
+}
+
+func (w *Wallet) findExpenseIndex(expenseID string) int {
+	for i, record := range w.expenseRecords {
+		if record.ID == expenseID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (w *Wallet) findIncomeIndex(incomeID string) int {
+	for i, record := range w.incomeRecords {
+		if record.ID == incomeID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (w *Wallet) findTransferIndex(transferID string) int {
+	for i, transfer := range w.transfers {
+		if transfer.ID == transferID {
+			return i
+		}
+	}
+	return -1
+}
+
 func (w *Wallet) CanTransfer(amount Money) error {
 	if amount.Currency != w.Currency() {
 		return fmt.Errorf("transfer currency %s does not match wallet currency %s", amount.Currency, w.Currency())
@@ -221,6 +683,25 @@ func (w *Wallet) CreateTransfer(toWalletID string, amount Money, fee Money, desc
 	}
 	
 	w.transfers = append(w.transfers, *transfer)
+	w.transferChanges.markAdded(transfer.ID)
+	w.events.record(TransferSent{WalletEvent: newWalletEvent(w), Transfer: *transfer})
+	return transfer, nil
+}
+
+// SendTransfer 建立轉帳並從來源錢包扣款 (金額及來源幣別的手續費)
+// toAmount為目標錢包幣別的金額，同幣別時與amount相同
+func (w *Wallet) SendTransfer(toWalletID string, amount, toAmount, fee Money, description string, date time.Time) (*Transfer, error) {
+	transfer, err := NewExchangeTransfer(w.ID, toWalletID, amount, toAmount, fee, description, date)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.ProcessOutgoingTransfer(transfer.Amount, transfer.SourceFee()); err != nil {
+		return nil, err
+	}
+
+	w.transfers = append(w.transfers, *transfer)
+	w.transferChanges.markAdded(transfer.ID)
+	w.events.record(TransferSent{WalletEvent: newWalletEvent(w), Transfer: *transfer})
 	return transfer, nil
 }
 
@@ -239,6 +720,27 @@ func (w *Wallet) ProcessIncomingTransfer(amount Money) error {
 	return nil
 }
 
+// ReceiveTransfer 目標錢包入帳 (CreditedAmount) 並記錄轉帳，讓轉入也出現在目標錢包的交易歷史中
+func (w *Wallet) ReceiveTransfer(transfer Transfer) error {
+	if transfer.ToWalletID != w.ID {
+		return fmt.Errorf("transfer %s is not addressed to wallet %s", transfer.ID, w.ID)
+	}
+
+	if err := w.ProcessIncomingTransfer(transfer.CreditedAmount()); err != nil {
+		return err
+	}
+
+	w.transfers = append(w.transfers, transfer)
+	w.transferChanges.markAdded(transfer.ID)
+	w.events.record(TransferReceived{WalletEvent: newWalletEvent(w), Transfer: transfer})
+	return nil
+}
+
+// IsIncomingTransfer 判斷轉帳是否為轉入此錢包
+func (w *Wallet) IsIncomingTransfer(transfer Transfer) bool {
+	return transfer.ToWalletID == w.ID
+}
+
 // Transaction 統一交易記錄介面
 type Transaction struct {
 	Type   string      // "expense", "income", "transfer"
@@ -0,0 +1,157 @@
+package model
+
+import "time"
+
+// 錢包聚合的事件名稱
+const (
+	EventWalletCreated     = "wallet.created"
+	EventWalletRenamed     = "wallet.renamed"
+	EventWalletTypeChanged = "wallet.type_changed"
+	EventWalletDeleted     = "wallet.deleted"
+	EventExpenseAdded      = "wallet.expense_added"
+	EventExpenseUpdated    = "wallet.expense_updated"
+	EventExpenseRemoved    = "wallet.expense_removed"
+	EventIncomeAdded       = "wallet.income_added"
+	EventIncomeUpdated     = "wallet.income_updated"
+	EventIncomeRemoved     = "wallet.income_removed"
+	EventTransferSent      = "wallet.transfer_sent"
+	EventTransferReceived  = "wallet.transfer_received"
+	EventTagsChanged       = "wallet.tags_changed"
+)
+
+// WalletEvent 錢包事件的共同欄位
+type WalletEvent struct {
+	WalletID   string
+	UserID     string
+	OccurredOn time.Time
+}
+
+func newWalletEvent(w *Wallet) WalletEvent {
+	return WalletEvent{WalletID: w.ID, UserID: w.UserID, OccurredOn: time.Now()}
+}
+
+func (e WalletEvent) AggregateID() string {
+	return e.WalletID
+}
+
+func (e WalletEvent) OccurredAt() time.Time {
+	return e.OccurredOn
+}
+
+// WalletCreated 新錢包建立
+type WalletCreated struct {
+	WalletEvent
+	Name           string
+	Type           WalletType
+	InitialBalance Money
+}
+
+func (WalletCreated) EventName() string { return EventWalletCreated }
+
+// WalletRenamed 錢包名稱變更
+type WalletRenamed struct {
+	WalletEvent
+	OldName string
+	NewName string
+}
+
+func (WalletRenamed) EventName() string { return EventWalletRenamed }
+
+// WalletTypeChanged 錢包類型變更
+type WalletTypeChanged struct {
+	WalletEvent
+	OldType WalletType
+	NewType WalletType
+}
+
+func (WalletTypeChanged) EventName() string { return EventWalletTypeChanged }
+
+// WalletDeleted 錢包與其所有記錄被刪除
+// 刪除不經過聚合的儲存，由儲存庫在刪除成功後以NewWalletDeleted建立並發布
+type WalletDeleted struct {
+	WalletEvent
+}
+
+// NewWalletDeleted 建立錢包的刪除事件
+func NewWalletDeleted(w *Wallet) WalletDeleted {
+	return WalletDeleted{WalletEvent: newWalletEvent(w)}
+}
+
+func (WalletDeleted) EventName() string { return EventWalletDeleted }
+
+// ExpenseAdded 新增支出記錄
+type ExpenseAdded struct {
+	WalletEvent
+	Expense ExpenseRecord
+}
+
+func (ExpenseAdded) EventName() string { return EventExpenseAdded }
+
+// ExpenseUpdated 修改支出記錄，Previous為修改前的內容
+type ExpenseUpdated struct {
+	WalletEvent
+	Previous ExpenseRecord
+	Expense  ExpenseRecord
+}
+
+func (ExpenseUpdated) EventName() string { return EventExpenseUpdated }
+
+// ExpenseRemoved 刪除支出記錄
+type ExpenseRemoved struct {
+	WalletEvent
+	Expense ExpenseRecord
+}
+
+func (ExpenseRemoved) EventName() string { return EventExpenseRemoved }
+
+// IncomeAdded 新增收入記錄
+type IncomeAdded struct {
+	WalletEvent
+	Income IncomeRecord
+}
+
+func (IncomeAdded) EventName() string { return EventIncomeAdded }
+
+// IncomeUpdated 修改收入記錄，Previous為修改前的內容
+type IncomeUpdated struct {
+	WalletEvent
+	Previous IncomeRecord
+	Income   IncomeRecord
+}
+
+func (IncomeUpdated) EventName() string { return EventIncomeUpdated }
+
+// IncomeRemoved 刪除收入記錄
+type IncomeRemoved struct {
+	WalletEvent
+	Income IncomeRecord
+}
+
+func (IncomeRemoved) EventName() string { return EventIncomeRemoved }
+
+// TransferSent 來源錢包轉出
+type TransferSent struct {
+	WalletEvent
+	Transfer Transfer
+}
+
+func (TransferSent) EventName() string { return EventTransferSent }
+
+// TransferReceived 目標錢包轉入
+type TransferReceived struct {
+	WalletEvent
+	Transfer Transfer
+}
+
+func (TransferReceived) EventName() string { return EventTransferReceived }
+
+// TagsChanged 交易的標籤變更；TransactionType 為 "expense"、"income" 或 "transfer"
+type TagsChanged struct {
+	WalletEvent
+	TransactionType string
+	TransactionID   string
+	Previous        []string
+	Tags            []string
+}
+
+func (TagsChanged) EventName() string { return EventTagsChanged }
@@ -0,0 +1,26 @@
+package database
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+)
+
+// NewPgAPIKeyStore 建立 api_keys 資料表的 QueryAggregateStore
+func NewPgAPIKeyStore(dbClient DatabaseClient) store.QueryAggregateStore[mapper.APIKeyData] {
+	return NewPgQueryAggregateStoreAdapter[mapper.APIKeyData](
+		dbClient,
+		"api_keys",
+		[]string{"id", "user_id", "name", "key_hash", "created_at", "revoked_at"},
+		func(row RowScanner) (*mapper.APIKeyData, error) {
+			var data mapper.APIKeyData
+			err := row.Scan(&data.ID, &data.UserID, &data.Name, &data.KeyHash, &data.CreatedAt, &data.RevokedAt)
+			if err != nil {
+				return nil, err
+			}
+			return &data, nil
+		},
+		func(data mapper.APIKeyData) []interface{} {
+			return []interface{}{data.ID, data.UserID, data.Name, data.KeyHash, data.CreatedAt, data.RevokedAt}
+		},
+	)
+}
@@ -0,0 +1,32 @@
+package database
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+)
+
+// NewPgAttachmentStore 建立 attachments 資料表的 QueryAggregateStore
+func NewPgAttachmentStore(dbClient DatabaseClient) store.QueryAggregateStore[mapper.AttachmentData] {
+	return NewPgQueryAggregateStoreAdapter[mapper.AttachmentData](
+		dbClient,
+		"attachments",
+		[]string{"id", "user_id", "wallet_id", "record_type", "record_id", "file_name", "content_type", "size", "checksum", "storage_key", "created_at"},
+		func(row RowScanner) (*mapper.AttachmentData, error) {
+			var data mapper.AttachmentData
+			err := row.Scan(
+				&data.ID, &data.UserID, &data.WalletID, &data.RecordType, &data.RecordID,
+				&data.FileName, &data.ContentType, &data.Size, &data.Checksum, &data.StorageKey, &data.CreatedAt,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return &data, nil
+		},
+		func(data mapper.AttachmentData) []interface{} {
+			return []interface{}{
+				data.ID, data.UserID, data.WalletID, data.RecordType, data.RecordID,
+				data.FileName, data.ContentType, data.Size, data.Checksum, data.StorageKey, data.CreatedAt,
+			}
+		},
+	)
+}
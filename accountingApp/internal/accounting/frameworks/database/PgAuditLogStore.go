@@ -0,0 +1,41 @@
+package database
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+)
+
+// AuditLogColumns audit_log 資料表欄位，順序與 ScanAuditEntry 一致
+// audit_log 只能新增，因此不提供會 upsert 的 QueryAggregateStore
+var AuditLogColumns = []string{
+	"sequence", "id", "actor_id", "command", "aggregate_type", "aggregate_id",
+	"before_snapshot", "after_snapshot", "request_id", "occurred_at", "prev_hash", "hash",
+}
+
+// ScanAuditEntry 依 AuditLogColumns 的順序掃描一筆稽核紀錄
+func ScanAuditEntry(row RowScanner) (*mapper.AuditEntryData, error) {
+	var data mapper.AuditEntryData
+	err := row.Scan(
+		&data.Sequence, &data.ID, &data.ActorID, &data.Command, &data.AggregateType, &data.AggregateID,
+		&data.Before, &data.After, &data.RequestID, &data.OccurredAt, &data.PrevHash, &data.Hash,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// AuditEntryValues 依 AuditLogColumns 的順序取得寫入參數
+func AuditEntryValues(data mapper.AuditEntryData) []interface{} {
+	return []interface{}{
+		data.Sequence, data.ID, data.ActorID, data.Command, data.AggregateType, data.AggregateID,
+		nullableJSON(data.Before), nullableJSON(data.After), data.RequestID, data.OccurredAt, data.PrevHash, data.Hash,
+	}
+}
+
+// nullableJSON 空快照寫入為 NULL 而非空字串 (空字串不是合法的 JSON)
+func nullableJSON(data []byte) interface{} {
+	if data == nil {
+		return nil
+	}
+	return string(data)
+}
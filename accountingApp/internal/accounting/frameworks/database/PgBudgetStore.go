@@ -0,0 +1,35 @@
+package database
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+)
+
+// NewPgBudgetStore 建立 budgets 資料表的 QueryAggregateStore
+func NewPgBudgetStore(dbClient DatabaseClient) store.QueryAggregateStore[mapper.BudgetData] {
+	return NewPgQueryAggregateStoreAdapter[mapper.BudgetData](
+		dbClient,
+		"budgets",
+		[]string{
+			"id", "user_id", "name", "period", "category_id", "subcategory_id", "amount", "currency",
+			"rollover", "warning_threshold", "start_date", "end_date", "created_at", "updated_at",
+		},
+		func(row RowScanner) (*mapper.BudgetData, error) {
+			var data mapper.BudgetData
+			err := row.Scan(
+				&data.ID, &data.UserID, &data.Name, &data.Period, &data.CategoryID, &data.SubcategoryID, &data.Amount, &data.Currency,
+				&data.Rollover, &data.WarningThreshold, &data.StartDate, &data.EndDate, &data.CreatedAt, &data.UpdatedAt,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return &data, nil
+		},
+		func(data mapper.BudgetData) []interface{} {
+			return []interface{}{
+				data.ID, data.UserID, data.Name, data.Period, data.CategoryID, data.SubcategoryID, data.Amount, data.Currency,
+				data.Rollover, data.WarningThreshold, data.StartDate, data.EndDate, data.CreatedAt, data.UpdatedAt,
+			}
+		},
+	)
+}
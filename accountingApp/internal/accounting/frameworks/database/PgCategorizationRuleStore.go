@@ -0,0 +1,35 @@
+package database
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+)
+
+// NewPgCategorizationRuleStore 建立 categorization_rules 資料表的 QueryAggregateStore
+func NewPgCategorizationRuleStore(dbClient DatabaseClient) store.QueryAggregateStore[mapper.CategorizationRuleData] {
+	return NewPgQueryAggregateStoreAdapter[mapper.CategorizationRuleData](
+		dbClient,
+		"categorization_rules",
+		[]string{
+			"id", "user_id", "name", "rule_type", "priority", "description_pattern", "min_amount", "max_amount",
+			"currency", "wallet_id", "weekday_mask", "subcategory_id", "tags", "created_at", "updated_at",
+		},
+		func(row RowScanner) (*mapper.CategorizationRuleData, error) {
+			var data mapper.CategorizationRuleData
+			err := row.Scan(
+				&data.ID, &data.UserID, &data.Name, &data.RuleType, &data.Priority, &data.DescriptionPattern, &data.MinAmount, &data.MaxAmount,
+				&data.Currency, &data.WalletID, &data.WeekdayMask, &data.SubcategoryID, &data.Tags, &data.CreatedAt, &data.UpdatedAt,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return &data, nil
+		},
+		func(data mapper.CategorizationRuleData) []interface{} {
+			return []interface{}{
+				data.ID, data.UserID, data.Name, data.RuleType, data.Priority, data.DescriptionPattern, data.MinAmount, data.MaxAmount,
+				data.Currency, data.WalletID, data.WeekdayMask, data.SubcategoryID, data.Tags, data.CreatedAt, data.UpdatedAt,
+			}
+		},
+	)
+}
@@ -0,0 +1,48 @@
+package database
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+)
+
+// NewPgExpenseCategoryStore 建立 expense_categories 資料表的 QueryAggregateStore
+// 子分類不在此 Store 處理，由 Peer 在同一事務中同步 expense_subcategories
+func NewPgExpenseCategoryStore(dbClient DatabaseClient) store.QueryAggregateStore[mapper.ExpenseCategoryData] {
+	return NewPgQueryAggregateStoreAdapter[mapper.ExpenseCategoryData](
+		dbClient,
+		"expense_categories",
+		[]string{"id", "user_id", "name", "created_at", "updated_at"},
+		func(row RowScanner) (*mapper.ExpenseCategoryData, error) {
+			var data mapper.ExpenseCategoryData
+			err := row.Scan(&data.ID, &data.UserID, &data.Name, &data.CreatedAt, &data.UpdatedAt)
+			if err != nil {
+				return nil, err
+			}
+			return &data, nil
+		},
+		func(data mapper.ExpenseCategoryData) []interface{} {
+			return []interface{}{data.ID, data.UserID, data.Name, data.CreatedAt, data.UpdatedAt}
+		},
+	)
+}
+
+// NewPgIncomeCategoryStore 建立 income_categories 資料表的 QueryAggregateStore
+// 子分類不在此 Store 處理，由 Peer 在同一事務中同步 income_subcategories
+func NewPgIncomeCategoryStore(dbClient DatabaseClient) store.QueryAggregateStore[mapper.IncomeCategoryData] {
+	return NewPgQueryAggregateStoreAdapter[mapper.IncomeCategoryData](
+		dbClient,
+		"income_categories",
+		[]string{"id", "user_id", "name", "created_at", "updated_at"},
+		func(row RowScanner) (*mapper.IncomeCategoryData, error) {
+			var data mapper.IncomeCategoryData
+			err := row.Scan(&data.ID, &data.UserID, &data.Name, &data.CreatedAt, &data.UpdatedAt)
+			if err != nil {
+				return nil, err
+			}
+			return &data, nil
+		},
+		func(data mapper.IncomeCategoryData) []interface{} {
+			return []interface{}{data.ID, data.UserID, data.Name, data.CreatedAt, data.UpdatedAt}
+		},
+	)
+}
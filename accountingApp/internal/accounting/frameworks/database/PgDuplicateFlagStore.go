@@ -0,0 +1,32 @@
+package database
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+)
+
+// NewPgDuplicateFlagStore 建立 duplicate_flags 資料表的 QueryAggregateStore
+func NewPgDuplicateFlagStore(dbClient DatabaseClient) store.QueryAggregateStore[mapper.DuplicateFlagData] {
+	return NewPgQueryAggregateStoreAdapter[mapper.DuplicateFlagData](
+		dbClient,
+		"duplicate_flags",
+		[]string{"id", "user_id", "wallet_id", "record_type", "record_id", "duplicate_of_id", "score", "status", "created_at", "resolved_at"},
+		func(row RowScanner) (*mapper.DuplicateFlagData, error) {
+			var data mapper.DuplicateFlagData
+			err := row.Scan(
+				&data.ID, &data.UserID, &data.WalletID, &data.RecordType, &data.RecordID,
+				&data.DuplicateOfID, &data.Score, &data.Status, &data.CreatedAt, &data.ResolvedAt,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return &data, nil
+		},
+		func(data mapper.DuplicateFlagData) []interface{} {
+			return []interface{}{
+				data.ID, data.UserID, data.WalletID, data.RecordType, data.RecordID,
+				data.DuplicateOfID, data.Score, data.Status, data.CreatedAt, data.ResolvedAt,
+			}
+		},
+	)
+}
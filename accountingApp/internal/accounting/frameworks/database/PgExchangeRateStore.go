@@ -0,0 +1,26 @@
+package database
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+)
+
+// ExchangeRateColumns exchange_rates 資料表欄位，順序與 ScanExchangeRate 一致
+// 匯率依 (base, quote, rate_date) 查詢最新一筆，因此不使用只支援等值條件的 QueryAggregateStore
+var ExchangeRateColumns = []string{
+	"id", "base_currency", "quote_currency", "rate", "rate_date", "source", "updated_at",
+}
+
+// ScanExchangeRate 依 ExchangeRateColumns 的順序掃描一筆匯率
+func ScanExchangeRate(row RowScanner) (*mapper.ExchangeRateData, error) {
+	var data mapper.ExchangeRateData
+	err := row.Scan(&data.ID, &data.Base, &data.Quote, &data.Rate, &data.Date, &data.Source, &data.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ExchangeRateValues 依 ExchangeRateColumns 的順序取得寫入參數
+func ExchangeRateValues(data mapper.ExchangeRateData) []interface{} {
+	return []interface{}{data.ID, data.Base, data.Quote, data.Rate, data.Date, data.Source, data.UpdatedAt}
+}
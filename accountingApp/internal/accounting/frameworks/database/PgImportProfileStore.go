@@ -0,0 +1,38 @@
+package database
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+)
+
+// NewPgImportProfileStore 建立 import_profiles 資料表的 QueryAggregateStore
+func NewPgImportProfileStore(dbClient DatabaseClient) store.QueryAggregateStore[mapper.ImportProfileData] {
+	return NewPgQueryAggregateStoreAdapter[mapper.ImportProfileData](
+		dbClient,
+		"import_profiles",
+		[]string{
+			"id", "user_id", "name", "delimiter", "encoding", "has_header", "skip_rows", "date_column", "date_format",
+			"description_column", "amount_column", "sign_convention", "debit_column", "credit_column",
+			"expense_subcategory_id", "income_subcategory_id", "created_at", "updated_at",
+		},
+		func(row RowScanner) (*mapper.ImportProfileData, error) {
+			var data mapper.ImportProfileData
+			err := row.Scan(
+				&data.ID, &data.UserID, &data.Name, &data.Delimiter, &data.Encoding, &data.HasHeader, &data.SkipRows, &data.DateColumn, &data.DateFormat,
+				&data.DescriptionColumn, &data.AmountColumn, &data.SignConvention, &data.DebitColumn, &data.CreditColumn,
+				&data.ExpenseSubcategoryID, &data.IncomeSubcategoryID, &data.CreatedAt, &data.UpdatedAt,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return &data, nil
+		},
+		func(data mapper.ImportProfileData) []interface{} {
+			return []interface{}{
+				data.ID, data.UserID, data.Name, data.Delimiter, data.Encoding, data.HasHeader, data.SkipRows, data.DateColumn, data.DateFormat,
+				data.DescriptionColumn, data.AmountColumn, data.SignConvention, data.DebitColumn, data.CreditColumn,
+				data.ExpenseSubcategoryID, data.IncomeSubcategoryID, data.CreatedAt, data.UpdatedAt,
+			}
+		},
+	)
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+)
+
+// OutboxColumns outbox 資料表欄位，順序與 ScanOutboxMessage 一致
+var OutboxColumns = []string{
+	"id", "aggregate_type", "aggregate_id", "event_type", "payload", "status",
+	"attempts", "last_error", "occurred_at", "next_attempt_at", "delivered_at", "created_at",
+}
+
+// ScanOutboxMessage 依 OutboxColumns 的順序掃描一筆 outbox 訊息
+func ScanOutboxMessage(row RowScanner) (*mapper.OutboxMessageData, error) {
+	var data mapper.OutboxMessageData
+	err := row.Scan(
+		&data.ID, &data.AggregateType, &data.AggregateID, &data.EventType, &data.Payload, &data.Status,
+		&data.Attempts, &data.LastError, &data.OccurredAt, &data.NextAttemptAt, &data.DeliveredAt, &data.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// NewPgOutboxStore 建立 outbox 資料表的 QueryAggregateStore
+func NewPgOutboxStore(dbClient DatabaseClient) store.QueryAggregateStore[mapper.OutboxMessageData] {
+	return NewPgQueryAggregateStoreAdapter[mapper.OutboxMessageData](
+		dbClient,
+		"outbox",
+		OutboxColumns,
+		ScanOutboxMessage,
+		func(data mapper.OutboxMessageData) []interface{} {
+			return []interface{}{
+				data.ID, data.AggregateType, data.AggregateID, data.EventType, data.Payload, data.Status,
+				data.Attempts, data.LastError, data.OccurredAt, data.NextAttemptAt, data.DeliveredAt, data.CreatedAt,
+			}
+		},
+	)
+}
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+)
+
+// RecurringRuleColumns recurring_rules 資料表的欄位，順序與Scan/Values一致
+var RecurringRuleColumns = []string{
+	"id", "user_id", "name", "type", "wallet_id", "subcategory_id", "amount", "currency", "description",
+	"frequency", "interval_count", "day_of_month", "last_business_day", "start_date", "end_date", "count_limit",
+	"status", "paused_at", "created_at", "updated_at", "version",
+}
+
+// NewPgRecurringRuleStore 建立 recurring_rules 資料表的 QueryAggregateStore
+// 只包含規則主體；發生日記錄由 PgRecurringRuleRepositoryPeerAdapter 載入與同步
+func NewPgRecurringRuleStore(dbClient DatabaseClient) store.QueryAggregateStore[mapper.RecurringRuleData] {
+	return NewPgQueryAggregateStoreAdapter[mapper.RecurringRuleData](
+		dbClient,
+		"recurring_rules",
+		RecurringRuleColumns,
+		func(row RowScanner) (*mapper.RecurringRuleData, error) {
+			var data mapper.RecurringRuleData
+			err := row.Scan(
+				&data.ID, &data.UserID, &data.Name, &data.Type, &data.WalletID, &data.SubcategoryID, &data.Amount, &data.Currency, &data.Description,
+				&data.Frequency, &data.Interval, &data.DayOfMonth, &data.LastBusinessDay, &data.StartDate, &data.EndDate, &data.Count,
+				&data.Status, &data.PausedAt, &data.CreatedAt, &data.UpdatedAt, &data.Version,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return &data, nil
+		},
+		RecurringRuleValues,
+	)
+}
+
+// RecurringRuleValues 依 RecurringRuleColumns 的順序列出欄位值
+func RecurringRuleValues(data mapper.RecurringRuleData) []interface{} {
+	return []interface{}{
+		data.ID, data.UserID, data.Name, data.Type, data.WalletID, data.SubcategoryID, data.Amount, data.Currency, data.Description,
+		data.Frequency, data.Interval, data.DayOfMonth, data.LastBusinessDay, data.StartDate, data.EndDate, data.Count,
+		data.Status, data.PausedAt, data.CreatedAt, data.UpdatedAt, data.Version,
+	}
+}
@@ -0,0 +1,117 @@
+package database
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+)
+
+// NewPgWalletStore 建立 wallets 資料表的 QueryAggregateStore
+func NewPgWalletStore(dbClient DatabaseClient) store.QueryAggregateStore[mapper.WalletData] {
+	return NewPgQueryAggregateStoreAdapter[mapper.WalletData](
+		dbClient,
+		"wallets",
+		[]string{
+			"id", "user_id", "name", "type", "currency",
+			"balance_amount", "balance_currency", "created_at", "updated_at", "version",
+		},
+		func(row RowScanner) (*mapper.WalletData, error) {
+			var data mapper.WalletData
+			err := row.Scan(
+				&data.ID, &data.UserID, &data.Name, &data.Type, &data.Currency,
+				&data.BalanceAmount, &data.BalanceCurrency, &data.CreatedAt, &data.UpdatedAt, &data.Version,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return &data, nil
+		},
+		func(data mapper.WalletData) []interface{} {
+			return []interface{}{
+				data.ID, data.UserID, data.Name, data.Type, data.Currency,
+				data.BalanceAmount, data.BalanceCurrency, data.CreatedAt, data.UpdatedAt, data.Version,
+			}
+		},
+	)
+}
+
+// NewPgIncomeRecordStore 建立 income_records 資料表的 BatchAggregateStore
+func NewPgIncomeRecordStore(dbClient DatabaseClient) store.BatchAggregateStore[mapper.IncomeRecordData] {
+	return NewPgBatchAggregateStoreAdapter[mapper.IncomeRecordData](
+		dbClient,
+		"income_records",
+		[]string{"id", "wallet_id", "category_id", "amount", "currency", "description", "date", "created_at", "import_id"},
+		func(row RowScanner) (*mapper.IncomeRecordData, error) {
+			var data mapper.IncomeRecordData
+			err := row.Scan(
+				&data.ID, &data.WalletID, &data.SubcategoryID, &data.Amount,
+				&data.Currency, &data.Description, &data.Date, &data.CreatedAt, &data.ImportID,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return &data, nil
+		},
+		func(data mapper.IncomeRecordData) []interface{} {
+			return []interface{}{
+				data.ID, data.WalletID, data.SubcategoryID, data.Amount,
+				data.Currency, data.Description, data.Date, data.CreatedAt, data.ImportID,
+			}
+		},
+	)
+}
+
+// NewPgExpenseRecordStore 建立 expense_records 資料表的 BatchAggregateStore
+func NewPgExpenseRecordStore(dbClient DatabaseClient) store.BatchAggregateStore[mapper.ExpenseRecordData] {
+	return NewPgBatchAggregateStoreAdapter[mapper.ExpenseRecordData](
+		dbClient,
+		"expense_records",
+		[]string{"id", "wallet_id", "category_id", "amount", "currency", "description", "date", "created_at", "import_id"},
+		func(row RowScanner) (*mapper.ExpenseRecordData, error) {
+			var data mapper.ExpenseRecordData
+			err := row.Scan(
+				&data.ID, &data.WalletID, &data.SubcategoryID, &data.Amount,
+				&data.Currency, &data.Description, &data.Date, &data.CreatedAt, &data.ImportID,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return &data, nil
+		},
+		func(data mapper.ExpenseRecordData) []interface{} {
+			return []interface{}{
+				data.ID, data.WalletID, data.SubcategoryID, data.Amount,
+				data.Currency, data.Description, data.Date, data.CreatedAt, data.ImportID,
+			}
+		},
+	)
+}
+
+// NewPgTransferStore 建立 transfers 資料表的 BatchAggregateStore
+// 跨幣別轉帳以 to_amount/to_currency 記錄目標錢包收到的金額
+func NewPgTransferStore(dbClient DatabaseClient) store.BatchAggregateStore[mapper.TransferData] {
+	return NewPgBatchAggregateStoreAdapter[mapper.TransferData](
+		dbClient,
+		"transfers",
+		[]string{
+			"id", "from_wallet_id", "to_wallet_id", "amount", "currency", "to_amount", "to_currency",
+			"fee_amount", "fee_currency", "description", "date", "created_at",
+		},
+		func(row RowScanner) (*mapper.TransferData, error) {
+			var data mapper.TransferData
+			err := row.Scan(
+				&data.ID, &data.FromWalletID, &data.ToWalletID, &data.Amount, &data.Currency, &data.ToAmount, &data.ToCurrency,
+				&data.Fee, &data.FeeCurrency, &data.Description, &data.Date, &data.CreatedAt,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return &data, nil
+		},
+		func(data mapper.TransferData) []interface{} {
+			return []interface{}{
+				data.ID, data.FromWalletID, data.ToWalletID, data.Amount, data.Currency, data.ToAmount, data.ToCurrency,
+				data.Fee, data.FeeCurrency, data.Description, data.Date, data.CreatedAt,
+			}
+		},
+	)
+}
@@ -129,8 +129,8 @@ func (t *PostgreSQLTransaction) Exec(query string, args ...interface{}) (ExecRes
 // BeginTx is not supported within a transaction (nested transactions not supported by PostgreSQL driver)
 func (t *PostgreSQLTransaction) BeginTx() (Transaction, error) {
 	// PostgreSQL doesn't support nested transactions with the standard library
-	// Return the current transaction instead
-	return t, nil
+	// Join the current transaction instead; the outer owner decides commit or rollback
+	return &joinedTransaction{Transaction: t}, nil
 }
 
 // Commit commits the transaction
@@ -141,4 +141,26 @@ func (t *PostgreSQLTransaction) Commit() error {
 // Rollback rolls back the transaction
 func (t *PostgreSQLTransaction) Rollback() error {
 	return t.tx.Rollback()
-}
\ No newline at end of file
+}
+
+// joinedTransaction is handed out when BeginTx is called inside a transaction.
+// Commit and Rollback are no-ops so that code which manages its own transaction
+// (e.g. repository peers) can run inside a Unit of Work without ending it early.
+type joinedTransaction struct {
+	Transaction
+}
+
+// BeginTx keeps joining the same outer transaction
+func (t *joinedTransaction) BeginTx() (Transaction, error) {
+	return t, nil
+}
+
+// Commit is deferred to the outer transaction
+func (t *joinedTransaction) Commit() error {
+	return nil
+}
+
+// Rollback is deferred to the outer transaction, which rolls back when the error propagates
+func (t *joinedTransaction) Rollback() error {
+	return nil
+}
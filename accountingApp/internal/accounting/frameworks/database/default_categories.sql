@@ -1,5 +1,7 @@
 -- Default Categories Seed Data for Taiwan Market
 -- This file contains default expense and income categories for new users
+-- New users receive their own copy of these categories via InitializeDefaultCategoriesService
+-- (domain/model/defaultCategoryTemplate.go); keep both lists in sync when editing.
 
 -- Default Expense Categories (支出類別)
 INSERT INTO expense_categories (id, user_id, name, created_at, updated_at) VALUES 
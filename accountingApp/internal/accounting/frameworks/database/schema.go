@@ -0,0 +1,18 @@
+package database
+
+import (
+	_ "embed"
+	"fmt"
+)
+
+//go:embed schema.sql
+var schemaSQL string
+
+// ApplySchema 執行內嵌的 schema.sql
+// schema.sql 中的語句皆可重複執行 (IF NOT EXISTS / CREATE OR REPLACE)，可於每次啟動時執行
+func ApplySchema(dbClient DatabaseClient) error {
+	if _, err := dbClient.Exec(schemaSQL); err != nil {
+		return fmt.Errorf("failed to apply schema: %w", err)
+	}
+	return nil
+}
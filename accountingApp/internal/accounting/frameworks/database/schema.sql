@@ -9,10 +9,14 @@ CREATE TABLE IF NOT EXISTS wallets (
     balance_currency CHAR(3) NOT NULL,
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    version BIGINT NOT NULL DEFAULT 0,
     
     CONSTRAINT fk_wallet_currency CHECK (currency = balance_currency)
 );
 
+-- Optimistic locking version for databases created before the column existed
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 0;
+
 -- Create expense_categories table
 CREATE TABLE IF NOT EXISTS expense_categories (
     id VARCHAR(36) PRIMARY KEY,
@@ -65,9 +69,10 @@ CREATE TABLE IF NOT EXISTS expense_records (
     description TEXT,
     date TIMESTAMP NOT NULL,
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    import_id VARCHAR(255) NOT NULL DEFAULT '',
     
     FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE,
-    FOREIGN KEY (category_id) REFERENCES expense_categories(id)
+    CONSTRAINT expense_records_category_id_fkey FOREIGN KEY (category_id) REFERENCES expense_subcategories(id)
 );
 
 -- Create income_records table
@@ -80,9 +85,53 @@ CREATE TABLE IF NOT EXISTS income_records (
     description TEXT,
     date TIMESTAMP NOT NULL,
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    import_id VARCHAR(255) NOT NULL DEFAULT '',
     
     FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE,
-    FOREIGN KEY (category_id) REFERENCES income_categories(id)
+    CONSTRAINT income_records_category_id_fkey FOREIGN KEY (category_id) REFERENCES income_subcategories(id)
+);
+
+-- Statement import identifiers (OFX FITID or QIF content hash) for databases created before the column existed
+ALTER TABLE expense_records ADD COLUMN IF NOT EXISTS import_id VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE income_records ADD COLUMN IF NOT EXISTS import_id VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Recurring occurrence keys (rule ID and occurrence date) so a retried occurrence finds its record instead of adding another
+ALTER TABLE expense_records ADD COLUMN IF NOT EXISTS occurrence_key VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE income_records ADD COLUMN IF NOT EXISTS occurrence_key VARCHAR(64) NOT NULL DEFAULT '';
+
+-- Category foreign keys of databases created before the subcategory fix.
+-- The records' category_id has always held a subcategory ID (the domain's SubcategoryID), but the
+-- original tables pointed the key at expense_categories / income_categories, so every insert of a
+-- record failed with a foreign key violation unless the ID happened to exist in the parent table.
+-- Repoint the keys at the subcategory tables. NOT VALID keeps whatever rows an old database holds
+-- (they were checked against the parent tables) while new and updated rows are checked.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'expense_records_category_id_fkey' AND confrelid = 'expense_categories'::regclass) THEN
+        ALTER TABLE expense_records DROP CONSTRAINT expense_records_category_id_fkey;
+        ALTER TABLE expense_records ADD CONSTRAINT expense_records_category_id_fkey
+            FOREIGN KEY (category_id) REFERENCES expense_subcategories(id) NOT VALID;
+    END IF;
+    IF EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'income_records_category_id_fkey' AND confrelid = 'income_categories'::regclass) THEN
+        ALTER TABLE income_records DROP CONSTRAINT income_records_category_id_fkey;
+        ALTER TABLE income_records ADD CONSTRAINT income_records_category_id_fkey
+            FOREIGN KEY (category_id) REFERENCES income_subcategories(id) NOT VALID;
+    END IF;
+END $$;
+
+-- Create expense_splits table (split lines of an expense; the lines sum to expense_records.amount
+-- and expense_records.category_id holds the first line's subcategory)
+CREATE TABLE IF NOT EXISTS expense_splits (
+    expense_id VARCHAR(36) NOT NULL,
+    line_no INTEGER NOT NULL CHECK (line_no > 0),
+    category_id VARCHAR(36) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    memo TEXT,
+
+    PRIMARY KEY (expense_id, line_no),
+    FOREIGN KEY (expense_id) REFERENCES expense_records(id) ON DELETE CASCADE,
+    FOREIGN KEY (category_id) REFERENCES expense_subcategories(id)
 );
 
 -- Create transfers table
@@ -92,6 +141,8 @@ CREATE TABLE IF NOT EXISTS transfers (
     to_wallet_id VARCHAR(36) NOT NULL,
     amount BIGINT NOT NULL CHECK (amount > 0),
     currency CHAR(3) NOT NULL,
+    to_amount BIGINT NOT NULL CHECK (to_amount > 0),
+    to_currency CHAR(3) NOT NULL,
     fee_amount BIGINT NOT NULL DEFAULT 0 CHECK (fee_amount >= 0),
     fee_currency CHAR(3) NOT NULL,
     description TEXT,
@@ -101,17 +152,410 @@ CREATE TABLE IF NOT EXISTS transfers (
     FOREIGN KEY (from_wallet_id) REFERENCES wallets(id),
     FOREIGN KEY (to_wallet_id) REFERENCES wallets(id),
     CHECK (from_wallet_id != to_wallet_id),
-    CHECK (currency = fee_currency)
+    CONSTRAINT transfers_same_currency_amount_check CHECK (currency <> to_currency OR to_amount = amount),
+    CONSTRAINT transfers_fee_currency_check CHECK (fee_currency IN (currency, to_currency))
 );
 
+-- Cross-currency transfers for databases created before the destination columns existed:
+-- earlier transfers received the amount they sent, and their fee was always in that currency.
+-- Each step is checked first so that later starts do not lock and re-scan transfers.
+DO $$
+DECLARE
+    legacy_check TEXT;
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'transfers' AND column_name = 'to_currency'
+          AND is_nullable = 'NO'
+    ) THEN
+        ALTER TABLE transfers ADD COLUMN IF NOT EXISTS to_amount BIGINT CHECK (to_amount > 0);
+        ALTER TABLE transfers ADD COLUMN IF NOT EXISTS to_currency CHAR(3);
+        UPDATE transfers SET to_amount = amount, to_currency = currency WHERE to_amount IS NULL OR to_currency IS NULL;
+        ALTER TABLE transfers ALTER COLUMN to_amount SET NOT NULL;
+        ALTER TABLE transfers ALTER COLUMN to_currency SET NOT NULL;
+    END IF;
+
+    -- The fee may now be in either wallet's currency; drop the unnamed CHECK (currency = fee_currency)
+    FOR legacy_check IN
+        SELECT conname FROM pg_constraint
+        WHERE conrelid = 'transfers'::regclass AND contype = 'c'
+          AND pg_get_constraintdef(oid) LIKE '%(currency = fee_currency)%'
+    LOOP
+        EXECUTE format('ALTER TABLE transfers DROP CONSTRAINT %I', legacy_check);
+    END LOOP;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conrelid = 'transfers'::regclass AND conname = 'transfers_same_currency_amount_check') THEN
+        ALTER TABLE transfers ADD CONSTRAINT transfers_same_currency_amount_check CHECK (currency <> to_currency OR to_amount = amount);
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conrelid = 'transfers'::regclass AND conname = 'transfers_fee_currency_check') THEN
+        ALTER TABLE transfers ADD CONSTRAINT transfers_fee_currency_check CHECK (fee_currency IN (currency, to_currency));
+    END IF;
+END $$;
+
+-- Create transaction tag tables (free-form, normalized lower-case tags; a transfer's tags are shared by both wallets)
+CREATE TABLE IF NOT EXISTS expense_record_tags (
+    expense_id VARCHAR(36) NOT NULL,
+    tag VARCHAR(50) NOT NULL,
+
+    PRIMARY KEY (expense_id, tag),
+    FOREIGN KEY (expense_id) REFERENCES expense_records(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS income_record_tags (
+    income_id VARCHAR(36) NOT NULL,
+    tag VARCHAR(50) NOT NULL,
+
+    PRIMARY KEY (income_id, tag),
+    FOREIGN KEY (income_id) REFERENCES income_records(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS transfer_tags (
+    transfer_id VARCHAR(36) NOT NULL,
+    tag VARCHAR(50) NOT NULL,
+
+    PRIMARY KEY (transfer_id, tag),
+    FOREIGN KEY (transfer_id) REFERENCES transfers(id) ON DELETE CASCADE
+);
+
+-- Create api_keys table (personal API keys; only the SHA-256 hash of the key is stored)
+CREATE TABLE IF NOT EXISTS api_keys (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP
+);
+
+-- Create budgets table (spending is computed from expense_records; only the limits are stored)
+CREATE TABLE IF NOT EXISTS budgets (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    period VARCHAR(10) NOT NULL CHECK (period IN ('MONTHLY', 'WEEKLY', 'CUSTOM')),
+    category_id VARCHAR(36),
+    subcategory_id VARCHAR(36),
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    rollover BOOLEAN NOT NULL DEFAULT FALSE,
+    warning_threshold INTEGER NOT NULL DEFAULT 80 CHECK (warning_threshold BETWEEN 1 AND 100),
+    start_date TIMESTAMP NOT NULL,
+    end_date TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CHECK ((category_id IS NULL) != (subcategory_id IS NULL)),
+    CHECK (period != 'CUSTOM' OR end_date IS NOT NULL),
+    CHECK (end_date IS NULL OR end_date > start_date)
+);
+
+-- Create recurring_rules table (schedules that the background scheduler turns into expenses or incomes)
+CREATE TABLE IF NOT EXISTS recurring_rules (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(10) NOT NULL CHECK (type IN ('EXPENSE', 'INCOME')),
+    wallet_id VARCHAR(36) NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    subcategory_id VARCHAR(36) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('DAILY', 'WEEKLY', 'MONTHLY', 'YEARLY')),
+    interval_count INTEGER NOT NULL DEFAULT 1 CHECK (interval_count > 0),
+    day_of_month INTEGER NOT NULL DEFAULT 0 CHECK (day_of_month BETWEEN 0 AND 31),
+    last_business_day BOOLEAN NOT NULL DEFAULT FALSE,
+    start_date TIMESTAMP NOT NULL,
+    end_date TIMESTAMP,
+    count_limit INTEGER NOT NULL DEFAULT 0 CHECK (count_limit >= 0),
+    status VARCHAR(10) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'PAUSED')),
+    paused_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    version BIGINT NOT NULL DEFAULT 0,
+
+    CHECK (end_date IS NULL OR count_limit = 0),
+    CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+-- Create recurring_occurrences table (one row per processed date, so a date is never generated twice)
+CREATE TABLE IF NOT EXISTS recurring_occurrences (
+    rule_id VARCHAR(36) NOT NULL REFERENCES recurring_rules(id) ON DELETE CASCADE,
+    occurrence_date TIMESTAMP NOT NULL,
+    status VARCHAR(10) NOT NULL CHECK (status IN ('CLAIMED', 'GENERATED', 'SKIPPED', 'FAILED')),
+    transaction_id VARCHAR(36) NOT NULL DEFAULT '',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (rule_id, occurrence_date)
+);
+
+-- Create attachments table (receipt metadata; the file content lives in the blob store under storage_key).
+-- No foreign keys: records are in two tables, and DeleteWalletService removes the rows together with their blobs.
+CREATE TABLE IF NOT EXISTS attachments (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    record_type VARCHAR(10) NOT NULL CHECK (record_type IN ('EXPENSE', 'INCOME')),
+    record_id VARCHAR(36) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL CHECK (size > 0),
+    checksum CHAR(64) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create import_profiles table (how the columns of a user's bank statement CSV map to transactions;
+-- columns are header names or 1-based column numbers)
+CREATE TABLE IF NOT EXISTS import_profiles (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    delimiter VARCHAR(4) NOT NULL DEFAULT '',
+    encoding VARCHAR(20) NOT NULL CHECK (encoding IN ('UTF-8', 'BIG5', 'WINDOWS-1252')),
+    has_header BOOLEAN NOT NULL DEFAULT TRUE,
+    skip_rows INTEGER NOT NULL DEFAULT 0 CHECK (skip_rows >= 0),
+    date_column VARCHAR(100) NOT NULL,
+    date_format VARCHAR(20) NOT NULL,
+    description_column VARCHAR(100) NOT NULL DEFAULT '',
+    amount_column VARCHAR(100) NOT NULL DEFAULT '',
+    sign_convention VARCHAR(20) NOT NULL DEFAULT '',
+    debit_column VARCHAR(100) NOT NULL DEFAULT '',
+    credit_column VARCHAR(100) NOT NULL DEFAULT '',
+    expense_subcategory_id VARCHAR(36) NOT NULL,
+    income_subcategory_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CHECK ((amount_column = '') != (debit_column = '' AND credit_column = ''))
+);
+
+-- Windows-1252 statements (older tables only accepted UTF-8 and Big5);
+-- checked first so that later starts do not rewrite or lock the table
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'import_profiles' AND column_name = 'encoding'
+          AND character_maximum_length < 20
+    ) THEN
+        ALTER TABLE import_profiles ALTER COLUMN encoding TYPE VARCHAR(20);
+    END IF;
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint
+        WHERE conrelid = 'import_profiles'::regclass AND conname = 'import_profiles_encoding_check'
+          AND pg_get_constraintdef(oid) LIKE '%WINDOWS-1252%'
+    ) THEN
+        ALTER TABLE import_profiles DROP CONSTRAINT IF EXISTS import_profiles_encoding_check;
+        ALTER TABLE import_profiles ADD CONSTRAINT import_profiles_encoding_check CHECK (encoding IN ('UTF-8', 'BIG5', 'WINDOWS-1252'));
+    END IF;
+END $$;
+
+-- Create duplicate_flags table (the review queue of likely duplicate expenses or incomes;
+-- record_id is the newer record, duplicate_of_id the similar record already in the wallet)
+CREATE TABLE IF NOT EXISTS duplicate_flags (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    record_type VARCHAR(10) NOT NULL CHECK (record_type IN ('EXPENSE', 'INCOME')),
+    record_id VARCHAR(36) NOT NULL,
+    duplicate_of_id VARCHAR(36) NOT NULL,
+    score DOUBLE PRECISION NOT NULL CHECK (score BETWEEN 0 AND 1),
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'MERGED', 'KEPT', 'DELETED')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP,
+
+    CHECK (record_id <> duplicate_of_id)
+);
+
+-- Create categorization_rules table (user-defined rules that assign a subcategory and tags to
+-- imported or entered transactions; unset conditions are empty, NULL or a zero weekday mask)
+CREATE TABLE IF NOT EXISTS categorization_rules (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    rule_type VARCHAR(10) NOT NULL CHECK (rule_type IN ('EXPENSE', 'INCOME')),
+    priority INTEGER NOT NULL DEFAULT 0 CHECK (priority >= 0),
+    description_pattern VARCHAR(200) NOT NULL DEFAULT '',
+    min_amount BIGINT CHECK (min_amount >= 0),
+    max_amount BIGINT CHECK (max_amount >= 0),
+    currency VARCHAR(3) NOT NULL DEFAULT '',
+    wallet_id VARCHAR(36) NOT NULL DEFAULT '',
+    weekday_mask SMALLINT NOT NULL DEFAULT 0 CHECK (weekday_mask BETWEEN 0 AND 127), -- bit 0 is Sunday
+    subcategory_id VARCHAR(36) NOT NULL,
+    tags TEXT NOT NULL DEFAULT '', -- comma-separated normalized tags
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CHECK (min_amount IS NULL OR max_amount IS NULL OR min_amount <= max_amount)
+);
+
+-- Create exchange_rates table (one rate per currency pair per day, shared by all users;
+-- rate is the amount of quote currency for one major unit of base currency)
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id VARCHAR(20) PRIMARY KEY, -- BASE-QUOTE-YYYY-MM-DD
+    base_currency VARCHAR(3) NOT NULL,
+    quote_currency VARCHAR(3) NOT NULL,
+    rate NUMERIC(24, 10) NOT NULL CHECK (rate > 0),
+    rate_date DATE NOT NULL,
+    source VARCHAR(50) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    UNIQUE (base_currency, quote_currency, rate_date),
+    CHECK (base_currency <> quote_currency)
+);
+
+-- Create outbox table (domain events written in the same transaction as the wallet save,
+-- delivered at-least-once by the background relay)
+CREATE TABLE IF NOT EXISTS outbox (
+    id VARCHAR(36) PRIMARY KEY,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id VARCHAR(36) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead_letter')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP NOT NULL,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create audit_log table (append-only; each entry's hash covers the previous entry's hash).
+-- Snapshots use JSON rather than JSONB so the stored text is byte-identical to what was hashed.
+CREATE TABLE IF NOT EXISTS audit_log (
+    sequence BIGINT PRIMARY KEY,
+    id VARCHAR(36) NOT NULL UNIQUE,
+    actor_id VARCHAR(36) NOT NULL,
+    command VARCHAR(100) NOT NULL,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id VARCHAR(36) NOT NULL,
+    before_snapshot JSON,
+    after_snapshot JSON,
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP NOT NULL,
+    prev_hash CHAR(64) NOT NULL,
+    hash CHAR(64) NOT NULL UNIQUE
+);
+
+-- Reject UPDATE and DELETE on audit_log, including from the application's own role
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_append_only ON audit_log;
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
+
+-- Claimed occurrences (a run in progress, taken over by another run once its lease expires)
+-- (checked first so that later starts do not lock and re-validate the table)
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint
+        WHERE conrelid = 'recurring_occurrences'::regclass AND conname = 'recurring_occurrences_status_check'
+          AND pg_get_constraintdef(oid) LIKE '%CLAIMED%'
+    ) THEN
+        ALTER TABLE recurring_occurrences DROP CONSTRAINT IF EXISTS recurring_occurrences_status_check;
+        ALTER TABLE recurring_occurrences ADD CONSTRAINT recurring_occurrences_status_check CHECK (status IN ('CLAIMED', 'GENERATED', 'SKIPPED', 'FAILED'));
+    END IF;
+END $$;
+
+-- Create schema_migrations table (one row per one-off data migration, so schema.sql can run on every start)
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    name VARCHAR(100) PRIMARY KEY,
+    applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Amounts in currencies the old subdivision map did not list (TWD, JPY, KRW, VND, USD, EUR, GBP, CNY)
+-- were stored in whole units; the ISO 4217 registry reads them in minor units, so a stored HKD 100
+-- would show as HK$1.00. Rescale those amounts once. Currencies without minor units (e.g. CLP, ISK)
+-- were already stored in whole units and are left alone, as are exchange rates (per major unit) and
+-- the outbox and audit log (history of what was written at the time).
+DO $$
+BEGIN
+    INSERT INTO schema_migrations (name) VALUES ('iso4217_minor_units') ON CONFLICT DO NOTHING;
+    IF NOT FOUND THEN
+        RETURN;
+    END IF;
+
+    CREATE TEMP TABLE rescaled_currencies (code CHAR(3) PRIMARY KEY, factor BIGINT NOT NULL);
+    INSERT INTO rescaled_currencies (code, factor)
+    SELECT code, 100 FROM unnest(ARRAY[
+        'AED', 'AFN', 'ALL', 'AMD', 'AOA', 'ARS', 'AUD', 'AWG', 'AZN', 'BAM', 'BBD', 'BDT', 'BGN', 'BMD', 'BND', 'BOB',
+        'BOV', 'BRL', 'BSD', 'BTN', 'BWP', 'BYN', 'BZD', 'CAD', 'CDF', 'CHE', 'CHF', 'CHW', 'COP', 'COU', 'CRC', 'CUP',
+        'CVE', 'CZK', 'DKK', 'DOP', 'DZD', 'EGP', 'ERN', 'ETB', 'FJD', 'FKP', 'GEL', 'GHS', 'GIP', 'GMD', 'GTQ', 'GYD',
+        'HKD', 'HNL', 'HTG', 'HUF', 'IDR', 'ILS', 'INR', 'IRR', 'JMD', 'KES', 'KGS', 'KHR', 'KPW', 'KYD', 'KZT', 'LAK',
+        'LBP', 'LKR', 'LRD', 'LSL', 'MAD', 'MDL', 'MGA', 'MKD', 'MMK', 'MNT', 'MOP', 'MRU', 'MUR', 'MVR', 'MWK', 'MXN',
+        'MXV', 'MYR', 'MZN', 'NAD', 'NGN', 'NIO', 'NOK', 'NPR', 'NZD', 'PAB', 'PEN', 'PGK', 'PHP', 'PKR', 'PLN', 'QAR',
+        'RON', 'RSD', 'RUB', 'SAR', 'SBD', 'SCR', 'SDG', 'SEK', 'SGD', 'SHP', 'SLE', 'SOS', 'SRD', 'SSP', 'STN', 'SVC',
+        'SYP', 'SZL', 'THB', 'TJS', 'TMT', 'TOP', 'TRY', 'TTD', 'TZS', 'UAH', 'USN', 'UYU', 'UZS', 'VED', 'VES', 'WST',
+        'XCD', 'XCG', 'YER', 'ZAR', 'ZMW', 'ZWG'
+    ]) AS code
+    UNION ALL
+    SELECT code, 1000 FROM unnest(ARRAY['BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND']) AS code
+    UNION ALL
+    SELECT code, 10000 FROM unnest(ARRAY['CLF', 'UYW']) AS code;
+
+    UPDATE wallets w SET balance_amount = w.balance_amount * r.factor
+    FROM rescaled_currencies r WHERE w.balance_currency = r.code;
+    UPDATE expense_records e SET amount = e.amount * r.factor
+    FROM rescaled_currencies r WHERE e.currency = r.code;
+    UPDATE expense_splits e SET amount = e.amount * r.factor
+    FROM rescaled_currencies r WHERE e.currency = r.code;
+    UPDATE income_records i SET amount = i.amount * r.factor
+    FROM rescaled_currencies r WHERE i.currency = r.code;
+    UPDATE budgets b SET amount = b.amount * r.factor
+    FROM rescaled_currencies r WHERE b.currency = r.code;
+    UPDATE recurring_rules rr SET amount = rr.amount * r.factor
+    FROM rescaled_currencies r WHERE rr.currency = r.code;
+    UPDATE categorization_rules c SET min_amount = c.min_amount * r.factor, max_amount = c.max_amount * r.factor
+    FROM rescaled_currencies r WHERE c.currency = r.code;
+    -- Each side of a transfer is in its own currency; rescale the row in one statement so its checks hold
+    UPDATE transfers t SET
+        amount = t.amount * COALESCE((SELECT factor FROM rescaled_currencies WHERE code = t.currency), 1),
+        to_amount = t.to_amount * COALESCE((SELECT factor FROM rescaled_currencies WHERE code = t.to_currency), 1),
+        fee_amount = t.fee_amount * COALESCE((SELECT factor FROM rescaled_currencies WHERE code = t.fee_currency), 1)
+    WHERE t.currency IN (SELECT code FROM rescaled_currencies) OR t.to_currency IN (SELECT code FROM rescaled_currencies);
+
+    DROP TABLE rescaled_currencies;
+END $$;
+
 -- Create indexes for better query performance
-CREATE INDEX idx_wallets_user_id ON wallets(user_id);
-CREATE INDEX idx_expense_categories_user_id ON expense_categories(user_id);
-CREATE INDEX idx_income_categories_user_id ON income_categories(user_id);
-CREATE INDEX idx_expense_records_wallet_id ON expense_records(wallet_id);
-CREATE INDEX idx_expense_records_date ON expense_records(date);
-CREATE INDEX idx_income_records_wallet_id ON income_records(wallet_id);
-CREATE INDEX idx_income_records_date ON income_records(date);
-CREATE INDEX idx_transfers_from_wallet ON transfers(from_wallet_id);
-CREATE INDEX idx_transfers_to_wallet ON transfers(to_wallet_id);
-CREATE INDEX idx_transfers_date ON transfers(date);
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS idx_wallets_user_id ON wallets(user_id);
+CREATE INDEX IF NOT EXISTS idx_expense_categories_user_id ON expense_categories(user_id);
+CREATE INDEX IF NOT EXISTS idx_income_categories_user_id ON income_categories(user_id);
+CREATE INDEX IF NOT EXISTS idx_expense_records_wallet_id ON expense_records(wallet_id);
+CREATE INDEX IF NOT EXISTS idx_expense_records_date ON expense_records(date);
+CREATE INDEX IF NOT EXISTS idx_expense_splits_category_id ON expense_splits(category_id);
+CREATE INDEX IF NOT EXISTS idx_income_records_wallet_id ON income_records(wallet_id);
+CREATE INDEX IF NOT EXISTS idx_income_records_date ON income_records(date);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_expense_records_import_id ON expense_records(wallet_id, import_id) WHERE import_id <> '';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_income_records_import_id ON income_records(wallet_id, import_id) WHERE import_id <> '';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_expense_records_occurrence_key ON expense_records(wallet_id, occurrence_key) WHERE occurrence_key <> '';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_income_records_occurrence_key ON income_records(wallet_id, occurrence_key) WHERE occurrence_key <> '';
+CREATE INDEX IF NOT EXISTS idx_transfers_from_wallet ON transfers(from_wallet_id);
+CREATE INDEX IF NOT EXISTS idx_transfers_to_wallet ON transfers(to_wallet_id);
+CREATE INDEX IF NOT EXISTS idx_transfers_date ON transfers(date);
+CREATE INDEX IF NOT EXISTS idx_expense_record_tags_tag ON expense_record_tags(tag);
+CREATE INDEX IF NOT EXISTS idx_income_record_tags_tag ON income_record_tags(tag);
+CREATE INDEX IF NOT EXISTS idx_transfer_tags_tag ON transfer_tags(tag);
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys(user_id);
+CREATE INDEX IF NOT EXISTS idx_budgets_user_id ON budgets(user_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_rules_user_id ON recurring_rules(user_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_rules_status ON recurring_rules(status);
+CREATE INDEX IF NOT EXISTS idx_attachments_record ON attachments(record_type, record_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_wallet_id ON attachments(wallet_id);
+CREATE INDEX IF NOT EXISTS idx_import_profiles_user_id ON import_profiles(user_id);
+CREATE INDEX IF NOT EXISTS idx_duplicate_flags_user_status ON duplicate_flags(user_id, status);
+CREATE INDEX IF NOT EXISTS idx_categorization_rules_user_id ON categorization_rules(user_id);
+CREATE INDEX IF NOT EXISTS idx_outbox_due ON outbox(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_outbox_status ON outbox(status, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor_id, sequence);
+CREATE INDEX IF NOT EXISTS idx_audit_log_aggregate ON audit_log(aggregate_type, aggregate_id, sequence);
+CREATE INDEX IF NOT EXISTS idx_audit_log_request_id ON audit_log(request_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_occurred_at ON audit_log(occurred_at);
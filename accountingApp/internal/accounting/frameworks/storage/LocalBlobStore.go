@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+)
+
+// LocalBlobStore 以本機目錄存放附件檔案的 BlobStore 實作
+type LocalBlobStore struct {
+	root string
+}
+
+// NewLocalBlobStore 建立以root為根目錄的 BlobStore，目錄不存在時建立
+func NewLocalBlobStore(root string) (*LocalBlobStore, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attachment directory %s: %w", root, err)
+	}
+	if err := os.MkdirAll(absRoot, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create attachment directory %s: %w", absRoot, err)
+	}
+	return &LocalBlobStore{root: absRoot}, nil
+}
+
+// Put 先寫入暫存檔再更名，讀取端不會看到寫到一半的檔案
+func (s *LocalBlobStore) Put(key string, content io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create blob: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // 更名成功後為no-op
+
+	if _, err := io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store blob: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalBlobStore) Get(key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, repository.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+	return file, nil
+}
+
+func (s *LocalBlobStore) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+	return nil
+}
+
+// path 將鍵轉為根目錄下的檔案路徑，拒絕跳出根目錄的鍵
+func (s *LocalBlobStore) path(key string) (string, error) {
+	if key == "" || strings.Contains(key, "\\") || strings.HasPrefix(key, "/") {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, s.root+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+	return path, nil
+}
+
+// 確保LocalBlobStore實現BlobStore介面
+var _ repository.BlobStore = (*LocalBlobStore)(nil)
@@ -0,0 +1,94 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// APIKeyHeader is the header carrying a personal API key
+const APIKeyHeader = "X-API-Key"
+
+// TokenVerifier verifies a bearer token and returns the user ID it was issued for
+type TokenVerifier interface {
+	Verify(token string) (string, error)
+}
+
+// AuthMiddleware authenticates every API request and places the user ID in the
+// request context. A request may authenticate with either a signed JWT
+// (Authorization: Bearer <token>) or a personal API key (X-API-Key: ak_...).
+type AuthMiddleware struct {
+	tokenVerifier             TokenVerifier
+	authenticateAPIKeyUseCase usecase.AuthenticateAPIKeyUseCase
+	publicPaths               map[string]bool
+}
+
+// NewAuthMiddleware creates a new AuthMiddleware; /health stays public
+func NewAuthMiddleware(tokenVerifier TokenVerifier, authenticateAPIKeyUseCase usecase.AuthenticateAPIKeyUseCase) *AuthMiddleware {
+	return &AuthMiddleware{
+		tokenVerifier:             tokenVerifier,
+		authenticateAPIKeyUseCase: authenticateAPIKeyUseCase,
+		publicPaths:               map[string]bool{"/health": true},
+	}
+}
+
+// Wrap returns a handler that rejects unauthenticated requests with 401
+func (m *AuthMiddleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if m.publicPaths[req.URL.Path] {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		userID, message := m.authenticate(req)
+		if userID == common.SystemActor {
+			// The system principal skips ownership checks and is never an HTTP caller
+			userID, message = "", "Reserved user ID"
+		}
+		if userID == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="accountingApp"`)
+			m.sendError(w, message, http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, req.WithContext(controller.WithUserID(req.Context(), userID)))
+	})
+}
+
+// authenticate returns the user ID, or an empty ID and the reason for rejection
+func (m *AuthMiddleware) authenticate(req *http.Request) (string, string) {
+	if authorization := req.Header.Get("Authorization"); authorization != "" {
+		token, found := strings.CutPrefix(authorization, "Bearer ")
+		if !found || token == "" {
+			return "", "Unsupported authorization scheme"
+		}
+		userID, err := m.tokenVerifier.Verify(token)
+		if err != nil {
+			return "", "Invalid token: " + err.Error()
+		}
+		return userID, ""
+	}
+
+	if key := req.Header.Get(APIKeyHeader); key != "" {
+		result := m.authenticateAPIKeyUseCase.Execute(usecase.AuthenticateAPIKeyInput{Key: key})
+		if result.GetExitCode() != common.Success {
+			return "", "Invalid API key"
+		}
+		return result.GetID(), ""
+	}
+
+	return "", "Authentication required"
+}
+
+func (m *AuthMiddleware) sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+}
@@ -0,0 +1,43 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+)
+
+// RequestIDHeader carries the request ID in both directions
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client-supplied IDs before they are stored in the audit log
+const maxRequestIDLength = 128
+
+// WithRequestID assigns every request an ID, places it in the request context and
+// echoes it in the response. A well-formed X-Request-ID from the client (e.g. set
+// by a proxy) is kept so the request can be traced across services.
+func WithRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requestID := req.Header.Get(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		w.Header().Set(RequestIDHeader, requestID)
+		next.ServeHTTP(w, req.WithContext(controller.WithRequestID(req.Context(), requestID)))
+	})
+}
+
+// validRequestID accepts non-empty printable ASCII IDs up to maxRequestIDLength
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < 0x21 || requestID[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -7,51 +7,80 @@ import (
 	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
 )
 
-type Router struct {
+// Controllers the HTTP controllers the router dispatches to, passed by name so that wiring
+// cannot swap two of them by position
+type Controllers struct {
 	// Specialized wallet controllers
-	createWalletController     *controller.CreateWalletController
-	queryWalletController      *controller.QueryWalletController
-	updateWalletController     *controller.UpdateWalletController
-	deleteWalletController     *controller.DeleteWalletController
-	getWalletBalanceController *controller.GetWalletBalanceController
+	CreateWalletController     *controller.CreateWalletController
+	QueryWalletController      *controller.QueryWalletController
+	UpdateWalletController     *controller.UpdateWalletController
+	DeleteWalletController     *controller.DeleteWalletController
+	GetWalletBalanceController *controller.GetWalletBalanceController
 
 	// Specialized transaction controllers
-	addExpenseController   *controller.AddExpenseController
-	addIncomeController    *controller.AddIncomeController
-	queryIncomeController  *controller.QueryIncomeController
-	queryExpenseController *controller.QueryExpenseController
+	AddExpenseController    *controller.AddExpenseController
+	AddIncomeController     *controller.AddIncomeController
+	QueryIncomeController   *controller.QueryIncomeController
+	QueryExpenseController  *controller.QueryExpenseController
+	UpdateExpenseController *controller.UpdateExpenseController
+	DeleteExpenseController *controller.DeleteExpenseController
+	UpdateIncomeController  *controller.UpdateIncomeController
+	DeleteIncomeController  *controller.DeleteIncomeController
+
+	// Transfer controllers
+	ProcessTransferController *controller.ProcessTransferController
+	QueryTransferController   *controller.QueryTransferController
 
 	// Category controllers
-	categoryController    *controller.CategoryController
-	getCategoriesController *controller.GetCategoriesController
-}
-
-func NewRouter(
-	createWalletController *controller.CreateWalletController,
-	queryWalletController *controller.QueryWalletController,
-	updateWalletController *controller.UpdateWalletController,
-	deleteWalletController *controller.DeleteWalletController,
-	getWalletBalanceController *controller.GetWalletBalanceController,
-	addExpenseController *controller.AddExpenseController,
-	addIncomeController *controller.AddIncomeController,
-	queryIncomeController *controller.QueryIncomeController,
-	queryExpenseController *controller.QueryExpenseController,
-	categoryController *controller.CategoryController,
-	getCategoriesController *controller.GetCategoriesController,
-) *Router {
-	return &Router{
-		createWalletController:     createWalletController,
-		queryWalletController:      queryWalletController,
-		updateWalletController:     updateWalletController,
-		deleteWalletController:     deleteWalletController,
-		getWalletBalanceController: getWalletBalanceController,
-		addExpenseController:       addExpenseController,
-		addIncomeController:        addIncomeController,
-		queryIncomeController:      queryIncomeController,
-		queryExpenseController:     queryExpenseController,
-		categoryController:         categoryController,
-		getCategoriesController:    getCategoriesController,
-	}
+	CategoryController                    *controller.CategoryController
+	GetCategoriesController               *controller.GetCategoriesController
+	InitializeDefaultCategoriesController *controller.InitializeDefaultCategoriesController
+
+	// Authentication
+	APIKeyController *controller.APIKeyController
+
+	// Administration
+	OutboxAdminController *controller.OutboxAdminController
+	AuditController       *controller.AuditController
+
+	// Budgets
+	BudgetController *controller.BudgetController
+
+	// Recurring transactions
+	RecurringRuleController *controller.RecurringRuleController
+
+	// Transaction tags
+	TagController *controller.TagController
+
+	// Attachments
+	AttachmentController *controller.AttachmentController
+
+	// Statement imports
+	ImportController *controller.ImportController
+
+	// Duplicate review queue
+	DuplicateController *controller.DuplicateController
+
+	// Categorization rules
+	CategorizationRuleController *controller.CategorizationRuleController
+
+	// Learned category suggestions
+	SuggestionController *controller.SuggestionController
+
+	// Exchange rates and currency conversion
+	ExchangeRateController *controller.ExchangeRateController
+
+	// ISO 4217 currency registry
+	CurrencyController *controller.CurrencyController
+}
+
+type Router struct {
+	Controllers
+	authMiddleware *AuthMiddleware
+}
+
+func NewRouter(controllers Controllers, authMiddleware *AuthMiddleware) *Router {
+	return &Router{Controllers: controllers, authMiddleware: authMiddleware}
 }
 
 func (r *Router) SetupRoutes() http.Handler {
@@ -64,29 +93,90 @@ func (r *Router) SetupRoutes() http.Handler {
 	})
 
 	// Wallet endpoints - REST API design
-	mux.HandleFunc("/api/v1/wallets", r.handleWalletCollection)                     // GET (with userID param), POST
-	mux.HandleFunc("/api/v1/wallets/", r.handleWalletResource)                      // GET, PUT, DELETE by ID
-	mux.HandleFunc("/api/v1/wallets/balance/", r.getWalletBalanceController.GetWalletBalance) // Specialized balance endpoint
+	mux.HandleFunc("/api/v1/wallets", r.handleWalletCollection)                               // GET, POST
+	mux.HandleFunc("/api/v1/wallets/", r.handleWalletResource)                                // GET, PUT, DELETE by ID
+	mux.HandleFunc("/api/v1/wallets/balance/", r.GetWalletBalanceController.GetWalletBalance) // Specialized balance endpoint
 
 	// Category endpoints
-	mux.HandleFunc("/api/v1/categories", r.getCategoriesController.GetCategories)              // GET all categories
-	mux.HandleFunc("/api/v1/categories/expense", r.getCategoriesController.GetExpenseCategories) // GET expense categories
-	mux.HandleFunc("/api/v1/categories/income", r.getCategoriesController.GetIncomeCategories)   // GET income categories
+	mux.HandleFunc("/api/v1/categories", r.GetCategoriesController.GetCategories)                                      // GET all categories
+	mux.HandleFunc("/api/v1/categories/expense", r.handleExpenseCategories)                                            // GET, POST
+	mux.HandleFunc("/api/v1/categories/expense/", r.handleExpenseCategoryResource)                                     // PUT, DELETE by ID; subcategories
+	mux.HandleFunc("/api/v1/categories/income", r.handleIncomeCategories)                                              // GET, POST
+	mux.HandleFunc("/api/v1/categories/income/", r.handleIncomeCategoryResource)                                       // PUT, DELETE by ID; subcategories
+	mux.HandleFunc("/api/v1/categories/defaults", r.InitializeDefaultCategoriesController.InitializeDefaultCategories) // POST
 
 	// Transaction endpoints
 	mux.HandleFunc("/api/v1/expenses", r.handleExpenses)
+	mux.HandleFunc("/api/v1/expenses/", r.handleExpenseResource) // PUT, DELETE by ID
 	mux.HandleFunc("/api/v1/incomes", r.handleIncomes)
+	mux.HandleFunc("/api/v1/incomes/", r.handleIncomeResource) // PUT, DELETE by ID
+	mux.HandleFunc("/api/v1/transfers", r.handleTransfers)
+
+	// Budget endpoints (the caller's own budgets)
+	mux.HandleFunc("/api/v1/budgets", r.handleBudgets)         // GET, POST
+	mux.HandleFunc("/api/v1/budgets/", r.handleBudgetResource) // PUT, DELETE by ID; GET {id}/status
+
+	// Recurring rule endpoints (the caller's own rules)
+	mux.HandleFunc("/api/v1/recurring-rules", r.handleRecurringRules)         // GET, POST
+	mux.HandleFunc("/api/v1/recurring-rules/", r.handleRecurringRuleResource) // DELETE by ID; GET {id}/preview; POST {id}/pause, resume, skip
+
+	// Tag endpoints (tags on the caller's own transactions)
+	mux.HandleFunc("/api/v1/tags/bulk", r.TagController.EditTransactionTags) // POST
+	mux.HandleFunc("/api/v1/tags/summary", r.TagController.GetTagSummary)    // GET
+
+	// Attachment endpoints (files on the caller's own expense and income records)
+	mux.HandleFunc("/api/v1/attachments", r.handleAttachments)         // GET by record, POST multipart
+	mux.HandleFunc("/api/v1/attachments/", r.handleAttachmentResource) // GET content, DELETE by ID
 
-	return mux
+	// Statement import endpoints (the caller's own profiles and wallets)
+	mux.HandleFunc("/api/v1/import-profiles", r.handleImportProfiles)           // GET, POST
+	mux.HandleFunc("/api/v1/import-profiles/", r.handleImportProfileResource)   // PUT, DELETE by ID
+	mux.HandleFunc("/api/v1/imports/preview", r.ImportController.PreviewImport) // POST multipart, dry run
+	mux.HandleFunc("/api/v1/imports/commit", r.ImportController.CommitImport)   // POST multipart
+
+	// Duplicate review queue (flags on the caller's own transactions)
+	mux.HandleFunc("/api/v1/duplicates", r.DuplicateController.GetDuplicates) // GET
+	mux.HandleFunc("/api/v1/duplicates/", r.handleDuplicateResource)          // POST {id}/resolve
+
+	// Categorization rule endpoints (the caller's own rules)
+	mux.HandleFunc("/api/v1/categorization-rules", r.handleCategorizationRules)                                // GET, POST
+	mux.HandleFunc("/api/v1/categorization-rules/test", r.CategorizationRuleController.TestCategorizationRule) // POST sample
+	mux.HandleFunc("/api/v1/categorization-rules/", r.handleCategorizationRuleResource)                        // PUT, DELETE by ID
+
+	// Suggestions learned from the caller's own transactions
+	mux.HandleFunc("/api/v1/suggestions/category", r.SuggestionController.GetCategorySuggestions) // GET ?description=
+
+	// Exchange rates shared by all users
+	mux.HandleFunc("/api/v1/exchange-rates", r.ExchangeRateController.GetExchangeRates)     // GET latest per pair or ?base=&quote= history
+	mux.HandleFunc("/api/v1/exchange-rates/convert", r.ExchangeRateController.ConvertMoney) // GET ?amount=&from=&to=
+	mux.HandleFunc("/api/v1/currencies", r.CurrencyController.GetCurrencies)                // GET ?locale=
+
+	// API key endpoints (the caller's own keys)
+	mux.HandleFunc("/api/v1/api-keys", r.handleAPIKeys)                  // GET, POST
+	mux.HandleFunc("/api/v1/api-keys/", r.APIKeyController.RevokeAPIKey) // DELETE by ID
+
+	// Admin endpoints (configured administrators only)
+	mux.HandleFunc("/api/v1/admin/outbox", r.OutboxAdminController.GetOutboxMessages)                     // GET stuck messages
+	mux.HandleFunc("/api/v1/admin/outbox/", r.OutboxAdminController.RetryOutboxMessage)                   // POST {id}/retry
+	mux.HandleFunc("/api/v1/admin/exchange-rates/import", r.ExchangeRateController.ImportExchangeRates)   // POST CSV file
+	mux.HandleFunc("/api/v1/admin/exchange-rates/refresh", r.ExchangeRateController.RefreshExchangeRates) // POST from the rate provider
+
+	// Audit log (own entries; administrators may query all and verify the hash chain)
+	mux.HandleFunc("/api/v1/audit", r.AuditController.GetAuditLog)           // GET with filters
+	mux.HandleFunc("/api/v1/audit/verify", r.AuditController.VerifyAuditLog) // GET
+
+	// Every route except /health requires an authenticated user;
+	// every request gets an X-Request-ID that commands record in the audit log
+	return WithRequestID(r.authMiddleware.Wrap(mux))
 }
 
 // handleWalletCollection routes requests to /api/v1/wallets
 func (r *Router) handleWalletCollection(w http.ResponseWriter, req *http.Request) {
 	switch req.Method {
 	case http.MethodGet:
-		r.queryWalletController.GetWallets(w, req)
+		r.QueryWalletController.GetWallets(w, req)
 	case http.MethodPost:
-		r.createWalletController.CreateWallet(w, req)
+		r.CreateWalletController.CreateWallet(w, req)
 	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
@@ -96,18 +186,18 @@ func (r *Router) handleWalletCollection(w http.ResponseWriter, req *http.Request
 func (r *Router) handleWalletResource(w http.ResponseWriter, req *http.Request) {
 	// Check if this is a balance request (ends with /balance)
 	if strings.HasSuffix(req.URL.Path, "/balance") {
-		r.getWalletBalanceController.GetWalletBalance(w, req)
+		r.GetWalletBalanceController.GetWalletBalance(w, req)
 		return
 	}
 
 	// Route to appropriate specialized wallet controller
 	switch req.Method {
 	case http.MethodGet:
-		r.queryWalletController.GetWallet(w, req)
+		r.QueryWalletController.GetWallet(w, req)
 	case http.MethodPut:
-		r.updateWalletController.UpdateWallet(w, req)
+		r.UpdateWalletController.UpdateWallet(w, req)
 	case http.MethodDelete:
-		r.deleteWalletController.DeleteWallet(w, req)
+		r.DeleteWalletController.DeleteWallet(w, req)
 	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
@@ -117,9 +207,21 @@ func (r *Router) handleWalletResource(w http.ResponseWriter, req *http.Request)
 func (r *Router) handleIncomes(w http.ResponseWriter, req *http.Request) {
 	switch req.Method {
 	case http.MethodGet:
-		r.queryIncomeController.GetIncomes(w, req)
+		r.QueryIncomeController.GetIncomes(w, req)
 	case http.MethodPost:
-		r.addIncomeController.AddIncome(w, req)
+		r.AddIncomeController.AddIncome(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleIncomeResource routes requests to /api/v1/incomes/{incomeID}
+func (r *Router) handleIncomeResource(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPut:
+		r.UpdateIncomeController.UpdateIncome(w, req)
+	case http.MethodDelete:
+		r.DeleteIncomeController.DeleteIncome(w, req)
 	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
@@ -129,11 +231,262 @@ func (r *Router) handleIncomes(w http.ResponseWriter, req *http.Request) {
 func (r *Router) handleExpenses(w http.ResponseWriter, req *http.Request) {
 	switch req.Method {
 	case http.MethodGet:
-		r.queryExpenseController.GetExpenses(w, req)
+		r.QueryExpenseController.GetExpenses(w, req)
 	case http.MethodPost:
-		r.addExpenseController.AddExpense(w, req)
+		r.AddExpenseController.AddExpense(w, req)
 	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
 }
 
+// handleExpenseResource routes requests to /api/v1/expenses/{expenseID}
+func (r *Router) handleExpenseResource(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPut:
+		r.UpdateExpenseController.UpdateExpense(w, req)
+	case http.MethodDelete:
+		r.DeleteExpenseController.DeleteExpense(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleTransfers routes requests to /api/v1/transfers
+func (r *Router) handleTransfers(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		r.QueryTransferController.GetTransfers(w, req)
+	case http.MethodPost:
+		r.ProcessTransferController.ProcessTransfer(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleExpenseCategories routes requests to /api/v1/categories/expense
+func (r *Router) handleExpenseCategories(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		r.CategoryController.GetExpenseCategories(w, req)
+	case http.MethodPost:
+		r.CategoryController.CreateExpenseCategory(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleExpenseCategoryResource routes requests to /api/v1/categories/expense/{categoryID}[/subcategories[/{subcategoryID}]]
+func (r *Router) handleExpenseCategoryResource(w http.ResponseWriter, req *http.Request) {
+	if strings.Contains(req.URL.Path, "/subcategories") {
+		switch req.Method {
+		case http.MethodPost:
+			r.CategoryController.AddExpenseSubcategory(w, req)
+		case http.MethodPut:
+			r.CategoryController.RenameExpenseSubcategory(w, req)
+		case http.MethodDelete:
+			r.CategoryController.RemoveExpenseSubcategory(w, req)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	switch req.Method {
+	case http.MethodPut:
+		r.CategoryController.RenameExpenseCategory(w, req)
+	case http.MethodDelete:
+		r.CategoryController.DeleteExpenseCategory(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleIncomeCategories routes requests to /api/v1/categories/income
+func (r *Router) handleIncomeCategories(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		r.CategoryController.GetIncomeCategories(w, req)
+	case http.MethodPost:
+		r.CategoryController.CreateIncomeCategory(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleIncomeCategoryResource routes requests to /api/v1/categories/income/{categoryID}[/subcategories[/{subcategoryID}]]
+func (r *Router) handleIncomeCategoryResource(w http.ResponseWriter, req *http.Request) {
+	if strings.Contains(req.URL.Path, "/subcategories") {
+		switch req.Method {
+		case http.MethodPost:
+			r.CategoryController.AddIncomeSubcategory(w, req)
+		case http.MethodPut:
+			r.CategoryController.RenameIncomeSubcategory(w, req)
+		case http.MethodDelete:
+			r.CategoryController.RemoveIncomeSubcategory(w, req)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	switch req.Method {
+	case http.MethodPut:
+		r.CategoryController.RenameIncomeCategory(w, req)
+	case http.MethodDelete:
+		r.CategoryController.DeleteIncomeCategory(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleBudgets routes requests to /api/v1/budgets
+func (r *Router) handleBudgets(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		r.BudgetController.GetBudgets(w, req)
+	case http.MethodPost:
+		r.BudgetController.CreateBudget(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleBudgetResource routes requests to /api/v1/budgets/{budgetID}[/status]
+func (r *Router) handleBudgetResource(w http.ResponseWriter, req *http.Request) {
+	if strings.HasSuffix(req.URL.Path, "/status") {
+		r.BudgetController.GetBudgetStatus(w, req)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodPut:
+		r.BudgetController.UpdateBudget(w, req)
+	case http.MethodDelete:
+		r.BudgetController.DeleteBudget(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleRecurringRules routes requests to /api/v1/recurring-rules
+func (r *Router) handleRecurringRules(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		r.RecurringRuleController.GetRecurringRules(w, req)
+	case http.MethodPost:
+		r.RecurringRuleController.CreateRecurringRule(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleRecurringRuleResource routes requests to /api/v1/recurring-rules/{ruleID}[/action]
+func (r *Router) handleRecurringRuleResource(w http.ResponseWriter, req *http.Request) {
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/preview"):
+		r.RecurringRuleController.PreviewRecurringRule(w, req)
+	case strings.HasSuffix(req.URL.Path, "/pause"):
+		r.RecurringRuleController.PauseRecurringRule(w, req)
+	case strings.HasSuffix(req.URL.Path, "/resume"):
+		r.RecurringRuleController.ResumeRecurringRule(w, req)
+	case strings.HasSuffix(req.URL.Path, "/skip"):
+		r.RecurringRuleController.SkipRecurringOccurrence(w, req)
+	case req.Method == http.MethodDelete:
+		r.RecurringRuleController.DeleteRecurringRule(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleAttachments routes requests to /api/v1/attachments
+func (r *Router) handleAttachments(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		r.AttachmentController.GetAttachments(w, req)
+	case http.MethodPost:
+		r.AttachmentController.UploadAttachment(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleAttachmentResource routes requests to /api/v1/attachments/{attachmentID}
+func (r *Router) handleAttachmentResource(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		r.AttachmentController.DownloadAttachment(w, req)
+	case http.MethodDelete:
+		r.AttachmentController.DeleteAttachment(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleImportProfiles routes requests to /api/v1/import-profiles
+func (r *Router) handleImportProfiles(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		r.ImportController.GetImportProfiles(w, req)
+	case http.MethodPost:
+		r.ImportController.CreateImportProfile(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleImportProfileResource routes requests to /api/v1/import-profiles/{profileID}
+func (r *Router) handleImportProfileResource(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPut:
+		r.ImportController.UpdateImportProfile(w, req)
+	case http.MethodDelete:
+		r.ImportController.DeleteImportProfile(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleDuplicateResource routes requests to /api/v1/duplicates/{flagID}/resolve
+func (r *Router) handleDuplicateResource(w http.ResponseWriter, req *http.Request) {
+	if !strings.HasSuffix(req.URL.Path, "/resolve") {
+		http.NotFound(w, req)
+		return
+	}
+	r.DuplicateController.ResolveDuplicate(w, req)
+}
+
+// handleCategorizationRules routes requests to /api/v1/categorization-rules
+func (r *Router) handleCategorizationRules(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		r.CategorizationRuleController.GetCategorizationRules(w, req)
+	case http.MethodPost:
+		r.CategorizationRuleController.CreateCategorizationRule(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleCategorizationRuleResource routes requests to /api/v1/categorization-rules/{ruleID}
+func (r *Router) handleCategorizationRuleResource(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPut:
+		r.CategorizationRuleController.UpdateCategorizationRule(w, req)
+	case http.MethodDelete:
+		r.CategorizationRuleController.DeleteCategorizationRule(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleAPIKeys routes requests to /api/v1/api-keys
+func (r *Router) handleAPIKeys(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		r.APIKeyController.GetAPIKeys(w, req)
+	case http.MethodPost:
+		r.APIKeyController.CreateAPIKey(w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -200,6 +200,58 @@ func (m *MockWalletRepository) FindDataByUserID(userID string) ([]mapper.WalletD
 	return dataList, nil
 }
 
+func (m *MockWalletRepository) FindByExpenseRecordID(expenseID string) (*model.Wallet, error) {
+	for _, wallet := range m.wallets {
+		for _, record := range wallet.GetExpenseRecords() {
+			if record.ID == expenseID {
+				return wallet, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockWalletRepository) FindByIncomeRecordID(incomeID string) (*model.Wallet, error) {
+	for _, wallet := range m.wallets {
+		for _, record := range wallet.GetIncomeRecords() {
+			if record.ID == incomeID {
+				return wallet, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockWalletRepository) FindByTransferID(transferID string) (*model.Wallet, error) {
+	for _, wallet := range m.wallets {
+		for _, transfer := range wallet.GetTransfers() {
+			if transfer.ID == transferID && transfer.FromWalletID == wallet.ID {
+				return wallet, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockWalletRepository) SumExpensesByUserID(userID string, from, to time.Time) ([]model.SpentAmount, error) {
+	var amounts []model.SpentAmount
+	for _, wallet := range m.wallets {
+		if wallet.UserID != userID {
+			continue
+		}
+		for _, record := range wallet.GetExpenseRecords() {
+			if !record.Date.Before(from) && record.Date.Before(to) {
+				amounts = append(amounts, record.SpentAmounts()...)
+			}
+		}
+	}
+	return amounts, nil
+}
+
+func (m *MockWalletRepository) FindRecordsByDateRange(walletID string, from, to time.Time) ([]model.ExpenseRecord, []model.IncomeRecord, error) {
+	return nil, nil, nil
+}
+
 // TestAddExpenseWithValidation 測試新增支出時的分類驗證
 func TestAddExpenseWithValidation(t *testing.T) {
 	// 設置測試資料
@@ -257,10 +309,11 @@ func TestAddExpenseWithValidation(t *testing.T) {
 	}
 
 	// 3. 建立服務
-	addExpenseService := command.NewAddExpenseService(walletRepo, categoryRepo)
+	addExpenseService := command.NewAddExpenseService(walletRepo, categoryRepo, nil, nil)
 
 	// 4. 測試有效的子分類ID
 	validInput := usecase.AddExpenseInput{
+		UserID:        wallet.UserID,
 		WalletID:      wallet.ID,
 		SubcategoryID: subcategory.ID,
 		Amount:        2500, // $25.00
@@ -281,6 +334,7 @@ func TestAddExpenseWithValidation(t *testing.T) {
 
 	// 5. 測試無效的子分類ID
 	invalidInput := usecase.AddExpenseInput{
+		UserID:        wallet.UserID,
 		WalletID:      wallet.ID,
 		SubcategoryID: "invalid-subcategory-id",
 		Amount:        1000,
@@ -290,7 +344,7 @@ func TestAddExpenseWithValidation(t *testing.T) {
 	}
 
 	result = addExpenseService.Execute(invalidInput)
-	if result.GetExitCode() != common.Failure {
+	if result.GetExitCode() != common.NotFound {
 		t.Error("Expected failure for invalid subcategory")
 	}
 
@@ -347,7 +401,7 @@ func TestAddExpenseValidationIntegration(t *testing.T) {
 	walletRepo.Save(wallet)
 
 	// 建立服務
-	service := command.NewAddExpenseService(walletRepo, categoryRepo)
+	service := command.NewAddExpenseService(walletRepo, categoryRepo, nil, nil)
 
 	// 測試案例：不同分類的子分類都應該可以正確驗證
 	testCases := []struct {
@@ -365,6 +419,7 @@ func TestAddExpenseValidationIntegration(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			input := usecase.AddExpenseInput{
+				UserID:        wallet.UserID,
 				WalletID:      wallet.ID,
 				SubcategoryID: tc.subcategoryID,
 				Amount:        tc.amount,
@@ -380,8 +435,8 @@ func TestAddExpenseValidationIntegration(t *testing.T) {
 					t.Errorf("Expected success for %s, got: %s", tc.name, result.GetMessage())
 				}
 			} else {
-				if result.GetExitCode() != common.Failure {
-					t.Errorf("Expected failure for %s, but got success", tc.name)
+				if result.GetExitCode() != common.NotFound {
+					t.Errorf("Expected not found for %s, got: %s", tc.name, result.GetMessage())
 				}
 			}
 		})
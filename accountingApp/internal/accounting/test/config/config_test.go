@@ -0,0 +1,120 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/config"
+)
+
+const testSigningKey = "0123456789abcdef0123456789abcdef"
+
+var configEnvVars = []string{
+	"CONFIG_FILE", "PORT", "DATABASE_URL", "APPLY_SCHEMA", "READ_TIMEOUT", "WRITE_TIMEOUT", "SHUTDOWN_TIMEOUT",
+	"JWT_SIGNING_KEY", "ADMIN_USER_IDS", "OUTBOX_POLL_INTERVAL", "RECURRING_POLL_INTERVAL", "ATTACHMENT_DIR",
+	"EXCHANGE_ROUNDING", "EXCHANGE_RATE_PROVIDER", "EXCHANGE_RATES_FILE",
+}
+
+// loadConfig 在乾淨的環境中載入設定；env 為要設定的環境變數，file 非空時寫成設定檔
+func loadConfig(t *testing.T, file string, env map[string]string, args ...string) (config.Config, error) {
+	t.Helper()
+	for _, name := range configEnvVars {
+		t.Setenv(name, "")
+	}
+	for name, value := range env {
+		t.Setenv(name, value)
+	}
+
+	dir := t.TempDir()
+	args = append([]string{"-env-file", filepath.Join(dir, "missing.env")}, args...)
+	if file != "" {
+		path := filepath.Join(dir, "config.json")
+		if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+			t.Fatalf("Failed to write config file: %v", err)
+		}
+		args = append([]string{"-config", path}, args...)
+	}
+	return config.Load(args)
+}
+
+func TestLoad_LaterSourcesOverrideEarlierOnes(t *testing.T) {
+	const file = `{"port": "9000", "shutdown_timeout": "45s", "admin_user_ids": ["file-admin"], "jwt_signing_key": "` + testSigningKey + `"}`
+
+	for _, tc := range []struct {
+		name            string
+		file            string
+		env             map[string]string
+		args            []string
+		port            string
+		shutdownTimeout time.Duration
+		admins          string
+	}{
+		{"defaults", "", map[string]string{"JWT_SIGNING_KEY": testSigningKey}, nil, "8080", 30 * time.Second, ""},
+		{"file over defaults", file, nil, nil, "9000", 45 * time.Second, "file-admin"},
+		{"env over file", file, map[string]string{"PORT": "9100", "SHUTDOWN_TIMEOUT": "1m", "ADMIN_USER_IDS": "a, b,"}, nil, "9100", time.Minute, "a,b"},
+		{"flags over env", file, map[string]string{"PORT": "9100", "ADMIN_USER_IDS": "a"}, []string{"-port", "9200", "-admin-user-ids", "c"}, "9200", 45 * time.Second, "c"},
+		{"unset flags keep env", file, map[string]string{"PORT": "9100"}, []string{"-shutdown-timeout", "5s"}, "9100", 5 * time.Second, "file-admin"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			cfg, err := loadConfig(t, tc.file, tc.env, tc.args...)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if cfg.Port != tc.port {
+				t.Errorf("Expected port %s, got %s", tc.port, cfg.Port)
+			}
+			if cfg.ShutdownTimeout != tc.shutdownTimeout {
+				t.Errorf("Expected shutdown timeout %s, got %s", tc.shutdownTimeout, cfg.ShutdownTimeout)
+			}
+			if admins := strings.Join(cfg.AdminUserIDs, ","); admins != tc.admins {
+				t.Errorf("Expected admins %q, got %q", tc.admins, admins)
+			}
+		})
+	}
+}
+
+func TestLoad_RejectsInvalidConfig(t *testing.T) {
+	valid := map[string]string{"JWT_SIGNING_KEY": testSigningKey}
+
+	for _, tc := range []struct {
+		name     string
+		file     string
+		env      map[string]string
+		args     []string
+		expected string
+	}{
+		{"missing signing key", "", nil, nil, "JWT signing key must be at least 32 bytes"},
+		{"short signing key", "", map[string]string{"JWT_SIGNING_KEY": "too-short"}, nil, "JWT signing key must be at least 32 bytes"},
+		{"short signing key flag", "", valid, []string{"-jwt-signing-key", "short"}, "JWT signing key must be at least 32 bytes"},
+		{"empty port", `{"port": ""}`, valid, nil, "port cannot be empty"},
+		{"zero shutdown timeout", "", valid, []string{"-shutdown-timeout", "0s"}, "shutdown timeout must be positive"},
+		{"negative poll interval", "", map[string]string{"JWT_SIGNING_KEY": testSigningKey, "OUTBOX_POLL_INTERVAL": "-1s"}, nil, "outbox poll interval must be positive"},
+		{"unknown rounding", "", map[string]string{"JWT_SIGNING_KEY": testSigningKey, "EXCHANGE_ROUNDING": "CEILING"}, nil, "exchange rounding"},
+		{"unknown rate provider", `{"exchange_rate_provider": "ecb"}`, valid, nil, `unknown exchange rate provider "ecb"`},
+		{"invalid file duration", `{"read_timeout": "soon"}`, valid, nil, "invalid read_timeout in config file"},
+		{"malformed file", `{"port": 8080}`, valid, nil, "failed to parse config file"},
+		{"invalid env duration", "", map[string]string{"JWT_SIGNING_KEY": testSigningKey, "WRITE_TIMEOUT": "10"}, nil, "invalid WRITE_TIMEOUT"},
+		{"invalid env bool", "", map[string]string{"JWT_SIGNING_KEY": testSigningKey, "APPLY_SCHEMA": "maybe"}, nil, "invalid APPLY_SCHEMA"},
+		{"missing config file", "", map[string]string{"JWT_SIGNING_KEY": testSigningKey, "CONFIG_FILE": "/nonexistent/config.json"}, nil, "failed to read config file"},
+		{"unknown flag", "", valid, []string{"-verbose"}, "flag provided but not defined"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			_, err := loadConfig(t, tc.file, tc.env, tc.args...)
+
+			// Assert
+			if err == nil {
+				t.Fatalf("Expected an error containing %q", tc.expected)
+			}
+			if !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("Expected an error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -30,7 +30,7 @@ func setupAddIncomeController(t *testing.T) (*controller.AddIncomeController, st
 	ctrl := controller.NewAddIncomeController(addIncomeService)
 	
 	// Create test wallet
-	createWalletService := command.NewCreateWalletService(walletRepo)
+	createWalletService := command.NewCreateWalletService(walletRepo, nil)
 	walletResult := createWalletService.Execute(usecase.CreateWalletInput{
 		UserID:   "test-user",
 		Name:     "Test Wallet",
@@ -70,6 +70,7 @@ func TestAddIncomeController_AddIncome_Success(t *testing.T) {
 	
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("POST", "/api/v1/incomes", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	
@@ -123,6 +124,7 @@ func TestAddIncomeController_AddIncome_Success_WithDescription(t *testing.T) {
 	
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("POST", "/api/v1/incomes", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	
@@ -153,6 +155,7 @@ func TestAddIncomeController_AddIncome_MethodNotAllowed(t *testing.T) {
 	for _, method := range methods {
 		t.Run("Method_"+method, func(t *testing.T) {
 			req := httptest.NewRequest(method, "/api/v1/incomes", nil)
+			req = asUser(req, testUserID)
 			w := httptest.NewRecorder()
 			
 			// Act
@@ -173,6 +176,7 @@ func TestAddIncomeController_AddIncome_InvalidJSON(t *testing.T) {
 	
 	// Test malformed JSON
 	req := httptest.NewRequest("POST", "/api/v1/incomes", bytes.NewBufferString("{invalid-json"))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	
@@ -212,6 +216,7 @@ func TestAddIncomeController_AddIncome_MissingWalletID(t *testing.T) {
 	
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("POST", "/api/v1/incomes", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	
@@ -251,6 +256,7 @@ func TestAddIncomeController_AddIncome_EmptyWalletID(t *testing.T) {
 	
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("POST", "/api/v1/incomes", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	
@@ -290,6 +296,7 @@ func TestAddIncomeController_AddIncome_MissingSubcategoryID(t *testing.T) {
 	
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("POST", "/api/v1/incomes", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	
@@ -325,6 +332,7 @@ func TestAddIncomeController_AddIncome_EmptySubcategoryID(t *testing.T) {
 	
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("POST", "/api/v1/incomes", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	
@@ -371,6 +379,7 @@ func TestAddIncomeController_AddIncome_InvalidAmount(t *testing.T) {
 			
 			jsonBody, _ := json.Marshal(requestBody)
 			req := httptest.NewRequest("POST", "/api/v1/incomes", bytes.NewBuffer(jsonBody))
+			req = asUser(req, testUserID)
 			req.Header.Set("Content-Type", "application/json")
 			w := httptest.NewRecorder()
 			
@@ -408,6 +417,7 @@ func TestAddIncomeController_AddIncome_MissingCurrency(t *testing.T) {
 	
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("POST", "/api/v1/incomes", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	
@@ -443,6 +453,7 @@ func TestAddIncomeController_AddIncome_EmptyCurrency(t *testing.T) {
 	
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("POST", "/api/v1/incomes", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	
@@ -478,15 +489,16 @@ func TestAddIncomeController_AddIncome_WalletNotFound(t *testing.T) {
 	
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("POST", "/api/v1/incomes", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	
 	// Act
 	ctrl.AddIncome(w, req)
 	
-	// Assert
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	// Assert - a wallet the caller cannot see is reported as not found
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
 	}
 	
 	var response map[string]interface{}
@@ -517,6 +529,7 @@ func TestAddIncomeController_AddIncome_SubcategoryNotFound(t *testing.T) {
 	
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("POST", "/api/v1/incomes", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	
@@ -524,8 +537,8 @@ func TestAddIncomeController_AddIncome_SubcategoryNotFound(t *testing.T) {
 	ctrl.AddIncome(w, req)
 	
 	// Assert
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
 	}
 	
 	var response map[string]interface{}
@@ -556,6 +569,7 @@ func TestAddIncomeController_AddIncome_FloatAmount(t *testing.T) {
 	}`
 	
 	req := httptest.NewRequest("POST", "/api/v1/incomes", bytes.NewBufferString(jsonString))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	
@@ -581,6 +595,7 @@ func TestAddIncomeController_AddIncome_EmptyRequestBody(t *testing.T) {
 	ctrl, _, _ := setupAddIncomeController(t)
 	
 	req := httptest.NewRequest("POST", "/api/v1/incomes", bytes.NewBuffer([]byte("{}")))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	
@@ -616,6 +631,7 @@ func TestAddIncomeController_AddIncome_NoContentType(t *testing.T) {
 	
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("POST", "/api/v1/incomes", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	// Intentionally not setting Content-Type
 	w := httptest.NewRecorder()
 	
@@ -643,6 +659,7 @@ func TestAddIncomeController_AddIncome_OptionalFieldsSuccess(t *testing.T) {
 	
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("POST", "/api/v1/incomes", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	
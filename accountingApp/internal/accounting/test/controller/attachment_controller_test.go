@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+)
+
+var testReceiptPDF = []byte("%PDF-1.7\n1 0 obj << >> endobj\n%%EOF")
+
+// newAttachmentUpload builds a multipart upload request for the given record
+func newAttachmentUpload(t *testing.T, recordID, contentType string, content []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("record_type", "expense")
+	writer.WriteField("record_id", recordID)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="receipt.pdf"`)
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		t.Fatalf("Failed to create multipart part: %v", err)
+	}
+	part.Write(content)
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/api/v1/attachments", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return asUser(req, testUserID)
+}
+
+func newTestAttachmentController(walletRepo *test.FakeWalletRepo) (*controller.AttachmentController, *test.FakeBlobStore) {
+	attachmentRepo := test.NewFakeAttachmentRepository()
+	blobStore := test.NewFakeBlobStore()
+	return controller.NewAttachmentController(
+		command.NewUploadAttachmentService(walletRepo, attachmentRepo, blobStore),
+		command.NewDeleteAttachmentService(attachmentRepo, blobStore),
+		query.NewGetAttachmentsService(attachmentRepo),
+		query.NewGetAttachmentContentService(attachmentRepo, blobStore),
+	), blobStore
+}
+
+func TestAttachmentController_UploadDownloadAndDelete(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	wallet, _ := model.NewWalletWithInitialBalance(testUserID, "Cash", model.WalletTypeCash, "USD", 100000)
+	walletRepo.Save(wallet)
+	expenseID := command.NewAddExpenseService(walletRepo, nil, nil, nil).Execute(usecase.AddExpenseInput{
+		UserID: testUserID, WalletID: wallet.ID, SubcategoryID: "food", Amount: 1500, Currency: "USD", Date: time.Now(),
+	}).GetID()
+	attachments, _ := newTestAttachmentController(walletRepo)
+
+	// Act - upload
+	w := httptest.NewRecorder()
+	attachments.UploadAttachment(w, newAttachmentUpload(t, expenseID, "application/pdf", testReceiptPDF))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var uploaded struct {
+		Data usecase.AttachmentData `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &uploaded)
+
+	// list and download
+	w = httptest.NewRecorder()
+	attachments.GetAttachments(w, asUser(httptest.NewRequest("GET", "/api/v1/attachments?recordType=EXPENSE&recordId="+expenseID, nil), testUserID))
+	var listed struct {
+		Data []usecase.AttachmentData `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &listed)
+
+	download := httptest.NewRecorder()
+	attachments.DownloadAttachment(download, asUser(httptest.NewRequest("GET", "/api/v1/attachments/"+uploaded.Data.ID, nil), testUserID))
+
+	// delete
+	deleted := httptest.NewRecorder()
+	attachments.DeleteAttachment(deleted, asUser(httptest.NewRequest("DELETE", "/api/v1/attachments/"+uploaded.Data.ID, nil), testUserID))
+	gone := httptest.NewRecorder()
+	attachments.DownloadAttachment(gone, asUser(httptest.NewRequest("GET", "/api/v1/attachments/"+uploaded.Data.ID, nil), testUserID))
+
+	// Assert
+	if uploaded.Data.FileName != "receipt.pdf" || uploaded.Data.Size != int64(len(testReceiptPDF)) || len(uploaded.Data.Checksum) != 64 {
+		t.Errorf("Unexpected upload response: %+v", uploaded.Data)
+	}
+	if len(listed.Data) != 1 || listed.Data[0].ID != uploaded.Data.ID {
+		t.Errorf("Expected the uploaded attachment to be listed, got %s", w.Body.String())
+	}
+	if download.Code != http.StatusOK || !bytes.Equal(download.Body.Bytes(), testReceiptPDF) {
+		t.Errorf("Expected the file content, got status %d body %q", download.Code, download.Body.String())
+	}
+	if got := download.Header().Get("Content-Type"); got != "application/pdf" {
+		t.Errorf("Expected Content-Type application/pdf, got %q", got)
+	}
+	if got := download.Header().Get("Content-Disposition"); got != `attachment; filename=receipt.pdf` {
+		t.Errorf("Unexpected Content-Disposition %q", got)
+	}
+	if deleted.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d. Response: %s", http.StatusOK, deleted.Code, deleted.Body.String())
+	}
+	if gone.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d after delete, got %d", http.StatusNotFound, gone.Code)
+	}
+}
+
+func TestAttachmentController_StatusCodes(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	wallet, _ := model.NewWalletWithInitialBalance(testUserID, "Cash", model.WalletTypeCash, "USD", 100000)
+	walletRepo.Save(wallet)
+	expenseID := command.NewAddExpenseService(walletRepo, nil, nil, nil).Execute(usecase.AddExpenseInput{
+		UserID: testUserID, WalletID: wallet.ID, SubcategoryID: "food", Amount: 1500, Currency: "USD", Date: time.Now(),
+	}).GetID()
+	attachments, _ := newTestAttachmentController(walletRepo)
+
+	cases := []struct {
+		name     string
+		serve    func(w http.ResponseWriter, r *http.Request)
+		request  *http.Request
+		expected int
+	}{
+		{"unknown record", attachments.UploadAttachment, newAttachmentUpload(t, "missing", "application/pdf", testReceiptPDF), http.StatusNotFound},
+		{"mismatched type", attachments.UploadAttachment, newAttachmentUpload(t, expenseID, "image/png", testReceiptPDF), http.StatusBadRequest},
+		{"too large", attachments.UploadAttachment, newAttachmentUpload(t, expenseID, "application/pdf", make([]byte, model.MaxAttachmentSize+2<<20)), http.StatusRequestEntityTooLarge},
+		{"missing record filter", attachments.GetAttachments, asUser(httptest.NewRequest("GET", "/api/v1/attachments", nil), testUserID), http.StatusBadRequest},
+		{"unknown attachment", attachments.DeleteAttachment, asUser(httptest.NewRequest("DELETE", "/api/v1/attachments/missing", nil), testUserID), http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			w := httptest.NewRecorder()
+			tc.serve(w, tc.request)
+
+			// Assert
+			if w.Code != tc.expected {
+				t.Errorf("Expected status %d, got %d. Response: %s", tc.expected, w.Code, w.Body.String())
+			}
+		})
+	}
+}
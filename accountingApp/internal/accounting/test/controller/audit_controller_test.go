@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/audit"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/web"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+)
+
+func newAuditController(repo *test.FakeAuditLogRepository) *controller.AuditController {
+	return controller.NewAuditController(
+		query.NewGetAuditLogService(repo),
+		query.NewVerifyAuditLogService(repo),
+		[]string{testAdminID},
+	)
+}
+
+type auditListResponse struct {
+	Data []usecase.AuditEntryData `json:"data"`
+}
+
+func TestAuditLog_RecordsRequestIDFromMiddleware(t *testing.T) {
+	// Arrange - 與正式環境相同：request ID middleware → 認證後的使用者 → 稽核包裝的Command
+	auditRepo := test.NewFakeAuditLogRepository()
+	walletRepo := repository.NewWalletRepositoryImpl(test.NewFakeWalletPeer(auditRepo), nil)
+	createWallet := audit.NewWalletCommand("CreateWallet", walletRepo, nil,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.CreateWalletInput] {
+			return command.NewCreateWalletService(repos.Wallets, nil)
+		})
+	createController := controller.NewCreateWalletController(createWallet)
+	handler := web.WithRequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		createController.CreateWallet(w, asUser(r, testUserID))
+	}))
+
+	body, _ := json.Marshal(map[string]interface{}{"name": "Main", "type": "CASH", "currency": "USD"})
+	req := httptest.NewRequest("POST", "/api/v1/wallets", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(web.RequestIDHeader, "trace-42")
+
+	// Act
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if got := w.Header().Get(web.RequestIDHeader); got != "trace-42" {
+		t.Errorf("Expected request ID to be echoed, got %q", got)
+	}
+	entries := auditRepo.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 audit entry, got %d", len(entries))
+	}
+	if entries[0].RequestID != "trace-42" || entries[0].ActorID != testUserID || entries[0].Command != "CreateWallet" {
+		t.Errorf("Unexpected audit entry: %+v", entries[0])
+	}
+
+	// An invalid client ID is replaced with a generated one
+	req = httptest.NewRequest("GET", "/health", nil)
+	req.Header.Set(web.RequestIDHeader, "has spaces")
+	w = httptest.NewRecorder()
+	web.WithRequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, req)
+	if got := w.Header().Get(web.RequestIDHeader); got == "" || got == "has spaces" {
+		t.Errorf("Expected a generated request ID, got %q", got)
+	}
+}
+
+func TestAuditController_UsersSeeOnlyTheirOwnEntries(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeAuditLogRepository()
+	recorder := audit.NewRecorder(repo)
+	now := time.Now()
+	recorder.Record(mapper.AuditEntryData{ID: "1", ActorID: testUserID, Command: "AddExpense", AggregateID: "w1", OccurredAt: now})
+	recorder.Record(mapper.AuditEntryData{ID: "2", ActorID: "other-user", Command: "AddExpense", AggregateID: "w2", OccurredAt: now})
+	ctrl := newAuditController(repo)
+
+	// Act - own entries
+	w := httptest.NewRecorder()
+	ctrl.GetAuditLog(w, asUser(httptest.NewRequest("GET", "/api/v1/audit?command=AddExpense", nil), testUserID))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var listed auditListResponse
+	json.Unmarshal(w.Body.Bytes(), &listed)
+	if len(listed.Data) != 1 || listed.Data[0].ActorID != testUserID {
+		t.Errorf("Expected only the caller's entry, got %s", w.Body.String())
+	}
+
+	// Act - another user's entries
+	w = httptest.NewRecorder()
+	ctrl.GetAuditLog(w, asUser(httptest.NewRequest("GET", "/api/v1/audit?actor_id=other-user", nil), testUserID))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d", http.StatusForbidden, w.Code)
+	}
+
+	// Act - administrators see everyone's entries
+	w = httptest.NewRecorder()
+	ctrl.GetAuditLog(w, asUser(httptest.NewRequest("GET", "/api/v1/audit", nil), testAdminID))
+	json.Unmarshal(w.Body.Bytes(), &listed)
+	if w.Code != http.StatusOK || len(listed.Data) != 2 {
+		t.Errorf("Expected admin to see 2 entries, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Act - invalid date
+	w = httptest.NewRecorder()
+	ctrl.GetAuditLog(w, asUser(httptest.NewRequest("GET", "/api/v1/audit?from=yesterday", nil), testUserID))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an invalid date, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestAuditController_VerifyRequiresAdministrator(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeAuditLogRepository()
+	audit.NewRecorder(repo).Record(mapper.AuditEntryData{ID: "1", ActorID: testUserID, Command: "AddExpense", OccurredAt: time.Now()})
+	ctrl := newAuditController(repo)
+
+	// Act - non-admin
+	w := httptest.NewRecorder()
+	ctrl.VerifyAuditLog(w, asUser(httptest.NewRequest("GET", "/api/v1/audit/verify", nil), testUserID))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d", http.StatusForbidden, w.Code)
+	}
+
+	// Act - admin
+	w = httptest.NewRecorder()
+	ctrl.VerifyAuditLog(w, asUser(httptest.NewRequest("GET", "/api/v1/audit/verify", nil), testAdminID))
+
+	// Assert
+	var response struct {
+		Data usecase.VerifyAuditLogOutput `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if w.Code != http.StatusOK || !response.Data.Valid || response.Data.EntriesChecked != 1 {
+		t.Errorf("Expected an intact chain, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,241 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/auth"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/web"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+)
+
+const (
+	testUserID     = "test-user"
+	testSigningKey = "test-signing-key-with-at-least-32-bytes"
+)
+
+// asUser attaches an authenticated user to the request, as the auth middleware would
+func asUser(req *http.Request, userID string) *http.Request {
+	return req.WithContext(controller.WithUserID(req.Context(), userID))
+}
+
+// newTestAuthMiddleware wraps a handler that echoes the authenticated user ID
+func newTestAuthMiddleware(apiKeyRepo repository.APIKeyRepository) http.Handler {
+	middleware := web.NewAuthMiddleware(auth.NewJWTVerifier(testSigningKey), query.NewAuthenticateAPIKeyService(apiKeyRepo))
+	return middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, _ := controller.UserIDFromContext(r.Context())
+		w.Write([]byte(userID))
+	}))
+}
+
+func TestAuthMiddleware_RejectsMissingCredentials(t *testing.T) {
+	// Arrange
+	handler := newTestAuthMiddleware(test.NewFakeAPIKeyRepository())
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/wallets?userID=test-user", nil))
+
+	// Assert
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+	if w.Header().Get("WWW-Authenticate") == "" {
+		t.Error("Expected WWW-Authenticate header on 401")
+	}
+
+	// Health check stays public
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/health", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected /health to be public, got status %d", w.Code)
+	}
+}
+
+func TestAuthMiddleware_BearerToken(t *testing.T) {
+	// Arrange
+	handler := newTestAuthMiddleware(test.NewFakeAPIKeyRepository())
+	validToken, _ := auth.NewJWTVerifier(testSigningKey).IssueToken(testUserID, time.Hour)
+	expiredToken, _ := auth.NewJWTVerifier(testSigningKey).IssueToken(testUserID, -time.Minute)
+	foreignToken, _ := auth.NewJWTVerifier("another-signing-key-with-32-bytes-or-more").IssueToken(testUserID, time.Hour)
+	parts := strings.Split(validToken, ".")
+	forgedClaims := strings.Join([]string{parts[0], "eyJzdWIiOiJpbnRydWRlciIsImV4cCI6OTk5OTk5OTk5OX0", parts[2]}, ".")
+	systemToken, _ := auth.NewJWTVerifier(testSigningKey).IssueToken(common.SystemActor, time.Hour)
+
+	testCases := []struct {
+		name           string
+		authorization  string
+		expectedStatus int
+	}{
+		{"valid token", "Bearer " + validToken, http.StatusOK},
+		{"expired token", "Bearer " + expiredToken, http.StatusUnauthorized},
+		{"token signed with another key", "Bearer " + foreignToken, http.StatusUnauthorized},
+		{"tampered claims", "Bearer " + forgedClaims, http.StatusUnauthorized},
+		{"unsupported scheme", "Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+		{"reserved system subject", "Bearer " + systemToken, http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/wallets", nil)
+			req.Header.Set("Authorization", tc.authorization)
+			w := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(w, req)
+
+			// Assert
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d. Response: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+			if tc.expectedStatus == http.StatusOK && w.Body.String() != testUserID {
+				t.Errorf("Expected user %q in request context, got %q", testUserID, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	// Arrange
+	apiKeyRepo := test.NewFakeAPIKeyRepository()
+	handler := newTestAuthMiddleware(apiKeyRepo)
+	created := command.NewCreateAPIKeyService(apiKeyRepo).Execute(usecase.CreateAPIKeyInput{
+		UserID: testUserID,
+		Name:   "CLI",
+	}).(usecase.CreateAPIKeyOutput)
+
+	request := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/v1/expenses", nil)
+		req.Header.Set(web.APIKeyHeader, key)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// Act & Assert - a valid key authenticates as its owner
+	if w := request(created.Key); w.Code != http.StatusOK || w.Body.String() != testUserID {
+		t.Fatalf("Expected API key to authenticate %q, got status %d body %q", testUserID, w.Code, w.Body.String())
+	}
+
+	// An unknown key is rejected
+	if w := request(created.Key + "x"); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d for unknown key, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	// A revoked key is rejected
+	command.NewRevokeAPIKeyService(apiKeyRepo).Execute(usecase.RevokeAPIKeyInput{UserID: testUserID, KeyID: created.ID})
+	if w := request(created.Key); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d for revoked key, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestOwnership_OtherUsersWallet(t *testing.T) {
+	// Arrange
+	repo, _ := test.NewFakeWalletRepo()
+	walletID := command.NewCreateWalletService(repo, nil).Execute(usecase.CreateWalletInput{
+		UserID:   testUserID,
+		Name:     "Private Wallet",
+		Type:     "CASH",
+		Currency: "USD",
+	}).GetID()
+	queryCtrl := controller.NewQueryWalletController(query.NewGetWalletsService(repo, nil), query.NewGetWalletService(repo))
+	deleteCtrl := controller.NewDeleteWalletController(command.NewDeleteWalletService(repo, nil, nil))
+	expenseCtrl := controller.NewQueryExpenseController(query.NewGetExpensesService(repo))
+
+	// Act & Assert - reading another user's wallet looks like it does not exist
+	w := httptest.NewRecorder()
+	queryCtrl.GetWallet(w, asUser(httptest.NewRequest("GET", "/api/v1/wallets/"+walletID, nil), "intruder"))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for another user's wallet, got %d", http.StatusNotFound, w.Code)
+	}
+
+	// Deleting it is refused and the wallet survives
+	w = httptest.NewRecorder()
+	deleteCtrl.DeleteWallet(w, asUser(httptest.NewRequest("DELETE", "/api/v1/wallets/"+walletID, nil), "intruder"))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d when deleting another user's wallet, got %d", http.StatusNotFound, w.Code)
+	}
+	if wallet, _ := repo.FindByID(walletID); wallet == nil {
+		t.Error("Expected wallet to still exist")
+	}
+
+	// Naming another user in the query is forbidden
+	w = httptest.NewRecorder()
+	expenseCtrl.GetExpenses(w, asUser(httptest.NewRequest("GET", "/api/v1/expenses?userID="+testUserID, nil), "intruder"))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d when querying another user's expenses, got %d", http.StatusForbidden, w.Code)
+	}
+
+	// Unauthenticated requests never fall back to a default user
+	w = httptest.NewRecorder()
+	expenseCtrl.GetExpenses(w, httptest.NewRequest("GET", "/api/v1/expenses", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d without authentication, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestAPIKeyController_CreateListRevoke(t *testing.T) {
+	// Arrange
+	apiKeyRepo := test.NewFakeAPIKeyRepository()
+	ctrl := controller.NewAPIKeyController(
+		command.NewCreateAPIKeyService(apiKeyRepo),
+		command.NewRevokeAPIKeyService(apiKeyRepo),
+		query.NewGetAPIKeysService(apiKeyRepo),
+	)
+
+	// Act - create
+	w := httptest.NewRecorder()
+	ctrl.CreateAPIKey(w, jsonRequest("POST", "/api/v1/api-keys", map[string]interface{}{"name": "Budget script"}))
+
+	// Assert
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var created struct {
+		Data struct {
+			ID  string `json:"id"`
+			Key string `json:"key"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &created)
+	if !strings.HasPrefix(created.Data.Key, "ak_") {
+		t.Fatalf("Expected plaintext key with ak_ prefix, got %q", created.Data.Key)
+	}
+
+	// Act - list never exposes the key
+	w = httptest.NewRecorder()
+	ctrl.GetAPIKeys(w, asUser(httptest.NewRequest("GET", "/api/v1/api-keys", nil), testUserID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if strings.Contains(w.Body.String(), created.Data.Key) {
+		t.Error("Expected key listing not to contain the plaintext key")
+	}
+
+	// Act - another user cannot revoke it
+	w = httptest.NewRecorder()
+	ctrl.RevokeAPIKey(w, asUser(httptest.NewRequest("DELETE", "/api/v1/api-keys/"+created.Data.ID, nil), "intruder"))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d when revoking another user's key, got %d", http.StatusNotFound, w.Code)
+	}
+
+	// Act - the owner can
+	w = httptest.NewRecorder()
+	ctrl.RevokeAPIKey(w, asUser(httptest.NewRequest("DELETE", "/api/v1/api-keys/"+created.Data.ID, nil), testUserID))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if key, _ := apiKeyRepo.FindByID(created.Data.ID); key == nil || key.IsActive() {
+		t.Error("Expected API key to be revoked")
+	}
+}
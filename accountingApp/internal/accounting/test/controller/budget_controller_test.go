@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+)
+
+func newBudgetController(budgetRepo repository.BudgetRepository, walletRepo repository.WalletRepository, categoryRepo repository.ExpenseCategoryRepository) *controller.BudgetController {
+	return controller.NewBudgetController(
+		command.NewCreateBudgetService(budgetRepo, categoryRepo),
+		command.NewUpdateBudgetService(budgetRepo),
+		command.NewDeleteBudgetService(budgetRepo),
+		query.NewGetBudgetsService(budgetRepo),
+		query.NewGetBudgetStatusService(budgetRepo, walletRepo, categoryRepo, nil),
+	)
+}
+
+func TestBudgetController_CreateBudgetAndWarnOnExpense(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	budgetRepo := test.NewFakeBudgetRepository()
+	wallet := test.CreateWallet(t, walletRepo, testUserID, "TWD", 100000)
+	_, subcategory := test.CreateExpenseSubcategory(t, categoryRepo, testUserID, "Food", "Lunch")
+	budgets := newBudgetController(budgetRepo, walletRepo, categoryRepo)
+	expenses := controller.NewAddExpenseController(command.NewAddExpenseService(walletRepo, nil,
+		query.NewCheckBudgetWarningsService(budgetRepo, walletRepo, categoryRepo, nil), nil))
+
+	w := httptest.NewRecorder()
+	budgets.CreateBudget(w, jsonRequest("POST", "/api/v1/budgets", map[string]interface{}{
+		"name":              "Lunch",
+		"period":            "MONTHLY",
+		"subcategory_id":    subcategory.ID,
+		"amount":            1000,
+		"currency":          "TWD",
+		"warning_threshold": 50,
+		"start_date":        "2024-01-01",
+	}))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &created)
+
+	// Act - an expense that reaches 60% of the budget
+	w = httptest.NewRecorder()
+	expenses.AddExpense(w, jsonRequest("POST", "/api/v1/expenses", map[string]interface{}{
+		"wallet_id":      wallet.ID,
+		"subcategory_id": subcategory.ID,
+		"amount":         600,
+		"currency":       "TWD",
+		"date":           "2024-03-10T12:00:00Z",
+	}))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var expense struct {
+		Success  bool                    `json:"success"`
+		Warnings []usecase.BudgetWarning `json:"warnings"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &expense)
+	if !expense.Success || len(expense.Warnings) != 1 || expense.Warnings[0].BudgetID != created.Data.ID {
+		t.Fatalf("Expected one budget warning, got %s", w.Body.String())
+	}
+
+	// The status endpoint reports the same period
+	req := httptest.NewRequest("GET", "/api/v1/budgets/"+created.Data.ID+"/status?date=2024-03-31", nil)
+	w = httptest.NewRecorder()
+	budgets.GetBudgetStatus(w, asUser(req, testUserID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var status struct {
+		Data struct {
+			Status usecase.BudgetStatusData `json:"status"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &status)
+	if status.Data.Status.Spent != 600 || status.Data.Status.Remaining != 400 || !status.Data.Status.Warning {
+		t.Errorf("Unexpected budget status: %s", w.Body.String())
+	}
+}
+
+func TestBudgetController_OtherUsersBudgetNotFound(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	_, subcategory := test.CreateExpenseSubcategory(t, categoryRepo, testUserID, "Food", "Lunch")
+	budgets := newBudgetController(test.NewFakeBudgetRepository(), walletRepo, categoryRepo)
+	w := httptest.NewRecorder()
+	budgets.CreateBudget(w, jsonRequest("POST", "/api/v1/budgets", map[string]interface{}{
+		"name":           "Lunch",
+		"period":         "WEEKLY",
+		"subcategory_id": subcategory.ID,
+		"amount":         1000,
+		"currency":       "TWD",
+	}))
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &created)
+
+	// Act
+	req := httptest.NewRequest("DELETE", "/api/v1/budgets/"+created.Data.ID, nil)
+	w = httptest.NewRecorder()
+	budgets.DeleteBudget(w, asUser(req, "intruder"))
+
+	// Assert
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Response: %s", http.StatusNotFound, w.Code, w.Body.String())
+	}
+}
+
+func TestBudgetController_CreateBudget_Validation(t *testing.T) {
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	_, subcategory := test.CreateExpenseSubcategory(t, categoryRepo, testUserID, "Food", "Lunch")
+	budgets := newBudgetController(test.NewFakeBudgetRepository(), walletRepo, categoryRepo)
+
+	tests := []struct {
+		name string
+		body map[string]interface{}
+	}{
+		{"invalid period", map[string]interface{}{"name": "Lunch", "period": "YEARLY", "subcategory_id": subcategory.ID, "amount": 1000, "currency": "TWD"}},
+		{"missing scope", map[string]interface{}{"name": "Lunch", "period": "MONTHLY", "amount": 1000, "currency": "TWD"}},
+		{"custom without end", map[string]interface{}{"name": "Trip", "period": "CUSTOM", "subcategory_id": subcategory.ID, "amount": 1000, "currency": "TWD"}},
+		{"invalid date", map[string]interface{}{"name": "Lunch", "period": "MONTHLY", "subcategory_id": subcategory.ID, "amount": 1000, "currency": "TWD", "start_date": "March"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			budgets.CreateBudget(w, jsonRequest("POST", "/api/v1/budgets", tt.body))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d. Response: %s", http.StatusBadRequest, w.Code, w.Body.String())
+			}
+		})
+	}
+}
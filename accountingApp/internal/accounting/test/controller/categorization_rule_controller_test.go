@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+)
+
+func newCategorizationRuleController(t *testing.T) (*controller.CategorizationRuleController, string) {
+	walletRepo, _ := test.NewFakeWalletRepo()
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	ruleRepo := test.NewFakeCategorizationRuleRepository()
+
+	name, _ := model.NewCategoryName("Transport")
+	category, _ := model.NewExpenseCategory(testUserID, *name)
+	subcategory, err := category.AddSubcategory(*name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expenseCategoryRepo.Save(category)
+
+	return controller.NewCategorizationRuleController(
+		command.NewCreateCategorizationRuleService(ruleRepo, walletRepo, expenseCategoryRepo, incomeCategoryRepo),
+		command.NewUpdateCategorizationRuleService(ruleRepo, walletRepo, expenseCategoryRepo, incomeCategoryRepo),
+		command.NewDeleteCategorizationRuleService(ruleRepo),
+		query.NewGetCategorizationRulesService(ruleRepo),
+		query.NewTestCategorizationRuleService(ruleRepo),
+	), subcategory.ID
+}
+
+func TestCategorizationRuleController_CreateThenTest(t *testing.T) {
+	// Arrange
+	rules, subcategoryID := newCategorizationRuleController(t)
+	payload, _ := json.Marshal(map[string]interface{}{
+		"name": "Rides", "type": "expense", "subcategory_id": subcategoryID, "tags": []string{"travel"},
+		"conditions": map[string]interface{}{"description_pattern": "uber|lyft", "max_amount": 5000, "currency": "USD"},
+	})
+	created := httptest.NewRecorder()
+	rules.CreateCategorizationRule(created, asUser(httptest.NewRequest("POST", "/api/v1/categorization-rules", bytes.NewBuffer(payload)), testUserID))
+	if created.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusCreated, created.Code, created.Body.String())
+	}
+
+	// Act
+	w := httptest.NewRecorder()
+	sample := `{"description":"UBER *TRIP","amount":1850,"currency":"USD","date":"2026-03-10T12:00:00Z"}`
+	rules.TestCategorizationRule(w, asUser(httptest.NewRequest("POST", "/api/v1/categorization-rules/test", bytes.NewBufferString(sample)), testUserID))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response struct {
+		Data struct {
+			Matched *struct {
+				SubcategoryID string   `json:"subcategory_id"`
+				Tags          []string `json:"tags"`
+			} `json:"matched"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if response.Data.Matched == nil || response.Data.Matched.SubcategoryID != subcategoryID || len(response.Data.Matched.Tags) != 1 {
+		t.Errorf("Expected the ride rule to match, got %s", w.Body.String())
+	}
+}
+
+func TestCategorizationRuleController_StatusCodes(t *testing.T) {
+	// Arrange
+	rules, subcategoryID := newCategorizationRuleController(t)
+
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		method   string
+		path     string
+		body     string
+		expected int
+	}{
+		{"unknown type filter", rules.GetCategorizationRules, "GET", "/api/v1/categorization-rules?type=TRANSFER", "", http.StatusBadRequest},
+		{"no conditions", rules.CreateCategorizationRule, "POST", "/api/v1/categorization-rules",
+			`{"name":"Empty","type":"EXPENSE","subcategory_id":"` + subcategoryID + `"}`, http.StatusBadRequest},
+		{"unknown subcategory", rules.CreateCategorizationRule, "POST", "/api/v1/categorization-rules",
+			`{"name":"Rides","type":"EXPENSE","subcategory_id":"missing","conditions":{"description_pattern":"uber"}}`, http.StatusNotFound},
+		{"unknown rule", rules.DeleteCategorizationRule, "DELETE", "/api/v1/categorization-rules/missing", "", http.StatusNotFound},
+		{"negative sample amount", rules.TestCategorizationRule, "POST", "/api/v1/categorization-rules/test", `{"description":"Uber","amount":-100,"currency":"USD"}`, http.StatusBadRequest},
+		{"wrong method", rules.UpdateCategorizationRule, "POST", "/api/v1/categorization-rules/rule-1", "{}", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			w := httptest.NewRecorder()
+			tt.handler(w, asUser(httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body)), testUserID))
+
+			// Assert
+			if w.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d. Response: %s", tt.expected, w.Code, w.Body.String())
+			}
+		})
+	}
+}
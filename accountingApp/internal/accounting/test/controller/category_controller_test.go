@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+)
+
+func newTestCategoryController(expenseRepo repository.ExpenseCategoryRepository, incomeRepo repository.IncomeCategoryRepository) *controller.CategoryController {
+	return controller.NewCategoryController(
+		command.NewCreateExpenseCategoryService(expenseRepo),
+		command.NewCreateIncomeCategoryService(incomeRepo),
+		query.NewGetExpenseCategoriesService(expenseRepo),
+		query.NewGetIncomeCategoriesService(incomeRepo),
+		command.NewRenameExpenseCategoryService(expenseRepo),
+		command.NewDeleteExpenseCategoryService(expenseRepo),
+		command.NewAddExpenseSubcategoryService(expenseRepo),
+		command.NewRenameExpenseSubcategoryService(expenseRepo),
+		command.NewRemoveExpenseSubcategoryService(expenseRepo),
+		command.NewRenameIncomeCategoryService(incomeRepo),
+		command.NewDeleteIncomeCategoryService(incomeRepo),
+		command.NewAddIncomeSubcategoryService(incomeRepo),
+		command.NewRenameIncomeSubcategoryService(incomeRepo),
+		command.NewRemoveIncomeSubcategoryService(incomeRepo),
+	)
+}
+
+func jsonRequest(method, path string, body map[string]interface{}) *http.Request {
+	jsonBody, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestCategoryController_ExpenseSubcategoryEndpoints(t *testing.T) {
+	// Arrange
+	expenseRepo := test.NewFakeExpenseCategoryRepository()
+	ctrl := newTestCategoryController(expenseRepo, test.NewFakeIncomeCategoryRepository())
+	categoryID := command.NewCreateExpenseCategoryService(expenseRepo).Execute(usecase.CreateExpenseCategoryInput{
+		UserID: "test-user",
+		Name:   "Food",
+	}).GetID()
+	basePath := "/api/v1/categories/expense/" + categoryID
+
+	// Act - 新增子分類
+	w := httptest.NewRecorder()
+	ctrl.AddExpenseSubcategory(w, jsonRequest("POST", basePath+"/subcategories", map[string]interface{}{"name": "Lunch"}))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	subcategoryID := response.Data.ID
+
+	// Act - 重新命名子分類
+	w = httptest.NewRecorder()
+	ctrl.RenameExpenseSubcategory(w, jsonRequest("PUT", basePath+"/subcategories/"+subcategoryID, map[string]interface{}{"name": "Brunch"}))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	category, _ := expenseRepo.FindByID(categoryID)
+	if len(category.Subcategories) != 1 || category.Subcategories[0].Name.Value != "Brunch" {
+		t.Errorf("Expected subcategory to be renamed, got %v", category.Subcategories)
+	}
+
+	// Act - 移除子分類
+	w = httptest.NewRecorder()
+	ctrl.RemoveExpenseSubcategory(w, asUser(httptest.NewRequest("DELETE", basePath+"/subcategories/"+subcategoryID, nil), testUserID))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	category, _ = expenseRepo.FindByID(categoryID)
+	if len(category.Subcategories) != 0 {
+		t.Errorf("Expected no subcategories, got %v", category.Subcategories)
+	}
+}
+
+func TestCategoryController_RenameExpenseCategory_NotFound(t *testing.T) {
+	ctrl := newTestCategoryController(test.NewFakeExpenseCategoryRepository(), test.NewFakeIncomeCategoryRepository())
+	w := httptest.NewRecorder()
+
+	ctrl.RenameExpenseCategory(w, jsonRequest("PUT", "/api/v1/categories/expense/missing", map[string]interface{}{"name": "Food"}))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Response: %s", http.StatusNotFound, w.Code, w.Body.String())
+	}
+}
+
+func TestCategoryController_DeleteIncomeCategory(t *testing.T) {
+	// Arrange
+	incomeRepo := test.NewFakeIncomeCategoryRepository()
+	ctrl := newTestCategoryController(test.NewFakeExpenseCategoryRepository(), incomeRepo)
+	categoryID := command.NewCreateIncomeCategoryService(incomeRepo).Execute(usecase.CreateIncomeCategoryInput{
+		UserID: "test-user",
+		Name:   "Salary",
+	}).GetID()
+	w := httptest.NewRecorder()
+
+	// Act
+	ctrl.DeleteIncomeCategory(w, asUser(httptest.NewRequest("DELETE", "/api/v1/categories/income/"+categoryID, nil), testUserID))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if category, _ := incomeRepo.FindByID(categoryID); category != nil {
+		t.Errorf("Expected category to be deleted, got %v", category)
+	}
+}
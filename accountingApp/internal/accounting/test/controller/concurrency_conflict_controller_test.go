@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+// conflictAddExpenseUseCase 模擬樂觀鎖重試用盡的use case
+type conflictAddExpenseUseCase struct{}
+
+func (conflictAddExpenseUseCase) Execute(input usecase.AddExpenseInput) common.Output {
+	return common.UseCaseOutput{
+		ExitCode: common.Conflict,
+		Message:  "failed to save wallet: concurrency conflict",
+	}
+}
+
+// TestAddExpenseController_ConcurrencyConflict_Returns409 tests that exhausted retries map to 409
+func TestAddExpenseController_ConcurrencyConflict_Returns409(t *testing.T) {
+	// Arrange
+	ctrl := controller.NewAddExpenseController(conflictAddExpenseUseCase{})
+	body, _ := json.Marshal(map[string]interface{}{
+		"wallet_id":      "wallet-123",
+		"subcategory_id": "food-123",
+		"amount":         1500,
+		"currency":       "USD",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/expenses", bytes.NewReader(body))
+	req = asUser(req, testUserID)
+	rr := httptest.NewRecorder()
+
+	// Act
+	ctrl.AddExpense(rr, req)
+
+	// Assert
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d", http.StatusConflict, rr.Code)
+	}
+}
@@ -15,7 +15,7 @@ import (
 func TestCreateWalletController_CreateWallet_Success(t *testing.T) {
 	// Arrange - Use real implementations
 	repo, _ := test.NewFakeWalletRepo()
-	service := command.NewCreateWalletService(repo)
+	service := command.NewCreateWalletService(repo, nil)
 	ctrl := controller.NewCreateWalletController(service)
 
 	requestBody := map[string]interface{}{
@@ -27,6 +27,7 @@ func TestCreateWalletController_CreateWallet_Success(t *testing.T) {
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("POST", "/api/v1/wallets", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -55,7 +56,7 @@ func TestCreateWalletController_CreateWallet_Success(t *testing.T) {
 func TestCreateWalletController_CreateWallet_WithInitialBalance(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	service := command.NewCreateWalletService(repo)
+	service := command.NewCreateWalletService(repo, nil)
 	ctrl := controller.NewCreateWalletController(service)
 
 	initialBalance := int64(10000) // 100.00 in cents
@@ -69,6 +70,7 @@ func TestCreateWalletController_CreateWallet_WithInitialBalance(t *testing.T) {
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("POST", "/api/v1/wallets", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -93,10 +95,10 @@ func TestCreateWalletController_CreateWallet_WithInitialBalance(t *testing.T) {
 	}
 }
 
-func TestCreateWalletController_CreateWallet_MissingUserID(t *testing.T) {
-	// Arrange
+func TestCreateWalletController_CreateWallet_UsesAuthenticatedUser(t *testing.T) {
+	// Arrange - user_id is optional now that the caller comes from the request context
 	repo, _ := test.NewFakeWalletRepo()
-	service := command.NewCreateWalletService(repo)
+	service := command.NewCreateWalletService(repo, nil)
 	ctrl := controller.NewCreateWalletController(service)
 
 	requestBody := map[string]interface{}{
@@ -107,6 +109,7 @@ func TestCreateWalletController_CreateWallet_MissingUserID(t *testing.T) {
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("POST", "/api/v1/wallets", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -114,22 +117,53 @@ func TestCreateWalletController_CreateWallet_MissingUserID(t *testing.T) {
 	ctrl.CreateWallet(w, req)
 
 	// Assert
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
 	}
 
-	var response map[string]interface{}
-	json.Unmarshal(w.Body.Bytes(), &response)
+	wallets, _ := repo.FindByUserID(testUserID)
+	if len(wallets) != 1 {
+		t.Errorf("Expected 1 wallet owned by the authenticated user, got %d", len(wallets))
+	}
+}
 
-	if response["success"] != false {
-		t.Errorf("Expected success to be false, got %v", response["success"])
+func TestCreateWalletController_CreateWallet_OtherUserID_Forbidden(t *testing.T) {
+	// Arrange
+	repo, _ := test.NewFakeWalletRepo()
+	service := command.NewCreateWalletService(repo, nil)
+	ctrl := controller.NewCreateWalletController(service)
+
+	requestBody := map[string]interface{}{
+		"user_id":  "someone-else",
+		"name":     "Test Wallet",
+		"type":     "CASH",
+		"currency": "USD",
+	}
+
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest("POST", "/api/v1/wallets", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	ctrl.CreateWallet(w, req)
+
+	// Assert
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d", http.StatusForbidden, w.Code)
+	}
+
+	wallets, _ := repo.FindByUserID("someone-else")
+	if len(wallets) != 0 {
+		t.Errorf("Expected no wallet to be created for another user, got %d", len(wallets))
 	}
 }
 
 func TestCreateWalletController_CreateWallet_InvalidWalletType(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	service := command.NewCreateWalletService(repo)
+	service := command.NewCreateWalletService(repo, nil)
 	ctrl := controller.NewCreateWalletController(service)
 
 	requestBody := map[string]interface{}{
@@ -141,6 +175,7 @@ func TestCreateWalletController_CreateWallet_InvalidWalletType(t *testing.T) {
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("POST", "/api/v1/wallets", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -169,10 +204,11 @@ func TestCreateWalletController_CreateWallet_InvalidWalletType(t *testing.T) {
 func TestCreateWalletController_CreateWallet_InvalidJSON(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	service := command.NewCreateWalletService(repo)
+	service := command.NewCreateWalletService(repo, nil)
 	ctrl := controller.NewCreateWalletController(service)
 
 	req := httptest.NewRequest("POST", "/api/v1/wallets", bytes.NewBufferString("{invalid-json"))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -188,10 +224,11 @@ func TestCreateWalletController_CreateWallet_InvalidJSON(t *testing.T) {
 func TestCreateWalletController_CreateWallet_MethodNotAllowed(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	service := command.NewCreateWalletService(repo)
+	service := command.NewCreateWalletService(repo, nil)
 	ctrl := controller.NewCreateWalletController(service)
 
 	req := httptest.NewRequest("GET", "/api/v1/wallets", nil)
+	req = asUser(req, testUserID)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+)
+
+func TestCurrencyController_GetCurrencies(t *testing.T) {
+	// Arrange
+	ctrl := controller.NewCurrencyController(query.NewGetCurrenciesService())
+
+	// Act
+	w := httptest.NewRecorder()
+	ctrl.GetCurrencies(w, asUser(httptest.NewRequest("GET", "/api/v1/currencies?locale=de_DE", nil), testUserID))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response struct {
+		Data struct {
+			Locale     string                 `json:"locale"`
+			Currencies []usecase.CurrencyData `json:"currencies"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if response.Data.Locale != "de-DE" {
+		t.Errorf("Expected locale de-DE, got %q", response.Data.Locale)
+	}
+	examples := make(map[string]usecase.CurrencyData)
+	for _, currency := range response.Data.Currencies {
+		examples[currency.Code] = currency
+	}
+	if kwd := examples["KWD"]; kwd.MinorUnits != 3 || kwd.Example != "1.234,560\u00a0KWD" {
+		t.Errorf("Expected KWD with 3 minor units, got %+v", kwd)
+	}
+	if eur := examples["EUR"]; eur.Example != "1.234,56\u00a0€" {
+		t.Errorf("Expected the euro example in German format, got %q", eur.Example)
+	}
+	if _, exists := examples["UDS"]; exists {
+		t.Error("Expected no entry for an unknown code")
+	}
+
+	// Act - the Accept-Language header picks the locale when none is given
+	w = httptest.NewRecorder()
+	req := asUser(httptest.NewRequest("GET", "/api/v1/currencies", nil), testUserID)
+	req.Header.Set("Accept-Language", "zh-TW,zh;q=0.9,en;q=0.8")
+	ctrl.GetCurrencies(w, req)
+
+	// Assert
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if response.Data.Locale != "zh-TW" {
+		t.Errorf("Expected locale zh-TW from Accept-Language, got %q", response.Data.Locale)
+	}
+
+	// Act - authentication is required
+	w = httptest.NewRecorder()
+	ctrl.GetCurrencies(w, httptest.NewRequest("GET", "/api/v1/currencies", nil))
+
+	// Assert
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d without a user, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
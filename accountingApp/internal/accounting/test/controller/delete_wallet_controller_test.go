@@ -14,11 +14,11 @@ import (
 func TestDeleteWalletController_DeleteWallet_Success(t *testing.T) {
 	// Arrange - Use real implementations
 	repo, _ := test.NewFakeWalletRepo()
-	deleteService := command.NewDeleteWalletService(repo)
+	deleteService := command.NewDeleteWalletService(repo, nil, nil)
 	ctrl := controller.NewDeleteWalletController(deleteService)
 
 	// Create a wallet first
-	createService := command.NewCreateWalletService(repo)
+	createService := command.NewCreateWalletService(repo, nil)
 	createResult := createService.Execute(usecase.CreateWalletInput{
 		UserID:   "test-user",
 		Name:     "Test Wallet",
@@ -32,6 +32,7 @@ func TestDeleteWalletController_DeleteWallet_Success(t *testing.T) {
 
 	walletID := createResult.GetID()
 	req := httptest.NewRequest("DELETE", "/api/v1/wallets/"+walletID, nil)
+	req = asUser(req, testUserID)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -52,11 +53,12 @@ func TestDeleteWalletController_DeleteWallet_Success(t *testing.T) {
 func TestDeleteWalletController_DeleteWallet_NotFound(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	deleteService := command.NewDeleteWalletService(repo)
+	deleteService := command.NewDeleteWalletService(repo, nil, nil)
 	ctrl := controller.NewDeleteWalletController(deleteService)
 
 	nonExistentID := "non-existent-wallet-id"
 	req := httptest.NewRequest("DELETE", "/api/v1/wallets/"+nonExistentID, nil)
+	req = asUser(req, testUserID)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -71,11 +73,12 @@ func TestDeleteWalletController_DeleteWallet_NotFound(t *testing.T) {
 func TestDeleteWalletController_DeleteWallet_InvalidWalletID(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	deleteService := command.NewDeleteWalletService(repo)
+	deleteService := command.NewDeleteWalletService(repo, nil, nil)
 	ctrl := controller.NewDeleteWalletController(deleteService)
 
 	// Test with empty wallet ID path
 	req := httptest.NewRequest("DELETE", "/api/v1/wallets/", nil)
+	req = asUser(req, testUserID)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -90,10 +93,11 @@ func TestDeleteWalletController_DeleteWallet_InvalidWalletID(t *testing.T) {
 func TestDeleteWalletController_DeleteWallet_MethodNotAllowed(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	deleteService := command.NewDeleteWalletService(repo)
+	deleteService := command.NewDeleteWalletService(repo, nil, nil)
 	ctrl := controller.NewDeleteWalletController(deleteService)
 
 	req := httptest.NewRequest("GET", "/api/v1/wallets/some-id", nil)
+	req = asUser(req, testUserID)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -108,11 +112,11 @@ func TestDeleteWalletController_DeleteWallet_MethodNotAllowed(t *testing.T) {
 func TestDeleteWalletController_DeleteWallet_URLDecoding(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	deleteService := command.NewDeleteWalletService(repo)
+	deleteService := command.NewDeleteWalletService(repo, nil, nil)
 	ctrl := controller.NewDeleteWalletController(deleteService)
 
 	// Create a wallet with a UUID that might need URL decoding
-	createService := command.NewCreateWalletService(repo)
+	createService := command.NewCreateWalletService(repo, nil)
 	createResult := createService.Execute(usecase.CreateWalletInput{
 		UserID:   "test-user",
 		Name:     "Test Wallet",
@@ -124,6 +128,7 @@ func TestDeleteWalletController_DeleteWallet_URLDecoding(t *testing.T) {
 	
 	// Test with URL encoded wallet ID (simulating special characters)
 	req := httptest.NewRequest("DELETE", "/api/v1/wallets/"+walletID, nil)
+	req = asUser(req, testUserID)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/duplicate"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+)
+
+func TestDuplicateController_FlagOnAddThenMerge(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	wallet, _ := model.NewWalletWithInitialBalance(testUserID, "Cash", model.WalletTypeCash, "USD", 100000)
+	walletRepo.Save(wallet)
+	flagRepo := test.NewFakeDuplicateFlagRepository()
+	flagger := duplicate.NewFlagger(walletRepo, flagRepo, duplicate.NewDetector(duplicate.DefaultDetectorConfig()))
+	addExpense := controller.NewAddExpenseController(duplicate.NewAddExpenseCommand(command.NewAddExpenseService(walletRepo, nil, nil, nil), flagger))
+	duplicates := controller.NewDuplicateController(
+		query.NewGetDuplicatesService(flagRepo, walletRepo),
+		command.NewResolveDuplicateService(flagRepo, walletRepo, test.NewFakeAttachmentRepository()),
+	)
+
+	var added struct {
+		ID         string `json:"id"`
+		Duplicates []struct {
+			ID string `json:"id"`
+		} `json:"duplicates"`
+	}
+	for _, description := range []string{"Uber trip", "UBER *TRIP HELP.UBER.COM"} {
+		payload, _ := json.Marshal(map[string]interface{}{
+			"wallet_id": wallet.ID, "subcategory_id": "transport", "amount": 1850, "currency": "USD",
+			"description": description, "date": "2026-03-10T12:00:00Z",
+		})
+		w := httptest.NewRecorder()
+		addExpense.AddExpense(w, asUser(httptest.NewRequest("POST", "/api/v1/expenses", bytes.NewBuffer(payload)), testUserID))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		json.Unmarshal(w.Body.Bytes(), &added)
+	}
+	if len(added.Duplicates) != 1 {
+		t.Fatalf("Expected the second expense to be flagged, got %+v", added)
+	}
+
+	// Act - list the queue, then merge
+	w := httptest.NewRecorder()
+	duplicates.GetDuplicates(w, asUser(httptest.NewRequest("GET", "/api/v1/duplicates?wallet_id="+wallet.ID, nil), testUserID))
+	var queue struct {
+		Data []struct {
+			ID     string `json:"id"`
+			Record struct {
+				ID string `json:"id"`
+			} `json:"record"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &queue)
+
+	resolve := httptest.NewRequest("POST", "/api/v1/duplicates/"+added.Duplicates[0].ID+"/resolve", bytes.NewBufferString(`{"resolution":"merge"}`))
+	resolved := httptest.NewRecorder()
+	duplicates.ResolveDuplicate(resolved, asUser(resolve, testUserID))
+
+	// Assert
+	if w.Code != http.StatusOK || len(queue.Data) != 1 || queue.Data[0].Record.ID != added.ID {
+		t.Fatalf("Expected the flagged expense in the queue, got status %d. Response: %s", w.Code, w.Body.String())
+	}
+	if resolved.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, resolved.Code, resolved.Body.String())
+	}
+	saved, _ := walletRepo.FindByIDWithTransactions(wallet.ID)
+	if len(saved.GetExpenseRecords()) != 1 || saved.Balance.Amount != 100000-1850 {
+		t.Errorf("Expected one expense left and the duplicate refunded, got %d expenses and balance %d",
+			len(saved.GetExpenseRecords()), saved.Balance.Amount)
+	}
+}
+
+func TestDuplicateController_StatusCodes(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	flagRepo := test.NewFakeDuplicateFlagRepository()
+	flag, _ := model.NewDuplicateFlag("other-user", "wallet-1", model.DuplicateExpense, "exp-2", "exp-1", 1)
+	flagRepo.Save(flag)
+	duplicates := controller.NewDuplicateController(
+		query.NewGetDuplicatesService(flagRepo, walletRepo),
+		command.NewResolveDuplicateService(flagRepo, walletRepo, test.NewFakeAttachmentRepository()),
+	)
+
+	resolvePath := "/api/v1/duplicates/" + flag.ID + "/resolve"
+
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		method   string
+		path     string
+		body     string
+		expected int
+	}{
+		{"unknown status filter", duplicates.GetDuplicates, "GET", "/api/v1/duplicates?status=DONE", "", http.StatusBadRequest},
+		{"missing resolution", duplicates.ResolveDuplicate, "POST", resolvePath, `{}`, http.StatusBadRequest},
+		{"invalid resolution", duplicates.ResolveDuplicate, "POST", resolvePath, `{"resolution":"ignore"}`, http.StatusBadRequest},
+		{"another user's flag", duplicates.ResolveDuplicate, "POST", resolvePath, `{"resolution":"KEEP_BOTH"}`, http.StatusNotFound},
+		{"wrong method", duplicates.ResolveDuplicate, "GET", resolvePath, "", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			w := httptest.NewRecorder()
+			tt.handler(w, asUser(httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body)), testUserID))
+
+			// Assert
+			if w.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d. Response: %s", tt.expected, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+)
+
+const testExchangeRatesCSV = "date,base,quote,rate\n" +
+	"2026-03-01,USD,TWD,30\n" +
+	"2026-03-10,USD,TWD,32\n"
+
+func newExchangeRateController(repo *test.FakeExchangeRateRepository) *controller.ExchangeRateController {
+	return controller.NewExchangeRateController(
+		query.NewGetExchangeRatesService(repo),
+		query.NewConvertMoneyService(exchange.NewConverter(repo, model.RoundHalfUp)),
+		command.NewImportExchangeRatesService(repo),
+		command.NewRefreshExchangeRatesService(repo, exchange.NewStubProvider()),
+		[]string{testAdminID},
+	)
+}
+
+func TestExchangeRateController_AdministratorImportsRates(t *testing.T) {
+	// Arrange
+	ctrl := newExchangeRateController(test.NewFakeExchangeRateRepository())
+
+	// Act - a regular user may not load rates
+	w := httptest.NewRecorder()
+	ctrl.ImportExchangeRates(w, newFormUpload(t, "/api/v1/admin/exchange-rates/import", "rates.csv", nil, []byte(testExchangeRatesCSV)))
+
+	// Assert
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("Expected status %d for a non-admin, got %d", http.StatusForbidden, w.Code)
+	}
+
+	// Act
+	w = httptest.NewRecorder()
+	ctrl.ImportExchangeRates(w, asUser(newFormUpload(t, "/api/v1/admin/exchange-rates/import", "rates.csv", nil, []byte(testExchangeRatesCSV)), testAdminID))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var imported struct {
+		Data struct {
+			Imported int `json:"imported"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &imported)
+	if imported.Data.Imported != 2 {
+		t.Errorf("Expected 2 imported rates, got %d", imported.Data.Imported)
+	}
+
+	// Act - an invalid line rejects the whole file
+	w = httptest.NewRecorder()
+	ctrl.ImportExchangeRates(w, asUser(newFormUpload(t, "/api/v1/admin/exchange-rates/import", "rates.csv", nil, []byte("date,base,quote,rate\n2026-03-11,USD,TWD,abc\n")), testAdminID))
+
+	// Assert
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an invalid file, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestExchangeRateController_ConvertMoney(t *testing.T) {
+	// Arrange
+	ctrl := newExchangeRateController(test.NewFakeExchangeRateRepository())
+	w := httptest.NewRecorder()
+	ctrl.ImportExchangeRates(w, asUser(newFormUpload(t, "/api/v1/admin/exchange-rates/import", "rates.csv", nil, []byte(testExchangeRatesCSV)), testAdminID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to import rates: %s", w.Body.String())
+	}
+
+	// Act
+	w = httptest.NewRecorder()
+	ctrl.ConvertMoney(w, asUser(httptest.NewRequest("GET", "/api/v1/exchange-rates/convert?amount=1050&from=usd&to=TWD&date=2026-03-05", nil), testUserID))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response struct {
+		Data struct {
+			Converted struct {
+				Amount   int64  `json:"amount"`
+				Currency string `json:"currency"`
+				Rate     string `json:"rate"`
+				RateDate string `json:"rate_date"`
+			} `json:"converted"`
+			Rounding string `json:"rounding"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if response.Data.Converted.Amount != 315 || response.Data.Converted.RateDate != "2026-03-01" {
+		t.Errorf("Expected 10.50 USD to be 315 TWD at the rate of 2026-03-01, got %+v", response.Data.Converted)
+	}
+	if response.Data.Rounding != "HALF_UP" {
+		t.Errorf("Expected rounding HALF_UP, got %s", response.Data.Rounding)
+	}
+
+	for url, expected := range map[string]int{
+		"/api/v1/exchange-rates/convert?amount=100&from=USD&to=EUR":        http.StatusNotFound,
+		"/api/v1/exchange-rates/convert?amount=1.5&from=USD&to=TWD":        http.StatusBadRequest,
+		"/api/v1/exchange-rates/convert?amount=100&from=USD&to=TW":         http.StatusBadRequest,
+		"/api/v1/exchange-rates/convert?amount=100&from=USD&to=TWD&date=x": http.StatusBadRequest,
+	} {
+		w = httptest.NewRecorder()
+		ctrl.ConvertMoney(w, asUser(httptest.NewRequest("GET", url, nil), testUserID))
+		if w.Code != expected {
+			t.Errorf("%s: expected status %d, got %d", url, expected, w.Code)
+		}
+	}
+}
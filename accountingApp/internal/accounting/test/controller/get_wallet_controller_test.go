@@ -16,12 +16,12 @@ import (
 func TestGetWalletController_GetWallets_Success(t *testing.T) {
 	// Arrange - Use real implementations
 	repo, _ := test.NewFakeWalletRepo()
-	getWalletsService := query.NewGetWalletsService(repo)
+	getWalletsService := query.NewGetWalletsService(repo, nil)
 	getWalletService := query.NewGetWalletService(repo)
 	ctrl := controller.NewQueryWalletController(getWalletsService, getWalletService)
 
 	// Create test wallets
-	createService := command.NewCreateWalletService(repo)
+	createService := command.NewCreateWalletService(repo, nil)
 	createService.Execute(usecase.CreateWalletInput{
 		UserID:   "test-user",
 		Name:     "Test Wallet 1",
@@ -36,6 +36,7 @@ func TestGetWalletController_GetWallets_Success(t *testing.T) {
 	})
 
 	req := httptest.NewRequest("GET", "/api/v1/wallets?userID=test-user", nil)
+	req = asUser(req, testUserID)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -53,13 +54,7 @@ func TestGetWalletController_GetWallets_Success(t *testing.T) {
 		t.Errorf("Expected success to be true, got %v", response["success"])
 	}
 
-	data := response["data"].(map[string]interface{})
-	wallets := data["data"].([]interface{})
-	count := data["count"].(float64)
-
-	if count != 2 {
-		t.Errorf("Expected count to be 2, got %v", count)
-	}
+	wallets := response["data"].([]interface{})
 
 	if len(wallets) != 2 {
 		t.Errorf("Expected 2 wallets, got %d", len(wallets))
@@ -69,11 +64,12 @@ func TestGetWalletController_GetWallets_Success(t *testing.T) {
 func TestGetWalletController_GetWallets_EmptyResult(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	getWalletsService := query.NewGetWalletsService(repo)
+	getWalletsService := query.NewGetWalletsService(repo, nil)
 	getWalletService := query.NewGetWalletService(repo)
 	ctrl := controller.NewQueryWalletController(getWalletsService, getWalletService)
 
 	req := httptest.NewRequest("GET", "/api/v1/wallets?userID=non-existent-user", nil)
+	req = asUser(req, "non-existent-user")
 	w := httptest.NewRecorder()
 
 	// Act
@@ -87,18 +83,17 @@ func TestGetWalletController_GetWallets_EmptyResult(t *testing.T) {
 	var response map[string]interface{}
 	json.Unmarshal(w.Body.Bytes(), &response)
 
-	data := response["data"].(map[string]interface{})
-	count := data["count"].(float64)
+	wallets := response["data"].([]interface{})
 
-	if count != 0 {
-		t.Errorf("Expected count to be 0, got %v", count)
+	if len(wallets) != 0 {
+		t.Errorf("Expected 0 wallets, got %d", len(wallets))
 	}
 }
 
-func TestGetWalletController_GetWallets_MissingUserID(t *testing.T) {
+func TestGetWalletController_GetWallets_Unauthenticated(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	getWalletsService := query.NewGetWalletsService(repo)
+	getWalletsService := query.NewGetWalletsService(repo, nil)
 	getWalletService := query.NewGetWalletService(repo)
 	ctrl := controller.NewQueryWalletController(getWalletsService, getWalletService)
 
@@ -109,8 +104,8 @@ func TestGetWalletController_GetWallets_MissingUserID(t *testing.T) {
 	ctrl.GetWallets(w, req)
 
 	// Assert
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, w.Code)
 	}
 
 	var response map[string]interface{}
@@ -124,11 +119,12 @@ func TestGetWalletController_GetWallets_MissingUserID(t *testing.T) {
 func TestGetWalletController_GetWallets_MethodNotAllowed(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	getWalletsService := query.NewGetWalletsService(repo)
+	getWalletsService := query.NewGetWalletsService(repo, nil)
 	getWalletService := query.NewGetWalletService(repo)
 	ctrl := controller.NewQueryWalletController(getWalletsService, getWalletService)
 
 	req := httptest.NewRequest("POST", "/api/v1/wallets?userID=test-user", nil)
+	req = asUser(req, testUserID)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -143,12 +139,12 @@ func TestGetWalletController_GetWallets_MethodNotAllowed(t *testing.T) {
 func TestGetWalletController_GetWallet_Success(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	getWalletsService := query.NewGetWalletsService(repo)
+	getWalletsService := query.NewGetWalletsService(repo, nil)
 	getWalletService := query.NewGetWalletService(repo)
 	ctrl := controller.NewQueryWalletController(getWalletsService, getWalletService)
 
 	// Create test wallet
-	createService := command.NewCreateWalletService(repo)
+	createService := command.NewCreateWalletService(repo, nil)
 	createResult := createService.Execute(usecase.CreateWalletInput{
 		UserID:   "test-user",
 		Name:     "Test Wallet",
@@ -158,6 +154,7 @@ func TestGetWalletController_GetWallet_Success(t *testing.T) {
 
 	walletID := createResult.GetID()
 	req := httptest.NewRequest("GET", "/api/v1/wallets/"+walletID, nil)
+	req = asUser(req, testUserID)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -175,8 +172,7 @@ func TestGetWalletController_GetWallet_Success(t *testing.T) {
 		t.Errorf("Expected success to be true, got %v", response["success"])
 	}
 
-	data := response["data"].(map[string]interface{})
-	walletData := data["data"].(map[string]interface{})
+	walletData := response["data"].(map[string]interface{})
 	
 	if walletData["name"] != "Test Wallet" {
 		t.Errorf("Expected wallet name 'Test Wallet', got %v", walletData["name"])
@@ -186,12 +182,12 @@ func TestGetWalletController_GetWallet_Success(t *testing.T) {
 func TestGetWalletController_GetWallet_WithTransactions(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	getWalletsService := query.NewGetWalletsService(repo)
+	getWalletsService := query.NewGetWalletsService(repo, nil)
 	getWalletService := query.NewGetWalletService(repo)
 	ctrl := controller.NewQueryWalletController(getWalletsService, getWalletService)
 
 	// Create test wallet
-	createService := command.NewCreateWalletService(repo)
+	createService := command.NewCreateWalletService(repo, nil)
 	createResult := createService.Execute(usecase.CreateWalletInput{
 		UserID:   "test-user",
 		Name:     "Test Wallet",
@@ -201,6 +197,7 @@ func TestGetWalletController_GetWallet_WithTransactions(t *testing.T) {
 
 	walletID := createResult.GetID()
 	req := httptest.NewRequest("GET", "/api/v1/wallets/"+walletID+"?includeTransactions=true", nil)
+	req = asUser(req, testUserID)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -214,8 +211,7 @@ func TestGetWalletController_GetWallet_WithTransactions(t *testing.T) {
 	var response map[string]interface{}
 	json.Unmarshal(w.Body.Bytes(), &response)
 
-	data := response["data"].(map[string]interface{})
-	walletData := data["data"].(map[string]interface{})
+	walletData := response["data"].(map[string]interface{})
 	
 	// Check if transactions field is present (even if empty)
 	if _, hasTransactions := walletData["transactions"]; !hasTransactions {
@@ -226,12 +222,13 @@ func TestGetWalletController_GetWallet_WithTransactions(t *testing.T) {
 func TestGetWalletController_GetWallet_NotFound(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	getWalletsService := query.NewGetWalletsService(repo)
+	getWalletsService := query.NewGetWalletsService(repo, nil)
 	getWalletService := query.NewGetWalletService(repo)
 	ctrl := controller.NewQueryWalletController(getWalletsService, getWalletService)
 
 	nonExistentID := "non-existent-wallet-id"
 	req := httptest.NewRequest("GET", "/api/v1/wallets/"+nonExistentID, nil)
+	req = asUser(req, testUserID)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -253,11 +250,12 @@ func TestGetWalletController_GetWallet_NotFound(t *testing.T) {
 func TestGetWalletController_GetWallet_InvalidWalletID(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	getWalletsService := query.NewGetWalletsService(repo)
+	getWalletsService := query.NewGetWalletsService(repo, nil)
 	getWalletService := query.NewGetWalletService(repo)
 	ctrl := controller.NewQueryWalletController(getWalletsService, getWalletService)
 
 	req := httptest.NewRequest("GET", "/api/v1/wallets/", nil)
+	req = asUser(req, testUserID)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -272,11 +270,12 @@ func TestGetWalletController_GetWallet_InvalidWalletID(t *testing.T) {
 func TestGetWalletController_GetWallet_MethodNotAllowed(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	getWalletsService := query.NewGetWalletsService(repo)
+	getWalletsService := query.NewGetWalletsService(repo, nil)
 	getWalletService := query.NewGetWalletService(repo)
 	ctrl := controller.NewQueryWalletController(getWalletsService, getWalletService)
 
 	req := httptest.NewRequest("DELETE", "/api/v1/wallets/some-id", nil)
+	req = asUser(req, testUserID)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -0,0 +1,263 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/statement"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+)
+
+const testStatementCSV = "Date,Memo,Amount\n" +
+	"2024-03-01,Salary,\"2,000\"\n" +
+	"2024-03-02,Groceries,-500\n" +
+	"2024-03-03,Laptop,-90000\n"
+
+const testStatementOFX = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX><CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS>
+<CURDEF>TWD</CURDEF>
+<CCACCTFROM><ACCTID>4000-1234</ACCTID></CCACCTFROM>
+<BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20240302</DTPOSTED><TRNAMT>-500</TRNAMT><FITID>A1</FITID><NAME>超市</NAME></STMTTRN>
+<STMTTRN><TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20240305</DTPOSTED><TRNAMT>120</TRNAMT><FITID>A2</FITID><NAME>Refund</NAME></STMTTRN>
+</BANKTRANLIST>
+</CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1></OFX>
+`
+
+func newImportController(walletRepo repository.WalletRepository, expenseCategoryRepo repository.ExpenseCategoryRepository, incomeCategoryRepo repository.IncomeCategoryRepository) *controller.ImportController {
+	profileRepo := test.NewFakeImportProfileRepository()
+	ruleRepo := test.NewFakeCategorizationRuleRepository()
+	return controller.NewImportController(
+		command.NewCreateImportProfileService(profileRepo, expenseCategoryRepo, incomeCategoryRepo),
+		command.NewUpdateImportProfileService(profileRepo, expenseCategoryRepo, incomeCategoryRepo),
+		command.NewDeleteImportProfileService(profileRepo),
+		query.NewGetImportProfilesService(profileRepo),
+		query.NewPreviewImportService(walletRepo, profileRepo, expenseCategoryRepo, incomeCategoryRepo, ruleRepo),
+		command.NewCommitImportService(walletRepo, profileRepo, expenseCategoryRepo, incomeCategoryRepo, ruleRepo),
+	)
+}
+
+// createImportProfile creates a signed-amount CSV profile through the controller and returns its ID
+func createImportProfile(t *testing.T, imports *controller.ImportController, expenseSubcategoryID, incomeSubcategoryID string) string {
+	payload, _ := json.Marshal(map[string]interface{}{"name": "My Bank", "mapping": map[string]interface{}{
+		"has_header":             true,
+		"date_column":            "Date",
+		"date_format":            "YYYY-MM-DD",
+		"description_column":     "Memo",
+		"amount_column":          "Amount",
+		"expense_subcategory_id": expenseSubcategoryID,
+		"income_subcategory_id":  incomeSubcategoryID,
+	}})
+	req := httptest.NewRequest("POST", "/api/v1/import-profiles", bytes.NewBuffer(payload))
+	w := httptest.NewRecorder()
+	imports.CreateImportProfile(w, asUser(req, testUserID))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &created)
+	return created.Data.ID
+}
+
+// newStatementUpload builds a multipart preview or commit request for a CSV statement
+func newStatementUpload(t *testing.T, path, profileID, walletID string, content []byte) *http.Request {
+	return newFormUpload(t, path, "statement.csv", map[string]string{"profile_id": profileID, "wallet_id": walletID}, content)
+}
+
+func newFormUpload(t *testing.T, path, fileName string, fields map[string]string, content []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range fields {
+		writer.WriteField(name, value)
+	}
+	if content != nil {
+		part, err := writer.CreateFormFile("file", fileName)
+		if err != nil {
+			t.Fatalf("Failed to create multipart part: %v", err)
+		}
+		part.Write(content)
+	}
+	writer.Close()
+
+	req := httptest.NewRequest("POST", path, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return asUser(req, testUserID)
+}
+
+func TestImportController_PreviewThenCommit(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	wallet := test.CreateWallet(t, walletRepo, testUserID, "TWD", 1000)
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, testUserID, "Food", "Groceries")
+	_, monthly := test.CreateIncomeSubcategory(t, incomeCategoryRepo, testUserID, "Salary", "Monthly")
+	imports := newImportController(walletRepo, expenseCategoryRepo, incomeCategoryRepo)
+	profileID := createImportProfile(t, imports, groceries.ID, monthly.ID)
+
+	// Act - preview records nothing
+	w := httptest.NewRecorder()
+	imports.PreviewImport(w, newStatementUpload(t, "/api/v1/imports/preview", profileID, wallet.ID, []byte(testStatementCSV)))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var preview struct {
+		Data struct {
+			Rows          []map[string]interface{} `json:"rows"`
+			Rejected      []map[string]interface{} `json:"rejected"`
+			TotalExpenses int64                    `json:"total_expenses"`
+			TotalIncomes  int64                    `json:"total_incomes"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &preview)
+	if len(preview.Data.Rows) != 2 || len(preview.Data.Rejected) != 1 {
+		t.Fatalf("Expected 2 rows and 1 rejection, got %s", w.Body.String())
+	}
+	if preview.Data.TotalExpenses != 500 || preview.Data.TotalIncomes != 2000 {
+		t.Errorf("Unexpected preview totals: %s", w.Body.String())
+	}
+	saved, _ := walletRepo.FindByIDWithTransactions(wallet.ID)
+	if len(saved.GetExpenseRecords()) != 0 {
+		t.Errorf("Expected preview not to record expenses, got %d", len(saved.GetExpenseRecords()))
+	}
+
+	// Act - commit records the same rows
+	w = httptest.NewRecorder()
+	imports.CommitImport(w, newStatementUpload(t, "/api/v1/imports/commit", profileID, wallet.ID, []byte(testStatementCSV)))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var committed struct {
+		Data struct {
+			Imported int `json:"imported"`
+			Rejected []struct {
+				Line   int    `json:"line"`
+				Reason string `json:"reason"`
+			} `json:"rejected"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &committed)
+	if committed.Data.Imported != 2 || len(committed.Data.Rejected) != 1 || committed.Data.Rejected[0].Line != 4 {
+		t.Errorf("Unexpected commit result: %s", w.Body.String())
+	}
+	saved, _ = walletRepo.FindByIDWithTransactions(wallet.ID)
+	if saved.Balance.Amount != 2500 {
+		t.Errorf("Expected balance 2500, got %d", saved.Balance.Amount)
+	}
+}
+
+func TestImportController_CommitOFXTwiceSkipsImportedTransactions(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	wallet := test.CreateWallet(t, walletRepo, testUserID, "TWD", 1000)
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, testUserID, "Food", "Groceries")
+	_, monthly := test.CreateIncomeSubcategory(t, incomeCategoryRepo, testUserID, "Salary", "Monthly")
+	imports := newImportController(walletRepo, expenseCategoryRepo, incomeCategoryRepo)
+	fields := map[string]string{
+		"wallet_id":              wallet.ID,
+		"expense_subcategory_id": groceries.ID,
+		"income_subcategory_id":  monthly.ID,
+	}
+	type commitResponse struct {
+		Data struct {
+			Imported   int                      `json:"imported"`
+			Skipped    []map[string]interface{} `json:"skipped"`
+			Statements []struct {
+				Account string `json:"account"`
+				Created int    `json:"created"`
+				Skipped int    `json:"skipped"`
+			} `json:"statements"`
+		} `json:"data"`
+	}
+
+	for _, expected := range []struct{ created, skipped int }{{2, 0}, {0, 2}} {
+		// Act - the format is detected from the file extension
+		w := httptest.NewRecorder()
+		imports.CommitImport(w, newFormUpload(t, "/api/v1/imports/commit", "card.ofx", fields, []byte(testStatementOFX)))
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var committed commitResponse
+		json.Unmarshal(w.Body.Bytes(), &committed)
+		if committed.Data.Imported != expected.created || len(committed.Data.Skipped) != expected.skipped || len(committed.Data.Statements) != 1 {
+			t.Fatalf("Unexpected commit result: %s", w.Body.String())
+		}
+		summary := committed.Data.Statements[0]
+		if summary.Account != "4000-1234" || summary.Created != expected.created || summary.Skipped != expected.skipped {
+			t.Errorf("Unexpected statement summary: %s", w.Body.String())
+		}
+	}
+
+	saved, _ := walletRepo.FindByIDWithTransactions(wallet.ID)
+	if saved.Balance.Amount != 620 {
+		t.Errorf("Expected balance 620, got %d", saved.Balance.Amount)
+	}
+}
+
+func TestImportController_StatusCodes(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	wallet := test.CreateWallet(t, walletRepo, testUserID, "TWD", 1000)
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, testUserID, "Food", "Groceries")
+	_, monthly := test.CreateIncomeSubcategory(t, incomeCategoryRepo, testUserID, "Salary", "Monthly")
+	imports := newImportController(walletRepo, expenseCategoryRepo, incomeCategoryRepo)
+	profileID := createImportProfile(t, imports, groceries.ID, monthly.ID)
+	invalidMapping, _ := json.Marshal(map[string]interface{}{"name": "Broken", "mapping": map[string]interface{}{"date_column": "Date"}})
+
+	cases := []struct {
+		name     string
+		serve    func(w http.ResponseWriter, r *http.Request)
+		request  *http.Request
+		expected int
+	}{
+		{"invalid mapping", imports.CreateImportProfile,
+			asUser(httptest.NewRequest("POST", "/api/v1/import-profiles", bytes.NewBuffer(invalidMapping)), testUserID), http.StatusBadRequest},
+		{"unknown profile", imports.DeleteImportProfile,
+			asUser(httptest.NewRequest("DELETE", "/api/v1/import-profiles/missing", nil), testUserID), http.StatusNotFound},
+		{"missing file", imports.PreviewImport,
+			newStatementUpload(t, "/api/v1/imports/preview", profileID, wallet.ID, nil), http.StatusBadRequest},
+		{"unknown wallet", imports.CommitImport,
+			newStatementUpload(t, "/api/v1/imports/commit", profileID, "missing", []byte(testStatementCSV)), http.StatusNotFound},
+		{"unparseable file", imports.CommitImport,
+			newStatementUpload(t, "/api/v1/imports/commit", profileID, wallet.ID, []byte("When,What\n")), http.StatusBadRequest},
+		{"too large", imports.PreviewImport,
+			newStatementUpload(t, "/api/v1/imports/preview", profileID, wallet.ID, make([]byte, statement.MaxFileSize+2<<20)), http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			w := httptest.NewRecorder()
+			tc.serve(w, tc.request)
+
+			// Assert
+			if w.Code != tc.expected {
+				t.Errorf("Expected status %d, got %d. Response: %s", tc.expected, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+)
+
+const testAdminID = "admin-user"
+
+func newOutboxAdminController(repo *test.FakeOutboxRepository) *controller.OutboxAdminController {
+	return controller.NewOutboxAdminController(
+		query.NewGetOutboxMessagesService(repo),
+		command.NewRetryOutboxMessageService(repo),
+		[]string{testAdminID},
+	)
+}
+
+func deadLetterMessage(id string) mapper.OutboxMessageData {
+	now := time.Now()
+	return mapper.OutboxMessageData{
+		ID:            id,
+		AggregateType: mapper.AggregateTypeWallet,
+		AggregateID:   "wallet-1",
+		EventType:     "wallet.expense_added",
+		Payload:       []byte(`{"WalletID":"wallet-1"}`),
+		Status:        mapper.OutboxStatusDeadLetter,
+		Attempts:      10,
+		LastError:     "webhook unavailable",
+		OccurredAt:    now,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
+
+func TestOutboxAdminController_RequiresAdministrator(t *testing.T) {
+	// Arrange
+	ctrl := newOutboxAdminController(test.NewFakeOutboxRepository())
+
+	// Act
+	w := httptest.NewRecorder()
+	ctrl.GetOutboxMessages(w, asUser(httptest.NewRequest("GET", "/api/v1/admin/outbox", nil), testUserID))
+
+	// Assert
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d for a non-admin, got %d", http.StatusForbidden, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	ctrl.GetOutboxMessages(w, httptest.NewRequest("GET", "/api/v1/admin/outbox", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d without authentication, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestOutboxAdminController_ListAndRetry(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeOutboxRepository()
+	repo.Add(deadLetterMessage("message-1"))
+	ctrl := newOutboxAdminController(repo)
+
+	// Act - list stuck messages
+	w := httptest.NewRecorder()
+	ctrl.GetOutboxMessages(w, asUser(httptest.NewRequest("GET", "/api/v1/admin/outbox", nil), testAdminID))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var listed struct {
+		Data []struct {
+			ID        string          `json:"id"`
+			Status    string          `json:"status"`
+			LastError string          `json:"last_error"`
+			Payload   json.RawMessage `json:"payload"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &listed)
+	if len(listed.Data) != 1 || listed.Data[0].Status != mapper.OutboxStatusDeadLetter || listed.Data[0].LastError == "" {
+		t.Fatalf("Expected the dead letter with its last error, got %s", w.Body.String())
+	}
+	if string(listed.Data[0].Payload) != `{"WalletID":"wallet-1"}` {
+		t.Errorf("Expected payload to be returned as JSON, got %s", listed.Data[0].Payload)
+	}
+
+	// Act - invalid status filter
+	w = httptest.NewRecorder()
+	ctrl.GetOutboxMessages(w, asUser(httptest.NewRequest("GET", "/api/v1/admin/outbox?status=lost", nil), testAdminID))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an invalid status, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	// Act - retry
+	w = httptest.NewRecorder()
+	ctrl.RetryOutboxMessage(w, asUser(httptest.NewRequest("POST", "/api/v1/admin/outbox/message-1/retry", nil), testAdminID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if stored, _ := repo.FindByID("message-1"); stored.Status != mapper.OutboxStatusPending {
+		t.Errorf("Expected message to be pending again, got %s", stored.Status)
+	}
+
+	// Act - retry an unknown message
+	w = httptest.NewRecorder()
+	ctrl.RetryOutboxMessage(w, asUser(httptest.NewRequest("POST", "/api/v1/admin/outbox/missing/retry", nil), testAdminID))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+)
+
+func newRecurringRuleController(ruleRepo repository.RecurringRuleRepository, walletRepo repository.WalletRepository, categoryRepo repository.ExpenseCategoryRepository) *controller.RecurringRuleController {
+	return controller.NewRecurringRuleController(
+		command.NewCreateRecurringRuleService(ruleRepo, walletRepo, categoryRepo, test.NewFakeIncomeCategoryRepository()),
+		command.NewPauseRecurringRuleService(ruleRepo),
+		command.NewResumeRecurringRuleService(ruleRepo),
+		command.NewSkipRecurringOccurrenceService(ruleRepo),
+		command.NewDeleteRecurringRuleService(ruleRepo),
+		query.NewGetRecurringRulesService(ruleRepo),
+		query.NewPreviewRecurringRuleService(ruleRepo),
+	)
+}
+
+func TestRecurringRuleController_CreateSkipAndPreview(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	wallet := test.CreateWallet(t, walletRepo, testUserID, "TWD", 100000)
+	_, rent := test.CreateExpenseSubcategory(t, categoryRepo, testUserID, "Housing", "Rent")
+	rules := newRecurringRuleController(test.NewFakeRecurringRuleRepository(), walletRepo, categoryRepo)
+
+	w := httptest.NewRecorder()
+	rules.CreateRecurringRule(w, jsonRequest("POST", "/api/v1/recurring-rules", map[string]interface{}{
+		"name":           "Rent",
+		"type":           "EXPENSE",
+		"wallet_id":      wallet.ID,
+		"subcategory_id": rent.ID,
+		"amount":         25000,
+		"currency":       "TWD",
+		"frequency":      "MONTHLY",
+		"day_of_month":   31,
+		"start_date":     "2024-01-01",
+	}))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &created)
+
+	// Act - skip February and preview the first quarter
+	w = httptest.NewRecorder()
+	rules.SkipRecurringOccurrence(w, jsonRequest("POST", "/api/v1/recurring-rules/"+created.Data.ID+"/skip", map[string]interface{}{
+		"date": "2024-02-29",
+	}))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/recurring-rules/"+created.Data.ID+"/preview?from=2024-01-01&limit=3", nil)
+	w = httptest.NewRecorder()
+	rules.PreviewRecurringRule(w, asUser(req, testUserID))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var preview struct {
+		Data struct {
+			Occurrences []struct {
+				Date   string `json:"date"`
+				Status string `json:"status"`
+			} `json:"occurrences"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &preview)
+
+	expected := []struct{ date, status string }{
+		{"2024-01-31", "SCHEDULED"},
+		{"2024-02-29", "SKIPPED"},
+		{"2024-03-31", "SCHEDULED"},
+	}
+	if len(preview.Data.Occurrences) != len(expected) {
+		t.Fatalf("Expected %d occurrences, got %d. Response: %s", len(expected), len(preview.Data.Occurrences), w.Body.String())
+	}
+	for i, occurrence := range preview.Data.Occurrences {
+		if occurrence.Date[:10] != expected[i].date || occurrence.Status != expected[i].status {
+			t.Errorf("Occurrence %d: expected %s %s, got %s %s", i, expected[i].date, expected[i].status, occurrence.Date, occurrence.Status)
+		}
+	}
+}
+
+func TestRecurringRuleController_CreateValidation(t *testing.T) {
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	wallet := test.CreateWallet(t, walletRepo, testUserID, "TWD", 100000)
+	_, rent := test.CreateExpenseSubcategory(t, categoryRepo, testUserID, "Housing", "Rent")
+	rules := newRecurringRuleController(test.NewFakeRecurringRuleRepository(), walletRepo, categoryRepo)
+
+	tests := []struct {
+		name   string
+		change func(body map[string]interface{})
+		status int
+	}{
+		{"missing frequency", func(body map[string]interface{}) { delete(body, "frequency") }, http.StatusBadRequest},
+		{"unknown frequency", func(body map[string]interface{}) { body["frequency"] = "HOURLY" }, http.StatusBadRequest},
+		{"end date and count", func(body map[string]interface{}) { body["end_date"] = "2024-12-31"; body["count"] = 3 }, http.StatusBadRequest},
+		{"day of month on a weekly rule", func(body map[string]interface{}) { body["frequency"] = "WEEKLY" }, http.StatusBadRequest},
+		{"unknown wallet", func(body map[string]interface{}) { body["wallet_id"] = "missing" }, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := map[string]interface{}{
+				"name":           "Rent",
+				"type":           "EXPENSE",
+				"wallet_id":      wallet.ID,
+				"subcategory_id": rent.ID,
+				"amount":         25000,
+				"currency":       "TWD",
+				"frequency":      "MONTHLY",
+				"day_of_month":   31,
+				"start_date":     "2024-01-01",
+			}
+			tt.change(body)
+			w := httptest.NewRecorder()
+			rules.CreateRecurringRule(w, jsonRequest("POST", "/api/v1/recurring-rules", body))
+			if w.Code != tt.status {
+				t.Errorf("Expected status %d, got %d. Response: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRecurringRuleController_PauseTwiceIsRejected(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	wallet := test.CreateWallet(t, walletRepo, testUserID, "TWD", 100000)
+	_, rent := test.CreateExpenseSubcategory(t, categoryRepo, testUserID, "Housing", "Rent")
+	rules := newRecurringRuleController(test.NewFakeRecurringRuleRepository(), walletRepo, categoryRepo)
+	w := httptest.NewRecorder()
+	rules.CreateRecurringRule(w, jsonRequest("POST", "/api/v1/recurring-rules", map[string]interface{}{
+		"name":           "Rent",
+		"type":           "EXPENSE",
+		"wallet_id":      wallet.ID,
+		"subcategory_id": rent.ID,
+		"amount":         25000,
+		"currency":       "TWD",
+		"frequency":      "MONTHLY",
+		"start_date":     "2024-01-01",
+	}))
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &created)
+
+	// Act & Assert - the second pause is rejected
+	for i, status := range []int{http.StatusOK, http.StatusBadRequest} {
+		req := httptest.NewRequest("POST", "/api/v1/recurring-rules/"+created.Data.ID+"/pause", nil)
+		w = httptest.NewRecorder()
+		rules.PauseRecurringRule(w, asUser(req, testUserID))
+		if w.Code != status {
+			t.Errorf("Pause %d: expected status %d, got %d. Response: %s", i+1, status, w.Code, w.Body.String())
+		}
+	}
+
+	// Another user cannot see the rule
+	req := httptest.NewRequest("POST", "/api/v1/recurring-rules/"+created.Data.ID+"/pause", nil)
+	w = httptest.NewRecorder()
+	rules.PauseRecurringRule(w, asUser(req, "user-456"))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for another user, got %d", http.StatusNotFound, w.Code)
+	}
+}
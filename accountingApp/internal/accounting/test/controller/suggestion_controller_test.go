@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/suggestion"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+)
+
+func TestSuggestionController_GetCategorySuggestions(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	name, _ := model.NewCategoryName("Transport")
+	category, _ := model.NewExpenseCategory(testUserID, *name)
+	subcategory, _ := category.AddSubcategory(*name)
+	expenseCategoryRepo.Save(category)
+
+	wallet, _ := model.NewWalletWithInitialBalance(testUserID, "Cash", model.WalletTypeCash, "USD", 100000)
+	amount, _ := model.NewMoney(1850, "USD")
+	wallet.AddExpense(*amount, subcategory.ID, "Uber trip", time.Now())
+	walletRepo.Save(wallet)
+
+	suggestions := controller.NewSuggestionController(query.NewGetCategorySuggestionsService(
+		suggestion.NewSuggester(walletRepo, suggestion.DefaultSuggesterConfig()), expenseCategoryRepo, test.NewFakeIncomeCategoryRepository()))
+
+	// Act
+	w := httptest.NewRecorder()
+	suggestions.GetCategorySuggestions(w, asUser(httptest.NewRequest("GET", "/api/v1/suggestions/category?description=UBER+*TRIP", nil), testUserID))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response struct {
+		Data []struct {
+			SubcategoryID string  `json:"subcategory_id"`
+			Confidence    float64 `json:"confidence"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if len(response.Data) != 1 || response.Data[0].SubcategoryID != subcategory.ID || response.Data[0].Confidence != 1 {
+		t.Errorf("Expected the transport subcategory, got %s", w.Body.String())
+	}
+
+	for path, expected := range map[string]int{
+		"/api/v1/suggestions/category":                          http.StatusBadRequest,
+		"/api/v1/suggestions/category?description=Uber&limit=0": http.StatusBadRequest,
+		"/api/v1/suggestions/category?description=Uber&type=x":  http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		suggestions.GetCategorySuggestions(w, asUser(httptest.NewRequest("GET", path, nil), testUserID))
+		if w.Code != expected {
+			t.Errorf("%s: expected status %d, got %d", path, expected, w.Code)
+		}
+	}
+}
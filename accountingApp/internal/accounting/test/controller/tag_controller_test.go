@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/controller"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+)
+
+func TestTagController_BulkEditThenFilterAndSummarize(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	wallet, _ := model.NewWalletWithInitialBalance(testUserID, "Cash", model.WalletTypeCash, "USD", 100000)
+	walletRepo.Save(wallet)
+	addExpense := controller.NewAddExpenseController(command.NewAddExpenseService(walletRepo, nil, nil, nil))
+	tags := controller.NewTagController(
+		command.NewEditTransactionTagsService(test.NewFakeUnitOfWork(walletRepo)),
+		query.NewGetTagSummaryService(walletRepo, nil),
+	)
+	expenses := controller.NewQueryExpenseController(query.NewGetExpensesService(walletRepo))
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"wallet_id": wallet.ID, "subcategory_id": "food", "amount": 1500, "currency": "USD",
+		"date": "2026-03-10T12:00:00Z", "tags": []string{"Food"},
+	})
+	w := httptest.NewRecorder()
+	addExpense.AddExpense(w, asUser(httptest.NewRequest("POST", "/api/v1/expenses", bytes.NewBuffer(payload)), testUserID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &created)
+
+	// Act - tag the expense, then query by tag and summarize
+	payload, _ = json.Marshal(map[string]interface{}{
+		"expense_ids": []string{created.ID}, "add": []string{"trip"},
+	})
+	w = httptest.NewRecorder()
+	tags.EditTransactionTags(w, asUser(httptest.NewRequest("POST", "/api/v1/tags/bulk", bytes.NewBuffer(payload)), testUserID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	expenses.GetExpenses(w, asUser(httptest.NewRequest("GET", "/api/v1/expenses?tags=trip,food&tagMatch=all", nil), testUserID))
+	var listed struct {
+		Count int `json:"count"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &listed)
+
+	w = httptest.NewRecorder()
+	tags.GetTagSummary(w, asUser(httptest.NewRequest("GET", "/api/v1/tags/summary?startDate=2026-03-01&endDate=2026-03-10", nil), testUserID))
+	var summary struct {
+		Data []struct {
+			Tag      string `json:"tag"`
+			Currency string `json:"currency"`
+			Expenses int64  `json:"expenses"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &summary)
+
+	// Assert
+	if listed.Count != 1 {
+		t.Errorf("Expected the expense to match both tags, got count %d", listed.Count)
+	}
+	if w.Code != http.StatusOK || len(summary.Data) != 2 {
+		t.Fatalf("Expected 2 summary rows, got status %d. Response: %s", w.Code, w.Body.String())
+	}
+	if summary.Data[1].Tag != "trip" || summary.Data[1].Currency != "USD" || summary.Data[1].Expenses != 1500 {
+		t.Errorf("Unexpected summary row: %+v", summary.Data[1])
+	}
+}
+
+func TestTagController_StatusCodes(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	tags := controller.NewTagController(
+		command.NewEditTransactionTagsService(test.NewFakeUnitOfWork(walletRepo)),
+		query.NewGetTagSummaryService(walletRepo, nil),
+	)
+	expenses := controller.NewQueryExpenseController(query.NewGetExpensesService(walletRepo))
+
+	cases := []struct {
+		name     string
+		serve    func(w http.ResponseWriter, r *http.Request)
+		method   string
+		path     string
+		body     string
+		expected int
+	}{
+		{"unknown expense", tags.EditTransactionTags, "POST", "/api/v1/tags/bulk", `{"expense_ids":["missing"],"add":["trip"]}`, http.StatusNotFound},
+		{"invalid tag", tags.EditTransactionTags, "POST", "/api/v1/tags/bulk", `{"expense_ids":["missing"],"add":["two words"]}`, http.StatusBadRequest},
+		{"invalid summary date", tags.GetTagSummary, "GET", "/api/v1/tags/summary?startDate=March", "", http.StatusBadRequest},
+		{"invalid tag match", expenses.GetExpenses, "GET", "/api/v1/expenses?tags=trip&tagMatch=some", "", http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			w := httptest.NewRecorder()
+			tc.serve(w, asUser(httptest.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.body)), testUserID))
+
+			// Assert
+			if w.Code != tc.expected {
+				t.Errorf("Expected status %d, got %d. Response: %s", tc.expected, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -21,7 +21,7 @@ func TestUpdateWalletController_UpdateWallet_Success_Name(t *testing.T) {
 	ctrl := controller.NewUpdateWalletController(updateService)
 
 	// Create a wallet first
-	createService := command.NewCreateWalletService(repo)
+	createService := command.NewCreateWalletService(repo, nil)
 	createResult := createService.Execute(usecase.CreateWalletInput{
 		UserID:   "test-user",
 		Name:     "Original Wallet",
@@ -37,6 +37,7 @@ func TestUpdateWalletController_UpdateWallet_Success_Name(t *testing.T) {
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("PUT", "/api/v1/wallets/"+walletID, bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -69,7 +70,7 @@ func TestUpdateWalletController_UpdateWallet_Success_Type(t *testing.T) {
 	ctrl := controller.NewUpdateWalletController(updateService)
 
 	// Create a wallet first
-	createService := command.NewCreateWalletService(repo)
+	createService := command.NewCreateWalletService(repo, nil)
 	createResult := createService.Execute(usecase.CreateWalletInput{
 		UserID:   "test-user",
 		Name:     "Test Wallet",
@@ -85,6 +86,7 @@ func TestUpdateWalletController_UpdateWallet_Success_Type(t *testing.T) {
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("PUT", "/api/v1/wallets/"+walletID, bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -110,7 +112,7 @@ func TestUpdateWalletController_UpdateWallet_Success_Multiple_Fields(t *testing.
 	ctrl := controller.NewUpdateWalletController(updateService)
 
 	// Create a wallet first
-	createService := command.NewCreateWalletService(repo)
+	createService := command.NewCreateWalletService(repo, nil)
 	createResult := createService.Execute(usecase.CreateWalletInput{
 		UserID:   "test-user",
 		Name:     "Original Wallet",
@@ -127,6 +129,7 @@ func TestUpdateWalletController_UpdateWallet_Success_Multiple_Fields(t *testing.
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("PUT", "/api/v1/wallets/"+walletID, bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -161,6 +164,7 @@ func TestUpdateWalletController_UpdateWallet_NotFound(t *testing.T) {
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("PUT", "/api/v1/wallets/"+nonExistentID, bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -180,7 +184,7 @@ func TestUpdateWalletController_UpdateWallet_InvalidWalletType(t *testing.T) {
 	ctrl := controller.NewUpdateWalletController(updateService)
 
 	// Create a wallet first
-	createService := command.NewCreateWalletService(repo)
+	createService := command.NewCreateWalletService(repo, nil)
 	createResult := createService.Execute(usecase.CreateWalletInput{
 		UserID:   "test-user",
 		Name:     "Test Wallet",
@@ -196,6 +200,7 @@ func TestUpdateWalletController_UpdateWallet_InvalidWalletType(t *testing.T) {
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("PUT", "/api/v1/wallets/"+walletID, bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -222,7 +227,7 @@ func TestUpdateWalletController_UpdateWallet_EmptyName(t *testing.T) {
 	ctrl := controller.NewUpdateWalletController(updateService)
 
 	// Create a wallet first
-	createService := command.NewCreateWalletService(repo)
+	createService := command.NewCreateWalletService(repo, nil)
 	createResult := createService.Execute(usecase.CreateWalletInput{
 		UserID:   "test-user",
 		Name:     "Original Name",
@@ -238,6 +243,7 @@ func TestUpdateWalletController_UpdateWallet_EmptyName(t *testing.T) {
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("PUT", "/api/v1/wallets/"+walletID, bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -262,6 +268,7 @@ func TestUpdateWalletController_UpdateWallet_InvalidWalletID(t *testing.T) {
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("PUT", "/api/v1/wallets/", bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -281,7 +288,7 @@ func TestUpdateWalletController_UpdateWallet_InvalidJSON(t *testing.T) {
 	ctrl := controller.NewUpdateWalletController(updateService)
 
 	// Create a wallet first
-	createService := command.NewCreateWalletService(repo)
+	createService := command.NewCreateWalletService(repo, nil)
 	createResult := createService.Execute(usecase.CreateWalletInput{
 		UserID:   "test-user",
 		Name:     "Test Wallet",
@@ -292,6 +299,7 @@ func TestUpdateWalletController_UpdateWallet_InvalidJSON(t *testing.T) {
 	walletID := createResult.GetID()
 
 	req := httptest.NewRequest("PUT", "/api/v1/wallets/"+walletID, bytes.NewBufferString("{invalid-json"))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -311,6 +319,7 @@ func TestUpdateWalletController_UpdateWallet_MethodNotAllowed(t *testing.T) {
 	ctrl := controller.NewUpdateWalletController(updateService)
 
 	req := httptest.NewRequest("GET", "/api/v1/wallets/some-id", nil)
+	req = asUser(req, testUserID)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -329,7 +338,7 @@ func TestUpdateWalletController_UpdateWallet_NoChanges(t *testing.T) {
 	ctrl := controller.NewUpdateWalletController(updateService)
 
 	// Create a wallet first
-	createService := command.NewCreateWalletService(repo)
+	createService := command.NewCreateWalletService(repo, nil)
 	createResult := createService.Execute(usecase.CreateWalletInput{
 		UserID:   "test-user",
 		Name:     "Test Wallet",
@@ -344,6 +353,7 @@ func TestUpdateWalletController_UpdateWallet_NoChanges(t *testing.T) {
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest("PUT", "/api/v1/wallets/"+walletID, bytes.NewBuffer(jsonBody))
+	req = asUser(req, testUserID)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
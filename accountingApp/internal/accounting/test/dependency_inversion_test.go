@@ -69,8 +69,8 @@ func TestInterfaceImplementation(t *testing.T) {
 	var createIncomeCategoryUseCase usecase.CreateIncomeCategoryUseCase
 
 	// These assignments will fail to compile if interfaces are not implemented
-	createWalletUseCase = command.NewCreateWalletService(nil)
-	addExpenseUseCase = command.NewAddExpenseService(nil, nil)
+	createWalletUseCase = command.NewCreateWalletService(nil, nil)
+	addExpenseUseCase = command.NewAddExpenseService(nil, nil, nil, nil)
 	addIncomeUseCase = command.NewAddIncomeService(nil, nil)
 	// getWalletBalanceUseCase = query.NewGetWalletBalanceService(nil) // Would need import
 	createExpenseCategoryUseCase = command.NewCreateExpenseCategoryService(nil)
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectAttachmentContentType_RecognizesAllowedFormats(t *testing.T) {
+	cases := map[string]string{
+		"\xFF\xD8\xFF\xE0\x00\x10JFIF":          "image/jpeg",
+		"\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR": "image/png",
+		"RIFF\x24\x00\x00\x00WEBPVP8 ":          "image/webp",
+		"\x00\x00\x00\x18ftypheic\x00\x00\x00":  "image/heic",
+		"%PDF-1.7\n":                            "application/pdf",
+		"<html><body>":                          "application/octet-stream",
+		"":                                      "application/octet-stream",
+	}
+
+	for head, expected := range cases {
+		assert.Equal(t, expected, model.DetectAttachmentContentType([]byte(head)), "head %q", head)
+	}
+}
+
+func TestNewAttachment_EnforcesLimitsAndStripsPath(t *testing.T) {
+	attachment, err := model.NewAttachment("user-123", "wallet-1", model.AttachmentExpense, "expense-1",
+		`C:\Users\me\..\receipt.pdf`, "application/pdf", 2048, strings.Repeat("a", 64))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "receipt.pdf", attachment.FileName)
+	assert.Equal(t, model.AttachmentStorageKey(attachment.ID), attachment.StorageKey)
+	assert.NotContains(t, attachment.StorageKey, "user-123")
+
+	_, err = model.NewAttachment("user-123", "wallet-1", model.AttachmentIncome, "income-1",
+		"notes.txt", "text/plain", 10, "abc")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported content type")
+
+	_, err = model.NewAttachment("user-123", "wallet-1", model.AttachmentIncome, "income-1",
+		"scan.png", "image/png", model.MaxAttachmentSize+1, "abc")
+	assert.Error(t, err)
+
+	_, err = model.NewAttachment("user-123", "wallet-1", "TRANSFER", "transfer-1",
+		"scan.png", "image/png", 10, "abc")
+	assert.Error(t, err)
+}
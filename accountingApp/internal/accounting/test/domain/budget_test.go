@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newTestBudget(t *testing.T, period model.BudgetPeriod, amount int64, start time.Time, end *time.Time) *model.Budget {
+	money, _ := model.NewMoney(amount, "TWD")
+	budget, err := model.NewBudget("user-123", "Food", period, model.BudgetScope{SubcategoryID: "food-123"}, *money, start, end)
+	assert.NoError(t, err)
+	return budget
+}
+
+func expenseOn(amount int64, currency string, day time.Time) model.ExpenseRecord {
+	return model.ExpenseRecord{SubcategoryID: "food-123", Amount: model.Money{Amount: amount, Currency: currency}, Date: day}
+}
+
+func TestNewBudget_RequiresExactlyOneScope(t *testing.T) {
+	money, _ := model.NewMoney(1000, "TWD")
+
+	_, err := model.NewBudget("user-123", "Food", model.BudgetPeriodMonthly, model.BudgetScope{}, *money, date(2024, 1, 1), nil)
+	assert.Error(t, err)
+
+	_, err = model.NewBudget("user-123", "Food", model.BudgetPeriodMonthly,
+		model.BudgetScope{CategoryID: "cat", SubcategoryID: "sub"}, *money, date(2024, 1, 1), nil)
+	assert.Error(t, err)
+}
+
+func TestNewBudget_CustomRequiresEndDate(t *testing.T) {
+	money, _ := model.NewMoney(1000, "TWD")
+
+	_, err := model.NewBudget("user-123", "Trip", model.BudgetPeriodCustom, model.BudgetScope{CategoryID: "cat"}, *money, date(2024, 1, 1), nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "end date")
+}
+
+func TestBudget_SetWarningThreshold_Validates(t *testing.T) {
+	budget := newTestBudget(t, model.BudgetPeriodMonthly, 1000, date(2024, 1, 1), nil)
+
+	assert.Equal(t, model.DefaultBudgetWarningThreshold, budget.WarningThreshold)
+	assert.Error(t, budget.SetWarningThreshold(0))
+	assert.Error(t, budget.SetWarningThreshold(101))
+	assert.NoError(t, budget.SetWarningThreshold(90))
+	assert.Equal(t, 90, budget.WarningThreshold)
+}
+
+func TestBudget_WindowAt_MonthlyWeeklyAndCustom(t *testing.T) {
+	monthly := newTestBudget(t, model.BudgetPeriodMonthly, 1000, date(2024, 1, 15), nil)
+	window, ok := monthly.WindowAt(date(2024, 3, 10))
+	assert.True(t, ok)
+	assert.Equal(t, date(2024, 3, 1), window.Start)
+	assert.Equal(t, date(2024, 4, 1), window.End)
+
+	// 第一期從開始日起算
+	window, _ = monthly.WindowAt(date(2024, 1, 20))
+	assert.Equal(t, date(2024, 1, 15), window.Start)
+	_, ok = monthly.WindowAt(date(2024, 1, 14))
+	assert.False(t, ok)
+
+	// 2024-03-13 是週三，該週從週一 03-11 開始
+	weekly := newTestBudget(t, model.BudgetPeriodWeekly, 1000, date(2024, 1, 1), nil)
+	window, _ = weekly.WindowAt(time.Date(2024, 3, 13, 18, 30, 0, 0, time.UTC))
+	assert.Equal(t, date(2024, 3, 11), window.Start)
+	assert.Equal(t, date(2024, 3, 18), window.End)
+
+	end := date(2024, 2, 10)
+	custom := newTestBudget(t, model.BudgetPeriodCustom, 1000, date(2024, 2, 1), &end)
+	window, ok = custom.WindowAt(date(2024, 2, 5))
+	assert.True(t, ok)
+	assert.Equal(t, model.BudgetWindow{Start: date(2024, 2, 1), End: end}, window)
+	_, ok = custom.WindowAt(end)
+	assert.False(t, ok)
+}
+
+func TestBudget_StatusAt_CountsOnlyCurrentPeriodAndCurrency(t *testing.T) {
+	budget := newTestBudget(t, model.BudgetPeriodMonthly, 1000, date(2024, 1, 1), nil)
+	expenses := []model.ExpenseRecord{
+		expenseOn(300, "TWD", date(2024, 2, 3)),
+		expenseOn(500, "TWD", date(2024, 2, 28)),
+		expenseOn(900, "TWD", date(2024, 1, 31)), // 上一期
+		expenseOn(900, "USD", date(2024, 2, 10)), // 其他幣別
+	}
+
+	status, ok := budget.StatusAt(date(2024, 2, 15), expenses)
+
+	assert.True(t, ok)
+	assert.Equal(t, int64(800), status.Spent)
+	assert.Equal(t, int64(200), status.Remaining)
+	assert.Equal(t, int64(0), status.Carryover)
+	assert.InDelta(t, 80.0, status.PercentUsed(), 0.001)
+	assert.True(t, status.ReachedThreshold(budget.WarningThreshold))
+	assert.False(t, status.IsOverBudget())
+}
+
+func TestBudget_StatusAt_RolloverCarriesUnusedAmount(t *testing.T) {
+	budget := newTestBudget(t, model.BudgetPeriodMonthly, 1000, date(2024, 1, 1), nil)
+	budget.SetRollover(true)
+	expenses := []model.ExpenseRecord{
+		expenseOn(400, "TWD", date(2024, 1, 10)),  // 1月剩600
+		expenseOn(1800, "TWD", date(2024, 2, 10)), // 2月可用1600，超支不扣減3月
+		expenseOn(100, "TWD", date(2024, 3, 10)),
+	}
+
+	february, _ := budget.StatusAt(date(2024, 2, 1), expenses)
+	assert.Equal(t, int64(600), february.Carryover)
+	assert.Equal(t, int64(1600), february.Available)
+	assert.True(t, february.IsOverBudget())
+
+	march, _ := budget.StatusAt(date(2024, 3, 31), expenses)
+	assert.Equal(t, int64(0), march.Carryover)
+	assert.Equal(t, int64(900), march.Remaining)
+}
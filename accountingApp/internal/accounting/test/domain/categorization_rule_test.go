@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestNewCategorizationRule_Validation(t *testing.T) {
+	_, err := model.NewCategorizationRule("user-123", "Empty", model.CategorizeExpense, 0, model.RuleConditions{}, "sub-1", nil)
+	assert.Error(t, err, "a rule needs at least one condition")
+
+	_, err = model.NewCategorizationRule("user-123", "Bad pattern", model.CategorizeExpense, 0, model.RuleConditions{DescriptionPattern: "uber("}, "sub-1", nil)
+	assert.Error(t, err)
+
+	_, err = model.NewCategorizationRule("user-123", "No currency", model.CategorizeExpense, 0, model.RuleConditions{MinAmount: int64Ptr(100)}, "sub-1", nil)
+	assert.Error(t, err)
+
+	_, err = model.NewCategorizationRule("user-123", "Inverted", model.CategorizeExpense, 0,
+		model.RuleConditions{MinAmount: int64Ptr(500), MaxAmount: int64Ptr(100), Currency: "USD"}, "sub-1", nil)
+	assert.Error(t, err)
+
+	_, err = model.NewCategorizationRule("user-123", "Bad tag", model.CategorizeExpense, 0, model.RuleConditions{DescriptionPattern: "uber"}, "sub-1", []string{"two words"})
+	assert.Error(t, err)
+
+	rule, err := model.NewCategorizationRule("user-123", " Rides ", model.CategorizeExpense, 0,
+		model.RuleConditions{DescriptionPattern: "uber|lyft", Weekdays: []time.Weekday{time.Friday, time.Monday, time.Friday}}, "sub-1", []string{"Travel"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Rides", rule.Name)
+	assert.Equal(t, []time.Weekday{time.Monday, time.Friday}, rule.Conditions.Weekdays)
+	assert.Equal(t, []string{"travel"}, rule.Tags)
+}
+
+func TestCategorizationRule_MatchesAllConditions(t *testing.T) {
+	rule, _ := model.NewCategorizationRule("user-123", "Weekday coffee", model.CategorizeExpense, 0, model.RuleConditions{
+		DescriptionPattern: `^starbucks\b`,
+		MinAmount:          int64Ptr(100),
+		MaxAmount:          int64Ptr(1000),
+		Currency:           "USD",
+		WalletID:           "wallet-1",
+		Weekdays:           []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	}, "coffee", nil)
+	monday := time.Date(2026, 3, 9, 8, 0, 0, 0, time.UTC)
+	sample := model.RuleSample{
+		Type:        model.CategorizeExpense,
+		WalletID:    "wallet-1",
+		Description: "STARBUCKS #1234",
+		Amount:      model.Money{Amount: 550, Currency: "USD"},
+		Date:        monday,
+	}
+
+	assert.True(t, rule.Matches(sample), "the pattern ignores case")
+
+	for name, change := range map[string]func(s *model.RuleSample){
+		"income":         func(s *model.RuleSample) { s.Type = model.CategorizeIncome },
+		"other wallet":   func(s *model.RuleSample) { s.WalletID = "wallet-2" },
+		"description":    func(s *model.RuleSample) { s.Description = "Paid at Starbucks" },
+		"above maximum":  func(s *model.RuleSample) { s.Amount.Amount = 1001 },
+		"other currency": func(s *model.RuleSample) { s.Amount.Currency = "EUR" },
+		"on the weekend": func(s *model.RuleSample) { s.Date = monday.AddDate(0, 0, -1) },
+	} {
+		changed := sample
+		change(&changed)
+		assert.False(t, rule.Matches(changed), name)
+	}
+}
+
+func TestCategorizationRuleSet_OrdersByPriorityThenAge(t *testing.T) {
+	conditions := model.RuleConditions{DescriptionPattern: "uber"}
+	general, _ := model.NewCategorizationRule("user-123", "Rides", model.CategorizeExpense, 10, conditions, "transport", nil)
+	older, _ := model.NewCategorizationRule("user-123", "Uber Eats", model.CategorizeExpense, 1, model.RuleConditions{DescriptionPattern: "uber\\s*eats"}, "food", nil)
+	newer, _ := model.NewCategorizationRule("user-123", "Eats again", model.CategorizeExpense, 1, conditions, "dining", nil)
+	newer.CreatedAt = older.CreatedAt.Add(time.Second)
+
+	set := model.NewCategorizationRuleSet([]*model.CategorizationRule{general, newer, older})
+	sample := model.RuleSample{Type: model.CategorizeExpense, Description: "UBER EATS order", Amount: model.Money{Amount: 1200, Currency: "USD"}}
+
+	assert.Equal(t, older.ID, set.Match(sample).ID)
+	matching := set.MatchAll(sample)
+	if assert.Len(t, matching, 3) {
+		assert.Equal(t, []string{older.ID, newer.ID, general.ID}, []string{matching[0].ID, matching[1].ID, matching[2].ID})
+	}
+
+	sample.Description = "Uber trip"
+	assert.Equal(t, newer.ID, set.Match(sample).ID)
+	sample.Description = "Taxi"
+	assert.Nil(t, set.Match(sample))
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrencyRegistry_MinorUnits(t *testing.T) {
+	for code, expected := range map[string]int64{
+		"USD": 100,
+		"EUR": 100,
+		"JPY": 1,
+		"KWD": 1000,
+		"CLF": 10000,
+		"TWD": 1, // ISO 4217 says 2 decimals; stored in whole dollars
+	} {
+		subdivision, err := model.GetCurrencySubdivision(code)
+		assert.NoError(t, err, code)
+		assert.Equal(t, expected, subdivision, code)
+	}
+	_, err := model.GetCurrencySubdivision("UDS")
+	assert.Error(t, err, "unknown codes have no subdivision")
+
+	kwd, exists := model.LookupCurrency("KWD")
+	assert.True(t, exists)
+	assert.Equal(t, model.Currency{Code: "KWD", Number: "414", MinorUnits: 3, Name: "Kuwaiti Dinar", Symbol: "KWD"}, kwd)
+
+	currencies := model.Currencies()
+	assert.Greater(t, len(currencies), 150)
+	for i := 1; i < len(currencies); i++ {
+		assert.Less(t, currencies[i-1].Code, currencies[i].Code)
+	}
+}
+
+func TestNewMoney_RejectsUnknownCurrencyCodes(t *testing.T) {
+	money, err := model.NewMoney(100, "UDS")
+
+	assert.Nil(t, money)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "unknown currency code: UDS")
+	}
+
+	_, err = model.NormalizeCurrencyCode("uds")
+	assert.Error(t, err)
+	_, err = model.NewWallet("user-123", "Typo", model.WalletTypeCash, "UDS")
+	assert.Error(t, err)
+}
+
+func TestMoney_FormatFollowsTheLocale(t *testing.T) {
+	usd := model.Money{Amount: 123456, Currency: "USD"}
+	eur := model.Money{Amount: 123456, Currency: "EUR"}
+
+	for _, tc := range []struct {
+		money    model.Money
+		locale   string
+		expected string
+	}{
+		{usd, "en", "$1,234.56"},
+		{usd, "", "$1,234.56"},
+		{usd, "xx-YY", "$1,234.56"},
+		{eur, "de-DE", "1.234,56\u00a0€"},
+		{eur, "de_AT", "1.234,56\u00a0€"}, // same language
+		{eur, "fr-FR", "1\u202f234,56\u00a0€"},
+		{model.Money{Amount: 123456, Currency: "BRL"}, "pt-BR", "R$\u00a01.234,56"},
+		{model.Money{Amount: 1234567, Currency: "TWD"}, "zh-TW", "$1,234,567"},
+		{model.Money{Amount: 1234567, Currency: "TWD"}, "en", "NT$1,234,567"},
+		{model.Money{Amount: 1234, Currency: "JPY"}, "ja-JP", "￥1,234"},
+		{model.Money{Amount: 1234567, Currency: "KWD"}, "en", "KWD\u00a01,234.567"},
+		{model.Money{Amount: 5, Currency: "USD"}, "en", "$0.05"},
+		{model.Money{Amount: -123456, Currency: "USD"}, "en-US", "-$1,234.56"},
+	} {
+		assert.Equal(t, tc.expected, tc.money.Format(tc.locale), "%v in %q", tc.money, tc.locale)
+	}
+
+	assert.Equal(t, "$1,234.56", usd.String())
+	assert.Contains(t, model.SupportedMoneyLocales(), "zh-TW")
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eventNames(events []model.DomainEvent) []string {
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = event.EventName()
+	}
+	return names
+}
+
+func TestWallet_RecordsDomainEvents(t *testing.T) {
+	wallet, _ := model.NewWalletWithInitialBalance("user-123", "My Wallet", model.WalletTypeCash, "USD", 10000)
+	amount, _ := model.NewMoney(2500, "USD")
+	changed, _ := model.NewMoney(3000, "USD")
+
+	expense, err := wallet.AddExpense(*amount, "food", "Lunch", time.Now())
+	require.NoError(t, err)
+	_, err = wallet.UpdateExpense(expense.ID, *changed, "food", "Dinner", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, wallet.RemoveExpense(expense.ID))
+	require.NoError(t, wallet.UpdateName("Daily Wallet"))
+
+	events := wallet.DomainEvents()
+	assert.Equal(t, []string{
+		model.EventWalletCreated,
+		model.EventExpenseAdded,
+		model.EventExpenseUpdated,
+		model.EventExpenseRemoved,
+		model.EventWalletRenamed,
+	}, eventNames(events))
+	for _, event := range events {
+		assert.Equal(t, wallet.ID, event.AggregateID())
+		assert.False(t, event.OccurredAt().IsZero())
+	}
+
+	created := events[0].(model.WalletCreated)
+	assert.Equal(t, "user-123", created.UserID)
+	assert.Equal(t, int64(10000), created.InitialBalance.Amount)
+
+	updated := events[2].(model.ExpenseUpdated)
+	assert.Equal(t, int64(2500), updated.Previous.Amount.Amount)
+	assert.Equal(t, int64(3000), updated.Expense.Amount.Amount)
+
+	renamed := events[4].(model.WalletRenamed)
+	assert.Equal(t, "My Wallet", renamed.OldName)
+	assert.Equal(t, "Daily Wallet", renamed.NewName)
+
+	wallet.ClearDomainEvents()
+	assert.Empty(t, wallet.DomainEvents())
+}
+
+func TestWallet_FailedOperationRecordsNoEvent(t *testing.T) {
+	wallet, _ := model.NewWallet("user-123", "My Wallet", model.WalletTypeCash, "USD")
+	wallet.ClearDomainEvents()
+	amount, _ := model.NewMoney(100, "USD")
+
+	_, err := wallet.AddExpense(*amount, "food", "Lunch", time.Now())
+	assert.Error(t, err)
+	assert.NoError(t, wallet.UpdateName("My Wallet"))
+
+	assert.Empty(t, wallet.DomainEvents())
+}
+
+func TestWallet_TransferRecordsEventsOnBothWallets(t *testing.T) {
+	from, _ := model.NewWalletWithInitialBalance("user-123", "Bank", model.WalletTypeBank, "USD", 10000)
+	to, _ := model.NewWallet("user-123", "Cash", model.WalletTypeCash, "USD")
+	from.ClearDomainEvents()
+	to.ClearDomainEvents()
+	amount, _ := model.NewMoney(3000, "USD")
+	fee, _ := model.NewMoney(0, "USD")
+
+	require.NoError(t, from.ProcessOutgoingTransfer(*amount, *fee))
+	transfer, err := from.CreateTransfer(to.ID, *amount, *fee, "Top up", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, to.ReceiveTransfer(*transfer))
+
+	assert.Equal(t, []string{model.EventTransferSent}, eventNames(from.DomainEvents()))
+	assert.Equal(t, []string{model.EventTransferReceived}, eventNames(to.DomainEvents()))
+	assert.Equal(t, to.ID, to.DomainEvents()[0].AggregateID())
+}
+
+func TestWallet_ReconstitutedFromDataRecordsNoEvents(t *testing.T) {
+	wallet, _ := model.NewWallet("user-123", "My Wallet", model.WalletTypeCash, "USD")
+	data := mapper.NewWalletMapper().ToData(wallet)
+
+	loaded, err := mapper.NewWalletMapper().ToDomain(data)
+
+	require.NoError(t, err)
+	assert.Empty(t, loaded.DomainEvents())
+}
+
+func TestCategory_RecordsDomainEvents(t *testing.T) {
+	name, _ := model.NewCategoryName("Food")
+	category, _ := model.NewExpenseCategory("user-123", *name)
+	lunch, _ := model.NewCategoryName("Lunch")
+	brunch, _ := model.NewCategoryName("Brunch")
+	meals, _ := model.NewCategoryName("Meals")
+
+	subcategory, err := category.AddSubcategory(*lunch)
+	require.NoError(t, err)
+	require.NoError(t, category.UpdateSubcategoryName(subcategory.ID, *brunch))
+	require.NoError(t, category.RemoveSubcategory(subcategory.ID))
+	category.Rename(*meals)
+
+	events := category.DomainEvents()
+	assert.Equal(t, []string{
+		model.EventCategoryCreated,
+		model.EventSubcategoryAdded,
+		model.EventSubcategoryRenamed,
+		model.EventSubcategoryRemoved,
+		model.EventCategoryRenamed,
+	}, eventNames(events))
+
+	added := events[1].(model.SubcategoryAdded)
+	assert.Equal(t, category.ID, added.AggregateID())
+	assert.Equal(t, model.CategoryKindExpense, added.Kind)
+	assert.Equal(t, subcategory.ID, added.SubcategoryID)
+
+	renamed := events[2].(model.SubcategoryRenamed)
+	assert.Equal(t, "Lunch", renamed.OldName)
+	assert.Equal(t, "Brunch", renamed.NewName)
+
+	incomeName, _ := model.NewCategoryName("Salary")
+	income, _ := model.NewIncomeCategory("user-123", *incomeName)
+	require.Len(t, income.DomainEvents(), 1)
+	assert.Equal(t, model.CategoryKindIncome, income.DomainEvents()[0].(model.CategoryCreated).Kind)
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDuplicateFlag_Validation(t *testing.T) {
+	_, err := model.NewDuplicateFlag("user-123", "wallet-1", model.DuplicateExpense, "exp-1", "exp-1", 0.9)
+	assert.Error(t, err, "a record cannot duplicate itself")
+
+	_, err = model.NewDuplicateFlag("user-123", "wallet-1", "TRANSFER", "exp-2", "exp-1", 0.9)
+	assert.Error(t, err)
+
+	_, err = model.NewDuplicateFlag("user-123", "wallet-1", model.DuplicateExpense, "exp-2", "exp-1", 1.5)
+	assert.Error(t, err)
+
+	flag, err := model.NewDuplicateFlag("user-123", "wallet-1", model.DuplicateExpense, "exp-2", "exp-1", 0.9)
+	assert.NoError(t, err)
+	assert.True(t, flag.IsPending())
+}
+
+func TestDuplicateFlag_ResolveOnlyOnce(t *testing.T) {
+	flag, _ := model.NewDuplicateFlag("user-123", "wallet-1", model.DuplicateIncome, "inc-2", "inc-1", 1)
+
+	assert.Error(t, flag.Resolve("IGNORE"))
+	assert.True(t, flag.IsPending())
+
+	assert.NoError(t, flag.Resolve(model.ResolveKeepBoth))
+	assert.Equal(t, model.DuplicateKept, flag.Status)
+	assert.NotNil(t, flag.ResolvedAt)
+
+	assert.ErrorIs(t, flag.Resolve(model.ResolveDelete), model.ErrDuplicateResolved)
+	assert.Equal(t, model.DuplicateKept, flag.Status)
+}
+
+func TestWallet_MergeExpense_KeepsOlderRecordAndRefundsDuplicate(t *testing.T) {
+	wallet, _ := model.NewWalletWithInitialBalance("user-123", "My Wallet", model.WalletTypeCash, "USD", 10000)
+	amount, _ := model.NewMoney(500, "USD")
+	kept, _ := wallet.AddExpense(*amount, "food", "", time.Now())
+	trip, _ := model.NewTagEdit([]string{"trip"}, nil)
+	_, _ = wallet.EditExpenseTags(kept.ID, trip)
+	duplicate, _ := wallet.AddExpense(*amount, "food", "Coffee shop", time.Now())
+	work, _ := model.NewTagEdit([]string{"work", "trip"}, nil)
+	_, _ = wallet.EditExpenseTags(duplicate.ID, work)
+	wallet.ClearDomainEvents()
+
+	merged, err := wallet.MergeExpense(kept.ID, duplicate.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, kept.ID, merged.ID)
+	assert.Equal(t, "Coffee shop", merged.Description, "an empty description is filled from the duplicate")
+	assert.Equal(t, []string{"trip", "work"}, merged.Tags)
+	assert.Len(t, wallet.GetExpenseRecords(), 1)
+	assert.Equal(t, int64(9500), wallet.Balance.Amount, "only the duplicate is refunded")
+	assert.Len(t, wallet.DomainEvents(), 2, "the duplicate's removal and the kept record's update")
+
+	_, err = wallet.MergeExpense(kept.ID, duplicate.ID)
+	assert.Error(t, err)
+}
+
+func TestWallet_MergeIncome_RejectsUnknownRecords(t *testing.T) {
+	wallet, _ := model.NewWalletWithInitialBalance("user-123", "My Wallet", model.WalletTypeCash, "USD", 0)
+	amount, _ := model.NewMoney(3000, "USD")
+	kept, _ := wallet.AddIncome(*amount, "salary", "Salary", time.Now())
+	duplicate, _ := wallet.AddIncome(*amount, "salary", "SALARY ACME", time.Now())
+
+	_, err := wallet.MergeIncome(kept.ID, kept.ID)
+	assert.Error(t, err)
+	_, err = wallet.MergeIncome(kept.ID, "missing")
+	assert.Error(t, err)
+
+	merged, err := wallet.MergeIncome(kept.ID, duplicate.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Salary", merged.Description, "the kept description wins")
+	assert.Len(t, wallet.GetIncomeRecords(), 1)
+	assert.Equal(t, int64(3000), wallet.Balance.Amount)
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExchangeRate_Validation(t *testing.T) {
+	date := time.Date(2026, 3, 10, 15, 30, 0, 0, time.FixedZone("UTC+8", 8*3600))
+
+	for _, tc := range []struct{ base, quote, rate string }{
+		{"USD", "USD", "1"},
+		{"US", "TWD", "32.5"},
+		{"USD", "TWD", "0"},
+		{"USD", "TWD", "-32.5"},
+		{"USD", "TWD", "1e3"},
+		{"USD", "TWD", "0.12345678901"},
+	} {
+		_, err := model.NewExchangeRate(tc.base, tc.quote, tc.rate, date, "csv")
+		assert.Error(t, err, "%s/%s %s", tc.base, tc.quote, tc.rate)
+	}
+
+	rate, err := model.NewExchangeRate("usd", " twd", "32.4500", date, "csv")
+	assert.NoError(t, err)
+	assert.Equal(t, "USD-TWD-2026-03-10", rate.ID)
+	assert.Equal(t, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), rate.Date)
+	assert.Equal(t, "32.45", rate.RateString())
+}
+
+func TestExchangeRate_ConvertRoundsOnceInTheTargetMinorUnit(t *testing.T) {
+	date := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	usdTWD, _ := model.NewExchangeRate("USD", "TWD", "31", date, "test")
+
+	for _, tc := range []struct {
+		cents    int64
+		rounding model.RoundingMode
+		expected int64
+	}{
+		{1050, model.RoundHalfUp, 326}, // 325.5
+		{1050, model.RoundHalfEven, 326},
+		{1050, model.RoundDown, 325},
+		{1150, model.RoundHalfUp, 357}, // 356.5
+		{1150, model.RoundHalfEven, 356},
+		{1150, model.RoundDown, 356},
+		{1051, model.RoundHalfEven, 326}, // 325.81
+	} {
+		converted, err := usdTWD.Convert(model.Money{Amount: tc.cents, Currency: "USD"}, tc.rounding)
+		assert.NoError(t, err)
+		assert.Equal(t, model.Money{Amount: tc.expected, Currency: "TWD"}, converted, "%d cents with %s", tc.cents, tc.rounding)
+	}
+
+	jpyUSD, _ := model.NewExchangeRate("JPY", "USD", "0.0067", date, "test")
+	converted, _ := jpyUSD.Convert(model.Money{Amount: 1000, Currency: "JPY"}, model.RoundHalfUp)
+	assert.Equal(t, int64(670), converted.Amount, "1000 yen is 6.70 dollars")
+
+	// The inverse keeps full precision: 1000 TWD at 1/32 is 31.25 dollars
+	usdTWD32, _ := model.NewExchangeRate("USD", "TWD", "32", date, "test")
+	converted, _ = usdTWD32.Inverse().Convert(model.Money{Amount: 1000, Currency: "TWD"}, model.RoundHalfUp)
+	assert.Equal(t, model.Money{Amount: 3125, Currency: "USD"}, converted)
+
+	_, err := usdTWD.Convert(model.Money{Amount: 100, Currency: "EUR"}, model.RoundHalfUp)
+	assert.Error(t, err)
+}
+
+func TestRoundingMode_Round(t *testing.T) {
+	half := big.NewRat(-651, 2) // -325.5
+	assert.Equal(t, int64(-326), model.RoundHalfUp.Round(half), "half up rounds away from zero")
+	assert.Equal(t, int64(-326), model.RoundHalfEven.Round(half))
+	assert.Equal(t, int64(-325), model.RoundDown.Round(half), "down truncates towards zero")
+
+	_, err := model.ParseRoundingMode("CEILING")
+	assert.Error(t, err)
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func split(subcategoryID string, amount int64) model.ExpenseSplit {
+	money, _ := model.NewMoney(amount, "USD")
+	return model.ExpenseSplit{SubcategoryID: subcategoryID, Amount: *money}
+}
+
+func newSplitTestWallet() *model.Wallet {
+	wallet, _ := model.NewWalletWithInitialBalance("user-123", "My Wallet", model.WalletTypeCash, "USD", 10000)
+	return wallet
+}
+
+func TestWallet_AddSplitExpense_Success(t *testing.T) {
+	wallet := newSplitTestWallet()
+	total, _ := model.NewMoney(3000, "USD")
+
+	expense, err := wallet.AddSplitExpense(*total, []model.ExpenseSplit{
+		split("groceries", 1800), split("household", 700), split("pet-food", 500),
+	}, "Supermarket", time.Now())
+
+	assert.NoError(t, err)
+	assert.True(t, expense.IsSplit())
+	assert.Equal(t, "groceries", expense.SubcategoryID)
+	assert.Equal(t, int64(7000), wallet.Balance.Amount)
+	assert.Len(t, expense.Portions(), 3)
+	assert.Equal(t, int64(1200), expense.AmountFor(func(id string) bool { return id != "groceries" }))
+}
+
+func TestWallet_AddSplitExpense_LinesMustMatchTotal(t *testing.T) {
+	total, _ := model.NewMoney(3000, "USD")
+	eur, _ := model.NewMoney(1000, "EUR")
+
+	tests := []struct {
+		name   string
+		splits []model.ExpenseSplit
+		err    string
+	}{
+		{"single line", []model.ExpenseSplit{split("groceries", 3000)}, "at least two lines"},
+		{"sum below total", []model.ExpenseSplit{split("groceries", 1000), split("household", 1000)}, "sum to 2000"},
+		{"missing subcategory", []model.ExpenseSplit{split("groceries", 2000), split("", 1000)}, "line 2: subcategory"},
+		{"other currency", []model.ExpenseSplit{split("groceries", 2000), {SubcategoryID: "household", Amount: *eur}}, "line 2: currency EUR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wallet := newSplitTestWallet()
+			expense, err := wallet.AddSplitExpense(*total, tt.splits, "Supermarket", time.Now())
+
+			assert.Nil(t, expense)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.err)
+			}
+			assert.Equal(t, int64(10000), wallet.Balance.Amount)
+		})
+	}
+}
+
+func TestWallet_UpdateExpense_ReplacesOrClearsSplits(t *testing.T) {
+	wallet := newSplitTestWallet()
+	total, _ := model.NewMoney(3000, "USD")
+	expense, _ := wallet.AddExpense(*total, "groceries", "Supermarket", time.Now())
+
+	// A plain expense becomes a split one
+	updated, err := wallet.UpdateSplitExpense(expense.ID, *total, []model.ExpenseSplit{
+		split("household", 1000), split("groceries", 2000),
+	}, "Supermarket", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, "household", updated.SubcategoryID)
+	assert.Len(t, wallet.GetExpenseRecords()[0].Splits, 2)
+
+	// Updating without splits records a single subcategory again
+	smaller, _ := model.NewMoney(2500, "USD")
+	updated, err = wallet.UpdateExpense(expense.ID, *smaller, "groceries", "Supermarket", time.Now())
+	assert.NoError(t, err)
+	assert.False(t, updated.IsSplit())
+	assert.Equal(t, int64(7500), wallet.Balance.Amount)
+
+	// Lines that no longer match the total are rejected
+	_, err = wallet.UpdateSplitExpense(expense.ID, *total, []model.ExpenseSplit{
+		split("household", 1000), split("groceries", 1000),
+	}, "Supermarket", time.Now())
+	assert.Error(t, err)
+	assert.False(t, wallet.GetExpenseRecords()[0].IsSplit())
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestImportMapping() model.ImportMapping {
+	return model.ImportMapping{
+		HasHeader:            true,
+		DateColumn:           "Date",
+		DateFormat:           "YYYY/MM/DD",
+		DescriptionColumn:    "Memo",
+		AmountColumn:         "Amount",
+		ExpenseSubcategoryID: "food-123",
+		IncomeSubcategoryID:  "salary-123",
+	}
+}
+
+func TestParseStatementAmount_AcceptsBankFormats(t *testing.T) {
+	cases := []struct {
+		text     string
+		currency string
+		expected int64
+	}{
+		{"1,200", "TWD", 1200},
+		{"1,200.00", "TWD", 1200},
+		{"NT$ 350", "TWD", 350},
+		{"-45.5", "USD", -4550},
+		{"(12.34)", "USD", -1234},
+		{"12.34-", "USD", -1234},
+		{"+$1,000.10", "USD", 100010},
+		{"20 USD", "USD", 2000},
+	}
+
+	for _, c := range cases {
+		amount, err := model.ParseStatementAmount(c.text, c.currency)
+		assert.NoError(t, err, c.text)
+		assert.Equal(t, c.expected, amount, c.text)
+	}
+}
+
+func TestParseStatementAmount_RejectsInvalidAmounts(t *testing.T) {
+	for _, text := range []string{"", "abc", "12.5", "1.2.3", "12 USD 5", "1-2"} {
+		_, err := model.ParseStatementAmount(text, "TWD")
+		assert.Error(t, err, text)
+	}
+
+	_, err := model.ParseStatementAmount("1.234", "USD")
+	assert.Error(t, err)
+
+	// Unknown currencies are rejected instead of being read as whole units
+	_, err = model.ParseStatementAmount("100", "UDS")
+	assert.Error(t, err)
+}
+
+func TestImportDateLayout_ConvertsFormatTokens(t *testing.T) {
+	layout, err := model.ImportDateLayout("DD.MM.YYYY")
+	assert.NoError(t, err)
+	assert.Equal(t, "02.01.2006", layout)
+
+	layout, err = model.ImportDateLayout("YYYY/M/D")
+	assert.NoError(t, err)
+	assert.Equal(t, "2006/1/2", layout)
+
+	_, err = model.ImportDateLayout("YYYY_MM_DD")
+	assert.Error(t, err)
+	_, err = model.ImportDateLayout("")
+	assert.Error(t, err)
+}
+
+func TestImportMapping_Validate(t *testing.T) {
+	assert.NoError(t, newTestImportMapping().Validate())
+
+	both := newTestImportMapping()
+	both.DebitColumn, both.CreditColumn = "Out", "In"
+	assert.Error(t, both.Validate())
+
+	debitOnly := newTestImportMapping()
+	debitOnly.AmountColumn, debitOnly.DebitColumn = "", "Out"
+	assert.Error(t, debitOnly.Validate())
+
+	noHeader := newTestImportMapping()
+	noHeader.HasHeader = false
+	assert.Error(t, noHeader.Validate())
+	noHeader.DateColumn, noHeader.DescriptionColumn, noHeader.AmountColumn = "1", "2", "3"
+	assert.NoError(t, noHeader.Validate())
+
+	delimiter := newTestImportMapping()
+	delimiter.Delimiter = ";;"
+	assert.Error(t, delimiter.Validate())
+
+	noSubcategory := newTestImportMapping()
+	noSubcategory.IncomeSubcategoryID = ""
+	assert.Error(t, noSubcategory.Validate())
+}
+
+func TestImportColumns_ParseRow_SignConventions(t *testing.T) {
+	header := []string{"date", "memo", "amount"}
+
+	mapping := newTestImportMapping()
+	columns, err := mapping.ResolveColumns(header)
+	assert.NoError(t, err)
+
+	tx, err := columns.ParseRow(2, []string{"2024/03/05", "Lunch", "-120"}, "TWD")
+	assert.NoError(t, err)
+	assert.Equal(t, model.ImportExpense, tx.Type)
+	assert.Equal(t, int64(120), tx.Amount)
+	assert.Equal(t, "Lunch", tx.Description)
+	assert.Equal(t, date(2024, 3, 5), tx.Date)
+	assert.Equal(t, 2, tx.Line)
+
+	// 信用卡帳單：消費為正數、退款為負數
+	mapping.SignConvention = model.SignPositiveIsExpense
+	columns, err = mapping.ResolveColumns(header)
+	assert.NoError(t, err)
+
+	tx, err = columns.ParseRow(3, []string{"2024/03/06", "Refund", "-80"}, "TWD")
+	assert.NoError(t, err)
+	assert.Equal(t, model.ImportIncome, tx.Type)
+	assert.Equal(t, int64(80), tx.Amount)
+
+	_, err = columns.ParseRow(4, []string{"2024/03/06", "Zero", "0"}, "TWD")
+	assert.Error(t, err)
+	_, err = columns.ParseRow(5, []string{"03/06/2024", "Bad date", "10"}, "TWD")
+	assert.Error(t, err)
+	_, err = columns.ParseRow(6, []string{"2024/03/06"}, "TWD")
+	assert.Error(t, err)
+}
+
+func TestImportColumns_ParseRow_DebitCreditColumns(t *testing.T) {
+	mapping := newTestImportMapping()
+	mapping.AmountColumn, mapping.DebitColumn, mapping.CreditColumn = "", "Withdrawal", "Deposit"
+	columns, err := mapping.ResolveColumns([]string{"Date", "Memo", "Withdrawal", "Deposit"})
+	assert.NoError(t, err)
+
+	tx, err := columns.ParseRow(2, []string{"2024/03/05", "ATM", "1,000", ""}, "TWD")
+	assert.NoError(t, err)
+	assert.Equal(t, model.ImportExpense, tx.Type)
+	assert.Equal(t, int64(1000), tx.Amount)
+
+	tx, err = columns.ParseRow(3, []string{"2024/03/05", "Salary", "", "50,000"}, "TWD")
+	assert.NoError(t, err)
+	assert.Equal(t, model.ImportIncome, tx.Type)
+	assert.Equal(t, int64(50000), tx.Amount)
+
+	_, err = columns.ParseRow(4, []string{"2024/03/05", "Both", "10", "10"}, "TWD")
+	assert.Error(t, err)
+
+	_, err = mapping.ResolveColumns([]string{"Date", "Memo", "Amount"})
+	assert.Error(t, err)
+}
+
+func TestNewImportProfile_AppliesDefaultsAndValidates(t *testing.T) {
+	profile, err := model.NewImportProfile("user-123", "  My Bank  ", newTestImportMapping())
+	assert.NoError(t, err)
+	assert.Equal(t, "My Bank", profile.Name)
+	assert.Equal(t, model.ImportEncodingUTF8, profile.Mapping.Encoding)
+	assert.Equal(t, model.SignNegativeIsExpense, profile.Mapping.SignConvention)
+
+	_, err = model.NewImportProfile("user-123", " ", newTestImportMapping())
+	assert.Error(t, err)
+
+	invalid := newTestImportMapping()
+	invalid.DateFormat = "whenever"
+	_, err = model.NewImportProfile("user-123", "My Bank", invalid)
+	assert.Error(t, err)
+
+	err = profile.Update("Card", invalid)
+	assert.Error(t, err)
+	assert.Equal(t, "My Bank", profile.Name)
+}
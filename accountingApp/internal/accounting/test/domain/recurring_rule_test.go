@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRecurringRule(t *testing.T, schedule model.RecurrenceSchedule) *model.RecurringRule {
+	money, _ := model.NewMoney(25000, "TWD")
+	rule, err := model.NewRecurringRule("user-123", "Rent", model.RecurringExpense, "wallet-123", "rent-123", *money, "", schedule)
+	assert.NoError(t, err)
+	return rule
+}
+
+func validSchedule(t *testing.T, schedule model.RecurrenceSchedule) model.RecurrenceSchedule {
+	assert.NoError(t, schedule.Validate())
+	return schedule
+}
+
+func TestRecurrenceSchedule_MonthlyDayIsClampedToMonthEnd(t *testing.T) {
+	schedule := validSchedule(t, model.RecurrenceSchedule{Frequency: model.RecurrenceMonthly, StartDate: date(2024, 1, 31)})
+
+	assert.Equal(t, []time.Time{date(2024, 1, 31), date(2024, 2, 29), date(2024, 3, 31), date(2024, 4, 30)},
+		schedule.Next(date(2024, 1, 1), 4))
+}
+
+func TestRecurrenceSchedule_MonthlyDayBeforeStartBeginsNextMonth(t *testing.T) {
+	schedule := validSchedule(t, model.RecurrenceSchedule{Frequency: model.RecurrenceMonthly, DayOfMonth: 1, StartDate: date(2024, 1, 15)})
+
+	assert.Equal(t, []time.Time{date(2024, 2, 1), date(2024, 3, 1)}, schedule.Next(date(2024, 1, 1), 2))
+}
+
+func TestRecurrenceSchedule_MonthlyLastBusinessDay(t *testing.T) {
+	schedule := validSchedule(t, model.RecurrenceSchedule{Frequency: model.RecurrenceMonthly, LastBusinessDay: true, StartDate: date(2024, 3, 1)})
+
+	// 2024-03-31 is a Sunday and 2024-08-31 a Saturday
+	assert.Equal(t, []time.Time{date(2024, 3, 29), date(2024, 4, 30), date(2024, 5, 31)}, schedule.Next(date(2024, 3, 1), 3))
+	assert.Equal(t, []time.Time{date(2024, 8, 30)}, schedule.Between(date(2024, 8, 1), date(2024, 8, 31)))
+}
+
+func TestRecurrenceSchedule_WeeklyIntervalStopsAtCount(t *testing.T) {
+	schedule := validSchedule(t, model.RecurrenceSchedule{Frequency: model.RecurrenceWeekly, Interval: 2, Count: 3, StartDate: date(2024, 1, 1)})
+
+	assert.Equal(t, []time.Time{date(2024, 1, 1), date(2024, 1, 15), date(2024, 1, 29)}, schedule.Next(date(2024, 1, 1), 10))
+}
+
+func TestRecurrenceSchedule_EndDateIsInclusive(t *testing.T) {
+	end := date(2024, 1, 3)
+	schedule := validSchedule(t, model.RecurrenceSchedule{Frequency: model.RecurrenceDaily, StartDate: date(2024, 1, 1), EndDate: &end})
+
+	assert.Len(t, schedule.Between(date(2024, 1, 1), date(2024, 12, 31)), 3)
+	assert.True(t, schedule.Includes(date(2024, 1, 3)))
+	assert.False(t, schedule.Includes(date(2024, 1, 4)))
+}
+
+func TestRecurrenceSchedule_YearlyOnLeapDay(t *testing.T) {
+	schedule := validSchedule(t, model.RecurrenceSchedule{Frequency: model.RecurrenceYearly, StartDate: date(2024, 2, 29)})
+
+	assert.Equal(t, []time.Time{date(2024, 2, 29), date(2025, 2, 28), date(2026, 2, 28), date(2027, 2, 28), date(2028, 2, 29)},
+		schedule.Next(date(2024, 1, 1), 5))
+}
+
+func TestRecurrenceSchedule_ValidateRejectsConflictingOptions(t *testing.T) {
+	end := date(2024, 6, 1)
+	invalid := []model.RecurrenceSchedule{
+		{Frequency: "HOURLY", StartDate: date(2024, 1, 1)},
+		{Frequency: model.RecurrenceDaily},
+		{Frequency: model.RecurrenceDaily, StartDate: date(2024, 1, 1), EndDate: &end, Count: 3},
+		{Frequency: model.RecurrenceWeekly, StartDate: date(2024, 1, 1), DayOfMonth: 5},
+		{Frequency: model.RecurrenceMonthly, StartDate: date(2024, 1, 1), DayOfMonth: 5, LastBusinessDay: true},
+		{Frequency: model.RecurrenceMonthly, StartDate: date(2024, 1, 1), DayOfMonth: 32},
+		{Frequency: model.RecurrenceDaily, StartDate: date(2024, 7, 1), EndDate: &end},
+	}
+
+	for _, schedule := range invalid {
+		assert.Error(t, schedule.Validate(), "%+v", schedule)
+	}
+}
+
+func TestRecurringRule_DueExcludesProcessedDates(t *testing.T) {
+	rule := newTestRecurringRule(t, model.RecurrenceSchedule{Frequency: model.RecurrenceDaily, StartDate: date(2024, 1, 1)})
+	now := date(2024, 1, 3).Add(9 * time.Hour)
+	assert.Len(t, rule.Due(now, 3, time.Hour), 3)
+
+	assert.NoError(t, rule.Claim(date(2024, 1, 1), now, time.Hour))
+	rule.MarkGenerated(date(2024, 1, 1), "expense-1", now)
+	assert.NoError(t, rule.Skip(date(2024, 1, 2), now))
+
+	assert.Equal(t, []time.Time{date(2024, 1, 3)}, rule.Due(now, 3, time.Hour))
+	assert.Error(t, rule.Claim(date(2024, 1, 1), now, time.Hour), "a generated date cannot be claimed twice")
+}
+
+func TestRecurringRule_FailedOccurrenceIsRetriedUntilMaxAttempts(t *testing.T) {
+	rule := newTestRecurringRule(t, model.RecurrenceSchedule{Frequency: model.RecurrenceDaily, StartDate: date(2024, 1, 1), Count: 1})
+	now := date(2024, 1, 1)
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		assert.Equal(t, []time.Time{date(2024, 1, 1)}, rule.Due(now, 2, time.Hour))
+		assert.NoError(t, rule.Claim(date(2024, 1, 1), now, time.Hour))
+		rule.MarkFailed(date(2024, 1, 1), "insufficient balance", now)
+	}
+
+	assert.Empty(t, rule.Due(now, 2, time.Hour))
+	assert.Equal(t, 2, rule.Occurrences[0].Attempts)
+	assert.Equal(t, "insufficient balance", rule.Occurrences[0].LastError)
+}
+
+func TestRecurringRule_ClaimedOccurrenceIsDueAgainAfterLease(t *testing.T) {
+	rule := newTestRecurringRule(t, model.RecurrenceSchedule{Frequency: model.RecurrenceDaily, StartDate: date(2024, 1, 1), Count: 1})
+	now := date(2024, 1, 1).Add(9 * time.Hour)
+
+	assert.NoError(t, rule.Claim(date(2024, 1, 1), now, time.Hour))
+	assert.Equal(t, model.OccurrenceClaimed, rule.Occurrences[0].Status)
+	assert.Empty(t, rule.Due(now.Add(59*time.Minute), 3, time.Hour), "still claimed")
+	assert.Error(t, rule.Claim(date(2024, 1, 1), now.Add(59*time.Minute), time.Hour))
+	assert.Error(t, rule.Skip(date(2024, 1, 1), now), "a claimed date is being generated")
+
+	// The claim was never completed: after the lease the date is claimed again
+	later := now.Add(time.Hour)
+	assert.Equal(t, []time.Time{date(2024, 1, 1)}, rule.Due(later, 3, time.Hour))
+	assert.NoError(t, rule.Claim(date(2024, 1, 1), later, time.Hour))
+	assert.Equal(t, 2, rule.Occurrences[0].Attempts)
+	assert.Equal(t, rule.ID+":2024-01-01", rule.OccurrenceKey(later))
+}
+
+func TestRecurringRule_ResumeSkipsDatesMissedWhilePaused(t *testing.T) {
+	rule := newTestRecurringRule(t, model.RecurrenceSchedule{Frequency: model.RecurrenceDaily, StartDate: date(2024, 1, 1)})
+
+	assert.NoError(t, rule.Pause(date(2024, 1, 2).Add(8*time.Hour)))
+	assert.Empty(t, rule.Due(date(2024, 1, 5), 3, time.Hour))
+	assert.Error(t, rule.Pause(date(2024, 1, 3)))
+
+	assert.NoError(t, rule.Resume(date(2024, 1, 5).Add(8*time.Hour)))
+
+	// Jan 1 was due before the pause and is still generated; Jan 2-4 are skipped; Jan 5 is today
+	assert.Equal(t, []time.Time{date(2024, 1, 1), date(2024, 1, 5)}, rule.Due(date(2024, 1, 5), 3, time.Hour))
+	preview := rule.Preview(date(2024, 1, 2), 4)
+	assert.Equal(t, model.OccurrenceSkipped, preview[0].Status)
+	assert.Equal(t, model.OccurrenceSkipped, preview[2].Status)
+	assert.Equal(t, model.OccurrenceScheduled, preview[3].Status)
+}
+
+func TestRecurringRule_SkipRequiresAnUngeneratedOccurrence(t *testing.T) {
+	rule := newTestRecurringRule(t, model.RecurrenceSchedule{Frequency: model.RecurrenceWeekly, StartDate: date(2024, 1, 1)})
+	now := date(2024, 1, 1)
+
+	assert.Error(t, rule.Skip(date(2024, 1, 2), now), "not a Monday")
+
+	assert.NoError(t, rule.Claim(date(2024, 1, 1), now, time.Hour))
+	rule.MarkGenerated(date(2024, 1, 1), "expense-1", now)
+	assert.Error(t, rule.Skip(date(2024, 1, 1), now))
+
+	assert.NoError(t, rule.Skip(date(2024, 1, 15), now))
+	assert.NoError(t, rule.Skip(date(2024, 1, 15), now), "skipping twice is a no-op")
+	next, ok := rule.NextScheduled(date(2024, 1, 2))
+	assert.True(t, ok)
+	assert.Equal(t, date(2024, 1, 8), next)
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTags_TrimsLowercasesAndDeduplicates(t *testing.T) {
+	tags, err := model.NormalizeTags([]string{" Trip-Tokyo", "food", "FOOD", "business"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"business", "food", "trip-tokyo"}, tags)
+}
+
+func TestNormalizeTag_RejectsInvalidNames(t *testing.T) {
+	for _, name := range []string{"", "   ", "two words", "a,b", strings.Repeat("x", model.MaxTagLength+1)} {
+		_, err := model.NormalizeTag(name)
+		assert.Error(t, err, "tag %q", name)
+	}
+}
+
+func TestNewTagEdit_Validation(t *testing.T) {
+	_, err := model.NewTagEdit(nil, nil)
+	assert.Error(t, err)
+
+	_, err = model.NewTagEdit([]string{"Trip"}, []string{"trip"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "both added and removed")
+}
+
+func TestTagMatch_AnyAndAll(t *testing.T) {
+	tags := []string{"food", "trip"}
+
+	assert.True(t, model.TagMatchAny.Matches(tags, []string{"trip", "work"}))
+	assert.False(t, model.TagMatchAll.Matches(tags, []string{"trip", "work"}))
+	assert.True(t, model.TagMatchAll.Matches(tags, []string{"trip", "food"}))
+	assert.True(t, model.TagMatchAll.Matches(nil, nil), "no filter matches everything")
+
+	match, err := model.ParseTagMatch("")
+	assert.NoError(t, err)
+	assert.Equal(t, model.TagMatchAny, match)
+	_, err = model.ParseTagMatch("some")
+	assert.Error(t, err)
+}
+
+func TestWallet_EditExpenseTags_RecordsChangeOnlyWhenTagsDiffer(t *testing.T) {
+	wallet, _ := model.NewWalletWithInitialBalance("user-123", "My Wallet", model.WalletTypeCash, "USD", 10000)
+	amount, _ := model.NewMoney(500, "USD")
+	expense, _ := wallet.AddExpense(*amount, "food", "Lunch", time.Now())
+	wallet.ClearChanges()
+	wallet.ClearDomainEvents()
+	add, _ := model.NewTagEdit([]string{"trip"}, nil)
+
+	changed, err := wallet.EditExpenseTags(expense.ID, add)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, []string{"trip"}, wallet.GetExpenseRecords()[0].Tags)
+	assert.Len(t, wallet.DomainEvents(), 1)
+	assert.Equal(t, model.EventTagsChanged, wallet.DomainEvents()[0].EventName())
+
+	// Applying the same edit again is a no-op
+	changed, err = wallet.EditExpenseTags(expense.ID, add)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	assert.Len(t, wallet.DomainEvents(), 1)
+
+	_, err = wallet.EditExpenseTags("missing", add)
+	assert.Error(t, err)
+}
+
+func TestWallet_EditExpenseTags_LimitsTagsPerTransaction(t *testing.T) {
+	wallet, _ := model.NewWalletWithInitialBalance("user-123", "My Wallet", model.WalletTypeCash, "USD", 10000)
+	amount, _ := model.NewMoney(500, "USD")
+	expense, _ := wallet.AddExpense(*amount, "food", "Lunch", time.Now())
+
+	names := make([]string, model.MaxTagsPerTransaction+1)
+	for i := range names {
+		names[i] = strings.Repeat("t", i+1)
+	}
+	edit, _ := model.NewTagEdit(names, nil)
+
+	_, err := wallet.EditExpenseTags(expense.ID, edit)
+	assert.Error(t, err)
+	assert.Empty(t, wallet.GetExpenseRecords()[0].Tags)
+}
@@ -100,3 +100,196 @@ func TestWallet_ProcessIncomingTransfer_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, int64(2000), wallet.Balance.Amount)
 }
+
+func TestWallet_ReceiveTransfer_RecordsIncomingTransfer(t *testing.T) {
+	fromWallet, _ := model.NewWalletWithInitialBalance("user-123", "Checking", model.WalletTypeBank, "USD", 10000)
+	toWallet, _ := model.NewWallet("user-123", "Savings", model.WalletTypeBank, "USD")
+
+	amount, _ := model.NewMoney(3000, "USD")
+	fee, _ := model.NewMoney(0, "USD")
+	transfer, _ := fromWallet.CreateTransfer(toWallet.ID, *amount, *fee, "Savings", time.Now())
+
+	err := toWallet.ReceiveTransfer(*transfer)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3000), toWallet.Balance.Amount)
+	assert.Len(t, toWallet.GetTransfers(), 1)
+	assert.True(t, toWallet.IsIncomingTransfer(toWallet.GetTransfers()[0]))
+	assert.False(t, fromWallet.IsIncomingTransfer(*transfer))
+}
+
+func TestWallet_ReceiveTransfer_WrongDestination(t *testing.T) {
+	fromWallet, _ := model.NewWalletWithInitialBalance("user-123", "Checking", model.WalletTypeBank, "USD", 10000)
+	otherWallet, _ := model.NewWallet("user-123", "Other", model.WalletTypeCash, "USD")
+
+	amount, _ := model.NewMoney(3000, "USD")
+	fee, _ := model.NewMoney(0, "USD")
+	transfer, _ := fromWallet.CreateTransfer("another-wallet", *amount, *fee, "Savings", time.Now())
+
+	err := otherWallet.ReceiveTransfer(*transfer)
+
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), otherWallet.Balance.Amount)
+	assert.Empty(t, otherWallet.GetTransfers())
+}
+
+func TestWallet_ChangeTracking_TracksNewRecordsOnly(t *testing.T) {
+	wallet, _ := model.NewWalletWithInitialBalance("user-123", "My Wallet", model.WalletTypeCash, "USD", 10000)
+	loaded, _ := model.NewMoney(500, "USD")
+	wallet.LoadExpenseRecord(model.ExpenseRecord{ID: "persisted-expense", WalletID: wallet.ID, Amount: *loaded})
+
+	amount, _ := model.NewMoney(2000, "USD")
+	expense, _ := wallet.AddExpense(*amount, "cat-123", "Coffee", time.Now())
+	income, _ := wallet.AddIncome(*amount, "salary", "Bonus", time.Now())
+
+	assert.Equal(t, []string{expense.ID}, wallet.ExpenseRecordChanges().Added)
+	assert.Equal(t, []string{income.ID}, wallet.IncomeRecordChanges().Added)
+	assert.True(t, wallet.TransferChanges().IsEmpty())
+
+	wallet.ClearChanges()
+
+	assert.True(t, wallet.ExpenseRecordChanges().IsEmpty())
+	assert.True(t, wallet.IncomeRecordChanges().IsEmpty())
+}
+
+func TestWallet_UpdateExpense_RecomputesBalance(t *testing.T) {
+	wallet, _ := model.NewWalletWithInitialBalance("user-123", "My Wallet", model.WalletTypeCash, "USD", 10000)
+	amount, _ := model.NewMoney(2000, "USD")
+	expense, _ := wallet.AddExpense(*amount, "cat-123", "Coffe", time.Now())
+
+	corrected, _ := model.NewMoney(2500, "USD")
+	updated, err := wallet.UpdateExpense(expense.ID, *corrected, "cat-456", "Coffee", expense.Date)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7500), wallet.Balance.Amount) // 10000 - 2500
+	assert.Equal(t, "Coffee", updated.Description)
+	assert.Equal(t, "cat-456", wallet.GetExpenseRecords()[0].SubcategoryID)
+}
+
+func TestWallet_UpdateExpense_RejectsNegativeBalance(t *testing.T) {
+	wallet, _ := model.NewWalletWithInitialBalance("user-123", "My Wallet", model.WalletTypeCash, "USD", 3000)
+	amount, _ := model.NewMoney(2000, "USD")
+	expense, _ := wallet.AddExpense(*amount, "cat-123", "Coffee", time.Now())
+
+	tooLarge, _ := model.NewMoney(3001, "USD")
+	_, err := wallet.UpdateExpense(expense.ID, *tooLarge, "cat-123", "Coffee", expense.Date)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "insufficient balance")
+	assert.Equal(t, int64(1000), wallet.Balance.Amount)
+	assert.Equal(t, int64(2000), wallet.GetExpenseRecords()[0].Amount.Amount)
+}
+
+func TestWallet_RemoveExpense_RestoresBalance(t *testing.T) {
+	wallet, _ := model.NewWalletWithInitialBalance("user-123", "My Wallet", model.WalletTypeCash, "USD", 10000)
+	amount, _ := model.NewMoney(2000, "USD")
+	expense, _ := wallet.AddExpense(*amount, "cat-123", "Coffee", time.Now())
+
+	err := wallet.RemoveExpense(expense.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10000), wallet.Balance.Amount)
+	assert.Empty(t, wallet.GetExpenseRecords())
+	assert.Error(t, wallet.RemoveExpense(expense.ID))
+}
+
+func TestWallet_UpdateIncome_RejectsNegativeBalance(t *testing.T) {
+	wallet, _ := model.NewWallet("user-123", "My Wallet", model.WalletTypeCash, "USD")
+	amount, _ := model.NewMoney(5000, "USD")
+	income, _ := wallet.AddIncome(*amount, "salary", "Salary", time.Now())
+	spent, _ := model.NewMoney(4000, "USD")
+	wallet.AddExpense(*spent, "cat-123", "Rent", time.Now())
+
+	// 收入已被花用，下修到低於支出會讓餘額為負
+	lowered, _ := model.NewMoney(3000, "USD")
+	_, err := wallet.UpdateIncome(income.ID, *lowered, "salary", "Salary", income.Date)
+
+	assert.Error(t, err)
+	assert.Equal(t, int64(1000), wallet.Balance.Amount)
+
+	raised, _ := model.NewMoney(6000, "USD")
+	_, err = wallet.UpdateIncome(income.ID, *raised, "salary", "Salary", income.Date)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2000), wallet.Balance.Amount)
+}
+
+func TestWallet_RemoveIncome_RejectsNegativeBalance(t *testing.T) {
+	wallet, _ := model.NewWallet("user-123", "My Wallet", model.WalletTypeCash, "USD")
+	amount, _ := model.NewMoney(5000, "USD")
+	income, _ := wallet.AddIncome(*amount, "salary", "Salary", time.Now())
+	spent, _ := model.NewMoney(4000, "USD")
+	wallet.AddExpense(*spent, "cat-123", "Rent", time.Now())
+
+	err := wallet.RemoveIncome(income.ID)
+
+	assert.Error(t, err)
+	assert.Len(t, wallet.GetIncomeRecords(), 1)
+	assert.Equal(t, int64(1000), wallet.Balance.Amount)
+}
+
+func TestWallet_ChangeTracking_ModifiedAndRemoved(t *testing.T) {
+	wallet, _ := model.NewWalletWithInitialBalance("user-123", "My Wallet", model.WalletTypeCash, "USD", 10000)
+	persisted, _ := model.NewMoney(500, "USD")
+	wallet.LoadExpenseRecord(model.ExpenseRecord{ID: "persisted-1", WalletID: wallet.ID, SubcategoryID: "cat", Amount: *persisted})
+	wallet.LoadExpenseRecord(model.ExpenseRecord{ID: "persisted-2", WalletID: wallet.ID, SubcategoryID: "cat", Amount: *persisted})
+
+	amount, _ := model.NewMoney(700, "USD")
+	wallet.UpdateExpense("persisted-1", *amount, "cat", "Fixed", time.Now())
+	wallet.RemoveExpense("persisted-2")
+	added, _ := wallet.AddExpense(*amount, "cat", "New", time.Now())
+	wallet.RemoveExpense(added.ID)
+
+	changes := wallet.ExpenseRecordChanges()
+	assert.Equal(t, []string{"persisted-1"}, changes.Modified)
+	assert.Equal(t, []string{"persisted-2"}, changes.Removed)
+	assert.Empty(t, changes.Added, "records added and removed before saving need no persistence")
+}
+
+func TestWallet_SendTransfer_CrossCurrencyFeeInEitherCurrency(t *testing.T) {
+	usd, _ := model.NewWalletWithInitialBalance("user-123", "Checking", model.WalletTypeBank, "USD", 10000)
+	twd, _ := model.NewWallet("user-123", "Savings", model.WalletTypeBank, "TWD")
+
+	amount, _ := model.NewMoney(3000, "USD")
+	toAmount, _ := model.NewMoney(975, "TWD")
+	sourceFee, _ := model.NewMoney(100, "USD")
+	transfer, err := usd.SendTransfer(twd.ID, *amount, *toAmount, *sourceFee, "Savings", time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, twd.ReceiveTransfer(*transfer))
+	assert.Equal(t, int64(6900), usd.Balance.Amount) // 10000 - 3000 - 100
+	assert.Equal(t, int64(975), twd.Balance.Amount)
+	assert.Equal(t, "32.5", transfer.ImpliedRate().RateString())
+
+	destinationFee, _ := model.NewMoney(30, "TWD")
+	transfer, err = usd.SendTransfer(twd.ID, *amount, *toAmount, *destinationFee, "Savings", time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, twd.ReceiveTransfer(*transfer))
+	assert.Equal(t, int64(3900), usd.Balance.Amount)
+	assert.Equal(t, int64(975+945), twd.Balance.Amount)
+
+	// The destination wallet only accepts its own currency
+	eur, _ := model.NewWallet("user-123", "Euro", model.WalletTypeBank, "EUR")
+	transfer.ToWalletID = eur.ID
+	assert.Error(t, eur.ReceiveTransfer(*transfer))
+}
+
+func TestNewExchangeTransfer_Validation(t *testing.T) {
+	usd := func(amount int64) model.Money { return model.Money{Amount: amount, Currency: "USD"} }
+	twd := func(amount int64) model.Money { return model.Money{Amount: amount, Currency: "TWD"} }
+
+	for name, tc := range map[string]struct{ amount, toAmount, fee model.Money }{
+		"same currency, different amounts": {usd(1000), usd(900), usd(0)},
+		"no destination amount":            {usd(1000), twd(0), usd(0)},
+		"fee in a third currency":          {usd(1000), twd(320), model.Money{Amount: 1, Currency: "EUR"}},
+		"fee above destination amount":     {usd(1000), twd(320), twd(321)},
+	} {
+		_, err := model.NewExchangeTransfer("from", "to", tc.amount, tc.toAmount, tc.fee, "", time.Now())
+		assert.Error(t, err, name)
+	}
+
+	transfer, err := model.NewTransfer("from", "to", usd(1000), usd(10), "", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, usd(1000), transfer.ToAmount)
+	assert.Equal(t, usd(1000), transfer.CreditedAmount(), "a same-currency fee is paid by the source")
+	assert.Nil(t, transfer.ImpliedRate())
+}
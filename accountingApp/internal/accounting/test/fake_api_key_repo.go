@@ -0,0 +1,78 @@
+package test
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"sync"
+)
+
+// FakeAPIKeyRepository 假的API金鑰倉庫，用於測試
+type FakeAPIKeyRepository struct {
+	keys  map[string]*model.APIKey
+	mutex sync.RWMutex
+}
+
+// NewFakeAPIKeyRepository 建立新的假倉庫
+func NewFakeAPIKeyRepository() repository.APIKeyRepository {
+	return &FakeAPIKeyRepository{
+		keys: make(map[string]*model.APIKey),
+	}
+}
+
+// Save 儲存API金鑰聚合
+func (r *FakeAPIKeyRepository) Save(key *model.APIKey) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if key == nil {
+		return fmt.Errorf("API key cannot be nil")
+	}
+
+	copied := *key
+	r.keys[key.ID] = &copied
+	return nil
+}
+
+// FindByID 根據ID查找API金鑰聚合
+func (r *FakeAPIKeyRepository) FindByID(id string) (*model.APIKey, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	key, exists := r.keys[id]
+	if !exists {
+		return nil, nil // Not found
+	}
+
+	copied := *key
+	return &copied, nil
+}
+
+// FindByKeyHash 根據金鑰雜湊查找API金鑰聚合
+func (r *FakeAPIKeyRepository) FindByKeyHash(keyHash string) (*model.APIKey, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, key := range r.keys {
+		if key.KeyHash == keyHash {
+			copied := *key
+			return &copied, nil
+		}
+	}
+	return nil, nil // Not found
+}
+
+// FindByUserID 根據用戶ID查找所有API金鑰聚合
+func (r *FakeAPIKeyRepository) FindByUserID(userID string) ([]*model.APIKey, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []*model.APIKey
+	for _, key := range r.keys {
+		if key.UserID == userID {
+			copied := *key
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
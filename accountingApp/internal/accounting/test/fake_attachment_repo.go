@@ -0,0 +1,158 @@
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// FakeAttachmentRepository 假的附件倉庫，用於測試
+type FakeAttachmentRepository struct {
+	attachments map[string]*model.Attachment
+	mutex       sync.RWMutex
+}
+
+// NewFakeAttachmentRepository 建立新的假倉庫
+func NewFakeAttachmentRepository() *FakeAttachmentRepository {
+	return &FakeAttachmentRepository{
+		attachments: make(map[string]*model.Attachment),
+	}
+}
+
+// Save 儲存附件聚合
+func (r *FakeAttachmentRepository) Save(attachment *model.Attachment) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if attachment == nil {
+		return fmt.Errorf("attachment cannot be nil")
+	}
+
+	copied := *attachment
+	r.attachments[attachment.ID] = &copied
+	return nil
+}
+
+// FindByID 根據ID查找附件聚合
+func (r *FakeAttachmentRepository) FindByID(id string) (*model.Attachment, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	attachment, exists := r.attachments[id]
+	if !exists {
+		return nil, nil // Not found
+	}
+
+	copied := *attachment
+	return &copied, nil
+}
+
+// FindByRecord 根據交易記錄查找其所有附件聚合
+func (r *FakeAttachmentRepository) FindByRecord(recordType model.AttachmentRecordType, recordID string) ([]*model.Attachment, error) {
+	return r.findWhere(func(attachment *model.Attachment) bool {
+		return attachment.RecordType == recordType && attachment.RecordID == recordID
+	}), nil
+}
+
+// FindByWalletID 根據錢包ID查找其記錄的所有附件聚合
+func (r *FakeAttachmentRepository) FindByWalletID(walletID string) ([]*model.Attachment, error) {
+	return r.findWhere(func(attachment *model.Attachment) bool {
+		return attachment.WalletID == walletID
+	}), nil
+}
+
+// Delete 根據ID刪除附件聚合
+func (r *FakeAttachmentRepository) Delete(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.attachments[id]; !exists {
+		return fmt.Errorf("attachment %s not found", id)
+	}
+
+	delete(r.attachments, id)
+	return nil
+}
+
+func (r *FakeAttachmentRepository) findWhere(match func(*model.Attachment) bool) []*model.Attachment {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []*model.Attachment
+	for _, attachment := range r.attachments {
+		if match(attachment) {
+			copied := *attachment
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result
+}
+
+// FakeBlobStore 記憶體中的 BlobStore，用於測試
+type FakeBlobStore struct {
+	blobs     map[string][]byte
+	mutex     sync.RWMutex
+	DeleteErr error // 不為nil時 Delete 回傳此錯誤，模擬儲存失敗
+}
+
+// NewFakeBlobStore 建立新的假 BlobStore
+func NewFakeBlobStore() *FakeBlobStore {
+	return &FakeBlobStore{
+		blobs: make(map[string][]byte),
+	}
+}
+
+func (s *FakeBlobStore) Put(key string, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.blobs[key] = data
+	return nil
+}
+
+func (s *FakeBlobStore) Get(key string) (io.ReadCloser, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	data, exists := s.blobs[key]
+	if !exists {
+		return nil, repository.ErrBlobNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *FakeBlobStore) Delete(key string) error {
+	if s.DeleteErr != nil {
+		return s.DeleteErr
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.blobs, key)
+	return nil
+}
+
+// Has 檢查是否存有指定鍵的檔案
+func (s *FakeBlobStore) Has(key string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	_, exists := s.blobs[key]
+	return exists
+}
+
+// 確保假實作符合介面
+var _ repository.AttachmentRepository = (*FakeAttachmentRepository)(nil)
+var _ repository.BlobStore = (*FakeBlobStore)(nil)
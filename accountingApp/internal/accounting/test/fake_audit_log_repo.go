@@ -0,0 +1,96 @@
+package test
+
+import (
+	"sync"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+)
+
+// FakeAuditLogRepository 假的稽核紀錄倉庫，以與PostgreSQL實作相同的方式串接雜湊鏈
+type FakeAuditLogRepository struct {
+	entries []mapper.AuditEntryData
+	mutex   sync.RWMutex
+}
+
+// NewFakeAuditLogRepository 建立新的假倉庫
+func NewFakeAuditLogRepository() *FakeAuditLogRepository {
+	return &FakeAuditLogRepository{}
+}
+
+// Append 將紀錄接在鏈尾
+func (r *FakeAuditLogRepository) Append(entry mapper.AuditEntryData) (*mapper.AuditEntryData, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var previous *mapper.AuditEntryData
+	if len(r.entries) > 0 {
+		previous = &r.entries[len(r.entries)-1]
+	}
+	chained := mapper.ChainAuditEntry(entry, previous)
+	r.entries = append(r.entries, chained)
+	return &chained, nil
+}
+
+// Find 依條件查詢，由新到舊
+func (r *FakeAuditLogRepository) Find(filter repository.AuditLogFilter) ([]mapper.AuditEntryData, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []mapper.AuditEntryData
+	for i := len(r.entries) - 1; i >= 0 && len(result) < filter.Limit; i-- {
+		entry := r.entries[i]
+		if (filter.ActorID != "" && entry.ActorID != filter.ActorID) ||
+			(filter.Command != "" && entry.Command != filter.Command) ||
+			(filter.AggregateType != "" && entry.AggregateType != filter.AggregateType) ||
+			(filter.AggregateID != "" && entry.AggregateID != filter.AggregateID) ||
+			(filter.RequestID != "" && entry.RequestID != filter.RequestID) ||
+			(filter.From != nil && entry.OccurredAt.Before(*filter.From)) ||
+			(filter.To != nil && !entry.OccurredAt.Before(*filter.To)) {
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// FindAfterSequence 依序號由舊到新取得紀錄
+func (r *FakeAuditLogRepository) FindAfterSequence(afterSequence int64, limit int) ([]mapper.AuditEntryData, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []mapper.AuditEntryData
+	for _, entry := range r.entries {
+		if entry.Sequence > afterSequence && len(result) < limit {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+// Entries 依寫入順序回傳所有紀錄
+func (r *FakeAuditLogRepository) Entries() []mapper.AuditEntryData {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return append([]mapper.AuditEntryData(nil), r.entries...)
+}
+
+// Tamper 直接修改已寫入的紀錄，模擬繞過應用程式竄改資料庫
+func (r *FakeAuditLogRepository) Tamper(sequence int64, modify func(entry *mapper.AuditEntryData)) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for i := range r.entries {
+		if r.entries[i].Sequence == sequence {
+			modify(&r.entries[i])
+		}
+	}
+}
+
+// truncate 只保留前n筆紀錄 (模擬交易回滾)
+func (r *FakeAuditLogRepository) truncate(n int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.entries = r.entries[:n]
+}
+
+var _ repository.AuditLogRepository = (*FakeAuditLogRepository)(nil)
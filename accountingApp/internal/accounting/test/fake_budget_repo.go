@@ -0,0 +1,83 @@
+package test
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"sync"
+)
+
+// FakeBudgetRepository 假的預算倉庫，用於測試
+type FakeBudgetRepository struct {
+	budgets map[string]*model.Budget
+	mutex   sync.RWMutex
+}
+
+// NewFakeBudgetRepository 建立新的假倉庫
+func NewFakeBudgetRepository() repository.BudgetRepository {
+	return &FakeBudgetRepository{
+		budgets: make(map[string]*model.Budget),
+	}
+}
+
+// Save 儲存預算聚合
+func (r *FakeBudgetRepository) Save(budget *model.Budget) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if budget == nil {
+		return fmt.Errorf("budget cannot be nil")
+	}
+
+	r.budgets[budget.ID] = copyBudget(budget)
+	return nil
+}
+
+// FindByID 根據ID查找預算聚合
+func (r *FakeBudgetRepository) FindByID(id string) (*model.Budget, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	budget, exists := r.budgets[id]
+	if !exists {
+		return nil, nil // Not found
+	}
+
+	return copyBudget(budget), nil
+}
+
+// FindByUserID 根據用戶ID查找所有預算聚合
+func (r *FakeBudgetRepository) FindByUserID(userID string) ([]*model.Budget, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []*model.Budget
+	for _, budget := range r.budgets {
+		if budget.UserID == userID {
+			result = append(result, copyBudget(budget))
+		}
+	}
+	return result, nil
+}
+
+// Delete 根據ID刪除預算聚合
+func (r *FakeBudgetRepository) Delete(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if id == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+
+	delete(r.budgets, id)
+	return nil
+}
+
+func copyBudget(budget *model.Budget) *model.Budget {
+	copied := *budget
+	if budget.EndDate != nil {
+		endDate := *budget.EndDate
+		copied.EndDate = &endDate
+	}
+	return &copied
+}
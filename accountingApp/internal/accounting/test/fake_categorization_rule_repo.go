@@ -0,0 +1,90 @@
+package test
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"sync"
+	"time"
+)
+
+// FakeCategorizationRuleRepository 假的分類規則倉庫，用於測試
+type FakeCategorizationRuleRepository struct {
+	rules map[string]*model.CategorizationRule
+	mutex sync.RWMutex
+}
+
+// NewFakeCategorizationRuleRepository 建立新的假倉庫
+func NewFakeCategorizationRuleRepository() repository.CategorizationRuleRepository {
+	return &FakeCategorizationRuleRepository{
+		rules: make(map[string]*model.CategorizationRule),
+	}
+}
+
+// Save 儲存分類規則聚合
+func (r *FakeCategorizationRuleRepository) Save(rule *model.CategorizationRule) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if rule == nil {
+		return fmt.Errorf("categorization rule cannot be nil")
+	}
+
+	r.rules[rule.ID] = copyCategorizationRule(rule)
+	return nil
+}
+
+// FindByID 根據ID查找分類規則聚合
+func (r *FakeCategorizationRuleRepository) FindByID(id string) (*model.CategorizationRule, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	rule, exists := r.rules[id]
+	if !exists {
+		return nil, nil // Not found
+	}
+
+	return copyCategorizationRule(rule), nil
+}
+
+// FindByUserID 根據用戶ID查找所有分類規則聚合
+func (r *FakeCategorizationRuleRepository) FindByUserID(userID string) ([]*model.CategorizationRule, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []*model.CategorizationRule
+	for _, rule := range r.rules {
+		if rule.UserID == userID {
+			result = append(result, copyCategorizationRule(rule))
+		}
+	}
+	return result, nil
+}
+
+// Delete 根據ID刪除分類規則聚合
+func (r *FakeCategorizationRuleRepository) Delete(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if id == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+
+	delete(r.rules, id)
+	return nil
+}
+
+func copyCategorizationRule(rule *model.CategorizationRule) *model.CategorizationRule {
+	copied := *rule
+	copied.Tags = append([]string(nil), rule.Tags...)
+	copied.Conditions.Weekdays = append([]time.Weekday(nil), rule.Conditions.Weekdays...)
+	if rule.Conditions.MinAmount != nil {
+		minAmount := *rule.Conditions.MinAmount
+		copied.Conditions.MinAmount = &minAmount
+	}
+	if rule.Conditions.MaxAmount != nil {
+		maxAmount := *rule.Conditions.MaxAmount
+		copied.Conditions.MaxAmount = &maxAmount
+	}
+	return &copied
+}
@@ -0,0 +1,70 @@
+package test
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"sync"
+)
+
+// FakeDuplicateFlagRepository 假的重複標記倉庫，用於測試
+type FakeDuplicateFlagRepository struct {
+	flags map[string]*model.DuplicateFlag
+	mutex sync.RWMutex
+}
+
+// NewFakeDuplicateFlagRepository 建立新的假倉庫
+func NewFakeDuplicateFlagRepository() repository.DuplicateFlagRepository {
+	return &FakeDuplicateFlagRepository{
+		flags: make(map[string]*model.DuplicateFlag),
+	}
+}
+
+// Save 儲存重複標記
+func (r *FakeDuplicateFlagRepository) Save(flag *model.DuplicateFlag) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if flag == nil {
+		return fmt.Errorf("duplicate flag cannot be nil")
+	}
+
+	r.flags[flag.ID] = copyDuplicateFlag(flag)
+	return nil
+}
+
+// FindByID 根據ID查找重複標記
+func (r *FakeDuplicateFlagRepository) FindByID(id string) (*model.DuplicateFlag, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	flag, exists := r.flags[id]
+	if !exists {
+		return nil, nil // Not found
+	}
+
+	return copyDuplicateFlag(flag), nil
+}
+
+// FindByUserID 根據用戶ID與狀態查找重複標記
+func (r *FakeDuplicateFlagRepository) FindByUserID(userID string, status model.DuplicateStatus) ([]*model.DuplicateFlag, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []*model.DuplicateFlag
+	for _, flag := range r.flags {
+		if flag.UserID == userID && flag.Status == status {
+			result = append(result, copyDuplicateFlag(flag))
+		}
+	}
+	return result, nil
+}
+
+func copyDuplicateFlag(flag *model.DuplicateFlag) *model.DuplicateFlag {
+	copied := *flag
+	if flag.ResolvedAt != nil {
+		resolvedAt := *flag.ResolvedAt
+		copied.ResolvedAt = &resolvedAt
+	}
+	return &copied
+}
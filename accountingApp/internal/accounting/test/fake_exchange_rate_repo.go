@@ -0,0 +1,114 @@
+package test
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// FakeExchangeRateRepository 假的匯率倉庫，用於測試
+type FakeExchangeRateRepository struct {
+	rates map[string]*model.ExchangeRate
+	mutex sync.RWMutex
+}
+
+// NewFakeExchangeRateRepository 建立新的假倉庫
+func NewFakeExchangeRateRepository() *FakeExchangeRateRepository {
+	return &FakeExchangeRateRepository{
+		rates: make(map[string]*model.ExchangeRate),
+	}
+}
+
+// SaveAll 儲存一批匯率，同一組貨幣同一天的匯率會被覆蓋
+func (r *FakeExchangeRateRepository) SaveAll(rates []*model.ExchangeRate) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, rate := range rates {
+		if rate == nil {
+			return fmt.Errorf("exchange rate cannot be nil")
+		}
+	}
+	for _, rate := range rates {
+		r.rates[rate.ID] = copyExchangeRate(rate)
+	}
+	return nil
+}
+
+// FindLatest 查找指定日期 (含) 之前最近的匯率
+func (r *FakeExchangeRateRepository) FindLatest(base, quote string, onOrBefore time.Time) (*model.ExchangeRate, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var latest *model.ExchangeRate
+	for _, rate := range r.rates {
+		if rate.Base != base || rate.Quote != quote || rate.Date.After(onOrBefore) {
+			continue
+		}
+		if latest == nil || rate.Date.After(latest.Date) {
+			latest = rate
+		}
+	}
+	if latest == nil {
+		return nil, nil // Not found
+	}
+	return copyExchangeRate(latest), nil
+}
+
+// FindByPair 依日期由新到舊列出一組貨幣的所有匯率
+func (r *FakeExchangeRateRepository) FindByPair(base, quote string) ([]*model.ExchangeRate, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []*model.ExchangeRate
+	for _, rate := range r.rates {
+		if rate.Base == base && rate.Quote == quote {
+			result = append(result, copyExchangeRate(rate))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date.After(result[j].Date) })
+	return result, nil
+}
+
+// FindLatestPerPair 每組貨幣在指定日期 (含) 之前最近的一筆匯率，依貨幣排序
+func (r *FakeExchangeRateRepository) FindLatestPerPair(onOrBefore time.Time) ([]*model.ExchangeRate, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	latest := make(map[string]*model.ExchangeRate)
+	for _, rate := range r.rates {
+		if rate.Date.After(onOrBefore) {
+			continue
+		}
+		pair := rate.Base + "/" + rate.Quote
+		if current, exists := latest[pair]; !exists || rate.Date.After(current.Date) {
+			latest[pair] = rate
+		}
+	}
+
+	result := make([]*model.ExchangeRate, 0, len(latest))
+	for _, rate := range latest {
+		result = append(result, copyExchangeRate(rate))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Base != result[j].Base {
+			return result[i].Base < result[j].Base
+		}
+		return result[i].Quote < result[j].Quote
+	})
+	return result, nil
+}
+
+func copyExchangeRate(rate *model.ExchangeRate) *model.ExchangeRate {
+	copied := *rate
+	copied.Rate = new(big.Rat).Set(rate.Rate)
+	return &copied
+}
+
+// 確保FakeExchangeRateRepository實現ExchangeRateRepository介面
+var _ repository.ExchangeRateRepository = (*FakeExchangeRateRepository)(nil)
@@ -0,0 +1,116 @@
+package test
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"sync"
+)
+
+// FakeExpenseCategoryRepository 假的支出分類倉庫，用於測試
+type FakeExpenseCategoryRepository struct {
+	categories map[string]*model.ExpenseCategory
+	mutex      sync.RWMutex
+}
+
+// NewFakeExpenseCategoryRepository 建立新的假倉庫
+func NewFakeExpenseCategoryRepository() repository.ExpenseCategoryRepository {
+	return &FakeExpenseCategoryRepository{
+		categories: make(map[string]*model.ExpenseCategory),
+	}
+}
+
+// Save 儲存支出分類聚合
+func (r *FakeExpenseCategoryRepository) Save(category *model.ExpenseCategory) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if category == nil {
+		return fmt.Errorf("category cannot be nil")
+	}
+
+	// 複製分類以避免外部修改
+	r.categories[category.ID] = copyExpenseCategory(category)
+	return nil
+}
+
+// FindByID 根據ID查找支出分類聚合
+func (r *FakeExpenseCategoryRepository) FindByID(id string) (*model.ExpenseCategory, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+
+	category, exists := r.categories[id]
+	if !exists {
+		return nil, nil // Not found
+	}
+
+	// 返回複製以避免外部修改
+	return copyExpenseCategory(category), nil
+}
+
+// Delete 根據ID刪除支出分類聚合
+func (r *FakeExpenseCategoryRepository) Delete(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if id == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+
+	delete(r.categories, id)
+	return nil
+}
+
+// FindBySubcategoryID 根據子分類ID查找包含它的支出分類聚合
+func (r *FakeExpenseCategoryRepository) FindBySubcategoryID(subcategoryID string) (*model.ExpenseCategory, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if subcategoryID == "" {
+		return nil, fmt.Errorf("subcategory ID cannot be empty")
+	}
+
+	// 遍歷所有分類尋找包含該子分類的分類
+	for _, category := range r.categories {
+		for _, subcategory := range category.Subcategories {
+			if subcategory.ID == subcategoryID {
+				// 返回複製以避免外部修改
+				return copyExpenseCategory(category), nil
+			}
+		}
+	}
+
+	return nil, nil // Not found
+}
+
+// FindByUserID 根據用戶ID查找用戶的所有支出分類聚合
+func (r *FakeExpenseCategoryRepository) FindByUserID(userID string) ([]*model.ExpenseCategory, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+
+	var result []*model.ExpenseCategory
+
+	for _, category := range r.categories {
+		if category.UserID == userID {
+			// 返回複製以避免外部修改
+			result = append(result, copyExpenseCategory(category))
+		}
+	}
+
+	return result, nil
+}
+
+// copyExpenseCategory 連同子分類一起複製，避免聚合修改影響倉庫內的狀態
+func copyExpenseCategory(category *model.ExpenseCategory) *model.ExpenseCategory {
+	categoryData := *category
+	categoryData.Subcategories = append([]model.ExpenseSubcategory(nil), category.Subcategories...)
+	return &categoryData
+}
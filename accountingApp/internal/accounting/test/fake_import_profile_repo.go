@@ -0,0 +1,79 @@
+package test
+
+import (
+	"fmt"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"sync"
+)
+
+// FakeImportProfileRepository 假的匯入設定倉庫，用於測試
+type FakeImportProfileRepository struct {
+	profiles map[string]*model.ImportProfile
+	mutex    sync.RWMutex
+}
+
+// NewFakeImportProfileRepository 建立新的假倉庫
+func NewFakeImportProfileRepository() repository.ImportProfileRepository {
+	return &FakeImportProfileRepository{
+		profiles: make(map[string]*model.ImportProfile),
+	}
+}
+
+// Save 儲存匯入設定聚合
+func (r *FakeImportProfileRepository) Save(profile *model.ImportProfile) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if profile == nil {
+		return fmt.Errorf("import profile cannot be nil")
+	}
+
+	r.profiles[profile.ID] = copyImportProfile(profile)
+	return nil
+}
+
+// FindByID 根據ID查找匯入設定聚合
+func (r *FakeImportProfileRepository) FindByID(id string) (*model.ImportProfile, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	profile, exists := r.profiles[id]
+	if !exists {
+		return nil, nil // Not found
+	}
+
+	return copyImportProfile(profile), nil
+}
+
+// FindByUserID 根據用戶ID查找所有匯入設定聚合
+func (r *FakeImportProfileRepository) FindByUserID(userID string) ([]*model.ImportProfile, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []*model.ImportProfile
+	for _, profile := range r.profiles {
+		if profile.UserID == userID {
+			result = append(result, copyImportProfile(profile))
+		}
+	}
+	return result, nil
+}
+
+// Delete 根據ID刪除匯入設定聚合
+func (r *FakeImportProfileRepository) Delete(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if id == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+
+	delete(r.profiles, id)
+	return nil
+}
+
+func copyImportProfile(profile *model.ImportProfile) *model.ImportProfile {
+	copied := *profile
+	return &copied
+}
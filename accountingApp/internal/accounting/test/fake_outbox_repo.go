@@ -0,0 +1,140 @@
+package test
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+)
+
+// FakeOutboxRepository 假的Outbox倉庫，用於測試Relay與管理端點
+type FakeOutboxRepository struct {
+	messages   map[string]mapper.OutboxMessageData
+	mutex      sync.RWMutex
+	UpdateErrs map[string]error // 訊息ID -> Update 回傳的錯誤，模擬更新失敗
+}
+
+// NewFakeOutboxRepository 建立新的假倉庫
+func NewFakeOutboxRepository() *FakeOutboxRepository {
+	return &FakeOutboxRepository{
+		messages: make(map[string]mapper.OutboxMessageData),
+	}
+}
+
+// Add 模擬錢包儲存時寫入的訊息
+func (r *FakeOutboxRepository) Add(messages ...mapper.OutboxMessageData) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, message := range messages {
+		r.messages[message.ID] = message
+	}
+}
+
+// ClaimDue 取得到期的待投遞訊息並延後下次嘗試時間
+func (r *FakeOutboxRepository) ClaimDue(now time.Time, lease time.Duration, limit int) ([]mapper.OutboxMessageData, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var due []mapper.OutboxMessageData
+	for _, message := range r.messages {
+		if message.Status == mapper.OutboxStatusPending && !message.NextAttemptAt.After(now) {
+			due = append(due, message)
+		}
+	}
+	sortByOccurredAt(due)
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	for _, message := range due {
+		message.NextAttemptAt = now.Add(lease)
+		r.messages[message.ID] = message
+	}
+	return due, nil
+}
+
+// FindByID 根據ID查找訊息
+func (r *FakeOutboxRepository) FindByID(id string) (*mapper.OutboxMessageData, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	message, exists := r.messages[id]
+	if !exists {
+		return nil, nil // Not found
+	}
+	return &message, nil
+}
+
+// FindStuck 查找dead letter或仍在重試中的訊息
+func (r *FakeOutboxRepository) FindStuck(limit int) ([]mapper.OutboxMessageData, error) {
+	return r.filter(limit, func(message mapper.OutboxMessageData) bool {
+		return message.Status == mapper.OutboxStatusDeadLetter ||
+			(message.Status == mapper.OutboxStatusPending && message.Attempts > 0)
+	})
+}
+
+// FindByStatus 依狀態查找訊息
+func (r *FakeOutboxRepository) FindByStatus(status string, limit int) ([]mapper.OutboxMessageData, error) {
+	return r.filter(limit, func(message mapper.OutboxMessageData) bool {
+		return message.Status == status
+	})
+}
+
+// Update 更新訊息的投遞狀態
+func (r *FakeOutboxRepository) Update(message mapper.OutboxMessageData) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.messages[message.ID]; !exists {
+		return fmt.Errorf("outbox message %s not found", message.ID)
+	}
+	if err := r.UpdateErrs[message.ID]; err != nil {
+		return err
+	}
+	r.messages[message.ID] = message
+	return nil
+}
+
+// DeleteDelivered 刪除投遞時間早於before的已投遞訊息
+func (r *FakeOutboxRepository) DeleteDelivered(before time.Time) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	deleted := 0
+	for id, message := range r.messages {
+		if message.Status == mapper.OutboxStatusDelivered && message.DeliveredAt != nil && message.DeliveredAt.Before(before) {
+			delete(r.messages, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (r *FakeOutboxRepository) filter(limit int, match func(mapper.OutboxMessageData) bool) ([]mapper.OutboxMessageData, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []mapper.OutboxMessageData
+	for _, message := range r.messages {
+		if match(message) {
+			result = append(result, message)
+		}
+	}
+	sortByOccurredAt(result)
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func sortByOccurredAt(messages []mapper.OutboxMessageData) {
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].OccurredAt.Before(messages[j].OccurredAt)
+	})
+}
+
+var _ repository.OutboxRepository = (*FakeOutboxRepository)(nil)
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// FakeRecurringRuleRepository 假的週期規則倉庫，用於測試
+// 與PostgreSQL實作相同以版本號做樂觀鎖，版本不符時回傳ErrConcurrencyConflict
+type FakeRecurringRuleRepository struct {
+	rules map[string]*model.RecurringRule
+	mutex sync.RWMutex
+}
+
+// NewFakeRecurringRuleRepository 建立新的假倉庫
+func NewFakeRecurringRuleRepository() *FakeRecurringRuleRepository {
+	return &FakeRecurringRuleRepository{
+		rules: make(map[string]*model.RecurringRule),
+	}
+}
+
+// Save 儲存週期規則聚合，成功後同步版本號
+func (r *FakeRecurringRuleRepository) Save(rule *model.RecurringRule) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if rule == nil {
+		return fmt.Errorf("recurring rule cannot be nil")
+	}
+	if stored, exists := r.rules[rule.ID]; exists && stored.Version != rule.Version {
+		return fmt.Errorf("%w: recurring rule %s was modified since version %d", repository.ErrConcurrencyConflict, rule.ID, rule.Version)
+	}
+
+	rule.Version++
+	r.rules[rule.ID] = copyRecurringRule(rule)
+	return nil
+}
+
+// FindByID 根據ID查找週期規則聚合
+func (r *FakeRecurringRuleRepository) FindByID(id string) (*model.RecurringRule, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	rule, exists := r.rules[id]
+	if !exists {
+		return nil, nil // Not found
+	}
+
+	return copyRecurringRule(rule), nil
+}
+
+// FindByUserID 根據用戶ID查找所有週期規則聚合
+func (r *FakeRecurringRuleRepository) FindByUserID(userID string) ([]*model.RecurringRule, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []*model.RecurringRule
+	for _, rule := range r.rules {
+		if rule.UserID == userID {
+			result = append(result, copyRecurringRule(rule))
+		}
+	}
+	return result, nil
+}
+
+// FindActive 查找所有啟用中的週期規則聚合
+func (r *FakeRecurringRuleRepository) FindActive() ([]*model.RecurringRule, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []*model.RecurringRule
+	for _, rule := range r.rules {
+		if rule.Status == model.RecurringRuleActive {
+			result = append(result, copyRecurringRule(rule))
+		}
+	}
+	return result, nil
+}
+
+// Delete 根據ID刪除週期規則聚合
+func (r *FakeRecurringRuleRepository) Delete(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if id == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+
+	delete(r.rules, id)
+	return nil
+}
+
+func copyRecurringRule(rule *model.RecurringRule) *model.RecurringRule {
+	copied := *rule
+	if rule.Schedule.EndDate != nil {
+		endDate := *rule.Schedule.EndDate
+		copied.Schedule.EndDate = &endDate
+	}
+	if rule.PausedAt != nil {
+		pausedAt := *rule.PausedAt
+		copied.PausedAt = &pausedAt
+	}
+	copied.Occurrences = append([]model.RecurringOccurrence(nil), rule.Occurrences...)
+	return &copied
+}
+
+// 確保FakeRecurringRuleRepository實現RecurringRuleRepository介面
+var _ repository.RecurringRuleRepository = (*FakeRecurringRuleRepository)(nil)
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// FakeUnitOfWork 以FakeWalletRepo模擬交易
+// 執行前對錢包資料做快照，fn回傳錯誤時還原快照 (模擬回滾)
+type FakeUnitOfWork struct {
+	walletRepo *FakeWalletRepo
+
+	// ScopeWalletRepo 交易範圍內使用的Repository，預設為walletRepo
+	// 測試可替換為會失敗的包裝以驗證回滾
+	ScopeWalletRepo repository.WalletRepository
+
+	Commits   int
+	Rollbacks int
+}
+
+func NewFakeUnitOfWork(walletRepo *FakeWalletRepo) *FakeUnitOfWork {
+	return &FakeUnitOfWork{
+		walletRepo:      walletRepo,
+		ScopeWalletRepo: walletRepo,
+	}
+}
+
+func (u *FakeUnitOfWork) Do(fn func(scope repository.TransactionScope) error) error {
+	snapshot := u.walletRepo.snapshot()
+
+	if err := fn(fakeTransactionScope{wallets: u.ScopeWalletRepo}); err != nil {
+		u.walletRepo.restore(snapshot)
+		u.Rollbacks++
+		return err
+	}
+
+	u.Commits++
+	return nil
+}
+
+type fakeTransactionScope struct {
+	wallets repository.WalletRepository
+}
+
+func (s fakeTransactionScope) Wallets() repository.WalletRepository {
+	return s.wallets
+}
+
+// snapshot 以Data Model深拷貝目前所有錢包 (含子實體)
+func (f *FakeWalletRepo) snapshot() map[string]mapper.WalletData {
+	walletMapper := mapper.NewWalletMapper()
+	snapshot := make(map[string]mapper.WalletData, len(f.data))
+	for id, wallet := range f.data {
+		data := walletMapper.ToData(wallet)
+		data.IsFullyLoaded = true
+		snapshot[id] = data
+	}
+	return snapshot
+}
+
+// restore 以快照重建錢包資料
+func (f *FakeWalletRepo) restore(snapshot map[string]mapper.WalletData) {
+	walletMapper := mapper.NewWalletMapper()
+	f.data = make(map[string]*model.Wallet, len(snapshot))
+	for id, data := range snapshot {
+		wallet, _ := walletMapper.ToDomain(data)
+		f.data[id] = wallet
+	}
+}
+
+var _ repository.UnitOfWork = (*FakeUnitOfWork)(nil)
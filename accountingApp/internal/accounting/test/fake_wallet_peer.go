@@ -0,0 +1,331 @@
+package test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/adapter/store"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+)
+
+// FakeWalletPeer 以記憶體保存錢包資料結構的WalletRepositoryPeer，搭配 repository.NewWalletRepositoryImpl 使用
+// 與PostgreSQL實作相同：只寫入子實體的差異、檢查版本，並在儲存錢包時把稽核紀錄接到AuditLog
+type FakeWalletPeer struct {
+	data     map[string]mapper.WalletData
+	AuditLog *FakeAuditLogRepository
+
+	// AuditErr 不為nil時寫入稽核紀錄失敗，錢包也不會被儲存 (模擬交易回滾)
+	AuditErr error
+
+	mutex sync.Mutex
+}
+
+// NewFakeWalletPeer 建立新的假Peer，稽核紀錄寫入auditLog
+func NewFakeWalletPeer(auditLog *FakeAuditLogRepository) *FakeWalletPeer {
+	return &FakeWalletPeer{data: make(map[string]mapper.WalletData), AuditLog: auditLog}
+}
+
+func (p *FakeWalletPeer) Save(data mapper.WalletData) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	stored, exists := p.data[data.ID]
+	if exists && stored.Version != data.Version {
+		return fmt.Errorf("%w: wallet %s was modified since version %d", repository.ErrConcurrencyConflict, data.ID, data.Version)
+	}
+
+	if data.Audit != nil {
+		var previous *mapper.WalletData
+		if exists {
+			previous = walletHeader(stored)
+		}
+		if err := p.appendAudit(*data.Audit, previous); err != nil {
+			return err
+		}
+	}
+
+	saved := *walletHeader(data)
+	saved.Version = data.Version + 1
+	saved.IncomeRecords = applyChildChanges(stored.IncomeRecords, data.IncomeRecords, data.IncomeRecordChanges)
+	saved.ExpenseRecords = applyChildChanges(stored.ExpenseRecords, data.ExpenseRecords, data.ExpenseRecordChanges)
+	saved.Transfers = applyChildChanges(stored.Transfers, data.Transfers, data.TransferChanges)
+	p.data[data.ID] = saved
+	return nil
+}
+
+func (p *FakeWalletPeer) FindByID(id string) (*mapper.WalletData, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	stored, exists := p.data[id]
+	if !exists {
+		return nil, nil
+	}
+	return walletHeader(stored), nil
+}
+
+func (p *FakeWalletPeer) FindByIDWithChildEntities(id string) (*mapper.WalletData, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.fullyLoaded(id), nil
+}
+
+func (p *FakeWalletPeer) FindByUserID(userID string) ([]mapper.WalletData, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var wallets []mapper.WalletData
+	for _, stored := range p.data {
+		if stored.UserID == userID {
+			wallets = append(wallets, *walletHeader(stored))
+		}
+	}
+	return wallets, nil
+}
+
+func (p *FakeWalletPeer) FindByExpenseRecordID(expenseID string) (*mapper.WalletData, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for id, stored := range p.data {
+		for _, record := range stored.ExpenseRecords {
+			if record.ID == expenseID {
+				return p.fullyLoaded(id), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (p *FakeWalletPeer) FindByIncomeRecordID(incomeID string) (*mapper.WalletData, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for id, stored := range p.data {
+		for _, record := range stored.IncomeRecords {
+			if record.ID == incomeID {
+				return p.fullyLoaded(id), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (p *FakeWalletPeer) FindByTransferID(transferID string) (*mapper.WalletData, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for id, stored := range p.data {
+		for _, transfer := range stored.Transfers {
+			if transfer.ID == transferID && transfer.FromWalletID == id {
+				return p.fullyLoaded(id), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (p *FakeWalletPeer) SumExpensesByUserID(userID string, from, to time.Time) ([]mapper.ExpenseTotalData, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var totals []mapper.ExpenseTotalData
+	for _, stored := range p.data {
+		if stored.UserID != userID {
+			continue
+		}
+		for _, record := range stored.ExpenseRecords {
+			if record.Date.Before(from) || !record.Date.Before(to) {
+				continue
+			}
+			if len(record.Splits) == 0 {
+				totals = append(totals, mapper.ExpenseTotalData{SubcategoryID: record.SubcategoryID, Currency: record.Currency, Date: record.Date, Amount: record.Amount})
+			}
+			for _, split := range record.Splits {
+				totals = append(totals, mapper.ExpenseTotalData{SubcategoryID: split.SubcategoryID, Currency: split.Currency, Date: record.Date, Amount: split.Amount})
+			}
+		}
+	}
+	return totals, nil
+}
+
+func (p *FakeWalletPeer) FindRecordsByDateRange(walletID string, from, to time.Time) ([]mapper.ExpenseRecordData, []mapper.IncomeRecordData, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	stored, exists := p.data[walletID]
+	if !exists {
+		return nil, nil, nil
+	}
+	var expenses []mapper.ExpenseRecordData
+	for _, record := range stored.ExpenseRecords {
+		if !record.Date.Before(from) && record.Date.Before(to) {
+			expenses = append(expenses, record)
+		}
+	}
+	var incomes []mapper.IncomeRecordData
+	for _, record := range stored.IncomeRecords {
+		if !record.Date.Before(from) && record.Date.Before(to) {
+			incomes = append(incomes, record)
+		}
+	}
+	return expenses, incomes, nil
+}
+
+func (p *FakeWalletPeer) Delete(id string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, exists := p.data[id]; !exists {
+		return fmt.Errorf("aggregate with id %s not found in table wallets", id)
+	}
+	delete(p.data, id)
+	return nil
+}
+
+func (p *FakeWalletPeer) DeleteWithAudit(id string, audit mapper.WalletAuditData) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	stored, exists := p.data[id]
+	if !exists {
+		return fmt.Errorf("aggregate with id %s not found in table wallets", id)
+	}
+	if err := p.appendAudit(audit, walletHeader(stored)); err != nil {
+		return err
+	}
+	delete(p.data, id)
+	return nil
+}
+
+// appendAudit 以儲存前的錢包欄位補齊快照並寫入AuditLog
+func (p *FakeWalletPeer) appendAudit(audit mapper.WalletAuditData, previous *mapper.WalletData) error {
+	if p.AuditErr != nil {
+		return fmt.Errorf("failed to save audit entry: %w", p.AuditErr)
+	}
+	entry, err := audit.Complete(previous)
+	if err != nil {
+		return err
+	}
+	_, err = p.AuditLog.Append(entry)
+	return err
+}
+
+// fullyLoaded 複製錢包資料與所有子實體，不存在時回傳nil
+func (p *FakeWalletPeer) fullyLoaded(id string) *mapper.WalletData {
+	stored, exists := p.data[id]
+	if !exists {
+		return nil
+	}
+	data := *walletHeader(stored)
+	data.IncomeRecords = append([]mapper.IncomeRecordData(nil), stored.IncomeRecords...)
+	data.ExpenseRecords = append([]mapper.ExpenseRecordData(nil), stored.ExpenseRecords...)
+	data.Transfers = append([]mapper.TransferData(nil), stored.Transfers...)
+	data.IsFullyLoaded = true
+	return &data
+}
+
+// walletHeader 只複製錢包欄位，不含子實體與儲存過程的暫存資料
+func walletHeader(data mapper.WalletData) *mapper.WalletData {
+	return &mapper.WalletData{
+		ID:              data.ID,
+		UserID:          data.UserID,
+		Name:            data.Name,
+		Type:            data.Type,
+		Currency:        data.Currency,
+		BalanceAmount:   data.BalanceAmount,
+		BalanceCurrency: data.BalanceCurrency,
+		CreatedAt:       data.CreatedAt,
+		UpdatedAt:       data.UpdatedAt,
+		Version:         data.Version,
+	}
+}
+
+// applyChildChanges 依變更記錄把新增、修改與刪除的子實體套用到已儲存的子實體
+func applyChildChanges[T store.AggregateData](stored, children []T, changes mapper.ChildEntityChanges) []T {
+	removed := make(map[string]bool, len(changes.Removed))
+	for _, id := range changes.Removed {
+		removed[id] = true
+	}
+	upserted := changes.Upserted()
+
+	var result []T
+	replaced := make(map[string]bool)
+	for _, child := range stored {
+		if removed[child.GetID()] {
+			continue
+		}
+		if upserted[child.GetID()] {
+			for _, changed := range children {
+				if changed.GetID() == child.GetID() {
+					child = changed
+				}
+			}
+			replaced[child.GetID()] = true
+		}
+		result = append(result, child)
+	}
+	for _, child := range children {
+		if upserted[child.GetID()] && !replaced[child.GetID()] {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+// snapshot 複製所有錢包資料與目前的稽核紀錄筆數
+func (p *FakeWalletPeer) snapshot() (map[string]mapper.WalletData, int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	data := make(map[string]mapper.WalletData, len(p.data))
+	for id := range p.data {
+		data[id] = *p.fullyLoaded(id)
+	}
+	return data, len(p.AuditLog.Entries())
+}
+
+// restore 還原錢包資料，並移除之後寫入的稽核紀錄
+func (p *FakeWalletPeer) restore(data map[string]mapper.WalletData, auditEntries int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.data = data
+	p.AuditLog.truncate(auditEntries)
+}
+
+// FakeWalletPeerUnitOfWork 以FakeWalletPeer模擬交易，fn回傳錯誤時還原錢包資料與稽核紀錄 (模擬回滾)
+type FakeWalletPeerUnitOfWork struct {
+	peer  *FakeWalletPeer
+	audit *repository.AuditContext
+}
+
+// NewFakeWalletPeerUnitOfWork 建立使用peer的Unit of Work
+func NewFakeWalletPeerUnitOfWork(peer *FakeWalletPeer) *FakeWalletPeerUnitOfWork {
+	return &FakeWalletPeerUnitOfWork{peer: peer}
+}
+
+func (u *FakeWalletPeerUnitOfWork) Do(fn func(scope repository.TransactionScope) error) error {
+	data, auditEntries := u.peer.snapshot()
+
+	wallets := repository.NewWalletRepositoryImpl(u.peer, nil)
+	scope := fakeTransactionScope{wallets: wallets}
+	if u.audit != nil {
+		scope.wallets = wallets.WithAudit(*u.audit)
+	}
+
+	if err := fn(scope); err != nil {
+		u.peer.restore(data, auditEntries)
+		return err
+	}
+	return nil
+}
+
+func (u *FakeWalletPeerUnitOfWork) WithAudit(ctx repository.AuditContext) repository.UnitOfWork {
+	return &FakeWalletPeerUnitOfWork{peer: u.peer, audit: &ctx}
+}
+
+var _ repository.WalletRepositoryPeer = (*FakeWalletPeer)(nil)
+var _ repository.AuditableUnitOfWork = (*FakeWalletPeerUnitOfWork)(nil)
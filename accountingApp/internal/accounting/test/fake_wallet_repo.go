@@ -1,6 +1,8 @@
 package test
 
 import (
+	"time"
+
 	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
 	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
 )
@@ -101,4 +103,72 @@ func (f *FakeWalletRepo) FindDataByUserID(userID string) ([]mapper.WalletData, e
 		dataList = append(dataList, data)
 	}
 	return dataList, nil
-}
\ No newline at end of file
+}
+
+func (f *FakeWalletRepo) FindByExpenseRecordID(expenseID string) (*model.Wallet, error) {
+	for _, wallet := range f.data {
+		for _, record := range wallet.GetExpenseRecords() {
+			if record.ID == expenseID {
+				return f.FindByIDWithTransactions(wallet.ID)
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakeWalletRepo) FindByTransferID(transferID string) (*model.Wallet, error) {
+	for _, wallet := range f.data {
+		for _, transfer := range wallet.GetTransfers() {
+			if transfer.ID == transferID && transfer.FromWalletID == wallet.ID {
+				return f.FindByIDWithTransactions(wallet.ID)
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakeWalletRepo) FindByIncomeRecordID(incomeID string) (*model.Wallet, error) {
+	for _, wallet := range f.data {
+		for _, record := range wallet.GetIncomeRecords() {
+			if record.ID == incomeID {
+				return f.FindByIDWithTransactions(wallet.ID)
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (f *FakeWalletRepo) SumExpensesByUserID(userID string, from, to time.Time) ([]model.SpentAmount, error) {
+	var amounts []model.SpentAmount
+	for _, wallet := range f.data {
+		if wallet.UserID != userID {
+			continue
+		}
+		for _, record := range wallet.GetExpenseRecords() {
+			if !record.Date.Before(from) && record.Date.Before(to) {
+				amounts = append(amounts, record.SpentAmounts()...)
+			}
+		}
+	}
+	return amounts, nil
+}
+
+func (f *FakeWalletRepo) FindRecordsByDateRange(walletID string, from, to time.Time) ([]model.ExpenseRecord, []model.IncomeRecord, error) {
+	wallet, ok := f.data[walletID]
+	if !ok {
+		return nil, nil, nil
+	}
+	var expenses []model.ExpenseRecord
+	for _, record := range wallet.GetExpenseRecords() {
+		if !record.Date.Before(from) && record.Date.Before(to) {
+			expenses = append(expenses, record)
+		}
+	}
+	var incomes []model.IncomeRecord
+	for _, record := range wallet.GetIncomeRecords() {
+		if !record.Date.Before(from) && record.Date.Before(to) {
+			incomes = append(incomes, record)
+		}
+	}
+	return expenses, incomes, nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	pgrepository "github.com/JingHsiu/accountingApp/internal/accounting/adapter/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/database"
+)
+
+// auditLogDatabaseClient 記錄執行的SQL與參數，查詢鏈尾時回傳tail
+type auditLogDatabaseClient struct {
+	recordingDatabaseClient
+	tail     *mapper.AuditEntryData
+	lastArgs []interface{}
+}
+
+func (c *auditLogDatabaseClient) Query(query string, args ...interface{}) (database.RowsScanner, error) {
+	var rows []mapper.AuditEntryData
+	if c.tail != nil {
+		rows = append(rows, *c.tail)
+	}
+	return &auditRows{rows: rows, index: -1}, nil
+}
+
+func (c *auditLogDatabaseClient) Exec(query string, args ...interface{}) (database.ExecResult, error) {
+	c.lastArgs = args
+	return c.recordingDatabaseClient.Exec(query, args...)
+}
+
+func (c *auditLogDatabaseClient) BeginTx() (database.Transaction, error) {
+	return auditLogTransaction{c}, nil
+}
+
+type auditLogTransaction struct {
+	*auditLogDatabaseClient
+}
+
+func (auditLogTransaction) Commit() error   { return nil }
+func (auditLogTransaction) Rollback() error { return nil }
+
+type auditRows struct {
+	rows  []mapper.AuditEntryData
+	index int
+}
+
+func (r *auditRows) Next() bool {
+	r.index++
+	return r.index < len(r.rows)
+}
+
+func (r *auditRows) Scan(dest ...interface{}) error {
+	row := r.rows[r.index]
+	*dest[0].(*int64) = row.Sequence
+	*dest[1].(*string) = row.ID
+	*dest[2].(*string) = row.ActorID
+	*dest[3].(*string) = row.Command
+	*dest[4].(*string) = row.AggregateType
+	*dest[5].(*string) = row.AggregateID
+	*dest[6].(*[]byte) = row.Before
+	*dest[7].(*[]byte) = row.After
+	*dest[8].(*string) = row.RequestID
+	*dest[9].(*time.Time) = row.OccurredAt
+	*dest[10].(*string) = row.PrevHash
+	*dest[11].(*string) = row.Hash
+	return nil
+}
+
+func (r *auditRows) Close() error { return nil }
+
+func TestPgAuditLogRepository_Append_ChainsOnTail(t *testing.T) {
+	// Arrange
+	tail := mapper.ChainAuditEntry(mapper.AuditEntryData{
+		ID: "entry-1", ActorID: "user-1", Command: "CreateWallet",
+		AggregateType: mapper.AuditAggregateWallet, AggregateID: "wallet-1",
+		After: []byte(`{"Name":"Main"}`), OccurredAt: time.Now(),
+	}, nil)
+	client := &auditLogDatabaseClient{tail: &tail}
+	repo := pgrepository.NewPgAuditLogRepository(client)
+
+	// Act
+	appended, err := repo.Append(mapper.AuditEntryData{
+		ID: "entry-2", ActorID: "user-1", Command: "DeleteWallet",
+		AggregateType: mapper.AuditAggregateWallet, AggregateID: "wallet-1",
+		Before: []byte(`{"Name":"Main"}`), RequestID: "req-1", OccurredAt: time.Now(),
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if appended.Sequence != 2 || appended.PrevHash != tail.Hash || appended.Hash != appended.ComputeHash() {
+		t.Errorf("Expected entry chained after the tail, got %+v", appended)
+	}
+	if len(client.statements) != 2 ||
+		!strings.HasPrefix(client.statements[0], "LOCK TABLE audit_log") ||
+		!strings.HasPrefix(client.statements[1], "INSERT INTO audit_log") {
+		t.Fatalf("Expected lock then insert, got %v", client.statements)
+	}
+	// 刪除後沒有後快照，寫入NULL
+	if client.lastArgs[7] != nil {
+		t.Errorf("Expected a NULL after snapshot, got %v", client.lastArgs[7])
+	}
+}
+
+func TestAuditEntry_ChainStartsAtGenesis(t *testing.T) {
+	// Arrange
+	occurredAt := time.Date(2026, 3, 1, 12, 0, 0, 123456789, time.FixedZone("UTC+8", 8*3600))
+
+	// Act
+	entry := mapper.ChainAuditEntry(mapper.AuditEntryData{ID: "entry-1", OccurredAt: occurredAt}, nil)
+
+	// Assert - 時間正規化為資料庫精度，讀回後重算雜湊仍相同
+	if entry.Sequence != 1 || entry.PrevHash != mapper.AuditGenesisHash {
+		t.Errorf("Expected the first entry to start at the genesis hash, got %+v", entry)
+	}
+	if entry.OccurredAt.Location() != time.UTC || entry.OccurredAt.Nanosecond() != 123456000 {
+		t.Errorf("Expected occurred_at normalized to UTC microseconds, got %v", entry.OccurredAt)
+	}
+	if entry.Hash != entry.ComputeHash() {
+		t.Error("Expected hash to be reproducible")
+	}
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	pgrepository "github.com/JingHsiu/accountingApp/internal/accounting/adapter/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/database"
+)
+
+// MockExpenseCategoryRepositoryPeer 以記憶體保存資料結構的Peer
+type MockExpenseCategoryRepositoryPeer struct {
+	data map[string]mapper.ExpenseCategoryData
+}
+
+func NewMockExpenseCategoryRepositoryPeer() *MockExpenseCategoryRepositoryPeer {
+	return &MockExpenseCategoryRepositoryPeer{data: make(map[string]mapper.ExpenseCategoryData)}
+}
+
+func (m *MockExpenseCategoryRepositoryPeer) SaveData(data mapper.ExpenseCategoryData) error {
+	m.data[data.ID] = data
+	return nil
+}
+
+func (m *MockExpenseCategoryRepositoryPeer) FindDataByID(id string) (*mapper.ExpenseCategoryData, error) {
+	if data, exists := m.data[id]; exists {
+		return &data, nil
+	}
+	return nil, nil
+}
+
+func (m *MockExpenseCategoryRepositoryPeer) FindDataBySubcategoryID(subcategoryID string) (*mapper.ExpenseCategoryData, error) {
+	for _, data := range m.data {
+		for _, subcategory := range data.Subcategories {
+			if subcategory.ID == subcategoryID {
+				return &data, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockExpenseCategoryRepositoryPeer) FindDataByUserID(userID string) ([]mapper.ExpenseCategoryData, error) {
+	var result []mapper.ExpenseCategoryData
+	for _, data := range m.data {
+		if data.UserID == userID {
+			result = append(result, data)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockExpenseCategoryRepositoryPeer) DeleteData(id string) error {
+	delete(m.data, id)
+	return nil
+}
+
+func newExpenseCategoryWithSubcategories(t *testing.T, names ...string) *model.ExpenseCategory {
+	categoryName, _ := model.NewCategoryName("Food")
+	category, err := model.NewExpenseCategory("user-1", *categoryName)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, name := range names {
+		subcategoryName, _ := model.NewCategoryName(name)
+		if _, err := category.AddSubcategory(*subcategoryName); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	return category
+}
+
+func TestExpenseCategoryRepositoryImpl_RoundTripsSubcategories(t *testing.T) {
+	// Arrange
+	repo := repository.NewExpenseCategoryRepositoryImpl(NewMockExpenseCategoryRepositoryPeer(), nil)
+	category := newExpenseCategoryWithSubcategories(t, "Lunch", "Dinner")
+
+	// Act
+	if err := repo.Save(category); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	bySubcategory, _ := repo.FindBySubcategoryID(category.Subcategories[1].ID)
+	byUser, _ := repo.FindByUserID("user-1")
+
+	// Assert
+	if bySubcategory == nil || bySubcategory.ID != category.ID {
+		t.Fatalf("Expected category %s by subcategory ID, got %v", category.ID, bySubcategory)
+	}
+	if len(bySubcategory.Subcategories) != 2 || bySubcategory.Subcategories[1].Name.Value != "Dinner" {
+		t.Errorf("Expected subcategories to be restored, got %v", bySubcategory.Subcategories)
+	}
+	if len(byUser) != 1 {
+		t.Errorf("Expected 1 category for user, got %d", len(byUser))
+	}
+}
+
+func TestPgExpenseCategoryPeer_SaveData_SyncsSubcategories(t *testing.T) {
+	// Arrange
+	client := &recordingDatabaseClient{}
+	peer := pgrepository.NewPgExpenseCategoryRepositoryPeerAdapter(database.NewPgExpenseCategoryStore(client), client)
+	repo := repository.NewExpenseCategoryRepositoryImpl(peer, nil)
+	category := newExpenseCategoryWithSubcategories(t, "Lunch", "Dinner")
+
+	// Act
+	if err := repo.Save(category); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert - 分類upsert、刪除不在聚合內的子分類、逐筆upsert子分類
+	if got := client.countPrefix("INSERT INTO expense_categories"); got != 1 {
+		t.Errorf("Expected 1 category upsert, got %d", got)
+	}
+	if got := client.countPrefix("INSERT INTO expense_subcategories"); got != 2 {
+		t.Errorf("Expected 2 subcategory upserts, got %d", got)
+	}
+	if got := client.countPrefix("DELETE FROM expense_subcategories"); got != 1 {
+		t.Fatalf("Expected 1 subcategory cleanup, got %d", got)
+	}
+	for _, statement := range client.statements {
+		if strings.HasPrefix(statement, "DELETE FROM expense_subcategories") && !strings.Contains(statement, "id NOT IN ($2, $3)") {
+			t.Errorf("Expected cleanup to keep both subcategories, got %q", statement)
+		}
+	}
+}
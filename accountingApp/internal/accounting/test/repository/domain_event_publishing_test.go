@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pgrepository "github.com/JingHsiu/accountingApp/internal/accounting/adapter/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/event"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// recordingHandler 記錄收到的事件名稱
+type recordingHandler struct {
+	received []string
+}
+
+func (h *recordingHandler) Handle(e model.DomainEvent) error {
+	h.received = append(h.received, e.EventName())
+	return nil
+}
+
+func TestWalletRepositoryImpl_Save_PublishesEventsAfterSave(t *testing.T) {
+	// Arrange
+	dispatcher := event.NewDispatcher()
+	expenseHandler := &recordingHandler{}
+	allHandler := &recordingHandler{}
+	dispatcher.Subscribe(model.EventExpenseAdded, expenseHandler)
+	dispatcher.SubscribeAll(allHandler)
+	repo := repository.NewWalletRepositoryImpl(NewMockWalletRepositoryPeer(), dispatcher)
+
+	wallet, _ := model.NewWalletWithInitialBalance("test-user", "Test Wallet", model.WalletTypeCash, "USD", 1000)
+	amount, _ := model.NewMoney(100, "USD")
+	wallet.AddExpense(*amount, "food", "Coffee", time.Now())
+
+	// Act
+	err := repo.Save(wallet)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(expenseHandler.received) != 1 {
+		t.Errorf("Expected 1 expense event, got %v", expenseHandler.received)
+	}
+	if len(allHandler.received) != 2 || allHandler.received[0] != model.EventWalletCreated {
+		t.Errorf("Expected wallet created and expense added events, got %v", allHandler.received)
+	}
+	if len(wallet.DomainEvents()) != 0 {
+		t.Error("Expected domain events to be cleared after save")
+	}
+
+	// 再次儲存不會重複發布
+	repo.Save(wallet)
+	if len(allHandler.received) != 2 {
+		t.Errorf("Expected no events to be republished, got %v", allHandler.received)
+	}
+}
+
+func TestWalletRepositoryImpl_Save_FailureDoesNotPublish(t *testing.T) {
+	// Arrange
+	dispatcher := event.NewDispatcher()
+	handler := &recordingHandler{}
+	dispatcher.SubscribeAll(handler)
+	mockPeer := NewMockWalletRepositoryPeer()
+	mockPeer.saveFunc = func(data mapper.WalletData) error {
+		return errors.New("database unavailable")
+	}
+	repo := repository.NewWalletRepositoryImpl(mockPeer, dispatcher)
+	wallet, _ := model.NewWallet("test-user", "Test Wallet", model.WalletTypeCash, "USD")
+
+	// Act
+	err := repo.Save(wallet)
+
+	// Assert - 事件保留在聚合中，等待下一次成功儲存
+	if err == nil {
+		t.Fatal("Expected save error")
+	}
+	if len(handler.received) != 0 {
+		t.Errorf("Expected no events to be published, got %v", handler.received)
+	}
+	if len(wallet.DomainEvents()) != 1 {
+		t.Errorf("Expected events to remain on the aggregate, got %d", len(wallet.DomainEvents()))
+	}
+}
+
+func TestWalletRepositoryImpl_Delete_PublishesWalletDeleted(t *testing.T) {
+	// Arrange
+	dispatcher := event.NewDispatcher()
+	var deleted []model.WalletDeleted
+	dispatcher.Subscribe(model.EventWalletDeleted, event.HandlerFunc(func(e model.DomainEvent) error {
+		deleted = append(deleted, e.(model.WalletDeleted))
+		return nil
+	}))
+	repo := repository.NewWalletRepositoryImpl(NewMockWalletRepositoryPeer(), dispatcher)
+	wallet, _ := model.NewWallet("test-user", "Test Wallet", model.WalletTypeCash, "USD")
+	repo.Save(wallet)
+
+	// Act
+	err := repo.Delete(wallet.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].WalletID != wallet.ID || deleted[0].UserID != "test-user" {
+		t.Errorf("Expected one wallet deleted event for the wallet's owner, got %v", deleted)
+	}
+}
+
+func TestDispatcher_HandlerFailureDoesNotStopOthers(t *testing.T) {
+	// Arrange
+	dispatcher := event.NewDispatcher()
+	var failures []string
+	dispatcher.OnError = func(e model.DomainEvent, err error) {
+		failures = append(failures, err.Error())
+	}
+	dispatcher.SubscribeAll(event.HandlerFunc(func(e model.DomainEvent) error {
+		return errors.New("handler failed")
+	}))
+	dispatcher.SubscribeAll(event.HandlerFunc(func(e model.DomainEvent) error {
+		panic("handler panicked")
+	}))
+	handler := &recordingHandler{}
+	dispatcher.SubscribeAll(handler)
+	repo := repository.NewWalletRepositoryImpl(NewMockWalletRepositoryPeer(), dispatcher)
+	wallet, _ := model.NewWallet("test-user", "Test Wallet", model.WalletTypeCash, "USD")
+
+	// Act
+	err := repo.Save(wallet)
+
+	// Assert - 儲存已完成，訂閱者失敗不回報為儲存錯誤
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(handler.received) != 1 {
+		t.Errorf("Expected remaining handler to receive the event, got %v", handler.received)
+	}
+	if len(failures) != 2 {
+		t.Errorf("Expected 2 reported failures, got %v", failures)
+	}
+}
+
+func TestExpenseCategoryRepositoryImpl_Save_PublishesEvents(t *testing.T) {
+	// Arrange
+	dispatcher := event.NewDispatcher()
+	handler := &recordingHandler{}
+	dispatcher.Subscribe(model.EventSubcategoryAdded, handler)
+	repo := repository.NewExpenseCategoryRepositoryImpl(NewMockExpenseCategoryRepositoryPeer(), dispatcher)
+	category := newExpenseCategoryWithSubcategories(t, "Lunch", "Dinner")
+
+	// Act
+	if err := repo.Save(category); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert
+	if len(handler.received) != 2 {
+		t.Errorf("Expected 2 subcategory events, got %v", handler.received)
+	}
+	if len(category.DomainEvents()) != 0 {
+		t.Error("Expected domain events to be cleared after save")
+	}
+}
+
+func TestPgUnitOfWork_PublishesOnlyAfterCommit(t *testing.T) {
+	// Arrange
+	dispatcher := event.NewDispatcher()
+	handler := &recordingHandler{}
+	dispatcher.SubscribeAll(handler)
+	uow := pgrepository.NewPgUnitOfWork(&recordingDatabaseClient{}, dispatcher)
+
+	// Act - 交易回滾
+	err := uow.Do(func(scope repository.TransactionScope) error {
+		wallet, _ := model.NewWallet("test-user", "Rolled Back", model.WalletTypeCash, "USD")
+		if err := scope.Wallets().Save(wallet); err != nil {
+			return err
+		}
+		if len(handler.received) != 0 {
+			t.Error("Expected no events to be published before commit")
+		}
+		return errors.New("abort")
+	})
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected transaction error")
+	}
+	if len(handler.received) != 0 {
+		t.Errorf("Expected rolled back events to be discarded, got %v", handler.received)
+	}
+
+	// Act - 交易提交
+	err = uow.Do(func(scope repository.TransactionScope) error {
+		wallet, _ := model.NewWallet("test-user", "Committed", model.WalletTypeCash, "USD")
+		return scope.Wallets().Save(wallet)
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(handler.received) != 1 || handler.received[0] != model.EventWalletCreated {
+		t.Errorf("Expected wallet created event after commit, got %v", handler.received)
+	}
+}
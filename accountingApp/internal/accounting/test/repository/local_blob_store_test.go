@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/storage"
+)
+
+func TestLocalBlobStore_PutGetDelete(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	store, err := storage.NewLocalBlobStore(root)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	if err := store.Put("attachments/ab/abc-123", strings.NewReader("receipt")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	reader, err := store.Get("attachments/ab/abc-123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	content, _ := io.ReadAll(reader)
+	reader.Close()
+
+	// Assert
+	if string(content) != "receipt" {
+		t.Errorf("Expected stored content, got %q", content)
+	}
+	if _, err := os.Stat(filepath.Join(root, "attachments", "ab", "abc-123")); err != nil {
+		t.Errorf("Expected the blob under the root directory: %v", err)
+	}
+
+	if err := store.Delete("attachments/ab/abc-123"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := store.Get("attachments/ab/abc-123"); !errors.Is(err, repository.ErrBlobNotFound) {
+		t.Errorf("Expected ErrBlobNotFound after delete, got %v", err)
+	}
+	// 刪除不存在的檔案視為成功
+	if err := store.Delete("attachments/ab/abc-123"); err != nil {
+		t.Errorf("Expected deleting a missing blob to succeed, got %v", err)
+	}
+}
+
+func TestLocalBlobStore_RejectsKeysOutsideRoot(t *testing.T) {
+	// Arrange
+	store, _ := storage.NewLocalBlobStore(t.TempDir())
+
+	for _, key := range []string{"", "../escape", "attachments/../../escape", "/etc/passwd", `..\escape`, "."} {
+		// Act
+		err := store.Put(key, strings.NewReader("x"))
+
+		// Assert
+		if err == nil {
+			t.Errorf("Expected key %q to be rejected", key)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+func TestPgWalletPeer_Save_WritesOutboxInSameTransaction(t *testing.T) {
+	// Arrange
+	client := &recordingDatabaseClient{}
+	repo := newRecordingWalletRepository(client)
+	wallet := loadedWalletWithExpenses(1)
+
+	amount, _ := model.NewMoney(100, "USD")
+	wallet.AddExpense(*amount, "food", "Coffee", time.Now())
+
+	// Act
+	err := repo.Save(wallet)
+
+	// Assert - 錢包、支出與outbox都在同一個交易中寫入
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := client.countPrefix("INSERT INTO outbox"); got != 1 {
+		t.Fatalf("Expected 1 outbox insert, got %d", got)
+	}
+	last := client.statements[len(client.statements)-1]
+	if !strings.HasPrefix(last, "INSERT INTO outbox") {
+		t.Errorf("Expected outbox to be written after the wallet changes, last statement was %q", last)
+	}
+
+	// 已寫入outbox的事件不會在下次儲存時重複寫入
+	repo.Save(wallet)
+	if got := client.countPrefix("INSERT INTO outbox"); got != 1 {
+		t.Errorf("Expected no additional outbox inserts, got %d", got)
+	}
+}
+
+func TestToOutboxMessages_SerializesEvents(t *testing.T) {
+	// Arrange
+	wallet, _ := model.NewWallet("user-1", "Main", model.WalletTypeCash, "USD")
+
+	// Act
+	messages, err := mapper.ToOutboxMessages(mapper.AggregateTypeWallet, wallet.DomainEvents())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	message := messages[0]
+	if message.EventType != model.EventWalletCreated || message.AggregateID != wallet.ID {
+		t.Errorf("Unexpected message metadata: %+v", message)
+	}
+	if message.Status != mapper.OutboxStatusPending || message.Attempts != 0 {
+		t.Errorf("Expected a fresh pending message, got status %s attempts %d", message.Status, message.Attempts)
+	}
+	if !strings.Contains(string(message.Payload), `"UserID":"user-1"`) {
+		t.Errorf("Expected payload to contain the event fields, got %s", message.Payload)
+	}
+}
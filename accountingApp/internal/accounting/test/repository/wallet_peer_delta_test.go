@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	pgrepository "github.com/JingHsiu/accountingApp/internal/accounting/adapter/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/frameworks/database"
+)
+
+// recordingDatabaseClient 記錄所有執行的SQL，不連接資料庫
+type recordingDatabaseClient struct {
+	statements []string
+}
+
+type recordingResult struct{}
+
+func (recordingResult) RowsAffected() (int64, error) { return 1, nil }
+
+func (c *recordingDatabaseClient) QueryRow(query string, args ...interface{}) database.RowScanner {
+	return nil
+}
+
+func (c *recordingDatabaseClient) Query(query string, args ...interface{}) (database.RowsScanner, error) {
+	return nil, errors.New("query not supported by recording client")
+}
+
+func (c *recordingDatabaseClient) Exec(query string, args ...interface{}) (database.ExecResult, error) {
+	c.statements = append(c.statements, strings.TrimSpace(query))
+	return recordingResult{}, nil
+}
+
+func (c *recordingDatabaseClient) BeginTx() (database.Transaction, error) {
+	return recordingTransaction{c}, nil
+}
+
+type recordingTransaction struct {
+	*recordingDatabaseClient
+}
+
+func (recordingTransaction) Commit() error   { return nil }
+func (recordingTransaction) Rollback() error { return nil }
+
+func (c *recordingDatabaseClient) countPrefix(prefix string) int {
+	count := 0
+	for _, statement := range c.statements {
+		if strings.HasPrefix(statement, prefix) {
+			count++
+		}
+	}
+	return count
+}
+
+func newRecordingWalletRepository(client *recordingDatabaseClient) repository.WalletRepository {
+	peer := pgrepository.NewPgWalletRepositoryPeerAdapter(
+		database.NewPgWalletStore(client),
+		client,
+		database.NewPgIncomeRecordStore(client),
+		database.NewPgExpenseRecordStore(client),
+		database.NewPgTransferStore(client),
+	)
+	return repository.NewWalletRepositoryImpl(peer, nil)
+}
+
+// loadedWalletWithExpenses 模擬從資料庫載入、已有expenseCount筆支出的錢包
+func loadedWalletWithExpenses(expenseCount int) *model.Wallet {
+	now := time.Now()
+	data := mapper.WalletData{
+		ID:              "wallet-1",
+		UserID:          "user-1",
+		Name:            "Main",
+		Type:            "CASH",
+		Currency:        "USD",
+		BalanceAmount:   int64(expenseCount) * 100,
+		BalanceCurrency: "USD",
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		Version:         1,
+		IsFullyLoaded:   true,
+		ExpenseRecords:  make([]mapper.ExpenseRecordData, expenseCount),
+	}
+	for i := range data.ExpenseRecords {
+		data.ExpenseRecords[i] = mapper.ExpenseRecordData{
+			ID:            fmt.Sprintf("expense-%d", i),
+			WalletID:      "wallet-1",
+			SubcategoryID: "food",
+			Amount:        100,
+			Currency:      "USD",
+			Date:          now,
+			CreatedAt:     now,
+		}
+	}
+
+	wallet, _ := mapper.NewWalletMapper().ToDomain(data)
+	return wallet
+}
+
+func TestPgWalletPeer_Save_WritesOnlyNewExpense(t *testing.T) {
+	// Arrange
+	client := &recordingDatabaseClient{}
+	repo := newRecordingWalletRepository(client)
+	wallet := loadedWalletWithExpenses(50)
+
+	amount, _ := model.NewMoney(100, "USD")
+	wallet.AddExpense(*amount, "food", "Coffee", time.Now())
+
+	// Act
+	err := repo.Save(wallet)
+
+	// Assert - 一筆錢包upsert + 一筆新支出，沒有刪除
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := client.countPrefix("INSERT INTO expense_records"); got != 1 {
+		t.Errorf("Expected 1 expense insert, got %d", got)
+	}
+	if got := client.countPrefix("DELETE"); got != 0 {
+		t.Errorf("Expected no deletes, got %d", got)
+	}
+	if !wallet.ExpenseRecordChanges().IsEmpty() {
+		t.Error("Expected change tracking to be cleared after save")
+	}
+}
+
+func TestPgWalletPeer_Save_NoChildChanges(t *testing.T) {
+	// Arrange
+	client := &recordingDatabaseClient{}
+	repo := newRecordingWalletRepository(client)
+	wallet := loadedWalletWithExpenses(50)
+	wallet.UpdateName("Renamed")
+
+	// Act
+	err := repo.Save(wallet)
+
+	// Assert - 只更新錢包本身 (以及改名事件的outbox訊息)，不寫入子實體
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(client.statements) != 2 || !strings.HasPrefix(client.statements[0], "INSERT INTO wallets") {
+		t.Errorf("Expected only the wallet upsert, got %v", client.statements)
+	}
+	if got := client.countPrefix("INSERT INTO outbox"); got != 1 {
+		t.Errorf("Expected the rename event in the outbox, got %d outbox inserts", got)
+	}
+}
+
+// BenchmarkWalletSave 比較在大量既有記錄下新增一筆支出的儲存成本
+// delta: 只寫入新增的支出；full_rewrite: 所有記錄都需要寫入 (舊的刪除後重新插入行為)
+func BenchmarkWalletSave(b *testing.B) {
+	for _, size := range []int{1000, 20000} {
+		b.Run("delta/records="+strconv.Itoa(size), func(b *testing.B) {
+			amount, _ := model.NewMoney(100, "USD")
+			wallet := loadedWalletWithExpenses(size)
+			client := &recordingDatabaseClient{}
+			repo := newRecordingWalletRepository(client)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				wallet.AddExpense(*amount, "food", "Coffee", time.Now())
+				if err := repo.Save(wallet); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(client.statements))/float64(b.N), "statements/op")
+		})
+
+		b.Run("full_rewrite/records="+strconv.Itoa(size), func(b *testing.B) {
+			amount, _ := model.NewMoney(100, "USD")
+			loaded := loadedWalletWithExpenses(size)
+			client := &recordingDatabaseClient{}
+			repo := newRecordingWalletRepository(client)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// 以AddExpenseRecord標記所有既有記錄為待寫入，重現整批重寫的成本
+				wallet, _ := model.NewWalletWithInitialBalance("user-1", "Main", model.WalletTypeCash, "USD", int64(size)*100)
+				for _, record := range loaded.GetExpenseRecords() {
+					wallet.AddExpenseRecord(record)
+				}
+				wallet.AddExpense(*amount, "food", "Coffee", time.Now())
+				if err := repo.Save(wallet); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(client.statements))/float64(b.N), "statements/op")
+		})
+	}
+}
+
+func TestPgWalletPeer_Save_UpdatesAndDeletesOnlyChangedExpenses(t *testing.T) {
+	// Arrange
+	client := &recordingDatabaseClient{}
+	repo := newRecordingWalletRepository(client)
+	wallet := loadedWalletWithExpenses(50)
+
+	amount, _ := model.NewMoney(150, "USD")
+	wallet.UpdateExpense("expense-1", *amount, "food", "Corrected", time.Now())
+	wallet.RemoveExpense("expense-2")
+
+	// Act
+	err := repo.Save(wallet)
+
+	// Assert - 一筆upsert修改、一筆刪除
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := client.countPrefix("INSERT INTO expense_records"); got != 1 {
+		t.Errorf("Expected 1 expense upsert, got %d", got)
+	}
+	if got := client.countPrefix("DELETE FROM expense_records"); got != 1 {
+		t.Errorf("Expected 1 expense delete, got %d", got)
+	}
+}
+
+func TestPgWalletPeer_Save_WritesSplitLines(t *testing.T) {
+	// Arrange
+	client := &recordingDatabaseClient{}
+	repo := newRecordingWalletRepository(client)
+	wallet := loadedWalletWithExpenses(5)
+
+	total, _ := model.NewMoney(300, "USD")
+	food, _ := model.NewMoney(200, "USD")
+	pets, _ := model.NewMoney(100, "USD")
+	wallet.AddSplitExpense(*total, []model.ExpenseSplit{
+		{SubcategoryID: "food", Amount: *food},
+		{SubcategoryID: "pets", Amount: *pets, Memo: "Cat litter"},
+	}, "Supermarket", time.Now())
+	wallet.UpdateExpense("expense-1", *food, "food", "Corrected", time.Now())
+
+	// Act
+	err := repo.Save(wallet)
+
+	// Assert - 新的拆帳支出寫入兩筆明細；修改過的支出先清除舊明細
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := client.countPrefix("INSERT INTO expense_splits"); got != 2 {
+		t.Errorf("Expected 2 split inserts, got %d", got)
+	}
+	if got := client.countPrefix("DELETE FROM expense_splits"); got != 1 {
+		t.Errorf("Expected the modified expense's splits to be cleared once, got %d", got)
+	}
+}
+
+func TestWalletMapper_RoundTripKeepsSplitOrder(t *testing.T) {
+	// Arrange - 明細順序決定SubcategoryID，載入順序不一定相同
+	wallet := loadedWalletWithExpenses(0)
+	data := mapper.NewWalletMapper().ToData(wallet)
+	data.ExpenseRecords = []mapper.ExpenseRecordData{{
+		ID: "expense-split", WalletID: "wallet-1", SubcategoryID: "food", Amount: 300, Currency: "USD",
+		Splits: []mapper.ExpenseSplitData{
+			{ExpenseID: "expense-split", LineNo: 2, SubcategoryID: "pets", Amount: 100, Currency: "USD"},
+			{ExpenseID: "expense-split", LineNo: 1, SubcategoryID: "food", Amount: 200, Currency: "USD"},
+		},
+	}}
+
+	// Act
+	restored, err := mapper.NewWalletMapper().ToDomain(data)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	splits := restored.GetExpenseRecords()[0].Splits
+	if len(splits) != 2 || splits[0].SubcategoryID != "food" || splits[1].SubcategoryID != "pets" {
+		t.Errorf("Expected splits in line order, got %+v", splits)
+	}
+	if lines := mapper.NewWalletMapper().ToData(restored).ExpenseRecords[0].Splits; len(lines) != 2 || lines[1].LineNo != 2 {
+		t.Errorf("Expected line numbers to be preserved, got %+v", lines)
+	}
+}
+
+func TestPgWalletPeer_Save_ReplacesTagsOnlyOfEditedRecords(t *testing.T) {
+	// Arrange
+	client := &recordingDatabaseClient{}
+	repo := newRecordingWalletRepository(client)
+	wallet := loadedWalletWithExpenses(5)
+
+	amount, _ := model.NewMoney(100, "USD")
+	added, _ := wallet.AddExpense(*amount, "food", "Coffee", time.Now())
+	edit, _ := model.NewTagEdit([]string{"trip", "Coffee"}, nil)
+	wallet.EditExpenseTags(added.ID, edit)
+	wallet.EditExpenseTags("expense-2", edit)
+
+	// Act
+	err := repo.Save(wallet)
+
+	// Assert - 新支出直接寫入標籤；已存在的支出先清除舊標籤再寫入
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := client.countPrefix("INSERT INTO expense_record_tags"); got != 4 {
+		t.Errorf("Expected 4 tag inserts, got %d", got)
+	}
+	if got := client.countPrefix("DELETE FROM expense_record_tags"); got != 1 {
+		t.Errorf("Expected the edited expense's tags to be cleared once, got %d", got)
+	}
+}
@@ -61,6 +61,47 @@ func (m *MockWalletRepositoryPeer) FindByID(id string) (*mapper.WalletData, erro
 	return nil, nil
 }
 
+func (m *MockWalletRepositoryPeer) FindByIDWithChildEntities(id string) (*mapper.WalletData, error) {
+	data, err := m.FindByID(id)
+	if data != nil {
+		data.IsFullyLoaded = true
+	}
+	return data, err
+}
+
+func (m *MockWalletRepositoryPeer) FindByExpenseRecordID(expenseID string) (*mapper.WalletData, error) {
+	for id, data := range m.data {
+		for _, record := range data.ExpenseRecords {
+			if record.ID == expenseID {
+				return m.FindByIDWithChildEntities(id)
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockWalletRepositoryPeer) FindByIncomeRecordID(incomeID string) (*mapper.WalletData, error) {
+	for id, data := range m.data {
+		for _, record := range data.IncomeRecords {
+			if record.ID == incomeID {
+				return m.FindByIDWithChildEntities(id)
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockWalletRepositoryPeer) FindByTransferID(transferID string) (*mapper.WalletData, error) {
+	for id, data := range m.data {
+		for _, transfer := range data.Transfers {
+			if transfer.ID == transferID && transfer.FromWalletID == id {
+				return m.FindByIDWithChildEntities(id)
+			}
+		}
+	}
+	return nil, nil
+}
+
 func (m *MockWalletRepositoryPeer) FindByUserID(userID string) ([]mapper.WalletData, error) {
 	if wallets, exists := m.userData[userID]; exists {
 		return wallets, nil
@@ -90,10 +131,48 @@ func (m *MockWalletRepositoryPeer) Delete(id string) error {
 	return nil
 }
 
+func (m *MockWalletRepositoryPeer) DeleteWithAudit(id string, audit mapper.WalletAuditData) error {
+	return m.Delete(id)
+}
+
+func (m *MockWalletRepositoryPeer) SumExpensesByUserID(userID string, from, to time.Time) ([]mapper.ExpenseTotalData, error) {
+	var totals []mapper.ExpenseTotalData
+	for _, wallet := range m.userData[userID] {
+		for _, record := range m.data[wallet.ID].ExpenseRecords {
+			if record.Date.Before(from) || !record.Date.Before(to) {
+				continue
+			}
+			total := mapper.ExpenseTotalData{SubcategoryID: record.SubcategoryID, Currency: record.Currency, Date: record.Date, Amount: record.Amount}
+			totals = append(totals, total)
+		}
+	}
+	return totals, nil
+}
+
+func (m *MockWalletRepositoryPeer) FindRecordsByDateRange(walletID string, from, to time.Time) ([]mapper.ExpenseRecordData, []mapper.IncomeRecordData, error) {
+	data, exists := m.data[walletID]
+	if !exists {
+		return nil, nil, nil
+	}
+	var expenses []mapper.ExpenseRecordData
+	for _, record := range data.ExpenseRecords {
+		if !record.Date.Before(from) && record.Date.Before(to) {
+			expenses = append(expenses, record)
+		}
+	}
+	var incomes []mapper.IncomeRecordData
+	for _, record := range data.IncomeRecords {
+		if !record.Date.Before(from) && record.Date.Before(to) {
+			incomes = append(incomes, record)
+		}
+	}
+	return expenses, incomes, nil
+}
+
 func TestWalletRepositoryImpl_Save(t *testing.T) {
 	// Arrange
 	mockPeer := NewMockWalletRepositoryPeer()
-	repo := repository.NewWalletRepositoryImpl(mockPeer)
+	repo := repository.NewWalletRepositoryImpl(mockPeer, nil)
 	
 	wallet, err := model.NewWallet("test-user", "Test Wallet", model.WalletTypeCash, "USD")
 	if err != nil {
@@ -126,7 +205,7 @@ func TestWalletRepositoryImpl_Save(t *testing.T) {
 func TestWalletRepositoryImpl_FindByID(t *testing.T) {
 	// Arrange
 	mockPeer := NewMockWalletRepositoryPeer()
-	repo := repository.NewWalletRepositoryImpl(mockPeer)
+	repo := repository.NewWalletRepositoryImpl(mockPeer, nil)
 	
 	// Create test data
 	now := time.Now()
@@ -168,7 +247,7 @@ func TestWalletRepositoryImpl_FindByID(t *testing.T) {
 func TestWalletRepositoryImpl_FindByID_NotFound(t *testing.T) {
 	// Arrange
 	mockPeer := NewMockWalletRepositoryPeer()
-	repo := repository.NewWalletRepositoryImpl(mockPeer)
+	repo := repository.NewWalletRepositoryImpl(mockPeer, nil)
 
 	// Act
 	wallet, err := repo.FindByID("non-existent-id")
@@ -186,7 +265,7 @@ func TestWalletRepositoryImpl_FindByID_NotFound(t *testing.T) {
 func TestWalletRepositoryImpl_FindByUserID(t *testing.T) {
 	// Arrange
 	mockPeer := NewMockWalletRepositoryPeer()
-	repo := repository.NewWalletRepositoryImpl(mockPeer)
+	repo := repository.NewWalletRepositoryImpl(mockPeer, nil)
 	
 	// Create test data
 	now := time.Now()
@@ -241,7 +320,7 @@ func TestWalletRepositoryImpl_FindByUserID(t *testing.T) {
 func TestWalletRepositoryImpl_FindByUserID_NoWallets(t *testing.T) {
 	// Arrange
 	mockPeer := NewMockWalletRepositoryPeer()
-	repo := repository.NewWalletRepositoryImpl(mockPeer)
+	repo := repository.NewWalletRepositoryImpl(mockPeer, nil)
 
 	// Act
 	wallets, err := repo.FindByUserID("non-existent-user")
@@ -259,7 +338,7 @@ func TestWalletRepositoryImpl_FindByUserID_NoWallets(t *testing.T) {
 func TestWalletRepositoryImpl_FindByIDWithTransactions(t *testing.T) {
 	// Arrange
 	mockPeer := NewMockWalletRepositoryPeer()
-	repo := repository.NewWalletRepositoryImpl(mockPeer)
+	repo := repository.NewWalletRepositoryImpl(mockPeer, nil)
 	
 	// Create test data
 	now := time.Now()
@@ -302,7 +381,7 @@ func TestWalletRepositoryImpl_FindByIDWithTransactions(t *testing.T) {
 func TestWalletRepositoryImpl_Delete(t *testing.T) {
 	// Arrange
 	mockPeer := NewMockWalletRepositoryPeer()
-	repo := repository.NewWalletRepositoryImpl(mockPeer)
+	repo := repository.NewWalletRepositoryImpl(mockPeer, nil)
 	
 	// Create test data
 	now := time.Now()
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+)
+
+// CreateWallet 建立有初始餘額的現金錢包並存入repo
+func CreateWallet(t *testing.T, repo repository.WalletRepository, userID, currency string, balance int64) *model.Wallet {
+	t.Helper()
+	wallet, err := model.NewWalletWithInitialBalance(userID, "Cash", model.WalletTypeCash, currency, balance)
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	if err := repo.Save(wallet); err != nil {
+		t.Fatalf("failed to save wallet: %v", err)
+	}
+	return wallet
+}
+
+// CreateExpenseSubcategory 建立只有一個子分類的支出分類並存入repo
+func CreateExpenseSubcategory(t *testing.T, repo repository.ExpenseCategoryRepository, userID, categoryName, subcategoryName string) (*model.ExpenseCategory, *model.ExpenseSubcategory) {
+	t.Helper()
+	name, err := model.NewCategoryName(categoryName)
+	if err != nil {
+		t.Fatalf("invalid category name: %v", err)
+	}
+	category, err := model.NewExpenseCategory(userID, *name)
+	if err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+	name, err = model.NewCategoryName(subcategoryName)
+	if err != nil {
+		t.Fatalf("invalid subcategory name: %v", err)
+	}
+	subcategory, err := category.AddSubcategory(*name)
+	if err != nil {
+		t.Fatalf("failed to add subcategory: %v", err)
+	}
+	if err := repo.Save(category); err != nil {
+		t.Fatalf("failed to save category: %v", err)
+	}
+	return category, subcategory
+}
+
+// CreateIncomeSubcategory 建立只有一個子分類的收入分類並存入repo
+func CreateIncomeSubcategory(t *testing.T, repo repository.IncomeCategoryRepository, userID, categoryName, subcategoryName string) (*model.IncomeCategory, *model.IncomeSubcategory) {
+	t.Helper()
+	name, err := model.NewCategoryName(categoryName)
+	if err != nil {
+		t.Fatalf("invalid category name: %v", err)
+	}
+	category, err := model.NewIncomeCategory(userID, *name)
+	if err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+	name, err = model.NewCategoryName(subcategoryName)
+	if err != nil {
+		t.Fatalf("invalid subcategory name: %v", err)
+	}
+	subcategory, err := category.AddSubcategory(*name)
+	if err != nil {
+		t.Fatalf("failed to add subcategory: %v", err)
+	}
+	if err := repo.Save(category); err != nil {
+		t.Fatalf("failed to save category: %v", err)
+	}
+	return category, subcategory
+}
+
+// FindTransfer 從錢包的交易記錄中找出轉帳，找不到時測試失敗
+func FindTransfer(t *testing.T, repo repository.WalletRepository, walletID, transferID string) model.Transfer {
+	t.Helper()
+	wallet, err := repo.FindByIDWithTransactions(walletID)
+	if err != nil || wallet == nil {
+		t.Fatalf("failed to load wallet %s: %v", walletID, err)
+	}
+	for _, transfer := range wallet.GetTransfers() {
+		if transfer.ID == transferID {
+			return transfer
+		}
+	}
+	t.Fatalf("transfer %s not found in wallet %s", transferID, walletID)
+	return model.Transfer{}
+}
@@ -33,6 +33,7 @@ func createTestIncomeCategoryWithSubcategory(repo repository.IncomeCategoryRepos
 
 func createAddIncomeInput(walletID, subcategoryID string, amount int64, currency, description string) usecase.AddIncomeInput {
 	return usecase.AddIncomeInput{
+		UserID:        "user-123",
 		WalletID:      walletID,
 		SubcategoryID: subcategoryID,
 		Amount:        amount,
@@ -151,7 +152,7 @@ func Test_AddIncomeService_Failure_WalletNotFound(t *testing.T) {
 	output := service.Execute(input)
 
 	// Assert
-	assert.Equal(t, common.Failure, output.GetExitCode())
+	assert.Equal(t, common.NotFound, output.GetExitCode())
 	assert.Contains(t, output.GetMessage(), "Wallet not found")
 	assert.Empty(t, output.GetID(), "No income ID should be generated on failure")
 }
@@ -168,7 +169,7 @@ func Test_AddIncomeService_Failure_EmptyWalletID(t *testing.T) {
 	output := service.Execute(input)
 
 	// Assert
-	assert.Equal(t, common.Failure, output.GetExitCode())
+	assert.Equal(t, common.NotFound, output.GetExitCode())
 	assert.Contains(t, output.GetMessage(), "Wallet not found")
 }
 
@@ -187,7 +188,7 @@ func Test_AddIncomeService_Failure_SubcategoryNotFound(t *testing.T) {
 	output := service.Execute(input)
 
 	// Assert
-	assert.Equal(t, common.Failure, output.GetExitCode())
+	assert.Equal(t, common.NotFound, output.GetExitCode())
 	assert.Contains(t, output.GetMessage(), "Subcategory not found in any category")
 	assert.Empty(t, output.GetID())
 }
@@ -208,7 +209,7 @@ func Test_AddIncomeService_Failure_InvalidSubcategoryID(t *testing.T) {
 	output := service.Execute(input)
 
 	// Assert
-	assert.Equal(t, common.Failure, output.GetExitCode())
+	assert.Equal(t, common.NotFound, output.GetExitCode())
 	assert.Contains(t, output.GetMessage(), "Subcategory not found")
 }
 
@@ -246,7 +247,7 @@ func Test_AddIncomeService_Failure_NegativeAmount(t *testing.T) {
 	output := service.Execute(input)
 
 	// Assert
-	assert.Equal(t, common.Failure, output.GetExitCode())
+	assert.Equal(t, common.InvalidInput, output.GetExitCode())
 	assert.Contains(t, output.GetMessage(), "Invalid amount")
 	assert.Contains(t, output.GetMessage(), "amount cannot be negative")
 	assert.Empty(t, output.GetID())
@@ -287,7 +288,7 @@ func Test_AddIncomeService_Failure_EmptyCurrency(t *testing.T) {
 	output := service.Execute(input)
 
 	// Assert
-	assert.Equal(t, common.Failure, output.GetExitCode())
+	assert.Equal(t, common.InvalidInput, output.GetExitCode())
 	assert.Contains(t, output.GetMessage(), "Invalid amount")
 	assert.Contains(t, output.GetMessage(), "currency cannot be empty")
 }
@@ -318,7 +319,7 @@ func Test_AddIncomeService_Failure_InvalidCurrencyLength(t *testing.T) {
 			output := service.Execute(input)
 
 			// Assert
-			assert.Equal(t, common.Failure, output.GetExitCode())
+			assert.Equal(t, common.InvalidInput, output.GetExitCode())
 			assert.Contains(t, output.GetMessage(), "Invalid amount")
 			assert.Contains(t, output.GetMessage(), "currency must be 3 characters")
 		})
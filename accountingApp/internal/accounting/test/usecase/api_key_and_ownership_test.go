@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateAPIKeyService_StoresOnlyHash(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeAPIKeyRepository()
+	service := command.NewCreateAPIKeyService(repo)
+
+	// Act
+	output := service.Execute(usecase.CreateAPIKeyInput{UserID: "user-123", Name: "CLI"})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	created := output.(usecase.CreateAPIKeyOutput)
+	assert.True(t, strings.HasPrefix(created.Key, model.APIKeyPrefix))
+	stored, _ := repo.FindByID(created.ID)
+	assert.NotNil(t, stored)
+	assert.NotEqual(t, created.Key, stored.KeyHash)
+	assert.Equal(t, model.HashAPIKey(created.Key), stored.KeyHash)
+}
+
+func Test_CreateAPIKeyService_RequiresName(t *testing.T) {
+	// Arrange
+	service := command.NewCreateAPIKeyService(test.NewFakeAPIKeyRepository())
+
+	// Act
+	output := service.Execute(usecase.CreateAPIKeyInput{UserID: "user-123", Name: "  "})
+
+	// Assert
+	assert.Equal(t, common.InvalidInput, output.GetExitCode())
+	assert.Contains(t, output.GetMessage(), "Invalid API key")
+}
+
+func Test_AuthenticateAPIKeyService_RejectsRevokedKey(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeAPIKeyRepository()
+	created := command.NewCreateAPIKeyService(repo).Execute(usecase.CreateAPIKeyInput{UserID: "user-123", Name: "CLI"}).(usecase.CreateAPIKeyOutput)
+	service := query.NewAuthenticateAPIKeyService(repo)
+
+	// Act & Assert - active key resolves to its owner
+	output := service.Execute(usecase.AuthenticateAPIKeyInput{Key: created.Key})
+	assert.Equal(t, common.Success, output.GetExitCode())
+	assert.Equal(t, "user-123", output.GetID())
+
+	// Revoked key is rejected
+	revoke := command.NewRevokeAPIKeyService(repo).Execute(usecase.RevokeAPIKeyInput{UserID: "user-123", KeyID: created.ID})
+	assert.Equal(t, common.Success, revoke.GetExitCode(), revoke.GetMessage())
+	output = service.Execute(usecase.AuthenticateAPIKeyInput{Key: created.Key})
+	assert.Equal(t, common.InvalidInput, output.GetExitCode())
+	assert.Equal(t, "Invalid API key", output.GetMessage())
+}
+
+func Test_RevokeAPIKeyService_OtherUsersKeyNotFound(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeAPIKeyRepository()
+	created := command.NewCreateAPIKeyService(repo).Execute(usecase.CreateAPIKeyInput{UserID: "user-123", Name: "CLI"})
+
+	// Act
+	output := command.NewRevokeAPIKeyService(repo).Execute(usecase.RevokeAPIKeyInput{UserID: "intruder", KeyID: created.GetID()})
+
+	// Assert
+	assert.Equal(t, common.NotFound, output.GetExitCode())
+	assert.Equal(t, "API key not found", output.GetMessage())
+	stored, _ := repo.FindByID(created.GetID())
+	assert.True(t, stored.IsActive())
+}
+
+func Test_UpdateExpenseService_OtherUsersRecordNotFound(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	wallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	amount, _ := model.NewMoney(2000, "USD")
+	expense, _ := wallet.AddExpense(*amount, "food-123", "Lunch", time.Now())
+	service := command.NewUpdateExpenseService(walletRepo)
+
+	// Act
+	output := service.Execute(usecase.UpdateExpenseInput{
+		UserID:        "intruder",
+		ExpenseID:     expense.ID,
+		SubcategoryID: "food-123",
+		Amount:        1,
+		Currency:      "USD",
+	})
+
+	// Assert - indistinguishable from a missing record, and nothing changes
+	assert.Equal(t, common.NotFound, output.GetExitCode())
+	assert.Equal(t, "Expense record not found", output.GetMessage())
+	saved, _ := walletRepo.FindByID(wallet.ID)
+	assert.Equal(t, int64(8000), saved.Balance.Amount)
+}
+
+func Test_AddExpenseService_OtherUsersWalletNotFound(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	wallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	service := command.NewAddExpenseService(walletRepo, nil, nil, nil)
+	input := createAddExpenseInput(wallet.ID, 500)
+	input.UserID = "intruder"
+
+	// Act
+	output := service.Execute(input)
+
+	// Assert
+	assert.Equal(t, common.NotFound, output.GetExitCode())
+	assert.Equal(t, "Wallet not found", output.GetMessage())
+}
+
+func Test_AddExpenseService_MissingUserIDIsNotTrusted(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	wallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	service := command.NewAddExpenseService(walletRepo, nil, nil, nil)
+	anonymous := createAddExpenseInput(wallet.ID, 500)
+	anonymous.UserID = ""
+	system := createAddExpenseInput(wallet.ID, 500)
+	system.UserID = common.SystemActor
+
+	// Act
+	anonymousOutput := service.Execute(anonymous)
+	systemOutput := service.Execute(system)
+
+	// Assert - only the explicit system principal skips the ownership check
+	assert.Equal(t, common.NotFound, anonymousOutput.GetExitCode())
+	assert.Equal(t, common.Success, systemOutput.GetExitCode(), systemOutput.GetMessage())
+}
+
+func Test_RenameExpenseCategoryService_OtherUsersCategoryNotFound(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeExpenseCategoryRepository()
+	categoryID := createExpenseCategoryInRepo(t, repo, "Food")
+
+	// Act
+	output := command.NewRenameExpenseCategoryService(repo).Execute(usecase.RenameExpenseCategoryInput{
+		UserID:     "intruder",
+		CategoryID: categoryID,
+		Name:       "Mine now",
+	})
+
+	// Assert
+	assert.Equal(t, common.NotFound, output.GetExitCode())
+	assert.Equal(t, "Expense category not found", output.GetMessage())
+}
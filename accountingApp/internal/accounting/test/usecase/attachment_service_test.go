@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// testReceiptPNG 最小的PNG檔頭，足以通過內容類型檢查
+var testReceiptPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR receipt")
+
+func Test_UploadAttachmentService_StoresBlobWithChecksumAndCanBeDownloaded(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	attachmentRepo := test.NewFakeAttachmentRepository()
+	blobStore := test.NewFakeBlobStore()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "USD", 100000)
+	expenseID := command.NewAddExpenseService(walletRepo, nil, nil, nil).Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: wallet.ID, SubcategoryID: "subcategory-food",
+		Amount: 1200, Currency: "USD", Date: time.Now(),
+	}).GetID()
+	upload := command.NewUploadAttachmentService(walletRepo, attachmentRepo, blobStore)
+
+	// Act
+	output := upload.Execute(usecase.UploadAttachmentInput{
+		UserID: "user-123", RecordType: "EXPENSE", RecordID: expenseID,
+		FileName: "receipt.png", ContentType: "image/png", Content: bytes.NewReader(testReceiptPNG),
+	})
+	uploaded := output.(usecase.UploadAttachmentOutput).Attachment
+	listed := query.NewGetAttachmentsService(attachmentRepo).Execute(usecase.GetAttachmentsInput{
+		UserID: "user-123", RecordType: "EXPENSE", RecordID: expenseID,
+	})
+	downloaded := query.NewGetAttachmentContentService(attachmentRepo, blobStore).Execute(usecase.GetAttachmentContentInput{
+		UserID: "user-123", AttachmentID: uploaded.ID,
+	})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	sum := sha256.Sum256(testReceiptPNG)
+	assert.Equal(t, hex.EncodeToString(sum[:]), uploaded.Checksum)
+	assert.Equal(t, int64(len(testReceiptPNG)), uploaded.Size)
+	assert.Equal(t, "image/png", uploaded.ContentType)
+
+	stored, _ := attachmentRepo.FindByID(uploaded.ID)
+	assert.Equal(t, wallet.ID, stored.WalletID)
+	assert.True(t, blobStore.Has(stored.StorageKey))
+
+	assert.Equal(t, []usecase.AttachmentData{uploaded}, listed.(usecase.GetAttachmentsOutput).Attachments)
+
+	assert.Equal(t, common.Success, downloaded.GetExitCode(), downloaded.GetMessage())
+	content := downloaded.(usecase.GetAttachmentContentOutput).Content
+	defer content.Close()
+	body, _ := io.ReadAll(content)
+	assert.Equal(t, testReceiptPNG, body)
+}
+
+func Test_UploadAttachmentService_RejectsInvalidUploadsWithoutStoringAnything(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	attachmentRepo := test.NewFakeAttachmentRepository()
+	blobStore := test.NewFakeBlobStore()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "USD", 100000)
+	expenseID := command.NewAddExpenseService(walletRepo, nil, nil, nil).Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: wallet.ID, SubcategoryID: "subcategory-food",
+		Amount: 1200, Currency: "USD", Date: time.Now(),
+	}).GetID()
+	upload := command.NewUploadAttachmentService(walletRepo, attachmentRepo, blobStore)
+	otherUsersWallet := test.CreateWallet(t, walletRepo, "user-456", "USD", 1000)
+	otherUsersExpense := command.NewAddExpenseService(walletRepo, nil, nil, nil).Execute(usecase.AddExpenseInput{
+		UserID: "user-456", WalletID: otherUsersWallet.ID, SubcategoryID: "subcategory-food",
+		Amount: 100, Currency: "USD", Date: time.Now(),
+	}).GetID()
+
+	cases := []struct {
+		name     string
+		input    usecase.UploadAttachmentInput
+		code     common.ExitCode
+		expected string
+	}{
+		{"declared type does not match content", usecase.UploadAttachmentInput{
+			RecordType: "EXPENSE", RecordID: expenseID, ContentType: "application/pdf",
+			Content: bytes.NewReader(testReceiptPNG),
+		}, common.InvalidInput, "does not match declared type"},
+		{"disallowed content", usecase.UploadAttachmentInput{
+			RecordType: "EXPENSE", RecordID: expenseID,
+			Content: bytes.NewReader([]byte("<html><script></script></html>")),
+		}, common.InvalidInput, "unsupported content type"},
+		{"too large", usecase.UploadAttachmentInput{
+			RecordType: "EXPENSE", RecordID: expenseID, ContentType: "image/png",
+			Content: io.MultiReader(bytes.NewReader(testReceiptPNG), bytes.NewReader(make([]byte, model.MaxAttachmentSize))),
+		}, common.InvalidInput, "exceeds"},
+		{"another user's record", usecase.UploadAttachmentInput{
+			RecordType: "EXPENSE", RecordID: otherUsersExpense, ContentType: "image/png",
+			Content: bytes.NewReader(testReceiptPNG),
+		}, common.NotFound, "Expense record not found"},
+		{"expense ID used as income", usecase.UploadAttachmentInput{
+			RecordType: "INCOME", RecordID: expenseID, ContentType: "image/png",
+			Content: bytes.NewReader(testReceiptPNG),
+		}, common.NotFound, "Income record not found"},
+		{"unknown record type", usecase.UploadAttachmentInput{
+			RecordType: "TRANSFER", RecordID: expenseID, ContentType: "image/png",
+			Content: bytes.NewReader(testReceiptPNG),
+		}, common.InvalidInput, "Invalid attachment"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			tc.input.UserID = "user-123"
+			output := upload.Execute(tc.input)
+
+			// Assert
+			assert.Equal(t, tc.code, output.GetExitCode())
+			assert.Contains(t, output.GetMessage(), tc.expected)
+		})
+	}
+
+	attachments, _ := attachmentRepo.FindByWalletID(wallet.ID)
+	assert.Empty(t, attachments)
+}
+
+func Test_DeleteAttachmentService_RemovesBlobAndMetadata(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	attachmentRepo := test.NewFakeAttachmentRepository()
+	blobStore := test.NewFakeBlobStore()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "USD", 100000)
+	expenseID := command.NewAddExpenseService(walletRepo, nil, nil, nil).Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: wallet.ID, SubcategoryID: "subcategory-food",
+		Amount: 1200, Currency: "USD", Date: time.Now(),
+	}).GetID()
+	upload := command.NewUploadAttachmentService(walletRepo, attachmentRepo, blobStore)
+	uploaded := upload.Execute(usecase.UploadAttachmentInput{
+		UserID: "user-123", RecordType: "EXPENSE", RecordID: expenseID,
+		FileName: "receipt.png", ContentType: "image/png", Content: bytes.NewReader(testReceiptPNG),
+	}).(usecase.UploadAttachmentOutput).Attachment
+	stored, _ := attachmentRepo.FindByID(uploaded.ID)
+	service := command.NewDeleteAttachmentService(attachmentRepo, blobStore)
+
+	// Act - another user cannot see the attachment
+	notOwner := service.Execute(usecase.DeleteAttachmentInput{UserID: "user-456", AttachmentID: uploaded.ID})
+
+	// A failed blob delete keeps the metadata so the delete can be retried
+	blobStore.DeleteErr = errors.New("disk unavailable")
+	failed := service.Execute(usecase.DeleteAttachmentInput{UserID: "user-123", AttachmentID: uploaded.ID})
+	kept, _ := attachmentRepo.FindByID(uploaded.ID)
+
+	blobStore.DeleteErr = nil
+	output := service.Execute(usecase.DeleteAttachmentInput{UserID: "user-123", AttachmentID: uploaded.ID})
+
+	// Assert
+	assert.Equal(t, "Attachment not found", notOwner.GetMessage())
+	assert.Equal(t, common.Failure, failed.GetExitCode())
+	assert.NotNil(t, kept)
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	assert.False(t, blobStore.Has(stored.StorageKey))
+	deleted, _ := attachmentRepo.FindByID(uploaded.ID)
+	assert.Nil(t, deleted)
+}
+
+func Test_DeleteWalletService_CleansUpAttachmentsOfTheWallet(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	attachmentRepo := test.NewFakeAttachmentRepository()
+	blobStore := test.NewFakeBlobStore()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "USD", 100000)
+	expenseID := command.NewAddExpenseService(walletRepo, nil, nil, nil).Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: wallet.ID, SubcategoryID: "subcategory-food",
+		Amount: 1200, Currency: "USD", Date: time.Now(),
+	}).GetID()
+	upload := command.NewUploadAttachmentService(walletRepo, attachmentRepo, blobStore)
+	first := upload.Execute(usecase.UploadAttachmentInput{
+		UserID: "user-123", RecordType: "EXPENSE", RecordID: expenseID,
+		FileName: "receipt.png", ContentType: "image/png", Content: bytes.NewReader(testReceiptPNG),
+	}).(usecase.UploadAttachmentOutput).Attachment
+	second := upload.Execute(usecase.UploadAttachmentInput{
+		UserID: "user-123", RecordType: "EXPENSE", RecordID: expenseID,
+		FileName: "receipt.png", ContentType: "image/png", Content: bytes.NewReader(testReceiptPNG),
+	}).(usecase.UploadAttachmentOutput).Attachment
+	otherWallet := test.CreateWallet(t, walletRepo, "user-123", "USD", 1000)
+	otherExpense := command.NewAddExpenseService(walletRepo, nil, nil, nil).Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: otherWallet.ID, SubcategoryID: "subcategory-food",
+		Amount: 100, Currency: "USD", Date: time.Now(),
+	}).GetID()
+	kept := upload.Execute(usecase.UploadAttachmentInput{
+		UserID: "user-123", RecordType: "EXPENSE", RecordID: otherExpense,
+		FileName: "receipt.png", ContentType: "image/png", Content: bytes.NewReader(testReceiptPNG),
+	}).(usecase.UploadAttachmentOutput).Attachment
+
+	var storageKeys []string
+	for _, id := range []string{first.ID, second.ID} {
+		attachment, _ := attachmentRepo.FindByID(id)
+		storageKeys = append(storageKeys, attachment.StorageKey)
+	}
+	service := command.NewDeleteWalletService(walletRepo, attachmentRepo, blobStore)
+
+	// Act
+	output := service.Execute(usecase.DeleteWalletInput{UserID: "user-123", WalletID: wallet.ID})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	for _, key := range storageKeys {
+		assert.False(t, blobStore.Has(key), "blob %s should be deleted", key)
+	}
+	remaining, _ := attachmentRepo.FindByWalletID(wallet.ID)
+	assert.Empty(t, remaining)
+
+	stillThere, _ := attachmentRepo.FindByID(kept.ID)
+	assert.NotNil(t, stillThere)
+	assert.True(t, blobStore.Has(stillThere.StorageKey))
+}
+
+func Test_DeleteExpenseAndIncomeServices_CleanUpAttachmentsOfTheRecord(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	attachmentRepo := test.NewFakeAttachmentRepository()
+	blobStore := test.NewFakeBlobStore()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "USD", 100000)
+	expenseID := command.NewAddExpenseService(walletRepo, nil, nil, nil).Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: wallet.ID, SubcategoryID: "subcategory-food",
+		Amount: 1200, Currency: "USD", Date: time.Now(),
+	}).GetID()
+	upload := command.NewUploadAttachmentService(walletRepo, attachmentRepo, blobStore)
+	incomeID := command.NewAddIncomeService(walletRepo, nil).Execute(usecase.AddIncomeInput{
+		UserID: "user-123", WalletID: wallet.ID, SubcategoryID: "subcategory-salary",
+		Amount: 5000, Currency: "USD", Date: time.Now(),
+	}).GetID()
+	receipt := upload.Execute(usecase.UploadAttachmentInput{
+		UserID: "user-123", RecordType: "EXPENSE", RecordID: expenseID,
+		FileName: "receipt.png", ContentType: "image/png", Content: bytes.NewReader(testReceiptPNG),
+	}).(usecase.UploadAttachmentOutput).Attachment
+	payslip := upload.Execute(usecase.UploadAttachmentInput{
+		UserID: "user-123", RecordType: "INCOME", RecordID: incomeID,
+		FileName: "payslip.png", ContentType: "image/png", Content: bytes.NewReader(testReceiptPNG),
+	}).(usecase.UploadAttachmentOutput).Attachment
+	receiptBlob, _ := attachmentRepo.FindByID(receipt.ID)
+	payslipBlob, _ := attachmentRepo.FindByID(payslip.ID)
+
+	// Act - the income's blob cannot be deleted at first
+	deletedExpense := command.NewDeleteExpenseService(walletRepo, attachmentRepo, blobStore).Execute(usecase.DeleteExpenseInput{
+		UserID: "user-123", ExpenseID: expenseID,
+	})
+	blobStore.DeleteErr = errors.New("disk unavailable")
+	deletedIncome := command.NewDeleteIncomeService(walletRepo, attachmentRepo, blobStore).Execute(usecase.DeleteIncomeInput{
+		UserID: "user-123", IncomeID: incomeID,
+	})
+
+	// Assert - the expense's attachment is gone; the income is deleted anyway and
+	// its attachment metadata is kept as the record of the orphaned blob
+	assert.Equal(t, common.Success, deletedExpense.GetExitCode(), deletedExpense.GetMessage())
+	assert.False(t, blobStore.Has(receiptBlob.StorageKey))
+	remaining, _ := attachmentRepo.FindByRecord(model.AttachmentExpense, expenseID)
+	assert.Empty(t, remaining)
+
+	assert.Equal(t, common.Success, deletedIncome.GetExitCode(), deletedIncome.GetMessage())
+	assert.True(t, blobStore.Has(payslipBlob.StorageKey))
+	orphan, _ := attachmentRepo.FindByID(payslip.ID)
+	assert.NotNil(t, orphan)
+}
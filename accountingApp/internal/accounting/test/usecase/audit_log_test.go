@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/audit"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuditedWalletRepo() (*test.FakeWalletPeer, repository.AuditableWalletRepository, *test.FakeAuditLogRepository) {
+	auditRepo := test.NewFakeAuditLogRepository()
+	peer := test.NewFakeWalletPeer(auditRepo)
+	return peer, repository.NewWalletRepositoryImpl(peer, nil), auditRepo
+}
+
+func newAuditedWallet(t *testing.T, walletRepo repository.WalletRepository, userID string, balance int64) *model.Wallet {
+	wallet, err := model.NewWalletWithInitialBalance(userID, "Main", model.WalletTypeCash, "USD", balance)
+	require.NoError(t, err)
+	require.NoError(t, walletRepo.Save(wallet))
+	return wallet
+}
+
+func walletSnapshot(t *testing.T, snapshot []byte) mapper.WalletData {
+	require.NotNil(t, snapshot)
+	var data mapper.WalletData
+	require.NoError(t, json.Unmarshal(snapshot, &data))
+	return data
+}
+
+func newAuditedAddExpense(walletRepo repository.AuditableWalletRepository) *audit.WalletCommand[usecase.AddExpenseInput] {
+	return audit.NewWalletCommand("AddExpense", walletRepo, nil,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.AddExpenseInput] {
+			return command.NewAddExpenseService(repos.Wallets, nil, nil, nil)
+		})
+}
+
+func Test_WalletCommand_RecordsOnlyTheChangedRecords(t *testing.T) {
+	// Arrange - 錢包已有一筆未稽核的支出
+	_, walletRepo, auditRepo := newAuditedWalletRepo()
+	wallet := newAuditedWallet(t, walletRepo, "user-123", 1000)
+	existing := command.NewAddExpenseService(walletRepo, nil, nil, nil).Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: wallet.ID, SubcategoryID: "food", Amount: 100, Currency: "USD", Date: time.Now(),
+	})
+	require.Equal(t, common.Success, existing.GetExitCode(), existing.GetMessage())
+	service := newAuditedAddExpense(walletRepo)
+
+	// Act
+	output := service.Execute(usecase.AddExpenseInput{
+		CommandMetadata: usecase.CommandMetadata{ActorID: "user-123", RequestID: "req-1"},
+		UserID:          "user-123",
+		WalletID:        wallet.ID,
+		SubcategoryID:   "food",
+		Amount:          250,
+		Currency:        "USD",
+		Description:     "Lunch",
+		Date:            time.Now(),
+	})
+
+	// Assert - 快照只有錢包欄位與新增的支出
+	require.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	entries := auditRepo.Entries()
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, "user-123", entry.ActorID)
+	assert.Equal(t, "AddExpense", entry.Command)
+	assert.Equal(t, mapper.AuditAggregateWallet, entry.AggregateType)
+	assert.Equal(t, wallet.ID, entry.AggregateID)
+	assert.Equal(t, "req-1", entry.RequestID)
+	before := walletSnapshot(t, entry.Before)
+	assert.Equal(t, int64(900), before.BalanceAmount)
+	assert.Empty(t, before.ExpenseRecords)
+	after := walletSnapshot(t, entry.After)
+	assert.Equal(t, int64(650), after.BalanceAmount)
+	assert.Equal(t, before.Version+1, after.Version)
+	require.Len(t, after.ExpenseRecords, 1)
+	assert.Equal(t, output.GetID(), after.ExpenseRecords[0].ID)
+	assert.Equal(t, mapper.AuditGenesisHash, entry.PrevHash)
+	assert.Equal(t, entry.ComputeHash(), entry.Hash)
+}
+
+func Test_WalletCommand_AuditFailureFailsTheCommand(t *testing.T) {
+	// Arrange
+	peer, walletRepo, auditRepo := newAuditedWalletRepo()
+	wallet := newAuditedWallet(t, walletRepo, "user-123", 1000)
+	peer.AuditErr = errors.New("audit log unavailable")
+
+	// Act
+	output := newAuditedAddExpense(walletRepo).Execute(usecase.AddExpenseInput{
+		CommandMetadata: usecase.CommandMetadata{ActorID: "user-123"},
+		UserID:          "user-123",
+		WalletID:        wallet.ID,
+		SubcategoryID:   "food",
+		Amount:          250,
+		Currency:        "USD",
+		Date:            time.Now(),
+	})
+
+	// Assert - 錢包與稽核紀錄在同一個交易中，兩者都沒有寫入
+	assert.Equal(t, common.Failure, output.GetExitCode())
+	assert.Empty(t, auditRepo.Entries())
+	saved, err := walletRepo.FindByIDWithTransactions(wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), saved.Balance.Amount)
+	assert.Empty(t, saved.GetExpenseRecords())
+}
+
+func Test_WalletCommand_FailedCommandIsNotRecorded(t *testing.T) {
+	// Arrange
+	_, walletRepo, auditRepo := newAuditedWalletRepo()
+	wallet := newAuditedWallet(t, walletRepo, "owner", 1000)
+	service := audit.NewWalletCommand("DeleteWallet", walletRepo, nil,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.DeleteWalletInput] {
+			return command.NewDeleteWalletService(repos.Wallets, nil, nil)
+		})
+
+	// Act - another user's wallet is reported as not found
+	output := service.Execute(usecase.DeleteWalletInput{
+		CommandMetadata: usecase.CommandMetadata{ActorID: "intruder"},
+		UserID:          "intruder",
+		WalletID:        wallet.ID,
+	})
+
+	// Assert
+	assert.Equal(t, common.NotFound, output.GetExitCode())
+	assert.Empty(t, auditRepo.Entries())
+}
+
+func Test_WalletCommand_CreateDeleteExpenseAndDeleteWallet(t *testing.T) {
+	// Arrange
+	_, walletRepo, auditRepo := newAuditedWalletRepo()
+	createWallet := audit.NewWalletCommand("CreateWallet", walletRepo, nil,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.CreateWalletInput] {
+			return command.NewCreateWalletService(repos.Wallets, nil)
+		})
+	addExpense := command.NewAddExpenseService(walletRepo, nil, nil, nil)
+	deleteExpense := audit.NewWalletCommand("DeleteExpense", walletRepo, nil,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.DeleteExpenseInput] {
+			return command.NewDeleteExpenseService(repos.Wallets, nil, nil)
+		})
+	deleteWallet := audit.NewWalletCommand("DeleteWallet", walletRepo, nil,
+		func(repos audit.WalletRepositories) audit.Executor[usecase.DeleteWalletInput] {
+			return command.NewDeleteWalletService(repos.Wallets, test.NewFakeAttachmentRepository(), test.NewFakeBlobStore())
+		})
+	metadata := usecase.CommandMetadata{ActorID: "user-123", RequestID: "req-2"}
+	initialBalance := int64(1000)
+
+	// Act
+	created := createWallet.Execute(usecase.CreateWalletInput{
+		CommandMetadata: metadata, UserID: "user-123", Name: "Main", Type: "CASH", Currency: "USD", InitialBalance: &initialBalance,
+	})
+	require.Equal(t, common.Success, created.GetExitCode(), created.GetMessage())
+	expense := addExpense.Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: created.GetID(), SubcategoryID: "food", Amount: 100, Currency: "USD", Date: time.Now(),
+	})
+	require.Equal(t, common.Success, expense.GetExitCode(), expense.GetMessage())
+	deleted := deleteExpense.Execute(usecase.DeleteExpenseInput{
+		CommandMetadata: metadata, UserID: "user-123", ExpenseID: expense.GetID(),
+	})
+	require.Equal(t, common.Success, deleted.GetExitCode(), deleted.GetMessage())
+	removed := deleteWallet.Execute(usecase.DeleteWalletInput{
+		CommandMetadata: metadata, UserID: "user-123", WalletID: created.GetID(),
+	})
+	require.Equal(t, common.Success, removed.GetExitCode(), removed.GetMessage())
+
+	// Assert - 建立時沒有前快照；刪除支出的前快照是被刪除的支出；刪除錢包沒有後快照
+	entries := auditRepo.Entries()
+	require.Len(t, entries, 3)
+	assert.Equal(t, created.GetID(), entries[0].AggregateID)
+	assert.Nil(t, entries[0].Before)
+	assert.Equal(t, "Main", walletSnapshot(t, entries[0].After).Name)
+	assert.Equal(t, created.GetID(), entries[1].AggregateID)
+	before := walletSnapshot(t, entries[1].Before)
+	require.Len(t, before.ExpenseRecords, 1)
+	assert.Equal(t, expense.GetID(), before.ExpenseRecords[0].ID)
+	assert.Equal(t, int64(900), before.BalanceAmount)
+	after := walletSnapshot(t, entries[1].After)
+	assert.Empty(t, after.ExpenseRecords)
+	assert.Equal(t, int64(1000), after.BalanceAmount)
+	assert.Equal(t, "DeleteWallet", entries[2].Command)
+	assert.Equal(t, int64(1000), walletSnapshot(t, entries[2].Before).BalanceAmount)
+	assert.Nil(t, entries[2].After)
+	assert.Equal(t, entries[0].Hash, entries[1].PrevHash)
+	assert.Equal(t, entries[1].Hash, entries[2].PrevHash)
+}
+
+func Test_WalletCommand_TagEditRecordsPreviousTags(t *testing.T) {
+	// Arrange
+	peer, walletRepo, auditRepo := newAuditedWalletRepo()
+	wallet := newAuditedWallet(t, walletRepo, "user-123", 1000)
+	expense := command.NewAddExpenseService(walletRepo, nil, nil, nil).Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: wallet.ID, SubcategoryID: "food", Amount: 100, Currency: "USD", Date: time.Now(),
+		Tags: []string{"work"},
+	})
+	require.Equal(t, common.Success, expense.GetExitCode(), expense.GetMessage())
+	service := audit.NewWalletCommand("EditTransactionTags", walletRepo, test.NewFakeWalletPeerUnitOfWork(peer),
+		func(repos audit.WalletRepositories) audit.Executor[usecase.EditTransactionTagsInput] {
+			return command.NewEditTransactionTagsService(repos.UnitOfWork)
+		})
+
+	// Act
+	output := service.Execute(usecase.EditTransactionTagsInput{
+		CommandMetadata: usecase.CommandMetadata{ActorID: "user-123"},
+		UserID:          "user-123",
+		ExpenseIDs:      []string{expense.GetID()},
+		Add:             []string{"travel"},
+	})
+
+	// Assert
+	require.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	entries := auditRepo.Entries()
+	require.Len(t, entries, 1)
+	before := walletSnapshot(t, entries[0].Before)
+	require.Len(t, before.ExpenseRecords, 1)
+	assert.Equal(t, []string{"work"}, before.ExpenseRecords[0].Tags)
+	after := walletSnapshot(t, entries[0].After)
+	require.Len(t, after.ExpenseRecords, 1)
+	assert.Equal(t, []string{"travel", "work"}, after.ExpenseRecords[0].Tags)
+}
+
+func Test_WalletCommand_TransferRecordsBothWallets(t *testing.T) {
+	// Arrange
+	peer, walletRepo, auditRepo := newAuditedWalletRepo()
+	from := newAuditedWallet(t, walletRepo, "user-123", 1000)
+	to := newAuditedWallet(t, walletRepo, "user-123", 0)
+	service := audit.NewWalletCommand("ProcessTransfer", walletRepo, test.NewFakeWalletPeerUnitOfWork(peer),
+		func(repos audit.WalletRepositories) audit.Executor[usecase.ProcessTransferInput] {
+			return command.NewProcessTransferService(repos.UnitOfWork, nil)
+		})
+
+	// Act
+	output := service.Execute(usecase.ProcessTransferInput{
+		CommandMetadata: usecase.CommandMetadata{ActorID: "user-123", RequestID: "req-3"},
+		UserID:          "user-123",
+		FromWalletID:    from.ID,
+		ToWalletID:      to.ID,
+		Amount:          400,
+		Currency:        "USD",
+		Date:            time.Now(),
+	})
+
+	// Assert
+	require.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	entries := auditRepo.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, from.ID, entries[0].AggregateID)
+	assert.Equal(t, int64(1000), walletSnapshot(t, entries[0].Before).BalanceAmount)
+	assert.Equal(t, int64(600), walletSnapshot(t, entries[0].After).BalanceAmount)
+	assert.Len(t, walletSnapshot(t, entries[0].After).Transfers, 1)
+	assert.Equal(t, to.ID, entries[1].AggregateID)
+	assert.Equal(t, int64(400), walletSnapshot(t, entries[1].After).BalanceAmount)
+	assert.Equal(t, "req-3", entries[1].RequestID)
+}
+
+func Test_VerifyAuditLogService_DetectsTampering(t *testing.T) {
+	// Arrange
+	auditRepo := test.NewFakeAuditLogRepository()
+	recorder := audit.NewRecorder(auditRepo)
+	for i := 0; i < 3; i++ {
+		recorder.Record(mapper.AuditEntryData{
+			ID: string(rune('a' + i)), ActorID: "user-123", Command: "RenameWallet",
+			AggregateType: mapper.AuditAggregateWallet, AggregateID: "wallet-1",
+			After: []byte(`{"Name":"Main"}`), OccurredAt: time.Now(),
+		})
+	}
+	service := query.NewVerifyAuditLogService(auditRepo)
+
+	// Act & Assert - 未被修改
+	result, ok := service.Execute(usecase.VerifyAuditLogInput{}).(usecase.VerifyAuditLogOutput)
+	require.True(t, ok)
+	assert.True(t, result.Valid)
+	assert.Equal(t, int64(3), result.EntriesChecked)
+
+	// Act & Assert - 修改快照但保留原雜湊
+	auditRepo.Tamper(2, func(entry *mapper.AuditEntryData) {
+		entry.After = []byte(`{"Name":"Savings"}`)
+	})
+	result = service.Execute(usecase.VerifyAuditLogInput{}).(usecase.VerifyAuditLogOutput)
+	assert.False(t, result.Valid)
+	assert.Equal(t, int64(2), result.BrokenAtSequence)
+	assert.Equal(t, int64(1), result.EntriesChecked)
+
+	// Act & Assert - 連同雜湊一起重算，下一筆的前一筆雜湊仍對不上
+	auditRepo.Tamper(2, func(entry *mapper.AuditEntryData) {
+		entry.Hash = entry.ComputeHash()
+	})
+	result = service.Execute(usecase.VerifyAuditLogInput{}).(usecase.VerifyAuditLogOutput)
+	assert.False(t, result.Valid)
+	assert.Equal(t, int64(3), result.BrokenAtSequence)
+}
+
+func Test_GetAuditLogService_Filters(t *testing.T) {
+	// Arrange
+	auditRepo := test.NewFakeAuditLogRepository()
+	recorder := audit.NewRecorder(auditRepo)
+	now := time.Now()
+	recorder.Record(mapper.AuditEntryData{ID: "1", ActorID: "alice", Command: "AddExpense", AggregateID: "w1", RequestID: "req-a", OccurredAt: now})
+	recorder.Record(mapper.AuditEntryData{ID: "2", ActorID: "bob", Command: "AddExpense", AggregateID: "w2", RequestID: "req-b", OccurredAt: now})
+	recorder.Record(mapper.AuditEntryData{ID: "3", ActorID: "alice", Command: "DeleteWallet", AggregateID: "w1", RequestID: "req-c", OccurredAt: now})
+	service := query.NewGetAuditLogService(auditRepo)
+
+	// Act
+	result := service.Execute(usecase.GetAuditLogInput{ActorID: "alice"}).(usecase.GetAuditLogOutput)
+	byCommand := service.Execute(usecase.GetAuditLogInput{Command: "AddExpense", RequestID: "req-b"}).(usecase.GetAuditLogOutput)
+	invalid := service.Execute(usecase.GetAuditLogInput{From: &now, To: &now})
+
+	// Assert - 由新到舊
+	require.Len(t, result.Entries, 2)
+	assert.Equal(t, "3", result.Entries[0].ID)
+	assert.Equal(t, "1", result.Entries[1].ID)
+	require.Len(t, byCommand.Entries, 1)
+	assert.Equal(t, "bob", byCommand.Entries[0].ActorID)
+	assert.Equal(t, common.InvalidInput, invalid.GetExitCode())
+}
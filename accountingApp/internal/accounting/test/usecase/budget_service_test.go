@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AddExpenseService_WarnsWhenBudgetCrossesThreshold(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	budgetRepo := test.NewFakeBudgetRepository()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 100000)
+	category, subcategory := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Food", "Lunch")
+	created := command.NewCreateBudgetService(budgetRepo, categoryRepo).Execute(usecase.CreateBudgetInput{
+		UserID:     "user-123",
+		Name:       "Food",
+		Period:     string(model.BudgetPeriodMonthly),
+		CategoryID: category.ID,
+		Amount:     1000,
+		Currency:   "TWD",
+		StartDate:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.Equal(t, common.Success, created.GetExitCode(), created.GetMessage())
+	service := command.NewAddExpenseService(walletRepo, nil,
+		query.NewCheckBudgetWarningsService(budgetRepo, walletRepo, categoryRepo, nil), nil)
+	march := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	lunch := func(amount int64, date time.Time) usecase.AddExpenseInput {
+		return usecase.AddExpenseInput{
+			UserID: "user-123", WalletID: wallet.ID, SubcategoryID: subcategory.ID,
+			Amount: amount, Currency: "TWD", Description: "Lunch", Date: date,
+		}
+	}
+
+	// Act & Assert - below the default 80% threshold
+	output := service.Execute(lunch(700, march)).(usecase.AddExpenseOutput)
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	assert.Empty(t, output.Warnings)
+
+	// Crossing 80% warns once
+	output = service.Execute(lunch(150, march)).(usecase.AddExpenseOutput)
+	assert.Len(t, output.Warnings, 1)
+	assert.Equal(t, created.GetID(), output.Warnings[0].BudgetID)
+	assert.Equal(t, int64(850), output.Warnings[0].Status.Spent)
+	assert.False(t, output.Warnings[0].Status.OverBudget)
+
+	// Already past the threshold: no repeated warning
+	output = service.Execute(lunch(10, march)).(usecase.AddExpenseOutput)
+	assert.Empty(t, output.Warnings)
+
+	// Going over the limit warns again
+	output = service.Execute(lunch(200, march)).(usecase.AddExpenseOutput)
+	assert.Len(t, output.Warnings, 1)
+	assert.True(t, output.Warnings[0].Status.OverBudget)
+	assert.Equal(t, int64(-60), output.Warnings[0].Status.Remaining)
+
+	// An expense in another month starts from zero
+	output = service.Execute(lunch(100, march.AddDate(0, 1, 0))).(usecase.AddExpenseOutput)
+	assert.Empty(t, output.Warnings)
+}
+
+func Test_GetBudgetStatusService_ComputesSpentAndRemaining(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	budgetRepo := test.NewFakeBudgetRepository()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 100000)
+	category, subcategory := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Food", "Lunch")
+	created := command.NewCreateBudgetService(budgetRepo, categoryRepo).Execute(usecase.CreateBudgetInput{
+		UserID:     "user-123",
+		Name:       "Food",
+		Period:     string(model.BudgetPeriodMonthly),
+		CategoryID: category.ID,
+		Amount:     1000,
+		Currency:   "TWD",
+		StartDate:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	addExpense := command.NewAddExpenseService(walletRepo, nil, nil, nil)
+	for _, expense := range []struct {
+		amount int64
+		date   time.Time
+	}{
+		{300, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{200, time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC)},
+		{999, time.Date(2024, 2, 25, 0, 0, 0, 0, time.UTC)},
+	} {
+		output := addExpense.Execute(usecase.AddExpenseInput{
+			UserID: "user-123", WalletID: wallet.ID, SubcategoryID: subcategory.ID,
+			Amount: expense.amount, Currency: "TWD", Date: expense.date,
+		})
+		assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	}
+	service := query.NewGetBudgetStatusService(budgetRepo, walletRepo, categoryRepo, nil)
+
+	// Act
+	output := service.Execute(usecase.GetBudgetStatusInput{
+		UserID:   "user-123",
+		BudgetID: created.GetID(),
+		At:       time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+	})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	status := output.(usecase.GetBudgetStatusOutput).Status
+	assert.NotNil(t, status)
+	assert.Equal(t, "2024-03-01", status.PeriodStart)
+	assert.Equal(t, "2024-04-01", status.PeriodEnd)
+	assert.Equal(t, int64(500), status.Spent)
+	assert.Equal(t, int64(500), status.Remaining)
+	assert.False(t, status.Warning)
+}
+
+func Test_BudgetServices_ConvertOtherCurrencyExpenses(t *testing.T) {
+	// Arrange - a TWD budget and a euro wallet, 1 EUR = 35 TWD
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	budgetRepo := test.NewFakeBudgetRepository()
+	euroWallet := test.CreateWallet(t, walletRepo, "user-123", "EUR", 100000)
+	_, subcategory := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Travel", "Hotel")
+	created := command.NewCreateBudgetService(budgetRepo, categoryRepo).Execute(usecase.CreateBudgetInput{
+		UserID:        "user-123",
+		Name:          "Hotels",
+		Period:        string(model.BudgetPeriodMonthly),
+		SubcategoryID: subcategory.ID,
+		Amount:        1000,
+		Currency:      "TWD",
+		StartDate:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	converter := exchange.NewConverter(seedExchangeRates(t, "2024-03-01,EUR,TWD,35"), model.RoundHalfUp)
+	addExpense := command.NewAddExpenseService(walletRepo, nil,
+		query.NewCheckBudgetWarningsService(budgetRepo, walletRepo, categoryRepo, converter), nil)
+	march := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	// Act - 25.00 EUR is 875 TWD
+	output := addExpense.Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: euroWallet.ID, SubcategoryID: subcategory.ID,
+		Amount: 2500, Currency: "EUR", Date: march,
+	}).(usecase.AddExpenseOutput)
+	status := query.NewGetBudgetStatusService(budgetRepo, walletRepo, categoryRepo, converter).Execute(usecase.GetBudgetStatusInput{
+		UserID: "user-123", BudgetID: created.GetID(), At: march,
+	})
+
+	// Assert - the expense crosses the 80% threshold in the budget currency
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	if assert.Len(t, output.Warnings, 1) {
+		assert.Equal(t, int64(875), output.Warnings[0].Status.Spent)
+		assert.Equal(t, "TWD", output.Warnings[0].Status.Currency)
+	}
+	assert.Equal(t, int64(875), status.(usecase.GetBudgetStatusOutput).Status.Spent)
+
+	// Without a rate on or before the expense date the status cannot be computed
+	later := exchange.NewConverter(seedExchangeRates(t, "2024-04-01,EUR,TWD,35"), model.RoundHalfUp)
+	status = query.NewGetBudgetStatusService(budgetRepo, walletRepo, categoryRepo, later).Execute(usecase.GetBudgetStatusInput{
+		UserID: "user-123", BudgetID: created.GetID(), At: march,
+	})
+	assert.Equal(t, common.InvalidInput, status.GetExitCode())
+	assert.Contains(t, status.GetMessage(), "no exchange rate from EUR to TWD")
+}
+
+func Test_CreateBudgetService_RejectsOtherUsersCategory(t *testing.T) {
+	// Arrange
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	_, subcategory := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Food", "Lunch")
+	service := command.NewCreateBudgetService(test.NewFakeBudgetRepository(), categoryRepo)
+
+	// Act
+	output := service.Execute(usecase.CreateBudgetInput{
+		UserID:        "intruder",
+		Name:          "Food",
+		Period:        string(model.BudgetPeriodWeekly),
+		SubcategoryID: subcategory.ID,
+		Amount:        1000,
+		Currency:      "TWD",
+		StartDate:     time.Now(),
+	})
+
+	// Assert
+	assert.Equal(t, common.NotFound, output.GetExitCode())
+	assert.Equal(t, "Expense category not found", output.GetMessage())
+}
+
+func Test_UpdateAndDeleteBudgetService_OwnerOnly(t *testing.T) {
+	// Arrange
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	budgetRepo := test.NewFakeBudgetRepository()
+	category, _ := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Food", "Lunch")
+	created := command.NewCreateBudgetService(budgetRepo, categoryRepo).Execute(usecase.CreateBudgetInput{
+		UserID:     "user-123",
+		Name:       "Food",
+		Period:     string(model.BudgetPeriodMonthly),
+		CategoryID: category.ID,
+		Amount:     1000,
+		Currency:   "TWD",
+		StartDate:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	budgetID := created.GetID()
+	amount := int64(2000)
+	threshold := 95
+
+	// Act & Assert - other users cannot see the budget
+	output := command.NewUpdateBudgetService(budgetRepo).Execute(usecase.UpdateBudgetInput{
+		UserID: "intruder", BudgetID: budgetID, Amount: &amount,
+	})
+	assert.Equal(t, "Budget not found", output.GetMessage())
+
+	output = command.NewUpdateBudgetService(budgetRepo).Execute(usecase.UpdateBudgetInput{
+		UserID: "user-123", BudgetID: budgetID, Amount: &amount, WarningThreshold: &threshold,
+	})
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	budget, _ := budgetRepo.FindByID(budgetID)
+	assert.Equal(t, int64(2000), budget.Amount.Amount)
+	assert.Equal(t, 95, budget.WarningThreshold)
+
+	output = command.NewDeleteBudgetService(budgetRepo).Execute(usecase.DeleteBudgetInput{
+		UserID: "user-123", BudgetID: budgetID,
+	})
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	budget, _ = budgetRepo.FindByID(budgetID)
+	assert.Nil(t, budget)
+}
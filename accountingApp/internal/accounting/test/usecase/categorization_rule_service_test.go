@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateCategorizationRuleService_RejectsInvalidRulesAndOtherUsersTargets(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	ruleRepo := test.NewFakeCategorizationRuleRepository()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 1000)
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Food", "Groceries")
+	_, salary := test.CreateIncomeSubcategory(t, incomeCategoryRepo, "user-123", "Salary", "Monthly")
+	service := command.NewCreateCategorizationRuleService(ruleRepo, walletRepo, expenseCategoryRepo, incomeCategoryRepo)
+	valid := usecase.CreateCategorizationRuleInput{
+		UserID:        "user-123",
+		Name:          "Coffee",
+		Type:          "EXPENSE",
+		Conditions:    usecase.RuleConditionsData{DescriptionPattern: "starbucks"},
+		SubcategoryID: groceries.ID,
+	}
+
+	tests := []struct {
+		name     string
+		change   func(input *usecase.CreateCategorizationRuleInput)
+		code     common.ExitCode
+		expected string
+	}{
+		{"no conditions", func(in *usecase.CreateCategorizationRuleInput) { in.Conditions = usecase.RuleConditionsData{} }, common.InvalidInput, "Invalid categorization rule"},
+		{"unknown weekday", func(in *usecase.CreateCategorizationRuleInput) { in.Conditions.Weekdays = []string{"FUNDAY"} }, common.InvalidInput, "Invalid categorization rule"},
+		{"unknown type", func(in *usecase.CreateCategorizationRuleInput) { in.Type = "TRANSFER" }, common.InvalidInput, "Invalid categorization rule"},
+		{"income subcategory on an expense rule", func(in *usecase.CreateCategorizationRuleInput) { in.SubcategoryID = salary.ID }, common.NotFound, "Subcategory not found"},
+		{"another user's subcategory", func(in *usecase.CreateCategorizationRuleInput) { in.UserID = "user-456" }, common.NotFound, "Subcategory not found"},
+		{"another user's wallet", func(in *usecase.CreateCategorizationRuleInput) {
+			in.UserID = "user-456"
+			in.Conditions.WalletID = wallet.ID
+		}, common.NotFound, "Wallet not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := valid
+			tt.change(&input)
+
+			// Act
+			output := service.Execute(input)
+
+			// Assert
+			assert.Equal(t, tt.code, output.GetExitCode())
+			assert.Contains(t, output.GetMessage(), tt.expected)
+		})
+	}
+	rules, _ := ruleRepo.FindByUserID("user-123")
+	assert.Empty(t, rules)
+}
+
+func Test_CategorizationRuleServices_UpdateListAndTest(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	ruleRepo := test.NewFakeCategorizationRuleRepository()
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Food", "Groceries")
+	_, coffee := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Coffee", "Coffee")
+	create := command.NewCreateCategorizationRuleService(ruleRepo, walletRepo, expenseCategoryRepo, incomeCategoryRepo)
+	broad := create.Execute(usecase.CreateCategorizationRuleInput{
+		UserID:        "user-123",
+		Name:          "Food",
+		Type:          "EXPENSE",
+		Priority:      5,
+		Conditions:    usecase.RuleConditionsData{DescriptionPattern: "cafe|coffee|starbucks"},
+		SubcategoryID: groceries.ID,
+	}).GetID()
+	specific := create.Execute(usecase.CreateCategorizationRuleInput{
+		UserID:        "user-123",
+		Name:          "Weekday coffee",
+		Type:          "EXPENSE",
+		Priority:      10,
+		Conditions:    usecase.RuleConditionsData{DescriptionPattern: "starbucks", Weekdays: []string{"mon", "TUESDAY"}},
+		SubcategoryID: coffee.ID,
+		Tags:          []string{"Caffeine"},
+	}).GetID()
+
+	// Act - move the specific rule ahead of the broad one
+	update := command.NewUpdateCategorizationRuleService(ruleRepo, walletRepo, expenseCategoryRepo, incomeCategoryRepo).Execute(usecase.UpdateCategorizationRuleInput{
+		UserID:        "user-123",
+		RuleID:        specific,
+		Name:          "Weekday coffee",
+		Priority:      1,
+		Conditions:    usecase.RuleConditionsData{DescriptionPattern: "starbucks", Weekdays: []string{"mon", "TUESDAY"}},
+		SubcategoryID: coffee.ID,
+		Tags:          []string{"Caffeine"},
+	})
+	listed := query.NewGetCategorizationRulesService(ruleRepo).Execute(usecase.GetCategorizationRulesInput{UserID: "user-123", Type: "EXPENSE"})
+	testService := query.NewTestCategorizationRuleService(ruleRepo)
+	monday := testService.Execute(usecase.TestCategorizationRuleInput{
+		UserID: "user-123", Type: "EXPENSE", Description: "Starbucks Reserve", Amount: 150, Currency: "TWD",
+		Date: time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC),
+	})
+	sunday := testService.Execute(usecase.TestCategorizationRuleInput{
+		UserID: "user-123", Type: "EXPENSE", Description: "Starbucks Reserve", Amount: 150, Currency: "TWD",
+		Date: time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC),
+	})
+	other := query.NewGetCategorizationRulesService(ruleRepo).Execute(usecase.GetCategorizationRulesInput{UserID: "user-456"})
+
+	// Assert
+	assert.Equal(t, common.Success, update.GetExitCode(), update.GetMessage())
+	rules := listed.(usecase.GetCategorizationRulesOutput).Rules
+	if assert.Len(t, rules, 2) {
+		assert.Equal(t, []string{specific, broad}, []string{rules[0].ID, rules[1].ID})
+		assert.Equal(t, []string{"MONDAY", "TUESDAY"}, rules[0].Conditions.Weekdays)
+		assert.Equal(t, []string{"caffeine"}, rules[0].Tags)
+	}
+
+	matched := monday.(usecase.TestCategorizationRuleOutput)
+	if assert.NotNil(t, matched.Matched) {
+		assert.Equal(t, specific, matched.Matched.ID)
+	}
+	assert.Len(t, matched.Matching, 2)
+	fallback := sunday.(usecase.TestCategorizationRuleOutput)
+	if assert.NotNil(t, fallback.Matched) {
+		assert.Equal(t, broad, fallback.Matched.ID)
+	}
+	assert.Empty(t, other.(usecase.GetCategorizationRulesOutput).Rules)
+}
+
+func Test_CommitImportService_AppliesRulesAndFallsBackToMapping(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	ruleRepo := test.NewFakeCategorizationRuleRepository()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 1000)
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Food", "Groceries")
+	_, salary := test.CreateIncomeSubcategory(t, incomeCategoryRepo, "user-123", "Salary", "Monthly")
+	_, coffee := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Coffee", "Coffee")
+	profileRepo := test.NewFakeImportProfileRepository()
+	ruleID := command.NewCreateCategorizationRuleService(ruleRepo, walletRepo, expenseCategoryRepo, incomeCategoryRepo).Execute(usecase.CreateCategorizationRuleInput{
+		UserID:        "user-123",
+		Name:          "Coffee",
+		Type:          "EXPENSE",
+		Conditions:    usecase.RuleConditionsData{DescriptionPattern: `^starbucks\b`},
+		SubcategoryID: coffee.ID,
+		Tags:          []string{"caffeine"},
+	}).GetID()
+	mapping := signedAmountMapping()
+	mapping.ExpenseSubcategoryID, mapping.IncomeSubcategoryID = groceries.ID, salary.ID
+	profileID := command.NewCreateImportProfileService(profileRepo, expenseCategoryRepo, incomeCategoryRepo).Execute(usecase.CreateImportProfileInput{
+		UserID: "user-123", Name: "My Bank", Mapping: mapping,
+	}).GetID()
+	content := "Date,Memo,Amount\n" +
+		"2024-03-02,STARBUCKS #12,-120\n" +
+		"2024-03-03,Supermarket,-300\n"
+
+	// Act
+	output := command.NewCommitImportService(walletRepo, profileRepo, expenseCategoryRepo, incomeCategoryRepo, ruleRepo).Execute(usecase.CommitImportInput{
+		StatementFile: csvStatement(profileID, content), UserID: "user-123", WalletID: wallet.ID,
+	})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	result := output.(usecase.CommitImportOutput)
+	if assert.Len(t, result.Rows, 2) {
+		assert.Equal(t, ruleID, result.Rows[0].RuleID)
+		assert.Equal(t, coffee.ID, result.Rows[0].SubcategoryID)
+		assert.Empty(t, result.Rows[1].RuleID)
+		assert.Equal(t, groceries.ID, result.Rows[1].SubcategoryID)
+	}
+
+	saved, _ := walletRepo.FindByIDWithTransactions(wallet.ID)
+	expenses := saved.GetExpenseRecords()
+	if assert.Len(t, expenses, 2) {
+		assert.Equal(t, coffee.ID, expenses[0].SubcategoryID)
+		assert.Equal(t, []string{"caffeine"}, expenses[0].Tags)
+		assert.Equal(t, groceries.ID, expenses[1].SubcategoryID)
+	}
+}
+
+func Test_AddExpenseService_CategorizesExpensesWithoutSubcategory(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	ruleRepo := test.NewFakeCategorizationRuleRepository()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 1000)
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Food", "Groceries")
+	created := command.NewCreateCategorizationRuleService(ruleRepo, walletRepo, expenseCategoryRepo, incomeCategoryRepo).Execute(usecase.CreateCategorizationRuleInput{
+		UserID:        "user-123",
+		Name:          "Groceries",
+		Type:          "EXPENSE",
+		Conditions:    usecase.RuleConditionsData{DescriptionPattern: "market", MaxAmount: int64Ptr(500), Currency: "TWD"},
+		SubcategoryID: groceries.ID,
+		Tags:          []string{"weekly"},
+	})
+	assert.Equal(t, common.Success, created.GetExitCode(), created.GetMessage())
+	service := command.NewAddExpenseService(walletRepo, nil, nil, ruleRepo)
+	input := usecase.AddExpenseInput{
+		UserID:      "user-123",
+		WalletID:    wallet.ID,
+		Amount:      300,
+		Currency:    "TWD",
+		Description: "Night market",
+		Date:        time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		Tags:        []string{"family"},
+	}
+
+	// Act
+	categorized := service.Execute(input)
+	input.Amount = 800
+	unmatched := service.Execute(input)
+
+	// Assert
+	assert.Equal(t, common.Success, categorized.GetExitCode(), categorized.GetMessage())
+	assert.Equal(t, common.Failure, unmatched.GetExitCode())
+	assert.Contains(t, unmatched.GetMessage(), "subcategory_id is required")
+
+	saved, _ := walletRepo.FindByIDWithTransactions(wallet.ID)
+	if assert.Len(t, saved.GetExpenseRecords(), 1) {
+		record := saved.GetExpenseRecords()[0]
+		assert.Equal(t, groceries.ID, record.SubcategoryID)
+		assert.ElementsMatch(t, []string{"family", "weekly"}, record.Tags)
+	}
+}
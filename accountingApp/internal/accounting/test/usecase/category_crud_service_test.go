@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createExpenseCategoryInRepo(t *testing.T, repo repository.ExpenseCategoryRepository, name string) string {
+	output := command.NewCreateExpenseCategoryService(repo).Execute(usecase.CreateExpenseCategoryInput{
+		UserID: "user-123",
+		Name:   name,
+	})
+	require.Equal(t, common.Success, output.GetExitCode())
+	return output.GetID()
+}
+
+func Test_RenameExpenseCategoryService(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeExpenseCategoryRepository()
+	categoryID := createExpenseCategoryInRepo(t, repo, "Food")
+
+	// Act
+	output := command.NewRenameExpenseCategoryService(repo).Execute(usecase.RenameExpenseCategoryInput{
+		UserID:     "user-123",
+		CategoryID: categoryID,
+		Name:       "Dining",
+	})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode())
+	category, _ := repo.FindByID(categoryID)
+	assert.Equal(t, "Dining", category.Name.Value)
+}
+
+func Test_RenameExpenseCategoryService_NotFound(t *testing.T) {
+	repo := test.NewFakeExpenseCategoryRepository()
+
+	output := command.NewRenameExpenseCategoryService(repo).Execute(usecase.RenameExpenseCategoryInput{
+		UserID:     "user-123",
+		CategoryID: "missing",
+		Name:       "Dining",
+	})
+
+	assert.Equal(t, common.NotFound, output.GetExitCode())
+	assert.Equal(t, "Expense category not found", output.GetMessage())
+}
+
+func Test_ExpenseSubcategoryLifecycle(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeExpenseCategoryRepository()
+	categoryID := createExpenseCategoryInRepo(t, repo, "Food")
+
+	// Act - 新增
+	added := command.NewAddExpenseSubcategoryService(repo).Execute(usecase.AddExpenseSubcategoryInput{
+		UserID:     "user-123",
+		CategoryID: categoryID,
+		Name:       "Lunch",
+	})
+	require.Equal(t, common.Success, added.GetExitCode())
+	subcategoryID := added.GetID()
+
+	// Act - 重新命名
+	renamed := command.NewRenameExpenseSubcategoryService(repo).Execute(usecase.RenameExpenseSubcategoryInput{
+		UserID:        "user-123",
+		CategoryID:    categoryID,
+		SubcategoryID: subcategoryID,
+		Name:          "Brunch",
+	})
+	require.Equal(t, common.Success, renamed.GetExitCode())
+
+	category, _ := repo.FindBySubcategoryID(subcategoryID)
+	require.NotNil(t, category)
+	assert.Equal(t, categoryID, category.ID)
+	assert.Equal(t, "Brunch", category.Subcategories[0].Name.Value)
+
+	// Act - 移除
+	removed := command.NewRemoveExpenseSubcategoryService(repo).Execute(usecase.RemoveExpenseSubcategoryInput{
+		UserID:        "user-123",
+		CategoryID:    categoryID,
+		SubcategoryID: subcategoryID,
+	})
+
+	// Assert
+	assert.Equal(t, common.Success, removed.GetExitCode())
+	category, _ = repo.FindByID(categoryID)
+	assert.Empty(t, category.Subcategories)
+}
+
+func Test_AddExpenseSubcategoryService_DuplicateName(t *testing.T) {
+	repo := test.NewFakeExpenseCategoryRepository()
+	categoryID := createExpenseCategoryInRepo(t, repo, "Food")
+	service := command.NewAddExpenseSubcategoryService(repo)
+	service.Execute(usecase.AddExpenseSubcategoryInput{UserID: "user-123", CategoryID: categoryID, Name: "Lunch"})
+
+	output := service.Execute(usecase.AddExpenseSubcategoryInput{UserID: "user-123", CategoryID: categoryID, Name: "Lunch"})
+
+	assert.Equal(t, common.InvalidInput, output.GetExitCode())
+	assert.Contains(t, output.GetMessage(), "Adding subcategory failed")
+}
+
+func Test_RemoveExpenseSubcategoryService_UnknownSubcategory(t *testing.T) {
+	repo := test.NewFakeExpenseCategoryRepository()
+	categoryID := createExpenseCategoryInRepo(t, repo, "Food")
+
+	output := command.NewRemoveExpenseSubcategoryService(repo).Execute(usecase.RemoveExpenseSubcategoryInput{
+		UserID:        "user-123",
+		CategoryID:    categoryID,
+		SubcategoryID: "missing",
+	})
+
+	assert.Equal(t, common.NotFound, output.GetExitCode())
+	assert.Equal(t, "Subcategory not found", output.GetMessage())
+}
+
+func Test_DeleteIncomeCategoryService(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeIncomeCategoryRepository()
+	created := command.NewCreateIncomeCategoryService(repo).Execute(usecase.CreateIncomeCategoryInput{
+		UserID: "user-123",
+		Name:   "Salary",
+	})
+	categoryID := created.GetID()
+
+	// Act
+	output := command.NewDeleteIncomeCategoryService(repo).Execute(usecase.DeleteIncomeCategoryInput{
+		UserID:     "user-123",
+		CategoryID: categoryID,
+	})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode())
+	category, _ := repo.FindByID(categoryID)
+	assert.Nil(t, category)
+}
@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/event"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/suggestion"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// trainingWalletRepo 記錄每個使用者的訓練次數；gate不為nil時，blockedUserID的訓練等到gate關閉才繼續
+type trainingWalletRepo struct {
+	*test.FakeWalletRepo
+	blockedUserID string
+	gate          chan struct{}
+
+	mu        sync.Mutex
+	trainings map[string]int
+}
+
+func (r *trainingWalletRepo) FindByUserID(userID string) ([]*model.Wallet, error) {
+	r.mu.Lock()
+	r.trainings[userID]++
+	r.mu.Unlock()
+	if r.gate != nil && userID == r.blockedUserID {
+		<-r.gate
+	}
+	return r.FakeWalletRepo.FindByUserID(userID)
+}
+
+func (r *trainingWalletRepo) trainingsOf(userID string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.trainings[userID]
+}
+
+func Test_Classifier_RanksSubcategoriesByDescriptionHistory(t *testing.T) {
+	// Arrange
+	classifier := suggestion.NewClassifier()
+	classifier.Learn("coffee", "Starbucks latte")
+	classifier.Learn("coffee", "STARBUCKS #1234")
+	classifier.Learn("coffee", "Louisa coffee")
+	classifier.Learn("transport", "Uber trip")
+	classifier.Learn("transport", "Uber trip airport")
+	classifier.Learn("groceries", "全聯福利中心")
+
+	// Act
+	predictions := classifier.Predict("starbucks reserve", 0)
+
+	// Assert
+	if assert.Len(t, predictions, 3) {
+		assert.Equal(t, "coffee", predictions[0].SubcategoryID)
+		assert.Greater(t, predictions[0].Confidence, 0.5)
+		assert.InDelta(t, 1.0, predictions[0].Confidence+predictions[1].Confidence+predictions[2].Confidence, 1e-9)
+	}
+	assert.Equal(t, "groceries", classifier.Predict("全聯", 1)[0].SubcategoryID)
+	assert.Nil(t, classifier.Predict("Netflix 2026", 0), "no learned token in the description")
+
+	classifier.Forget("groceries", "全聯福利中心")
+	assert.Nil(t, classifier.Predict("全聯", 0))
+}
+
+func Test_Tokenize_SplitsWordsAndHanCharacters(t *testing.T) {
+	assert.Equal(t, []string{"uber", "trip", "help", "com", "7eleven", "全", "家"},
+		suggestion.Tokenize("UBER *TRIP HELP.UBER.COM 2026-03-10 7ELEVEN 全家 x"))
+}
+
+func Test_GetCategorySuggestions_TrainsFromHistoryAndLearnsNewRecords(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 1000)
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Food", "Groceries")
+	_, coffee := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Coffee", "Coffee")
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	amount, _ := model.NewMoney(100, "TWD")
+	for _, expense := range []struct{ subcategoryID, description string }{
+		{coffee.ID, "Starbucks latte"},
+		{coffee.ID, "Starbucks americano"},
+		{groceries.ID, "Costco groceries"},
+		{groceries.ID, "Night market"},
+	} {
+		_, err := wallet.AddExpense(*amount, expense.subcategoryID, expense.description, date)
+		assert.NoError(t, err)
+	}
+	wallet.ClearDomainEvents()
+	walletRepo.Save(wallet)
+
+	dispatcher := event.NewDispatcher()
+	suggester := suggestion.NewSuggester(walletRepo, suggestion.DefaultSuggesterConfig())
+	suggester.Subscribe(dispatcher)
+	service := query.NewGetCategorySuggestionsService(suggester, expenseCategoryRepo, test.NewFakeIncomeCategoryRepository())
+	suggest := func(description string) []usecase.CategorySuggestionData {
+		output := service.Execute(usecase.GetCategorySuggestionsInput{UserID: "user-123", Description: description})
+		assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+		return output.(usecase.GetCategorySuggestionsOutput).Suggestions
+	}
+
+	// Act - trained from history on the first query
+	fromHistory := suggest("STARBUCKS 1234")
+	beforeLearning := suggest("Louisa")
+
+	// Act - a new record reaches the model through its event
+	_, err := wallet.AddExpense(*amount, coffee.ID, "Louisa coffee", date)
+	assert.NoError(t, err)
+	walletRepo.Save(wallet)
+	dispatcher.Publish(wallet.DomainEvents())
+	wallet.ClearDomainEvents()
+	afterLearning := suggest("Louisa")
+
+	// Assert
+	if assert.Len(t, fromHistory, 2) {
+		assert.Equal(t, coffee.ID, fromHistory[0].SubcategoryID)
+		assert.Equal(t, "Coffee", fromHistory[0].SubcategoryName)
+		assert.Equal(t, "Coffee", fromHistory[0].CategoryName)
+		assert.Greater(t, fromHistory[0].Confidence, fromHistory[1].Confidence)
+	}
+	assert.Empty(t, beforeLearning)
+	if assert.NotEmpty(t, afterLearning) {
+		assert.Equal(t, coffee.ID, afterLearning[0].SubcategoryID)
+	}
+	other := service.Execute(usecase.GetCategorySuggestionsInput{UserID: "user-456", Description: "Starbucks"})
+	assert.Empty(t, other.(usecase.GetCategorySuggestionsOutput).Suggestions)
+}
+
+func Test_GetCategorySuggestions_SkipsDeletedSubcategoriesAndValidatesInput(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 1000)
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Food", "Groceries")
+	amount, _ := model.NewMoney(100, "TWD")
+	wallet.AddExpense(*amount, "deleted-subcategory", "Uber trip", time.Now())
+	wallet.AddExpense(*amount, groceries.ID, "Uber eats", time.Now())
+	walletRepo.Save(wallet)
+	service := query.NewGetCategorySuggestionsService(suggestion.NewSuggester(walletRepo, suggestion.DefaultSuggesterConfig()), expenseCategoryRepo, test.NewFakeIncomeCategoryRepository())
+
+	// Act
+	output := service.Execute(usecase.GetCategorySuggestionsInput{UserID: "user-123", Description: "Uber trip"})
+
+	// Assert
+	suggestions := output.(usecase.GetCategorySuggestionsOutput).Suggestions
+	if assert.Len(t, suggestions, 1) {
+		assert.Equal(t, groceries.ID, suggestions[0].SubcategoryID)
+	}
+
+	for _, input := range []usecase.GetCategorySuggestionsInput{
+		{UserID: "user-123", Description: "  "},
+		{UserID: "user-123", Description: "Uber", Type: "TRANSFER"},
+		{UserID: "user-123", Description: "Uber", Limit: 11},
+	} {
+		failed := service.Execute(input)
+		assert.Equal(t, common.InvalidInput, failed.GetExitCode())
+		assert.Contains(t, failed.GetMessage(), "Invalid")
+	}
+}
+
+func Test_Suggester_TrainsOutsideTheLockAndOncePerUser(t *testing.T) {
+	// Arrange
+	fakeWalletRepo, _ := test.NewFakeWalletRepo()
+	walletRepo := &trainingWalletRepo{FakeWalletRepo: fakeWalletRepo, blockedUserID: "user-123", gate: make(chan struct{}), trainings: make(map[string]int)}
+	wallet := test.CreateWallet(t, fakeWalletRepo, "user-123", "TWD", 1000)
+	amount, _ := model.NewMoney(100, "TWD")
+	wallet.AddExpense(*amount, "coffee", "Starbucks latte", time.Now())
+	walletRepo.Save(wallet)
+	suggester := suggestion.NewSuggester(walletRepo, suggestion.DefaultSuggesterConfig())
+
+	// Act - two queries of user-123 wait for the same training
+	results := make(chan []suggestion.Prediction, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			predictions, _ := suggester.Suggest("user-123", model.CategorizeExpense, "Starbucks", 0)
+			results <- predictions
+		}()
+	}
+	for walletRepo.trainingsOf("user-123") == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Assert - other users and record events are not blocked meanwhile
+	other, err := suggester.Suggest("user-456", model.CategorizeExpense, "Starbucks", 0)
+	assert.NoError(t, err)
+	assert.Empty(t, other)
+	assert.NoError(t, suggester.Handle(model.ExpenseAdded{WalletEvent: model.WalletEvent{UserID: "user-456"}}))
+
+	close(walletRepo.gate)
+	for i := 0; i < 2; i++ {
+		predictions := <-results
+		if assert.Len(t, predictions, 1) {
+			assert.Equal(t, "coffee", predictions[0].SubcategoryID)
+		}
+	}
+	assert.Equal(t, 1, walletRepo.trainingsOf("user-123"))
+}
+
+func Test_Suggester_EvictsLeastRecentUsersAndDeletedWallets(t *testing.T) {
+	// Arrange
+	fakeWalletRepo, _ := test.NewFakeWalletRepo()
+	walletRepo := &trainingWalletRepo{FakeWalletRepo: fakeWalletRepo, trainings: make(map[string]int)}
+	wallet := test.CreateWallet(t, fakeWalletRepo, "user-123", "TWD", 1000)
+	amount, _ := model.NewMoney(100, "TWD")
+	wallet.AddExpense(*amount, "coffee", "Starbucks latte", time.Now())
+	walletRepo.Save(wallet)
+	suggester := suggestion.NewSuggester(walletRepo, suggestion.SuggesterConfig{MaxUsers: 2})
+	suggest := func(userID string) []suggestion.Prediction {
+		predictions, err := suggester.Suggest(userID, model.CategorizeExpense, "Starbucks", 0)
+		assert.NoError(t, err)
+		return predictions
+	}
+
+	// Act & Assert - user-456 is evicted when a third user queries
+	suggest("user-456")
+	suggest("user-123")
+	suggest("user-789")
+	suggest("user-123")
+	suggest("user-456")
+	assert.Equal(t, 1, walletRepo.trainingsOf("user-123"))
+	assert.Equal(t, 2, walletRepo.trainingsOf("user-456"))
+
+	// Deleting a wallet drops the user's model, the next query trains without it
+	assert.NoError(t, walletRepo.Delete(wallet.ID))
+	assert.NoError(t, suggester.Handle(model.NewWalletDeleted(wallet)))
+	assert.Empty(t, suggest("user-123"))
+	assert.Equal(t, 2, walletRepo.trainingsOf("user-123"))
+}
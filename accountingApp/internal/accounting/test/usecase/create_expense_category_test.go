@@ -89,7 +89,7 @@ func TestCreateExpenseCategory_InvalidName(t *testing.T) {
 
 	output := service.Execute(input)
 
-	assert.Equal(t, common.Failure, output.GetExitCode())
+	assert.Equal(t, common.InvalidInput, output.GetExitCode())
 	assert.Contains(t, output.GetMessage(), "Invalid category name")
 	repo.AssertNotCalled(t, "Save")
 }
@@ -12,7 +12,7 @@ import (
 
 func Test_CreateWallet_Success(t *testing.T) {
 	repo, _ := test.NewFakeWalletRepo()
-	service := command.NewCreateWalletService(repo)
+	service := command.NewCreateWalletService(repo, nil)
 	input := usecase.CreateWalletInput{
 		UserID:   "user-123",
 		Name:     "My Wallet",
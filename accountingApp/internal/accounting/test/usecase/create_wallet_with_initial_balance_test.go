@@ -13,7 +13,7 @@ import (
 func TestCreateWalletWithInitialBalance(t *testing.T) {
 	// Arrange
 	repo, _ := test.NewFakeWalletRepo()
-	service := command.NewCreateWalletService(repo)
+	service := command.NewCreateWalletService(repo, nil)
 
 	testCases := []struct {
 		name           string
@@ -89,7 +89,7 @@ func TestCreateWalletWithInitialBalance(t *testing.T) {
 func TestCreateWalletWithDifferentTypes(t *testing.T) {
 	// Test all wallet types
 	repo, _ := test.NewFakeWalletRepo()
-	service := command.NewCreateWalletService(repo)
+	service := command.NewCreateWalletService(repo, nil)
 
 	walletTypes := []string{"CASH", "BANK", "CREDIT", "INVESTMENT"}
 	initialBalance := int64(100000) // $1000.00
@@ -0,0 +1,346 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/duplicate"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// expenseDuplicates 新增支出輸出上的重複記錄 (沒有重複時輸出可能不是AddExpenseOutput)
+func expenseDuplicates(output common.Output) []usecase.DuplicateData {
+	if result, ok := output.(usecase.AddExpenseOutput); ok {
+		return result.Duplicates
+	}
+	return nil
+}
+
+// windowOnlyWalletRepo 載入完整錢包聚合時測試失敗，確認重複偵測只查詢日期範圍內的記錄
+type windowOnlyWalletRepo struct {
+	*test.FakeWalletRepo
+	t *testing.T
+}
+
+func (r windowOnlyWalletRepo) FindByIDWithTransactions(id string) (*model.Wallet, error) {
+	r.t.Errorf("unexpected full load of wallet %s", id)
+	return r.FakeWalletRepo.FindByIDWithTransactions(id)
+}
+
+func Test_AddExpense_FlagsNearIdenticalExpensesWithinWindow(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	flagRepo := test.NewFakeDuplicateFlagRepository()
+	flagger := duplicate.NewFlagger(walletRepo, flagRepo, duplicate.NewDetector(duplicate.DefaultDetectorConfig()))
+	addExpense := duplicate.NewAddExpenseCommand(command.NewAddExpenseService(walletRepo, nil, nil, nil), flagger)
+	wallet := createTestWalletInRepo(walletRepo, "user-123", "TWD", 10000)
+	add := func(amount int64, description string, date time.Time) common.Output {
+		output := addExpense.Execute(usecase.AddExpenseInput{
+			UserID:        "user-123",
+			WalletID:      wallet.ID,
+			SubcategoryID: "food",
+			Amount:        amount,
+			Currency:      "TWD",
+			Description:   description,
+			Date:          date,
+		})
+		assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+		return output
+	}
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	original := add(500, "STARBUCKS #123 TAIPEI", date)
+
+	// Act
+	different := add(800, "Starbucks", date)
+	outsideWindow := add(500, "Starbucks", date.AddDate(0, 0, 4))
+	flagged := add(502, "Starbucks", date.AddDate(0, 0, 2))
+
+	// Assert
+	assert.Empty(t, expenseDuplicates(different), "amount differs by more than 1%")
+	assert.Empty(t, expenseDuplicates(outsideWindow), "more than 3 days apart")
+
+	duplicates := expenseDuplicates(flagged)
+	if assert.Len(t, duplicates, 1) {
+		assert.Equal(t, "EXPENSE", duplicates[0].Type)
+		assert.Equal(t, "PENDING", duplicates[0].Status)
+		assert.Equal(t, flagged.GetID(), duplicates[0].Record.ID)
+		assert.Contains(t, []string{original.GetID(), outsideWindow.GetID()}, duplicates[0].DuplicateOf.ID)
+		assert.GreaterOrEqual(t, duplicates[0].Score, 0.9)
+	}
+	queue := query.NewGetDuplicatesService(flagRepo, walletRepo).Execute(usecase.GetDuplicatesInput{UserID: "user-123"})
+	assert.Len(t, queue.(usecase.GetDuplicatesOutput).Duplicates, 1)
+}
+
+func Test_Flagger_ComparesOnlyRecordsWithinTheWindow(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	wallet := createTestWalletInRepo(walletRepo, "user-123", "TWD", 10000)
+	addExpense := command.NewAddExpenseService(walletRepo, nil, nil, nil)
+	add := func(description string, date time.Time) string {
+		output := addExpense.Execute(usecase.AddExpenseInput{
+			UserID:        "user-123",
+			WalletID:      wallet.ID,
+			SubcategoryID: "food",
+			Amount:        500,
+			Currency:      "TWD",
+			Description:   description,
+			Date:          date,
+		})
+		assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+		return output.GetID()
+	}
+	date := time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC)
+	add("Starbucks", date.AddDate(0, -1, 0))
+	nearby := add("Starbucks", date.AddDate(0, 0, -3))
+	added := add("Starbucks", date)
+	flagger := duplicate.NewFlagger(windowOnlyWalletRepo{FakeWalletRepo: walletRepo, t: t}, test.NewFakeDuplicateFlagRepository(), duplicate.NewDetector(duplicate.DefaultDetectorConfig()))
+
+	// Act
+	duplicates, err := flagger.Flag(wallet.ID, date, date, []string{added}, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, duplicates, 1) {
+		assert.Equal(t, added, duplicates[0].Record.ID)
+		assert.Equal(t, nearby, duplicates[0].DuplicateOf.ID)
+	}
+	from, to := duplicate.NewDetector(duplicate.DefaultDetectorConfig()).Range(date, date)
+	assert.True(t, from.Before(date.AddDate(0, 0, -3)) && to.After(date.AddDate(0, 0, 3)), "the range covers the detection window")
+}
+
+func Test_AddIncome_FlagsOnlySimilarDescriptions(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	flagger := duplicate.NewFlagger(walletRepo, test.NewFakeDuplicateFlagRepository(), duplicate.NewDetector(duplicate.DefaultDetectorConfig()))
+	addIncome := duplicate.NewAddIncomeCommand(command.NewAddIncomeService(walletRepo, nil), flagger)
+	wallet := createTestWalletInRepo(walletRepo, "user-123", "TWD", 10000)
+	add := func(description string) common.Output {
+		output := addIncome.Execute(usecase.AddIncomeInput{
+			UserID:        "user-123",
+			WalletID:      wallet.ID,
+			SubcategoryID: "salary",
+			Amount:        30000,
+			Currency:      "TWD",
+			Description:   description,
+			Date:          time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC),
+		})
+		assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+		return output
+	}
+
+	// Act
+	salary := add("Salary March")
+	freelance := add("Freelance project")
+	repeated := add("salary - march")
+
+	// Assert
+	_, flagged := freelance.(usecase.AddIncomeOutput)
+	assert.False(t, flagged, "descriptions are not similar")
+	duplicates := repeated.(usecase.AddIncomeOutput).Duplicates
+	if assert.Len(t, duplicates, 1) {
+		assert.Equal(t, "INCOME", duplicates[0].Type)
+		assert.Equal(t, salary.GetID(), duplicates[0].DuplicateOf.ID)
+		assert.Equal(t, 1.0, duplicates[0].Score)
+	}
+}
+
+func Test_Detector_SkipsBankDistinctTransactionsAndEarlierBatchPairs(t *testing.T) {
+	detector := duplicate.NewDetector(duplicate.DefaultDetectorConfig())
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	record := func(id, importID string) mapper.ExpenseRecordData {
+		return mapper.ExpenseRecordData{ID: id, Amount: 120, Currency: "TWD", Description: "Metro ticket", Date: date, ImportID: importID}
+	}
+
+	// Two card transactions the bank reports separately are both real
+	matches := detector.ExpenseDuplicates([]mapper.ExpenseRecordData{record("a", "FITID-1"), record("b", "FITID-2")}, []string{"b"})
+	assert.Empty(t, matches)
+
+	// An imported transaction matches one entered by hand
+	matches = detector.ExpenseDuplicates([]mapper.ExpenseRecordData{record("a", ""), record("b", "FITID-2")}, []string{"b"})
+	assert.Equal(t, []duplicate.Match{{RecordID: "b", DuplicateOfID: "a", Score: 1}}, matches)
+
+	// Within one batch only the later record is flagged
+	matches = detector.ExpenseDuplicates([]mapper.ExpenseRecordData{record("a", ""), record("b", "")}, []string{"a", "b"})
+	assert.Equal(t, []duplicate.Match{{RecordID: "b", DuplicateOfID: "a", Score: 1}}, matches)
+}
+
+func Test_CommitImport_FlagsRowsMatchingManualEntries(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	profileRepo := test.NewFakeImportProfileRepository()
+	flagRepo := test.NewFakeDuplicateFlagRepository()
+	flagger := duplicate.NewFlagger(walletRepo, flagRepo, duplicate.NewDetector(duplicate.DefaultDetectorConfig()))
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 1000)
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Food", "Groceries")
+	_, salary := test.CreateIncomeSubcategory(t, incomeCategoryRepo, "user-123", "Salary", "Monthly")
+	manual := command.NewAddExpenseService(walletRepo, nil, nil, nil).Execute(usecase.AddExpenseInput{
+		UserID:        "user-123",
+		WalletID:      wallet.ID,
+		SubcategoryID: groceries.ID,
+		Amount:        500,
+		Currency:      "TWD",
+		Description:   "Groceries",
+		Date:          time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	mapping := signedAmountMapping()
+	mapping.ExpenseSubcategoryID, mapping.IncomeSubcategoryID = groceries.ID, salary.ID
+	profileID := command.NewCreateImportProfileService(profileRepo, expenseCategoryRepo, incomeCategoryRepo).Execute(usecase.CreateImportProfileInput{
+		UserID: "user-123", Name: "My Bank", Mapping: mapping,
+	}).GetID()
+	content := "Date,Memo,Amount\n" +
+		"2024-03-01,Salary,2000\n" +
+		"2024-03-02,PX MART GROCERIES,-500\n"
+
+	// Act
+	output := duplicate.NewCommitImportCommand(
+		command.NewCommitImportService(walletRepo, profileRepo, expenseCategoryRepo, incomeCategoryRepo, test.NewFakeCategorizationRuleRepository()), flagger,
+	).Execute(usecase.CommitImportInput{StatementFile: csvStatement(profileID, content), UserID: "user-123", WalletID: wallet.ID})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	result := output.(usecase.CommitImportOutput)
+	assert.Equal(t, 2, result.Imported)
+	if assert.Len(t, result.Duplicates, 1) {
+		assert.Equal(t, result.Rows[1].RecordID, result.Duplicates[0].Record.ID)
+		assert.Equal(t, manual.GetID(), result.Duplicates[0].DuplicateOf.ID)
+	}
+}
+
+func Test_ResolveDuplicate_MergeMovesAttachmentsAndImportedDetails(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	flagRepo := test.NewFakeDuplicateFlagRepository()
+	flagger := duplicate.NewFlagger(walletRepo, flagRepo, duplicate.NewDetector(duplicate.DefaultDetectorConfig()))
+	addExpense := duplicate.NewAddExpenseCommand(command.NewAddExpenseService(walletRepo, nil, nil, nil), flagger)
+	wallet := createTestWalletInRepo(walletRepo, "user-123", "TWD", 10000)
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	input := usecase.AddExpenseInput{UserID: "user-123", WalletID: wallet.ID, SubcategoryID: "food", Amount: 500, Currency: "TWD", Description: "Starbucks", Date: date}
+	original := addExpense.Execute(input)
+	input.Description, input.Date = "STARBUCKS #123 TAIPEI", date.AddDate(0, 0, 1)
+	added := addExpense.Execute(input)
+	duplicates := expenseDuplicates(added)
+	if !assert.Len(t, duplicates, 1) {
+		t.FailNow()
+	}
+	attachmentRepo := test.NewFakeAttachmentRepository()
+	receipt, _ := model.NewAttachment("user-123", wallet.ID, model.AttachmentExpense, added.GetID(), "receipt.png", "image/png", 10, "checksum")
+	assert.NoError(t, attachmentRepo.Save(receipt))
+	resolve := command.NewResolveDuplicateService(flagRepo, walletRepo, attachmentRepo)
+	getDuplicates := query.NewGetDuplicatesService(flagRepo, walletRepo)
+
+	// Act
+	output := resolve.Execute(usecase.ResolveDuplicateInput{UserID: "user-123", FlagID: duplicates[0].ID, Resolution: "MERGE"})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	saved, _ := walletRepo.FindByIDWithTransactions(wallet.ID)
+	if assert.Len(t, saved.GetExpenseRecords(), 1) {
+		assert.Equal(t, original.GetID(), saved.GetExpenseRecords()[0].ID)
+		assert.Equal(t, "Starbucks", saved.GetExpenseRecords()[0].Description)
+	}
+	assert.Equal(t, int64(9500), saved.Balance.Amount)
+	moved, _ := attachmentRepo.FindByID(receipt.ID)
+	assert.Equal(t, original.GetID(), moved.RecordID)
+
+	pending := getDuplicates.Execute(usecase.GetDuplicatesInput{UserID: "user-123"})
+	assert.Empty(t, pending.(usecase.GetDuplicatesOutput).Duplicates)
+	merged := getDuplicates.Execute(usecase.GetDuplicatesInput{UserID: "user-123", Status: "merged"}).(usecase.GetDuplicatesOutput).Duplicates
+	if assert.Len(t, merged, 1) {
+		assert.Nil(t, merged[0].Record, "the merged record no longer exists")
+		assert.Equal(t, original.GetID(), merged[0].DuplicateOf.ID)
+		assert.NotEmpty(t, merged[0].ResolvedAt)
+	}
+
+	again := resolve.Execute(usecase.ResolveDuplicateInput{UserID: "user-123", FlagID: duplicates[0].ID, Resolution: "KEEP_BOTH"})
+	assert.Equal(t, common.Failure, again.GetExitCode())
+	assert.Contains(t, again.GetMessage(), "already resolved")
+}
+
+func Test_ResolveDuplicate_DeleteKeepBothAndValidation(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	flagRepo := test.NewFakeDuplicateFlagRepository()
+	flagger := duplicate.NewFlagger(walletRepo, flagRepo, duplicate.NewDetector(duplicate.DefaultDetectorConfig()))
+	addExpense := duplicate.NewAddExpenseCommand(command.NewAddExpenseService(walletRepo, nil, nil, nil), flagger)
+	wallet := createTestWalletInRepo(walletRepo, "user-123", "TWD", 10000)
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	input := usecase.AddExpenseInput{UserID: "user-123", WalletID: wallet.ID, SubcategoryID: "food", Amount: 500, Currency: "TWD", Description: "Starbucks", Date: date}
+	addExpense.Execute(input)
+	input.Description, input.Date = "STARBUCKS #123 TAIPEI", date.AddDate(0, 0, 1)
+	added := addExpense.Execute(input)
+	duplicates := expenseDuplicates(added)
+	if !assert.Len(t, duplicates, 1) {
+		t.FailNow()
+	}
+	resolve := command.NewResolveDuplicateService(flagRepo, walletRepo, test.NewFakeAttachmentRepository())
+	flagID := duplicates[0].ID
+
+	// Act & Assert
+	output := resolve.Execute(usecase.ResolveDuplicateInput{UserID: "user-456", FlagID: flagID, Resolution: "DELETE"})
+	assert.Equal(t, "Duplicate flag not found", output.GetMessage(), "another user's flag is not visible")
+
+	output = resolve.Execute(usecase.ResolveDuplicateInput{UserID: "user-123", FlagID: flagID, Resolution: "IGNORE"})
+	assert.Contains(t, output.GetMessage(), "Invalid resolution")
+
+	output = resolve.Execute(usecase.ResolveDuplicateInput{UserID: "user-123", FlagID: flagID, Resolution: "DELETE"})
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	saved, _ := walletRepo.FindByIDWithTransactions(wallet.ID)
+	assert.Len(t, saved.GetExpenseRecords(), 1)
+	assert.NotEqual(t, added.GetID(), saved.GetExpenseRecords()[0].ID)
+	assert.Equal(t, int64(9500), saved.Balance.Amount)
+
+	// Keeping both leaves the wallet unchanged
+	input.Description, input.Date = "Starbucks", date
+	addExpense.Execute(input)
+	input.Description, input.Date = "STARBUCKS #123 TAIPEI", date.AddDate(0, 0, 1)
+	kept := expenseDuplicates(addExpense.Execute(input))
+	if !assert.Len(t, kept, 1) {
+		t.FailNow()
+	}
+	output = resolve.Execute(usecase.ResolveDuplicateInput{UserID: "user-123", FlagID: kept[0].ID, Resolution: "KEEP_BOTH"})
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	saved, _ = walletRepo.FindByIDWithTransactions(wallet.ID)
+	assert.Len(t, saved.GetExpenseRecords(), 3)
+	keptFlags := query.NewGetDuplicatesService(flagRepo, walletRepo).Execute(usecase.GetDuplicatesInput{UserID: "user-123", Status: "KEPT"})
+	assert.Len(t, keptFlags.(usecase.GetDuplicatesOutput).Duplicates, 1)
+}
+
+func Test_GetDuplicates_DropsPendingFlagsWhoseRecordWasDeleted(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	flagRepo := test.NewFakeDuplicateFlagRepository()
+	flagger := duplicate.NewFlagger(walletRepo, flagRepo, duplicate.NewDetector(duplicate.DefaultDetectorConfig()))
+	addExpense := duplicate.NewAddExpenseCommand(command.NewAddExpenseService(walletRepo, nil, nil, nil), flagger)
+	wallet := createTestWalletInRepo(walletRepo, "user-123", "TWD", 10000)
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	input := usecase.AddExpenseInput{UserID: "user-123", WalletID: wallet.ID, SubcategoryID: "food", Amount: 500, Currency: "TWD", Description: "Starbucks", Date: date}
+	addExpense.Execute(input)
+	input.Description, input.Date = "STARBUCKS #123 TAIPEI", date.AddDate(0, 0, 1)
+	added := addExpense.Execute(input)
+	duplicates := expenseDuplicates(added)
+	if !assert.Len(t, duplicates, 1) {
+		t.FailNow()
+	}
+	getDuplicates := query.NewGetDuplicatesService(flagRepo, walletRepo)
+
+	// Act
+	deleted := command.NewDeleteExpenseService(walletRepo, nil, nil).Execute(usecase.DeleteExpenseInput{UserID: "user-123", ExpenseID: added.GetID()})
+
+	// Assert
+	assert.Equal(t, common.Success, deleted.GetExitCode(), deleted.GetMessage())
+	pending := getDuplicates.Execute(usecase.GetDuplicatesInput{UserID: "user-123"})
+	assert.Empty(t, pending.(usecase.GetDuplicatesOutput).Duplicates)
+
+	output := getDuplicates.Execute(usecase.GetDuplicatesInput{UserID: "user-123", Status: "DONE"})
+	assert.Equal(t, common.InvalidInput, output.GetExitCode())
+	assert.Contains(t, output.GetMessage(), "Invalid status")
+}
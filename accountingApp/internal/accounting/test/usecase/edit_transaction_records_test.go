@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UpdateExpenseService_Success(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	wallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	amount, _ := model.NewMoney(2000, "USD")
+	expense, _ := wallet.AddExpense(*amount, "food-123", "Lunhc", time.Now())
+	service := command.NewUpdateExpenseService(walletRepo)
+
+	// Act
+	output := service.Execute(usecase.UpdateExpenseInput{
+		UserID:        "user-123",
+		ExpenseID:     expense.ID,
+		SubcategoryID: "food-123",
+		Amount:        1200,
+		Currency:      "USD",
+		Description:   "Lunch",
+		Date:          expense.Date,
+	})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	saved, _ := walletRepo.FindByID(wallet.ID)
+	assert.Equal(t, int64(8800), saved.Balance.Amount)
+	assert.Equal(t, "Lunch", saved.GetExpenseRecords()[0].Description)
+}
+
+func Test_UpdateExpenseService_NotFound(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	service := command.NewUpdateExpenseService(walletRepo)
+
+	// Act
+	output := service.Execute(usecase.UpdateExpenseInput{
+		ExpenseID:     "missing",
+		SubcategoryID: "food-123",
+		Amount:        1200,
+		Currency:      "USD",
+	})
+
+	// Assert
+	assert.Equal(t, common.NotFound, output.GetExitCode())
+	assert.Equal(t, "Expense record not found", output.GetMessage())
+}
+
+func Test_DeleteExpenseService_RestoresBalance(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	wallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	amount, _ := model.NewMoney(2000, "USD")
+	expense, _ := wallet.AddExpense(*amount, "food-123", "Lunch", time.Now())
+	service := command.NewDeleteExpenseService(walletRepo, nil, nil)
+
+	// Act
+	output := service.Execute(usecase.DeleteExpenseInput{UserID: "user-123", ExpenseID: expense.ID})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	saved, _ := walletRepo.FindByID(wallet.ID)
+	assert.Equal(t, int64(10000), saved.Balance.Amount)
+	assert.Empty(t, saved.GetExpenseRecords())
+}
+
+func Test_DeleteIncomeService_RejectsNegativeBalance(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	wallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 0)
+	salary, _ := model.NewMoney(5000, "USD")
+	income, _ := wallet.AddIncome(*salary, "salary-123", "Salary", time.Now())
+	rent, _ := model.NewMoney(4000, "USD")
+	wallet.AddExpense(*rent, "rent-123", "Rent", time.Now())
+	service := command.NewDeleteIncomeService(walletRepo, nil, nil)
+
+	// Act
+	output := service.Execute(usecase.DeleteIncomeInput{UserID: "user-123", IncomeID: income.ID})
+
+	// Assert
+	assert.Equal(t, common.InvalidInput, output.GetExitCode())
+	assert.Contains(t, output.GetMessage(), "insufficient balance")
+	saved, _ := walletRepo.FindByID(wallet.ID)
+	assert.Len(t, saved.GetIncomeRecords(), 1)
+}
@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// seedExchangeRates 以CSV資料列 (date,base,quote,rate) 建立匯率
+func seedExchangeRates(t *testing.T, rows ...string) *test.FakeExchangeRateRepository {
+	repo := test.NewFakeExchangeRateRepository()
+	rates, err := exchange.ParseCSV(strings.NewReader("date,base,quote,rate\n"+strings.Join(rows, "\n")), "test")
+	assert.NoError(t, err)
+	assert.NoError(t, repo.SaveAll(rates))
+	return repo
+}
+
+func Test_ParseExchangeRateCSV_ReportsTheFirstInvalidLine(t *testing.T) {
+	// Arrange
+	valid := "\ufeffQuote,Date,Base,Rate,Note\nTWD,2026-03-10,USD,32.45,close\nJPY, 2026-03-10 ,usd,149.8,\n"
+
+	// Act
+	rates, err := exchange.ParseCSV(strings.NewReader(valid), "csv")
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, rates, 2) {
+		assert.Equal(t, "USD-JPY-2026-03-10", rates[1].ID)
+		assert.Equal(t, "149.8", rates[1].RateString())
+		assert.Equal(t, "csv", rates[1].Source)
+	}
+
+	for content, expected := range map[string]string{
+		"":                       "empty",
+		"date,base,rate\n":       `missing column "quote"`,
+		"date,base,quote,rate\n": "no exchange rates",
+		"date,base,quote,rate\n2026-03-10,USD,TWD,32\n10/03/2026,USD,JPY,150\n": "line 3: invalid date",
+		"date,base,quote,rate\n2026-03-10,USD,TWD,abc\n":                        "line 2: invalid rate",
+		"date,base,quote,rate\n2026-03-10,USD,TWD,32\n2026-03-10,USD,TWD,33\n":  "line 3: duplicate USD/TWD rate for 2026-03-10 (also on line 2)",
+	} {
+		_, err := exchange.ParseCSV(strings.NewReader(content), "csv")
+		if assert.Error(t, err, content) {
+			assert.Contains(t, err.Error(), expected)
+		}
+	}
+}
+
+func Test_ImportExchangeRates_StoresNothingWhenALineIsInvalid(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeExchangeRateRepository()
+	service := command.NewImportExchangeRatesService(repo)
+
+	// Act
+	failed := service.Execute(usecase.ImportExchangeRatesInput{
+		Content: strings.NewReader("date,base,quote,rate\n2026-03-10,USD,TWD,32\n2026-03-10,USD,USD,1\n"),
+	})
+	imported := service.Execute(usecase.ImportExchangeRatesInput{
+		Content: strings.NewReader("date,base,quote,rate\n2026-03-10,USD,TWD,32\n2026-03-11,USD,TWD,32.1\n"),
+	})
+	replaced := service.Execute(usecase.ImportExchangeRatesInput{
+		Content: strings.NewReader("date,base,quote,rate\n2026-03-11,USD,TWD,32.2\n"),
+	})
+
+	// Assert
+	assert.Equal(t, common.InvalidInput, failed.GetExitCode())
+	assert.Contains(t, failed.GetMessage(), "Invalid exchange rate file: line 3")
+	assert.Equal(t, common.Success, imported.GetExitCode(), imported.GetMessage())
+	assert.Equal(t, 2, imported.(usecase.ImportExchangeRatesOutput).Imported)
+	assert.Equal(t, common.Success, replaced.GetExitCode(), replaced.GetMessage())
+
+	history, _ := repo.FindByPair("USD", "TWD")
+	if assert.Len(t, history, 2, "the rate of the same day is replaced") {
+		assert.Equal(t, "32.2", history[0].RateString())
+		assert.Equal(t, "32", history[1].RateString())
+	}
+}
+
+func Test_RefreshExchangeRates_StoresTheProviderRatesOfTheDay(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeExchangeRateRepository()
+	date := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	output := command.NewRefreshExchangeRatesService(repo, exchange.NewStubProvider()).Execute(usecase.RefreshExchangeRatesInput{Date: date})
+	unconfigured := command.NewRefreshExchangeRatesService(repo, nil).Execute(usecase.RefreshExchangeRatesInput{})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	assert.Equal(t, "stub", output.GetID())
+	rate, _ := repo.FindLatest("USD", "TWD", date)
+	if assert.NotNil(t, rate) {
+		assert.Equal(t, "stub", rate.Source)
+	}
+	assert.Equal(t, common.Failure, unconfigured.GetExitCode())
+	assert.Equal(t, "No exchange rate provider configured", unconfigured.GetMessage())
+}
+
+func Test_Converter_UsesTheLatestRateOnOrBeforeTheDate(t *testing.T) {
+	// Arrange
+	repo := seedExchangeRates(t,
+		"2026-03-01,USD,TWD,30",
+		"2026-03-10,USD,TWD,32",
+		"2026-03-05,TWD,USD,0.04", // newer than the direct rate of 03-01
+	)
+	converter := exchange.NewConverter(repo, model.RoundHalfEven)
+	usd := model.Money{Amount: 1000, Currency: "USD"}
+
+	// Act
+	march2, _ := converter.Convert(usd, "TWD", time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))
+	march6, _ := converter.Convert(usd, "TWD", time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC))
+	march10, _ := converter.Convert(usd, "TWD", time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC))
+	same, _ := converter.Convert(usd, "USD", time.Now())
+	_, missing := converter.Convert(usd, "TWD", time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.Equal(t, int64(300), march2.Converted.Amount)
+	assert.Equal(t, int64(250), march6.Converted.Amount, "the inverse of 0.04 is 25")
+	assert.Equal(t, "USD", march6.Rate.Base)
+	assert.Equal(t, int64(320), march10.Converted.Amount)
+	assert.Equal(t, usd, same.Converted)
+	assert.Nil(t, same.Rate)
+	var notFound *exchange.RateNotFoundError
+	assert.True(t, errors.As(missing, &notFound))
+}
+
+func Test_GetWallets_ReportsBalancesInTheReportingCurrency(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	twd := createTestWalletInRepo(walletRepo, "user-123", "TWD", 1000)
+	usd := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	converter := exchange.NewConverter(seedExchangeRates(t, "2026-03-10,USD,TWD,32"), model.RoundHalfUp)
+	service := query.NewGetWalletsService(walletRepo, converter)
+
+	// Act
+	output := service.Execute(usecase.GetWalletsInput{UserID: "user-123", ReportingCurrency: "usd"})
+	plain := service.Execute(usecase.GetWalletsInput{UserID: "user-123"})
+	noRate := service.Execute(usecase.GetWalletsInput{UserID: "user-123", ReportingCurrency: "EUR"})
+	invalid := service.Execute(usecase.GetWalletsInput{UserID: "user-123", ReportingCurrency: "dollars"})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	wallets := output.(usecase.GetWalletsOutput)
+	assert.Equal(t, "USD", wallets.ReportingCurrency)
+	assert.Equal(t, usecase.ConvertedMoneyData{Amount: 3125, Currency: "USD", Rate: "0.03125", RateDate: "2026-03-10"}, wallets.ConvertedBalances[twd.ID])
+	assert.Equal(t, usecase.ConvertedMoneyData{Amount: 10000, Currency: "USD"}, wallets.ConvertedBalances[usd.ID])
+	assert.Equal(t, &usecase.MoneyData{Amount: 13125, Currency: "USD"}, wallets.TotalBalance)
+
+	assert.Nil(t, plain.(usecase.GetWalletsOutput).TotalBalance)
+	assert.Equal(t, common.InvalidInput, noRate.GetExitCode())
+	assert.Contains(t, noRate.GetMessage(), "Invalid reporting currency: no exchange rate from")
+	assert.Contains(t, noRate.GetMessage(), "to EUR")
+	assert.Contains(t, invalid.GetMessage(), "Invalid reporting currency")
+}
+
+func Test_GetTagSummaryService_ConvertsTotalsWithTheRatesOfTheEndDate(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	cash := test.CreateWallet(t, walletRepo, "user-123", "USD", 100000)
+	bank := test.CreateWallet(t, walletRepo, "user-123", "USD", 100000)
+	yen := test.CreateWallet(t, walletRepo, "user-123", "JPY", 500000)
+	march := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	addExpense := command.NewAddExpenseService(walletRepo, nil, nil, nil)
+	addExpense.Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: cash.ID, SubcategoryID: "subcategory-food",
+		Amount: 1200, Currency: "USD", Date: march, Tags: []string{"trip", "food"},
+	})
+	addExpense.Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: yen.ID, SubcategoryID: "subcategory-food",
+		Amount: 8000, Currency: "JPY", Date: march, Tags: []string{"trip"},
+	})
+	command.NewAddIncomeService(walletRepo, nil).Execute(usecase.AddIncomeInput{
+		UserID: "user-123", WalletID: bank.ID, SubcategoryID: "subcategory-salary",
+		Amount: 3000, Currency: "USD", Date: march, Tags: []string{"trip"},
+	})
+	transfer := createTransferInput(cash.ID, bank.ID, 5000, 0)
+	transfer.UserID = "user-123"
+	transfer.Date = march
+	transfer.Tags = []string{"trip"}
+	command.NewProcessTransferService(test.NewFakeUnitOfWork(walletRepo), nil).Execute(transfer)
+	converter := exchange.NewConverter(seedExchangeRates(t,
+		"2026-03-01,USD,TWD,30",
+		"2026-04-01,USD,TWD,33", // after the report
+		"2026-03-01,TWD,JPY,4.5",
+	), model.RoundHalfUp)
+	service := query.NewGetTagSummaryService(walletRepo, converter)
+	endDate := time.Date(2026, 3, 31, 23, 59, 59, 0, time.UTC)
+
+	// Act
+	output := service.Execute(usecase.GetTagSummaryInput{UserID: "user-123", EndDate: &endDate, ReportingCurrency: "TWD"})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	summary := output.(usecase.GetTagSummaryOutput)
+	assert.Equal(t, "TWD", summary.ReportingCurrency)
+	assert.Equal(t, &usecase.ConvertedTagTotalsData{Currency: "TWD", Expenses: 1778, Rate: "0.2222222222", RateDate: "2026-03-01"},
+		summary.Tags[1].Converted, "8000 yen at 1/4.5")
+	assert.Equal(t, &usecase.ConvertedTagTotalsData{Currency: "TWD", Expenses: 360, Incomes: 900, Transfers: 1500, Rate: "30", RateDate: "2026-03-01"},
+		summary.Tags[2].Converted)
+	assert.Equal(t, []usecase.TagSummaryData{
+		{Tag: "food", Currency: "TWD", Expenses: 360, Count: 1},
+		{Tag: "trip", Currency: "TWD", Expenses: 2138, Incomes: 900, Transfers: 1500, Count: 4},
+	}, summary.ReportingTotals)
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetTransfersService_ListsTransferOnceAcrossWallets(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	fromWallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	toWallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 0)
+
+	transferService := command.NewProcessTransferService(test.NewFakeUnitOfWork(walletRepo), nil)
+	transferService.Execute(createTransferInput(fromWallet.ID, toWallet.ID, 3000, 0))
+
+	service := query.NewGetTransfersService(walletRepo)
+
+	// Act
+	output := service.Execute(usecase.GetTransfersInput{UserID: "user-123"})
+
+	// Assert - 轉帳同時存在於兩個錢包，但只列出一次
+	assert.Equal(t, common.Success, output.GetExitCode())
+	transfersOutput := output.(usecase.GetTransfersOutput)
+	assert.Equal(t, 1, transfersOutput.Count)
+	assert.Empty(t, transfersOutput.Data[0].Direction)
+}
+
+func Test_GetTransfersService_WalletFilterShowsIncomingOnDestination(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	fromWallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	toWallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 0)
+
+	transferService := command.NewProcessTransferService(test.NewFakeUnitOfWork(walletRepo), nil)
+	transferService.Execute(createTransferInput(fromWallet.ID, toWallet.ID, 3000, 100))
+
+	service := query.NewGetTransfersService(walletRepo)
+
+	// Act
+	output := service.Execute(usecase.GetTransfersInput{UserID: "user-123", WalletID: &toWallet.ID})
+
+	// Assert
+	transfersOutput := output.(usecase.GetTransfersOutput)
+	assert.Equal(t, 1, transfersOutput.Count)
+	assert.Equal(t, "incoming", transfersOutput.Data[0].Direction)
+	assert.Equal(t, fromWallet.ID, transfersOutput.Data[0].FromWalletID)
+	assert.Equal(t, int64(3000), transfersOutput.Data[0].Amount.Amount)
+	assert.Equal(t, int64(100), transfersOutput.Data[0].Fee.Amount)
+}
+
+func Test_GetTransfersService_AppliesFilters(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	fromWallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	toWallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 0)
+
+	transferService := command.NewProcessTransferService(test.NewFakeUnitOfWork(walletRepo), nil)
+	small := createTransferInput(fromWallet.ID, toWallet.ID, 500, 0)
+	small.Description = "Lunch money"
+	small.Date = time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	large := createTransferInput(fromWallet.ID, toWallet.ID, 5000, 0)
+	large.Date = time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+	transferService.Execute(small)
+	transferService.Execute(large)
+
+	service := query.NewGetTransfersService(walletRepo)
+	minAmount := int64(1000)
+	startDate := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	description := "Lunch"
+
+	// Act
+	byAmount := service.Execute(usecase.GetTransfersInput{UserID: "user-123", MinAmount: &minAmount}).(usecase.GetTransfersOutput)
+	byDate := service.Execute(usecase.GetTransfersInput{UserID: "user-123", StartDate: &startDate}).(usecase.GetTransfersOutput)
+	byDescription := service.Execute(usecase.GetTransfersInput{UserID: "user-123", Description: &description}).(usecase.GetTransfersOutput)
+
+	// Assert
+	assert.Equal(t, 1, byAmount.Count)
+	assert.Equal(t, int64(5000), byAmount.Data[0].Amount.Amount)
+	assert.Equal(t, 1, byDate.Count)
+	assert.Equal(t, int64(5000), byDate.Data[0].Amount.Amount)
+	assert.Equal(t, 1, byDescription.Count)
+	assert.Equal(t, int64(500), byDescription.Data[0].Amount.Amount)
+}
@@ -0,0 +1,403 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// countingWalletRepo 記錄錢包的儲存次數
+type countingWalletRepo struct {
+	*test.FakeWalletRepo
+	saves int
+}
+
+func (r *countingWalletRepo) Save(wallet *model.Wallet) error {
+	r.saves++
+	return r.FakeWalletRepo.Save(wallet)
+}
+
+func csvStatement(profileID, content string) usecase.StatementFile {
+	return usecase.StatementFile{FileName: "statement.csv", ProfileID: profileID, Content: strings.NewReader(content)}
+}
+
+func signedAmountMapping() usecase.ImportMappingData {
+	return usecase.ImportMappingData{
+		HasHeader:         true,
+		DateColumn:        "Date",
+		DateFormat:        "YYYY-MM-DD",
+		DescriptionColumn: "Memo",
+		AmountColumn:      "Amount",
+	}
+}
+
+func Test_CommitImportService_RecordsAcceptedRowsInOneSaveAndReportsRejected(t *testing.T) {
+	// Arrange
+	fakeWalletRepo, _ := test.NewFakeWalletRepo()
+	walletRepo := &countingWalletRepo{FakeWalletRepo: fakeWalletRepo}
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	profileRepo := test.NewFakeImportProfileRepository()
+	wallet := test.CreateWallet(t, fakeWalletRepo, "user-123", "TWD", 1000)
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Food", "Groceries")
+	_, salary := test.CreateIncomeSubcategory(t, incomeCategoryRepo, "user-123", "Salary", "Monthly")
+	mapping := signedAmountMapping()
+	mapping.ExpenseSubcategoryID, mapping.IncomeSubcategoryID = groceries.ID, salary.ID
+	profileID := command.NewCreateImportProfileService(profileRepo, expenseCategoryRepo, incomeCategoryRepo).Execute(usecase.CreateImportProfileInput{
+		UserID: "user-123", Name: "My Bank", Mapping: mapping,
+	}).GetID()
+	commit := command.NewCommitImportService(walletRepo, profileRepo, expenseCategoryRepo, incomeCategoryRepo, test.NewFakeCategorizationRuleRepository())
+	content := "\ufeffDate,Memo,Amount\n" +
+		"2024-03-01,Salary,\"2,000\"\n" +
+		"2024-03-02,Groceries,-500.00\n" +
+		"03/04/2024,Wrong date,-10\n" +
+		"\n" +
+		"2024-03-05,Laptop,\"-30,000\"\n" +
+		"2024-03-06,Coffee,-80\n"
+
+	// Act
+	output := commit.Execute(usecase.CommitImportInput{
+		StatementFile: csvStatement(profileID, content), UserID: "user-123", WalletID: wallet.ID,
+	})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	result := output.(usecase.CommitImportOutput)
+	assert.Equal(t, 3, result.Imported)
+	assert.Equal(t, 1, walletRepo.saves)
+
+	if assert.Len(t, result.Rejected, 2) {
+		assert.Equal(t, 4, result.Rejected[0].Line)
+		assert.Contains(t, result.Rejected[0].Reason, "invalid date")
+		assert.Equal(t, 6, result.Rejected[1].Line)
+		assert.Contains(t, result.Rejected[1].Reason, "insufficient balance")
+	}
+	assert.Equal(t, []int{2, 3, 7}, []int{result.Rows[0].Line, result.Rows[1].Line, result.Rows[2].Line})
+	assert.NotEmpty(t, result.Rows[0].RecordID)
+
+	saved, _ := walletRepo.FindByIDWithTransactions(wallet.ID)
+	assert.Equal(t, int64(1000+2000-500-80), saved.Balance.Amount)
+	assert.Len(t, saved.GetExpenseRecords(), 2)
+	assert.Len(t, saved.GetIncomeRecords(), 1)
+	assert.Equal(t, groceries.ID, saved.GetExpenseRecords()[0].SubcategoryID)
+	assert.Equal(t, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), saved.GetExpenseRecords()[0].Date)
+}
+
+func Test_CommitImportService_DoesNotSaveWhenNoRowIsAccepted(t *testing.T) {
+	// Arrange
+	fakeWalletRepo, _ := test.NewFakeWalletRepo()
+	walletRepo := &countingWalletRepo{FakeWalletRepo: fakeWalletRepo}
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	profileRepo := test.NewFakeImportProfileRepository()
+	wallet := test.CreateWallet(t, fakeWalletRepo, "user-123", "TWD", 1000)
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Food", "Groceries")
+	_, salary := test.CreateIncomeSubcategory(t, incomeCategoryRepo, "user-123", "Salary", "Monthly")
+	mapping := signedAmountMapping()
+	mapping.ExpenseSubcategoryID, mapping.IncomeSubcategoryID = groceries.ID, salary.ID
+	profileID := command.NewCreateImportProfileService(profileRepo, expenseCategoryRepo, incomeCategoryRepo).Execute(usecase.CreateImportProfileInput{
+		UserID: "user-123", Name: "My Bank", Mapping: mapping,
+	}).GetID()
+	commit := command.NewCommitImportService(walletRepo, profileRepo, expenseCategoryRepo, incomeCategoryRepo, test.NewFakeCategorizationRuleRepository())
+
+	// Act
+	output := commit.Execute(usecase.CommitImportInput{
+		StatementFile: csvStatement(profileID, "Date,Memo,Amount\n2024-03-05,Laptop,-5000\n"), UserID: "user-123", WalletID: wallet.ID,
+	})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	assert.Equal(t, 0, output.(usecase.CommitImportOutput).Imported)
+	assert.Equal(t, 0, walletRepo.saves)
+}
+
+func Test_PreviewImportService_ParsesBig5StatementWithoutRecording(t *testing.T) {
+	// Arrange
+	fakeWalletRepo, _ := test.NewFakeWalletRepo()
+	walletRepo := &countingWalletRepo{FakeWalletRepo: fakeWalletRepo}
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	profileRepo := test.NewFakeImportProfileRepository()
+	wallet := test.CreateWallet(t, fakeWalletRepo, "user-123", "TWD", 1000)
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Food", "Groceries")
+	_, salary := test.CreateIncomeSubcategory(t, incomeCategoryRepo, "user-123", "Salary", "Monthly")
+	mapping := usecase.ImportMappingData{
+		Encoding:          "big5",
+		HasHeader:         true,
+		SkipRows:          1,
+		DateColumn:        "交易日期",
+		DateFormat:        "YYYY/MM/DD",
+		DescriptionColumn: "摘要",
+		DebitColumn:       "支出",
+		CreditColumn:      "存入",
+	}
+	mapping.ExpenseSubcategoryID, mapping.IncomeSubcategoryID = groceries.ID, salary.ID
+	profileID := command.NewCreateImportProfileService(profileRepo, expenseCategoryRepo, incomeCategoryRepo).Execute(usecase.CreateImportProfileInput{
+		UserID: "user-123", Name: "My Bank", Mapping: mapping,
+	}).GetID()
+	preview := query.NewPreviewImportService(walletRepo, profileRepo, expenseCategoryRepo, incomeCategoryRepo, test.NewFakeCategorizationRuleRepository())
+	content, err := traditionalchinese.Big5.NewEncoder().String("帳號 0123-456,,,\n" +
+		"交易日期,摘要,支出,存入\n" +
+		"2024/03/01,薪資,,\"1,500\"\n" +
+		"2024/03/02,全聯,300,\n" +
+		"2024/03/03,房租,\"5,000\",\n")
+	assert.NoError(t, err)
+
+	// Act
+	output := preview.Execute(usecase.PreviewImportInput{
+		StatementFile: csvStatement(profileID, content), UserID: "user-123", WalletID: wallet.ID,
+	})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	result := output.(usecase.PreviewImportOutput)
+	if assert.Len(t, result.Rows, 2) {
+		assert.Equal(t, "薪資", result.Rows[0].Description)
+		assert.Equal(t, string(model.ImportIncome), result.Rows[0].Type)
+		assert.Equal(t, "全聯", result.Rows[1].Description)
+	}
+	assert.Equal(t, int64(300), result.TotalExpenses)
+	assert.Equal(t, int64(1500), result.TotalIncomes)
+	if assert.Len(t, result.Rejected, 1) {
+		assert.Equal(t, 5, result.Rejected[0].Line)
+	}
+
+	// 預覽不會修改錢包
+	assert.Equal(t, 0, walletRepo.saves)
+	saved, _ := walletRepo.FindByIDWithTransactions(wallet.ID)
+	assert.Equal(t, int64(1000), saved.Balance.Amount)
+	assert.Empty(t, saved.GetExpenseRecords())
+}
+
+func Test_ImportServices_RejectOtherUsersResources(t *testing.T) {
+	// Arrange
+	fakeWalletRepo, _ := test.NewFakeWalletRepo()
+	walletRepo := &countingWalletRepo{FakeWalletRepo: fakeWalletRepo}
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	profileRepo := test.NewFakeImportProfileRepository()
+	wallet := test.CreateWallet(t, fakeWalletRepo, "user-123", "TWD", 1000)
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Food", "Groceries")
+	_, salary := test.CreateIncomeSubcategory(t, incomeCategoryRepo, "user-123", "Salary", "Monthly")
+	mapping := signedAmountMapping()
+	mapping.ExpenseSubcategoryID, mapping.IncomeSubcategoryID = groceries.ID, salary.ID
+	profileID := command.NewCreateImportProfileService(profileRepo, expenseCategoryRepo, incomeCategoryRepo).Execute(usecase.CreateImportProfileInput{
+		UserID: "user-123", Name: "My Bank", Mapping: mapping,
+	}).GetID()
+	_, otherUsersSubcategory := test.CreateIncomeSubcategory(t, incomeCategoryRepo, "user-456", "Bonus", "Yearly")
+	otherUsersMapping := signedAmountMapping()
+	otherUsersMapping.ExpenseSubcategoryID, otherUsersMapping.IncomeSubcategoryID = groceries.ID, otherUsersSubcategory.ID
+
+	// Act
+	createOutput := command.NewCreateImportProfileService(profileRepo, expenseCategoryRepo, incomeCategoryRepo).Execute(usecase.CreateImportProfileInput{
+		UserID:  "user-123",
+		Name:    "Other",
+		Mapping: otherUsersMapping,
+	})
+	commitOutput := command.NewCommitImportService(walletRepo, profileRepo, expenseCategoryRepo, incomeCategoryRepo, test.NewFakeCategorizationRuleRepository()).Execute(usecase.CommitImportInput{
+		StatementFile: csvStatement(profileID, "Date,Memo,Amount\n2024-03-01,Salary,100\n"),
+		UserID:        "user-456",
+		WalletID:      wallet.ID,
+	})
+	deleteOutput := command.NewDeleteImportProfileService(profileRepo).Execute(usecase.DeleteImportProfileInput{
+		UserID:    "user-456",
+		ProfileID: profileID,
+	})
+
+	// Assert
+	assert.Equal(t, common.NotFound, createOutput.GetExitCode())
+	assert.Equal(t, "Income subcategory not found", createOutput.GetMessage())
+	assert.Equal(t, common.NotFound, commitOutput.GetExitCode())
+	assert.Equal(t, "Import profile not found", commitOutput.GetMessage())
+	assert.Equal(t, common.NotFound, deleteOutput.GetExitCode())
+	assert.Equal(t, 0, walletRepo.saves)
+}
+
+func Test_ImportProfileServices_UpdateAndList(t *testing.T) {
+	// Arrange
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	profileRepo := test.NewFakeImportProfileRepository()
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Food", "Groceries")
+	_, salary := test.CreateIncomeSubcategory(t, incomeCategoryRepo, "user-123", "Salary", "Monthly")
+	mapping := signedAmountMapping()
+	mapping.ExpenseSubcategoryID, mapping.IncomeSubcategoryID = groceries.ID, salary.ID
+	profileID := command.NewCreateImportProfileService(profileRepo, expenseCategoryRepo, incomeCategoryRepo).Execute(usecase.CreateImportProfileInput{
+		UserID: "user-123", Name: "My Bank", Mapping: mapping,
+	}).GetID()
+	mapping.SignConvention = string(model.SignPositiveIsExpense)
+	updateService := command.NewUpdateImportProfileService(profileRepo, expenseCategoryRepo, incomeCategoryRepo)
+
+	// Act
+	updateOutput := updateService.Execute(usecase.UpdateImportProfileInput{
+		UserID:    "user-123",
+		ProfileID: profileID,
+		Name:      "Credit Card",
+		Mapping:   mapping,
+	})
+	mapping.DateFormat = "sometime"
+	invalidOutput := updateService.Execute(usecase.UpdateImportProfileInput{
+		UserID:    "user-123",
+		ProfileID: profileID,
+		Name:      "Credit Card",
+		Mapping:   mapping,
+	})
+	listOutput := query.NewGetImportProfilesService(profileRepo).Execute(usecase.GetImportProfilesInput{UserID: "user-123"})
+
+	// Assert
+	assert.Equal(t, common.Success, updateOutput.GetExitCode(), updateOutput.GetMessage())
+	assert.Equal(t, common.InvalidInput, invalidOutput.GetExitCode())
+	assert.Contains(t, invalidOutput.GetMessage(), "Invalid import profile")
+
+	profiles := listOutput.(usecase.GetImportProfilesOutput).Profiles
+	if assert.Len(t, profiles, 1) {
+		assert.Equal(t, "Credit Card", profiles[0].Name)
+		assert.Equal(t, string(model.SignPositiveIsExpense), profiles[0].Mapping.SignConvention)
+		assert.Equal(t, "YYYY-MM-DD", profiles[0].Mapping.DateFormat)
+		assert.Equal(t, string(model.ImportEncodingUTF8), profiles[0].Mapping.Encoding)
+	}
+}
+
+const testOFXStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+ENCODING:USASCII
+CHARSET:1252
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>TWD
+<BANKACCTFROM><BANKID>012<ACCTID>123-456<ACCTTYPE>CHECKING</BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN><TRNTYPE>CREDIT<DTPOSTED>20240301120000[+8:CST]<TRNAMT>2000.00<FITID>T1<NAME>Salary</STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240302<TRNAMT>-500<FITID>T2<NAME>Groceries<MEMO>Market</STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240303<TRNAMT>0<FITID>T3<NAME>Void</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+func Test_CommitImportService_SkipsOFXTransactionsImportedBefore(t *testing.T) {
+	// Arrange
+	fakeWalletRepo, _ := test.NewFakeWalletRepo()
+	walletRepo := &countingWalletRepo{FakeWalletRepo: fakeWalletRepo}
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	profileRepo := test.NewFakeImportProfileRepository()
+	wallet := test.CreateWallet(t, fakeWalletRepo, "user-123", "TWD", 1000)
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Food", "Groceries")
+	_, salary := test.CreateIncomeSubcategory(t, incomeCategoryRepo, "user-123", "Salary", "Monthly")
+	commit := command.NewCommitImportService(walletRepo, profileRepo, expenseCategoryRepo, incomeCategoryRepo, test.NewFakeCategorizationRuleRepository())
+
+	// Act
+	first := commit.Execute(usecase.CommitImportInput{
+		StatementFile: usecase.StatementFile{FileName: "statement.ofx", Content: strings.NewReader(testOFXStatement), ExpenseSubcategoryID: groceries.ID, IncomeSubcategoryID: salary.ID}, UserID: "user-123", WalletID: wallet.ID,
+	})
+	second := commit.Execute(usecase.CommitImportInput{
+		StatementFile: usecase.StatementFile{FileName: "statement.qfx", Content: strings.NewReader(testOFXStatement), ExpenseSubcategoryID: groceries.ID, IncomeSubcategoryID: salary.ID}, UserID: "user-123", WalletID: wallet.ID,
+	})
+
+	// Assert
+	assert.Equal(t, common.Success, first.GetExitCode(), first.GetMessage())
+	firstResult := first.(usecase.CommitImportOutput)
+	assert.Equal(t, 2, firstResult.Imported)
+	assert.Equal(t, []usecase.ImportStatementData{{Account: "123-456", Created: 2, Rejected: 1}}, firstResult.Statements)
+	assert.Equal(t, "Groceries - Market", firstResult.Rows[1].Description)
+
+	assert.Equal(t, common.Success, second.GetExitCode(), second.GetMessage())
+	secondResult := second.(usecase.CommitImportOutput)
+	assert.Equal(t, 0, secondResult.Imported)
+	assert.Len(t, secondResult.Skipped, 2)
+	assert.Equal(t, []usecase.ImportStatementData{{Account: "123-456", Skipped: 2, Rejected: 1}}, secondResult.Statements)
+
+	assert.Equal(t, 1, walletRepo.saves)
+	saved, _ := walletRepo.FindByIDWithTransactions(wallet.ID)
+	assert.Equal(t, int64(2500), saved.Balance.Amount)
+	assert.True(t, saved.HasImportedTransaction("ofx:123-456:T2"))
+}
+
+func Test_ImportServices_ImportQIFByContentHash(t *testing.T) {
+	// Arrange
+	fakeWalletRepo, _ := test.NewFakeWalletRepo()
+	walletRepo := &countingWalletRepo{FakeWalletRepo: fakeWalletRepo}
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	profileRepo := test.NewFakeImportProfileRepository()
+	wallet := test.CreateWallet(t, fakeWalletRepo, "user-123", "TWD", 1000)
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Food", "Groceries")
+	_, salary := test.CreateIncomeSubcategory(t, incomeCategoryRepo, "user-123", "Salary", "Monthly")
+	commit := command.NewCommitImportService(walletRepo, profileRepo, expenseCategoryRepo, incomeCategoryRepo, test.NewFakeCategorizationRuleRepository())
+	preview := query.NewPreviewImportService(walletRepo, profileRepo, expenseCategoryRepo, incomeCategoryRepo, test.NewFakeCategorizationRuleRepository())
+	content := "!Account\nNCash Card\n^\n!Type:Bank\n" +
+		"D3/ 1'24\nT2,000.00\nPSalary\n^\n" +
+		"D3/2/2024\nT-120\nPCoffee\n^\n" +
+		"D3/2/2024\nT-120\nPCoffee\n^\n" +
+		"D13/45/2024\nT-1\nPBroken\n^\n"
+
+	// Act
+	previewed := preview.Execute(usecase.PreviewImportInput{
+		StatementFile: usecase.StatementFile{FileName: "export.qif", Content: strings.NewReader(content), ExpenseSubcategoryID: groceries.ID, IncomeSubcategoryID: salary.ID}, UserID: "user-123", WalletID: wallet.ID,
+	})
+	first := commit.Execute(usecase.CommitImportInput{
+		StatementFile: usecase.StatementFile{FileName: "export.qif", Content: strings.NewReader(content), ExpenseSubcategoryID: groceries.ID, IncomeSubcategoryID: salary.ID}, UserID: "user-123", WalletID: wallet.ID,
+	})
+	repeated := preview.Execute(usecase.PreviewImportInput{
+		StatementFile: usecase.StatementFile{FileName: "export.qif", Content: strings.NewReader(content), ExpenseSubcategoryID: groceries.ID, IncomeSubcategoryID: salary.ID}, UserID: "user-123", WalletID: wallet.ID,
+	})
+
+	// Assert - 同一檔案中內容相同的兩筆交易都會匯入，重複匯入整個檔案則全部略過
+	assert.Equal(t, common.Success, previewed.GetExitCode(), previewed.GetMessage())
+	previewResult := previewed.(usecase.PreviewImportOutput)
+	assert.Len(t, previewResult.Rows, 3)
+	assert.Equal(t, int64(240), previewResult.TotalExpenses)
+	assert.Equal(t, int64(2000), previewResult.TotalIncomes)
+	assert.Equal(t, []usecase.ImportStatementData{{Account: "Cash Card", Created: 3, Rejected: 1}}, previewResult.Statements)
+
+	assert.Equal(t, common.Success, first.GetExitCode(), first.GetMessage())
+	assert.Equal(t, 3, first.(usecase.CommitImportOutput).Imported)
+
+	repeatedResult := repeated.(usecase.PreviewImportOutput)
+	assert.Empty(t, repeatedResult.Rows)
+	assert.Len(t, repeatedResult.Skipped, 3)
+	assert.Equal(t, 1, walletRepo.saves)
+}
+
+func Test_ImportServices_RejectStatementsWithoutSubcategoriesOrInOtherCurrency(t *testing.T) {
+	// Arrange
+	fakeWalletRepo, _ := test.NewFakeWalletRepo()
+	walletRepo := &countingWalletRepo{FakeWalletRepo: fakeWalletRepo}
+	expenseCategoryRepo := test.NewFakeExpenseCategoryRepository()
+	incomeCategoryRepo := test.NewFakeIncomeCategoryRepository()
+	profileRepo := test.NewFakeImportProfileRepository()
+	wallet := test.CreateWallet(t, fakeWalletRepo, "user-123", "TWD", 1000)
+	_, groceries := test.CreateExpenseSubcategory(t, expenseCategoryRepo, "user-123", "Food", "Groceries")
+	_, salary := test.CreateIncomeSubcategory(t, incomeCategoryRepo, "user-123", "Salary", "Monthly")
+	commit := command.NewCommitImportService(walletRepo, profileRepo, expenseCategoryRepo, incomeCategoryRepo, test.NewFakeCategorizationRuleRepository())
+	missingSubcategories := usecase.StatementFile{FileName: "statement.ofx", Content: strings.NewReader(testOFXStatement)}
+	otherCurrency := usecase.StatementFile{FileName: "statement.ofx", Content: strings.NewReader(strings.Replace(testOFXStatement, "<CURDEF>TWD", "<CURDEF>USD", 1)), ExpenseSubcategoryID: groceries.ID, IncomeSubcategoryID: salary.ID}
+	notOFX := usecase.StatementFile{FileName: "statement.ofx", Content: strings.NewReader("Date,Memo,Amount\n"), ExpenseSubcategoryID: groceries.ID, IncomeSubcategoryID: salary.ID}
+
+	// Act
+	missingOutput := commit.Execute(usecase.CommitImportInput{StatementFile: missingSubcategories, UserID: "user-123", WalletID: wallet.ID})
+	currencyOutput := commit.Execute(usecase.CommitImportInput{StatementFile: otherCurrency, UserID: "user-123", WalletID: wallet.ID})
+	notOFXOutput := commit.Execute(usecase.CommitImportInput{StatementFile: notOFX, UserID: "user-123", WalletID: wallet.ID})
+
+	// Assert
+	assert.Equal(t, common.InvalidInput, missingOutput.GetExitCode())
+	assert.Contains(t, missingOutput.GetMessage(), "subcategories are required")
+	assert.Equal(t, common.Success, currencyOutput.GetExitCode())
+	currencyResult := currencyOutput.(usecase.CommitImportOutput)
+	assert.Equal(t, 0, currencyResult.Imported)
+	assert.Len(t, currencyResult.Rejected, 3)
+	assert.Equal(t, common.InvalidInput, notOFXOutput.GetExitCode())
+	assert.Equal(t, 0, walletRepo.saves)
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_InitializeDefaultCategoriesService_IsIdempotent(t *testing.T) {
+	// Arrange
+	expenseRepo := test.NewFakeExpenseCategoryRepository()
+	incomeRepo := test.NewFakeIncomeCategoryRepository()
+	service := command.NewInitializeDefaultCategoriesService(expenseRepo, incomeRepo)
+	input := usecase.InitializeDefaultCategoriesInput{UserID: "user-123"}
+
+	// Act
+	first := service.Execute(input)
+	second := service.Execute(input)
+
+	// Assert - 第二次執行不會重複建立
+	require.Equal(t, common.Success, first.GetExitCode())
+	require.Equal(t, common.Success, second.GetExitCode())
+	assert.Contains(t, first.GetMessage(), "8 expense, 4 income categories created")
+	assert.Contains(t, second.GetMessage(), "0 expense, 0 income categories created")
+
+	expenses, _ := expenseRepo.FindByUserID("user-123")
+	incomes, _ := incomeRepo.FindByUserID("user-123")
+	assert.Len(t, expenses, 8)
+	assert.Len(t, incomes, 4)
+}
+
+func Test_InitializeDefaultCategoriesService_RestoresMissingSubcategory(t *testing.T) {
+	// Arrange
+	expenseRepo := test.NewFakeExpenseCategoryRepository()
+	service := command.NewInitializeDefaultCategoriesService(expenseRepo, test.NewFakeIncomeCategoryRepository())
+	service.Execute(usecase.InitializeDefaultCategoriesInput{UserID: "user-123", Locale: "en"})
+
+	food := findExpenseCategoryByName(t, expenseRepo, "user-123", "Food & Dining")
+	food.RemoveSubcategory(food.Subcategories[0].ID)
+	expenseRepo.Save(food)
+
+	// Act
+	output := service.Execute(usecase.InitializeDefaultCategoriesInput{UserID: "user-123", Locale: "en"})
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode())
+	food = findExpenseCategoryByName(t, expenseRepo, "user-123", "Food & Dining")
+	assert.Len(t, food.Subcategories, 5)
+}
+
+func Test_InitializeDefaultCategoriesService_UnknownLocale(t *testing.T) {
+	service := command.NewInitializeDefaultCategoriesService(test.NewFakeExpenseCategoryRepository(), test.NewFakeIncomeCategoryRepository())
+
+	output := service.Execute(usecase.InitializeDefaultCategoriesInput{UserID: "user-123", Locale: "fr"})
+
+	assert.Equal(t, common.InvalidInput, output.GetExitCode())
+	assert.Contains(t, output.GetMessage(), "Invalid locale")
+}
+
+func Test_CreateWalletService_InitializesCategoriesForFirstWalletOnly(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	expenseRepo := test.NewFakeExpenseCategoryRepository()
+	initializer := command.NewInitializeDefaultCategoriesService(expenseRepo, test.NewFakeIncomeCategoryRepository())
+	service := command.NewCreateWalletService(walletRepo, initializer)
+	input := usecase.CreateWalletInput{UserID: "user-123", Name: "Cash", Type: "CASH", Currency: "TWD"}
+
+	// Act
+	first := service.Execute(input)
+	require.Equal(t, common.Success, first.GetExitCode())
+	food := findExpenseCategoryByName(t, expenseRepo, "user-123", "餐飲")
+	expenseRepo.Delete(food.ID)
+
+	second := service.Execute(input)
+
+	// Assert - 第二個錢包不會觸發初始化，被刪除的分類不會回來
+	require.Equal(t, common.Success, second.GetExitCode())
+	expenses, _ := expenseRepo.FindByUserID("user-123")
+	assert.Len(t, expenses, 7)
+}
+
+func findExpenseCategoryByName(t *testing.T, repo repository.ExpenseCategoryRepository, userID, name string) *model.ExpenseCategory {
+	categories, _ := repo.FindByUserID(userID)
+	for _, category := range categories {
+		if category.Name.Value == name {
+			return category
+		}
+	}
+	t.Fatalf("Expected category %q for user %s", name, userID)
+	return nil
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/repository"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// conflictingWalletRepo 前conflicts次儲存回傳樂觀鎖衝突，模擬其他請求搶先修改錢包
+type conflictingWalletRepo struct {
+	*test.FakeWalletRepo
+	conflicts int
+	saves     int
+}
+
+func (r *conflictingWalletRepo) Save(wallet *model.Wallet) error {
+	r.saves++
+	if r.conflicts > 0 {
+		r.conflicts--
+		return fmt.Errorf("%w: wallet %s was modified", repository.ErrConcurrencyConflict, wallet.ID)
+	}
+	return r.FakeWalletRepo.Save(wallet)
+}
+
+func createAddExpenseInput(walletID string, amount int64) usecase.AddExpenseInput {
+	return usecase.AddExpenseInput{
+		UserID:        "user-123",
+		WalletID:      walletID,
+		SubcategoryID: "food-123",
+		Amount:        amount,
+		Currency:      "USD",
+		Description:   "Lunch",
+		Date:          time.Now(),
+	}
+}
+
+func Test_AddExpenseService_RetriesOnConcurrencyConflict(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	wallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	repo := &conflictingWalletRepo{FakeWalletRepo: walletRepo, conflicts: 2}
+	service := command.NewAddExpenseService(repo, nil, nil, nil)
+
+	// Act
+	output := service.Execute(createAddExpenseInput(wallet.ID, 1500))
+
+	// Assert - 第三次嘗試成功
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	assert.Equal(t, 3, repo.saves)
+}
+
+func Test_AddExpenseService_ConflictRetriesExhausted(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	wallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	repo := &conflictingWalletRepo{FakeWalletRepo: walletRepo, conflicts: 100}
+	service := command.NewAddExpenseService(repo, nil, nil, nil)
+
+	// Act
+	output := service.Execute(createAddExpenseInput(wallet.ID, 1500))
+
+	// Assert - 重試有上限，並回傳可區分的Conflict
+	assert.Equal(t, common.Conflict, output.GetExitCode())
+	assert.Contains(t, output.GetMessage(), "concurrency conflict")
+	assert.Equal(t, 4, repo.saves)
+}
+
+func Test_AddExpenseService_OtherSaveErrorsAreNotRetried(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	wallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	service := command.NewAddExpenseService(&saveFailingWalletRepo{FakeWalletRepo: walletRepo, failWalletID: wallet.ID}, nil, nil, nil)
+
+	// Act
+	output := service.Execute(createAddExpenseInput(wallet.ID, 1500))
+
+	// Assert
+	assert.Equal(t, common.Failure, output.GetExitCode())
+}
+
+func Test_ProcessTransferService_RetriesWholeTransactionOnConflict(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	fromWallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	toWallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 500)
+
+	uow := test.NewFakeUnitOfWork(walletRepo)
+	uow.ScopeWalletRepo = &conflictingWalletRepo{FakeWalletRepo: walletRepo, conflicts: 1}
+	service := command.NewProcessTransferService(uow, nil)
+
+	// Act
+	output := service.Execute(createTransferInput(fromWallet.ID, toWallet.ID, 3000, 100))
+
+	// Assert - 第一次交易回滾，第二次提交；餘額只扣一次
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	assert.Equal(t, 1, uow.Rollbacks)
+	assert.Equal(t, 1, uow.Commits)
+
+	savedFrom, _ := walletRepo.FindByID(fromWallet.ID)
+	savedTo, _ := walletRepo.FindByID(toWallet.ID)
+	assert.Equal(t, int64(6900), savedFrom.Balance.Amount)
+	assert.Equal(t, int64(3500), savedTo.Balance.Amount)
+}
@@ -0,0 +1,309 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/mapper"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/outbox"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOutboxMessages 以錢包的領域事件產生待投遞訊息，與WalletRepositoryImpl相同
+func newOutboxMessages(t *testing.T) []mapper.OutboxMessageData {
+	wallet, _ := model.NewWalletWithInitialBalance("user-123", "Main", model.WalletTypeCash, "USD", 1000)
+	amount, _ := model.NewMoney(250, "USD")
+	_, err := wallet.AddExpense(*amount, "food", "Lunch", time.Now())
+	require.NoError(t, err)
+
+	messages, err := mapper.ToOutboxMessages(mapper.AggregateTypeWallet, wallet.DomainEvents())
+	require.NoError(t, err)
+	return messages
+}
+
+func testRelayConfig() outbox.RelayConfig {
+	return outbox.RelayConfig{
+		PollInterval: time.Second,
+		BatchSize:    10,
+		Lease:        time.Minute,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   4 * time.Second,
+		MaxAttempts:  3,
+	}
+}
+
+func Test_OutboxRelay_DeliversToRegisteredHandlers(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeOutboxRepository()
+	messages := newOutboxMessages(t)
+	repo.Add(messages...)
+	relay := outbox.NewRelay(repo, testRelayConfig())
+
+	var expenseEvents, allEvents []string
+	relay.Register(model.EventExpenseAdded, outbox.HandlerFunc(func(message mapper.OutboxMessageData) error {
+		expenseEvents = append(expenseEvents, message.EventType)
+		return nil
+	}))
+	relay.RegisterAll(outbox.HandlerFunc(func(message mapper.OutboxMessageData) error {
+		allEvents = append(allEvents, message.EventType)
+		return nil
+	}))
+	now := time.Now()
+
+	// Act
+	processed, err := relay.ProcessBatch(now)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed)
+	assert.Equal(t, []string{model.EventExpenseAdded}, expenseEvents)
+	assert.Equal(t, []string{model.EventWalletCreated, model.EventExpenseAdded}, allEvents)
+	for _, message := range messages {
+		stored, _ := repo.FindByID(message.ID)
+		assert.Equal(t, mapper.OutboxStatusDelivered, stored.Status)
+		assert.Equal(t, 1, stored.Attempts)
+		assert.NotNil(t, stored.DeliveredAt)
+	}
+
+	// 已投遞的訊息不會再被取得
+	processed, _ = relay.ProcessBatch(now.Add(time.Hour))
+	assert.Equal(t, 0, processed)
+}
+
+func Test_OutboxRelay_RetriesWithBackoffThenDeadLetters(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeOutboxRepository()
+	message := newOutboxMessages(t)[0]
+	repo.Add(message)
+	relay := outbox.NewRelay(repo, testRelayConfig())
+	calls := 0
+	relay.RegisterAll(outbox.HandlerFunc(func(message mapper.OutboxMessageData) error {
+		calls++
+		return errors.New("webhook unavailable")
+	}))
+	now := time.Now()
+
+	// Act & Assert - 第一次失敗，等待BaseBackoff
+	relay.ProcessBatch(now)
+	stored, _ := repo.FindByID(message.ID)
+	assert.Equal(t, mapper.OutboxStatusPending, stored.Status)
+	assert.Equal(t, 1, stored.Attempts)
+	assert.Equal(t, "webhook unavailable", stored.LastError)
+	assert.Equal(t, now.Add(time.Second), stored.NextAttemptAt)
+
+	// 尚未到期不會重試
+	processed, _ := relay.ProcessBatch(now.Add(500 * time.Millisecond))
+	assert.Equal(t, 0, processed)
+
+	// 第二次失敗，等待時間加倍
+	now = now.Add(time.Second)
+	relay.ProcessBatch(now)
+	stored, _ = repo.FindByID(message.ID)
+	assert.Equal(t, 2, stored.Attempts)
+	assert.Equal(t, now.Add(2*time.Second), stored.NextAttemptAt)
+
+	// 達到MaxAttempts進入dead letter
+	relay.ProcessBatch(now.Add(2 * time.Second))
+	stored, _ = repo.FindByID(message.ID)
+	assert.Equal(t, mapper.OutboxStatusDeadLetter, stored.Status)
+	assert.Equal(t, 3, calls)
+
+	processed, _ = relay.ProcessBatch(now.Add(time.Hour))
+	assert.Equal(t, 0, processed)
+}
+
+func Test_OutboxRelay_PanickingHandlerIsRetried(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeOutboxRepository()
+	message := newOutboxMessages(t)[0]
+	repo.Add(message)
+	relay := outbox.NewRelay(repo, testRelayConfig())
+	relay.RegisterAll(outbox.HandlerFunc(func(message mapper.OutboxMessageData) error {
+		panic("boom")
+	}))
+
+	// Act
+	_, err := relay.ProcessBatch(time.Now())
+
+	// Assert
+	require.NoError(t, err)
+	stored, _ := repo.FindByID(message.ID)
+	assert.Equal(t, mapper.OutboxStatusPending, stored.Status)
+	assert.Contains(t, stored.LastError, "panicked")
+}
+
+func Test_OutboxRelay_LeavesMessagesPendingWithoutHandlers(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeOutboxRepository()
+	messages := newOutboxMessages(t)
+	repo.Add(messages...)
+	relay := outbox.NewRelay(repo, testRelayConfig())
+	now := time.Now()
+
+	// Act
+	processed, err := relay.ProcessBatch(now)
+
+	// Assert - 訊息未被取得，註冊處理器後立即可投遞
+	require.NoError(t, err)
+	assert.Equal(t, 0, processed)
+	assert.False(t, relay.HasHandlers())
+	for _, message := range messages {
+		stored, _ := repo.FindByID(message.ID)
+		assert.Equal(t, mapper.OutboxStatusPending, stored.Status)
+		assert.Equal(t, 0, stored.Attempts)
+	}
+
+	relay.RegisterAll(outbox.HandlerFunc(func(message mapper.OutboxMessageData) error { return nil }))
+	processed, err = relay.ProcessBatch(now)
+	require.NoError(t, err)
+	assert.Equal(t, len(messages), processed)
+}
+
+func Test_OutboxRelay_LeavesEventTypesWithoutHandlersPending(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeOutboxRepository()
+	messages := newOutboxMessages(t)
+	repo.Add(messages...)
+	relay := outbox.NewRelay(repo, testRelayConfig())
+	relay.Register(model.EventExpenseAdded, outbox.HandlerFunc(func(message mapper.OutboxMessageData) error { return nil }))
+	now := time.Now()
+
+	// Act
+	processed, err := relay.ProcessBatch(now)
+
+	// Assert - 只投遞有處理器的事件，錢包建立事件維持pending而不是被標記為delivered
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	created, _ := repo.FindByID(messages[0].ID)
+	assert.Equal(t, model.EventWalletCreated, created.EventType)
+	assert.Equal(t, mapper.OutboxStatusPending, created.Status)
+	assert.Equal(t, 0, created.Attempts)
+	added, _ := repo.FindByID(messages[1].ID)
+	assert.Equal(t, mapper.OutboxStatusDelivered, added.Status)
+
+	// 租約到期後，註冊處理器即可投遞
+	relay.RegisterAll(outbox.LogHandler)
+	processed, err = relay.ProcessBatch(now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+}
+
+func Test_OutboxRelay_PurgeDeletesDeliveredMessagesPastRetention(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeOutboxRepository()
+	messages := newOutboxMessages(t)
+	repo.Add(messages...)
+	config := testRelayConfig()
+	config.Retention = 24 * time.Hour
+	relay := outbox.NewRelay(repo, config)
+	relay.Register(model.EventExpenseAdded, outbox.LogHandler)
+	now := time.Now()
+	relay.ProcessBatch(now)
+
+	// Act
+	early, _ := relay.Purge(now.Add(time.Hour))
+	purged, err := relay.Purge(now.Add(25 * time.Hour))
+
+	// Assert - 只刪除超過保留期限的已投遞訊息
+	require.NoError(t, err)
+	assert.Equal(t, 0, early)
+	assert.Equal(t, 1, purged)
+	deleted, _ := repo.FindByID(messages[1].ID)
+	assert.Nil(t, deleted)
+	pending, _ := repo.FindByID(messages[0].ID)
+	assert.NotNil(t, pending)
+}
+
+func Test_OutboxRelay_UpdateFailureDoesNotStopTheBatch(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeOutboxRepository()
+	messages := newOutboxMessages(t)
+	repo.Add(messages...)
+	repo.UpdateErrs = map[string]error{messages[0].ID: errors.New("connection reset")}
+	relay := outbox.NewRelay(repo, testRelayConfig())
+	relay.RegisterAll(outbox.HandlerFunc(func(message mapper.OutboxMessageData) error { return nil }))
+
+	// Act
+	processed, err := relay.ProcessBatch(time.Now())
+
+	// Assert - 第二筆仍投遞，錯誤與實際處理數一起回傳
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection reset")
+	assert.Equal(t, 1, processed)
+	first, _ := repo.FindByID(messages[0].ID)
+	assert.Equal(t, mapper.OutboxStatusPending, first.Status)
+	second, _ := repo.FindByID(messages[1].ID)
+	assert.Equal(t, mapper.OutboxStatusDelivered, second.Status)
+}
+
+func Test_RelayConfig_BackoffIsCapped(t *testing.T) {
+	config := testRelayConfig()
+
+	assert.Equal(t, time.Second, config.Backoff(1))
+	assert.Equal(t, 2*time.Second, config.Backoff(2))
+	assert.Equal(t, 4*time.Second, config.Backoff(3))
+	assert.Equal(t, 4*time.Second, config.Backoff(10))
+}
+
+func Test_GetOutboxMessagesService_ListsStuckMessages(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeOutboxRepository()
+	messages := newOutboxMessages(t)
+	messages[0].Status = mapper.OutboxStatusDeadLetter
+	messages[0].Attempts = 10
+	messages[0].LastError = "webhook unavailable"
+	repo.Add(messages...)
+	service := query.NewGetOutboxMessagesService(repo)
+
+	// Act
+	output := service.Execute(usecase.GetOutboxMessagesInput{})
+
+	// Assert - 尚未嘗試的訊息不算卡住
+	require.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	listed := output.(usecase.GetOutboxMessagesOutput).Messages
+	require.Len(t, listed, 1)
+	assert.Equal(t, messages[0].ID, listed[0].ID)
+	assert.Equal(t, "webhook unavailable", listed[0].LastError)
+	assert.JSONEq(t, string(messages[0].Payload), string(listed[0].Payload))
+
+	// 依狀態查詢
+	output = service.Execute(usecase.GetOutboxMessagesInput{Status: mapper.OutboxStatusPending})
+	assert.Len(t, output.(usecase.GetOutboxMessagesOutput).Messages, 1)
+
+	output = service.Execute(usecase.GetOutboxMessagesInput{Status: "lost"})
+	assert.Equal(t, common.InvalidInput, output.GetExitCode())
+}
+
+func Test_RetryOutboxMessageService_RequeuesDeadLetter(t *testing.T) {
+	// Arrange
+	repo := test.NewFakeOutboxRepository()
+	messages := newOutboxMessages(t)
+	messages[0].Status = mapper.OutboxStatusDeadLetter
+	messages[0].Attempts = 10
+	messages[1].Status = mapper.OutboxStatusDelivered
+	repo.Add(messages...)
+	service := command.NewRetryOutboxMessageService(repo)
+
+	// Act
+	output := service.Execute(usecase.RetryOutboxMessageInput{MessageID: messages[0].ID})
+
+	// Assert
+	require.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	stored, _ := repo.FindByID(messages[0].ID)
+	assert.Equal(t, mapper.OutboxStatusPending, stored.Status)
+	assert.Equal(t, 0, stored.Attempts)
+
+	output = service.Execute(usecase.RetryOutboxMessageInput{MessageID: messages[1].ID})
+	assert.Equal(t, common.Conflict, output.GetExitCode())
+
+	output = service.Execute(usecase.RetryOutboxMessageInput{MessageID: "missing"})
+	assert.Equal(t, "Outbox message not found", output.GetMessage())
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/exchange"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// saveFailingWalletRepo 儲存特定錢包時失敗，用於模擬交易中途崩潰
+type saveFailingWalletRepo struct {
+	*test.FakeWalletRepo
+	failWalletID string
+}
+
+func (r *saveFailingWalletRepo) Save(wallet *model.Wallet) error {
+	if wallet.ID == r.failWalletID {
+		return errors.New("connection lost")
+	}
+	return r.FakeWalletRepo.Save(wallet)
+}
+
+func createTransferInput(fromWalletID, toWalletID string, amount, fee int64) usecase.ProcessTransferInput {
+	return usecase.ProcessTransferInput{
+		UserID:       "user-123",
+		FromWalletID: fromWalletID,
+		ToWalletID:   toWalletID,
+		Amount:       amount,
+		Currency:     "USD",
+		Fee:          fee,
+		Description:  "Monthly savings",
+		Date:         time.Now(),
+	}
+}
+
+func Test_ProcessTransferService_Success_CommitsBothWallets(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	uow := test.NewFakeUnitOfWork(walletRepo)
+	service := command.NewProcessTransferService(uow, nil)
+
+	fromWallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	toWallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 500)
+
+	// Act
+	output := service.Execute(createTransferInput(fromWallet.ID, toWallet.ID, 3000, 100))
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	assert.NotEmpty(t, output.GetID())
+	assert.Equal(t, 1, uow.Commits)
+	assert.Equal(t, 0, uow.Rollbacks)
+
+	savedFrom, _ := walletRepo.FindByID(fromWallet.ID)
+	savedTo, _ := walletRepo.FindByID(toWallet.ID)
+	assert.Equal(t, int64(6900), savedFrom.Balance.Amount)
+	assert.Equal(t, int64(3500), savedTo.Balance.Amount)
+	assert.Len(t, savedFrom.GetTransfers(), 1)
+	assert.Len(t, savedTo.GetTransfers(), 1, "incoming transfer must appear in destination history")
+}
+
+func Test_ProcessTransferService_SaveFailure_RollsBackBothWallets(t *testing.T) {
+	// Arrange - 來源錢包已儲存，目標錢包儲存時失敗
+	walletRepo, _ := test.NewFakeWalletRepo()
+	fromWallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	toWallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 500)
+
+	uow := test.NewFakeUnitOfWork(walletRepo)
+	uow.ScopeWalletRepo = &saveFailingWalletRepo{FakeWalletRepo: walletRepo, failWalletID: toWallet.ID}
+	service := command.NewProcessTransferService(uow, nil)
+
+	// Act
+	output := service.Execute(createTransferInput(fromWallet.ID, toWallet.ID, 3000, 100))
+
+	// Assert - 沒有任何一邊的變更被保留
+	assert.Equal(t, common.Failure, output.GetExitCode())
+	assert.Contains(t, output.GetMessage(), "failed to save to wallet")
+	assert.Equal(t, 0, uow.Commits)
+	assert.Equal(t, 1, uow.Rollbacks)
+
+	savedFrom, _ := walletRepo.FindByID(fromWallet.ID)
+	savedTo, _ := walletRepo.FindByID(toWallet.ID)
+	assert.Equal(t, int64(10000), savedFrom.Balance.Amount, "money must not leave the source wallet")
+	assert.Equal(t, int64(500), savedTo.Balance.Amount)
+	assert.Empty(t, savedFrom.GetTransfers())
+}
+
+func Test_ProcessTransferService_DestinationNotFound(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	uow := test.NewFakeUnitOfWork(walletRepo)
+	service := command.NewProcessTransferService(uow, nil)
+
+	fromWallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+
+	// Act
+	output := service.Execute(createTransferInput(fromWallet.ID, "missing-wallet", 3000, 0))
+
+	// Assert
+	assert.Equal(t, common.NotFound, output.GetExitCode())
+	assert.Contains(t, output.GetMessage(), "to wallet not found")
+	assert.Equal(t, 1, uow.Rollbacks)
+
+	savedFrom, _ := walletRepo.FindByID(fromWallet.ID)
+	assert.Equal(t, int64(10000), savedFrom.Balance.Amount)
+}
+
+func Test_ProcessTransferService_InsufficientBalance(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	uow := test.NewFakeUnitOfWork(walletRepo)
+	service := command.NewProcessTransferService(uow, nil)
+
+	fromWallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 1000)
+	toWallet := createTestWalletInRepo(walletRepo, "user-123", "USD", 0)
+
+	// Act
+	output := service.Execute(createTransferInput(fromWallet.ID, toWallet.ID, 1000, 1))
+
+	// Assert
+	assert.Equal(t, common.Failure, output.GetExitCode())
+	assert.Contains(t, output.GetMessage(), "insufficient balance")
+
+	savedTo, _ := walletRepo.FindByID(toWallet.ID)
+	assert.Equal(t, int64(0), savedTo.Balance.Amount)
+}
+
+func Test_ProcessTransferService_CrossCurrencyDebitsAndCreditsEachSideInItsCurrency(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	service := command.NewProcessTransferService(test.NewFakeUnitOfWork(walletRepo), nil)
+	usd := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	twd := createTestWalletInRepo(walletRepo, "user-123", "TWD", 0)
+
+	input := createTransferInput(usd.ID, twd.ID, 3000, 15)
+	input.ToAmount = 960
+	input.FeeCurrency = "TWD" // charged by the receiving bank
+
+	// Act
+	output := service.Execute(input)
+
+	// Assert
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	savedUSD, _ := walletRepo.FindByID(usd.ID)
+	savedTWD, _ := walletRepo.FindByID(twd.ID)
+	assert.Equal(t, int64(7000), savedUSD.Balance.Amount)
+	assert.Equal(t, int64(945), savedTWD.Balance.Amount, "960 TWD less the 15 TWD fee")
+
+	transfers := query.NewGetTransfersService(walletRepo).Execute(usecase.GetTransfersInput{UserID: "user-123"}).(usecase.GetTransfersOutput)
+	if assert.Len(t, transfers.Data, 1) {
+		assert.Equal(t, usecase.MoneyData{Amount: 960, Currency: "TWD"}, transfers.Data[0].ToAmount)
+		assert.Equal(t, "32", transfers.Data[0].Rate)
+		assert.Equal(t, "TWD", transfers.Data[0].Fee.Currency)
+	}
+}
+
+func Test_ProcessTransferService_CrossCurrencyDestinationAmountFromRate(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	usd := createTestWalletInRepo(walletRepo, "user-123", "USD", 10000)
+	twd := createTestWalletInRepo(walletRepo, "user-123", "TWD", 0)
+	eur := createTestWalletInRepo(walletRepo, "user-123", "EUR", 0)
+	converter := exchange.NewConverter(seedExchangeRates(t, "2026-03-01,USD,TWD,30"), model.RoundHalfUp)
+	service := command.NewProcessTransferService(test.NewFakeUnitOfWork(walletRepo), converter)
+
+	withRate := createTransferInput(usd.ID, twd.ID, 1050, 100)
+	withRate.Rate = "32.5" // 341.25 TWD
+	stored := createTransferInput(usd.ID, twd.ID, 1000, 0)
+	stored.Date = time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	first := service.Execute(withRate)
+	second := service.Execute(stored)
+
+	// Assert
+	assert.Equal(t, common.Success, first.GetExitCode(), first.GetMessage())
+	assert.Equal(t, common.Success, second.GetExitCode(), second.GetMessage())
+	savedUSD, _ := walletRepo.FindByID(usd.ID)
+	savedTWD, _ := walletRepo.FindByID(twd.ID)
+	assert.Equal(t, int64(10000-1050-100-1000), savedUSD.Balance.Amount)
+	assert.Equal(t, int64(341+300), savedTWD.Balance.Amount)
+
+	for name, tc := range map[string]struct {
+		input    usecase.ProcessTransferInput
+		expected string
+	}{
+		"no rate stored": {createTransferInput(usd.ID, eur.ID, 1000, 0), "no exchange rate from USD to EUR"},
+		"amount and rate": {func() usecase.ProcessTransferInput {
+			input := createTransferInput(usd.ID, twd.ID, 1000, 0)
+			input.ToAmount, input.Rate = 300, "30"
+			return input
+		}(), "either a destination amount or a rate"},
+		"third currency fee": {func() usecase.ProcessTransferInput {
+			input := createTransferInput(usd.ID, twd.ID, 1000, 10)
+			input.ToAmount, input.FeeCurrency = 300, "EUR"
+			return input
+		}(), "fee currency EUR must be USD or TWD"},
+	} {
+		output := service.Execute(tc.input)
+		assert.Equal(t, common.Failure, output.GetExitCode(), name)
+		assert.Contains(t, output.GetMessage(), tc.expected, name)
+	}
+
+	unconfigured := command.NewProcessTransferService(test.NewFakeUnitOfWork(walletRepo), nil).Execute(createTransferInput(usd.ID, twd.ID, 1000, 0))
+	assert.Contains(t, unconfigured.GetMessage(), "a destination amount or rate is required from USD to TWD")
+}
@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/recurring"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateRecurringRuleService_Validation(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 100000)
+	_, lunch := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Food", "Lunch")
+	service := command.NewCreateRecurringRuleService(test.NewFakeRecurringRuleRepository(), walletRepo, categoryRepo, test.NewFakeIncomeCategoryRepository())
+	valid := usecase.CreateRecurringRuleInput{
+		UserID:        "user-123",
+		Name:          "Lunch plan",
+		Type:          string(model.RecurringExpense),
+		WalletID:      wallet.ID,
+		SubcategoryID: lunch.ID,
+		Amount:        100,
+		Currency:      "TWD",
+		Frequency:     string(model.RecurrenceDaily),
+		StartDate:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Act & Assert
+	otherUser := valid
+	otherUser.UserID = "user-456"
+	assert.Equal(t, "Wallet not found", service.Execute(otherUser).GetMessage())
+
+	wrongCurrency := valid
+	wrongCurrency.Currency = "USD"
+	assert.Contains(t, service.Execute(wrongCurrency).GetMessage(), "does not match wallet currency")
+
+	income := valid
+	income.Type = string(model.RecurringIncome)
+	assert.Equal(t, "Subcategory not found", service.Execute(income).GetMessage(), "an expense subcategory cannot be used for income")
+
+	end := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	bothEnds := valid
+	bothEnds.EndDate, bothEnds.Count = &end, 3
+	output := service.Execute(bothEnds)
+	assert.Equal(t, common.InvalidInput, output.GetExitCode())
+	assert.Contains(t, output.GetMessage(), "Invalid recurring rule")
+}
+
+func Test_RecurringScheduler_GeneratesEachOccurrenceOnce(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	ruleRepo := test.NewFakeRecurringRuleRepository()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 100000)
+	_, lunch := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Food", "Lunch")
+	created := command.NewCreateRecurringRuleService(ruleRepo, walletRepo, categoryRepo, test.NewFakeIncomeCategoryRepository()).Execute(usecase.CreateRecurringRuleInput{
+		UserID:        "user-123",
+		Name:          "Lunch plan",
+		Type:          string(model.RecurringExpense),
+		WalletID:      wallet.ID,
+		SubcategoryID: lunch.ID,
+		Amount:        100,
+		Currency:      "TWD",
+		Frequency:     string(model.RecurrenceDaily),
+		StartDate:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.Equal(t, common.Success, created.GetExitCode(), created.GetMessage())
+	scheduler := recurring.NewScheduler(ruleRepo, command.NewAddExpenseService(walletRepo, nil, nil, nil), command.NewAddIncomeService(walletRepo, nil), recurring.DefaultSchedulerConfig())
+	now := time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC)
+
+	// Act - a second run at the same time finds nothing left to do
+	first, err := scheduler.RunOnce(now)
+	assert.NoError(t, err)
+	second, err := scheduler.RunOnce(now)
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 3, first)
+	assert.Equal(t, 0, second)
+	saved, _ := walletRepo.FindByIDWithTransactions(wallet.ID)
+	assert.Len(t, saved.GetExpenseRecords(), 3)
+	rule, _ := ruleRepo.FindByID(created.GetID())
+	for _, occurrence := range rule.Occurrences {
+		assert.Equal(t, model.OccurrenceGenerated, occurrence.Status)
+		assert.NotEmpty(t, occurrence.TransactionID)
+	}
+}
+
+func Test_RecurringScheduler_ReconcilesAnExpiredClaim(t *testing.T) {
+	// Arrange - a run claimed Jan 1 and added the expense, then stopped before recording it
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	ruleRepo := test.NewFakeRecurringRuleRepository()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 100000)
+	_, rent := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Housing", "Rent")
+	created := command.NewCreateRecurringRuleService(ruleRepo, walletRepo, categoryRepo, test.NewFakeIncomeCategoryRepository()).Execute(usecase.CreateRecurringRuleInput{
+		UserID:        "user-123",
+		Name:          "Rent",
+		Type:          string(model.RecurringExpense),
+		WalletID:      wallet.ID,
+		SubcategoryID: rent.ID,
+		Amount:        20000,
+		Currency:      "TWD",
+		Frequency:     string(model.RecurrenceMonthly),
+		StartDate:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Count:         1,
+	})
+	assert.Equal(t, common.Success, created.GetExitCode(), created.GetMessage())
+	addExpense := command.NewAddExpenseService(walletRepo, nil, nil, nil)
+	config := recurring.DefaultSchedulerConfig()
+	claimedAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	rule, _ := ruleRepo.FindByID(created.GetID())
+	assert.NoError(t, rule.Claim(rule.Schedule.StartDate, claimedAt, config.ClaimLease))
+	assert.NoError(t, ruleRepo.Save(rule))
+	generated := addExpense.Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: wallet.ID, SubcategoryID: rent.ID,
+		Amount: 20000, Currency: "TWD", Description: "Rent", Date: rule.Schedule.StartDate,
+		OccurrenceKey: rule.OccurrenceKey(rule.Schedule.StartDate),
+	})
+	assert.Equal(t, common.Success, generated.GetExitCode(), generated.GetMessage())
+	scheduler := recurring.NewScheduler(ruleRepo, addExpense, command.NewAddIncomeService(walletRepo, nil), config)
+
+	// Act - still claimed within the lease, then claimed again once it expires
+	early, err := scheduler.RunOnce(claimedAt.Add(config.ClaimLease / 2))
+	assert.NoError(t, err)
+	late, err := scheduler.RunOnce(claimedAt.Add(config.ClaimLease))
+	assert.NoError(t, err)
+
+	// Assert - the existing expense is recorded instead of adding a second one
+	assert.Equal(t, 0, early)
+	assert.Equal(t, 1, late)
+	saved, _ := walletRepo.FindByIDWithTransactions(wallet.ID)
+	assert.Len(t, saved.GetExpenseRecords(), 1)
+	assert.Equal(t, int64(100000-20000), saved.Balance.Amount)
+	rule, _ = ruleRepo.FindByID(created.GetID())
+	assert.Equal(t, model.OccurrenceGenerated, rule.Occurrences[0].Status)
+	assert.Equal(t, generated.GetID(), rule.Occurrences[0].TransactionID)
+	assert.Equal(t, 2, rule.Occurrences[0].Attempts)
+}
+
+func Test_RecurringScheduler_SkipsPausedRulesAndSkippedDates(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	ruleRepo := test.NewFakeRecurringRuleRepository()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 100000)
+	_, lunch := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Food", "Lunch")
+	create := command.NewCreateRecurringRuleService(ruleRepo, walletRepo, categoryRepo, test.NewFakeIncomeCategoryRepository())
+	input := usecase.CreateRecurringRuleInput{
+		UserID:        "user-123",
+		Name:          "Lunch plan",
+		Type:          string(model.RecurringExpense),
+		WalletID:      wallet.ID,
+		SubcategoryID: lunch.ID,
+		Amount:        100,
+		Currency:      "TWD",
+		Frequency:     string(model.RecurrenceDaily),
+		StartDate:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	pausedID := create.Execute(input).GetID()
+	input.Amount = 200
+	skippedID := create.Execute(input).GetID()
+	pause := command.NewPauseRecurringRuleService(ruleRepo).Execute(usecase.PauseRecurringRuleInput{UserID: "user-123", RuleID: pausedID})
+	assert.Equal(t, common.Success, pause.GetExitCode(), pause.GetMessage())
+	skip := command.NewSkipRecurringOccurrenceService(ruleRepo).Execute(usecase.SkipRecurringOccurrenceInput{
+		UserID: "user-123",
+		RuleID: skippedID,
+		Date:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	assert.Equal(t, common.Success, skip.GetExitCode(), skip.GetMessage())
+	scheduler := recurring.NewScheduler(ruleRepo, command.NewAddExpenseService(walletRepo, nil, nil, nil), command.NewAddIncomeService(walletRepo, nil), recurring.DefaultSchedulerConfig())
+
+	// Act
+	processed, err := scheduler.RunOnce(time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC))
+
+	// Assert - only Jan 1 and Jan 3 of the unpaused rule are generated
+	assert.NoError(t, err)
+	assert.Equal(t, 2, processed)
+	saved, _ := walletRepo.FindByIDWithTransactions(wallet.ID)
+	assert.Len(t, saved.GetExpenseRecords(), 2)
+}
+
+func Test_RecurringScheduler_RecordsFailedOccurrences(t *testing.T) {
+	// Arrange - the wallet only holds 100000
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	ruleRepo := test.NewFakeRecurringRuleRepository()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 100000)
+	_, lunch := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Food", "Lunch")
+	created := command.NewCreateRecurringRuleService(ruleRepo, walletRepo, categoryRepo, test.NewFakeIncomeCategoryRepository()).Execute(usecase.CreateRecurringRuleInput{
+		UserID:        "user-123",
+		Name:          "Catering",
+		Type:          string(model.RecurringExpense),
+		WalletID:      wallet.ID,
+		SubcategoryID: lunch.ID,
+		Amount:        60000,
+		Currency:      "TWD",
+		Frequency:     string(model.RecurrenceDaily),
+		StartDate:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Count:         2,
+	})
+	assert.Equal(t, common.Success, created.GetExitCode(), created.GetMessage())
+	scheduler := recurring.NewScheduler(ruleRepo, command.NewAddExpenseService(walletRepo, nil, nil, nil), command.NewAddIncomeService(walletRepo, nil), recurring.DefaultSchedulerConfig())
+
+	// Act - retries stop after MaxAttempts
+	for i := 0; i < 5; i++ {
+		_, err := scheduler.RunOnce(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+	}
+
+	// Assert
+	rule, _ := ruleRepo.FindByID(created.GetID())
+	assert.Equal(t, model.OccurrenceGenerated, rule.Occurrences[0].Status)
+	assert.Equal(t, model.OccurrenceFailed, rule.Occurrences[1].Status)
+	assert.Equal(t, recurring.DefaultSchedulerConfig().MaxAttempts, rule.Occurrences[1].Attempts)
+	assert.Contains(t, rule.Occurrences[1].LastError, "insufficient balance")
+	saved, _ := walletRepo.FindByIDWithTransactions(wallet.ID)
+	assert.Len(t, saved.GetExpenseRecords(), 1)
+}
+
+func Test_RecurringRuleServices_OwnerOnly(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	ruleRepo := test.NewFakeRecurringRuleRepository()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 100000)
+	_, lunch := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Food", "Lunch")
+	created := command.NewCreateRecurringRuleService(ruleRepo, walletRepo, categoryRepo, test.NewFakeIncomeCategoryRepository()).Execute(usecase.CreateRecurringRuleInput{
+		UserID:        "user-123",
+		Name:          "Lunch plan",
+		Type:          string(model.RecurringExpense),
+		WalletID:      wallet.ID,
+		SubcategoryID: lunch.ID,
+		Amount:        100,
+		Currency:      "TWD",
+		Frequency:     string(model.RecurrenceDaily),
+		StartDate:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	ruleID := created.GetID()
+
+	// Act & Assert - other users cannot see or change the rule
+	pause := command.NewPauseRecurringRuleService(ruleRepo).Execute(usecase.PauseRecurringRuleInput{UserID: "user-456", RuleID: ruleID})
+	assert.Equal(t, "Recurring rule not found", pause.GetMessage())
+	remove := command.NewDeleteRecurringRuleService(ruleRepo).Execute(usecase.DeleteRecurringRuleInput{UserID: "user-456", RuleID: ruleID})
+	assert.Equal(t, "Recurring rule not found", remove.GetMessage())
+
+	rules := query.NewGetRecurringRulesService(ruleRepo).Execute(usecase.GetRecurringRulesInput{UserID: "user-456"})
+	assert.Empty(t, rules.(usecase.GetRecurringRulesOutput).Rules)
+
+	preview := query.NewPreviewRecurringRuleService(ruleRepo).Execute(usecase.PreviewRecurringRuleInput{
+		UserID: "user-123",
+		RuleID: ruleID,
+		From:   time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Limit:  3,
+	})
+	assert.Equal(t, common.Success, preview.GetExitCode(), preview.GetMessage())
+	assert.Len(t, preview.(usecase.PreviewRecurringRuleOutput).Occurrences, 3)
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/domain/model"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AddExpenseService_SplitExpenseCountsOnlyCoveredLinesInBudget(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	budgetRepo := test.NewFakeBudgetRepository()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 100000)
+	food, lunch := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Food", "Lunch")
+	_, cleaning := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Household", "Cleaning")
+	created := command.NewCreateBudgetService(budgetRepo, categoryRepo).Execute(usecase.CreateBudgetInput{
+		UserID:     "user-123",
+		Name:       "Food",
+		Period:     string(model.BudgetPeriodMonthly),
+		CategoryID: food.ID,
+		Amount:     1000,
+		Currency:   "TWD",
+		StartDate:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	service := command.NewAddExpenseService(walletRepo, nil,
+		query.NewCheckBudgetWarningsService(budgetRepo, walletRepo, categoryRepo, nil), nil)
+	march := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	// Act - 1200 in total, of which 850 is food
+	output := service.Execute(usecase.AddExpenseInput{
+		UserID:      "user-123",
+		WalletID:    wallet.ID,
+		Amount:      1200,
+		Currency:    "TWD",
+		Description: "Supermarket",
+		Date:        march,
+		Splits: []usecase.ExpenseSplitInput{
+			{SubcategoryID: lunch.ID, Amount: 850},
+			{SubcategoryID: cleaning.ID, Amount: 350, Memo: "Detergent"},
+		},
+	}).(usecase.AddExpenseOutput)
+
+	// Assert - the food budget crosses 80% but is not over its limit
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	assert.Len(t, output.Warnings, 1)
+	assert.Equal(t, int64(850), output.Warnings[0].Status.Spent)
+	assert.False(t, output.Warnings[0].Status.OverBudget)
+
+	status := query.NewGetBudgetStatusService(budgetRepo, walletRepo, categoryRepo, nil).Execute(usecase.GetBudgetStatusInput{
+		UserID: "user-123", BudgetID: created.GetID(), At: march,
+	})
+	assert.Equal(t, int64(850), status.(usecase.GetBudgetStatusOutput).Status.Spent)
+
+	saved, _ := walletRepo.FindByID(wallet.ID)
+	assert.Equal(t, int64(100000-1200), saved.Balance.Amount)
+}
+
+func Test_GetExpensesService_CategoryFilterMatchesSplitLines(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 100000)
+	_, lunch := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Food", "Lunch")
+	_, cleaning := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Household", "Cleaning")
+	addExpense := command.NewAddExpenseService(walletRepo, nil, nil, nil)
+	march := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	addExpense.Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: wallet.ID, SubcategoryID: lunch.ID,
+		Amount: 300, Currency: "TWD", Description: "Lunch", Date: march,
+	})
+	split := addExpense.Execute(usecase.AddExpenseInput{
+		UserID:      "user-123",
+		WalletID:    wallet.ID,
+		Amount:      1000,
+		Currency:    "TWD",
+		Description: "Supermarket",
+		Date:        march,
+		Splits: []usecase.ExpenseSplitInput{
+			{SubcategoryID: lunch.ID, Amount: 800},
+			{SubcategoryID: cleaning.ID, Amount: 200, Memo: "Detergent"},
+		},
+	})
+	service := query.NewGetExpensesService(walletRepo)
+
+	// Act
+	cleaningExpenses := service.Execute(usecase.GetExpensesInput{UserID: "user-123", CategoryID: &cleaning.ID}).(usecase.GetExpensesOutput)
+	lunchExpenses := service.Execute(usecase.GetExpensesInput{UserID: "user-123", CategoryID: &lunch.ID}).(usecase.GetExpensesOutput)
+
+	// Assert - the split expense appears under both subcategories with its portion
+	assert.Equal(t, 1, cleaningExpenses.Count)
+	assert.Equal(t, split.GetID(), cleaningExpenses.Data[0].ID)
+	assert.Equal(t, int64(1000), cleaningExpenses.Data[0].Amount.Amount)
+	assert.Equal(t, int64(200), *cleaningExpenses.Data[0].CategoryAmount)
+	assert.Len(t, cleaningExpenses.Data[0].Splits, 2)
+	assert.Equal(t, "Detergent", cleaningExpenses.Data[0].Splits[1].Memo)
+
+	assert.Equal(t, 2, lunchExpenses.Count)
+	var lunchTotal int64
+	for _, expense := range lunchExpenses.Data {
+		lunchTotal += *expense.CategoryAmount
+	}
+	assert.Equal(t, int64(1100), lunchTotal)
+}
+
+func Test_AddExpenseService_RejectsInvalidSplits(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	categoryRepo := test.NewFakeExpenseCategoryRepository()
+	wallet := test.CreateWallet(t, walletRepo, "user-123", "TWD", 100000)
+	_, lunch := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Food", "Lunch")
+	_, cleaning := test.CreateExpenseSubcategory(t, categoryRepo, "user-123", "Household", "Cleaning")
+	service := command.NewAddExpenseService(walletRepo, nil, nil, nil)
+	input := usecase.AddExpenseInput{
+		UserID:   "user-123",
+		WalletID: wallet.ID,
+		Amount:   1000,
+		Currency: "TWD",
+		Date:     time.Now(),
+		Splits: []usecase.ExpenseSplitInput{
+			{SubcategoryID: lunch.ID, Amount: 600},
+			{SubcategoryID: cleaning.ID, Amount: 300},
+		},
+	}
+
+	// Act & Assert - lines must sum to the total
+	output := service.Execute(input)
+	assert.Equal(t, common.Failure, output.GetExitCode())
+	assert.Contains(t, output.GetMessage(), "split lines sum to 900")
+
+	// A subcategory and split lines cannot both be given
+	input.Splits[1].Amount = 400
+	input.SubcategoryID = lunch.ID
+	output = service.Execute(input)
+	assert.Equal(t, common.Failure, output.GetExitCode())
+	assert.Contains(t, output.GetMessage(), "either a subcategory or split lines")
+
+	saved, _ := walletRepo.FindByID(wallet.ID)
+	assert.Equal(t, int64(100000), saved.Balance.Amount)
+}
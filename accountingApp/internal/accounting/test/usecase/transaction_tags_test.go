@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/command"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/common"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/query"
+	"github.com/JingHsiu/accountingApp/internal/accounting/application/usecase"
+	"github.com/JingHsiu/accountingApp/internal/accounting/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AddServices_NormalizeTagsOnNewTransactions(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	cash := test.CreateWallet(t, walletRepo, "user-123", "USD", 100000)
+	bank := test.CreateWallet(t, walletRepo, "user-123", "USD", 100000)
+	transfer := createTransferInput(cash.ID, bank.ID, 5000, 0)
+	transfer.UserID = "user-123"
+	transfer.Tags = []string{"Savings"}
+
+	// Act
+	expense := command.NewAddExpenseService(walletRepo, nil, nil, nil).Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: cash.ID, SubcategoryID: "subcategory-food",
+		Amount: 1200, Currency: "USD", Date: time.Now(),
+		Tags: []string{" Trip-Tokyo ", "food", "FOOD"},
+	})
+	transferred := command.NewProcessTransferService(test.NewFakeUnitOfWork(walletRepo), nil).Execute(transfer)
+	invalid := command.NewAddIncomeService(walletRepo, nil).Execute(usecase.AddIncomeInput{
+		UserID: "user-123", WalletID: bank.ID, SubcategoryID: "subcategory-salary",
+		Amount: 100, Currency: "USD", Date: time.Now(), Tags: []string{"two words"},
+	})
+
+	// Assert - tags are trimmed, lower-cased, de-duplicated and sorted
+	assert.Equal(t, common.Success, expense.GetExitCode(), expense.GetMessage())
+	assert.Equal(t, common.Success, transferred.GetExitCode(), transferred.GetMessage())
+	saved, _ := walletRepo.FindByExpenseRecordID(expense.GetID())
+	assert.Equal(t, []string{"food", "trip-tokyo"}, saved.GetExpenseRecords()[0].Tags)
+	assert.Equal(t, []string{"savings"}, test.FindTransfer(t, walletRepo, cash.ID, transferred.GetID()).Tags)
+	assert.Equal(t, []string{"savings"}, test.FindTransfer(t, walletRepo, bank.ID, transferred.GetID()).Tags)
+
+	// Invalid tags are rejected before anything is recorded
+	assert.Equal(t, common.InvalidInput, invalid.GetExitCode())
+	assert.Contains(t, invalid.GetMessage(), "Invalid tags")
+	savedBank, _ := walletRepo.FindByIDWithTransactions(bank.ID)
+	assert.Empty(t, savedBank.GetIncomeRecords())
+}
+
+func Test_EditTransactionTagsService_EditsRecordsAcrossWalletsAndBothSidesOfTransfer(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	uow := test.NewFakeUnitOfWork(walletRepo)
+	cash := test.CreateWallet(t, walletRepo, "user-123", "USD", 100000)
+	bank := test.CreateWallet(t, walletRepo, "user-123", "USD", 100000)
+	addExpense := command.NewAddExpenseService(walletRepo, nil, nil, nil)
+	expense := addExpense.Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: cash.ID, SubcategoryID: "subcategory-food",
+		Amount: 1200, Currency: "USD", Date: time.Now(), Tags: []string{"trip"},
+	})
+	otherExpense := addExpense.Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: cash.ID, SubcategoryID: "subcategory-food",
+		Amount: 800, Currency: "USD", Date: time.Now(), Tags: []string{"tokyo"},
+	})
+	income := command.NewAddIncomeService(walletRepo, nil).Execute(usecase.AddIncomeInput{
+		UserID: "user-123", WalletID: bank.ID, SubcategoryID: "subcategory-salary",
+		Amount: 3000, Currency: "USD", Date: time.Now(),
+	})
+	transferInput := createTransferInput(cash.ID, bank.ID, 5000, 0)
+	transferInput.UserID = "user-123"
+	transferInput.Tags = []string{"trip"}
+	transfer := command.NewProcessTransferService(uow, nil).Execute(transferInput)
+	service := command.NewEditTransactionTagsService(uow)
+
+	// Act
+	output := service.Execute(usecase.EditTransactionTagsInput{
+		UserID:      "user-123",
+		ExpenseIDs:  []string{expense.GetID(), otherExpense.GetID()},
+		IncomeIDs:   []string{income.GetID()},
+		TransferIDs: []string{transfer.GetID()},
+		Add:         []string{"Tokyo"},
+		Remove:      []string{"trip"},
+	})
+
+	// Assert - the second expense already had exactly these tags and is not counted
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	assert.Equal(t, 3, output.(usecase.EditTransactionTagsOutput).Updated)
+
+	savedCash, _ := walletRepo.FindByIDWithTransactions(cash.ID)
+	for _, record := range savedCash.GetExpenseRecords() {
+		assert.Equal(t, []string{"tokyo"}, record.Tags)
+	}
+	savedBank, _ := walletRepo.FindByIDWithTransactions(bank.ID)
+	assert.Equal(t, []string{"tokyo"}, savedBank.GetIncomeRecords()[0].Tags)
+	assert.Equal(t, []string{"tokyo"}, test.FindTransfer(t, walletRepo, cash.ID, transfer.GetID()).Tags)
+	assert.Equal(t, []string{"tokyo"}, test.FindTransfer(t, walletRepo, bank.ID, transfer.GetID()).Tags)
+}
+
+func Test_EditTransactionTagsService_UnknownRecordRollsBackWholeBatch(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	cash := test.CreateWallet(t, walletRepo, "user-123", "USD", 100000)
+	otherUsersWallet := test.CreateWallet(t, walletRepo, "user-456", "USD", 1000)
+	expense := command.NewAddExpenseService(walletRepo, nil, nil, nil).Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: cash.ID, SubcategoryID: "subcategory-food",
+		Amount: 1200, Currency: "USD", Date: time.Now(),
+	})
+	otherUsersIncome := command.NewAddIncomeService(walletRepo, nil).Execute(usecase.AddIncomeInput{
+		UserID: "user-456", WalletID: otherUsersWallet.ID, SubcategoryID: "subcategory-salary",
+		Amount: 100, Currency: "USD", Date: time.Now(),
+	})
+	service := command.NewEditTransactionTagsService(test.NewFakeUnitOfWork(walletRepo))
+
+	// Act - another user's income is reported as not found
+	output := service.Execute(usecase.EditTransactionTagsInput{
+		UserID:     "user-123",
+		ExpenseIDs: []string{expense.GetID()},
+		IncomeIDs:  []string{otherUsersIncome.GetID()},
+		Add:        []string{"trip"},
+	})
+
+	// Assert
+	assert.Equal(t, common.NotFound, output.GetExitCode())
+	assert.Contains(t, output.GetMessage(), "income record not found")
+	savedCash, _ := walletRepo.FindByIDWithTransactions(cash.ID)
+	assert.Empty(t, savedCash.GetExpenseRecords()[0].Tags)
+
+	// A request must select something and change something
+	output = service.Execute(usecase.EditTransactionTagsInput{UserID: "user-123", Add: []string{"trip"}})
+	assert.Contains(t, output.GetMessage(), "no transactions selected")
+	output = service.Execute(usecase.EditTransactionTagsInput{UserID: "user-123", ExpenseIDs: []string{expense.GetID()}})
+	assert.Contains(t, output.GetMessage(), "no tags to add or remove")
+}
+
+func Test_GetExpensesService_FiltersByTagsWithAnyOrAllMatch(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	cash := test.CreateWallet(t, walletRepo, "user-123", "USD", 100000)
+	addExpense := command.NewAddExpenseService(walletRepo, nil, nil, nil)
+	var ids []string
+	for _, expense := range []struct {
+		amount int64
+		tags   []string
+	}{
+		{100, []string{"trip", "food"}},
+		{200, []string{"trip"}},
+		{300, nil},
+	} {
+		output := addExpense.Execute(usecase.AddExpenseInput{
+			UserID: "user-123", WalletID: cash.ID, SubcategoryID: "subcategory-food",
+			Amount: expense.amount, Currency: "USD", Date: time.Now(), Tags: expense.tags,
+		})
+		assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+		ids = append(ids, output.GetID())
+	}
+	service := query.NewGetExpensesService(walletRepo)
+
+	// Act
+	anyMatch := service.Execute(usecase.GetExpensesInput{UserID: "user-123", Tags: []string{"TRIP", "food"}}).(usecase.GetExpensesOutput)
+	allMatch := service.Execute(usecase.GetExpensesInput{UserID: "user-123", Tags: []string{"trip", "food"}, TagMatch: "all"}).(usecase.GetExpensesOutput)
+	invalid := service.Execute(usecase.GetExpensesInput{UserID: "user-123", Tags: []string{"trip"}, TagMatch: "some"})
+
+	// Assert
+	assert.Equal(t, 2, anyMatch.Count)
+	assert.ElementsMatch(t, ids[:2], []string{anyMatch.Data[0].ID, anyMatch.Data[1].ID})
+	assert.Equal(t, 1, allMatch.Count)
+	assert.Equal(t, ids[0], allMatch.Data[0].ID)
+	assert.Equal(t, []string{"food", "trip"}, allMatch.Data[0].Tags)
+	assert.Equal(t, common.InvalidInput, invalid.GetExitCode())
+	assert.Contains(t, invalid.GetMessage(), "Invalid tag filter")
+}
+
+func Test_GetTagSummaryService_TotalsPerTagAndCurrency(t *testing.T) {
+	// Arrange
+	walletRepo, _ := test.NewFakeWalletRepo()
+	cash := test.CreateWallet(t, walletRepo, "user-123", "USD", 100000)
+	bank := test.CreateWallet(t, walletRepo, "user-123", "USD", 100000)
+	yen := test.CreateWallet(t, walletRepo, "user-123", "JPY", 500000)
+	addExpense := command.NewAddExpenseService(walletRepo, nil, nil, nil)
+	addExpense.Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: cash.ID, SubcategoryID: "subcategory-food",
+		Amount: 1200, Currency: "USD", Date: time.Now(), Tags: []string{"trip", "food"},
+	})
+	addExpense.Execute(usecase.AddExpenseInput{
+		UserID: "user-123", WalletID: yen.ID, SubcategoryID: "subcategory-food",
+		Amount: 8000, Currency: "JPY", Date: time.Now(), Tags: []string{"trip"},
+	})
+	command.NewAddIncomeService(walletRepo, nil).Execute(usecase.AddIncomeInput{
+		UserID: "user-123", WalletID: bank.ID, SubcategoryID: "subcategory-salary",
+		Amount: 3000, Currency: "USD", Date: time.Now(), Tags: []string{"trip"},
+	})
+	transfer := createTransferInput(cash.ID, bank.ID, 5000, 0)
+	transfer.UserID = "user-123"
+	transfer.Tags = []string{"trip"}
+	command.NewProcessTransferService(test.NewFakeUnitOfWork(walletRepo), nil).Execute(transfer)
+	service := query.NewGetTagSummaryService(walletRepo, nil)
+
+	// Act
+	output := service.Execute(usecase.GetTagSummaryInput{UserID: "user-123"})
+	tripOnly := service.Execute(usecase.GetTagSummaryInput{UserID: "user-123", Tags: []string{"Trip"}})
+
+	// Assert - amounts in different currencies are reported separately and the
+	// transfer is counted once even though both wallets hold it
+	assert.Equal(t, common.Success, output.GetExitCode(), output.GetMessage())
+	assert.Equal(t, []usecase.TagSummaryData{
+		{Tag: "food", Currency: "USD", Expenses: 1200, Count: 1},
+		{Tag: "trip", Currency: "JPY", Expenses: 8000, Count: 1},
+		{Tag: "trip", Currency: "USD", Expenses: 1200, Incomes: 3000, Transfers: 5000, Count: 3},
+	}, output.(usecase.GetTagSummaryOutput).Tags)
+	assert.Len(t, tripOnly.(usecase.GetTagSummaryOutput).Tags, 2)
+}
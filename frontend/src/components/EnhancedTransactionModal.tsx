@@ -1,5 +1,6 @@
 import React, { useState, useEffect } from 'react'
 import { cn } from '@/lib/utils'
+import { convertToBackendAmount } from '@/utils/format'
 import { Button } from '@/components/ui'
 import { Card, CardContent } from '@/components/ui'
 // Simple Label component since shadcn/ui not fully configured
@@ -192,7 +193,7 @@ export const EnhancedTransactionModal: React.FC<EnhancedTransactionModalProps> =
     if (isNaN(amount) || amount <= 0) return;
 
     // Convert to backend format based on currency
-    const backendAmount = formData.currency === 'TWD' ? amount : Math.round(amount * 100);
+    const backendAmount = convertToBackendAmount(amount, formData.currency);
 
     onSubmit({
       wallet_id: formData.walletID,
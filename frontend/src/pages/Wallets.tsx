@@ -11,7 +11,7 @@ import {
 } from 'lucide-react'
 import { Card, CardContent, Button, Modal, Input, Select } from '@/components/ui'
 import { walletService } from '@/services'
-import { formatMoney, getWalletTypeDisplayName } from '@/utils/format'
+import { formatMoney, getWalletTypeDisplayName, convertToBackendAmount, convertFromBackendAmount } from '@/utils/format'
 import type { CreateWalletRequest } from '@/services/walletService'
 import { WalletType } from '@/types'
 import WalletDebugPanel from '@/components/WalletDebugPanel'
@@ -197,7 +197,7 @@ const Wallets: React.FC = () => {
         type: formData.type,
         currency: formData.currency,
         user_id: DEMO_USER_ID,
-        initialBalance: formData.initialBalance
+        initialBalance: convertToBackendAmount(formData.initialBalance, formData.currency)
       })
       
       createWalletMutation.mutate({
@@ -205,7 +205,7 @@ const Wallets: React.FC = () => {
         type: formData.type,
         currency: formData.currency,
         user_id: DEMO_USER_ID,
-        initialBalance: formData.initialBalance
+        initialBalance: convertToBackendAmount(formData.initialBalance, formData.currency)
       })
     }
   }
@@ -352,7 +352,9 @@ const Wallets: React.FC = () => {
                           name: wallet.name, 
                           type: wallet.type, 
                           currency: wallet.currency || 'TWD',
-                          initialBalance: wallet.balance.amount || 0
+                          initialBalance: wallet.balance.amount
+                            ? convertFromBackendAmount(wallet.balance.amount, wallet.balance.currency)
+                            : 0
                         })
                         setShowCreateModal(true)
                       }}
@@ -21,7 +21,7 @@ import { useEnhancedQuery, useEnhancedMutation } from '@/hooks/useEnhancedQuery'
 
 // Enhanced service import (would replace existing walletService)
 import { enhancedApiRequest } from '@/services/enhancedApi'
-import { formatMoney, getWalletTypeDisplayName } from '@/utils/format'
+import { formatMoney, getWalletTypeDisplayName, convertToBackendAmount } from '@/utils/format'
 import type { CreateWalletRequest } from '@/services/walletService'
 import { WalletType, type Wallet } from '@/types'
 
@@ -159,7 +159,7 @@ const WalletsEnhanced: React.FC = () => {
       type: formData.type,
       currency: formData.currency,
       user_id: DEMO_USER_ID,
-      initialBalance: formData.initialBalance
+      initialBalance: convertToBackendAmount(formData.initialBalance, formData.currency)
     })
   }
 
@@ -2,24 +2,17 @@ import type { Money } from '@/types'
 
 /**
  * Format money amount with currency
+ * Backend amounts are in minor units and are converted to major units here
  */
 export const formatMoney = (money: Money): string => {
   if (!money) return 'NT$ 0'
   
   const { amount, currency } = money
+  const decimals = getCurrencyMinorUnits(currency)
   const formattedAmount = new Intl.NumberFormat('zh-TW', {
-    minimumFractionDigits: 0,
-    maximumFractionDigits: 2,
-  }).format(Math.abs(amount))
-
-  // Handle different currencies
-  const currencySymbols: Record<string, string> = {
-    TWD: 'NT$',
-    USD: '$',
-    JPY: '¥',
-    EUR: '€',
-    CNY: '¥',
-  }
+    minimumFractionDigits: decimals,
+    maximumFractionDigits: decimals,
+  }).format(Math.abs(convertFromBackendAmount(amount, currency)))
 
   const symbol = currencySymbols[currency] || currency
   const sign = amount < 0 ? '-' : ''
@@ -112,25 +105,61 @@ export const formatRelativeTime = (date: string | Date): string => {
   return formatDate(d)
 }
 
+// Display symbols, mirroring the symbol column of the backend iso4217.csv
+const currencySymbols: Record<string, string> = {
+  AUD: 'A$',
+  BRL: 'R$',
+  CAD: 'CA$',
+  CNY: 'CN¥',
+  EUR: '€',
+  GBP: '£',
+  HKD: 'HK$',
+  ILS: '₪',
+  INR: '₹',
+  JPY: '¥',
+  KRW: '₩',
+  MXN: 'MX$',
+  NZD: 'NZ$',
+  PHP: '₱',
+  TWD: 'NT$',
+  USD: '$',
+  VND: '₫',
+  XAF: 'FCFA',
+  XCD: 'EC$',
+  XOF: 'F CFA',
+  XPF: 'CFPF',
+}
+
+// ISO 4217 minor units that differ from the usual 2 decimals.
+// Must stay in sync with the backend registry (domain/model/iso4217.csv),
+// including the TWD override: the backend stores TWD as whole dollars.
+const currencyMinorUnitExceptions: Record<string, number> = {
+  // No minor unit
+  BIF: 0, CLP: 0, DJF: 0, GNF: 0, ISK: 0, JPY: 0, KMF: 0, KRW: 0, PYG: 0,
+  RWF: 0, TWD: 0, UGX: 0, UYI: 0, VND: 0, VUV: 0, XAF: 0, XOF: 0, XPF: 0,
+
+  // 1 unit = 1000 smaller units
+  BHD: 3, IQD: 3, JOD: 3, KWD: 3, LYD: 3, OMR: 3, TND: 3,
+
+  // 1 unit = 10000 smaller units
+  CLF: 4, UYW: 4,
+}
+
+/**
+ * Get the number of decimals the backend stores for a currency
+ */
+export const getCurrencyMinorUnits = (currency: string): number => {
+  if (!/^[A-Z]{3}$/.test(currency)) {
+    throw new Error(`Invalid currency code: ${currency}`)
+  }
+  return currencyMinorUnitExceptions[currency] ?? 2
+}
+
 /**
  * Get currency subdivision (how many smaller units make up 1 major unit)
  */
 export const getCurrencySubdivision = (currency: string): number => {
-  const currencySubdivisions: Record<string, number> = {
-    // Whole number currencies (no subdivision) - TWD as default
-    TWD: 1,     // 1 台幣 = 1 台幣 (no cents, base unit)
-    JPY: 1,     // 1 yen = 1 yen (no sen in practice)
-    KRW: 1,     // 1 won = 1 won (no subdivision)
-    VND: 1,     // 1 dong = 1 dong (no subdivision)
-    
-    // Decimal currencies (1 unit = 100 smaller units)
-    USD: 100,   // 1 dollar = 100 cents
-    EUR: 100,   // 1 euro = 100 cents
-    GBP: 100,   // 1 pound = 100 pence
-    CNY: 100,   // 1 yuan = 100 fen
-  }
-  
-  return currencySubdivisions[currency] || 1 // Default to 1 for unknown currencies (like TWD)
+  return 10 ** getCurrencyMinorUnits(currency)
 }
 
 /**
@@ -160,7 +189,7 @@ export const parseMoney = (input: string, currency: string = 'TWD'): Money | nul
   
   if (isNaN(amount)) return null
   
-  // Convert display amount to storage format using TWD as base unit
+  // Convert display amount to the currency's minor units
   const storageAmount = convertToBackendAmount(amount, currency)
   
   return { amount: storageAmount, currency }